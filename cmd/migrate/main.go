@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"event-horizon/config"
+	"event-horizon/db"
+	"event-horizon/migrations"
+	"flag"
+	"log"
+)
+
+/** *********************  SCHEMA MIGRATIONS   ********************
+
+Applies the pending migrations of the migrations package, the same ones the
+server runs on startup (unless RUN_MIGRATIONS=false).
+
+	MONGO_URI=... DATABASE_NAME=... go run ./cmd/migrate
+	MONGO_URI=... DATABASE_NAME=... go run ./cmd/migrate -status
+
+Applied migrations are recorded, so running it again only applies new ones.
+
+ **************************************/
+
+func main() {
+	status := flag.Bool("status", false, "list applied and pending migrations without running them")
+	flag.Parse()
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal("Invalid configuration: ", err)
+	}
+
+	ctx := context.Background()
+	database := db.ConnectDB(cfg.MongoURI, cfg.DatabaseName, cfg.Mongo.DBOptions())
+
+	if *status {
+		states, err := migrations.Status(ctx, database)
+		if err != nil {
+			log.Fatal("Error reading migration status:", err)
+		}
+		for _, state := range states {
+			if state.AppliedAt == nil {
+				log.Printf("%4d  %-32s  pending", state.Version, state.Name)
+				continue
+			}
+			log.Printf("%4d  %-32s  applied %s", state.Version, state.Name, state.AppliedAt.Format("2006-01-02 15:04:05"))
+		}
+		return
+	}
+
+	applied, err := migrations.Run(ctx, database)
+	if err != nil {
+		log.Fatal("Error running migrations:", err)
+	}
+
+	log.Printf("Applied %d migration(s)", applied)
+}
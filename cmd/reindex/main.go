@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"event-horizon/config"
+	"event-horizon/db"
+	"event-horizon/models"
+	"event-horizon/search"
+	"event-horizon/store"
+	"log"
+)
+
+/** *********************  SEARCH REINDEX   ********************
+
+Fills the Meilisearch index with every public event from the database, for
+a new index or one that missed changes (e.g. while Meilisearch was down).
+Documents are replaced, so running it again is safe.
+
+	MONGO_URI=... DATABASE_NAME=... MEILISEARCH_URL=... go run ./cmd/reindex
+
+Events that are no longer public are removed by the API as they change,
+hits are checked against the database anyway.
+
+ **************************************/
+
+// reindexBatch is how many events are sent to Meilisearch per request
+const reindexBatch = 500
+
+func main() {
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal("Invalid configuration: ", err)
+	}
+	if cfg.Meilisearch.URL == "" {
+		log.Fatal("MEILISEARCH_URL is not set, search runs on MongoDB and has no index to fill")
+	}
+
+	ctx := context.Background()
+	database := db.ConnectDB(cfg.MongoURI, cfg.DatabaseName, cfg.Mongo.DBOptions())
+
+	bookingStore := store.NewBookingStore(database)
+	categoryStore := store.NewCategoryStore(database, bookingStore)
+	eventStore := store.NewEventStore(database, categoryStore, bookingStore)
+
+	engine := search.NewMeilisearchEngine(cfg.Meilisearch.URL, cfg.Meilisearch.APIKey, cfg.Meilisearch.Index)
+	if err := engine.EnsureIndex(ctx); err != nil {
+		log.Fatal("Error configuring the index:", err)
+	}
+
+	events, err := eventStore.GetAllEvents(ctx, nil)
+	if err != nil {
+		log.Fatal("Error reading events:", err)
+	}
+
+	batch := make([]models.Event, 0, reindexBatch)
+	for i, event := range events {
+		batch = append(batch, *event)
+		if len(batch) == reindexBatch || i == len(events)-1 {
+			if err := engine.Index(ctx, batch...); err != nil {
+				log.Fatal("Error indexing events:", err)
+			}
+			batch = batch[:0]
+		}
+	}
+
+	log.Printf("Sent %d event(s) to the %q index", len(events), cfg.Meilisearch.Index)
+}
@@ -0,0 +1,252 @@
+package main
+
+import (
+	"context"
+	"event-horizon/config"
+	"event-horizon/db"
+	"event-horizon/dto"
+	"event-horizon/eventbus"
+	"event-horizon/models"
+	"event-horizon/services"
+	"event-horizon/store"
+	"log"
+	"time"
+)
+
+/** *********************  DEVELOPMENT SEED DATA   ********************
+
+Fills an empty database with a working dataset for local development and
+the frontend: hosts, attendees, categories, published events with tickets
+(one of them online, one with sessions) and a few bookings.
+
+	MONGO_URI=... DATABASE_NAME=... go run ./cmd/seed
+
+Every seeded account uses the password "password123". Admins can't be
+created through the API, flip is_admin on one of the users by hand if you
+need one.
+
+Bookings run in Mongo transactions, so the database must be a replica set
+(see "Testing against a throwaway MongoDB" in the README).
+
+If the first seed host already exists the database counts as seeded and
+nothing is written, so running it twice is harmless.
+
+ **************************************/
+
+// seedPassword is the password of every seeded account
+const seedPassword = "password123"
+
+type seedUser struct {
+	name   string
+	email  string
+	isHost bool
+}
+
+var seedUsers = []seedUser{
+	{name: "Maya Host", email: "maya.host@example.com", isHost: true},
+	{name: "Leo Host", email: "leo.host@example.com", isHost: true},
+	{name: "Ada Attendee", email: "ada@example.com"},
+	{name: "Tom Attendee", email: "tom@example.com"},
+	{name: "Sara Attendee", email: "sara@example.com"},
+	{name: "Ken Attendee", email: "ken@example.com"},
+}
+
+var seedCategories = []models.Category{
+	{Name: "Music", Description: "Concerts, festivals and live sets"},
+	{Name: "Technology", Description: "Meetups, conferences and workshops"},
+	{Name: "Sports", Description: "Matches, runs and tournaments"},
+	{Name: "Food & Drink", Description: "Tastings, markets and dinners"},
+	{Name: "Arts", Description: "Exhibitions, theatre and film"},
+}
+
+// tickets builds the usual three ticket types with all tickets available
+func tickets(regularPrice float64, total int) []models.TicketInfo {
+	return []models.TicketInfo{
+		{Type: "Regular", Price: regularPrice, TotalQuantity: total, AvailableQuantity: total},
+		{Type: "VIP", Price: regularPrice * 3, TotalQuantity: total / 10, AvailableQuantity: total / 10},
+		{Type: "Student", Price: regularPrice / 2, TotalQuantity: total / 4, AvailableQuantity: total / 4},
+	}
+}
+
+// point builds a GeoJSON point from latitude and longitude
+func point(lat, lng float64) *models.GeoPoint {
+	return &models.GeoPoint{Type: "Point", Coordinates: []float64{lng, lat}}
+}
+
+// seedEvents returns the events to create, days are counted from today so they are always upcoming
+func seedEvents(today time.Time) []models.Event {
+	at := func(days, hour int) time.Time {
+		return today.AddDate(0, 0, days).Add(time.Duration(hour) * time.Hour)
+	}
+
+	return []models.Event{
+		{
+			CategoryName: "Music", Name: "Summer Rooftop Jazz", Tags: []string{"jazz", "live-music", "outdoor"},
+			Description: "An evening of live jazz above the city.",
+			Date:        at(7, 0), StartTime: at(7, 19), EndTime: at(7, 23),
+			Location: "Skyline Rooftop, Berlin", GeoLocation: point(52.5200, 13.4050),
+			Tickets: tickets(25, 200),
+		},
+		{
+			CategoryName: "Music", Name: "Indie Night Vol. 3", Tags: []string{"indie", "live-music"},
+			Description: "Three local indie bands, one stage.",
+			Date:        at(14, 0), StartTime: at(14, 20), EndTime: at(14, 23),
+			Location: "Lido, Berlin", GeoLocation: point(52.4990, 13.4450),
+			Tickets: tickets(18, 120),
+		},
+		{
+			CategoryName: "Technology", Name: "Go Meetup: Concurrency in Practice", Tags: []string{"go", "backend", "meetup"},
+			Description: "Talks and pizza for Go developers of every level.",
+			Date:        at(10, 0), StartTime: at(10, 18), EndTime: at(10, 21),
+			Location: "Tech Hub, Hamburg", GeoLocation: point(53.5511, 9.9937),
+			Tickets: tickets(10, 80),
+		},
+		{
+			CategoryName: "Technology", Name: "Cloud Native Summit", Tags: []string{"cloud", "kubernetes", "conference"},
+			Description: "Two days of talks on running software in the cloud.",
+			Date:        at(30, 0),
+			Location:    "Messe, Munich", GeoLocation: point(48.1351, 11.5820),
+			Tickets: tickets(150, 500),
+			Sessions: []models.Session{
+				{Title: "Day 1: Platforms", StartTime: at(30, 9), EndTime: at(30, 18), Capacity: 500},
+				{Title: "Day 2: Operations", StartTime: at(31, 9), EndTime: at(31, 17), Capacity: 500},
+			},
+		},
+		{
+			CategoryName: "Technology", Name: "Intro to Databases (Online)", Tags: []string{"databases", "online", "workshop"},
+			Description: "A hands-on online workshop on MongoDB basics.",
+			Date:        at(5, 0), StartTime: at(5, 17), EndTime: at(5, 19),
+			Location: "Online", EventType: models.EventTypeOnline, StreamURL: "https://meet.example.com/intro-to-databases",
+			Tickets: tickets(5, 300),
+		},
+		{
+			CategoryName: "Sports", Name: "City Half Marathon", Tags: []string{"running", "outdoor"},
+			Description: "21 km through the old town.",
+			Date:        at(21, 0), StartTime: at(21, 8), EndTime: at(21, 13),
+			Location: "Town Hall Square, Cologne", GeoLocation: point(50.9375, 6.9603),
+			Tickets: tickets(40, 1000),
+		},
+		{
+			CategoryName: "Food & Drink", Name: "Street Food Market", Tags: []string{"food", "market", "outdoor"},
+			Description: "Twenty stalls, one long afternoon.",
+			Date:        at(3, 0), StartTime: at(3, 12), EndTime: at(3, 20),
+			Location: "Harbour, Hamburg", GeoLocation: point(53.5430, 9.9660),
+			Tickets: tickets(8, 400),
+		},
+		{
+			CategoryName: "Arts", Name: "Modern Photography Exhibition", Tags: []string{"photography", "exhibition"},
+			Description: "Opening night with the artists.",
+			Date:        at(12, 0), StartTime: at(12, 18), EndTime: at(12, 22),
+			Location: "Gallery East, Berlin", GeoLocation: point(52.5050, 13.4390),
+			Tickets: tickets(12, 150),
+		},
+	}
+}
+
+func main() {
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal("Invalid configuration: ", err)
+	}
+
+	ctx := context.Background()
+	database := db.ConnectDB(cfg.MongoURI, cfg.DatabaseName, cfg.Mongo.DBOptions())
+
+	userStore := store.NewUserStore(database)
+	bookingStore := store.NewBookingStore(database)
+	categoryStore := store.NewCategoryStore(database, bookingStore)
+	eventStore := store.NewEventStore(database, categoryStore, bookingStore)
+	bookingService := services.NewBookingService(bookingStore, eventStore, eventbus.NopPublisher{}, nil, nil, nil, services.BookingLimits{}) //? Seed bookings are not real sales, nobody is notified
+
+	if _, err := userStore.FindUserByEmail(ctx, seedUsers[0].email); err == nil {
+		log.Println("Database is already seeded, nothing to do")
+		return
+	}
+
+	//? Indexes the API relies on (nearby search, category slugs)
+	if err := eventStore.EnsureGeoIndex(ctx); err != nil {
+		log.Fatal("Error creating geo index:", err)
+	}
+	if err := categoryStore.EnsureSlugIndex(ctx); err != nil {
+		log.Fatal("Error creating category slug index:", err)
+	}
+
+	//? 1. Users, hosts first
+	var hosts, attendees []*models.User
+	for _, seed := range seedUsers {
+		user := &models.User{Name: seed.name, Email: seed.email, Password: seedPassword}
+		if err := userStore.CreateUser(ctx, user); err != nil {
+			log.Fatal("Error creating user ", seed.email, ": ", err)
+		}
+
+		if seed.isHost {
+			if err := userStore.SetHostStatus(ctx, user.ID, true); err != nil {
+				log.Fatal("Error making ", seed.email, " a host: ", err)
+			}
+			//? Verified, so their paid events are live like a real host's
+			if err := userStore.SetVerified(ctx, user.ID, true); err != nil {
+				log.Fatal("Error verifying ", seed.email, ": ", err)
+			}
+			user.IsHost = true
+			hosts = append(hosts, user)
+			continue
+		}
+		attendees = append(attendees, user)
+	}
+
+	//? 2. Categories
+	for i := range seedCategories {
+		if err := categoryStore.CreateCategory(ctx, &seedCategories[i]); err != nil {
+			log.Fatal("Error creating category ", seedCategories[i].Name, ": ", err)
+		}
+	}
+
+	//? 3. Events, published right away so they show up even with moderation on
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	events := seedEvents(today)
+	for i := range events {
+		event := &events[i]
+		event.HostID = hosts[i%len(hosts)].ID
+		event.HostVerified = true
+		event.Timezone = "UTC"
+		event.Status = models.EventStatusPublished
+		if event.EventType == "" {
+			event.EventType = models.EventTypeInPerson
+		}
+		if len(event.Sessions) > 0 {
+			event.StartTime = event.Sessions[0].StartTime
+			event.EndTime = event.Sessions[len(event.Sessions)-1].EndTime
+		}
+		event.ApplyCurrency()
+
+		if err := eventStore.CreateEvent(ctx, event); err != nil {
+			log.Fatal("Error creating event ", event.Name, ": ", err)
+		}
+	}
+
+	//? 4. Bookings, through the booking service so tickets and prices are handled like in the API
+	ticketTypes := []string{"Regular", "Student", "VIP"}
+	bookings := 0
+	for i, attendee := range attendees {
+		for j := 0; j < 3; j++ {
+			event := events[(i+j*2)%len(events)]
+			if len(event.Sessions) > 0 {
+				continue //? multi-session events need a session, keep the seed simple
+			}
+
+			req := &dto.CreateBookingRequest{
+				EventID:    event.ID.Hex(),
+				TicketType: ticketTypes[(i+j)%len(ticketTypes)],
+				Quantity:   1 + j,
+			}
+			if _, _, err := bookingService.CreateBooking(ctx, attendee.ID, req); err != nil {
+				log.Fatal("Error booking ", event.Name, " for ", attendee.Email, ": ", err)
+			}
+			bookings++
+		}
+	}
+
+	log.Printf("Seeded %d users (%d hosts), %d categories, %d events and %d bookings",
+		len(seedUsers), len(hosts), len(seedCategories), len(events), bookings)
+	log.Printf("Sign in with any seeded email and the password %q, e.g. %s", seedPassword, seedUsers[0].email)
+}
@@ -0,0 +1,705 @@
+package config
+
+import (
+	"errors"
+	"event-horizon/db"
+	"log"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/labstack/gommon/bytes"
+	"go.mongodb.org/mongo-driver/v2/mongo/readpref"
+)
+
+/** *********************  APP CONFIGURATION   ********************
+
+All server settings are loaded ONCE at startup from the environment (and a
+.env file if there is one) and validated, so a misconfigured deploy fails
+immediately instead of at the first request.
+
+PORT                      - HTTP port (default 3000)
+MONGO_URI                 - MongoDB connection string (required)
+DATABASE_NAME             - MongoDB database name (required)
+JWT_SECRET                - Secret used to sign JWT tokens (required, at least 32 characters)
+CORS_ORIGINS              - Comma separated list of allowed frontend origins
+TRUSTED_PROXIES           - Comma separated CIDR ranges of the proxies in front of the API (Heroku: 10.0.0.0/8), the client IP is read
+                            from X-Forwarded-For only behind them, without it it is the address of the connection
+TOKEN_TTL                 - How long sessions signed in with remember_me (and their tokens) stay valid (default 720h)
+SESSION_TTL               - How long every other session (and its tokens) stays valid (default 12h)
+AUTH_COOKIE_SECURE        - Send the token cookie (use_cookie sign ins) over HTTPS only, turn off for local HTTP (default true)
+CLEANUP_INTERVAL          - How often expired events are cleaned up (default 1h)
+EVENT_RETENTION_DAYS      - How many days events are kept after they end before the cleanup deletes them, 0 = right away (default 30)
+CLEANUP_BATCH_SIZE        - How many expired events the cleanup reads per batch (default 100)
+PUBLISH_INTERVAL          - How often drafts scheduled with publish_at are checked and published (default 1m)
+RECONFIRM_INTERVAL        - How often bookings of rescheduled events that weren't reconfirmed in time are refunded (default 15m)
+OUTBOX_INTERVAL           - How often the outbox of booking transactions is delivered to the booking emails and hooks (default 1s)
+JOB_WORKERS               - How many workers run background jobs (booking emails, commissions ...) (default 4)
+JOB_POLL_INTERVAL         - How often an idle worker looks for due jobs (default 2s)
+JOB_MAX_ATTEMPTS          - How often a failing job is tried before it is dead-lettered (default 5)
+LEGACY_API_SUNSET         - HTTP date after which unversioned /api/* paths go away
+MODERATION_ENABLED        - When true, new events wait for admin approval before they are listed (default false)
+HOST_VERIFICATION_REQUIRED - When true, hosts must be verified by an admin before their paid events go live (default true)
+REPORT_HIDE_THRESHOLD     - Number of user reports after which an event or comment is hidden pending review (default 5)
+EVENT_CACHE_TTL           - How long event reads are cached in memory, 0 disables the cache (default 30s)
+REDIS_URL                 - Optional Redis URL, enables distributed booking locks for hot events
+EVENT_BUS                 - Where domain events (booking.created, event.cancelled ...) go: none, log or redis (default none)
+EVENT_BUS_STREAM          - Redis Stream the redis event bus appends to (default event-horizon:events)
+EVENT_BUS_MAX_LEN         - The stream is trimmed to about this many messages, 0 keeps all (default 100000)
+MEILISEARCH_URL           - Optional Meilisearch server for event search, without it search runs on MongoDB
+MEILISEARCH_API_KEY       - Meilisearch API key
+MEILISEARCH_INDEX         - Meilisearch index of the events (default events)
+BOOKING_LOCK_TTL          - How long a booking lock lives if it is never released (default 5s)
+BOOKING_LOCK_WAIT         - How long a booking waits for the lock before giving up (default 3s)
+BOOKING_MAX_QUANTITY      - Most tickets in one booking, 0 = no cap (default 10)
+BOOKING_MAX_PER_EVENT     - Most tickets one buyer can hold for an event, 0 = no cap (default 20)
+BOOKING_VELOCITY_LIMIT    - Bookings per minute allowed per buyer and per IP, 0 = no limit (default 5), counted in Redis when REDIS_URL is set
+GZIP_LEVEL                - Gzip compression level 1-9, 0 turns compression off (default 5)
+GZIP_MIN_LENGTH           - Responses smaller than this many bytes are not compressed (default 1024)
+BODY_LIMIT                - Largest accepted request body, e.g. "512K", "2M" (default 2M)
+REQUEST_TIMEOUT           - Deadline for a request and every store call it makes, answered with 504 (default 15s)
+RUN_MIGRATIONS            - Apply pending schema migrations on startup (default true)
+APP_BASE_URL              - Frontend URL used in emailed links (default https://www.event-horizons.app)
+SHARE_BASE_URL            - Public URL of this API, short share links are SHARE_BASE_URL/s/<code> (default http://localhost:PORT)
+SELLING_FAST_PERCENT      - Events and ticket types with less than this share of tickets left are flagged selling_fast, 0 = off (default 25)
+ALMOST_SOLD_OUT_PERCENT   - Same for the almost_sold_out flag, at most SELLING_FAST_PERCENT (default 10)
+TERMS_VERSION             - Current version of the terms of service users accept at sign up and booking, without it the terms aren't asked for
+PRIVACY_VERSION           - Current version of the privacy policy, same as TERMS_VERSION
+
+SMTP_HOST                 - SMTP server for emails, without it emails are only logged
+SMTP_PORT                 - SMTP port (default 587)
+SMTP_USERNAME             - SMTP user, leave empty for servers without auth
+SMTP_PASSWORD             - SMTP password
+MAIL_FROM                 - From header of emails (default Event Horizon <no-reply@event-horizons.app>)
+
+TWILIO_ACCOUNT_SID        - Twilio account for SMS (phone verification, booking confirmations), without it SMS are only logged
+TWILIO_AUTH_TOKEN         - Twilio auth token
+TWILIO_FROM               - Twilio number (E.164) or messaging service SID (MG...) SMS are sent from
+
+CAPTCHA_SECRET            - Secret key of the site at the CAPTCHA provider, without it no CAPTCHA is ever asked for
+CAPTCHA_PROVIDER          - hcaptcha or recaptcha (default hcaptcha)
+CAPTCHA_LOGIN_FAILURES    - Failed sign ins of an email or IP (15 minutes) after which login asks for a CAPTCHA, 0 = never (default 3)
+
+OTEL_EXPORTER_OTLP_ENDPOINT - OTLP/HTTP endpoint traces are exported to (Jaeger: http://localhost:4318), without it tracing is off
+OTEL_EXPORTER_OTLP_HEADERS  - Headers sent with every export, "key=value,key2=value2" (API keys of hosted backends)
+OTEL_SERVICE_NAME           - Service name of the traces (default event-horizon)
+TRACE_SAMPLE_RATIO          - Share of new traces that are recorded, 0 to 1 (default 1), a caller's traceparent decides for its traces
+
+SENTRY_DSN                - Sentry project panics and 500s are reported to, without it they are only logged
+SENTRY_ENVIRONMENT        - Environment of the reports (default production)
+SENTRY_RELEASE            - Release of the reports (git SHA, version ...)
+
+MONGO_MAX_POOL_SIZE              - Most open connections per server (default 100)
+MONGO_MIN_POOL_SIZE              - Connections kept open when idle (default 0)
+MONGO_CONNECT_TIMEOUT            - Timeout for opening a connection (default 10s)
+MONGO_SERVER_SELECTION_TIMEOUT   - How long an operation waits for a usable server (default 10s)
+MONGO_OPERATION_TIMEOUT          - Upper bound for a single operation, 0 = none (default 10s)
+MONGO_RETRY_WRITES               - Retry writes once on network errors / failovers (default true)
+MONGO_RETRY_READS                - Retry reads once on network errors / failovers (default true)
+MONGO_READ_PREFERENCE            - primary, primaryPreferred, secondary, secondaryPreferred or nearest (default primary)
+MONGO_CONNECT_RETRIES            - Extra connection attempts at startup before giving up (default 5)
+MONGO_CONNECT_RETRY_BACKOFF      - Wait before the first startup retry, doubled every attempt (default 1s)
+
+ **************************************/
+
+// defaultCORSOrigins are used when CORS_ORIGINS is not set
+var defaultCORSOrigins = []string{
+	"http://localhost:3000",
+	"http://localhost:5173",
+	"https://event-horizon-wine.vercel.app",
+	"https://www.event-horizons.app",
+	"https://go-lang-project-9f592fc57357.herokuapp.com",
+}
+
+// minJWTSecretLength is the shortest JWT secret accepted at startup
+const minJWTSecretLength = 32
+
+// Config holds every setting the server needs
+type Config struct {
+	Port                string
+	MongoURI            string
+	DatabaseName        string
+	JWTSecret           string
+	CORSOrigins         []string
+	TrustedProxies      []*net.IPNet
+	TokenTTL            time.Duration
+	SessionTTL          time.Duration
+	AuthCookieSecure    bool
+	CleanupInterval     time.Duration
+	EventRetentionDays  int
+	CleanupBatchSize    int
+	PublishInterval     time.Duration
+	ReconfirmInterval   time.Duration
+	OutboxInterval      time.Duration
+	JobWorkers          int
+	JobPollInterval     time.Duration
+	JobMaxAttempts      int
+	LegacyAPISunset     string
+	ModerationEnabled   bool
+	RequireVerification bool
+	ReportHideThreshold int
+	EventCacheTTL       time.Duration
+	RedisURL            string
+	EventBus            string
+	EventBusStream      string
+	EventBusMaxLen      int
+	Meilisearch         MeilisearchConfig
+	BookingLockTTL      time.Duration
+	BookingLockWait     time.Duration
+	BookingMaxQuantity  int
+	BookingMaxPerEvent  int
+	BookingVelocity     int
+	GzipLevel           int
+	GzipMinLength       int
+	BodyLimit           string
+	RequestTimeout      time.Duration
+	RunMigrations       bool
+	AppBaseURL          string
+	ShareBaseURL        string
+	SMTP                SMTPConfig
+	Twilio              TwilioConfig
+	Captcha             CaptchaConfig
+	Tracing             TracingConfig
+	Sentry              SentryConfig
+	LowStock            LowStockConfig
+	Policies            PolicyConfig
+	Mongo               MongoConfig
+}
+
+// MeilisearchConfig is the optional search engine for events
+type MeilisearchConfig struct {
+	URL    string
+	APIKey string
+	Index  string
+}
+
+// LowStockConfig sets when events are flagged as selling fast / almost sold out, in percent of tickets left
+type LowStockConfig struct {
+	SellingFastPercent   int
+	AlmostSoldOutPercent int
+}
+
+// PolicyConfig holds the current versions of the legal documents users accept, an empty version isn't asked for
+type PolicyConfig struct {
+	TermsVersion   string
+	PrivacyVersion string
+}
+
+// SMTPConfig is the mail server emails are sent through
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+}
+
+// TwilioConfig is the optional Twilio account SMS are sent through
+type TwilioConfig struct {
+	AccountSID string
+	AuthToken  string
+	From       string
+}
+
+// CaptchaConfig is the optional CAPTCHA provider sign ups, sign ins and fast bookings are checked with
+type CaptchaConfig struct {
+	Provider      string
+	Secret        string
+	LoginFailures int
+}
+
+// TracingConfig is the optional OTLP collector request traces are exported to
+type TracingConfig struct {
+	Endpoint    string
+	Headers     map[string]string
+	ServiceName string
+	SampleRatio float64
+}
+
+// SentryConfig is the optional Sentry project errors are reported to
+type SentryConfig struct {
+	DSN         string
+	Environment string
+	Release     string
+}
+
+// MongoConfig tunes the MongoDB client
+type MongoConfig struct {
+	MaxPoolSize            int
+	MinPoolSize            int
+	ConnectTimeout         time.Duration
+	ServerSelectionTimeout time.Duration
+	OperationTimeout       time.Duration
+	RetryWrites            bool
+	RetryReads             bool
+	ReadPreference         string
+	ConnectRetries         int
+	ConnectRetryBackoff    time.Duration
+}
+
+// Load reads the configuration from the environment and validates it
+func Load() (*Config, error) {
+	//? .env is optional (Heroku sets real env vars)
+	if err := godotenv.Load(); err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Println("Could not read .env file:", err)
+	}
+
+	cfg := &Config{
+		Port:         getEnv("PORT", "3000"),
+		MongoURI:     os.Getenv("MONGO_URI"),
+		DatabaseName: os.Getenv("DATABASE_NAME"),
+		JWTSecret:    os.Getenv("JWT_SECRET"),
+		RedisURL:     os.Getenv("REDIS_URL"),
+		BodyLimit:    getEnv("BODY_LIMIT", "2M"),
+		CORSOrigins:  getEnvList("CORS_ORIGINS", defaultCORSOrigins),
+		//? Sunset header value (RFC 7231 HTTP date) sent on legacy /api/* routes
+		LegacyAPISunset: getEnv("LEGACY_API_SUNSET", "Thu, 31 Dec 2026 23:59:59 GMT"),
+		AppBaseURL:      getEnv("APP_BASE_URL", "https://www.event-horizons.app"),
+		EventBus:        getEnv("EVENT_BUS", "none"),
+		EventBusStream:  getEnv("EVENT_BUS_STREAM", "event-horizon:events"),
+		Meilisearch: MeilisearchConfig{
+			URL:    os.Getenv("MEILISEARCH_URL"),
+			APIKey: os.Getenv("MEILISEARCH_API_KEY"),
+			Index:  getEnv("MEILISEARCH_INDEX", "events"),
+		},
+		SMTP: SMTPConfig{
+			Host:     os.Getenv("SMTP_HOST"),
+			Username: os.Getenv("SMTP_USERNAME"),
+			Password: os.Getenv("SMTP_PASSWORD"),
+			From:     getEnv("MAIL_FROM", "Event Horizon <no-reply@event-horizons.app>"),
+		},
+		Twilio: TwilioConfig{
+			AccountSID: os.Getenv("TWILIO_ACCOUNT_SID"),
+			AuthToken:  os.Getenv("TWILIO_AUTH_TOKEN"),
+			From:       os.Getenv("TWILIO_FROM"),
+		},
+		Captcha: CaptchaConfig{
+			Provider: getEnv("CAPTCHA_PROVIDER", "hcaptcha"),
+			Secret:   os.Getenv("CAPTCHA_SECRET"),
+		},
+		Tracing: TracingConfig{
+			Endpoint:    os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT"),
+			Headers:     getEnvMap("OTEL_EXPORTER_OTLP_HEADERS"),
+			ServiceName: getEnv("OTEL_SERVICE_NAME", "event-horizon"),
+		},
+		Sentry: SentryConfig{
+			DSN:         os.Getenv("SENTRY_DSN"),
+			Environment: getEnv("SENTRY_ENVIRONMENT", "production"),
+			Release:     os.Getenv("SENTRY_RELEASE"),
+		},
+		Policies: PolicyConfig{
+			TermsVersion:   strings.TrimSpace(os.Getenv("TERMS_VERSION")),
+			PrivacyVersion: strings.TrimSpace(os.Getenv("PRIVACY_VERSION")),
+		},
+	}
+	cfg.ShareBaseURL = getEnv("SHARE_BASE_URL", "http://localhost:"+cfg.Port)
+
+	var err error
+	if cfg.TrustedProxies, err = getEnvCIDRs("TRUSTED_PROXIES"); err != nil {
+		return nil, err
+	}
+	if cfg.TokenTTL, err = getEnvDuration("TOKEN_TTL", 30*24*time.Hour); err != nil {
+		return nil, err
+	}
+	if cfg.SessionTTL, err = getEnvDuration("SESSION_TTL", 12*time.Hour); err != nil {
+		return nil, err
+	}
+	if cfg.AuthCookieSecure, err = getEnvBool("AUTH_COOKIE_SECURE", true); err != nil {
+		return nil, err
+	}
+	if cfg.CleanupInterval, err = getEnvDuration("CLEANUP_INTERVAL", time.Hour); err != nil {
+		return nil, err
+	}
+	if cfg.EventRetentionDays, err = getEnvInt("EVENT_RETENTION_DAYS", 30); err != nil {
+		return nil, err
+	}
+	if cfg.CleanupBatchSize, err = getEnvInt("CLEANUP_BATCH_SIZE", 100); err != nil {
+		return nil, err
+	}
+	if cfg.PublishInterval, err = getEnvDuration("PUBLISH_INTERVAL", time.Minute); err != nil {
+		return nil, err
+	}
+	if cfg.ReconfirmInterval, err = getEnvDuration("RECONFIRM_INTERVAL", 15*time.Minute); err != nil {
+		return nil, err
+	}
+	if cfg.OutboxInterval, err = getEnvDuration("OUTBOX_INTERVAL", time.Second); err != nil {
+		return nil, err
+	}
+	if cfg.Tracing.SampleRatio, err = getEnvFloat("TRACE_SAMPLE_RATIO", 1); err != nil {
+		return nil, err
+	}
+	if cfg.JobWorkers, err = getEnvInt("JOB_WORKERS", 4); err != nil {
+		return nil, err
+	}
+	if cfg.JobPollInterval, err = getEnvDuration("JOB_POLL_INTERVAL", 2*time.Second); err != nil {
+		return nil, err
+	}
+	if cfg.JobMaxAttempts, err = getEnvInt("JOB_MAX_ATTEMPTS", 5); err != nil {
+		return nil, err
+	}
+	if cfg.ModerationEnabled, err = getEnvBool("MODERATION_ENABLED", false); err != nil {
+		return nil, err
+	}
+	if cfg.RequireVerification, err = getEnvBool("HOST_VERIFICATION_REQUIRED", true); err != nil {
+		return nil, err
+	}
+	if cfg.RunMigrations, err = getEnvBool("RUN_MIGRATIONS", true); err != nil {
+		return nil, err
+	}
+	if cfg.ReportHideThreshold, err = getEnvInt("REPORT_HIDE_THRESHOLD", 5); err != nil {
+		return nil, err
+	}
+	if cfg.EventCacheTTL, err = getEnvDuration("EVENT_CACHE_TTL", 30*time.Second); err != nil {
+		return nil, err
+	}
+	if cfg.BookingLockTTL, err = getEnvDuration("BOOKING_LOCK_TTL", 5*time.Second); err != nil {
+		return nil, err
+	}
+	if cfg.BookingLockWait, err = getEnvDuration("BOOKING_LOCK_WAIT", 3*time.Second); err != nil {
+		return nil, err
+	}
+	if cfg.Captcha.LoginFailures, err = getEnvInt("CAPTCHA_LOGIN_FAILURES", 3); err != nil {
+		return nil, err
+	}
+	if cfg.BookingMaxQuantity, err = getEnvInt("BOOKING_MAX_QUANTITY", 10); err != nil {
+		return nil, err
+	}
+	if cfg.BookingMaxPerEvent, err = getEnvInt("BOOKING_MAX_PER_EVENT", 20); err != nil {
+		return nil, err
+	}
+	if cfg.BookingVelocity, err = getEnvInt("BOOKING_VELOCITY_LIMIT", 5); err != nil {
+		return nil, err
+	}
+	if cfg.GzipLevel, err = getEnvInt("GZIP_LEVEL", 5); err != nil {
+		return nil, err
+	}
+	if cfg.GzipMinLength, err = getEnvInt("GZIP_MIN_LENGTH", 1024); err != nil {
+		return nil, err
+	}
+	if cfg.RequestTimeout, err = getEnvDuration("REQUEST_TIMEOUT", 15*time.Second); err != nil {
+		return nil, err
+	}
+	if cfg.EventBusMaxLen, err = getEnvInt("EVENT_BUS_MAX_LEN", 100000); err != nil {
+		return nil, err
+	}
+	if cfg.SMTP.Port, err = getEnvInt("SMTP_PORT", 587); err != nil {
+		return nil, err
+	}
+	if cfg.LowStock.SellingFastPercent, err = getEnvInt("SELLING_FAST_PERCENT", 25); err != nil {
+		return nil, err
+	}
+	if cfg.LowStock.AlmostSoldOutPercent, err = getEnvInt("ALMOST_SOLD_OUT_PERCENT", 10); err != nil {
+		return nil, err
+	}
+	if cfg.Mongo, err = loadMongoConfig(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// validate checks that required settings are present and sane
+func (cfg *Config) validate() error {
+	if cfg.MongoURI == "" {
+		return errors.New("MONGO_URI environment variable not set")
+	}
+	if cfg.DatabaseName == "" {
+		return errors.New("DATABASE_NAME environment variable not set")
+	}
+	if cfg.JWTSecret == "" {
+		return errors.New("JWT_SECRET environment variable not set")
+	}
+	if len(cfg.JWTSecret) < minJWTSecretLength {
+		return errors.New("JWT_SECRET must be at least 32 characters long")
+	}
+	if len(cfg.CORSOrigins) == 0 {
+		return errors.New("CORS_ORIGINS must contain at least one origin")
+	}
+	if cfg.TokenTTL <= 0 || cfg.SessionTTL <= 0 {
+		return errors.New("TOKEN_TTL and SESSION_TTL must be positive")
+	}
+	if cfg.CleanupInterval <= 0 {
+		return errors.New("CLEANUP_INTERVAL must be positive")
+	}
+	if cfg.EventRetentionDays < 0 {
+		return errors.New("EVENT_RETENTION_DAYS cannot be negative")
+	}
+	if cfg.CleanupBatchSize <= 0 {
+		return errors.New("CLEANUP_BATCH_SIZE must be positive")
+	}
+	if cfg.PublishInterval <= 0 {
+		return errors.New("PUBLISH_INTERVAL must be positive")
+	}
+	if cfg.ReconfirmInterval <= 0 {
+		return errors.New("RECONFIRM_INTERVAL must be positive")
+	}
+	if cfg.OutboxInterval <= 0 {
+		return errors.New("OUTBOX_INTERVAL must be positive")
+	}
+	if cfg.Tracing.SampleRatio < 0 || cfg.Tracing.SampleRatio > 1 {
+		return errors.New("TRACE_SAMPLE_RATIO must be between 0 and 1")
+	}
+	if cfg.JobWorkers <= 0 {
+		return errors.New("JOB_WORKERS must be positive")
+	}
+	if cfg.JobPollInterval <= 0 {
+		return errors.New("JOB_POLL_INTERVAL must be positive")
+	}
+	if cfg.JobMaxAttempts <= 0 {
+		return errors.New("JOB_MAX_ATTEMPTS must be positive")
+	}
+	if cfg.EventCacheTTL < 0 {
+		return errors.New("EVENT_CACHE_TTL cannot be negative")
+	}
+	if cfg.ReportHideThreshold <= 0 {
+		return errors.New("REPORT_HIDE_THRESHOLD must be positive")
+	}
+	if cfg.BookingLockTTL <= 0 || cfg.BookingLockWait <= 0 {
+		return errors.New("BOOKING_LOCK_TTL and BOOKING_LOCK_WAIT must be positive")
+	}
+	if cfg.BookingMaxQuantity < 0 || cfg.BookingMaxPerEvent < 0 || cfg.BookingVelocity < 0 {
+		return errors.New("BOOKING_MAX_QUANTITY, BOOKING_MAX_PER_EVENT and BOOKING_VELOCITY_LIMIT cannot be negative (0 turns them off)")
+	}
+	if cfg.BookingMaxQuantity > 0 && cfg.BookingMaxPerEvent > 0 && cfg.BookingMaxPerEvent < cfg.BookingMaxQuantity {
+		return errors.New("BOOKING_MAX_PER_EVENT cannot be lower than BOOKING_MAX_QUANTITY")
+	}
+	if cfg.GzipLevel < 0 || cfg.GzipLevel > 9 {
+		return errors.New("GZIP_LEVEL must be between 0 (off) and 9")
+	}
+	if cfg.RequestTimeout <= 0 {
+		return errors.New("REQUEST_TIMEOUT must be positive")
+	}
+	if cfg.GzipMinLength < 0 {
+		return errors.New("GZIP_MIN_LENGTH cannot be negative")
+	}
+	if limit, err := bytes.Parse(cfg.BodyLimit); err != nil || limit <= 0 {
+		return errors.New("BODY_LIMIT must be a size like 512K or 2M")
+	}
+	switch cfg.EventBus {
+	case "none", "log":
+	case "redis":
+		if cfg.RedisURL == "" {
+			return errors.New("EVENT_BUS=redis needs REDIS_URL")
+		}
+	default:
+		return errors.New("EVENT_BUS must be none, log or redis")
+	}
+	if cfg.EventBusMaxLen < 0 {
+		return errors.New("EVENT_BUS_MAX_LEN cannot be negative")
+	}
+	if cfg.SMTP.Port <= 0 || cfg.SMTP.Port > 65535 {
+		return errors.New("SMTP_PORT must be a port number")
+	}
+	if cfg.Twilio.AccountSID != "" && (cfg.Twilio.AuthToken == "" || cfg.Twilio.From == "") {
+		return errors.New("TWILIO_ACCOUNT_SID needs TWILIO_AUTH_TOKEN and TWILIO_FROM")
+	}
+	if cfg.Captcha.Secret != "" && cfg.Captcha.Provider != "hcaptcha" && cfg.Captcha.Provider != "recaptcha" {
+		return errors.New("CAPTCHA_PROVIDER must be hcaptcha or recaptcha")
+	}
+	if cfg.Captcha.LoginFailures < 0 {
+		return errors.New("CAPTCHA_LOGIN_FAILURES cannot be negative (0 never asks)")
+	}
+	if cfg.LowStock.SellingFastPercent < 0 || cfg.LowStock.SellingFastPercent > 100 {
+		return errors.New("SELLING_FAST_PERCENT must be between 0 and 100")
+	}
+	if cfg.LowStock.AlmostSoldOutPercent < 0 || cfg.LowStock.AlmostSoldOutPercent > 100 {
+		return errors.New("ALMOST_SOLD_OUT_PERCENT must be between 0 and 100")
+	}
+	if cfg.LowStock.SellingFastPercent != 0 && cfg.LowStock.AlmostSoldOutPercent > cfg.LowStock.SellingFastPercent {
+		return errors.New("ALMOST_SOLD_OUT_PERCENT cannot be above SELLING_FAST_PERCENT")
+	}
+	return cfg.Mongo.validate()
+}
+
+// loadMongoConfig reads the MONGO_* client settings
+func loadMongoConfig() (MongoConfig, error) {
+	mc := MongoConfig{
+		ReadPreference: getEnv("MONGO_READ_PREFERENCE", "primary"),
+	}
+
+	var err error
+	if mc.MaxPoolSize, err = getEnvInt("MONGO_MAX_POOL_SIZE", 100); err != nil {
+		return mc, err
+	}
+	if mc.MinPoolSize, err = getEnvInt("MONGO_MIN_POOL_SIZE", 0); err != nil {
+		return mc, err
+	}
+	if mc.ConnectTimeout, err = getEnvDuration("MONGO_CONNECT_TIMEOUT", 10*time.Second); err != nil {
+		return mc, err
+	}
+	if mc.ServerSelectionTimeout, err = getEnvDuration("MONGO_SERVER_SELECTION_TIMEOUT", 10*time.Second); err != nil {
+		return mc, err
+	}
+	if mc.OperationTimeout, err = getEnvDuration("MONGO_OPERATION_TIMEOUT", 10*time.Second); err != nil {
+		return mc, err
+	}
+	if mc.RetryWrites, err = getEnvBool("MONGO_RETRY_WRITES", true); err != nil {
+		return mc, err
+	}
+	if mc.RetryReads, err = getEnvBool("MONGO_RETRY_READS", true); err != nil {
+		return mc, err
+	}
+	if mc.ConnectRetries, err = getEnvInt("MONGO_CONNECT_RETRIES", 5); err != nil {
+		return mc, err
+	}
+	if mc.ConnectRetryBackoff, err = getEnvDuration("MONGO_CONNECT_RETRY_BACKOFF", time.Second); err != nil {
+		return mc, err
+	}
+
+	return mc, nil
+}
+
+// validate checks the MongoDB client settings
+func (mc MongoConfig) validate() error {
+	if mc.MaxPoolSize <= 0 {
+		return errors.New("MONGO_MAX_POOL_SIZE must be positive")
+	}
+	if mc.MinPoolSize < 0 || mc.MinPoolSize > mc.MaxPoolSize {
+		return errors.New("MONGO_MIN_POOL_SIZE must be between 0 and MONGO_MAX_POOL_SIZE")
+	}
+	if mc.ConnectTimeout <= 0 || mc.ServerSelectionTimeout <= 0 {
+		return errors.New("MONGO_CONNECT_TIMEOUT and MONGO_SERVER_SELECTION_TIMEOUT must be positive")
+	}
+	if mc.OperationTimeout < 0 {
+		return errors.New("MONGO_OPERATION_TIMEOUT cannot be negative")
+	}
+	if _, err := readpref.ModeFromString(mc.ReadPreference); err != nil {
+		return errors.New("MONGO_READ_PREFERENCE must be primary, primaryPreferred, secondary, secondaryPreferred or nearest")
+	}
+	if mc.ConnectRetries < 0 {
+		return errors.New("MONGO_CONNECT_RETRIES cannot be negative")
+	}
+	if mc.ConnectRetryBackoff <= 0 {
+		return errors.New("MONGO_CONNECT_RETRY_BACKOFF must be positive")
+	}
+	return nil
+}
+
+// DBOptions converts the settings into MongoDB client options
+func (mc MongoConfig) DBOptions() db.Options {
+	return db.Options{
+		MaxPoolSize:            uint64(mc.MaxPoolSize),
+		MinPoolSize:            uint64(mc.MinPoolSize),
+		ConnectTimeout:         mc.ConnectTimeout,
+		ServerSelectionTimeout: mc.ServerSelectionTimeout,
+		OperationTimeout:       mc.OperationTimeout,
+		RetryWrites:            mc.RetryWrites,
+		RetryReads:             mc.RetryReads,
+		ReadPreference:         mc.ReadPreference,
+		ConnectRetries:         mc.ConnectRetries,
+		ConnectRetryBackoff:    mc.ConnectRetryBackoff,
+	}
+}
+
+// getEnv returns the env value or a fallback
+func getEnv(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// getEnvList splits a comma separated env value, or returns the fallback
+func getEnvList(key string, fallback []string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	var list []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// getEnvCIDRs parses a comma separated list of CIDR ranges (e.g. "10.0.0.0/8,172.16.0.0/12") from env
+func getEnvCIDRs(key string) ([]*net.IPNet, error) {
+	var ranges []*net.IPNet
+	for _, item := range getEnvList(key, nil) {
+		_, ipRange, err := net.ParseCIDR(item)
+		if err != nil {
+			return nil, errors.New(key + " must be CIDR ranges like 10.0.0.0/8, got " + item)
+		}
+		ranges = append(ranges, ipRange)
+	}
+	return ranges, nil
+}
+
+// getEnvDuration parses a Go duration (e.g. "90m", "24h") from env, or returns the fallback
+func getEnvDuration(key string, fallback time.Duration) (time.Duration, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback, nil
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, errors.New(key + " must be a duration like 1h or 30m")
+	}
+	return duration, nil
+}
+
+// getEnvBool parses a boolean (true/false/1/0) from env, or returns the fallback
+func getEnvBool(key string, fallback bool) (bool, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback, nil
+	}
+
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, errors.New(key + " must be true or false")
+	}
+	return enabled, nil
+}
+
+// getEnvInt parses an integer from env, or returns the fallback
+func getEnvInt(key string, fallback int) (int, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback, nil
+	}
+
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, errors.New(key + " must be a whole number")
+	}
+	return number, nil
+}
+
+// getEnvFloat parses a decimal number from env, or returns the fallback
+func getEnvFloat(key string, fallback float64) (float64, error) {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback, nil
+	}
+
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, errors.New(key + " must be a number")
+	}
+	return number, nil
+}
+
+// getEnvMap parses "key=value,key2=value2" from env, entries without = are skipped
+func getEnvMap(key string) map[string]string {
+	entries := make(map[string]string)
+	for _, entry := range strings.Split(os.Getenv(key), ",") {
+		name, value, ok := strings.Cut(entry, "=")
+		if !ok || strings.TrimSpace(name) == "" {
+			continue
+		}
+		entries[strings.TrimSpace(name)] = strings.TrimSpace(value)
+	}
+	return entries
+}
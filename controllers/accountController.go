@@ -0,0 +1,126 @@
+package controllers
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"event-horizon/dto"
+	"event-horizon/middleware"
+	"event-horizon/models"
+	"event-horizon/services"
+	"event-horizon/store"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+//! THIS FILE HANDLES A USER'S OWN DATA (GDPR EXPORT AND ACCOUNT DELETION)
+
+/******************************* NOTE **************************************
+
+I DID THE FOLLOWING THINGS IN THIS FILE:
+
+1. Created AccountController struct, the rules are in services.AccountService.
+
+2. Implemented ExportAccount method, one JSON file by default or a ZIP with one JSON file per part (?format=zip).
+
+3. Implemented DeleteAccount method, the deletion is recorded in the AUDIT LOG and the token cookie is cleared.
+
+********************************* NOTE ************************************/
+
+type AccountController struct {
+	accounts   *services.AccountService
+	auditStore store.AuditRepository
+}
+
+func NewAccountController(accountService *services.AccountService, auditStore store.AuditRepository) *AccountController {
+	return &AccountController{
+		accounts:   accountService,
+		auditStore: auditStore,
+	}
+}
+
+// ExportAccount downloads everything kept about the authenticated user
+func (cntrlr *AccountController) ExportAccount(c echo.Context) error {
+	userObjID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	format := strings.ToLower(c.QueryParam("format"))
+	if format != "" && format != "json" && format != "zip" {
+		return echo.NewHTTPError(http.StatusBadRequest, "format must be json or zip")
+	}
+
+	export, err := cntrlr.accounts.Export(c.Request().Context(), userObjID)
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	filename := "event-horizon-export-" + time.Now().UTC().Format(exportDateLayout)
+	res := c.Response()
+
+	if format != "zip" {
+		res.Header().Set(echo.HeaderContentDisposition, `attachment; filename="`+filename+`.json"`)
+		return c.JSON(http.StatusOK, export)
+	}
+
+	files := export.Files()
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	res.Header().Set(echo.HeaderContentType, "application/zip")
+	res.Header().Set(echo.HeaderContentDisposition, `attachment; filename="`+filename+`.zip"`)
+	res.WriteHeader(http.StatusOK)
+
+	//! The status is sent, a failure from here on can only cut the download short
+	archive := zip.NewWriter(res)
+	for _, name := range names {
+		file, err := archive.Create(name)
+		if err != nil {
+			return err
+		}
+		encoder := json.NewEncoder(file)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(files[name]); err != nil {
+			return err
+		}
+	}
+	return archive.Close()
+}
+
+// DeleteAccount deletes the authenticated user's account, the password is asked again
+func (cntrlr *AccountController) DeleteAccount(c echo.Context) error {
+	userObjID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.DeleteAccountRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request payload")
+	}
+	if req.Password == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "password is required")
+	}
+
+	user, err := cntrlr.accounts.Delete(c.Request().Context(), userObjID, req.Password)
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	//? Only the ID and roles are kept in the log, the email would bring back what was just deleted
+	recordAudit(c, cntrlr.auditStore, models.AuditUserDeleted, "user", user.ID,
+		bson.M{"is_host": user.IsHost, "is_admin": user.IsAdmin}, bson.M{"name": models.DeletedUserName})
+
+	c.SetCookie(middleware.ClearTokenCookie())
+	return c.JSON(http.StatusOK, map[string]string{
+		"message": "Your account was deleted",
+	})
+}
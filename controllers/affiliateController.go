@@ -0,0 +1,108 @@
+package controllers
+
+import (
+	"event-horizon/dto"
+	"event-horizon/services"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+//! THIS FILE HANDLES THE AFFILIATES (AMBASSADORS) OF HOSTS AND THEIR COMMISSION REPORT
+
+/******************************* NOTE **************************************
+
+I DID THE FOLLOWING THINGS IN THIS FILE:
+
+1. Created AffiliateController struct, the rules are in services.AffiliateService.
+
+2. Implemented CreateAffiliate, GetAffiliates and UpdateAffiliate methods for the authenticated host.
+
+3. Implemented GetAffiliateReport method with the sales and owed commission per affiliate, ?from=&to= like the bookings export.
+
+********************************* NOTE ************************************/
+
+type AffiliateController struct {
+	affiliates *services.AffiliateService
+}
+
+func NewAffiliateController(affiliateService *services.AffiliateService) *AffiliateController {
+	return &AffiliateController{
+		affiliates: affiliateService,
+	}
+}
+
+// CreateAffiliate registers an affiliate of the authenticated host
+func (cntrlr *AffiliateController) CreateAffiliate(c echo.Context) error {
+	userObjID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.AffiliateRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request payload")
+	}
+
+	affiliate, err := cntrlr.affiliates.Create(c.Request().Context(), userObjID, &req)
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, affiliate)
+}
+
+// GetAffiliates returns the affiliates of the authenticated host
+func (cntrlr *AffiliateController) GetAffiliates(c echo.Context) error {
+	userObjID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	affiliates, err := cntrlr.affiliates.List(c.Request().Context(), userObjID)
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, affiliates)
+}
+
+// UpdateAffiliate changes the commission or turns an affiliate on / off
+func (cntrlr *AffiliateController) UpdateAffiliate(c echo.Context) error {
+	userObjID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.UpdateAffiliateRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request payload")
+	}
+
+	affiliate, err := cntrlr.affiliates.Update(c.Request().Context(), userObjID, c.Param("id"), &req)
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, affiliate)
+}
+
+// GetAffiliateReport returns the sales and owed commission of every affiliate of the authenticated host
+func (cntrlr *AffiliateController) GetAffiliateReport(c echo.Context) error {
+	userObjID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	from, to, err := parseDateRange(c)
+	if err != nil {
+		return err
+	}
+
+	reports, err := cntrlr.affiliates.Report(c.Request().Context(), userObjID, from, to)
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, reports)
+}
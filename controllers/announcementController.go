@@ -0,0 +1,68 @@
+package controllers
+
+import (
+	"event-horizon/dto"
+	"event-horizon/services"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+//! THIS FILE HANDLES EVENT ANNOUNCEMENTS (UPDATES FROM THE HOST TO THE ATTENDEES)
+
+/******************************* NOTE **************************************
+
+I DID THE FOLLOWING THINGS IN THIS FILE:
+
+1. Created AnnouncementController struct, the rules are in services.AnnouncementService.
+
+2. Implemented CreateAnnouncement method so the host and co-hosts can notify every confirmed attendee.
+
+3. Implemented GetAnnouncements method for the public announcement history shown on the event page.
+
+********************************* NOTE ************************************/
+
+type AnnouncementController struct {
+	announcements *services.AnnouncementService
+}
+
+func NewAnnouncementController(announcementService *services.AnnouncementService) *AnnouncementController {
+	return &AnnouncementController{
+		announcements: announcementService,
+	}
+}
+
+// CreateAnnouncement sends an announcement to the attendees of an event (event host and co-hosts only)
+func (cntrlr *AnnouncementController) CreateAnnouncement(c echo.Context) error {
+	userObjID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.AnnouncementRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request payload")
+	}
+
+	announcement, err := cntrlr.announcements.Announce(c.Request().Context(), userObjID, c.Param("id"), &req)
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, announcement)
+}
+
+// GetAnnouncements returns the latest announcements of an event, newest first
+func (cntrlr *AnnouncementController) GetAnnouncements(c echo.Context) error {
+	limit, err := parseLimit(c)
+	if err != nil {
+		return err
+	}
+
+	announcements, err := cntrlr.announcements.List(c.Request().Context(), c.Param("id"), limit)
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, announcements)
+}
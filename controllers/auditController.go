@@ -0,0 +1,100 @@
+package controllers
+
+import (
+	"event-horizon/models"
+	"event-horizon/store"
+	"event-horizon/utils"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+//! THIS FILE HANDLES THE AUDIT LOG: RECORDING SENSITIVE ACTIONS AND LETTING ADMINS QUERY THEM
+
+/******************************* NOTE **************************************
+
+I DID THE FOLLOWING THINGS IN THIS FILE:
+
+1. Created recordAudit helper that other controllers call after sensitive actions (actor, IP, before/after).
+
+2. Created AuditController struct for the admin-only audit log query endpoint.
+
+3. Implemented GetAuditLogs method with ?action=, ?actor_id=, ?target_id= and ?limit= filters.
+
+********************************* NOTE ************************************/
+
+// recordAudit writes an audit entry for the current request, failures are logged but never fail the request
+func recordAudit(c echo.Context, auditStore store.AuditRepository, action, targetType string, targetID bson.ObjectID, before, after interface{}) {
+	entry := models.AuditLog{
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		IP:         c.RealIP(),
+		Before:     before,
+		After:      after,
+	}
+
+	//? Actor comes from the JWT
+	if userID, err := utils.GetUserIDFromToken(c); err == nil {
+		entry.ActorID, _ = bson.ObjectIDFromHex(userID)
+	}
+
+	if err := auditStore.RecordAudit(c.Request().Context(), &entry); err != nil {
+		log.Printf("Error recording audit log %s: %v", action, err)
+	}
+}
+
+type AuditController struct {
+	auditStore store.AuditRepository
+}
+
+func NewAuditController(auditStore store.AuditRepository) *AuditController {
+	return &AuditController{
+		auditStore: auditStore,
+	}
+}
+
+// GetAuditLogs lists audit entries (admin only)
+func (cntrlr *AuditController) GetAuditLogs(c echo.Context) error {
+	filter := store.AuditFilter{
+		Action: c.QueryParam("action"),
+		Limit:  100,
+	}
+
+	if actorID := c.QueryParam("actor_id"); actorID != "" {
+		objID, err := bson.ObjectIDFromHex(actorID)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid actor_id")
+		}
+		filter.ActorID = objID
+	}
+
+	if targetID := c.QueryParam("target_id"); targetID != "" {
+		objID, err := bson.ObjectIDFromHex(targetID)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid target_id")
+		}
+		filter.TargetID = objID
+	}
+
+	if limit := c.QueryParam("limit"); limit != "" {
+		parsed, err := strconv.ParseInt(limit, 10, 64)
+		if err != nil || parsed <= 0 || parsed > 1000 {
+			return echo.NewHTTPError(http.StatusBadRequest, "limit must be between 1 and 1000")
+		}
+		filter.Limit = parsed
+	}
+
+	logs, err := cntrlr.auditStore.GetAuditLogs(c.Request().Context(), filter)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to retrieve audit logs")
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"audit_logs": logs,
+		"count":      len(logs),
+	})
+}
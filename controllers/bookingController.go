@@ -1,12 +1,14 @@
 package controllers
 
 import (
-	"crypto/rand"
-	"encoding/hex"
+	"event-horizon/dto"
 	"event-horizon/models"
+	"event-horizon/realtime"
+	"event-horizon/services"
 	"event-horizon/store"
 	"event-horizon/utils"
 	"net/http"
+	"strings"
 
 	"github.com/labstack/echo/v4"
 	"go.mongodb.org/mongo-driver/v2/bson"
@@ -54,120 +56,339 @@ I DID THE FOLLOWING THINGS IN THIS FILE:
 
 10. Sent appropriate HTTP responses based on operation outcomes.
 
+11. Published ticket availability changes to the realtime Hub after bookings are created or cancelled.
+
+12. Recorded booking cancellations in the AUDIT LOG.
+
+13. Implemented GetEventBookings method so the host and CO-HOSTS can see the bookings of their event.
+
+14. CreateBooking answers 503 when the event's booking lock is busy so clients can retry.
+
+15. The controller depends on store REPOSITORY interfaces instead of the concrete stores.
+
+16. Booking validation, ownership and host checks moved into services.BookingService, the handlers only bind, call it and respond.
+
+17. Implemented GetBookingReceipt method that sends the PDF receipt of a booking (built by services.ReceiptService).
+
+18. CreateBooking responds with the currency and the tax breakdown (subtotal, tax and total in minor units).
+
+19. Implemented CreateGuestBooking, GetGuestBooking and ClaimGuestBookings for GUEST checkout (services.GuestService).
+
+20. CreateBooking responds with the ATTENDEES and their check-in codes, implemented UpdateAttendees to rename them before the event.
+
+21. Live availability updates leave out exact ticket counts when the host hides them.
+
+22. GetUserBookings and GetAllBookings are CURSOR paginated (?limit=, ?cursor=) and filter by ?status= and the booking date (?from=, ?to=).
+
+23. GetUserBookings splits the tickets into ?when=upcoming and ?when=past events for the app's tabs.
+
+24. Booking lists come with the event's name, dates and location, hosts and admins also see the buyer's name and email.
+
+25. Implemented ForceCancelBooking so support can cancel any booking with a reason, it is audited under the acting admin and the user is notified.
+
+26. Implemented ChangeTicketType so buyers can switch their tickets to another type, the response carries the price difference to charge or refund.
+
+27. Implemented ReconfirmBooking so buyers keep their tickets after the event was rescheduled (cancelling refunds them).
+
+28. CreateBooking and CreateGuestBooking pass the client's IP on, the booking service limits bookings per minute per IP against SCALPERS.
+
+29. GetEventBookings also answers door devices that come with a SCANNER KEY of the event (attendee lookup).
+
+30. Implemented GetWallet method, the upcoming tickets of the user in one compact call for the mobile wallet.
+
 ********************************* NOTE ************************************/
 
 type BookingController struct {
-	BookingStore *store.BookingStore
-	EventStore   *store.EventStore
+	Bookings     *services.BookingService
+	Receipts     *services.ReceiptService
+	Guests       *services.GuestService
+	BookingStore store.BookingRepository
+	EventStore   store.EventRepository
+	AuditStore   store.AuditRepository
+	Hub          *realtime.Hub
+	Notifier     *utils.NotificationWorker
 }
 
-func NewBookingController(bookingStore *store.BookingStore, eventStore *store.EventStore) *BookingController {
+func NewBookingController(bookingService *services.BookingService, receiptService *services.ReceiptService, guestService *services.GuestService, bookingStore store.BookingRepository, eventStore store.EventRepository, auditStore store.AuditRepository, hub *realtime.Hub, notifier *utils.NotificationWorker) *BookingController {
 	return &BookingController{
+		Bookings:     bookingService,
+		Receipts:     receiptService,
+		Guests:       guestService,
 		BookingStore: bookingStore,
 		EventStore:   eventStore,
+		AuditStore:   auditStore,
+		Hub:          hub,
+		Notifier:     notifier,
 	}
 }
 
-// generateTransactionID generates a random transaction ID
-func generateTransactionID() string {
-	bytes := make([]byte, 16)
-	rand.Read(bytes)
-	return "TXN-" + hex.EncodeToString(bytes)
+// publishTicketAvailability pushes the event's current ticket availability to live listeners
+func (cntrlr *BookingController) publishTicketAvailability(c echo.Context, eventID string) {
+	event, err := cntrlr.EventStore.GetEventByID(c.Request().Context(), eventID)
+	if err != nil {
+		return
+	}
+
+	cntrlr.Hub.Publish(realtime.Update{
+		Type:    realtime.UpdateTicketsChanged,
+		EventID: eventID,
+		Tickets: event.PublicTickets(),
+	})
 }
 
 // CreateBooking handles booking creation
 func (cntrlr *BookingController) CreateBooking(c echo.Context) error {
 
 	//? REQUEST PAYLOAD STRUCT
-	var bookingRequest struct {
-		EventID    string `json:"event_id" validate:"required"`
-		TicketType string `json:"ticket_type" validate:"required,oneof=VIP Regular Student"`
-		Quantity   int    `json:"quantity" validate:"required,gt=0"`
-	}
+	var bookingRequest dto.CreateBookingRequest
 
 	//? Bind Request
 	if err := c.Bind(&bookingRequest); err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request payload FROM BOOKING")
 	}
+	bookingRequest.ClientIP = c.RealIP()
 
-	//? Validate quantity
-	if bookingRequest.Quantity <= 0 {
-		return echo.NewHTTPError(http.StatusBadRequest, "Quantity must be greater than zero FROM BOOKING")
+	//? Get user from JWT
+	userObjID, err := currentUserID(c)
+	if err != nil {
+		return err
 	}
 
-	//? Get user from JWT
-	userID, err := utils.GetUserIDFromToken(c)
+	// Create booking (the service validates it, the store checks availability and calculates the price)
+	booking, event, err := cntrlr.Bookings.CreateBooking(c.Request().Context(), userObjID, &bookingRequest)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized FROM BOOKING")
+		return serviceError(c, err)
 	}
 
-	//? Convert userID string to ObjectID
-	userObjID, err := bson.ObjectIDFromHex(userID)
+	//? Push the new availability to live listeners
+	cntrlr.publishTicketAvailability(c, event.ID.Hex())
+
+	// Success Response
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"message":              "Booking created successfully",
+		"booking_id":           booking.ID.Hex(),
+		"transaction_id":       booking.TransactionID,
+		"event_id":             event.ID.Hex(),
+		"event_name":           event.Name,
+		"session_id":           booking.SessionID,
+		"ticket_type":          booking.TicketType,
+		"quantity":             booking.Quantity,
+		"total_paid":           booking.TotalPaid,
+		"subtotal_minor":       booking.SubtotalMinor,
+		"tax_minor":            booking.TaxMinor,
+		"tax_rate":             booking.TaxRate,
+		"tax_inclusive":        booking.TaxInclusive,
+		"total_paid_minor":     booking.TotalPaidMinor,
+		"total_paid_formatted": models.FormatAmount(booking.TotalPaidMinor, booking.Currency),
+		"currency":             booking.Currency,
+		"attendees":            booking.Attendees,
+		"status":               booking.Status,
+		"booked_at":            booking.BookedAt,
+	})
+}
+
+// UpdateAttendees changes the names and emails of a booking's attendees before the event starts
+func (cntrlr *BookingController) UpdateAttendees(c echo.Context) error {
+	userObjID, err := currentUserID(c)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID FROM BOOKING")
+		return err
 	}
 
-	//? Validate and convert event ID
-	eventObjID, err := bson.ObjectIDFromHex(bookingRequest.EventID)
+	var req dto.UpdateAttendeesRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request payload FROM BOOKING")
+	}
+
+	//? The service verifies the booking belongs to the user and the event hasn't started
+	booking, err := cntrlr.Bookings.UpdateAttendees(c.Request().Context(), userObjID, c.Param("id"), &req)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "Invalid event ID FROM BOOKING")
+		return serviceError(c, err)
 	}
 
-	//? Verify event exists
-	event, err := cntrlr.EventStore.GetEventByID(c.Request().Context(), bookingRequest.EventID)
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message":   "Attendees updated successfully",
+		"attendees": booking.Attendees,
+	})
+}
+
+// ChangeTicketType moves the user's booking to another ticket type, the response carries the price difference
+func (cntrlr *BookingController) ChangeTicketType(c echo.Context) error {
+	userObjID, err := currentUserID(c)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusNotFound, "Event not found FROM BOOKING")
+		return err
 	}
 
-	//? Create booking object
-	booking := models.Booking{
-		UserID:        userObjID,
-		EventID:       eventObjID,
-		TicketType:    bookingRequest.TicketType,
-		TransactionID: generateTransactionID(),
-		Quantity:      bookingRequest.Quantity,
-		Status:        "confirmed", // Auto-set
+	var req dto.ChangeTicketTypeRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request payload FROM BOOKING")
 	}
 
-	// Create booking (this handles ticket availability check and price calculation)
-	if err := cntrlr.BookingStore.CreateBooking(c.Request().Context(), &booking); err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "error creating booking FROM BOOKING")
+	//? The service verifies the booking belongs to the user, the event hasn't started and the new type is on sale
+	booking, adjustment, err := cntrlr.Bookings.ChangeTicketType(c.Request().Context(), userObjID, c.Param("id"), &req)
+	if err != nil {
+		return serviceError(c, err)
 	}
 
-	// Success Response
-	return c.JSON(http.StatusCreated, map[string]interface{}{
-		"message":        "Booking created successfully",
-		"booking_id":     booking.ID.Hex(),
-		"transaction_id": booking.TransactionID,
-		"event_id":       event.ID.Hex(),
-		"event_name":     event.Name,
-		"ticket_type":    booking.TicketType,
-		"quantity":       booking.Quantity,
-		"total_paid":     booking.TotalPaid,
-		"status":         booking.Status,
-		"booked_at":      booking.BookedAt,
+	recordAudit(c, cntrlr.AuditStore, models.AuditBookingTicketTypeChanged, "booking", booking.ID,
+		bson.M{"ticket_type": adjustment.FromType}, bson.M{"ticket_type": adjustment.ToType, "difference_minor": adjustment.DifferenceMinor})
+
+	//? Both ticket types changed availability
+	cntrlr.publishTicketAvailability(c, booking.EventID.Hex())
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message":              "Ticket type changed successfully",
+		"booking":              booking,
+		"difference_minor":     adjustment.DifferenceMinor,
+		"difference_formatted": models.FormatAmount(adjustment.DifferenceMinor, adjustment.Currency),
 	})
 }
 
-// GetUserBookings retrieves all bookings for the authenticated user
+// ReconfirmBooking keeps the user's booking after its event was rescheduled
+func (cntrlr *BookingController) ReconfirmBooking(c echo.Context) error {
+	userObjID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	booking, err := cntrlr.Bookings.Reconfirm(c.Request().Context(), userObjID, c.Param("id"))
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Booking reconfirmed",
+		"booking": booking,
+	})
+}
+
+// Booking page sizes
+const (
+	defaultBookingPageSize = 20
+	maxBookingPageSize     = 100
+)
+
+// parseBookingFilter reads the paging (?limit=, ?cursor=) and filters (?status=, ?from=, ?to=) of a booking listing
+func parseBookingFilter(c echo.Context) (store.BookingFilter, error) {
+	filter := store.BookingFilter{Limit: defaultBookingPageSize}
+
+	limit, err := parseLimit(c)
+	if err != nil {
+		return filter, err
+	}
+	if limit < 0 || limit > maxBookingPageSize {
+		return filter, echo.NewHTTPError(http.StatusBadRequest, "limit must be between 1 and 100")
+	}
+	if limit > 0 {
+		filter.Limit = int64(limit)
+	}
+
+	if cursor := c.QueryParam("cursor"); cursor != "" {
+		filter.After, err = bson.ObjectIDFromHex(cursor)
+		if err != nil {
+			return filter, echo.NewHTTPError(http.StatusBadRequest, "Invalid cursor")
+		}
+	}
+
+	switch status := c.QueryParam("status"); status {
+	case "", "confirmed", "cancelled":
+		filter.Status = status
+	default:
+		return filter, echo.NewHTTPError(http.StatusBadRequest, "status must be confirmed or cancelled")
+	}
+
+	filter.From, filter.To, err = parseDateRange(c)
+	return filter, err
+}
+
+// bookingPage is the response of a paginated booking listing, next_cursor is missing on the last page
+func bookingPage(c echo.Context, bookings []models.BookingWithDetails, nextCursor string) error {
+	localizeBookingEvents(bookings)
+	response := map[string]interface{}{
+		"bookings": bookings,
+		"count":    len(bookings),
+	}
+	if nextCursor != "" {
+		response["next_cursor"] = nextCursor
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+// localizeBookingEvents shows the looked up event times in each event's own timezone
+func localizeBookingEvents(bookings []models.BookingWithDetails) {
+	for i := range bookings {
+		if bookings[i].Event != nil {
+			utils.LocalizeSummaryTimes(bookings[i].Event)
+		}
+	}
+}
+
+// GetUserBookings retrieves one page of the authenticated user's bookings, newest first
 func (cntrlr *BookingController) GetUserBookings(c echo.Context) error {
 	//? Get user from JWT
-	userID, err := utils.GetUserIDFromToken(c)
+	userObjID, err := currentUserID(c)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized FROM BOOKING")
+		return err
 	}
 
-	//? Convert userID string to ObjectID
-	userObjID, err := bson.ObjectIDFromHex(userID)
+	filter, err := parseBookingFilter(c)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID FROM BOOKING")
+		return err
 	}
+	filter.UserID = userObjID
 
-	bookings, err := cntrlr.BookingStore.GetBookingsByUserID(c.Request().Context(), userObjID)
+	switch when := c.QueryParam("when"); when {
+	case "", store.BookingsUpcoming, store.BookingsPast:
+		filter.When = when
+	default:
+		return echo.NewHTTPError(http.StatusBadRequest, "when must be upcoming or past")
+	}
+
+	bookings, nextCursor, err := cntrlr.BookingStore.ListBookings(c.Request().Context(), filter)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Error retrieving bookings FROM BOOKING")
 	}
 
+	return bookingPage(c, bookings, nextCursor)
+}
+
+// GetWallet returns the authenticated user's upcoming confirmed bookings for the mobile ticket wallet
+func (cntrlr *BookingController) GetWallet(c echo.Context) error {
+	userObjID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	tickets, err := cntrlr.Bookings.Wallet(c.Request().Context(), userObjID)
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	//? Times in each event's own time zone like every event response
+	for i := range tickets {
+		if loc, err := utils.LoadEventLocation(tickets[i].Event.Timezone); err == nil {
+			tickets[i].Event.StartTime = tickets[i].Event.StartTime.In(loc)
+			tickets[i].Event.EndTime = tickets[i].Event.EndTime.In(loc)
+		}
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"tickets": tickets,
+		"count":   len(tickets),
+	})
+}
+
+// GetEventBookings retrieves all bookings of an event (event host, co-hosts and door staff only)
+func (cntrlr *BookingController) GetEventBookings(c echo.Context) error {
+	door, err := currentDoor(c)
+	if err != nil {
+		return err
+	}
+
+	bookings, err := cntrlr.Bookings.GetEventBookings(c.Request().Context(), door, c.Param("eventId"))
+	if err != nil {
+		return serviceError(c, err)
+	}
+
 	return c.JSON(http.StatusOK, map[string]interface{}{
 		"bookings": bookings,
 		"count":    len(bookings),
@@ -190,51 +411,169 @@ func (cntrlr *BookingController) GetBookingByID(c echo.Context) error {
 	})
 }
 
-// CancelBooking deletes a booking and restores ticket quantity
-func (cntrlr *BookingController) CancelBooking(c echo.Context) error {
-	bookingID := c.Param("id") //! GET PARAM
+// CreateGuestBooking books tickets with only a name and an email, the access code is also emailed
+func (cntrlr *BookingController) CreateGuestBooking(c echo.Context) error {
+	var req dto.GuestBookingRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request payload")
+	}
+	req.ClientIP = c.RealIP()
+
+	booking, event, err := cntrlr.Guests.CreateGuestBooking(c.Request().Context(), &req)
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	//? Push the new availability to live listeners
+	cntrlr.publishTicketAvailability(c, event.ID.Hex())
+
+	//! The access code is only emailed, whoever booked with someone else's email must not get it
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"message":    "Booking created, the access code was sent to " + req.Email,
+		"booking":    booking,
+		"event_name": event.Name,
+	})
+}
+
+// GetGuestBooking returns the guest booking behind an access code
+func (cntrlr *BookingController) GetGuestBooking(c echo.Context) error {
+	booking, event, err := cntrlr.Guests.GuestBooking(c.Request().Context(), c.Param("code"))
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	response := map[string]interface{}{
+		"booking": booking,
+	}
+	if event != nil {
+		toPublicEvent(event)
+		response["event"] = event
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// ClaimGuestBookings moves guest bookings into the authenticated user's account, if it has the guest's email
+func (cntrlr *BookingController) ClaimGuestBookings(c echo.Context) error {
+	userObjID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+	userEmail, err := currentUserEmail(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.ClaimGuestBookingsRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request payload")
+	}
+
+	moved, err := cntrlr.Guests.ClaimGuestBookings(c.Request().Context(), userObjID, userEmail, req.Code)
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Guest bookings added to your account",
+		"claimed": moved,
+	})
+}
 
-	//? Convert to ObjectID
-	bookingObjID, err := bson.ObjectIDFromHex(bookingID)
+// GetBookingReceipt sends the PDF receipt of a booking (buyer or admin only)
+func (cntrlr *BookingController) GetBookingReceipt(c echo.Context) error {
+	userObjID, err := currentUserID(c)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "Invalid booking ID FROM FROM BOOKING")
+		return err
 	}
 
+	pdf, booking, err := cntrlr.Receipts.BookingReceipt(c.Request().Context(), userObjID, c.Param("id"))
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, `inline; filename="receipt-`+booking.TransactionID+`.pdf"`)
+	return c.Blob(http.StatusOK, "application/pdf", pdf)
+}
+
+// CancelBooking cancels a booking and restores ticket quantity
+func (cntrlr *BookingController) CancelBooking(c echo.Context) error {
 	//? Get user from JWT
-	userID, err := utils.GetUserIDFromToken(c)
+	userObjID, err := currentUserID(c)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized FROM BOOKING")
+		return err
 	}
 
-	//? Verify the booking belongs to the user
-	booking, err := cntrlr.BookingStore.GetBookingByID(c.Request().Context(), bookingID)
+	//? The service verifies the booking belongs to the user
+	booking, err := cntrlr.Bookings.CancelBooking(c.Request().Context(), userObjID, c.Param("id"))
 	if err != nil {
-		return echo.NewHTTPError(http.StatusNotFound, "Booking not found FROM BOOKING")
+		return serviceError(c, err)
+	}
+
+	recordAudit(c, cntrlr.AuditStore, models.AuditBookingCancelled, "booking", booking.ID, booking, nil)
+
+	//? Push the restored availability to live listeners
+	cntrlr.publishTicketAvailability(c, booking.EventID.Hex())
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Booking cancelled successfully",
+	})
+}
+
+// ForceCancelBooking cancels any booking to resolve a dispute (admin function), the reason comes in the body or ?reason=
+func (cntrlr *BookingController) ForceCancelBooking(c echo.Context) error {
+	var req struct {
+		Reason string `json:"reason" query:"reason"`
+	}
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
 	}
 
-	if booking.UserID.Hex() != userID {
-		return echo.NewHTTPError(http.StatusForbidden, "You can only cancel your own bookings FROM BOOKING")
+	booking, event, err := cntrlr.Bookings.ForceCancelBooking(c.Request().Context(), c.Param("id"), req.Reason)
+	if err != nil {
+		return serviceError(c, err)
 	}
 
-	//? Cancel (delete) the booking
-	if err := cntrlr.BookingStore.CancelBooking(c.Request().Context(), bookingObjID); err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "Error cancelling booking FROM BOOKING")
+	//? The audit entry's actor is the admin from the JWT
+	recordAudit(c, cntrlr.AuditStore, models.AuditBookingForceCancelled, "booking", booking.ID, booking, bson.M{"reason": strings.TrimSpace(req.Reason)})
+
+	//? Guest bookings have no account to notify, they still get the cancellation email
+	if !booking.UserID.IsZero() {
+		message := "Your booking was cancelled by support: " + strings.TrimSpace(req.Reason)
+		if event != nil {
+			message = "Your booking for " + event.Name + " was cancelled by support: " + strings.TrimSpace(req.Reason)
+		}
+		cntrlr.Notifier.NotifyUser(booking.UserID, models.NotificationBookingCancellation, message, booking.EventID)
 	}
 
+	cntrlr.publishTicketAvailability(c, booking.EventID.Hex())
+
 	return c.JSON(http.StatusOK, map[string]interface{}{
-		"message": "Booking cancelled and deleted successfully",
+		"message": "Booking cancelled",
+		"booking": booking,
 	})
 }
 
-// GetAllBookings retrieves all bookings across all events (admin function)
+// GetAllBookings retrieves one page of the bookings across all events, newest first (admin function)
 func (cntrlr *BookingController) GetAllBookings(c echo.Context) error {
-	bookings, err := cntrlr.BookingStore.GetAllBookings(c.Request().Context())
+	filter, err := parseBookingFilter(c)
+	if err != nil {
+		return err
+	}
+
+	filter.WithUser = true
+
+	if userID := c.QueryParam("user_id"); userID != "" {
+		filter.UserID, err = bson.ObjectIDFromHex(userID)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "Invalid user_id")
+		}
+	}
+
+	bookings, nextCursor, err := cntrlr.BookingStore.ListBookings(c.Request().Context(), filter)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, "Error retrieving all bookings FROM BOOKING")
 	}
 
-	return c.JSON(http.StatusOK, map[string]interface{}{
-		"bookings": bookings,
-		"count":    len(bookings),
-	})
+	return bookingPage(c, bookings, nextCursor)
 }
@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"event-horizon/dto"
 	"event-horizon/models"
 	"event-horizon/store"
 	"net/http"
@@ -50,7 +51,7 @@ I DID THE FOLLOWING THINGS IN THIS FILE:
 
 8. Implemented UpdateCategory method to update category details.
 
-9. Implemented DeleteCategory method to delete a category if it has no associated events.
+9. Implemented DeleteCategory method to delete a category if it has no associated events, or everything under it with ?cascade=true.
 
 10. Used echo.Context for handling HTTP requests and responses.
 
@@ -58,32 +59,43 @@ I DID THE FOLLOWING THINGS IN THIS FILE:
 
 12. Interacted with CategoryStore for data operations.
 
+13. Implemented GetCategoryBySlug method to fetch a category by its URL slug.
+
+14. Recorded cascade deletes in the AUDIT LOG.
+
+15. The controller depends on store REPOSITORY interfaces instead of the concrete stores.
+
 ********************************* NOTE ************************************/
 
 type CategoryController struct {
-	categoryStore *store.CategoryStore
+	categoryStore store.CategoryRepository
+	auditStore    store.AuditRepository
 }
 
-func NewCategoryController(categoryStore *store.CategoryStore) *CategoryController {
+func NewCategoryController(categoryStore store.CategoryRepository, auditStore store.AuditRepository) *CategoryController {
 	return &CategoryController{
 		categoryStore: categoryStore,
+		auditStore:    auditStore,
 	}
 }
 
 // CreateCategory creates a new category
 func (cc *CategoryController) CreateCategory(c echo.Context) error {
-	var category models.Category
+	var req dto.CreateCategoryRequest
 
-	if err := c.Bind(&category); err != nil {
+	if err := c.Bind(&req); err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
 	}
 
 	// Validate
-	if category.Name == "" {
+	if req.Name == "" {
 		return echo.NewHTTPError(http.StatusBadRequest, "Category name is required")
 	}
 
-	if err := cc.categoryStore.CreateCategory(c.Request().Context(), &category); err != nil {
+	//? Slug is always generated from the name
+	category := req.ToModel()
+
+	if err := cc.categoryStore.CreateCategory(c.Request().Context(), category); err != nil {
 		println("error creating categories FROM CATEGORY", err.Error())
 		return echo.NewHTTPError(http.StatusInternalServerError, "cannot create category")
 	}
@@ -110,6 +122,10 @@ func (cc *CategoryController) GetAllCategoriesWithEvents(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusInternalServerError, "cannot fetch categories with events")
 	}
 
+	for i := range categories {
+		toPublicEvents(categories[i].Events)
+	}
+
 	return c.JSON(http.StatusOK, categories)
 }
 
@@ -131,6 +147,19 @@ func (cc *CategoryController) GetCategoryByID(c echo.Context) error {
 	return c.JSON(http.StatusOK, category)
 }
 
+// GetCategoryBySlug retrieves a single category by its slug
+func (cc *CategoryController) GetCategoryBySlug(c echo.Context) error {
+	slug := c.Param("slug") //! GET PARAM
+
+	category, err := cc.categoryStore.GetCategoryBySlug(c.Request().Context(), slug)
+	if err != nil {
+		println("error getting category by slug FROM CATEGORY", err.Error())
+		return echo.NewHTTPError(http.StatusNotFound, "Category not found")
+	}
+
+	return c.JSON(http.StatusOK, category)
+}
+
 // GetCategoryWithEvents retrieves a category with all its events
 func (cc *CategoryController) GetCategoryWithEvents(c echo.Context) error {
 	categoryID := c.Param("id")
@@ -146,6 +175,8 @@ func (cc *CategoryController) GetCategoryWithEvents(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusNotFound, "Category not found")
 	}
 
+	toPublicEvents(categoryWithEvents.Events)
+
 	return c.JSON(http.StatusOK, categoryWithEvents)
 }
 
@@ -177,6 +208,8 @@ func (cc *CategoryController) GetEventsByCategoryName(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusInternalServerError, "cannot fetch events for category")
 	}
 
+	toPublicEvents(categoryWithEvents.Events)
+
 	return c.JSON(http.StatusOK, categoryWithEvents)
 }
 
@@ -189,23 +222,14 @@ func (cc *CategoryController) UpdateCategory(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid category ID")
 	}
 
-	var updates struct {
-		Name        string `json:"name"`
-		Description string `json:"description"`
-	}
+	var updates dto.UpdateCategoryRequest
 
 	if err := c.Bind(&updates); err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
 	}
 
 	//! Build update map
-	updateMap := bson.M{}
-	if updates.Name != "" {
-		updateMap["name"] = updates.Name
-	}
-	if updates.Description != "" {
-		updateMap["description"] = updates.Description
-	}
+	updateMap := updates.ToUpdate()
 
 	if len(updateMap) == 0 {
 		return echo.NewHTTPError(http.StatusBadRequest, "No fields to update")
@@ -219,7 +243,7 @@ func (cc *CategoryController) UpdateCategory(c echo.Context) error {
 	return c.JSON(http.StatusOK, map[string]string{"message": "Category updated successfully"})
 }
 
-// DeleteCategory deletes a category and all its associated events and bookings (CASCADE)
+// DeleteCategory deletes an empty category, or with ?cascade=true the category and all its events and bookings
 func (cc *CategoryController) DeleteCategory(c echo.Context) error {
 	categoryID := c.Param("id")
 
@@ -228,13 +252,40 @@ func (cc *CategoryController) DeleteCategory(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid category ID")
 	}
 
+	//! The destructive path must be confirmed explicitly
+	if c.QueryParam("cascade") != "true" {
+		if err := cc.categoryStore.DeleteCategory(c.Request().Context(), objID); err != nil {
+			println("error deleting category FROM CATEGORY", err.Error())
+			return echo.NewHTTPError(http.StatusBadRequest, "Failed to delete category: "+err.Error()+" (use ?cascade=true to delete its events and bookings too)")
+		}
+
+		return c.JSON(http.StatusOK, map[string]string{
+			"message": "Category deleted successfully",
+		})
+	}
+
+	//? Snapshot the category and its events for the audit log
+	before, err := cc.categoryStore.GetCategoryWithEvents(c.Request().Context(), objID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Category not found")
+	}
+
 	//! cascade delete to remove category, events, and bookings
 	if err := cc.categoryStore.DeleteCategoryWithCascade(c.Request().Context(), objID); err != nil {
 		println("error deleting category FROM CATEGORY", err.Error())
 		return echo.NewHTTPError(http.StatusBadRequest, "Failed to delete category: "+err.Error())
 	}
 
+	recordAudit(c, cc.auditStore, models.AuditCategoryCascadeDeleted, "category", objID, before, nil)
+
 	return c.JSON(http.StatusOK, map[string]string{
 		"message": "Category and all associated events deleted successfully",
 	})
 }
+
+// toPublicEvents prepares a list of category events for public responses
+func toPublicEvents(events []models.Event) {
+	for i := range events {
+		toPublicEvent(&events[i])
+	}
+}
@@ -0,0 +1,155 @@
+package controllers
+
+import (
+	"event-horizon/dto"
+	"event-horizon/middleware"
+	"event-horizon/realtime"
+	"event-horizon/services"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+//! THIS FILE HANDLES CHECK-IN AT THE DOOR (LIVE COUNTS AND SCANNED CODES)
+
+/******************************* NOTE **************************************
+
+I DID THE FOLLOWING THINGS IN THIS FILE:
+
+1. Created CheckInController struct, the rules are in services.CheckInService.
+
+2. Implemented GetCheckIns method for polling the door counts per ticket type.
+
+3. Implemented StreamCheckIns method that pushes the door counts with Server-Sent Events after every check-in.
+
+4. Implemented CheckIn method that takes one or many scanned codes (offline scans are synced in bulk).
+
+5. Every method also takes door devices with a SCANNER KEY of the event instead of a login (see currentDoor).
+
+********************************* NOTE ************************************/
+
+type CheckInController struct {
+	checkIns *services.CheckInService
+	hub      *realtime.Hub //? Check-in hub, not the public event hub
+}
+
+func NewCheckInController(checkInService *services.CheckInService, hub *realtime.Hub) *CheckInController {
+	return &CheckInController{
+		checkIns: checkInService,
+		hub:      hub,
+	}
+}
+
+// currentDoor returns who works the door, the scanner key of the request or the logged in user
+func currentDoor(c echo.Context) (services.Door, error) {
+	if key := middleware.ScannerKeyFromContext(c); key != nil {
+		return services.Door{Key: key}, nil
+	}
+
+	userObjID, err := currentUserID(c)
+	if err != nil {
+		return services.Door{}, err
+	}
+	return services.Door{UserID: userObjID}, nil
+}
+
+// GetCheckIns returns the door counts of an event (event host, co-hosts and door staff only)
+func (cntrlr *CheckInController) GetCheckIns(c echo.Context) error {
+	door, err := currentDoor(c)
+	if err != nil {
+		return err
+	}
+
+	stats, err := cntrlr.checkIns.Stats(c.Request().Context(), door, c.Param("id"))
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, stats)
+}
+
+// ! StreamCheckIns streams the door counts of an event using Server-Sent Events
+func (cntrlr *CheckInController) StreamCheckIns(c echo.Context) error {
+	door, err := currentDoor(c)
+	if err != nil {
+		return err
+	}
+	ctx := c.Request().Context()
+
+	//? Checks the door belongs to the event before opening the stream
+	stats, err := cntrlr.checkIns.Stats(ctx, door, c.Param("id"))
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	updates, unsubscribe := cntrlr.hub.Subscribe(stats.EventID.Hex())
+	defer unsubscribe()
+
+	//? SSE headers
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set(echo.HeaderCacheControl, "no-cache")
+	res.Header().Set(echo.HeaderConnection, "keep-alive")
+	res.WriteHeader(http.StatusOK)
+
+	//? Send the current counts first so the door screen starts in sync
+	if err := writeSSE(res, realtime.Update{
+		Type:     realtime.UpdateCheckIns,
+		EventID:  stats.EventID.Hex(),
+		CheckIns: stats,
+	}); err != nil {
+		return nil
+	}
+
+	//! Keep-alive so proxies don't close idle connections
+	keepAlive := time.NewTicker(30 * time.Second)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(res, ": keep-alive\n\n"); err != nil {
+				return nil
+			}
+			res.Flush()
+		case update := <-updates:
+			if err := writeSSE(res, update); err != nil {
+				return nil
+			}
+		}
+	}
+}
+
+// CheckIn checks in the scanned codes and answers one result per code
+func (cntrlr *CheckInController) CheckIn(c echo.Context) error {
+	door, err := currentDoor(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.CheckInRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request payload")
+	}
+
+	results, stats, err := cntrlr.checkIns.CheckIn(c.Request().Context(), door, c.Param("id"), &req)
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	//? Push the new counts to every door screen of the event
+	cntrlr.hub.Publish(realtime.Update{
+		Type:     realtime.UpdateCheckIns,
+		EventID:  stats.EventID.Hex(),
+		CheckIns: stats,
+	})
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"results":   results,
+		"check_ins": stats,
+	})
+}
@@ -0,0 +1,72 @@
+package controllers
+
+import (
+	"event-horizon/store"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+//! THIS FILE LETS ADMINS SEE WHAT THE EXPIRED EVENT CLEANUP WILL DELETE BEFORE IT DOES
+
+/******************************* NOTE **************************************
+
+I DID THE FOLLOWING THINGS IN THIS FILE:
+
+1. Created CleanupController with the retention policy the cleanup scheduler runs with.
+
+2. Implemented GetCleanupPreview, a DRY RUN listing the events (and how many bookings) the next cleanup deletes, nothing is deleted.
+
+********************************* NOTE ************************************/
+
+// Cleanup preview sizes
+const (
+	defaultCleanupPreviewLimit = 100
+	maxCleanupPreviewLimit     = 1000
+)
+
+type CleanupController struct {
+	eventStore store.EventRepository
+	policy     store.CleanupPolicy
+}
+
+func NewCleanupController(eventStore store.EventRepository, policy store.CleanupPolicy) *CleanupController {
+	return &CleanupController{
+		eventStore: eventStore,
+		policy:     policy,
+	}
+}
+
+// GetCleanupPreview lists what the expired event cleanup would delete right now, ?limit= caps the list (admin only)
+func (cntrlr *CleanupController) GetCleanupPreview(c echo.Context) error {
+	limit := defaultCleanupPreviewLimit
+	if raw := c.QueryParam("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 || parsed > maxCleanupPreviewLimit {
+			return echo.NewHTTPError(http.StatusBadRequest, "limit must be between 1 and 1000")
+		}
+		limit = parsed
+	}
+
+	events, total, err := cntrlr.eventStore.GetExpiredEvents(c.Request().Context(), cntrlr.policy, limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to load expired events")
+	}
+
+	var bookings int64
+	for _, event := range events {
+		bookings += event.Bookings
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"dry_run":        true,
+		"retention_days": int(cntrlr.policy.Retention / (24 * time.Hour)),
+		"batch_size":     cntrlr.policy.BatchSize,
+		"ended_before":   cntrlr.policy.Cutoff(time.Now()),
+		"total_events":   total,
+		"events":         events,
+		"bookings":       bookings, //? Of the listed events only
+	})
+}
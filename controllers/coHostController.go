@@ -0,0 +1,166 @@
+package controllers
+
+import (
+	"event-horizon/models"
+	"event-horizon/store"
+	"event-horizon/utils"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+//! THIS FILE HANDLES HTTP REQUESTS RELATED TO EVENT CO-HOSTS AND SEND RESPONSES TO THE CLIENTS
+
+/******************************* NOTE **************************************
+
+I DID THE FOLLOWING THINGS IN THIS FILE:
+
+1. Created CoHostController struct to manage event co-host invites.
+
+2. Implemented InviteCoHost method so the event host can invite another host by email (the invitee gets a notification).
+
+3. Implemented AcceptCoHostInvite method so the invited user becomes a co-host.
+
+4. Implemented RemoveCoHost method so the host can remove a co-host, or a co-host can leave the event.
+
+5. currentUserID and currentUserEmail are the only way controllers read the authenticated user, both from the claims JWTMiddleware stored.
+
+********************************* NOTE ************************************/
+
+type CoHostController struct {
+	eventStore store.EventRepository
+	userStore  store.UserRepository
+	notifier   *utils.NotificationWorker
+}
+
+func NewCoHostController(eventStore store.EventRepository, userStore store.UserRepository, notifier *utils.NotificationWorker) *CoHostController {
+	return &CoHostController{
+		eventStore: eventStore,
+		userStore:  userStore,
+		notifier:   notifier,
+	}
+}
+
+// currentUserID returns the authenticated user's ObjectID
+func currentUserID(c echo.Context) (bson.ObjectID, error) {
+	userID, err := utils.GetUserIDFromToken(c)
+	if err != nil {
+		return bson.NilObjectID, echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	userObjID, err := bson.ObjectIDFromHex(userID)
+	if err != nil {
+		return bson.NilObjectID, echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	return userObjID, nil
+}
+
+// currentUserEmail returns the authenticated user's email from the claims JWTMiddleware stored
+func currentUserEmail(c echo.Context) (string, error) {
+	claims, err := utils.GetClaimsFromToken(c)
+	if err != nil || claims.Email == "" {
+		return "", echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+	}
+	return claims.Email, nil
+}
+
+// InviteCoHost invites a host (by email) to co-host the event (event host only)
+func (cntrlr *CoHostController) InviteCoHost(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userObjID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	event, err := cntrlr.eventStore.GetEventByID(ctx, c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Event not found")
+	}
+
+	//! Only the primary host manages the team
+	if event.HostID != userObjID {
+		return echo.NewHTTPError(http.StatusForbidden, "Only the event host can invite co-hosts")
+	}
+
+	var req struct {
+		Email string `json:"email" validate:"required,email"`
+	}
+	if err := c.Bind(&req); err != nil || req.Email == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "email is required")
+	}
+
+	invitee, err := cntrlr.userStore.FindUserByEmail(ctx, req.Email)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "User not found")
+	}
+
+	if !invitee.IsHost {
+		return echo.NewHTTPError(http.StatusBadRequest, "Only hosts can be invited as co-hosts")
+	}
+
+	if err := cntrlr.eventStore.InviteCoHost(ctx, event.ID, invitee.ID); err != nil {
+		return echo.NewHTTPError(http.StatusConflict, err.Error())
+	}
+
+	cntrlr.notifier.NotifyUser(invitee.ID, models.NotificationCoHostInvite, "You were invited to co-host "+event.Name, event.ID)
+
+	return c.JSON(http.StatusCreated, map[string]string{
+		"message": "Co-host invited successfully",
+	})
+}
+
+// AcceptCoHostInvite makes the authenticated user a co-host of the event they were invited to
+func (cntrlr *CoHostController) AcceptCoHostInvite(c echo.Context) error {
+	userObjID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	eventObjID, err := bson.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid event ID")
+	}
+
+	if err := cntrlr.eventStore.AcceptCoHostInvite(c.Request().Context(), eventObjID, userObjID); err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{
+		"message": "You are now a co-host of this event",
+	})
+}
+
+// RemoveCoHost removes a co-host (event host), or lets a co-host leave the event
+func (cntrlr *CoHostController) RemoveCoHost(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userObjID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	coHostObjID, err := bson.ObjectIDFromHex(c.Param("userId"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	event, err := cntrlr.eventStore.GetEventByID(ctx, c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Event not found")
+	}
+
+	if event.HostID != userObjID && coHostObjID != userObjID {
+		return echo.NewHTTPError(http.StatusForbidden, "Only the event host can remove co-hosts")
+	}
+
+	if err := cntrlr.eventStore.RemoveCoHost(ctx, event.ID, coHostObjID); err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{
+		"message": "Co-host removed successfully",
+	})
+}
@@ -0,0 +1,156 @@
+package controllers
+
+import (
+	"event-horizon/dto"
+	"event-horizon/models"
+	"event-horizon/services"
+	"event-horizon/store"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+//! THIS FILE HANDLES THE Q&A COMMENTS ON EVENT PAGES AND THE ADMIN REVIEW OF REPORTED COMMENTS
+
+/******************************* NOTE **************************************
+
+I DID THE FOLLOWING THINGS IN THIS FILE:
+
+1. Created CommentController struct, the rules are in services.CommentService.
+
+2. Implemented GetComments method for a page of comments with their replies (?limit=, ?offset=).
+
+3. Implemented CreateComment, DeleteComment and ReportComment methods for signed in users.
+
+4. Implemented GetReportedComments and AdminDeleteComment methods for admins, hidden and deleted comments go to the audit log.
+
+********************************* NOTE ************************************/
+
+type CommentController struct {
+	comments   *services.CommentService
+	auditStore store.AuditRepository
+}
+
+func NewCommentController(commentService *services.CommentService, auditStore store.AuditRepository) *CommentController {
+	return &CommentController{
+		comments:   commentService,
+		auditStore: auditStore,
+	}
+}
+
+// GetComments returns a page of the comments of an event, newest questions first
+func (cntrlr *CommentController) GetComments(c echo.Context) error {
+	var limit, offset int
+	for name, target := range map[string]*int{"limit": &limit, "offset": &offset} {
+		if value := c.QueryParam(name); value != "" {
+			var err error
+			if *target, err = strconv.Atoi(value); err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, name+" must be a number")
+			}
+		}
+	}
+
+	page, err := cntrlr.comments.List(c.Request().Context(), c.Param("id"), limit, offset)
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, page)
+}
+
+// CreateComment posts a comment, or a reply when parent_id is set
+func (cntrlr *CommentController) CreateComment(c echo.Context) error {
+	userObjID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.CommentRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request payload")
+	}
+
+	comment, err := cntrlr.comments.Post(c.Request().Context(), userObjID, c.Param("id"), &req)
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, comment)
+}
+
+// DeleteComment deletes a comment with its replies (the author or the event host and co-hosts)
+func (cntrlr *CommentController) DeleteComment(c echo.Context) error {
+	userObjID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	if err := cntrlr.comments.Delete(c.Request().Context(), userObjID, c.Param("id"), c.Param("commentId")); err != nil {
+		return serviceError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{
+		"message": "Comment deleted",
+	})
+}
+
+// ReportComment flags a comment for the admins
+func (cntrlr *CommentController) ReportComment(c echo.Context) error {
+	userObjID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.CommentReportRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request payload")
+	}
+
+	hidden, err := cntrlr.comments.Report(c.Request().Context(), userObjID, c.Param("id"), c.Param("commentId"), &req)
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	if hidden {
+		commentID, _ := bson.ObjectIDFromHex(c.Param("commentId"))
+		recordAudit(c, cntrlr.auditStore, models.AuditCommentAutoHidden, "comment", commentID, nil, nil)
+	}
+
+	return c.JSON(http.StatusCreated, map[string]string{
+		"message": "Comment reported, thank you",
+	})
+}
+
+// GetReportedComments returns the reported comments, most reported first (admin only)
+func (cntrlr *CommentController) GetReportedComments(c echo.Context) error {
+	limit, err := parseLimit(c)
+	if err != nil {
+		return err
+	}
+
+	reported, err := cntrlr.comments.Reported(c.Request().Context(), limit)
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"reported_comments": reported,
+		"count":             len(reported),
+	})
+}
+
+// AdminDeleteComment deletes any comment with its replies (admin only)
+func (cntrlr *CommentController) AdminDeleteComment(c echo.Context) error {
+	comment, err := cntrlr.comments.AdminDelete(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	recordAudit(c, cntrlr.auditStore, models.AuditCommentDeleted, "comment", comment.ID, comment, nil)
+
+	return c.JSON(http.StatusOK, map[string]string{
+		"message": "Comment deleted",
+	})
+}
@@ -0,0 +1,73 @@
+package controllers
+
+import (
+	"event-horizon/dto"
+	"event-horizon/services"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+//! THIS FILE HANDLES THE TERMS OF SERVICE AND PRIVACY POLICY ACCEPTANCE
+
+/******************************* NOTE **************************************
+
+I DID THE FOLLOWING THINGS IN THIS FILE:
+
+1. Created ConsentController struct, the rules are in services.ConsentService.
+
+2. Implemented GetPolicies method, the current versions for the sign up and checkout forms (public).
+
+3. Implemented GetConsents and AcceptPolicies methods, the frontend checks after sign in whether the user has to accept the documents again.
+
+********************************* NOTE ************************************/
+
+type ConsentController struct {
+	consents *services.ConsentService
+}
+
+func NewConsentController(consentService *services.ConsentService) *ConsentController {
+	return &ConsentController{
+		consents: consentService,
+	}
+}
+
+// GetPolicies returns the versions of the terms and privacy policy sign ups and bookings must accept
+func (cntrlr *ConsentController) GetPolicies(c echo.Context) error {
+	return c.JSON(http.StatusOK, cntrlr.consents.Current())
+}
+
+// GetConsents returns the authenticated user's latest acceptances and whether they must accept the documents again
+func (cntrlr *ConsentController) GetConsents(c echo.Context) error {
+	userObjID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	status, err := cntrlr.consents.Status(c.Request().Context(), userObjID)
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, status)
+}
+
+// AcceptPolicies records the authenticated user accepting the current versions
+func (cntrlr *ConsentController) AcceptPolicies(c echo.Context) error {
+	userObjID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.PolicyAcceptance
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request payload")
+	}
+
+	status, err := cntrlr.consents.Accept(c.Request().Context(), userObjID, req, c.RealIP())
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, status)
+}
@@ -0,0 +1,60 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+//! THIS FILE SERVES THE OPENAPI SPECIFICATION AND THE SWAGGER UI
+
+/******************************* NOTE **************************************
+
+I DID THE FOLLOWING THINGS IN THIS FILE:
+
+1. Created DocsController struct holding the embedded OpenAPI document.
+
+2. Implemented GetSpec method to serve the raw OpenAPI YAML.
+
+3. Implemented GetSwaggerUI method to serve an interactive Swagger UI page for the spec.
+
+********************************* NOTE ************************************/
+
+type DocsController struct {
+	spec    []byte
+	specURL string
+}
+
+func NewDocsController(spec []byte, specURL string) *DocsController {
+	return &DocsController{
+		spec:    spec,
+		specURL: specURL,
+	}
+}
+
+// GetSpec serves the OpenAPI document
+func (cntrlr *DocsController) GetSpec(c echo.Context) error {
+	return c.Blob(http.StatusOK, "application/yaml", cntrlr.spec)
+}
+
+// GetSwaggerUI serves a Swagger UI page that loads the OpenAPI document
+func (cntrlr *DocsController) GetSwaggerUI(c echo.Context) error {
+	//? Swagger UI assets come from the CDN so nothing extra ships with the binary
+	page := `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8" />
+  <title>Event Horizon API Docs</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css" />
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+  <script>
+    window.ui = SwaggerUIBundle({ url: "` + cntrlr.specURL + `", dom_id: "#swagger-ui" });
+  </script>
+</body>
+</html>`
+
+	return c.HTML(http.StatusOK, page)
+}
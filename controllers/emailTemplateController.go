@@ -0,0 +1,57 @@
+package controllers
+
+import (
+	"event-horizon/services"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+//! THIS FILE HANDLES THE EMAIL TEMPLATE PREVIEWS OF ADMINS
+
+/******************************* NOTE **************************************
+
+I DID THE FOLLOWING THINGS IN THIS FILE:
+
+1. Created EmailTemplateController struct, the registry lives in the templates package.
+
+2. Implemented GetEmailTemplates method to list every email with its locales.
+
+3. Implemented PreviewEmailTemplate method: the email rendered with sample data, as JSON or as the bare HTML / text body (?format=).
+
+********************************* NOTE ************************************/
+
+type EmailTemplateController struct {
+	emailTemplates *services.EmailTemplateService
+}
+
+func NewEmailTemplateController(emailTemplateService *services.EmailTemplateService) *EmailTemplateController {
+	return &EmailTemplateController{
+		emailTemplates: emailTemplateService,
+	}
+}
+
+// GetEmailTemplates lists every email template with the locales it is translated to
+func (cntrlr *EmailTemplateController) GetEmailTemplates(c echo.Context) error {
+	return c.JSON(http.StatusOK, cntrlr.emailTemplates.List())
+}
+
+// PreviewEmailTemplate renders an email template with sample data in the ?locale= (default en)
+func (cntrlr *EmailTemplateController) PreviewEmailTemplate(c echo.Context) error {
+	preview, err := cntrlr.emailTemplates.Preview(c.Param("name"), c.QueryParam("locale"))
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	//? The bare bodies open straight in a browser
+	switch c.QueryParam("format") {
+	case "", "json":
+		return c.JSON(http.StatusOK, preview)
+	case "html":
+		return c.HTML(http.StatusOK, preview.HTML)
+	case "text":
+		return c.String(http.StatusOK, preview.Text)
+	default:
+		return echo.NewHTTPError(http.StatusBadRequest, "format must be json, html or text")
+	}
+}
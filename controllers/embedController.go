@@ -0,0 +1,70 @@
+package controllers
+
+import (
+	"event-horizon/services"
+	"event-horizon/utils"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+//! THIS FILE SERVES THE EMBEDDABLE EVENT WIDGET (CORS-OPEN, SEE middleware.EmbedCORS)
+
+/******************************* NOTE **************************************
+
+I DID THE FOLLOWING THINGS IN THIS FILE:
+
+1. Created EmbedController struct, the widget is built in services.EmbedService.
+
+2. Implemented GetEmbedEvents method: ?host_id= and ?category= pick the events, ?format=html returns a ready-made snippet instead of JSON.
+
+3. Responses carry an ETag and may be cached for 5 minutes by browsers and CDNs.
+
+********************************* NOTE ************************************/
+
+type EmbedController struct {
+	embeds *services.EmbedService
+}
+
+func NewEmbedController(embedService *services.EmbedService) *EmbedController {
+	return &EmbedController{
+		embeds: embedService,
+	}
+}
+
+// GetEmbedEvents returns the upcoming events of the widget as JSON or as an HTML snippet
+func (cntrlr *EmbedController) GetEmbedEvents(c echo.Context) error {
+	format := c.QueryParam("format")
+	if format != "" && format != "json" && format != "html" {
+		return echo.NewHTTPError(http.StatusBadRequest, "format must be json or html")
+	}
+
+	limit, err := parseLimit(c)
+	if err != nil {
+		return err
+	}
+
+	events, err := cntrlr.embeds.Events(c.Request().Context(), c.QueryParam("host_id"), c.QueryParam("category"), limit)
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	//? 304 when the embedding page's copy is still fresh
+	c.Response().Header().Set(echo.HeaderCacheControl, "public, max-age=300")
+	versions := make([]utils.ETagVersion, 0, len(events))
+	for _, event := range events {
+		versions = append(versions, utils.ETagVersion{ID: event.ID.Hex(), UpdatedAt: event.UpdatedAt})
+	}
+	if notModified(c, versions) {
+		return c.NoContent(http.StatusNotModified)
+	}
+
+	if format == "html" {
+		snippet, err := cntrlr.embeds.HTML(events)
+		if err != nil {
+			return serviceError(c, err)
+		}
+		return c.HTMLBlob(http.StatusOK, snippet)
+	}
+	return c.JSON(http.StatusOK, events)
+}
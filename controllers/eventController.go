@@ -1,13 +1,22 @@
 package controllers
 
 import (
+	"encoding/json"
+	"errors"
+	"event-horizon/dto"
 	"event-horizon/models"
+	"event-horizon/realtime"
+	"event-horizon/services"
 	"event-horizon/store"
 	"event-horizon/utils"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/v2/bson"
 )
 
 /******** ECHO FRAMEWORK FUNCTIONALITY ***********
@@ -45,115 +54,273 @@ I DID THE FOLLOWING THINGS IN THIS FILE:
 
 7. Created UpdateEvent method to handle event update requests, ensuring only HOSTS can update their own events.
 
+8. Hooked CreateEvent into the NotificationWorker so FOLLOWERS of the host are notified of new events.
+
+9. Added StreamEventUpdates method to push LIVE ticket availability and event updates over Server-Sent Events.
+
+10. Added prepareSessions helper so MULTI-SESSION events get session IDs, capacities and an overall start/end time.
+
+11. Added GetNearbyEvents method to find events around a LAT/LNG within a radius.
+
+12. Added GetJoinLink method so ONLY attendees with confirmed bookings (or the host) get the stream URL of online events, close to start time.
+
+13. Added GetPopularTags method and ?tags= filtering on GetAllEvents.
+
+14. Recorded event creation and deletion in the AUDIT LOG.
+
+15. Switched CreateEvent and UpdateEvent to bind REQUEST DTOs, so clients can't set server owned fields like ID, HostID or CreatedAt.
+
+16. Added PatchEvent method for PARTIAL updates, only the sent fields are $set (ticket availability is recomputed by the store from what was already sold).
+
+17. Added DuplicateEvent method to copy an event into a new DRAFT with new dates, and PublishEvent method to publish it.
+
+18. CO-HOSTS can update, delete and publish events and get the join link, just like the host.
+
+19. With MODERATION enabled, created and published events wait for admin review and followers are only notified after approval.
+
+20. GetAllEvents accepts ?fields= and then returns slim EventSummary items built from a Mongo projection.
+
+21. GetEventByID and GetAllEvents send ETag (and Last-Modified for a single event) and answer 304 to conditional GETs.
+
+22. UpdateEvent requires the event VERSION the client read and answers 409 when someone else edited it first (PatchEvent optionally).
+
+23. The controller depends on store REPOSITORY interfaces (EventRepository, BookingRepository ...) so it can be tested with mocks.
+
+24. Moved the host checks and event validation into services.EventService, the write handlers only bind, call the service and respond (reads still go to the store).
+
+25. GetEventByID answers 404 for events of SUSPENDED hosts.
+
+26. Public responses leave out the host's CAPACITY ALERT settings and carry a STOCK FLAG (selling fast / almost sold out).
+
+27. Public responses and live updates leave out exact ticket counts when the host HIDES them.
+
+28. Implemented SchedulePublish and CancelScheduledPublish so drafts go live at a set time.
+
+29. Implemented RescheduleEvent, the response says how many bookings must be reconfirmed.
+
+30. The host's email comes from the claims JWTMiddleware stored (currentUserEmail), the Authorization header is no longer parsed a second time.
+
+31. Added BulkEvents method for BULK actions on many events, deletions and cancellations are recorded in the AUDIT LOG one by one.
+
+32. Added BulkPrice method for PRICE CHANGES across many events, with a dry_run preview.
+
+33. Implemented GetCalendar method, the public platform calendar grouped by day.
+
 ********************************* NOTE ************************************/
 
 // EventController manages HTTP requests related to events!
 type EventController struct {
-	eventStore    *store.EventStore
-	categoryStore *store.CategoryStore
-	userStore     *store.UserStore
+	events     *services.EventService
+	eventStore store.EventRepository
+	auditStore store.AuditRepository
+	hub        *realtime.Hub
 }
 
 // NewEventController creates a new EventController.
-func NewEventController(eventStore *store.EventStore, categoryStore *store.CategoryStore, userStore *store.UserStore) *EventController {
+func NewEventController(eventService *services.EventService, eventStore store.EventRepository, auditStore store.AuditRepository, hub *realtime.Hub) *EventController {
 	return &EventController{
-		eventStore:    eventStore,
-		categoryStore: categoryStore,
-		userStore:     userStore,
+		events:     eventService,
+		eventStore: eventStore,
+		auditStore: auditStore,
+		hub:        hub,
 	}
 }
 
+// ! toPublicEvent prepares an event for public responses (local times, stock flag, no stream URL or host settings)
+func toPublicEvent(event *models.Event) {
+	utils.LocalizeEventTimes(event)
+	event.StreamURL = ""
+	event.CoHostInvites = nil
+	event.CapacityAlerts = nil
+	event.StockFlag = models.EventStockFlag(event.Tickets)
+	event.Tickets = event.PublicTickets()
+}
+
 // ! CreateEvent handles the creation of a new event
 func (cntrlr *EventController) CreateEvent(c echo.Context) error {
-	event := new(models.Event)
+	req := new(dto.CreateEventRequest)
 
-	//? Bind Request (gets name, description, location, date, category_name from JSON)
-	if err := c.Bind(event); err != nil {
+	//? Bind Request (only the fields a host is allowed to set)
+	if err := c.Bind(req); err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, map[string]interface{}{
 			"message": "Cannot bind event data",
 			"error":   err.Error(),
 		})
 	}
+	event := req.ToModel()
 
 	//? Get user email from JWT token
-	userEmail, err := utils.GetUserEmailFromToken(c)
+	userEmail, err := currentUserEmail(c)
 	if err != nil {
-		c.Logger().Error("TOKEN VALIDATION FAILED", err)
-		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized - Invalid token")
+		return err
 	}
 
-	ctx := c.Request().Context() //! CONTEXT FROM REQUEST
-
-	//? Get user from database to get user ID
-	user, err := cntrlr.userStore.FindUserByEmail(ctx, userEmail)
+	//? The service checks the host, validates the event and notifies followers
+	warnings, err := cntrlr.events.CreateEvent(c.Request().Context(), userEmail, event)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusUnauthorized, "User not found")
+		return serviceError(c, err)
 	}
 
-	//? Check if user is a host
-	if !user.IsHost {
-		return echo.NewHTTPError(http.StatusForbidden, "Only hosts can create events")
+	recordAudit(c, cntrlr.auditStore, models.AuditEventCreated, "event", event.ID, nil, event)
+
+	//? Convert to EventResponse (in the event's timezone) and send HTTP Response
+	utils.LocalizeEventTimes(event)
+	eventResponse := dto.NewEventResponse(event)
+	eventResponse.Warnings = warnings //? Possible duplicates, the event is created anyway
+
+	return c.JSON(http.StatusCreated, eventResponse)
+}
+
+// ! GetAllEvents retrieves and returns all events
+func (cntrlr *EventController) GetAllEvents(c echo.Context) error {
+	ctx := c.Request().Context() //! CONTEXT FROM REQUEST
+
+	//? Optional ?tags=a,b filter (events must have every tag)
+	tags := utils.ParseTagsQuery(c.QueryParam("tags"))
+
+	//? Optional ?fields=name,date,min_price returns slim summaries for cards and grids
+	if fieldsQuery := c.QueryParam("fields"); fieldsQuery != "" {
+		return cntrlr.getEventSummaries(c, tags, strings.Split(fieldsQuery, ","))
 	}
 
-	//? Set HostID from authenticated user
-	event.HostID = user.ID
+	//? Call the Store
+	events, err := cntrlr.eventStore.GetAllEvents(ctx, tags)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, map[string]interface{}{
+			"message": "Failed to retrieve events",
+			"error":   err.Error(),
+		})
+	}
 
-	//? Validate that category_name is provided
-	if event.CategoryName == "" {
-		return echo.NewHTTPError(http.StatusBadRequest, "category_name is required")
+	//? 304 when the client's copy of the listing is still fresh
+	versions := make([]utils.ETagVersion, 0, len(events))
+	for _, event := range events {
+		versions = append(versions, utils.ETagVersion{ID: event.ID.Hex(), UpdatedAt: event.LastModified()})
+	}
+	if notModified(c, versions) {
+		return c.NoContent(http.StatusNotModified)
 	}
 
-	//? Validate that event date is not in the past (compare dates only, not time)
-	today := time.Now().Truncate(24 * time.Hour)
-	eventDate := event.Date.Truncate(24 * time.Hour)
-	if eventDate.Before(today) {
-		return echo.NewHTTPError(http.StatusBadRequest, "event date cannot be in the past")
+	//? Show times in each event's own timezone and hide stream links
+	for _, event := range events {
+		toPublicEvent(event)
 	}
 
-	//? Validate that end_time is after start_time
-	if event.EndTime.Before(event.StartTime) || event.EndTime.Equal(event.StartTime) {
-		return echo.NewHTTPError(http.StatusBadRequest, "end time must be after start time")
+	//? Send HTTP Response
+	return c.JSON(http.StatusOK, events)
+}
+
+// notModified sets the ETag of a listing and reports whether the client can reuse its copy.
+// Listings get no Last-Modified, a deleted event would not move it forward.
+func notModified(c echo.Context, versions []utils.ETagVersion) bool {
+	etag := utils.BuildETag(c.Request().URL.RawQuery, versions...)
+	return utils.CheckNotModified(c, etag, time.Time{})
+}
+
+// getEventSummaries answers GetAllEvents with only the requested fields
+func (cntrlr *EventController) getEventSummaries(c echo.Context, tags []string, fields []string) error {
+	for i := range fields {
+		fields[i] = strings.TrimSpace(fields[i])
 	}
 
-	//? Create the event in database (CategoryID lookup happens in store)
-	if err := cntrlr.eventStore.CreateEvent(ctx, event); err != nil {
+	summaries, err := cntrlr.eventStore.GetEventSummaries(c.Request().Context(), tags, fields)
+	if err != nil {
+		if errors.Is(err, store.ErrUnknownEventField) {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
 		return echo.NewHTTPError(http.StatusInternalServerError, map[string]interface{}{
-			"message": "Failed to create event",
+			"message": "Failed to retrieve events",
 			"error":   err.Error(),
 		})
 	}
 
-	//? Convert to EventResponse and send HTTP Response
-	eventResponse := &models.EventResponse{
-		ID:           event.ID,
-		Name:         event.Name,
-		HostID:       event.HostID,
-		CategoryName: event.CategoryName,
-		Date:         event.Date,
-		Location:     event.Location,
-		Tickets:      event.Tickets,
+	versions := make([]utils.ETagVersion, 0, len(summaries))
+	for _, summary := range summaries {
+		versions = append(versions, utils.ETagVersion{ID: summary.ID.Hex(), UpdatedAt: summary.UpdatedAt})
+	}
+	if notModified(c, versions) {
+		return c.NoContent(http.StatusNotModified)
+	}
+
+	for _, summary := range summaries {
+		utils.LocalizeSummaryTimes(summary)
 	}
 
-	return c.JSON(http.StatusCreated, eventResponse)
+	return c.JSON(http.StatusOK, summaries)
 }
 
-// ! GetAllEvents retrieves and returns all events
-func (cntrlr *EventController) GetAllEvents(c echo.Context) error {
+// ! GetCalendar returns the public events from ?from= to ?to= grouped by day, optionally of one ?category= and in a ?timezone=
+func (cntrlr *EventController) GetCalendar(c echo.Context) error {
+	calendar, err := cntrlr.events.Calendar(c.Request().Context(),
+		c.QueryParam("from"), c.QueryParam("to"), c.QueryParam("timezone"), c.QueryParam("category"))
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, calendar)
+}
+
+// ! GetNearbyEvents returns events within radius_km of the given lat/lng, closest first
+func (cntrlr *EventController) GetNearbyEvents(c echo.Context) error {
 	ctx := c.Request().Context() //! CONTEXT FROM REQUEST
 
-	//? Call the Store
-	events, err := cntrlr.eventStore.GetAllEvents(ctx)
+	lat, err := strconv.ParseFloat(c.QueryParam("lat"), 64)
+	if err != nil || lat < -90 || lat > 90 {
+		return echo.NewHTTPError(http.StatusBadRequest, "lat must be a number between -90 and 90")
+	}
+
+	lng, err := strconv.ParseFloat(c.QueryParam("lng"), 64)
+	if err != nil || lng < -180 || lng > 180 {
+		return echo.NewHTTPError(http.StatusBadRequest, "lng must be a number between -180 and 180")
+	}
+
+	//? Default radius is 10km
+	radiusKm := 10.0
+	if radiusParam := c.QueryParam("radius_km"); radiusParam != "" {
+		radiusKm, err = strconv.ParseFloat(radiusParam, 64)
+		if err != nil || radiusKm <= 0 {
+			return echo.NewHTTPError(http.StatusBadRequest, "radius_km must be a positive number")
+		}
+	}
+
+	events, err := cntrlr.eventStore.GetEventsNearby(ctx, lng, lat, radiusKm)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, map[string]interface{}{
-			"message": "Failed to retrieve events",
+			"message": "Failed to retrieve nearby events",
 			"error":   err.Error(),
 		})
 	}
 
-	//? Send HTTP Response
+	//? Show times in each event's own timezone and hide stream links
+	for i := range events {
+		toPublicEvent(&events[i].Event)
+	}
+
 	return c.JSON(http.StatusOK, events)
 }
 
+// ! GetPopularTags returns the most used event tags with their counts
+func (cntrlr *EventController) GetPopularTags(c echo.Context) error {
+	limit := 20
+	if limitParam := c.QueryParam("limit"); limitParam != "" {
+		parsed, err := strconv.Atoi(limitParam)
+		if err != nil || parsed <= 0 || parsed > 100 {
+			return echo.NewHTTPError(http.StatusBadRequest, "limit must be between 1 and 100")
+		}
+		limit = parsed
+	}
+
+	tags, err := cntrlr.eventStore.GetPopularTags(c.Request().Context(), limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, map[string]interface{}{
+			"message": "Failed to retrieve popular tags",
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, tags)
+}
+
 // ! GetEventByID retrieves and returns a specific event by its ID
 func (cntrlr *EventController) GetEventByID(c echo.Context) error {
 
@@ -169,137 +336,403 @@ func (cntrlr *EventController) GetEventByID(c echo.Context) error {
 		})
 	}
 
+	//? Events of suspended hosts are gone for the public
+	if event.HostSuspended {
+		return echo.NewHTTPError(http.StatusNotFound, "Event not found")
+	}
+
+	//? 304 when the client's copy is still fresh
+	etag := utils.BuildETag("", utils.ETagVersion{ID: event.ID.Hex(), UpdatedAt: event.LastModified()})
+	if utils.CheckNotModified(c, etag, event.LastModified()) {
+		return c.NoContent(http.StatusNotModified)
+	}
+
+	//? Show times in the event's own timezone and hide the stream link
+	toPublicEvent(event)
+
 	//? Send HTTP Response
 	return c.JSON(http.StatusOK, event)
 }
 
 // ! DeleteEvent deletes an event and all its associated bookings (HOST ONLY)
 func (cntrlr *EventController) DeleteEvent(c echo.Context) error {
-	id := c.Param("id")          //! GET ID FROM URL PARAMS
-	ctx := c.Request().Context() //! CONTEXT FROM REQUEST
-
 	//? Get user email from JWT token
-	userEmail, err := utils.GetUserEmailFromToken(c)
+	userEmail, err := currentUserEmail(c)
 	if err != nil {
-		c.Logger().Error("TOKEN VALIDATION FAILED", err)
-		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized - Invalid token")
+		return err
 	}
 
-	//? Get user from database to get user ID
-	user, err := cntrlr.userStore.FindUserByEmail(ctx, userEmail)
+	//? The service verifies OWNERSHIP and CASCADE deletes the bookings
+	event, err := cntrlr.events.DeleteEvent(c.Request().Context(), userEmail, c.Param("id"))
 	if err != nil {
-		return echo.NewHTTPError(http.StatusUnauthorized, "User not found")
+		return serviceError(c, err)
 	}
 
-	//? Check if user is a HOST
-	if !user.IsHost {
-		return echo.NewHTTPError(http.StatusForbidden, "Only hosts can delete events")
-	}
+	recordAudit(c, cntrlr.auditStore, models.AuditEventDeleted, "event", event.ID, event, nil)
 
-	//? Get the event to verify OWNERSHIP
-	event, err := cntrlr.eventStore.GetEventByID(ctx, id)
+	//? Tell live listeners the event is gone
+	cntrlr.hub.Publish(realtime.Update{
+		Type:    realtime.UpdateEventDeleted,
+		EventID: event.ID.Hex(),
+	})
+
+	return c.JSON(http.StatusOK, map[string]string{
+		"message": "Event and all associated bookings deleted successfully",
+	})
+}
+
+// ! UpdateEvent updates an event (host only)
+func (cntrlr *EventController) UpdateEvent(c echo.Context) error {
+	//? Get user email from JWT token
+	userEmail, err := currentUserEmail(c)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusNotFound, map[string]string{"error": "Event not found"})
+		return err
 	}
 
-	//? Verify that the user is the HOST of this EVENT
-	if event.HostID.Hex() != user.ID.Hex() {
-		return echo.NewHTTPError(http.StatusForbidden, map[string]interface{}{
-			"message": "You can only delete your own events",
-			"error":   "forbidden",
-		})
+	//? Bind the updated event data
+	req := new(dto.UpdateEventRequest)
+	if err := c.Bind(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Cannot bind event data")
+	}
+	if req.Version == nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "version is required, send the version of the event you are editing")
 	}
+	updatedEvent := req.ToModel()
 
-	//? Delete the event (and CASCADE delete bookings)
-	if err := cntrlr.eventStore.DeleteEvent(ctx, event.ID); err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, map[string]interface{}{
-			"message": "Failed to delete event",
-			"error":   err.Error(),
-		})
+	if err := cntrlr.events.UpdateEvent(c.Request().Context(), userEmail, c.Param("id"), updatedEvent, *req.Version); err != nil {
+		return serviceError(c, err)
 	}
 
-	return c.JSON(http.StatusOK, map[string]string{
-		"message": "Event and all associated bookings deleted successfully",
+	//? Push the change to live listeners
+	cntrlr.hub.Publish(realtime.Update{
+		Type:    realtime.UpdateEventUpdated,
+		EventID: updatedEvent.ID.Hex(),
+		Tickets: updatedEvent.PublicTickets(),
 	})
+
+	//? Convert to EventResponse (in the event's timezone) and send HTTP Response
+	utils.LocalizeEventTimes(updatedEvent)
+	eventResponse := dto.NewEventResponse(updatedEvent)
+
+	return c.JSON(http.StatusOK, eventResponse)
 }
 
-// ! UpdateEvent updates an event (host only)
-func (cntrlr *EventController) UpdateEvent(c echo.Context) error {
-	id := c.Param("id")          //! GET ID FROM URL PARAMS
-	ctx := c.Request().Context() //! CONTEXT FROM REQUEST
+// ! PatchEvent updates only the fields sent in the request (host only)
+func (cntrlr *EventController) PatchEvent(c echo.Context) error {
+	//? Get user email from JWT token
+	userEmail, err := currentUserEmail(c)
+	if err != nil {
+		return err
+	}
 
+	//? Bind the partial event data
+	req := new(dto.PatchEventRequest)
+	if err := c.Bind(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Cannot bind event data")
+	}
+
+	patchedEvent, err := cntrlr.events.PatchEvent(c.Request().Context(), userEmail, c.Param("id"), req)
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	//? Push the change to live listeners
+	cntrlr.hub.Publish(realtime.Update{
+		Type:    realtime.UpdateEventUpdated,
+		EventID: patchedEvent.ID.Hex(),
+		Tickets: patchedEvent.PublicTickets(),
+	})
+
+	//? Convert to EventResponse (in the event's timezone) and send HTTP Response
+	utils.LocalizeEventTimes(patchedEvent)
+
+	return c.JSON(http.StatusOK, dto.NewEventResponse(patchedEvent))
+}
+
+// ! DuplicateEvent copies one of the host's events into a new draft with new dates (host only)
+func (cntrlr *EventController) DuplicateEvent(c echo.Context) error {
 	//? Get user email from JWT token
-	userEmail, err := utils.GetUserEmailFromToken(c)
+	userEmail, err := currentUserEmail(c)
 	if err != nil {
-		c.Logger().Error("TOKEN VALIDATION FAILED", err)
-		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized - Invalid token")
+		return err
 	}
 
-	//? Get user from database to get user ID
-	user, err := cntrlr.userStore.FindUserByEmail(ctx, userEmail)
+	//? Bind the new dates
+	req := new(dto.DuplicateEventRequest)
+	if err := c.Bind(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Cannot bind event data")
+	}
+
+	event, err := cntrlr.events.DuplicateEvent(c.Request().Context(), userEmail, c.Param("id"), req)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusUnauthorized, "User not found")
+		return serviceError(c, err)
 	}
 
-	//? Check if user is a host
-	if !user.IsHost {
-		return echo.NewHTTPError(http.StatusForbidden, "Only hosts can update events")
+	recordAudit(c, cntrlr.auditStore, models.AuditEventCreated, "event", event.ID, nil, event)
+
+	utils.LocalizeEventTimes(event)
+
+	return c.JSON(http.StatusCreated, dto.NewEventResponse(event))
+}
+
+// ! SchedulePublish sets when one of the host's drafts goes live (host and co-hosts)
+func (cntrlr *EventController) SchedulePublish(c echo.Context) error {
+	userObjID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	req := new(dto.SchedulePublishRequest)
+	if err := c.Bind(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Cannot bind schedule data")
+	}
+	if req.PublishAt.IsZero() {
+		return echo.NewHTTPError(http.StatusBadRequest, "publish_at is required")
 	}
 
-	//? Get the event to verify ownership
-	existingEvent, err := cntrlr.eventStore.GetEventByID(ctx, id)
+	event, err := cntrlr.events.SchedulePublish(c.Request().Context(), userObjID, c.Param("id"), &req.PublishAt)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusNotFound, "Event not found")
+		return serviceError(c, err)
 	}
 
-	//? Verify that the user is the host of this event
-	if existingEvent.HostID.Hex() != user.ID.Hex() {
-		return echo.NewHTTPError(http.StatusForbidden, "You can only update your own events")
+	utils.LocalizeEventTimes(event)
+
+	return c.JSON(http.StatusOK, dto.NewEventResponse(event))
+}
+
+// ! CancelScheduledPublish keeps a scheduled draft a draft (host and co-hosts)
+func (cntrlr *EventController) CancelScheduledPublish(c echo.Context) error {
+	userObjID, err := currentUserID(c)
+	if err != nil {
+		return err
 	}
 
-	//? Bind the updated event data
-	updatedEvent := new(models.Event)
-	if err := c.Bind(updatedEvent); err != nil {
+	event, err := cntrlr.events.SchedulePublish(c.Request().Context(), userObjID, c.Param("id"), nil)
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	utils.LocalizeEventTimes(event)
+
+	return c.JSON(http.StatusOK, dto.NewEventResponse(event))
+}
+
+// ! PublishEvent publishes one of the host's draft events and notifies their followers (host only)
+func (cntrlr *EventController) PublishEvent(c echo.Context) error {
+	userObjID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	event, err := cntrlr.events.PublishEvent(c.Request().Context(), userObjID, c.Param("id"))
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	utils.LocalizeEventTimes(event)
+
+	return c.JSON(http.StatusOK, dto.NewEventResponse(event))
+}
+
+// ! BulkEvents applies one action (publish, unpublish, cancel, delete, change_category) to many of the host's events
+func (cntrlr *EventController) BulkEvents(c echo.Context) error {
+	userEmail, err := currentUserEmail(c)
+	if err != nil {
+		return err
+	}
+
+	req := new(dto.BulkEventRequest)
+	if err := c.Bind(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Cannot bind bulk request")
+	}
+
+	//? A failed event doesn't fail the request, every event gets its own result
+	results, err := cntrlr.events.BulkEvents(c.Request().Context(), userEmail, req)
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	succeeded := 0
+	for _, result := range results {
+		if !result.OK {
+			continue
+		}
+		succeeded++
+
+		eventID, _ := bson.ObjectIDFromHex(result.EventID)
+		switch req.Action {
+		case models.BulkActionDelete:
+			recordAudit(c, cntrlr.auditStore, models.AuditEventDeleted, "event", eventID, nil, bson.M{"bulk": true})
+			cntrlr.hub.Publish(realtime.Update{Type: realtime.UpdateEventDeleted, EventID: result.EventID})
+		case models.BulkActionCancel:
+			recordAudit(c, cntrlr.auditStore, models.AuditEventCancelled, "event", eventID, nil,
+				bson.M{"bulk": true, "refunded": result.Refunded})
+			cntrlr.hub.Publish(realtime.Update{Type: realtime.UpdateEventUpdated, EventID: result.EventID})
+		default:
+			cntrlr.hub.Publish(realtime.Update{Type: realtime.UpdateEventUpdated, EventID: result.EventID})
+		}
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"action":    req.Action,
+		"succeeded": succeeded,
+		"failed":    len(results) - succeeded,
+		"results":   results,
+	})
+}
+
+// ! BulkPrice changes the prices of matching ticket types across many of the host's events, dry_run only previews them
+func (cntrlr *EventController) BulkPrice(c echo.Context) error {
+	userEmail, err := currentUserEmail(c)
+	if err != nil {
+		return err
+	}
+
+	req := new(dto.BulkPriceRequest)
+	if err := c.Bind(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Cannot bind price change")
+	}
+
+	results, err := cntrlr.events.BulkPrice(c.Request().Context(), userEmail, req)
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	succeeded := 0
+	for _, result := range results {
+		if result.OK {
+			succeeded++
+		}
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"dry_run":   req.DryRun,
+		"succeeded": succeeded,
+		"failed":    len(results) - succeeded,
+		"results":   results,
+	})
+}
+
+// ! RescheduleEvent moves an event to new dates, attendees are asked to reconfirm when it moved a day or more (host and co-hosts)
+func (cntrlr *EventController) RescheduleEvent(c echo.Context) error {
+	userObjID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	req := new(dto.RescheduleEventRequest)
+	if err := c.Bind(req); err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, "Cannot bind event data")
 	}
 
-	//? Preserve the original ID and HostID
-	updatedEvent.ID = existingEvent.ID
-	updatedEvent.HostID = existingEvent.HostID
-	updatedEvent.CreatedAt = existingEvent.CreatedAt
+	event, flagged, err := cntrlr.events.RescheduleEvent(c.Request().Context(), userObjID, c.Param("id"), req)
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	recordAudit(c, cntrlr.auditStore, models.AuditEventRescheduled, "event", event.ID,
+		bson.M{"date": event.Reschedule.PreviousDate, "start_time": event.Reschedule.PreviousStartTime, "end_time": event.Reschedule.PreviousEndTime},
+		bson.M{"date": event.Date, "start_time": event.StartTime, "end_time": event.EndTime, "reconfirm_by": event.Reschedule.ReconfirmBy})
+
+	//? Live listeners see the new dates
+	cntrlr.hub.Publish(realtime.Update{
+		Type:    realtime.UpdateEventUpdated,
+		EventID: event.ID.Hex(),
+		Tickets: event.PublicTickets(),
+	})
+
+	utils.LocalizeEventTimes(event)
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"event":                 dto.NewEventResponse(event),
+		"bookings_to_reconfirm": flagged,
+	})
+}
+
+// ! GetJoinLink returns the stream URL of an online/hybrid event to the host or to confirmed attendees near start time
+func (cntrlr *EventController) GetJoinLink(c echo.Context) error {
+	userObjID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	event, err := cntrlr.events.JoinLink(c.Request().Context(), userObjID, c.Param("id"))
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"event_id":   event.ID.Hex(),
+		"stream_url": event.StreamURL,
+		"start_time": event.StartTime,
+	})
+}
+
+// ! StreamEventUpdates streams live ticket availability and event updates using Server-Sent Events
+func (cntrlr *EventController) StreamEventUpdates(c echo.Context) error {
+	id := c.Param("id")          //! GET ID FROM URL PARAMS
+	ctx := c.Request().Context() //! CONTEXT FROM REQUEST
 
-	//? Validate that category_name is provided
-	if updatedEvent.CategoryName == "" {
-		return echo.NewHTTPError(http.StatusBadRequest, "category_name is required")
+	//? Make sure the event exists before opening the stream
+	event, err := cntrlr.eventStore.GetEventByID(ctx, id)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Event not found")
 	}
 
-	//? Validate that event date is not in the past (compare dates only, not time)
-	today := time.Now().Truncate(24 * time.Hour)
-	eventDate := updatedEvent.Date.Truncate(24 * time.Hour)
-	if eventDate.Before(today) {
-		return echo.NewHTTPError(http.StatusBadRequest, "event date cannot be in the past")
+	updates, unsubscribe := cntrlr.hub.Subscribe(event.ID.Hex())
+	defer unsubscribe()
+
+	//? SSE headers
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set(echo.HeaderCacheControl, "no-cache")
+	res.Header().Set(echo.HeaderConnection, "keep-alive")
+	res.WriteHeader(http.StatusOK)
+
+	//? Send the current availability first so the client starts in sync
+	if err := writeSSE(res, realtime.Update{
+		Type:    realtime.UpdateTicketsChanged,
+		EventID: event.ID.Hex(),
+		Tickets: event.PublicTickets(),
+	}); err != nil {
+		return nil
 	}
 
-	//? Validate that end_time is after start_time
-	if updatedEvent.EndTime.Before(updatedEvent.StartTime) || updatedEvent.EndTime.Equal(updatedEvent.StartTime) {
-		return echo.NewHTTPError(http.StatusBadRequest, "end time must be after start time")
+	//! Keep-alive so proxies don't close idle connections
+	keepAlive := time.NewTicker(30 * time.Second)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(res, ": keep-alive\n\n"); err != nil {
+				return nil
+			}
+			res.Flush()
+		case update := <-updates:
+			if err := writeSSE(res, update); err != nil {
+				return nil
+			}
+			if update.Type == realtime.UpdateEventDeleted {
+				return nil
+			}
+		}
 	}
+}
 
-	//? Update the event in database
-	if err := cntrlr.eventStore.UpdateEvent(ctx, updatedEvent); err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to update event: "+err.Error())
+// ! writeSSE writes a single update as an SSE message and flushes it
+func writeSSE(res *echo.Response, update realtime.Update) error {
+	data, err := json.Marshal(update)
+	if err != nil {
+		return err
 	}
 
-	//? Convert to EventResponse and send HTTP Response
-	eventResponse := &models.EventResponse{
-		ID:           updatedEvent.ID,
-		Name:         updatedEvent.Name,
-		HostID:       updatedEvent.HostID,
-		CategoryName: updatedEvent.CategoryName,
-		Date:         updatedEvent.Date,
-		Location:     updatedEvent.Location,
-		Tickets:      updatedEvent.Tickets,
+	if _, err := fmt.Fprintf(res, "event: %s\ndata: %s\n\n", update.Type, data); err != nil {
+		return err
 	}
 
-	return c.JSON(http.StatusOK, eventResponse)
+	res.Flush()
+	return nil
 }
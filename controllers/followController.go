@@ -0,0 +1,128 @@
+package controllers
+
+import (
+	"event-horizon/models"
+	"event-horizon/store"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+//! THIS FILE HANDLES HTTP REQUESTS RELATED TO FOLLOWING HOSTS AND SEND RESPONSES TO THE CLIENTS
+
+/******************************* NOTE **************************************
+
+I DID THE FOLLOWING THINGS IN THIS FILE:
+
+1. Created FollowController struct to manage follow-related operations.
+
+2. Implemented FollowHost method so a user can follow a host.
+
+3. Implemented UnfollowHost method so a user can stop following a host.
+
+4. Implemented GetFollowing method to list the hosts the authenticated user follows.
+
+5. Deleted accounts are answered like unknown hosts, nobody can follow them.
+
+********************************* NOTE ************************************/
+
+type FollowController struct {
+	followStore store.FollowRepository
+	userStore   store.UserRepository
+}
+
+func NewFollowController(followStore store.FollowRepository, userStore store.UserRepository) *FollowController {
+	return &FollowController{
+		followStore: followStore,
+		userStore:   userStore,
+	}
+}
+
+// FollowHost makes the authenticated user follow a host
+func (cntrlr *FollowController) FollowHost(c echo.Context) error {
+	hostID := c.Param("id") //! GET PARAM
+	ctx := c.Request().Context()
+
+	hostObjID, err := bson.ObjectIDFromHex(hostID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid host ID")
+	}
+
+	//? Get user from JWT
+	userObjID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	if userObjID == hostObjID {
+		return echo.NewHTTPError(http.StatusBadRequest, "You cannot follow yourself")
+	}
+
+	//? Make sure the target is actually a host
+	host, err := cntrlr.userStore.GetUserByID(ctx, hostObjID)
+	if err != nil || host.IsDeleted() {
+		return echo.NewHTTPError(http.StatusNotFound, "Host not found")
+	}
+
+	if !host.IsHost {
+		return echo.NewHTTPError(http.StatusBadRequest, "You can only follow hosts")
+	}
+
+	follow := models.Follow{
+		FollowerID: userObjID,
+		HostID:     hostObjID,
+	}
+
+	if err := cntrlr.followStore.FollowHost(ctx, &follow); err != nil {
+		return echo.NewHTTPError(http.StatusConflict, err.Error())
+	}
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"message": "Host followed successfully",
+		"follow":  follow,
+	})
+}
+
+// UnfollowHost makes the authenticated user stop following a host
+func (cntrlr *FollowController) UnfollowHost(c echo.Context) error {
+	hostID := c.Param("id") //! GET PARAM
+
+	hostObjID, err := bson.ObjectIDFromHex(hostID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid host ID")
+	}
+
+	//? Get user from JWT
+	userObjID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	if err := cntrlr.followStore.UnfollowHost(c.Request().Context(), userObjID, hostObjID); err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{
+		"message": "Host unfollowed successfully",
+	})
+}
+
+// GetFollowing lists the hosts the authenticated user follows
+func (cntrlr *FollowController) GetFollowing(c echo.Context) error {
+	//? Get user from JWT
+	userObjID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	follows, err := cntrlr.followStore.GetFollowing(c.Request().Context(), userObjID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Error retrieving followed hosts")
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"following": follows,
+		"count":     len(follows),
+	})
+}
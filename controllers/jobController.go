@@ -0,0 +1,160 @@
+package controllers
+
+import (
+	"errors"
+	"event-horizon/models"
+	"event-horizon/store"
+	"event-horizon/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+//! THIS FILE LETS ADMINS INSPECT THE BACKGROUND JOB QUEUE AND THE SCHEDULERS, RETRY DEAD JOBS AND RUN SCHEDULERS
+
+/******************************* NOTE **************************************
+
+I DID THE FOLLOWING THINGS IN THIS FILE:
+
+1. Created JobController on the job store the queue workers use.
+
+2. Implemented GetJobs, the dead (failed for good) jobs by default with their last error, ?status= shows the others.
+
+3. Implemented RetryJob, puts a dead job back in the queue with fresh attempts and records it in the audit log.
+
+4. Implemented GetSchedulers and RunScheduler, the admin dashboard of the background schedulers (cleanup, publishing ...), a manual run is recorded in the audit log.
+
+********************************* NOTE ************************************/
+
+// Job list sizes
+const (
+	defaultJobListLimit = 50
+	maxJobListLimit     = 500
+)
+
+type JobController struct {
+	jobStore       store.JobRepository
+	schedulerStore store.SchedulerRepository
+	schedulers     *utils.Schedulers
+	auditStore     store.AuditRepository
+}
+
+func NewJobController(jobStore store.JobRepository, schedulerStore store.SchedulerRepository, schedulers *utils.Schedulers, auditStore store.AuditRepository) *JobController {
+	return &JobController{
+		jobStore:       jobStore,
+		schedulerStore: schedulerStore,
+		schedulers:     schedulers,
+		auditStore:     auditStore,
+	}
+}
+
+// GetJobs lists the jobs of a status, ?status=dead|pending|running|done|all (default dead), ?limit= (admin only)
+func (cntrlr *JobController) GetJobs(c echo.Context) error {
+	status := c.QueryParam("status")
+	switch status {
+	case "":
+		status = models.JobDead
+	case "all":
+		status = ""
+	case models.JobDead, models.JobPending, models.JobRunning, models.JobDone:
+	default:
+		return echo.NewHTTPError(http.StatusBadRequest, "status must be one of dead, pending, running, done or all")
+	}
+
+	limit := defaultJobListLimit
+	if raw := c.QueryParam("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 || parsed > maxJobListLimit {
+			return echo.NewHTTPError(http.StatusBadRequest, "limit must be between 1 and 500")
+		}
+		limit = parsed
+	}
+
+	jobs, err := cntrlr.jobStore.ListJobs(c.Request().Context(), status, limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to load jobs")
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"jobs":  jobs,
+		"count": len(jobs),
+	})
+}
+
+// RetryJob runs a dead job again (admin only)
+func (cntrlr *JobController) RetryJob(c echo.Context) error {
+	id, err := bson.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid job ID")
+	}
+
+	job, err := cntrlr.jobStore.RetryJob(c.Request().Context(), id)
+	if err != nil {
+		if errors.Is(err, store.ErrJobNotDead) {
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		}
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return echo.NewHTTPError(http.StatusNotFound, "Job not found")
+		}
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to retry the job")
+	}
+
+	recordAudit(c, cntrlr.auditStore, models.AuditJobRetried, "job", job.ID, nil, bson.M{"type": job.Type})
+
+	return c.JSON(http.StatusOK, job)
+}
+
+// GetSchedulers lists the background schedulers with their last run, duration, count and error (admin only)
+func (cntrlr *JobController) GetSchedulers(c echo.Context) error {
+	schedulers, err := cntrlr.schedulerStore.GetSchedulers(c.Request().Context())
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to load schedulers")
+	}
+
+	//? A scheduler of this instance that hasn't finished its first run yet is listed without runs
+	recorded := make(map[string]bool, len(schedulers))
+	for _, scheduler := range schedulers {
+		recorded[scheduler.Name] = true
+	}
+	for _, name := range cntrlr.schedulers.Names() {
+		if !recorded[name] {
+			schedulers = append(schedulers, models.Scheduler{Name: name})
+		}
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"schedulers": schedulers,
+		"count":      len(schedulers),
+	})
+}
+
+// RunScheduler runs a scheduler right away and answers with the outcome (admin only)
+func (cntrlr *JobController) RunScheduler(c echo.Context) error {
+	name := c.Param("name")
+
+	scheduler, err := cntrlr.schedulers.RunNow(c.Request().Context(), name)
+	switch {
+	case errors.Is(err, utils.ErrUnknownScheduler):
+		return echo.NewHTTPError(http.StatusNotFound, "Scheduler not found")
+	case errors.Is(err, utils.ErrSchedulerBusy):
+		return echo.NewHTTPError(http.StatusConflict, err.Error())
+	case errors.Is(err, utils.ErrSchedulerStillRunning):
+		recordAudit(c, cntrlr.auditStore, models.AuditSchedulerRun, "scheduler", bson.NilObjectID, nil, bson.M{"name": name})
+		return c.JSON(http.StatusAccepted, map[string]string{
+			"message": err.Error(),
+		})
+	case err != nil:
+		return echo.NewHTTPError(http.StatusInternalServerError, "The run finished but recording it failed").SetInternal(err)
+	}
+
+	recordAudit(c, cntrlr.auditStore, models.AuditSchedulerRun, "scheduler", scheduler.ID, nil, bson.M{
+		"name":  name,
+		"count": scheduler.LastCount,
+		"ok":    scheduler.LastRunOK,
+	})
+
+	return c.JSON(http.StatusOK, scheduler)
+}
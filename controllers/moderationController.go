@@ -0,0 +1,182 @@
+package controllers
+
+import (
+	"event-horizon/dto"
+	"event-horizon/eventbus"
+	"event-horizon/models"
+	"event-horizon/search"
+	"event-horizon/services"
+	"event-horizon/store"
+	"event-horizon/utils"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+//! THIS FILE HANDLES THE ADMIN MODERATION QUEUE FOR NEW EVENTS
+
+/******************************* NOTE **************************************
+
+I DID THE FOLLOWING THINGS IN THIS FILE:
+
+1. Created ModerationController struct for the admin-only event review endpoints.
+
+2. Implemented GetPendingEvents method to list events waiting for review, oldest first.
+
+3. Implemented ApproveEvent method to publish a pending event and notify the host's followers.
+
+4. Implemented RejectEvent method to reject a pending event with a reason and notify the host.
+
+5. Added SuspendHost and UnsuspendHost methods, the rules (hiding events, notifying attendees) live in services.HostService.
+
+6. Approved events are published on the EVENT BUS like events the host publishes.
+
+7. Approved events are added to the SEARCH index, rejected ones taken out.
+
+********************************* NOTE ************************************/
+
+type ModerationController struct {
+	hosts      *services.HostService
+	eventStore store.EventRepository
+	auditStore store.AuditRepository
+	notifier   *utils.NotificationWorker
+	bus        eventbus.Publisher
+	indexer    *search.Indexer
+}
+
+func NewModerationController(hostService *services.HostService, eventStore store.EventRepository, auditStore store.AuditRepository, notifier *utils.NotificationWorker, bus eventbus.Publisher, indexer *search.Indexer) *ModerationController {
+	return &ModerationController{
+		hosts:      hostService,
+		eventStore: eventStore,
+		auditStore: auditStore,
+		notifier:   notifier,
+		bus:        bus,
+		indexer:    indexer,
+	}
+}
+
+// GetPendingEvents lists the events waiting for review (admin only)
+func (cntrlr *ModerationController) GetPendingEvents(c echo.Context) error {
+	events, err := cntrlr.eventStore.GetEventsByStatus(c.Request().Context(), models.EventStatusPendingReview)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to retrieve pending events")
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"events": events,
+		"count":  len(events),
+	})
+}
+
+// ApproveEvent publishes an event waiting for review (admin only)
+func (cntrlr *ModerationController) ApproveEvent(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	event, err := cntrlr.eventStore.GetEventByID(ctx, c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Event not found")
+	}
+
+	if err := cntrlr.eventStore.ModerateEvent(ctx, event.ID, true, ""); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	event.Status = models.EventStatusPublished
+
+	recordAudit(c, cntrlr.auditStore, models.AuditEventApproved, "event", event.ID, nil, nil)
+
+	cntrlr.notifier.NotifyUser(event.HostID, models.NotificationEventApproved, "Your event was approved and is now live: "+event.Name, event.ID)
+
+	//? Now that it is live, let the host's followers know
+	cntrlr.notifier.NotifyNewEvent(*event)
+	cntrlr.bus.Publish(ctx, eventbus.NewEventMessage(eventbus.EventPublished, event))
+	cntrlr.indexer.Update(*event)
+
+	return c.JSON(http.StatusOK, map[string]string{
+		"message": "Event approved successfully",
+	})
+}
+
+// RejectEvent rejects an event waiting for review with a reason for the host (admin only)
+func (cntrlr *ModerationController) RejectEvent(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	var req struct {
+		Reason string `json:"reason" validate:"required"`
+	}
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+	req.Reason = strings.TrimSpace(req.Reason)
+	if req.Reason == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "reason is required")
+	}
+
+	event, err := cntrlr.eventStore.GetEventByID(ctx, c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Event not found")
+	}
+
+	if err := cntrlr.eventStore.ModerateEvent(ctx, event.ID, false, req.Reason); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	cntrlr.indexer.Remove(event.ID)
+
+	recordAudit(c, cntrlr.auditStore, models.AuditEventRejected, "event", event.ID, nil, bson.M{"reason": req.Reason})
+
+	cntrlr.notifier.NotifyUser(event.HostID, models.NotificationEventRejected, "Your event "+event.Name+" was rejected: "+req.Reason, event.ID)
+
+	return c.JSON(http.StatusOK, map[string]string{
+		"message": "Event rejected successfully",
+	})
+}
+
+// SuspendHost suspends a host, hides their events and notifies the attendees (admin only)
+func (cntrlr *ModerationController) SuspendHost(c echo.Context) error {
+	hostID, err := bson.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	var req struct {
+		Reason string `json:"reason" validate:"required"`
+	}
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	user, hidden, err := cntrlr.hosts.SuspendHost(c.Request().Context(), hostID, req.Reason)
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	recordAudit(c, cntrlr.auditStore, models.AuditUserSuspended, "user", hostID, nil, bson.M{"reason": user.SuspendReason, "hidden_events": hidden})
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message":       "Host suspended successfully",
+		"user":          dto.NewUserResponse(user),
+		"hidden_events": hidden,
+	})
+}
+
+// UnsuspendHost lifts a host's suspension and shows their events again (admin only)
+func (cntrlr *ModerationController) UnsuspendHost(c echo.Context) error {
+	hostID, err := bson.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid user ID")
+	}
+
+	user, restored, err := cntrlr.hosts.UnsuspendHost(c.Request().Context(), hostID)
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	recordAudit(c, cntrlr.auditStore, models.AuditUserUnsuspended, "user", hostID, nil, bson.M{"restored_events": restored})
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message":         "Host suspension lifted successfully",
+		"user":            dto.NewUserResponse(user),
+		"restored_events": restored,
+	})
+}
@@ -0,0 +1,76 @@
+package controllers
+
+import (
+	"event-horizon/store"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+//! THIS FILE HANDLES HTTP REQUESTS RELATED TO IN-APP NOTIFICATIONS AND SEND RESPONSES TO THE CLIENTS
+
+/******************************* NOTE **************************************
+
+I DID THE FOLLOWING THINGS IN THIS FILE:
+
+1. Created NotificationController struct to manage notification-related operations.
+
+2. Implemented GetUserNotifications method to list the authenticated user's notifications.
+
+3. Implemented MarkNotificationAsRead method to mark a single notification as read.
+
+********************************* NOTE ************************************/
+
+type NotificationController struct {
+	notificationStore store.NotificationRepository
+}
+
+func NewNotificationController(notificationStore store.NotificationRepository) *NotificationController {
+	return &NotificationController{
+		notificationStore: notificationStore,
+	}
+}
+
+// GetUserNotifications retrieves all notifications for the authenticated user
+func (cntrlr *NotificationController) GetUserNotifications(c echo.Context) error {
+	//? Get user from JWT
+	userObjID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	notifications, err := cntrlr.notificationStore.GetNotificationsByUserID(c.Request().Context(), userObjID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Error retrieving notifications")
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"notifications": notifications,
+		"count":         len(notifications),
+	})
+}
+
+// MarkNotificationAsRead marks one of the authenticated user's notifications as read
+func (cntrlr *NotificationController) MarkNotificationAsRead(c echo.Context) error {
+	notificationID := c.Param("id") //! GET PARAM
+
+	notificationObjID, err := bson.ObjectIDFromHex(notificationID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid notification ID")
+	}
+
+	//? Get user from JWT
+	userObjID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	if err := cntrlr.notificationStore.MarkAsRead(c.Request().Context(), notificationObjID, userObjID); err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Notification not found")
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{
+		"message": "Notification marked as read",
+	})
+}
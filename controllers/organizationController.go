@@ -0,0 +1,198 @@
+package controllers
+
+import (
+	"event-horizon/dto"
+	"event-horizon/models"
+	"event-horizon/services"
+	"event-horizon/store"
+	"event-horizon/utils"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+//! THIS FILE HANDLES ORGANIZATIONS (TEAM ACCOUNTS), THEIR MEMBERS AND THEIR EVENTS
+
+/******************************* NOTE **************************************
+
+I DID THE FOLLOWING THINGS IN THIS FILE:
+
+1. Created OrganizationController struct, the rules are in services.OrganizationService.
+
+2. Implemented CreateOrganization, GetOrganizations and GetOrganization methods for the authenticated user.
+
+3. Implemented AddMember, UpdateMember and RemoveMember methods, only the owner manages the members (anyone can leave).
+
+4. Implemented GetOrgEvents and CreateOrgEvent methods for the org-scoped events, created events are audited like the host's own.
+
+********************************* NOTE ************************************/
+
+type OrganizationController struct {
+	orgs       *services.OrganizationService
+	events     *services.EventService
+	auditStore store.AuditRepository
+}
+
+func NewOrganizationController(orgService *services.OrganizationService, eventService *services.EventService, auditStore store.AuditRepository) *OrganizationController {
+	return &OrganizationController{
+		orgs:       orgService,
+		events:     eventService,
+		auditStore: auditStore,
+	}
+}
+
+// CreateOrganization creates an organization owned by the authenticated host
+func (cntrlr *OrganizationController) CreateOrganization(c echo.Context) error {
+	userObjID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.OrganizationRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request payload")
+	}
+
+	org, err := cntrlr.orgs.Create(c.Request().Context(), userObjID, &req)
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, org)
+}
+
+// GetOrganizations returns the organizations the authenticated user is a member of
+func (cntrlr *OrganizationController) GetOrganizations(c echo.Context) error {
+	userObjID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	orgs, err := cntrlr.orgs.List(c.Request().Context(), userObjID)
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, orgs)
+}
+
+// GetOrganization returns one organization with its members (members only)
+func (cntrlr *OrganizationController) GetOrganization(c echo.Context) error {
+	userObjID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	org, err := cntrlr.orgs.Get(c.Request().Context(), userObjID, c.Param("id"))
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, org)
+}
+
+// AddMember adds a user to the organization as manager or scanner (owner only)
+func (cntrlr *OrganizationController) AddMember(c echo.Context) error {
+	userObjID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.OrgMemberRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request payload")
+	}
+
+	org, err := cntrlr.orgs.AddMember(c.Request().Context(), userObjID, c.Param("id"), &req)
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, org)
+}
+
+// UpdateMember changes the role of a member (owner only)
+func (cntrlr *OrganizationController) UpdateMember(c echo.Context) error {
+	userObjID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.UpdateOrgMemberRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request payload")
+	}
+
+	org, err := cntrlr.orgs.SetMemberRole(c.Request().Context(), userObjID, c.Param("id"), c.Param("userId"), req.Role)
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, org)
+}
+
+// RemoveMember removes a member (owner), or lets the authenticated member leave
+func (cntrlr *OrganizationController) RemoveMember(c echo.Context) error {
+	userObjID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	org, err := cntrlr.orgs.RemoveMember(c.Request().Context(), userObjID, c.Param("id"), c.Param("userId"))
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, org)
+}
+
+// GetOrgEvents returns every event of the organization, drafts included (members only)
+func (cntrlr *OrganizationController) GetOrgEvents(c echo.Context) error {
+	userObjID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	events, err := cntrlr.orgs.Events(c.Request().Context(), userObjID, c.Param("id"))
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	responses := make([]*models.EventResponse, 0, len(events))
+	for i := range events {
+		utils.LocalizeEventTimes(&events[i])
+		responses = append(responses, dto.NewEventResponse(&events[i]))
+	}
+
+	return c.JSON(http.StatusOK, responses)
+}
+
+// CreateOrgEvent creates an event of the organization (owner and managers)
+func (cntrlr *OrganizationController) CreateOrgEvent(c echo.Context) error {
+	userObjID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	req := new(dto.CreateEventRequest)
+	if err := c.Bind(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, map[string]interface{}{
+			"message": "Cannot bind event data",
+			"error":   err.Error(),
+		})
+	}
+	event := req.ToModel()
+
+	warnings, err := cntrlr.events.CreateOrgEvent(c.Request().Context(), userObjID, c.Param("id"), event)
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	recordAudit(c, cntrlr.auditStore, models.AuditEventCreated, "event", event.ID, nil, event)
+
+	utils.LocalizeEventTimes(event)
+	eventResponse := dto.NewEventResponse(event)
+	eventResponse.Warnings = warnings
+
+	return c.JSON(http.StatusCreated, eventResponse)
+}
@@ -0,0 +1,115 @@
+package controllers
+
+import (
+	"event-horizon/recommendations"
+	"event-horizon/services"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+//! THIS FILE HANDLES THE "EVENTS YOU MAY LIKE" REQUESTS
+
+/******************************* NOTE **************************************
+
+I DID THE FOLLOWING THINGS IN THIS FILE:
+
+1. Created RecommendationController struct, the scoring runs in services.RecommendationService.
+
+2. Implemented GetRecommendations method: the signed in user's recommended events, optionally near ?lat=&lng=.
+
+3. Implemented GetRelatedEvents method for the "you might also like" rail of the event page.
+
+********************************* NOTE ************************************/
+
+type RecommendationController struct {
+	recommendations *services.RecommendationService
+}
+
+func NewRecommendationController(recommendationService *services.RecommendationService) *RecommendationController {
+	return &RecommendationController{
+		recommendations: recommendationService,
+	}
+}
+
+// parseLocation reads the optional ?lat=&lng= pair, both or neither must be sent
+func parseLocation(c echo.Context) (*recommendations.Location, error) {
+	latParam, lngParam := c.QueryParam("lat"), c.QueryParam("lng")
+	if latParam == "" && lngParam == "" {
+		return nil, nil
+	}
+
+	lat, err := strconv.ParseFloat(latParam, 64)
+	if err != nil || lat < -90 || lat > 90 {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "lat must be a number between -90 and 90")
+	}
+	lng, err := strconv.ParseFloat(lngParam, 64)
+	if err != nil || lng < -180 || lng > 180 {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, "lng must be a number between -180 and 180")
+	}
+
+	return &recommendations.Location{Lat: lat, Lng: lng}, nil
+}
+
+// parseLimit reads the optional ?limit=, 0 when it isn't sent
+func parseLimit(c echo.Context) (int, error) {
+	limitParam := c.QueryParam("limit")
+	if limitParam == "" {
+		return 0, nil
+	}
+	limit, err := strconv.Atoi(limitParam)
+	if err != nil {
+		return 0, echo.NewHTTPError(http.StatusBadRequest, "limit must be a number")
+	}
+	return limit, nil
+}
+
+// GetRecommendations returns the upcoming events the signed in user may like
+func (cntrlr *RecommendationController) GetRecommendations(c echo.Context) error {
+	userID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	location, err := parseLocation(c)
+	if err != nil {
+		return err
+	}
+
+	limit, err := parseLimit(c)
+	if err != nil {
+		return err
+	}
+
+	recommended, err := cntrlr.recommendations.Recommend(c.Request().Context(), userID, location, limit)
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	//? Show times in each event's own timezone and hide stream links
+	for _, recommendation := range recommended {
+		toPublicEvent(recommendation.Event)
+	}
+
+	return c.JSON(http.StatusOK, recommended)
+}
+
+// GetRelatedEvents returns upcoming events of the same host or category as the event (public)
+func (cntrlr *RecommendationController) GetRelatedEvents(c echo.Context) error {
+	limit, err := parseLimit(c)
+	if err != nil {
+		return err
+	}
+
+	related, err := cntrlr.recommendations.Related(c.Request().Context(), c.Param("id"), limit)
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	for _, recommendation := range related {
+		toPublicEvent(recommendation.Event)
+	}
+
+	return c.JSON(http.StatusOK, related)
+}
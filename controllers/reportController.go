@@ -0,0 +1,135 @@
+package controllers
+
+import (
+	"event-horizon/models"
+	"event-horizon/store"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+//! THIS FILE HANDLES USER REPORTS (FLAGS) ON EVENTS AND THE ADMIN REPORT VIEW
+
+/******************************* NOTE **************************************
+
+I DID THE FOLLOWING THINGS IN THIS FILE:
+
+1. Created ReportController struct to manage event reports.
+
+2. Implemented ReportEvent method so users can flag an event as spam, scam, inappropriate or other.
+
+3. Events reaching the report THRESHOLD are hidden from listings and sent to the moderation queue.
+
+4. Implemented GetReportedEvents method so admins can see report counts per event.
+
+********************************* NOTE ************************************/
+
+// maxReportDetailsLength limits the free text of a report
+const maxReportDetailsLength = 1000
+
+type ReportController struct {
+	reportStore   store.ReportRepository
+	eventStore    store.EventRepository
+	auditStore    store.AuditRepository
+	hideThreshold int
+}
+
+func NewReportController(reportStore store.ReportRepository, eventStore store.EventRepository, auditStore store.AuditRepository, hideThreshold int) *ReportController {
+	return &ReportController{
+		reportStore:   reportStore,
+		eventStore:    eventStore,
+		auditStore:    auditStore,
+		hideThreshold: hideThreshold,
+	}
+}
+
+// ReportEvent flags an event for the admins
+func (cntrlr *ReportController) ReportEvent(c echo.Context) error {
+	ctx := c.Request().Context()
+
+	userObjID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	var req struct {
+		Reason  string `json:"reason" validate:"required,oneof=spam scam inappropriate other"`
+		Details string `json:"details"`
+	}
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	switch req.Reason {
+	case models.ReportReasonSpam, models.ReportReasonScam, models.ReportReasonInappropriate, models.ReportReasonOther:
+	default:
+		return echo.NewHTTPError(http.StatusBadRequest, "reason must be one of spam, scam, inappropriate, other")
+	}
+
+	req.Details = strings.TrimSpace(req.Details)
+	if len(req.Details) > maxReportDetailsLength {
+		return echo.NewHTTPError(http.StatusBadRequest, "details is too long")
+	}
+
+	event, err := cntrlr.eventStore.GetEventByID(ctx, c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Event not found")
+	}
+
+	if event.IsManagedBy(userObjID) {
+		return echo.NewHTTPError(http.StatusBadRequest, "You can't report your own event")
+	}
+
+	report := models.Report{
+		EventID:    event.ID,
+		ReporterID: userObjID,
+		Reason:     req.Reason,
+		Details:    req.Details,
+	}
+	if err := cntrlr.reportStore.CreateReport(ctx, &report); err != nil {
+		return echo.NewHTTPError(http.StatusConflict, err.Error())
+	}
+
+	//? Too many reports, hide the event until an admin reviews it
+	count, err := cntrlr.reportStore.CountReportsByEventID(ctx, event.ID)
+	if err != nil {
+		log.Printf("Error counting reports for event %s: %v", event.ID.Hex(), err)
+	} else if count >= cntrlr.hideThreshold {
+		hidden, err := cntrlr.eventStore.HideForReview(ctx, event.ID)
+		if err != nil {
+			log.Printf("Error hiding reported event %s: %v", event.ID.Hex(), err)
+		} else if hidden {
+			recordAudit(c, cntrlr.auditStore, models.AuditEventAutoHidden, "event", event.ID, nil, map[string]int{"reports": count})
+		}
+	}
+
+	return c.JSON(http.StatusCreated, map[string]string{
+		"message": "Event reported, thank you",
+	})
+}
+
+// GetReportedEvents lists events by report count, most reported first (admin only)
+func (cntrlr *ReportController) GetReportedEvents(c echo.Context) error {
+	limit := 100
+	if limitParam := c.QueryParam("limit"); limitParam != "" {
+		parsed, err := strconv.Atoi(limitParam)
+		if err != nil || parsed <= 0 || parsed > 1000 {
+			return echo.NewHTTPError(http.StatusBadRequest, "limit must be between 1 and 1000")
+		}
+		limit = parsed
+	}
+
+	summaries, err := cntrlr.reportStore.GetReportSummaries(c.Request().Context(), limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to retrieve reports")
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"reported_events": summaries,
+		"count":           len(summaries),
+		"hide_threshold":  cntrlr.hideThreshold,
+	})
+}
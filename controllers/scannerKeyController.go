@@ -0,0 +1,97 @@
+package controllers
+
+import (
+	"event-horizon/dto"
+	"event-horizon/models"
+	"event-horizon/services"
+	"event-horizon/store"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+//! THIS FILE HANDLES SCANNER KEYS (CHECK-IN DEVICES OF DOOR STAFF)
+
+/******************************* NOTE **************************************
+
+I DID THE FOLLOWING THINGS IN THIS FILE:
+
+1. Created ScannerKeyController struct, the rules are in services.ScannerKeyService.
+
+2. Implemented CreateScannerKey method, the key is in the answer only this once.
+
+3. Implemented GetScannerKeys and RevokeScannerKey methods, creating and revoking keys is recorded in the AUDIT LOG.
+
+********************************* NOTE ************************************/
+
+type ScannerKeyController struct {
+	keys       *services.ScannerKeyService
+	auditStore store.AuditRepository
+}
+
+func NewScannerKeyController(scannerKeyService *services.ScannerKeyService, auditStore store.AuditRepository) *ScannerKeyController {
+	return &ScannerKeyController{
+		keys:       scannerKeyService,
+		auditStore: auditStore,
+	}
+}
+
+// CreateScannerKey creates a scanner key for the event (event host and co-hosts only)
+func (cntrlr *ScannerKeyController) CreateScannerKey(c echo.Context) error {
+	userObjID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.ScannerKeyRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request payload")
+	}
+
+	key, secret, err := cntrlr.keys.Create(c.Request().Context(), userObjID, c.Param("id"), req.Name)
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	recordAudit(c, cntrlr.auditStore, models.AuditScannerKeyCreated, "event", key.EventID, nil, bson.M{"key_id": key.ID, "name": key.Name, "hint": key.Hint})
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"message":     "Scanner key created, copy it now, it won't be shown again",
+		"key":         secret,
+		"scanner_key": key,
+	})
+}
+
+// GetScannerKeys lists the scanner keys of the event (event host and co-hosts only)
+func (cntrlr *ScannerKeyController) GetScannerKeys(c echo.Context) error {
+	userObjID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	keys, err := cntrlr.keys.List(c.Request().Context(), userObjID, c.Param("id"))
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, keys)
+}
+
+// RevokeScannerKey revokes a scanner key of the event (event host and co-hosts only)
+func (cntrlr *ScannerKeyController) RevokeScannerKey(c echo.Context) error {
+	userObjID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	keyID, err := cntrlr.keys.Revoke(c.Request().Context(), userObjID, c.Param("id"), c.Param("keyId"))
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	eventObjID, _ := bson.ObjectIDFromHex(c.Param("id")) //? Revoke already loaded the event
+	recordAudit(c, cntrlr.auditStore, models.AuditScannerKeyRevoked, "event", eventObjID, nil, bson.M{"key_id": keyID})
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "Scanner key revoked"})
+}
@@ -0,0 +1,95 @@
+package controllers
+
+import (
+	"event-horizon/models"
+	"event-horizon/services"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+//! THIS FILE HANDLES THE EVENT SEARCH REQUESTS
+
+/******************************* NOTE **************************************
+
+I DID THE FOLLOWING THINGS IN THIS FILE:
+
+1. Created SearchController struct, the search itself runs in services.SearchService.
+
+2. Implemented SearchEvents method: ?q= full-text search with category, date and price filters, facets and paging.
+
+********************************* NOTE ************************************/
+
+type SearchController struct {
+	search *services.SearchService
+}
+
+func NewSearchController(searchService *services.SearchService) *SearchController {
+	return &SearchController{
+		search: searchService,
+	}
+}
+
+// parseSearchTime reads a from / to parameter, either a date (YYYY-MM-DD, UTC) or an RFC 3339 time
+func parseSearchTime(name, value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, nil
+	}
+	return time.Time{}, echo.NewHTTPError(http.StatusBadRequest, name+" must be a date like 2025-01-31 or an RFC 3339 time")
+}
+
+// parseSearchPrice reads a min_price / max_price parameter
+func parseSearchPrice(name, value string) (*float64, error) {
+	if value == "" {
+		return nil, nil
+	}
+	price, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return nil, echo.NewHTTPError(http.StatusBadRequest, name+" must be a number")
+	}
+	return &price, nil
+}
+
+// SearchEvents searches the public events
+func (cntrlr *SearchController) SearchEvents(c echo.Context) error {
+	query := models.EventSearchQuery{
+		Text:     c.QueryParam("q"),
+		Category: c.QueryParam("category"),
+	}
+
+	var err error
+	if query.From, err = parseSearchTime("from", c.QueryParam("from")); err != nil {
+		return err
+	}
+	if query.To, err = parseSearchTime("to", c.QueryParam("to")); err != nil {
+		return err
+	}
+	if query.MinPrice, err = parseSearchPrice("min_price", c.QueryParam("min_price")); err != nil {
+		return err
+	}
+	if query.MaxPrice, err = parseSearchPrice("max_price", c.QueryParam("max_price")); err != nil {
+		return err
+	}
+	for name, target := range map[string]*int{"limit": &query.Limit, "offset": &query.Offset} {
+		if value := c.QueryParam(name); value != "" {
+			if *target, err = strconv.Atoi(value); err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, name+" must be a number")
+			}
+		}
+	}
+
+	result, err := cntrlr.search.SearchEvents(c.Request().Context(), query)
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,71 @@
+package controllers
+
+import (
+	"encoding/json"
+	"event-horizon/services"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+//! THIS FILE SERVES THE SITEMAP AND THE STRUCTURED DATA (JSON-LD) SEARCH ENGINES INDEX
+
+/******************************* NOTE **************************************
+
+I DID THE FOLLOWING THINGS IN THIS FILE:
+
+1. Created SEOController struct, the documents are built in services.SEOService.
+
+2. Implemented GetSitemap method that serves /sitemap.xml, crawlers may cache it for an hour.
+
+3. Implemented GetEventJSONLD method that serves the schema.org Event of a public event as application/ld+json.
+
+4. Implemented GetEventFeed method that serves the RSS feed of upcoming events, optionally ?category=.
+
+********************************* NOTE ************************************/
+
+type SEOController struct {
+	seo *services.SEOService
+}
+
+func NewSEOController(seoService *services.SEOService) *SEOController {
+	return &SEOController{
+		seo: seoService,
+	}
+}
+
+// GetSitemap serves the sitemap of every public event page
+func (cntrlr *SEOController) GetSitemap(c echo.Context) error {
+	body, err := cntrlr.seo.Sitemap(c.Request().Context())
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	c.Response().Header().Set(echo.HeaderCacheControl, "public, max-age=3600")
+	return c.Blob(http.StatusOK, "application/xml; charset=UTF-8", body)
+}
+
+// GetEventFeed serves the RSS feed of upcoming events, ?category= keeps one category
+func (cntrlr *SEOController) GetEventFeed(c echo.Context) error {
+	body, err := cntrlr.seo.Feed(c.Request().Context(), c.QueryParam("category"))
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	c.Response().Header().Set(echo.HeaderCacheControl, "public, max-age=900")
+	return c.Blob(http.StatusOK, "application/rss+xml; charset=UTF-8", body)
+}
+
+// GetEventJSONLD serves the schema.org structured data of a public event
+func (cntrlr *SEOController) GetEventJSONLD(c echo.Context) error {
+	data, err := cntrlr.seo.EventJSONLD(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	body, err := json.Marshal(data)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to build the structured data")
+	}
+	return c.Blob(http.StatusOK, "application/ld+json; charset=UTF-8", body)
+}
@@ -0,0 +1,44 @@
+package controllers
+
+import (
+	"errors"
+	"event-horizon/services"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// serviceStatus maps service error kinds to HTTP status codes
+var serviceStatus = map[services.Kind]int{
+	services.KindInternal:     http.StatusInternalServerError,
+	services.KindInvalid:      http.StatusBadRequest,
+	services.KindUnauthorized: http.StatusUnauthorized,
+	services.KindForbidden:    http.StatusForbidden,
+	services.KindNotFound:     http.StatusNotFound,
+	services.KindConflict:     http.StatusConflict,
+	services.KindGone:         http.StatusGone,
+	services.KindUnavailable:  http.StatusServiceUnavailable,
+	services.KindRateLimited:  http.StatusTooManyRequests,
+	//? 428, the client shows the CAPTCHA and sends the request again with captcha_token
+	services.KindCaptchaRequired: http.StatusPreconditionRequired,
+}
+
+// ! serviceError turns an error returned by a service into an HTTP error
+func serviceError(c echo.Context, err error) error {
+	var serviceErr *services.Error
+	if !errors.As(err, &serviceErr) {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Internal server error").SetInternal(err)
+	}
+
+	status, ok := serviceStatus[serviceErr.Kind]
+	if !ok {
+		status = http.StatusInternalServerError
+	}
+
+	//? Internal details go to the error reporter (middleware.ErrorHandler), the client only gets the message
+	if status == http.StatusInternalServerError {
+		return echo.NewHTTPError(status, serviceErr.Message).SetInternal(serviceErr)
+	}
+
+	return echo.NewHTTPError(status, serviceErr.Message)
+}
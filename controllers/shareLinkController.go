@@ -0,0 +1,82 @@
+package controllers
+
+import (
+	"event-horizon/dto"
+	"event-horizon/services"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+//! THIS FILE HANDLES SHARE LINKS (SHORT LINKS PER CHANNEL) AND THE REFERRAL REPORT
+
+/******************************* NOTE **************************************
+
+I DID THE FOLLOWING THINGS IN THIS FILE:
+
+1. Created ShareLinkController struct, the rules are in services.ShareLinkService.
+
+2. Implemented CreateShareLink method so the host and co-hosts get a short link per channel.
+
+3. Implemented FollowShareLink method that counts the click and redirects to the event page with ?ref= and the UTM parameters.
+
+4. Implemented GetReferrals method so hosts see which channel sells tickets.
+
+********************************* NOTE ************************************/
+
+type ShareLinkController struct {
+	shareLinks *services.ShareLinkService
+}
+
+func NewShareLinkController(shareLinkService *services.ShareLinkService) *ShareLinkController {
+	return &ShareLinkController{
+		shareLinks: shareLinkService,
+	}
+}
+
+// CreateShareLink creates a share link for the event (event host and co-hosts only)
+func (cntrlr *ShareLinkController) CreateShareLink(c echo.Context) error {
+	userObjID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.ShareLinkRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request payload")
+	}
+
+	link, err := cntrlr.shareLinks.Create(c.Request().Context(), userObjID, c.Param("id"), &req)
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, link)
+}
+
+// FollowShareLink counts a click and redirects to the event page
+func (cntrlr *ShareLinkController) FollowShareLink(c echo.Context) error {
+	target, err := cntrlr.shareLinks.Click(c.Request().Context(), c.Param("code"))
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	//? Every visit must reach the server to be counted
+	c.Response().Header().Set(echo.HeaderCacheControl, "no-store")
+	return c.Redirect(http.StatusFound, target)
+}
+
+// GetReferrals returns the clicks and bookings per share link of the event (event host and co-hosts only)
+func (cntrlr *ShareLinkController) GetReferrals(c echo.Context) error {
+	userObjID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	report, err := cntrlr.shareLinks.Referrals(c.Request().Context(), userObjID, c.Param("id"))
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,135 @@
+package controllers
+
+import (
+	"event-horizon/dto"
+	"event-horizon/models"
+	"event-horizon/services"
+	"event-horizon/store"
+	"event-horizon/utils"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+//! THIS FILE HANDLES EVENT TEMPLATES OF HOSTS
+
+/******************************* NOTE **************************************
+
+I DID THE FOLLOWING THINGS IN THIS FILE:
+
+1. Created TemplateController struct, the rules are in services.TemplateService.
+
+2. Implemented SaveTemplate, GetTemplates, GetTemplate and DeleteTemplate methods for the host's own templates.
+
+3. Implemented CreateEventFromTemplate method, the new draft is recorded in the AUDIT LOG like any created event.
+
+********************************* NOTE ************************************/
+
+type TemplateController struct {
+	templates  *services.TemplateService
+	auditStore store.AuditRepository
+}
+
+func NewTemplateController(templateService *services.TemplateService, auditStore store.AuditRepository) *TemplateController {
+	return &TemplateController{
+		templates:  templateService,
+		auditStore: auditStore,
+	}
+}
+
+// SaveTemplate saves one of the host's events as a template
+func (cntrlr *TemplateController) SaveTemplate(c echo.Context) error {
+	userEmail, err := currentUserEmail(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.SaveTemplateRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request payload")
+	}
+
+	template, err := cntrlr.templates.Save(c.Request().Context(), userEmail, &req)
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	utils.LocalizeEventTimes(&template.Event)
+	return c.JSON(http.StatusCreated, template)
+}
+
+// GetTemplates returns the host's templates
+func (cntrlr *TemplateController) GetTemplates(c echo.Context) error {
+	userObjID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	templates, err := cntrlr.templates.List(c.Request().Context(), userObjID)
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	for i := range templates {
+		utils.LocalizeEventTimes(&templates[i].Event)
+	}
+	return c.JSON(http.StatusOK, templates)
+}
+
+// GetTemplate returns one of the host's templates
+func (cntrlr *TemplateController) GetTemplate(c echo.Context) error {
+	userObjID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	template, err := cntrlr.templates.Get(c.Request().Context(), userObjID, c.Param("id"))
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	utils.LocalizeEventTimes(&template.Event)
+	return c.JSON(http.StatusOK, template)
+}
+
+// DeleteTemplate deletes one of the host's templates
+func (cntrlr *TemplateController) DeleteTemplate(c echo.Context) error {
+	userObjID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	if err := cntrlr.templates.Delete(c.Request().Context(), userObjID, c.Param("id")); err != nil {
+		return serviceError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{
+		"message": "Template deleted",
+	})
+}
+
+// CreateEventFromTemplate creates a new draft from one of the host's templates on the sent dates
+func (cntrlr *TemplateController) CreateEventFromTemplate(c echo.Context) error {
+	userEmail, err := currentUserEmail(c)
+	if err != nil {
+		return err
+	}
+
+	req := new(dto.EventFromTemplateRequest)
+	if err := c.Bind(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Cannot bind event data")
+	}
+
+	event, warnings, err := cntrlr.templates.CreateEvent(c.Request().Context(), userEmail, c.Param("templateId"), req)
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	recordAudit(c, cntrlr.auditStore, models.AuditEventCreated, "event", event.ID, nil, event)
+
+	utils.LocalizeEventTimes(event)
+	eventResponse := dto.NewEventResponse(event)
+	eventResponse.Warnings = warnings //? Possible duplicates, the event is created anyway
+
+	return c.JSON(http.StatusCreated, eventResponse)
+}
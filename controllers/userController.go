@@ -1,117 +1,341 @@
 package controllers
 
 import (
+	"event-horizon/dto"
+	"event-horizon/middleware"
 	"event-horizon/models"
+	"event-horizon/services"
 	"event-horizon/store"
 	"event-horizon/utils"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/v2/bson"
 )
 
 type UserController struct {
-	store *store.UserStore
+	users        *services.UserService
+	sessionStore store.SessionRepository
+	auditStore   store.AuditRepository
 }
 
-func NewUserController(s *store.UserStore) *UserController {
+func NewUserController(userService *services.UserService, sessionStore store.SessionRepository, auditStore store.AuditRepository) *UserController {
 	return &UserController{
-		store: s,
+		users:        userService,
+		sessionStore: sessionStore,
+		auditStore:   auditStore,
 	}
 }
 
-// Register functions
-func (cntrlr *UserController) Register(c echo.Context) error {
-	user := new(models.User)
+// deliverToken puts the token in the HttpOnly cookie for use_cookie clients, every other client gets it in the body
+func deliverToken(c echo.Context, body map[string]interface{}, token string, session *models.UserSession, useCookie bool) {
+	if useCookie {
+		c.SetCookie(middleware.TokenCookie(token, session))
+		return
+	}
+	body["token"] = token
+}
 
-	// 1. Bind Request
-	if err := c.Bind(user); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "invalid request payload")
+// cookieAuth reports whether the request came with the token cookie instead of the Authorization header
+func cookieAuth(c echo.Context) bool {
+	if c.Request().Header.Get(echo.HeaderAuthorization) != "" {
+		return false
 	}
+	_, err := c.Cookie(middleware.TokenCookieName)
+	return err == nil
+}
 
-	// 2. Calling the Store password hashing will be done
-	ctx := c.Request().Context()
-	if err := cntrlr.store.CreateUser(ctx, user); err != nil {
-		println("DEBUG Controller: Error creating user -", err.Error())
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to create user")
+// device describes the client making this request, it is stored with the new session.
+// The IP also keys the failed sign in counter, so it comes from the server's IPExtractor (TRUSTED_PROXIES), never straight from X-Forwarded-For
+func device(c echo.Context) services.Device {
+	return services.Device{
+		UserAgent: c.Request().UserAgent(),
+		IP:        c.RealIP(),
 	}
+}
 
-	//! IMPORTANT: Fetch the newly created user to get the generated ID
-	createdUser, err := cntrlr.store.FindUserByEmail(ctx, user.Email)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to retrieve user")
+// Register functions
+func (cntrlr *UserController) Register(c echo.Context) error {
+	req := new(dto.RegisterRequest)
+
+	// 1. Bind Request (name, email, password and language only, roles can never be granted through registration)
+	if err := c.Bind(req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request payload")
 	}
 
-	// Generate JWT token
-	token, err := utils.GenerateJWT(createdUser.ID.Hex(), createdUser.Email, createdUser.Name)
+	// 2. The service creates the user (store hashes the password) and signs them in
+	createdUser, signIn, err := cntrlr.users.Register(c.Request().Context(), req, device(c))
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to generate token")
+		return serviceError(c, err)
 	}
 
-	//! password will be removed from response
-	createdUser.Password = ""
-
-	//   Response
-	return c.JSON(http.StatusCreated, map[string]interface{}{
-		"message": "User registered successfully",
-		"user":    user,
-		"token":   token,
-	})
+	//   Response (password is never part of UserResponse)
+	body := map[string]interface{}{
+		"message":    "User registered successfully",
+		"user":       dto.NewUserResponse(createdUser),
+		"expires_at": signIn.Session.ExpiresAt,
+	}
+	deliverToken(c, body, signIn.Token, signIn.Session, req.UseCookie)
+	return c.JSON(http.StatusCreated, body)
 }
 
 // Login functions
 func (cntrlr *UserController) Login(c echo.Context) error {
-	//? login structure
-	type LoginRequest struct {
-		Email    string `json:"email" validate:"required,email"`
-		Password string `json:"password" validate:"required"`
-	}
-
 	//? instance of login request
-	loginReq := new(LoginRequest)
+	loginReq := new(dto.LoginRequest)
 
 	// Bind Request with the context
 	if err := c.Bind(loginReq); err != nil {
-		println("DEBUG Controller: Error parsing login request -", err.Error())
 		return echo.NewHTTPError(http.StatusBadRequest, map[string]interface{}{
 			"message": "Invalid request payload",
 			"error":   err.Error(),
 		})
 	}
 
-	//? Find user by email
-	ctx := c.Request().Context()
-	user, err := cntrlr.store.FindUserByEmail(ctx, loginReq.Email)
+	//? Verify the credentials and generate the JWT token
+	user, signIn, err := cntrlr.users.Login(c.Request().Context(), loginReq, device(c))
 	if err != nil {
-		return echo.NewHTTPError(http.StatusUnauthorized, map[string]interface{}{
-			"message": "Invalid email or password",
-			"error":   err.Error(),
-		})
+		return serviceError(c, err)
 	}
 
-	// Verify password
-	if err := cntrlr.store.VerifyPassword(user.Password, loginReq.Password); err != nil {
-		return echo.NewHTTPError(http.StatusUnauthorized, map[string]interface{}{
-			"message": "Invalid email or password",
-			"error":   err.Error(),
-		})
+	//? Send HTTP Response with JWT token (or the cookie)
+	body := map[string]interface{}{
+		"message":    "Login successful",
+		"user":       dto.NewUserResponse(user),
+		"expires_at": signIn.Session.ExpiresAt,
 	}
+	deliverToken(c, body, signIn.Token, signIn.Session, loginReq.UseCookie)
+	return c.JSON(http.StatusOK, body)
+}
 
-	// Generate JWT token
-	token, err := utils.GenerateJWT(user.ID.Hex(), user.Email, user.Name)
+// GetSessions lists the authenticated user's signed-in devices
+func (cntrlr *UserController) GetSessions(c echo.Context) error {
+	userObjID, err := currentUserID(c)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusInternalServerError, map[string]interface{}{
-			"message": "Failed to generate token",
-			"error":   err.Error(),
-		})
+		return err
 	}
 
-	// Remove password from response
-	user.Password = ""
+	sessions, err := cntrlr.sessionStore.GetActiveSessionsByUserID(c.Request().Context(), userObjID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, "Failed to retrieve sessions")
+	}
+
+	//? Mark the session making this request
+	currentSessionID, _ := utils.GetSessionIDFromToken(c)
 
-	//? Send HTTP Response with JWT token
 	return c.JSON(http.StatusOK, map[string]interface{}{
-		"message": "Login successful",
-		"user":    user,
-		"token":   token,
+		"sessions":           sessions,
+		"current_session_id": currentSessionID,
+		"count":              len(sessions),
+	})
+}
+
+// RevokeSession signs one of the authenticated user's devices out
+func (cntrlr *UserController) RevokeSession(c echo.Context) error {
+	sessionObjID, err := bson.ObjectIDFromHex(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid session ID")
+	}
+
+	userObjID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	if err := cntrlr.sessionStore.RevokeSession(c.Request().Context(), sessionObjID, userObjID); err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Session not found")
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{
+		"message": "Session revoked successfully",
+	})
+}
+
+// Logout revokes the session making this request
+func (cntrlr *UserController) Logout(c echo.Context) error {
+	sessionID, err := utils.GetSessionIDFromToken(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	sessionObjID, err := bson.ObjectIDFromHex(sessionID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid session ID")
+	}
+
+	userObjID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	if err := cntrlr.sessionStore.RevokeSession(c.Request().Context(), sessionObjID, userObjID); err != nil {
+		return echo.NewHTTPError(http.StatusNotFound, "Session not found")
+	}
+
+	c.SetCookie(middleware.ClearTokenCookie()) //? Cookie clients sign out of the browser too
+	return c.JSON(http.StatusOK, map[string]string{
+		"message": "Logged out successfully",
+	})
+}
+
+// UpdateLanguage sets the language the authenticated user gets their emails in
+func (cntrlr *UserController) UpdateLanguage(c echo.Context) error {
+	userObjID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.UpdateLanguageRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request payload")
+	}
+
+	user, err := cntrlr.users.UpdateLanguage(c.Request().Context(), userObjID, req.Language)
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, dto.NewUserResponse(user))
+}
+
+// RequestPhoneVerification texts a verification code to the phone number the authenticated user wants to use
+func (cntrlr *UserController) RequestPhoneVerification(c echo.Context) error {
+	userObjID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.UpdatePhoneRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request payload")
+	}
+
+	if err := cntrlr.users.RequestPhoneVerification(c.Request().Context(), userObjID, req.Phone); err != nil {
+		return serviceError(c, err)
+	}
+
+	return c.JSON(http.StatusAccepted, map[string]string{
+		"message": "A verification code was sent to the phone number",
 	})
 }
+
+// VerifyPhone checks the code and makes the pending number the authenticated user's phone
+func (cntrlr *UserController) VerifyPhone(c echo.Context) error {
+	userObjID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.VerifyPhoneRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request payload")
+	}
+
+	user, err := cntrlr.users.VerifyPhone(c.Request().Context(), userObjID, req.Code)
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, dto.NewUserResponse(user))
+}
+
+// RemovePhone removes the authenticated user's phone number
+func (cntrlr *UserController) RemovePhone(c echo.Context) error {
+	userObjID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	user, err := cntrlr.users.RemovePhone(c.Request().Context(), userObjID)
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, dto.NewUserResponse(user))
+}
+
+// GetPreferences returns the authenticated user's notification preferences
+func (cntrlr *UserController) GetPreferences(c echo.Context) error {
+	userObjID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	preferences, err := cntrlr.users.Preferences(c.Request().Context(), userObjID)
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, dto.NewPreferencesResponse(preferences))
+}
+
+// UpdatePreferences replaces the authenticated user's notification preferences
+func (cntrlr *UserController) UpdatePreferences(c echo.Context) error {
+	userObjID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.UpdatePreferencesRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request payload")
+	}
+
+	preferences, err := cntrlr.users.UpdatePreferences(c.Request().Context(), userObjID, req.Notifications)
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, dto.NewPreferencesResponse(preferences))
+}
+
+// BecomeHost turns the authenticated user into a host so they can create events
+func (cntrlr *UserController) BecomeHost(c echo.Context) error {
+	userObjID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	session, err := utils.SessionFromToken(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	user, token, err := cntrlr.users.BecomeHost(c.Request().Context(), userObjID, session)
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	recordAudit(c, cntrlr.auditStore, models.AuditUserRoleChanged, "user", userObjID,
+		map[string]bool{"is_host": false}, map[string]bool{"is_host": true})
+
+	//? The old token has no host scopes, the client swaps it for this one
+	body := map[string]interface{}{
+		"message": "You are now a host",
+		"user":    dto.NewUserResponse(user),
+	}
+	deliverToken(c, body, token, session, cookieAuth(c))
+	return c.JSON(http.StatusOK, body)
+}
+
+// RefreshToken returns a new token of this session with the user's current role and scopes
+func (cntrlr *UserController) RefreshToken(c echo.Context) error {
+	userObjID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	session, err := utils.SessionFromToken(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+	}
+
+	token, err := cntrlr.users.RefreshToken(c.Request().Context(), userObjID, session)
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	body := map[string]interface{}{"expires_at": session.ExpiresAt}
+	deliverToken(c, body, token, session, cookieAuth(c))
+	return c.JSON(http.StatusOK, body)
+}
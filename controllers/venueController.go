@@ -0,0 +1,155 @@
+package controllers
+
+import (
+	"event-horizon/dto"
+	"event-horizon/models"
+	"event-horizon/services"
+	"event-horizon/store"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+//! THIS FILE HANDLES VENUES (PLACES EVENTS ARE HELD AT)
+
+/******************************* NOTE **************************************
+
+I DID THE FOLLOWING THINGS IN THIS FILE:
+
+1. Created VenueController struct, the rules are in services.VenueService.
+
+2. Implemented CreateVenue, UpdateVenue and DeleteVenue methods, deleting a venue is recorded in the AUDIT LOG.
+
+3. Implemented GetVenues, GetVenue and GetVenueEvents methods, they are public like the event list.
+
+********************************* NOTE ************************************/
+
+type VenueController struct {
+	venues     *services.VenueService
+	auditStore store.AuditRepository
+}
+
+func NewVenueController(venueService *services.VenueService, auditStore store.AuditRepository) *VenueController {
+	return &VenueController{
+		venues:     venueService,
+		auditStore: auditStore,
+	}
+}
+
+// venueLimit reads the optional ?limit= of the venue lists
+func venueLimit(c echo.Context) (int, error) {
+	raw := c.QueryParam("limit")
+	if raw == "" {
+		return 0, nil
+	}
+	parsed, err := strconv.Atoi(raw)
+	if err != nil || parsed < 1 || parsed > 100 {
+		return 0, echo.NewHTTPError(http.StatusBadRequest, "limit must be between 1 and 100")
+	}
+	return parsed, nil
+}
+
+// CreateVenue adds a venue (hosts only)
+func (cntrlr *VenueController) CreateVenue(c echo.Context) error {
+	userObjID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.VenueRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request payload")
+	}
+
+	venue, err := cntrlr.venues.Create(c.Request().Context(), userObjID, &req)
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, venue)
+}
+
+// GetVenues returns the venues, optionally filtered by ?name= and ?city=
+func (cntrlr *VenueController) GetVenues(c echo.Context) error {
+	limit, err := venueLimit(c)
+	if err != nil {
+		return err
+	}
+
+	venues, err := cntrlr.venues.List(c.Request().Context(), models.VenueQuery{
+		Name:  c.QueryParam("name"),
+		City:  c.QueryParam("city"),
+		Limit: limit,
+	})
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, venues)
+}
+
+// GetVenue returns a venue
+func (cntrlr *VenueController) GetVenue(c echo.Context) error {
+	venue, err := cntrlr.venues.Get(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, venue)
+}
+
+// UpdateVenue changes a venue (the host who added it or an admin)
+func (cntrlr *VenueController) UpdateVenue(c echo.Context) error {
+	userObjID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.VenueRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request payload")
+	}
+
+	venue, err := cntrlr.venues.Update(c.Request().Context(), userObjID, c.Param("id"), &req)
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, venue)
+}
+
+// DeleteVenue deletes a venue without upcoming events (the host who added it or an admin)
+func (cntrlr *VenueController) DeleteVenue(c echo.Context) error {
+	userObjID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	venue, err := cntrlr.venues.Delete(c.Request().Context(), userObjID, c.Param("id"))
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	recordAudit(c, cntrlr.auditStore, models.AuditVenueDeleted, "venue", venue.ID, venue, nil)
+
+	return c.JSON(http.StatusOK, map[string]string{
+		"message": "Venue deleted",
+	})
+}
+
+// GetVenueEvents returns the upcoming public events at a venue
+func (cntrlr *VenueController) GetVenueEvents(c echo.Context) error {
+	limit, err := venueLimit(c)
+	if err != nil {
+		return err
+	}
+
+	events, err := cntrlr.venues.Events(c.Request().Context(), c.Param("id"), limit)
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	toPublicEvents(events)
+	return c.JSON(http.StatusOK, events)
+}
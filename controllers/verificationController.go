@@ -0,0 +1,133 @@
+package controllers
+
+import (
+	"event-horizon/dto"
+	"event-horizon/models"
+	"event-horizon/services"
+	"event-horizon/store"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+//! THIS FILE HANDLES HOST VERIFICATION (KYC) SUBMISSIONS AND THEIR ADMIN REVIEW
+
+/******************************* NOTE **************************************
+
+I DID THE FOLLOWING THINGS IN THIS FILE:
+
+1. Created VerificationController struct, the rules are in services.HostVerificationService.
+
+2. Implemented SubmitVerification and GetVerification methods for the authenticated host.
+
+3. Implemented GetVerifications, ApproveVerification and RejectVerification methods for the admin review queue, reviews are audited.
+
+********************************* NOTE ************************************/
+
+type VerificationController struct {
+	verifications *services.HostVerificationService
+	auditStore    store.AuditRepository
+}
+
+func NewVerificationController(verificationService *services.HostVerificationService, auditStore store.AuditRepository) *VerificationController {
+	return &VerificationController{
+		verifications: verificationService,
+		auditStore:    auditStore,
+	}
+}
+
+// SubmitVerification sends the authenticated host's document or payout account for review
+func (cntrlr *VerificationController) SubmitVerification(c echo.Context) error {
+	userObjID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.HostVerificationRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request payload")
+	}
+
+	verification, err := cntrlr.verifications.Submit(c.Request().Context(), userObjID, &req)
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, verification)
+}
+
+// GetVerification returns the authenticated host's newest submission
+func (cntrlr *VerificationController) GetVerification(c echo.Context) error {
+	userObjID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	verification, err := cntrlr.verifications.Status(c.Request().Context(), userObjID)
+	if err != nil {
+		return serviceError(c, err)
+	}
+	if verification == nil {
+		return echo.NewHTTPError(http.StatusNotFound, "No verification submitted yet")
+	}
+
+	return c.JSON(http.StatusOK, verification)
+}
+
+// GetVerifications lists the submissions of a status, pending by default (admin only)
+func (cntrlr *VerificationController) GetVerifications(c echo.Context) error {
+	status := c.QueryParam("status")
+	if status == "" {
+		status = models.VerificationPending
+	}
+
+	verifications, err := cntrlr.verifications.List(c.Request().Context(), status)
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"verifications": verifications,
+		"count":         len(verifications),
+	})
+}
+
+// ApproveVerification verifies the host of a pending submission (admin only)
+func (cntrlr *VerificationController) ApproveVerification(c echo.Context) error {
+	return cntrlr.review(c, true)
+}
+
+// RejectVerification rejects a pending submission with a note for the host (admin only)
+func (cntrlr *VerificationController) RejectVerification(c echo.Context) error {
+	return cntrlr.review(c, false)
+}
+
+// review approves or rejects the submission of the :id param and audits it
+func (cntrlr *VerificationController) review(c echo.Context, approve bool) error {
+	adminID, err := currentUserID(c)
+	if err != nil {
+		return err
+	}
+
+	var req dto.ReviewVerificationRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	verification, err := cntrlr.verifications.Review(c.Request().Context(), c.Param("id"), adminID, approve, req.Note)
+	if err != nil {
+		return serviceError(c, err)
+	}
+
+	action, message := models.AuditHostVerified, "Host verified successfully"
+	if !approve {
+		action, message = models.AuditHostVerificationRejected, "Host verification rejected"
+	}
+	recordAudit(c, cntrlr.auditStore, action, "user", verification.HostID, nil, bson.M{"verification_id": verification.ID, "kind": verification.Kind, "note": verification.ReviewNote})
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message":      message,
+		"verification": verification,
+	})
+}
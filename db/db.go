@@ -3,21 +3,53 @@ package db
 import (
 	"context"
 	"log"
-	"os"
+	"time"
 
+	"go.mongodb.org/mongo-driver/v2/event"
 	"go.mongodb.org/mongo-driver/v2/mongo"
 	"go.mongodb.org/mongo-driver/v2/mongo/options"
+	"go.mongodb.org/mongo-driver/v2/mongo/readpref"
 )
 
-func ConnectDB() *mongo.Database {
-	MongoURI := os.Getenv("MONGO_URI")
+/** *********************  MONGODB CLIENT   ********************
 
-	if MongoURI == "" {
+The client is tuned from config (pool size, timeouts, retryable reads and
+writes, read preference). Once connected the driver reconnects on its own,
+so only the first connection needs care: a Mongo that is briefly unreachable
+at startup (e.g. during a deploy or a failover) is retried with a growing
+backoff instead of crashing the dyno right away.
+
+ **************************************/
+
+// Options tunes the MongoDB client
+type Options struct {
+	MaxPoolSize            uint64
+	MinPoolSize            uint64
+	ConnectTimeout         time.Duration
+	ServerSelectionTimeout time.Duration
+	OperationTimeout       time.Duration //? Upper bound for every single operation (0 = none)
+	RetryWrites            bool
+	RetryReads             bool
+	ReadPreference         string //? primary, primaryPreferred, secondary, secondaryPreferred, nearest
+	ConnectRetries         int    //? Extra attempts at startup before giving up
+	ConnectRetryBackoff    time.Duration
+	Monitor                *event.CommandMonitor //? Optional, sees every command (request tracing)
+}
+
+// maxConnectBackoff caps the wait between startup attempts
+const maxConnectBackoff = 30 * time.Second
+
+// ConnectDB connects to MongoDB and returns the app database
+func ConnectDB(mongoURI, databaseName string, opts Options) *mongo.Database {
+	if mongoURI == "" {
 		// EXIT IF MONGO_URI IS NOT SET
 		log.Fatal("MONGO_URI environment variable not set")
 	}
 
-	clientOptions := options.Client().ApplyURI(MongoURI)
+	clientOptions, err := clientOptions(mongoURI, opts)
+	if err != nil {
+		log.Fatal("Invalid MongoDB options:", err)
+	}
 
 	client, err := mongo.Connect(clientOptions)
 
@@ -25,14 +57,63 @@ func ConnectDB() *mongo.Database {
 		log.Fatal("Error connecting to MongoDB:", err)
 	}
 
-	err = client.Ping(context.Background(), nil)
+	//! Retry the first ping, the driver handles reconnects after that
+	backoff := opts.ConnectRetryBackoff
+	for attempt := 0; ; attempt++ {
+		err = ping(client, opts.ServerSelectionTimeout)
+		if err == nil {
+			break
+		}
+		if attempt >= opts.ConnectRetries {
+			log.Fatal("Error pinging MongoDB:", err)
+		}
 
-	if err != nil {
-		log.Fatal("Error pinging MongoDB:", err)
+		log.Printf("MongoDB not reachable (attempt %d of %d), retrying in %s: %v", attempt+1, opts.ConnectRetries+1, backoff, err)
+		time.Sleep(backoff)
+		backoff = min(backoff*2, maxConnectBackoff)
 	}
 
 	log.Println("Connected to MongoDB")
 
-	return client.Database(os.Getenv("DATABASE_NAME"))
+	return client.Database(databaseName)
+
+}
+
+// clientOptions builds the driver options, settings given here win over the ones in the URI
+func clientOptions(mongoURI string, opts Options) (*options.ClientOptions, error) {
+	mode, err := readpref.ModeFromString(opts.ReadPreference)
+	if err != nil {
+		return nil, err
+	}
+	readPreference, err := readpref.New(mode)
+	if err != nil {
+		return nil, err
+	}
+
+	clientOptions := options.Client().
+		ApplyURI(mongoURI).
+		SetMaxPoolSize(opts.MaxPoolSize).
+		SetMinPoolSize(opts.MinPoolSize).
+		SetConnectTimeout(opts.ConnectTimeout).
+		SetServerSelectionTimeout(opts.ServerSelectionTimeout).
+		SetRetryWrites(opts.RetryWrites).
+		SetRetryReads(opts.RetryReads).
+		SetReadPreference(readPreference)
+
+	if opts.OperationTimeout > 0 {
+		clientOptions.SetTimeout(opts.OperationTimeout)
+	}
+	if opts.Monitor != nil {
+		clientOptions.SetMonitor(opts.Monitor)
+	}
+
+	return clientOptions, nil
+}
+
+// ping checks that a server can be selected within the timeout
+func ping(client *mongo.Client, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
 
+	return client.Ping(ctx, nil)
 }
@@ -0,0 +1,168 @@
+package mongotest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+/** *********************  THROWAWAY MONGODB FOR TESTS   ********************
+
+Integration tests run against a real single node replica set, bookings and
+cascade deletes need transactions:
+
+1. MONGO_TEST_URI is used as is when set (it must point at a replica set).
+2. Otherwise a mongo:7 container is started with the docker CLI on a random
+   local port, initiated as a replica set and removed after the package's tests.
+3. Without either the tests are skipped, so go test ./... stays green on
+   machines without Docker.
+
+Every test gets its own database, dropped when the test ends. Packages with
+integration tests call Main from their TestMain so the container is removed.
+
+ **************************************/
+
+const (
+	image      = "mongo:7"
+	replicaSet = "rs0"
+	startWait  = time.Minute //? Pulling the image on a cold machine is not counted
+)
+
+var (
+	once     sync.Once
+	uri      string
+	client   *mongo.Client
+	stop     = func() {}
+	startErr error
+)
+
+// Main runs the package's tests and removes the container afterwards
+func Main(m *testing.M) {
+	code := m.Run()
+	if client != nil {
+		client.Disconnect(context.Background())
+	}
+	stop()
+	os.Exit(code)
+}
+
+// URI returns the connection string of the throwaway MongoDB, the test is skipped when there is none
+func URI(t testing.TB) string {
+	t.Helper()
+	once.Do(start)
+	if startErr != nil {
+		t.Skipf("no MongoDB to test against: %v", startErr)
+	}
+	return uri
+}
+
+// Database returns a new empty database, dropped when the test ends
+func Database(t testing.TB) *mongo.Database {
+	t.Helper()
+	URI(t)
+
+	database := client.Database("test_" + bson.NewObjectID().Hex())
+	t.Cleanup(func() {
+		if err := database.Drop(context.Background()); err != nil {
+			t.Logf("dropping %s: %v", database.Name(), err)
+		}
+	})
+	return database
+}
+
+// start connects to MONGO_TEST_URI or to a new container
+func start() {
+	uri = os.Getenv("MONGO_TEST_URI")
+	container := uri == ""
+	if container {
+		if uri, startErr = startContainer(); startErr != nil {
+			return
+		}
+	}
+
+	if client, startErr = mongo.Connect(options.Client().ApplyURI(uri)); startErr != nil {
+		return
+	}
+	if container {
+		startErr = initiate(client)
+	}
+}
+
+// startContainer runs mongo as a replica set on a random port and returns its connection string
+func startContainer() (string, error) {
+	if _, err := exec.LookPath("docker"); err != nil {
+		return "", errors.New("MONGO_TEST_URI is not set and docker is not installed")
+	}
+
+	out, err := exec.Command("docker", "run", "--rm", "-d", "-p", "127.0.0.1::27017", image, "--replSet", replicaSet, "--bind_ip_all").Output()
+	if err != nil {
+		return "", fmt.Errorf("starting %s: %w", image, commandError(err))
+	}
+	id := strings.TrimSpace(string(out))
+	stop = func() { exec.Command("docker", "rm", "-f", id).Run() }
+
+	//? e.g. 127.0.0.1:49153, one line per address family
+	out, err = exec.Command("docker", "port", id, "27017/tcp").Output()
+	if err != nil {
+		return "", fmt.Errorf("reading the port of %s: %w", image, commandError(err))
+	}
+	address := strings.TrimSpace(strings.SplitN(string(out), "\n", 2)[0])
+
+	//! The member is known as localhost:27017 inside the container, connect to it directly
+	return "mongodb://" + address + "/?directConnection=true", nil
+}
+
+// initiate turns the new mongod into a replica set and waits until it is primary
+func initiate(client *mongo.Client) error {
+	ctx, cancel := context.WithTimeout(context.Background(), startWait)
+	defer cancel()
+	admin := client.Database("admin")
+
+	config := bson.D{
+		{Key: "_id", Value: replicaSet},
+		{Key: "members", Value: bson.A{bson.D{{Key: "_id", Value: 0}, {Key: "host", Value: "localhost:27017"}}}},
+	}
+	for {
+		err := admin.RunCommand(ctx, bson.D{{Key: "replSetInitiate", Value: config}}).Err()
+		var commandErr mongo.CommandError
+		if err == nil || (errors.As(err, &commandErr) && commandErr.Name == "AlreadyInitialized") {
+			break //? A retry can find the first attempt went through
+		}
+		if ctx.Err() != nil {
+			return fmt.Errorf("initiating the replica set: %w", err)
+		}
+		time.Sleep(500 * time.Millisecond) //? mongod is still starting
+	}
+
+	for {
+		var hello struct {
+			IsWritablePrimary bool `bson:"isWritablePrimary"`
+		}
+		if err := admin.RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&hello); err == nil && hello.IsWritablePrimary {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return errors.New("the replica set has no primary")
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+}
+
+// commandError adds what the command printed on stderr
+func commandError(err error) error {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(exitErr.Stderr)))
+	}
+	return err
+}
@@ -0,0 +1,26 @@
+package db
+
+import (
+	"context"
+	"log"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ConnectRedis connects to Redis (used for distributed booking locks)
+func ConnectRedis(redisURL string) *redis.Client {
+	opts, err := redis.ParseURL(redisURL)
+	if err != nil {
+		log.Fatal("Invalid REDIS_URL:", err)
+	}
+
+	client := redis.NewClient(opts)
+
+	if err := client.Ping(context.Background()).Err(); err != nil {
+		log.Fatal("Error pinging Redis:", err)
+	}
+
+	log.Println("Connected to Redis")
+
+	return client
+}
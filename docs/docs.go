@@ -0,0 +1,16 @@
+package docs
+
+import _ "embed"
+
+/** *********************  OPENAPI SPEC   ********************
+
+The OpenAPI document is hand-written in openapi.yaml (next to this file) and
+embedded into the binary, so the server can serve it without reading files at
+runtime. Update openapi.yaml whenever a route or payload changes.
+
+ **************************************/
+
+// OpenAPISpec is the OpenAPI 3 document for the API
+//
+//go:embed openapi.yaml
+var OpenAPISpec []byte
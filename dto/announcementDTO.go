@@ -0,0 +1,6 @@
+package dto
+
+// AnnouncementRequest is the body of POST /events/:id/announcements
+type AnnouncementRequest struct {
+	Message string `json:"message" validate:"required"`
+}
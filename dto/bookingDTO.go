@@ -0,0 +1,46 @@
+package dto
+
+// CreateBookingRequest is the body of POST /bookings/create
+type CreateBookingRequest struct {
+	EventID    string `json:"event_id" validate:"required"`
+	TicketType string `json:"ticket_type" validate:"required,oneof=VIP Regular Student"`
+	Quantity   int    `json:"quantity" validate:"required,gt=0"`
+	SessionID  string `json:"session_id"` //? Optional, for multi-session events
+	Ref        string `json:"ref"`        //? Optional, the share code from the event page's ?ref=
+
+	Attendees []AttendeeInput `json:"attendees" validate:"omitempty,dive"` //? Optional, one per ticket
+
+	CaptchaToken string `json:"captcha_token,omitempty"` //? Only needed once the buyer booked too often (428)
+
+	PolicyAcceptance //? Required for guests, users send it when they accept the documents at checkout
+
+	ClientIP string `json:"-"` //? Set by the controller, bookings per minute are also counted per IP
+}
+
+// AttendeeInput is the name (and optional email) one ticket is for
+type AttendeeInput struct {
+	Name  string `json:"name"`
+	Email string `json:"email" validate:"omitempty,email"`
+}
+
+// UpdateAttendeesRequest is the body of PUT /bookings/:id/attendees, one entry per ticket in the booking's order
+type UpdateAttendeesRequest struct {
+	Attendees []AttendeeInput `json:"attendees" validate:"required,dive"`
+}
+
+// ChangeTicketTypeRequest is the body of PUT /bookings/:id/change-type
+type ChangeTicketTypeRequest struct {
+	TicketType string `json:"ticket_type" validate:"required,oneof=VIP Regular Student"`
+}
+
+// GuestBookingRequest is the body of POST /guest/bookings, a booking without an account
+type GuestBookingRequest struct {
+	Name  string `json:"name" validate:"required"`
+	Email string `json:"email" validate:"required,email"`
+	CreateBookingRequest
+}
+
+// ClaimGuestBookingsRequest is the body of POST /guest/bookings/claim
+type ClaimGuestBookingsRequest struct {
+	Code string `json:"code" validate:"required"` //? The access code from the guest booking email
+}
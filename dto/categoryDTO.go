@@ -0,0 +1,45 @@
+package dto
+
+import (
+	"event-horizon/models"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// CreateCategoryRequest is the body of POST /categories/create
+type CreateCategoryRequest struct {
+	Name        string `json:"name" validate:"required,min=2"`
+	Description string `json:"description"`
+	ImageURL    string `json:"image_url"`
+}
+
+// ToModel maps the request to a new category (the slug is generated by the store)
+func (req *CreateCategoryRequest) ToModel() *models.Category {
+	return &models.Category{
+		Name:        req.Name,
+		Description: req.Description,
+		ImageURL:    req.ImageURL,
+	}
+}
+
+// UpdateCategoryRequest is the body of PUT /categories/:id, empty fields are left unchanged
+type UpdateCategoryRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	ImageURL    string `json:"image_url"`
+}
+
+// ToUpdate builds the $set document from the provided fields
+func (req *UpdateCategoryRequest) ToUpdate() bson.M {
+	update := bson.M{}
+	if req.Name != "" {
+		update["name"] = req.Name
+	}
+	if req.Description != "" {
+		update["description"] = req.Description
+	}
+	if req.ImageURL != "" {
+		update["image_url"] = req.ImageURL
+	}
+	return update
+}
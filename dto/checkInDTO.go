@@ -0,0 +1,19 @@
+package dto
+
+import "time"
+
+// CheckInRequest is the body of POST /events/:id/checkins, one or many codes (e.g. scanned offline and synced later)
+type CheckInRequest struct {
+	Scans []CheckInScan `json:"scans" validate:"required,min=1,max=500,dive"`
+}
+
+// CheckInScan is one scanned check-in code
+type CheckInScan struct {
+	Code      string     `json:"code" validate:"required"`
+	ScannedAt *time.Time `json:"scanned_at"` //? When the code was scanned offline, now when missing
+}
+
+// ScannerKeyRequest is the body of POST /events/:id/scanner-keys
+type ScannerKeyRequest struct {
+	Name string `json:"name" validate:"required"` //? Which device or gate it is for, e.g. "Main gate 1"
+}
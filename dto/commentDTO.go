@@ -0,0 +1,22 @@
+package dto
+
+import "event-horizon/models"
+
+// CommentRequest is the body of POST /events/:id/comments
+type CommentRequest struct {
+	Body     string `json:"body" validate:"required"`
+	ParentID string `json:"parent_id"` //? Set to reply to a top level comment
+}
+
+// CommentReportRequest is the body of POST /events/:id/comments/:commentId/report
+type CommentReportRequest struct {
+	Reason string `json:"reason" validate:"required,oneof=spam scam inappropriate other"`
+}
+
+// CommentPage is one page of the comments of an event
+type CommentPage struct {
+	Comments []models.Comment `json:"comments"`
+	Total    int64            `json:"total"`
+	Limit    int              `json:"limit"`
+	Offset   int              `json:"offset"`
+}
@@ -0,0 +1,377 @@
+package dto
+
+import (
+	"event-horizon/models"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+/** *********************  REQUEST / RESPONSE DTOs   ********************
+
+Controllers bind request bodies into these structs instead of the models, so
+clients can only send the fields an endpoint accepts. Server owned fields
+(ID, HostID, CategoryID, CreatedAt, available quantities ...) are always set
+by the controller or the store, never from JSON.
+
+
+ **************************************/
+
+// TicketRequest is a ticket type as sent by the host (availability is managed by the server)
+type TicketRequest struct {
+	Type          string  `json:"type" validate:"required,oneof=VIP Regular Student"`
+	Price         float64 `json:"price" validate:"gte=0"` //? 0 for a free ticket
+	TotalQuantity int     `json:"total_quantity" validate:"required,gt=0"`
+
+	SaleStart *time.Time `json:"sale_start,omitempty"` //? Optional sale window of the ticket type
+	SaleEnd   *time.Time `json:"sale_end,omitempty"`
+}
+
+// SessionRequest is a session of a multi-session event, send the ID to keep an existing session
+type SessionRequest struct {
+	ID        string    `json:"id,omitempty"`
+	Title     string    `json:"title" validate:"required"`
+	StartTime time.Time `json:"start_time" validate:"required"`
+	EndTime   time.Time `json:"end_time" validate:"required"`
+	Capacity  int       `json:"capacity,omitempty"` //? 0 means unlimited
+}
+
+// CreateEventRequest is the body of POST /events/create
+type CreateEventRequest struct {
+	Name         string           `json:"name" validate:"required"`
+	Description  string           `json:"description"`
+	CategoryName string           `json:"category_name" validate:"required"`
+	Tags         []string         `json:"tags,omitempty"`
+	Date         time.Time        `json:"date" validate:"required"`
+	Timezone     string           `json:"timezone"`
+	Currency     string           `json:"currency"` //? ISO 4217, USD when empty
+	TaxRate      float64          `json:"tax_rate"` //? Percent, 0 means no tax
+	TaxInclusive bool             `json:"tax_inclusive"`
+	Location     string           `json:"location" validate:"required"`
+	GeoLocation  *models.GeoPoint `json:"geo_location,omitempty"`
+	VenueID      *bson.ObjectID   `json:"venue_id,omitempty"` //? Optional, location and geo_location are taken from the venue
+	EventType    string           `json:"event_type"`
+	StreamURL    string           `json:"stream_url,omitempty"`
+	ImageURL     string           `json:"image_url"`
+	StartTime    time.Time        `json:"start_time" validate:"required"`
+	EndTime      time.Time        `json:"end_time" validate:"required"`
+	Tickets      []TicketRequest  `json:"tickets" validate:"dive,required"`
+	Sessions     []SessionRequest `json:"sessions,omitempty" validate:"dive"`
+
+	CapacityAlerts   *models.CapacityAlertSettings `json:"capacity_alerts,omitempty"` //? Optional, 50/90/100% in-app and email when not sent
+	HideTicketCounts bool                          `json:"hide_ticket_counts"`        //? Public responses only show available / low / sold_out
+	PublishAt        *time.Time                    `json:"publish_at,omitempty"`      //? Create only, the event is saved as a draft and goes live at this time
+
+	AllowVenueOverlap bool `json:"allow_venue_overlap"` //? Keep the event even though another one runs at its venue at the same time
+}
+
+// UpdateEventRequest is the body of PUT /events/:id, which replaces the whole event
+type UpdateEventRequest struct {
+	CreateEventRequest
+	Version *int `json:"version" validate:"required"` //? The version the client read, a stale one is rejected with 409
+}
+
+// ToModel maps the request to a new event, every ticket starts fully available
+func (req *CreateEventRequest) ToModel() *models.Event {
+	event := &models.Event{
+		Name:         req.Name,
+		Description:  req.Description,
+		CategoryName: req.CategoryName,
+		Tags:         req.Tags,
+		Date:         req.Date,
+		Timezone:     req.Timezone,
+		Currency:     req.Currency,
+		TaxRate:      req.TaxRate,
+		TaxInclusive: req.TaxInclusive,
+		Location:     req.Location,
+		GeoLocation:  req.GeoLocation,
+		EventType:    req.EventType,
+		StreamURL:    req.StreamURL,
+		ImageURL:     req.ImageURL,
+		StartTime:    req.StartTime,
+		EndTime:      req.EndTime,
+		Tickets:      make([]models.TicketInfo, 0, len(req.Tickets)),
+
+		CapacityAlerts:   req.CapacityAlerts,
+		HideTicketCounts: req.HideTicketCounts,
+		PublishAt:        req.PublishAt,
+
+		AllowVenueOverlap: req.AllowVenueOverlap,
+	}
+	if req.VenueID != nil {
+		event.VenueID = models.OptionalID(*req.VenueID)
+	}
+
+	for _, ticket := range req.Tickets {
+		event.Tickets = append(event.Tickets, models.TicketInfo{
+			Type:              ticket.Type,
+			Price:             ticket.Price,
+			TotalQuantity:     ticket.TotalQuantity,
+			AvailableQuantity: ticket.TotalQuantity,
+			SaleStart:         ticket.SaleStart,
+			SaleEnd:           ticket.SaleEnd,
+		})
+	}
+
+	for _, session := range req.Sessions {
+		//? An unknown or missing ID means a new session
+		sessionID, _ := bson.ObjectIDFromHex(session.ID)
+
+		event.Sessions = append(event.Sessions, models.Session{
+			ID:        sessionID,
+			Title:     session.Title,
+			StartTime: session.StartTime,
+			EndTime:   session.EndTime,
+			Capacity:  session.Capacity,
+		})
+	}
+
+	return event
+}
+
+// NewEventResponse maps an event to the response returned after create and update
+func NewEventResponse(event *models.Event) *models.EventResponse {
+	return &models.EventResponse{
+		ID:               event.ID,
+		Name:             event.Name,
+		Description:      event.Description,
+		HostID:           event.HostID,
+		HostVerified:     event.HostVerified,
+		OrgID:            event.OrgID,
+		CoHosts:          event.CoHosts,
+		CategoryID:       event.CategoryID,
+		CategoryName:     event.CategoryName,
+		Tags:             event.Tags,
+		Date:             event.Date,
+		Timezone:         event.Timezone,
+		Currency:         event.Currency,
+		TaxRate:          event.TaxRate,
+		TaxInclusive:     event.TaxInclusive,
+		StartTime:        event.StartTime,
+		EndTime:          event.EndTime,
+		Location:         event.Location,
+		GeoLocation:      event.GeoLocation,
+		VenueID:          event.VenueID,
+		EventType:        event.EventType,
+		StreamURL:        event.StreamURL,
+		ImageURL:         event.ImageURL,
+		Status:           event.Status,
+		PublishAt:        event.PublishAt,
+		ModerationReason: event.ModerationReason,
+		CreatedAt:        event.CreatedAt,
+		UpdatedAt:        event.UpdatedAt,
+		Version:          event.Version,
+		Tickets:          event.Tickets,
+		Sessions:         event.Sessions,
+
+		CapacityAlerts:   event.CapacityAlerts,
+		HideTicketCounts: event.HideTicketCounts,
+		StockFlag:        models.EventStockFlag(event.Tickets),
+		Reschedule:       event.Reschedule,
+		VenueConflicts:   event.VenueConflicts,
+	}
+}
+
+// PatchEventRequest is the body of PATCH /events/:id, only the fields that are sent get updated
+type PatchEventRequest struct {
+	Name         *string           `json:"name"`
+	Description  *string           `json:"description"`
+	CategoryName *string           `json:"category_name"`
+	Tags         *[]string         `json:"tags"`
+	Date         *time.Time        `json:"date"`
+	Timezone     *string           `json:"timezone"`
+	Currency     *string           `json:"currency"`
+	TaxRate      *float64          `json:"tax_rate"`
+	TaxInclusive *bool             `json:"tax_inclusive"`
+	Location     *string           `json:"location"`
+	GeoLocation  *models.GeoPoint  `json:"geo_location"`
+	VenueID      *bson.ObjectID    `json:"venue_id"` //? "" takes the event out of its venue
+	EventType    *string           `json:"event_type"`
+	StreamURL    *string           `json:"stream_url"`
+	ImageURL     *string           `json:"image_url"`
+	StartTime    *time.Time        `json:"start_time"`
+	EndTime      *time.Time        `json:"end_time"`
+	Tickets      *[]TicketRequest  `json:"tickets"`
+	Sessions     *[]SessionRequest `json:"sessions"`
+	Version      *int              `json:"version"` //? Optional, when sent the patch is rejected with 409 if the event moved on
+
+	CapacityAlerts   *models.CapacityAlertSettings `json:"capacity_alerts"`
+	HideTicketCounts *bool                         `json:"hide_ticket_counts"`
+
+	AllowVenueOverlap bool `json:"allow_venue_overlap"` //? Keep the new venue or times even though another event runs there then
+}
+
+// ApplyTo copies the provided fields onto the event and returns their bson field names
+func (req *PatchEventRequest) ApplyTo(event *models.Event) []string {
+	var fields []string
+
+	if req.Name != nil {
+		event.Name = *req.Name
+		fields = append(fields, "name")
+	}
+	if req.Description != nil {
+		event.Description = *req.Description
+		fields = append(fields, "description")
+	}
+	if req.CategoryName != nil {
+		event.CategoryName = *req.CategoryName
+		fields = append(fields, "category_name")
+	}
+	if req.Tags != nil {
+		event.Tags = *req.Tags
+		fields = append(fields, "tags")
+	}
+	if req.Date != nil {
+		event.Date = *req.Date
+		fields = append(fields, "date")
+	}
+	if req.Timezone != nil {
+		event.Timezone = *req.Timezone
+		fields = append(fields, "timezone")
+	}
+	if req.Currency != nil {
+		event.Currency = *req.Currency
+		fields = append(fields, "currency")
+	}
+	if req.TaxRate != nil {
+		event.TaxRate = *req.TaxRate
+		fields = append(fields, "tax_rate")
+	}
+	if req.TaxInclusive != nil {
+		event.TaxInclusive = *req.TaxInclusive
+		fields = append(fields, "tax_inclusive")
+	}
+	if req.Location != nil {
+		event.Location = *req.Location
+		fields = append(fields, "location")
+	}
+	if req.GeoLocation != nil {
+		event.GeoLocation = req.GeoLocation
+		fields = append(fields, "geo_location")
+	}
+	if req.VenueID != nil {
+		event.VenueID = models.OptionalID(*req.VenueID)
+		fields = append(fields, "venue_id")
+	}
+	if req.EventType != nil {
+		event.EventType = *req.EventType
+		fields = append(fields, "event_type")
+	}
+	if req.StreamURL != nil {
+		event.StreamURL = *req.StreamURL
+		fields = append(fields, "stream_url")
+	}
+	if req.ImageURL != nil {
+		event.ImageURL = *req.ImageURL
+		fields = append(fields, "image_url")
+	}
+	if req.StartTime != nil {
+		event.StartTime = *req.StartTime
+		fields = append(fields, "start_time")
+	}
+	if req.EndTime != nil {
+		event.EndTime = *req.EndTime
+		fields = append(fields, "end_time")
+	}
+	if req.CapacityAlerts != nil {
+		event.CapacityAlerts = req.CapacityAlerts
+		fields = append(fields, "capacity_alerts")
+	}
+	if req.HideTicketCounts != nil {
+		event.HideTicketCounts = *req.HideTicketCounts
+		fields = append(fields, "hide_ticket_counts")
+	}
+	event.AllowVenueOverlap = req.AllowVenueOverlap //? Not a field, only tells the service the host accepts an overlap
+	if req.Tickets != nil || req.Sessions != nil {
+		//? Reuse the create mapping for tickets and sessions
+		full := CreateEventRequest{}
+		if req.Tickets != nil {
+			full.Tickets = *req.Tickets
+		}
+		if req.Sessions != nil {
+			full.Sessions = *req.Sessions
+		}
+		mapped := full.ToModel()
+
+		if req.Tickets != nil {
+			event.Tickets = mapped.Tickets
+			fields = append(fields, "tickets")
+		}
+		if req.Sessions != nil {
+			event.Sessions = mapped.Sessions
+			fields = append(fields, "sessions")
+		}
+	}
+
+	return fields
+}
+
+// SchedulePublishRequest is the body of PUT /events/:id/schedule
+type SchedulePublishRequest struct {
+	PublishAt time.Time `json:"publish_at" validate:"required"`
+}
+
+// DuplicateEventRequest is the body of POST /events/:id/duplicate, the copy gets these new dates
+type DuplicateEventRequest struct {
+	Date      time.Time `json:"date" validate:"required"`
+	StartTime time.Time `json:"start_time" validate:"required"`
+	EndTime   time.Time `json:"end_time" validate:"required"`
+
+	AllowVenueOverlap bool `json:"allow_venue_overlap"` //? Keep the copy even though another event runs at its venue then
+}
+
+// RescheduleEventRequest is the body of POST /events/:id/reschedule, sessions move along with the new start time
+type RescheduleEventRequest struct {
+	Date          time.Time `json:"date" validate:"required"`
+	StartTime     time.Time `json:"start_time" validate:"required"`
+	EndTime       time.Time `json:"end_time" validate:"required"`
+	ReconfirmDays int       `json:"reconfirm_days"` //? Optional, how long attendees have to reconfirm (default RECONFIRM_WINDOW)
+
+	AllowVenueOverlap bool `json:"allow_venue_overlap"` //? Move the event even though another one runs at its venue then
+}
+
+// ShareLinkRequest is the body of POST /events/:id/share-links
+type ShareLinkRequest struct {
+	Channel     string `json:"channel" validate:"required"` //? instagram, newsletter ...
+	UTMSource   string `json:"utm_source"`                  //? Defaults to the channel
+	UTMMedium   string `json:"utm_medium"`
+	UTMCampaign string `json:"utm_campaign"`
+}
+
+// BulkEventRequest is the body of POST /events/bulk, one action for many of the host's events
+type BulkEventRequest struct {
+	Action       string   `json:"action" validate:"required"` //? publish, unpublish, cancel, delete or change_category
+	EventIDs     []string `json:"event_ids" validate:"required,min=1,max=100"`
+	CategoryName string   `json:"category_name"` //? Only for change_category
+}
+
+// BulkPriceRequest is the body of PUT /hosts/me/tickets/bulk-price, exactly one of percent and amount is sent
+type BulkPriceRequest struct {
+	EventIDs    []string `json:"event_ids" validate:"required,min=1,max=100"`
+	TicketTypes []string `json:"ticket_types"` //? Only these ticket types (e.g. VIP), every type when empty
+	Percent     *float64 `json:"percent"`      //? e.g. 10 raises prices by 10%, -20 lowers them by 20%
+	Amount      *float64 `json:"amount"`       //? Added to the price in the event's currency, e.g. 5 or -2.5
+	DryRun      bool     `json:"dry_run"`      //? Only preview the new prices
+}
+
+// SaveTemplateRequest is the body of POST /hosts/me/templates, the event is saved under the name
+type SaveTemplateRequest struct {
+	EventID string `json:"event_id" validate:"required"`
+	Name    string `json:"name" validate:"required"`
+}
+
+// EventFromTemplateRequest is the body of POST /events/from-template/:templateId, the new event gets these dates
+type EventFromTemplateRequest struct {
+	DuplicateEventRequest
+	Name string `json:"name"` //? Optional, the name of the template's event by default
+}
+
+// VenueRequest is the body of POST /venues and PUT /venues/:id
+type VenueRequest struct {
+	Name        string           `json:"name" validate:"required"`
+	Address     string           `json:"address" validate:"required"`
+	City        string           `json:"city"`
+	GeoLocation *models.GeoPoint `json:"geo_location,omitempty"`
+	Capacity    int              `json:"capacity"`
+	Amenities   []string         `json:"amenities,omitempty"`
+}
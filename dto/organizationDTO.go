@@ -0,0 +1,17 @@
+package dto
+
+// OrganizationRequest is the body of POST /orgs
+type OrganizationRequest struct {
+	Name string `json:"name" validate:"required"`
+}
+
+// OrgMemberRequest is the body of POST /orgs/:id/members
+type OrgMemberRequest struct {
+	Email string `json:"email" validate:"required,email"`
+	Role  string `json:"role" validate:"required,oneof=manager scanner"` //? Managers must be hosts, scanners can be any user
+}
+
+// UpdateOrgMemberRequest is the body of PATCH /orgs/:id/members/:userId
+type UpdateOrgMemberRequest struct {
+	Role string `json:"role" validate:"required,oneof=manager scanner"`
+}
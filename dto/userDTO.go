@@ -0,0 +1,160 @@
+package dto
+
+import (
+	"event-horizon/models"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// RegisterRequest is the body of POST /users/register
+type RegisterRequest struct {
+	Name     string `json:"name" validate:"required"`
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required,min=6"`
+	Language string `json:"language,omitempty"` //? Optional, language of the emails
+
+	CaptchaToken string `json:"captcha_token,omitempty"` //? Token of the solved CAPTCHA, when CAPTCHAs are on
+
+	SignInOptions
+	PolicyAcceptance
+}
+
+// PolicyAcceptance are the versions of the terms and privacy policy the user accepted, the current ones are at GET /policies.
+// It is also the body of POST /users/me/consents
+type PolicyAcceptance struct {
+	TermsVersion   string `json:"terms_version,omitempty"`
+	PrivacyVersion string `json:"privacy_version,omitempty"`
+}
+
+// SignInOptions choose how long the new session lasts and how its token is delivered
+type SignInOptions struct {
+	RememberMe bool `json:"remember_me,omitempty"` //? TOKEN_TTL instead of the short SESSION_TTL
+	UseCookie  bool `json:"use_cookie,omitempty"`  //? Token in an HttpOnly cookie instead of the response body (web app)
+}
+
+// ToModel maps the request to a new regular user (roles are never taken from the request)
+func (req *RegisterRequest) ToModel() *models.User {
+	return &models.User{
+		Name:     req.Name,
+		Email:    req.Email,
+		Password: req.Password,
+		Language: req.Language,
+	}
+}
+
+// UpdatePhoneRequest is the body of PUT /users/me/phone
+type UpdatePhoneRequest struct {
+	Phone string `json:"phone"` //? E.164, e.g. +14155550123
+}
+
+// VerifyPhoneRequest is the body of POST /users/me/phone/verify
+type VerifyPhoneRequest struct {
+	Code string `json:"code"`
+}
+
+// PreferencesResponse is the body of GET and PUT /users/me/preferences
+type PreferencesResponse struct {
+	Notifications models.NotificationPreferences `json:"notifications"` //? Every type and channel users can turn off, with its setting
+	Channels      map[string][]string            `json:"channels"`      //? The channels each notification type goes out on
+}
+
+// NewPreferencesResponse maps a user's preferences to their API response, defaults filled in
+func NewPreferencesResponse(preferences models.UserPreferences) *PreferencesResponse {
+	return &PreferencesResponse{
+		Notifications: preferences.Notifications.Effective(),
+		Channels:      models.NotificationChannels,
+	}
+}
+
+// UpdatePreferencesRequest is the body of PUT /users/me/preferences
+type UpdatePreferencesRequest struct {
+	Notifications models.NotificationPreferences `json:"notifications"` //? Type -> channel -> on / off, anything left out is on
+}
+
+// UpdateLanguageRequest is the body of PUT /users/me/language
+type UpdateLanguageRequest struct {
+	Language string `json:"language"` //? Empty goes back to the default (English)
+}
+
+// DeleteAccountRequest is the body of DELETE /users/me
+type DeleteAccountRequest struct {
+	Password string `json:"password" validate:"required"` //? Asked again, deleting can't be undone
+}
+
+// LoginRequest is the body of POST /users/login
+type LoginRequest struct {
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required"`
+
+	CaptchaToken string `json:"captcha_token,omitempty"` //? Only needed after failed sign ins (428)
+
+	SignInOptions
+}
+
+// UserResponse is the user data returned in API responses (without password)
+type UserResponse struct {
+	ID        bson.ObjectID `json:"id"`
+	Name      string        `json:"name"`
+	Email     string        `json:"email"`
+	IsHost    bool          `json:"is_host"`
+	IsAdmin   bool          `json:"is_admin"`
+	CreatedAt time.Time     `json:"created_at"`
+	Language  string        `json:"language,omitempty"`
+
+	Phone           string     `json:"phone,omitempty"`
+	PhoneVerifiedAt *time.Time `json:"phone_verified_at,omitempty"`
+
+	SuspendedAt *time.Time `json:"suspended_at,omitempty"`
+	VerifiedAt  *time.Time `json:"verified_at,omitempty"` //? VERIFIED HOST, may sell paid tickets
+}
+
+// NewUserResponse maps a user to its API response
+func NewUserResponse(user *models.User) *UserResponse {
+	return &UserResponse{
+		ID:        user.ID,
+		Name:      user.Name,
+		Email:     user.Email,
+		IsHost:    user.IsHost,
+		IsAdmin:   user.IsAdmin,
+		CreatedAt: user.CreatedAt,
+		Language:  user.Language,
+
+		Phone:           user.Phone,
+		PhoneVerifiedAt: user.PhoneVerifiedAt,
+
+		SuspendedAt: user.SuspendedAt,
+		VerifiedAt:  user.VerifiedAt,
+	}
+}
+
+// AffiliateRequest is the body of POST /hosts/me/affiliates
+type AffiliateRequest struct {
+	Name              string  `json:"name" validate:"required"`
+	Email             string  `json:"email" validate:"omitempty,email"`
+	CommissionPercent float64 `json:"commission_percent" validate:"gt=0,lte=100"`
+}
+
+// UpdateAffiliateRequest is the body of PATCH /hosts/me/affiliates/:id, only the sent fields change
+type UpdateAffiliateRequest struct {
+	CommissionPercent *float64 `json:"commission_percent"` //? Only new bookings earn the new commission
+	Active            *bool    `json:"active"`
+}
+
+// HostVerificationRequest is the body of POST /hosts/me/verification, the fields of the kind are required
+type HostVerificationRequest struct {
+	Kind      string `json:"kind"` //? document or payout_account
+	LegalName string `json:"legal_name"`
+	Country   string `json:"country"` //? ISO 3166 alpha-2, e.g. US
+
+	DocumentType string `json:"document_type,omitempty"` //? passport, id_card, drivers_license, business_registration
+	DocumentURL  string `json:"document_url,omitempty"`  //? https URL of the uploaded document
+
+	PayoutProvider string `json:"payout_provider,omitempty"` //? bank, stripe, paypal ...
+	PayoutAccount  string `json:"payout_account,omitempty"`  //? IBAN, account number or account ID, only the last 4 are ever shown
+}
+
+// ReviewVerificationRequest is the body of POST /admin/verifications/:id/approve and /reject
+type ReviewVerificationRequest struct {
+	Note string `json:"note"` //? Required to reject, the host is told why
+}
@@ -0,0 +1,135 @@
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"event-horizon/reporting"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+/** *********************  OUTBOUND EVENT BUS   ********************
+
+Services publish DOMAIN EVENTS (a booking was created, an event was
+cancelled ...) to a Publisher, so other services (email, analytics, a data
+warehouse) can react to them without this API calling them.
+
+The Publisher is pluggable (EVENT_BUS):
+
+1. none  - NopPublisher, nothing is sent (default)
+2. log   - LogPublisher, every message is written to the log (local development)
+3. redis - RedisStreamPublisher, messages are appended to a Redis Stream that consumers read with XREADGROUP
+
+NATS, Kafka or RabbitMQ only need another Publisher, the services don't change.
+
+Publishing is asynchronous (AsyncPublisher): a slow or unreachable broker
+never fails or holds up the request, messages are dropped (and logged)
+when the queue is full.
+
+ **************************************/
+
+// Message types
+const (
+	BookingCreated   = "booking.created"
+	BookingCancelled = "booking.cancelled"
+	BookingChanged   = "booking.changed" //? The ticket type (and so the price) changed
+	EventCreated     = "event.created"
+	EventUpdated     = "event.updated"
+	EventPublished   = "event.published"
+	EventCancelled   = "event.cancelled" //? The host deleted the event
+)
+
+// Message is one domain event
+type Message struct {
+	ID         string      `json:"id"` //? Unique, consumers can use it to drop duplicates
+	Type       string      `json:"type"`
+	OccurredAt time.Time   `json:"occurred_at"`
+	Data       interface{} `json:"data"`
+}
+
+// NewMessage creates a message of the given type
+func NewMessage(messageType string, data interface{}) Message {
+	return Message{
+		ID:         bson.NewObjectID().Hex(),
+		Type:       messageType,
+		OccurredAt: time.Now().UTC(),
+		Data:       data,
+	}
+}
+
+// Publisher sends domain events to a message broker
+type Publisher interface {
+	Publish(ctx context.Context, msg Message) error
+}
+
+// NopPublisher drops every message
+type NopPublisher struct{}
+
+// Publish does nothing
+func (NopPublisher) Publish(ctx context.Context, msg Message) error {
+	return nil
+}
+
+// LogPublisher writes every message to the log
+type LogPublisher struct{}
+
+// Publish logs the message
+func (LogPublisher) Publish(ctx context.Context, msg Message) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	log.Printf("EVENT BUS %s", data)
+	return nil
+}
+
+// publishTimeout is how long the background worker gives the broker for one message
+const publishTimeout = 5 * time.Second
+
+// AsyncPublisher hands messages to a background worker that publishes them to the next Publisher
+type AsyncPublisher struct {
+	next  Publisher
+	queue chan Message
+}
+
+// NewAsyncPublisher starts the worker, buffer is how many messages may wait for the broker
+func NewAsyncPublisher(next Publisher, buffer int) *AsyncPublisher {
+	publisher := &AsyncPublisher{
+		next:  next,
+		queue: make(chan Message, buffer),
+	}
+
+	//! RUN IN CONCURRENT GO ROUTINE
+	go func() {
+		for msg := range publisher.queue {
+			publisher.publish(msg)
+		}
+	}()
+
+	return publisher
+}
+
+// publish hands one message to the broker, a panic is reported and the worker goes on with the next message
+func (p *AsyncPublisher) publish(msg Message) {
+	defer reporting.Recover("event bus publisher")
+
+	ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+	defer cancel()
+
+	if err := p.next.Publish(ctx, msg); err != nil {
+		log.Printf("Error publishing %s message %s: %v", msg.Type, msg.ID, err)
+	}
+}
+
+// Publish queues the message, the request's context is not used since it ends with the request
+func (p *AsyncPublisher) Publish(ctx context.Context, msg Message) error {
+	select {
+	case p.queue <- msg:
+	default:
+		//! Never block the request if the queue is full
+		log.Printf("Event bus queue full, dropping %s message %s", msg.Type, msg.ID)
+	}
+	return nil
+}
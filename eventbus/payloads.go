@@ -0,0 +1,86 @@
+package eventbus
+
+import (
+	"event-horizon/models"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+//! THE DATA OF EVERY MESSAGE TYPE, CONSUMERS DEPEND ON THESE FIELDS SO ONLY ADD NEW ONES
+
+// BookingData is the data of booking.* messages (check-in codes and guest codes are left out)
+type BookingData struct {
+	BookingID      bson.ObjectID `json:"booking_id"`
+	TransactionID  string        `json:"transaction_id"`
+	EventID        bson.ObjectID `json:"event_id"`
+	SessionID      string        `json:"session_id,omitempty"`
+	UserID         string        `json:"user_id,omitempty"`  //? Empty for guest bookings
+	GuestID        string        `json:"guest_id,omitempty"` //? Only for guest bookings
+	TicketType     string        `json:"ticket_type"`
+	Quantity       int           `json:"quantity"`
+	TotalPaidMinor int64         `json:"total_paid_minor"`
+	TaxMinor       int64         `json:"tax_minor"`
+	Currency       string        `json:"currency"`
+	BookedAt       time.Time     `json:"booked_at"`
+
+	DifferenceMinor int64 `json:"difference_minor,omitempty"` //? booking.changed only, > 0 to charge, < 0 to refund
+}
+
+// optionalID is the hex of an ID, empty when it is not set
+func optionalID(id bson.ObjectID) string {
+	if id.IsZero() {
+		return ""
+	}
+	return id.Hex()
+}
+
+// NewBookingMessage creates a booking.* message
+func NewBookingMessage(messageType string, booking *models.Booking) Message {
+	data := BookingData{
+		BookingID:      booking.ID,
+		TransactionID:  booking.TransactionID,
+		EventID:        booking.EventID,
+		SessionID:      optionalID(booking.SessionID),
+		UserID:         optionalID(booking.UserID),
+		GuestID:        optionalID(booking.GuestID),
+		TicketType:     booking.TicketType,
+		Quantity:       booking.Quantity,
+		TotalPaidMinor: booking.TotalPaidMinor,
+		TaxMinor:       booking.TaxMinor,
+		Currency:       booking.Currency,
+		BookedAt:       booking.BookedAt,
+	}
+	if messageType == BookingChanged && len(booking.Adjustments) > 0 {
+		data.DifferenceMinor = booking.Adjustments[len(booking.Adjustments)-1].DifferenceMinor
+	}
+	return NewMessage(messageType, data)
+}
+
+// EventData is the data of event.* messages
+type EventData struct {
+	EventID      bson.ObjectID `json:"event_id"`
+	HostID       bson.ObjectID `json:"host_id"`
+	Name         string        `json:"name"`
+	CategoryName string        `json:"category_name"`
+	Status       string        `json:"status,omitempty"`
+	Currency     string        `json:"currency"`
+	StartTime    time.Time     `json:"start_time"`
+	EndTime      time.Time     `json:"end_time"`
+	Version      int           `json:"version"`
+}
+
+// NewEventMessage creates an event.* message
+func NewEventMessage(messageType string, event *models.Event) Message {
+	return NewMessage(messageType, EventData{
+		EventID:      event.ID,
+		HostID:       event.HostID,
+		Name:         event.Name,
+		CategoryName: event.CategoryName,
+		Status:       event.Status,
+		Currency:     event.Currency,
+		StartTime:    event.StartTime,
+		EndTime:      event.EndTime,
+		Version:      event.Version,
+	})
+}
@@ -0,0 +1,43 @@
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisStreamPublisher appends messages to a Redis Stream
+type RedisStreamPublisher struct {
+	client *redis.Client
+	stream string
+	maxLen int64
+}
+
+// NewRedisStreamPublisher creates a publisher for the stream, it is trimmed to about maxLen messages (0 keeps all)
+func NewRedisStreamPublisher(client *redis.Client, stream string, maxLen int64) *RedisStreamPublisher {
+	return &RedisStreamPublisher{
+		client: client,
+		stream: stream,
+		maxLen: maxLen,
+	}
+}
+
+// Publish adds the message to the stream, the type is a field of its own so consumers can filter without decoding
+func (p *RedisStreamPublisher) Publish(ctx context.Context, msg Message) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	return p.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: p.stream,
+		MaxLen: p.maxLen,
+		Approx: true,
+		Values: map[string]interface{}{
+			"id":   msg.ID,
+			"type": msg.Type,
+			"data": data,
+		},
+	}).Err()
+}
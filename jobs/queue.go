@@ -0,0 +1,171 @@
+package jobs
+
+import (
+	"context"
+	"event-horizon/models"
+	"event-horizon/reporting"
+	"event-horizon/store"
+	"fmt"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+/** *********************  JOB QUEUE   ********************
+
+Work that must not run inside a request (booking emails, capacity alerts,
+affiliate commissions ...) is ENQUEUED as a Job in the Jobs collection and
+run by a pool of workers (JOB_WORKERS), so it survives restarts and is
+shared by every instance of the API.
+
+1. A worker claims the oldest due job and holds it for jobLease, a job of a
+   worker that died is claimed again once the lease is over.
+2. A handler that returns an error (or panics) is retried with exponential
+   backoff: 30s, 1m, 2m ... at most retryBackoffCap apart.
+3. After JOB_MAX_ATTEMPTS failures the job is DEAD (dead-lettered), admins
+   list dead jobs at GET /admin/queue/jobs and put them back with
+   POST /admin/queue/jobs/:id/retry.
+4. Handler panics and dead jobs go to the error reporter (log or Sentry).
+
+Handlers run AT LEAST ONCE, a job can run again after a crash, so they must
+not mind repeating themselves.
+
+ **************************************/
+
+// Timings of the workers
+const (
+	jobTimeout       = 2 * time.Minute
+	jobLease         = 5 * time.Minute //? Longer than jobTimeout, a running job is never claimed twice
+	retryBackoffBase = 30 * time.Second
+	retryBackoffCap  = time.Hour
+)
+
+// Handler runs one job, returning an error retries it
+type Handler func(ctx context.Context, payload bson.Raw) error
+
+// Queue enqueues jobs and runs them with the handler registered for their type
+type Queue struct {
+	store       store.JobRepository
+	handlers    map[string]Handler
+	maxAttempts int
+}
+
+// NewQueue creates a queue on the job store, a job is dead after maxAttempts failures
+func NewQueue(jobs store.JobRepository, maxAttempts int) *Queue {
+	return &Queue{
+		store:       jobs,
+		handlers:    make(map[string]Handler),
+		maxAttempts: maxAttempts,
+	}
+}
+
+// Register sets the handler of a job type, register every handler before Start
+func (q *Queue) Register(jobType string, handler Handler) {
+	q.handlers[jobType] = handler
+}
+
+// Enqueue stores a job of the given type, payload is marshalled to BSON and given back to the handler
+func (q *Queue) Enqueue(ctx context.Context, jobType string, payload interface{}) error {
+	raw, err := bson.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("encoding the payload of a %s job: %w", jobType, err)
+	}
+	return q.store.EnqueueJob(ctx, &models.Job{Type: jobType, Payload: raw, MaxAttempts: q.maxAttempts})
+}
+
+// Start starts the workers, each one looks for a due job every pollInterval while the queue is empty
+func (q *Queue) Start(workers int, pollInterval time.Duration) {
+	for i := 0; i < workers; i++ {
+		//! RUN IN CONCURRENT GO ROUTINE
+		go q.work(pollInterval)
+	}
+	log.Printf("Job queue started with %d workers", workers)
+}
+
+// work runs jobs back to back and only sleeps when there is nothing to do
+func (q *Queue) work(pollInterval time.Duration) {
+	for {
+		job, err := q.store.ClaimJob(context.Background(), jobLease)
+		if err != nil {
+			log.Println("Job queue: error claiming a job:", err)
+		}
+		if job == nil {
+			time.Sleep(pollInterval)
+			continue
+		}
+		q.run(job)
+	}
+}
+
+// run runs a claimed job and records the outcome
+func (q *Queue) run(job *models.Job) {
+	err := q.handle(job)
+	if err == nil {
+		if err := q.store.CompleteJob(context.Background(), job.ID); err != nil {
+			log.Printf("Job queue: error completing job %s: %v", job.ID.Hex(), err)
+		}
+		return
+	}
+
+	var retryAt *time.Time
+	if job.Attempts < job.MaxAttempts {
+		next := time.Now().Add(Backoff(job.Attempts))
+		retryAt = &next
+		log.Printf("Job queue: %s job %s failed (attempt %d of %d), retrying at %s: %v", job.Type, job.ID.Hex(), job.Attempts, job.MaxAttempts, next.Format(time.RFC3339), err)
+	} else {
+		log.Printf("Job queue: %s job %s failed %d times, it is dead: %v", job.Type, job.ID.Hex(), job.Attempts, err)
+		reporting.CaptureError(context.Background(), err, "job "+job.Type, map[string]string{"job.id": job.ID.Hex()})
+	}
+
+	if err := q.store.FailJob(context.Background(), job.ID, err.Error(), retryAt); err != nil {
+		log.Printf("Job queue: error recording the failure of job %s: %v", job.ID.Hex(), err)
+	}
+}
+
+// handle calls the job's handler, a panic fails the job instead of the worker
+func (q *Queue) handle(job *models.Job) (err error) {
+	handler, ok := q.handlers[job.Type]
+	if !ok {
+		return fmt.Errorf("no handler for job type %q", job.Type)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			reporting.CapturePanic(context.Background(), r, "job "+job.Type, map[string]string{"job.id": job.ID.Hex()})
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), jobTimeout)
+	defer cancel()
+	return handler(ctx, job.Payload)
+}
+
+// Backoff is how long a job waits after its attempt-th failure
+func Backoff(attempt int) time.Duration {
+	delay := retryBackoffBase
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= retryBackoffCap {
+			return retryBackoffCap
+		}
+	}
+	return delay
+}
+
+// Hook registers handler for jobType and returns a function that enqueues its argument as a job of that type,
+// it fits the booking hooks of the services so their work gets retries (a failed enqueue is returned to the outbox)
+func Hook[T any](q *Queue, jobType string, handler func(ctx context.Context, payload T) error) func(ctx context.Context, payload T) error {
+	q.Register(jobType, func(ctx context.Context, raw bson.Raw) error {
+		var payload T
+		if err := bson.Unmarshal(raw, &payload); err != nil {
+			return fmt.Errorf("decoding the payload: %w", err)
+		}
+		return handler(ctx, payload)
+	})
+
+	return func(ctx context.Context, payload T) error {
+		return q.Enqueue(ctx, jobType, payload)
+	}
+}
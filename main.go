@@ -1,18 +1,34 @@
 package main
 
 import (
+	"context"
+	"event-horizon/config"
 	"event-horizon/controllers"
 	"event-horizon/db"
+	"event-horizon/docs"
+	"event-horizon/eventbus"
+	"event-horizon/jobs"
+	appMiddleware "event-horizon/middleware"
+	"event-horizon/migrations"
+	"event-horizon/models"
+	"event-horizon/realtime"
+	"event-horizon/reporting"
 	"event-horizon/routes"
+	"event-horizon/search"
+	"event-horizon/services"
 	"event-horizon/store"
+	"event-horizon/tracing"
 	"event-horizon/utils"
 
+	"log"
 	"net/http"
-	"os"
+	"strings"
+	"time"
 
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
+	"github.com/redis/go-redis/v9"
 )
 
 type User struct {
@@ -22,51 +38,480 @@ type User struct {
 
 func main() {
 
+	// LOAD AND VALIDATE CONFIG
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal("Invalid configuration: ", err)
+	}
+
+	e := newServer(cfg)
+	e.Logger.Fatal(e.Start(":" + cfg.Port))
+}
+
+// newServer connects to the databases, starts the background workers and returns the app with every route registered
+func newServer(cfg *config.Config) *echo.Echo {
+	utils.ConfigureJWT(cfg.JWTSecret, cfg.TokenTTL, cfg.SessionTTL)
+
 	e := echo.New()
-	database := db.ConnectDB()
+
+	//! c.RealIP() feeds rate limits, CAPTCHA, audit logs and consent records, so X-Forwarded-For is only believed from our own proxies
+	if len(cfg.TrustedProxies) > 0 {
+		trust := []echo.TrustOption{echo.TrustLoopback(false), echo.TrustLinkLocal(false), echo.TrustPrivateNet(false)}
+		for _, ipRange := range cfg.TrustedProxies {
+			trust = append(trust, echo.TrustIPRange(ipRange))
+		}
+		e.IPExtractor = echo.ExtractIPFromXFFHeader(trust...)
+	} else {
+		e.IPExtractor = echo.ExtractIPDirect()
+	}
+
+	// REQUEST TRACING, spans go to the OTLP endpoint (Jaeger ...) when one is configured
+	dbOptions := cfg.Mongo.DBOptions()
+	if cfg.Tracing.Endpoint != "" {
+		if _, err := tracing.Setup(context.Background(), cfg.Tracing.Endpoint, cfg.Tracing.ServiceName, cfg.Tracing.Headers, cfg.Tracing.SampleRatio); err != nil {
+			log.Fatal("Invalid tracing configuration: ", err)
+		}
+		dbOptions.Monitor = tracing.MongoMonitor()
+	}
+	e.Use(appMiddleware.Tracing(cfg.Tracing.ServiceName)) //! First, every other middleware runs inside the request's span
+
+	// PANIC RECOVERY AND ERROR REPORTING, panics and 500s go to Sentry when a DSN is configured, the log otherwise
+	if cfg.Sentry.DSN != "" {
+		reporter, err := reporting.NewSentryReporter(cfg.Sentry.DSN, cfg.Sentry.Environment, cfg.Sentry.Release)
+		if err != nil {
+			log.Fatal("Invalid Sentry configuration: ", err)
+		}
+		reporting.Configure(reporter)
+	}
+	e.Use(appMiddleware.Recover())
+	e.HTTPErrorHandler = appMiddleware.ErrorHandler(e)
+
+	database := db.ConnectDB(cfg.MongoURI, cfg.DatabaseName, dbOptions)
 	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
-		AllowOrigins:     []string{"http://localhost:3000", "http://localhost:5173" , "https://event-horizon-wine.vercel.app" , "https://www.event-horizons.app" , "https://go-lang-project-9f592fc57357.herokuapp.com"}, // frontend URLs
-		AllowMethods:     []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete, http.MethodOptions},
-		AllowHeaders:     []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization},
+		AllowOrigins:     cfg.CORSOrigins, // frontend URLs
+		AllowMethods:     []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodOptions},
+		AllowHeaders:     []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization, "If-None-Match", echo.HeaderIfModifiedSince, appMiddleware.HeaderScannerKey},
+		ExposeHeaders:    []string{"ETag", echo.HeaderLastModified, "X-Trace-Id"}, // conditional GETs on events, trace lookups
 		AllowCredentials: true, //  using cookies or Authorization header
+		Skipper:          appMiddleware.IsEmbedRequest, // the widget is open to every origin
 	}))
+	e.Use(appMiddleware.EmbedCORS())
+
+	// Bound how long a request and its Mongo queries may run (504 when exceeded)
+	e.Use(appMiddleware.RequestTimeout(cfg.RequestTimeout))
+
+	// Reject oversized request bodies before they are read
+	e.Use(middleware.BodyLimit(cfg.BodyLimit))
+
+	// Compress responses (category-with-events responses can be hundreds of KB)
+	if cfg.GzipLevel > 0 {
+		e.Use(middleware.GzipWithConfig(middleware.GzipConfig{
+			Level:     cfg.GzipLevel,
+			MinLength: cfg.GzipMinLength,
+			Skipper: func(c echo.Context) bool {
+				return strings.HasSuffix(c.Path(), "/stream") //! SSE streams must not be buffered
+			},
+		}))
+	}
 
 	// STARTING THE STORES
 	userStore := store.NewUserStore(database)
-	categoryStore := store.NewCategoryStore(database)
-	eventStore := store.NewEventStore(database, categoryStore)
+	//? Bookings first, events and categories cascade their deletes into them
 	bookingStore := store.NewBookingStore(database)
+	categoryStore := store.NewCategoryStore(database, bookingStore)
+	eventStore := store.NewEventStore(database, categoryStore, bookingStore)
+	followStore := store.NewFollowStore(database)
+	notificationStore := store.NewNotificationStore(database)
+	sessionStore := store.NewSessionStore(database)
+	auditStore := store.NewAuditStore(database)
+	reportStore := store.NewReportStore(database)
+	guestStore := store.NewGuestStore(database)
+	announcementStore := store.NewAnnouncementStore(database)
+	commentStore := store.NewCommentStore(database)
+	shareLinkStore := store.NewShareLinkStore(database)
+	affiliateStore := store.NewAffiliateStore(database)
+	verificationStore := store.NewHostVerificationStore(database)
+	organizationStore := store.NewOrganizationStore(database)
+	scannerKeyStore := store.NewScannerKeyStore(database)
+	consentStore := store.NewConsentStore(database)
+	jobStore := store.NewJobStore(database)
+	schedulerStore := store.NewSchedulerStore(database)
+	outboxStore := store.NewOutboxStore(database)
+	templateStore := store.NewTemplateStore(database)
+	venueStore := store.NewVenueStore(database)
+
+	// Backfill documents written before new fields existed
+	if cfg.RunMigrations {
+		if _, err := migrations.Run(context.Background(), database); err != nil {
+			log.Fatal("Error running migrations: ", err)
+		}
+	}
+
+	// Create the 2dsphere index used by the nearby events query
+	if err := eventStore.EnsureGeoIndex(context.Background()); err != nil {
+		log.Println("Error creating geo index:", err)
+	}
+
+	// Hosts list their archived events newest first
+	if err := eventStore.EnsureArchiveIndexes(context.Background()); err != nil {
+		log.Println("Error creating event archive indexes:", err)
+	}
+
+	// The platform calendar is answered from its index alone
+	if err := eventStore.EnsureCalendarIndex(context.Background()); err != nil {
+		log.Println("Error creating calendar index:", err)
+	}
+
+	// Create the index the publish scheduler finds due drafts with
+	if err := eventStore.EnsurePublishAtIndex(context.Background()); err != nil {
+		log.Println("Error creating publish_at index:", err)
+	}
+
+	// Create the unique index on category slugs
+	if err := categoryStore.EnsureSlugIndex(context.Background()); err != nil {
+		log.Println("Error creating category slug index:", err)
+	}
+
+	// Expired sessions are removed by MongoDB
+	if err := sessionStore.EnsureTTLIndex(context.Background()); err != nil {
+		log.Println("Error creating session TTL index:", err)
+	}
+
+	// A user can report an event only once
+	if err := reportStore.EnsureReportIndex(context.Background()); err != nil {
+		log.Println("Error creating report index:", err)
+	}
+
+	// The announcement history of an event is read newest first
+	if err := announcementStore.EnsureAnnouncementIndex(context.Background()); err != nil {
+		log.Println("Error creating announcement index:", err)
+	}
+
+	// Comments are listed per event and counted per author for the rate limit
+	if err := commentStore.EnsureCommentIndexes(context.Background()); err != nil {
+		log.Println("Error creating comment indexes:", err)
+	}
+
+	// Share codes must never repeat
+	if err := shareLinkStore.EnsureShareLinkIndexes(context.Background()); err != nil {
+		log.Println("Error creating share link indexes:", err)
+	}
+
+	// Affiliate codes must never repeat, their bookings are summed per affiliate
+	if err := affiliateStore.EnsureAffiliateIndexes(context.Background()); err != nil {
+		log.Println("Error creating affiliate indexes:", err)
+	}
+	if err := bookingStore.EnsureAffiliateIndex(context.Background()); err != nil {
+		log.Println("Error creating booking affiliate index:", err)
+	}
+
+	// A host has one pending verification at a time, admins review the oldest first
+	if err := verificationStore.EnsureHostVerificationIndexes(context.Background()); err != nil {
+		log.Println("Error creating host verification indexes:", err)
+	}
+
+	// Organizations are found by member, their events by org
+	if err := organizationStore.EnsureOrganizationIndexes(context.Background()); err != nil {
+		log.Println("Error creating organization indexes:", err)
+	}
+	if err := eventStore.EnsureOrgIndex(context.Background()); err != nil {
+		log.Println("Error creating event organization index:", err)
+	}
+
+	// Guest bookings are looked up by their access code
+	if err := bookingStore.EnsureGuestCodeIndex(context.Background()); err != nil {
+		log.Println("Error creating guest code index:", err)
+	}
+
+	// The reconfirmation scheduler finds expired bookings by reconfirm_by
+	if err := bookingStore.EnsureReconfirmIndex(context.Background()); err != nil {
+		log.Println("Error creating reconfirm_by index:", err)
+	}
+
+	// "My tickets" pages read a user's bookings newest first
+	if err := bookingStore.EnsureBookingListIndex(context.Background()); err != nil {
+		log.Println("Error creating booking list index:", err)
+	}
+
+	// Door devices look their scanner key up by hash
+	if err := scannerKeyStore.EnsureScannerKeyIndexes(context.Background()); err != nil {
+		log.Println("Error creating scanner key indexes:", err)
+	}
+
+	// The consents of a user are looked up on every booking once the terms change
+	if err := consentStore.EnsureConsentIndexes(context.Background()); err != nil {
+		log.Println("Error creating consent indexes:", err)
+	}
+
+	// Check-in codes must never repeat
+	if err := bookingStore.EnsureCheckInCodeIndex(context.Background()); err != nil {
+		log.Println("Error creating check-in code index:", err)
+	}
+
+	// Workers claim due jobs, finished jobs expire
+	if err := jobStore.EnsureJobIndexes(context.Background()); err != nil {
+		log.Println("Error creating job indexes:", err)
+	}
 
-	// Set bookingStore reference in eventStore for cascade delete
-	eventStore.SetBookingStore(bookingStore)
-	
-	// Set bookingStore reference in categoryStore for cascade delete
-	categoryStore.SetBookingStore(bookingStore)
+	// One document per scheduler, whichever instance ran it last
+	if err := schedulerStore.EnsureSchedulerIndexes(context.Background()); err != nil {
+		log.Println("Error creating scheduler indexes:", err)
+	}
+
+	// The relay claims due outbox messages, delivered ones expire
+	if err := outboxStore.EnsureOutboxIndexes(context.Background()); err != nil {
+		log.Println("Error creating outbox indexes:", err)
+	}
+
+	// Venues are searched by name and city, their events are listed by start time
+	if err := venueStore.EnsureVenueIndexes(context.Background()); err != nil {
+		log.Println("Error creating venue indexes:", err)
+	}
+	if err := eventStore.EnsureVenueIndex(context.Background()); err != nil {
+		log.Println("Error creating event venue index:", err)
+	}
+
+	// A host names each template once
+	if err := templateStore.EnsureTemplateIndexes(context.Background()); err != nil {
+		log.Println("Error creating template indexes:", err)
+	}
+
+	// Booking transactions announce their changes through the outbox
+	bookingStore.SetOutbox(outboxStore)
+
+	// JWT middleware rejects tokens of revoked sessions, the token cookie is Secure unless turned off
+	appMiddleware.SetSessionStore(sessionStore)
+	appMiddleware.ConfigureTokenCookie(cfg.AuthCookieSecure)
+
+	// Cache hot event reads in memory
+	store.ConfigureEventCache(cfg.EventCacheTTL)
+	models.ConfigureStockFlags(cfg.LowStock.SellingFastPercent, cfg.LowStock.AlmostSoldOutPercent)
+
+	// Serialize bookings of hot events across instances when Redis is configured, bookings per minute are counted there too
+	var redisClient *redis.Client
+	var velocity store.VelocityCounter = store.NewMemoryVelocityCounter()
+	if cfg.RedisURL != "" {
+		redisClient = db.ConnectRedis(cfg.RedisURL)
+		bookingStore.SetLocker(store.NewRedisLocker(redisClient, cfg.BookingLockTTL, cfg.BookingLockWait))
+		velocity = store.NewRedisVelocityCounter(redisClient)
+	}
+
+	// OUTBOUND EVENT BUS, other services consume the domain events
+	var bus eventbus.Publisher = eventbus.NopPublisher{}
+	switch cfg.EventBus {
+	case "log":
+		bus = eventbus.NewAsyncPublisher(eventbus.LogPublisher{}, 1000)
+	case "redis":
+		bus = eventbus.NewAsyncPublisher(eventbus.NewRedisStreamPublisher(redisClient, cfg.EventBusStream, int64(cfg.EventBusMaxLen)), 1000)
+	}
+
+	// New events wait for admin review when moderation is enabled
+	eventStore.SetModeration(cfg.ModerationEnabled)
+
+	// START BACKGROUND WORKER TO NOTIFY FOLLOWERS OF NEW EVENTS
+	notifier := utils.StartNotificationWorker(followStore, notificationStore, userStore)
+
+	// EVENT SEARCH, Meilisearch when configured, MongoDB otherwise
+	var searchEngine search.Engine = search.NewMongoEngine(eventStore)
+	if cfg.Meilisearch.URL != "" {
+		meilisearch := search.NewMeilisearchEngine(cfg.Meilisearch.URL, cfg.Meilisearch.APIKey, cfg.Meilisearch.Index)
+		if err := meilisearch.EnsureIndex(context.Background()); err != nil {
+			log.Println("Error configuring the Meilisearch index:", err)
+		}
+		searchEngine = meilisearch
+	}
+	searchIndexer := search.StartIndexer(searchEngine, 1000)
+
+	// EMAIL, only logged when no SMTP server is configured
+	var mailer utils.Mailer = utils.LogMailer{}
+	if cfg.SMTP.Host != "" {
+		mailer = utils.NewSMTPMailer(cfg.SMTP.Host, cfg.SMTP.Port, cfg.SMTP.Username, cfg.SMTP.Password, cfg.SMTP.From)
+	}
+
+	// SMS, only logged when no Twilio account is configured
+	var smsSender utils.SMSSender = utils.LogSMSSender{}
+	if cfg.Twilio.AccountSID != "" {
+		smsSender = utils.NewTwilioSender(cfg.Twilio.AccountSID, cfg.Twilio.AuthToken, cfg.Twilio.From)
+	}
+
+	// CAPTCHA on sign up, after failed sign ins and for fast bookings, only when a provider secret is configured
+	var captcha utils.CaptchaVerifier
+	if cfg.Captcha.Secret != "" {
+		verifier, err := utils.NewCaptchaVerifier(cfg.Captcha.Provider, cfg.Captcha.Secret)
+		if err != nil {
+			log.Fatal("Invalid CAPTCHA configuration: ", err)
+		}
+		captcha = verifier
+	}
+
+	// REALTIME HUB FOR LIVE TICKET AVAILABILITY
+	hub := realtime.NewHub()
+	checkInHub := realtime.NewHub() //? Door counts, only for hosts
+
+	// BACKGROUND JOB QUEUE, booking hooks and the new-event fan-out run as jobs with retries
+	jobQueue := jobs.NewQueue(jobStore, cfg.JobMaxAttempts)
+	notifier.SetFanOut(jobs.Hook(jobQueue, "event.notify_followers", notifier.NotifyFollowers))
+
+	// SCHEDULERS, every run is recorded for the admin dashboard
+	schedulers := utils.NewSchedulers(schedulerStore)
 
 	// STARTING THE CONTROLLERS
-	eventController := controllers.NewEventController(eventStore, categoryStore, userStore)
-	userController := controllers.NewUserController(userStore)
-	categoryController := controllers.NewCategoryController(categoryStore)
-	bookingController := controllers.NewBookingController(bookingStore, eventStore)
+	//? SERVICES hold the business rules, controllers only speak HTTP
+	consentService := services.NewConsentService(consentStore, models.PolicyVersions{
+		Terms:   cfg.Policies.TermsVersion,
+		Privacy: cfg.Policies.PrivacyVersion,
+	})
+	eventService := services.NewEventService(eventStore, userStore, bookingStore, notifier, bus, searchIndexer, organizationStore, venueStore, cfg.RequireVerification)
+	bookingService := services.NewBookingService(bookingStore, eventStore, bus, notifier, outboxStore, consentService, services.BookingLimits{
+		MaxPerRequest: cfg.BookingMaxQuantity,
+		MaxPerEvent:   cfg.BookingMaxPerEvent,
+		PerMinute:     cfg.BookingVelocity,
+		Velocity:      velocity,
+		Captcha:       captcha,
+	})
+	capacityAlertService := services.NewCapacityAlertService(eventStore, userStore, notifier, mailer)
+	bookingService.AfterBooking(jobs.Hook(jobQueue, "booking.capacity_alert", capacityAlertService.CheckBooking))
+	receiptService := services.NewReceiptService(bookingStore, eventStore, userStore)
+	confirmationService := services.NewConfirmationService(eventStore, userStore, mailer, smsSender, cfg.AppBaseURL)
+	bookingService.AfterBooking(jobs.Hook(jobQueue, "booking.confirmation_email", confirmationService.SendConfirmation))
+	bookingService.AfterCancel(jobs.Hook(jobQueue, "booking.cancellation_email", confirmationService.SendCancellation))
+	eventService.RefundCancelledWith(bookingService.RefundEvent)
+	guestService := services.NewGuestService(guestStore, bookingStore, eventStore, bookingService, confirmationService)
+	userService := services.NewUserService(userStore, sessionStore, consentService, smsSender, services.CaptchaRules{
+		Verifier:      captcha,
+		Failures:      velocity,
+		LoginFailures: cfg.Captcha.LoginFailures,
+	})
+	hostService := services.NewHostService(userStore, eventStore, bookingStore, notifier, searchIndexer)
+	analyticsService := services.NewAnalyticsService(userStore, eventStore, bookingStore, auditStore)
+	checkInService := services.NewCheckInService(eventStore, bookingStore)
+	searchService := services.NewSearchService(searchEngine, eventStore)
+	recommendationService := services.NewRecommendationService(bookingStore, eventStore, followStore)
+	announcementService := services.NewAnnouncementService(announcementStore, eventStore, bookingStore, notifier)
+	commentService := services.NewCommentService(commentStore, eventStore, userStore, notifier, cfg.ReportHideThreshold)
+	shareLinkService := services.NewShareLinkService(shareLinkStore, eventStore, bookingStore, cfg.AppBaseURL, cfg.ShareBaseURL)
+	affiliateService := services.NewAffiliateService(affiliateStore, userStore, eventStore, bookingStore)
+	bookingService.AfterBooking(jobs.Hook(jobQueue, "booking.affiliate_commission", affiliateService.RecordBooking))
+	seoService := services.NewSEOService(eventStore, userStore, cfg.AppBaseURL)
+	embedService := services.NewEmbedService(eventStore, cfg.AppBaseURL)
+	verificationService := services.NewHostVerificationService(verificationStore, userStore, eventStore, notifier)
+	organizationService := services.NewOrganizationService(organizationStore, userStore, eventStore, notifier)
+	scannerKeyService := services.NewScannerKeyService(scannerKeyStore, eventStore)
+	accountService := services.NewAccountService(userStore, sessionStore, bookingStore, eventStore, commentStore, followStore, notificationStore, consentStore, verificationStore)
+	templateService := services.NewTemplateService(templateStore, eventStore, eventService)
+	venueService := services.NewVenueService(venueStore, eventStore, userStore, searchIndexer)
 
-	// START BACKGROUND SCHEDULER TO DELETE EXPIRED EVENTS
-	utils.StartEventCleanupScheduler(eventStore)
+	// EXPIRED EVENT CLEANUP, the scheduler and the admin dry run use the same policy
+	cleanupPolicy := store.CleanupPolicy{
+		Retention: time.Duration(cfg.EventRetentionDays) * 24 * time.Hour,
+		BatchSize: cfg.CleanupBatchSize,
+	}
+
+	eventController := controllers.NewEventController(eventService, eventStore, auditStore, hub)
+	coHostController := controllers.NewCoHostController(eventStore, userStore, notifier)
+	userController := controllers.NewUserController(userService, sessionStore, auditStore)
+	accountController := controllers.NewAccountController(accountService, auditStore)
+	consentController := controllers.NewConsentController(consentService)
+	templateController := controllers.NewTemplateController(templateService, auditStore)
+	venueController := controllers.NewVenueController(venueService, auditStore)
+	categoryController := controllers.NewCategoryController(categoryStore, auditStore)
+	bookingController := controllers.NewBookingController(bookingService, receiptService, guestService, bookingStore, eventStore, auditStore, hub, notifier)
+	followController := controllers.NewFollowController(followStore, userStore)
+	notificationController := controllers.NewNotificationController(notificationStore)
+	auditController := controllers.NewAuditController(auditStore)
+	cleanupController := controllers.NewCleanupController(eventStore, cleanupPolicy)
+	jobController := controllers.NewJobController(jobStore, schedulerStore, schedulers, auditStore)
+	moderationController := controllers.NewModerationController(hostService, eventStore, auditStore, notifier, bus, searchIndexer)
+	reportController := controllers.NewReportController(reportStore, eventStore, auditStore, cfg.ReportHideThreshold)
+	analyticsController := controllers.NewAnalyticsController(analyticsService)
+	checkInController := controllers.NewCheckInController(checkInService, checkInHub)
+	searchController := controllers.NewSearchController(searchService)
+	recommendationController := controllers.NewRecommendationController(recommendationService)
+	announcementController := controllers.NewAnnouncementController(announcementService)
+	commentController := controllers.NewCommentController(commentService, auditStore)
+	shareLinkController := controllers.NewShareLinkController(shareLinkService)
+	affiliateController := controllers.NewAffiliateController(affiliateService)
+	seoController := controllers.NewSEOController(seoService)
+	embedController := controllers.NewEmbedController(embedService)
+	verificationController := controllers.NewVerificationController(verificationService, auditStore)
+	organizationController := controllers.NewOrganizationController(organizationService, eventService, auditStore)
+	scannerKeyController := controllers.NewScannerKeyController(scannerKeyService, auditStore)
+	emailTemplateController := controllers.NewEmailTemplateController(services.NewEmailTemplateService(cfg.AppBaseURL))
+	docsController := controllers.NewDocsController(docs.OpenAPISpec, "/api/docs/openapi.yaml")
+
+	// START BACKGROUND SCHEDULERS TO DELETE EXPIRED EVENTS AND PUBLISH SCHEDULED DRAFTS
+	utils.StartEventCleanupScheduler(schedulers, eventStore, cleanupPolicy, cfg.CleanupInterval)
+	utils.StartPublishScheduler(schedulers, eventService.PublishScheduled, cfg.PublishInterval)
+	utils.StartReconfirmationScheduler(schedulers, bookingService.RefundUnconfirmed, cfg.ReconfirmInterval)
+
+	// START THE JOB QUEUE WORKERS AND THE OUTBOX RELAY, every hook is registered by now
+	jobQueue.Start(cfg.JobWorkers, cfg.JobPollInterval)
+	utils.StartOutboxRelay(schedulers, bookingService.DeliverOutbox, cfg.OutboxInterval)
 
 	e.GET("/", func(c echo.Context) error {
 		data := "Welcome to Event Horizon Backend!"
 		return c.String(http.StatusOK, data)
 	})
 
+	// ADMIN ROLE CHECK (used after JWT middleware)
+	adminOnly := appMiddleware.AdminMiddleware(userStore)
+
+	// DOOR DEVICES come with a scanner key instead of a JWT (check-in and attendee lookup only)
+	doorAuth := appMiddleware.ScannerKeyOrJWT(scannerKeyService)
+
 	// SETTING UP THE ROUTES
-	eventGroup := e.Group("/api/events")
-	userGroup := e.Group("/api/users")
-	categoryGroup := e.Group("/api/categories")
-	bookingGroup := e.Group("/api/bookings")
-
-	routes.SetupEventRoutes(eventGroup, eventController)
-	routes.UserRoutes(userGroup, userController)
-	routes.CategoryRoutes(categoryGroup, categoryController)
-	routes.SetupBookingRoutes(bookingGroup, bookingController)
-	e.Logger.Fatal(e.Start(":" + os.Getenv("PORT")))
-	
+	ctrls := routes.Controllers{
+		Event:        eventController,
+		CoHost:       coHostController,
+		User:         userController,
+		Account:      accountController,
+		Consent:      consentController,
+		Template:     templateController,
+		Venue:        venueController,
+		Category:     categoryController,
+		Booking:      bookingController,
+		Follow:       followController,
+		Notification: notificationController,
+		Audit:        auditController,
+		Moderation:   moderationController,
+		Report:       reportController,
+		Analytics:    analyticsController,
+		CheckIn:      checkInController,
+		Search:       searchController,
+		Recommend:    recommendationController,
+		EmailPreview: emailTemplateController,
+		Announcement: announcementController,
+		Comment:      commentController,
+		ShareLink:    shareLinkController,
+		Affiliate:    affiliateController,
+		SEO:          seoController,
+		Embed:        embedController,
+		Cleanup:      cleanupController,
+		Jobs:         jobController,
+		Verification: verificationController,
+		Organization: organizationController,
+		ScannerKey:   scannerKeyController,
+		AdminOnly:    adminOnly,
+		DoorAuth:     doorAuth,
+	}
+
+	//! Current version
+	v1Group := e.Group("/api/v1", appMiddleware.APIVersion("v1"))
+	routes.RegisterV1(v1Group, ctrls)
+
+	//! Legacy unversioned paths, kept as aliases of v1 during the deprecation window
+	legacyGroup := e.Group("/api", appMiddleware.Deprecated("/api", "/api/v1", cfg.LegacyAPISunset), appMiddleware.APIVersion("v1"))
+	routes.RegisterV1(legacyGroup, ctrls)
+
+	//! OpenAPI spec and Swagger UI (not versioned)
+	docsGroup := e.Group("/api/docs")
+	routes.SetupDocsRoutes(docsGroup, docsController)
+
+	//! Short share links (not versioned)
+	routes.SetupShortLinkRoutes(e.Group("/s"), shareLinkController)
+
+	//! Sitemap for search engines (not versioned)
+	routes.SetupSitemapRoutes(e.Group(""), seoController)
+
+	return e
 }
@@ -0,0 +1,381 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"event-horizon/config"
+	"event-horizon/db/mongotest"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+/** *********************  API INTEGRATION TESTS   ********************
+
+The full Echo app (every middleware, service and store) against a throwaway
+MongoDB replica set, see db/mongotest. Skipped without Docker or MONGO_TEST_URI.
+
+ **************************************/
+
+const (
+	testPolicyVersion = "2026-01"
+	testPassword      = "correct-horse"
+)
+
+func TestMain(m *testing.M) {
+	mongotest.Main(m)
+}
+
+// startApp serves the app on a new database and returns the base URL of the v1 API
+func startApp(t *testing.T) (string, *mongo.Database) {
+	t.Helper()
+	database := mongotest.Database(t)
+
+	t.Setenv("MONGO_URI", mongotest.URI(t))
+	t.Setenv("DATABASE_NAME", database.Name())
+	t.Setenv("JWT_SECRET", strings.Repeat("integration-secret-", 2))
+	t.Setenv("TERMS_VERSION", testPolicyVersion)
+	t.Setenv("PRIVACY_VERSION", testPolicyVersion)
+	t.Setenv("HOST_VERIFICATION_REQUIRED", "false") //? Paid tickets without the verification flow
+	t.Setenv("BOOKING_VELOCITY_LIMIT", "0")         //? Every request comes from 127.0.0.1
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("config: %v", err)
+	}
+
+	server := httptest.NewServer(newServer(cfg))
+	t.Cleanup(server.Close)
+	return server.URL + "/api/v1", database
+}
+
+// send calls the API and returns the status and the JSON body, it only reports errors so goroutines can use it
+func send(t *testing.T, method, url, token string, body interface{}) (int, map[string]interface{}) {
+	var payload io.Reader
+	if body != nil {
+		raw, err := json.Marshal(body)
+		if err != nil {
+			t.Errorf("encoding %s %s: %v", method, url, err)
+			return 0, nil
+		}
+		payload = bytes.NewReader(raw)
+	}
+
+	req, err := http.NewRequest(method, url, payload)
+	if err != nil {
+		t.Errorf("%s %s: %v", method, url, err)
+		return 0, nil
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Errorf("%s %s: %v", method, url, err)
+		return 0, nil
+	}
+	defer resp.Body.Close()
+
+	var decoded map[string]interface{}
+	raw, _ := io.ReadAll(resp.Body)
+	json.Unmarshal(raw, &decoded)
+	return resp.StatusCode, decoded
+}
+
+// call sends the request and stops the test unless the API answers with want
+func call(t *testing.T, method, url, token string, body interface{}, want int) map[string]interface{} {
+	t.Helper()
+	status, decoded := send(t, method, url, token, body)
+	if status != want {
+		t.Fatalf("%s %s = %d %v, want %d", method, url, status, decoded, want)
+	}
+	return decoded
+}
+
+// register signs a new user up and returns their token
+func register(t *testing.T, api, name, email string) string {
+	t.Helper()
+	resp := call(t, http.MethodPost, api+"/users/register", "", map[string]string{
+		"name":            name,
+		"email":           email,
+		"password":        testPassword,
+		"terms_version":   testPolicyVersion,
+		"privacy_version": testPolicyVersion,
+	}, http.StatusCreated)
+
+	token, _ := resp["token"].(string)
+	if token == "" {
+		t.Fatalf("registering %s returned no token: %v", email, resp)
+	}
+	return token
+}
+
+// registerHost signs a new user up as a host and returns the token with the host scopes
+func registerHost(t *testing.T, api, name, email string) string {
+	t.Helper()
+	resp := call(t, http.MethodPost, api+"/users/me/become-host", register(t, api, name, email), nil, http.StatusOK)
+	return resp["token"].(string)
+}
+
+// registerAdmin signs a new user up, makes them admin in the database and signs in again for the admin scopes
+func registerAdmin(t *testing.T, api string, database *mongo.Database, email string) string {
+	t.Helper()
+	register(t, api, "Admin", email)
+	if _, err := database.Collection("Users").UpdateOne(context.Background(), bson.M{"email": email}, bson.M{"$set": bson.M{"is_admin": true}}); err != nil {
+		t.Fatalf("making %s admin: %v", email, err)
+	}
+	resp := call(t, http.MethodPost, api+"/users/login", "", map[string]string{"email": email, "password": testPassword}, http.StatusOK)
+	return resp["token"].(string)
+}
+
+// createCategory creates a category as admin and returns its ID
+func createCategory(t *testing.T, api, adminToken, name string) string {
+	t.Helper()
+	resp := call(t, http.MethodPost, api+"/categories/create", adminToken, map[string]string{"name": name}, http.StatusCreated)
+	return resp["id"].(string)
+}
+
+// eventRequest is the body of a one day event next month with Regular tickets
+func eventRequest(name, category string, tickets int) map[string]interface{} {
+	start := time.Now().UTC().AddDate(0, 1, 0).Truncate(24 * time.Hour).Add(19 * time.Hour)
+	return map[string]interface{}{
+		"name":          name,
+		"category_name": category,
+		"date":          start,
+		"timezone":      "UTC",
+		"location":      "Town Hall",
+		"start_time":    start,
+		"end_time":      start.Add(3 * time.Hour),
+		"tickets":       []map[string]interface{}{{"type": "Regular", "price": 25, "total_quantity": tickets}},
+	}
+}
+
+// createEvent creates an event as the host and returns its ID
+func createEvent(t *testing.T, api, hostToken string, body map[string]interface{}) string {
+	t.Helper()
+	resp := call(t, http.MethodPost, api+"/events/create", hostToken, body, http.StatusCreated)
+	return resp["id"].(string)
+}
+
+// book books one Regular ticket, it only reports errors so goroutines can use it
+func book(t *testing.T, api, token, eventID string) int {
+	status, _ := send(t, http.MethodPost, api+"/bookings/create", token, map[string]interface{}{
+		"event_id":    eventID,
+		"ticket_type": "Regular",
+		"quantity":    1,
+	})
+	return status
+}
+
+// count counts the documents of the collection matching the filter
+func count(t *testing.T, database *mongo.Database, collection string, filter bson.M) int64 {
+	t.Helper()
+	n, err := database.Collection(collection).CountDocuments(context.Background(), filter)
+	if err != nil {
+		t.Fatalf("counting %s: %v", collection, err)
+	}
+	return n
+}
+
+// availableTickets reads the Regular tickets left from the public event
+func availableTickets(t *testing.T, api, eventID string) int {
+	t.Helper()
+	event := call(t, http.MethodGet, api+"/events/"+eventID, "", nil, http.StatusOK)
+	tickets, _ := event["tickets"].([]interface{})
+	for _, ticket := range tickets {
+		if ticket := ticket.(map[string]interface{}); ticket["type"] == "Regular" {
+			return int(ticket["available_quantity"].(float64))
+		}
+	}
+	t.Fatalf("event %s has no Regular tickets: %v", eventID, event)
+	return 0
+}
+
+func TestAPI(t *testing.T) {
+	api, database := startApp(t)
+	admin := registerAdmin(t, api, database, "admin@example.com")
+	host := registerHost(t, api, "Host", "host@example.com")
+	createCategory(t, api, admin, "Concerts")
+
+	t.Run("register and login", func(t *testing.T) {
+		token := register(t, api, "Ada", "ada@example.com")
+
+		call(t, http.MethodPost, api+"/users/login", "", map[string]string{"email": "ada@example.com", "password": "wrong-horse"}, http.StatusUnauthorized)
+		call(t, http.MethodPost, api+"/users/login", "", map[string]string{"email": "nobody@example.com", "password": testPassword}, http.StatusUnauthorized)
+		resp := call(t, http.MethodPost, api+"/users/login", "", map[string]string{"email": "ada@example.com", "password": testPassword}, http.StatusOK)
+		if user := resp["user"].(map[string]interface{}); user["email"] != "ada@example.com" || user["is_host"] != false {
+			t.Fatalf("login user = %v", user)
+		}
+
+		//? Signing up and signing in are two sessions, both tokens work
+		sessions := call(t, http.MethodGet, api+"/users/me/sessions", token, nil, http.StatusOK)
+		if list, _ := sessions["sessions"].([]interface{}); len(list) != 2 {
+			t.Fatalf("sessions = %v, want the sign up and the login", sessions["sessions"])
+		}
+		call(t, http.MethodGet, api+"/users/me/sessions", resp["token"].(string), nil, http.StatusOK)
+		call(t, http.MethodGet, api+"/users/me/sessions", "", nil, http.StatusUnauthorized)
+
+		//! Registration never grants a role
+		call(t, http.MethodPost, api+"/events/create", token, eventRequest("Not a host", "Concerts", 10), http.StatusForbidden)
+	})
+
+	t.Run("event CRUD", func(t *testing.T) {
+		id := createEvent(t, api, host, eventRequest("Jazz Night", "Concerts", 100))
+
+		event := call(t, http.MethodGet, api+"/events/"+id, "", nil, http.StatusOK)
+		if event["name"] != "Jazz Night" || event["category_name"] != "Concerts" || event["version"] != float64(1) {
+			t.Fatalf("created event = %v", event)
+		}
+
+		update := eventRequest("Jazz Night Live", "Concerts", 120)
+		update["version"] = 1
+		call(t, http.MethodPut, api+"/events/"+id, host, update, http.StatusOK)
+		event = call(t, http.MethodGet, api+"/events/"+id, "", nil, http.StatusOK)
+		if event["name"] != "Jazz Night Live" || event["version"] != float64(2) {
+			t.Fatalf("updated event = %v", event)
+		}
+		if available := availableTickets(t, api, id); available != 120 {
+			t.Fatalf("%d tickets available after the update, want 120", available)
+		}
+
+		//? A stale version is refused, so is another host
+		call(t, http.MethodPut, api+"/events/"+id, host, update, http.StatusConflict)
+		otherHost := registerHost(t, api, "Other Host", "other-host@example.com")
+		call(t, http.MethodDelete, api+"/events/"+id, otherHost, nil, http.StatusForbidden)
+
+		call(t, http.MethodDelete, api+"/events/"+id, host, nil, http.StatusOK)
+		call(t, http.MethodGet, api+"/events/"+id, "", nil, http.StatusNotFound)
+	})
+
+	t.Run("concurrent bookings", func(t *testing.T) {
+		const tickets, buyers = 5, 12
+		id := createEvent(t, api, host, eventRequest("Sold Out Show", "Concerts", tickets))
+
+		tokens := make([]string, buyers)
+		for i := range tokens {
+			tokens[i] = register(t, api, "Buyer", fmt.Sprintf("buyer%d@example.com", i))
+		}
+
+		//! Everyone books the last tickets at once, only as many as there are get one
+		statuses := make([]int, buyers)
+		var wg sync.WaitGroup
+		for i, token := range tokens {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				statuses[i] = book(t, api, token, id)
+			}()
+		}
+		wg.Wait()
+
+		booked, soldOut := 0, 0
+		for _, status := range statuses {
+			switch status {
+			case http.StatusCreated:
+				booked++
+			case http.StatusConflict:
+				soldOut++
+			default:
+				t.Errorf("booking answered %d", status)
+			}
+		}
+		if booked != tickets || soldOut != buyers-tickets {
+			t.Fatalf("%d booked and %d sold out, want %d and %d", booked, soldOut, tickets, buyers-tickets)
+		}
+
+		eventID, _ := bson.ObjectIDFromHex(id)
+		if n := count(t, database, "Bookings", bson.M{"event_id": eventID}); n != tickets {
+			t.Fatalf("%d bookings stored, want %d", n, tickets)
+		}
+		if available := availableTickets(t, api, id); available != 0 {
+			t.Fatalf("%d tickets available, want 0", available)
+		}
+	})
+
+	t.Run("cancelled bookings are kept", func(t *testing.T) {
+		buyer := register(t, api, "Changed Mind", "changed@example.com")
+		id := createEvent(t, api, host, eventRequest("Matinee", "Concerts", 10))
+		for i := 0; i < 2; i++ {
+			if status := book(t, api, buyer, id); status != http.StatusCreated {
+				t.Fatalf("booking answered %d", status)
+			}
+		}
+		listed := call(t, http.MethodGet, api+"/bookings/user?status=confirmed", buyer, nil, http.StatusOK)["bookings"].([]interface{})
+		cancelled := listed[0].(map[string]interface{})["id"].(string)
+
+		//! The tickets go back on sale, the booking stays with its status
+		call(t, http.MethodPut, api+"/bookings/"+cancelled+"/cancel", buyer, nil, http.StatusOK)
+		if available := availableTickets(t, api, id); available != 9 {
+			t.Fatalf("%d tickets available after the cancellation, want 9", available)
+		}
+		call(t, http.MethodPut, api+"/bookings/"+cancelled+"/cancel", buyer, nil, http.StatusConflict)
+
+		for status, want := range map[string]int{"confirmed": 1, "cancelled": 1, "": 2} {
+			page := call(t, http.MethodGet, api+"/bookings/user?status="+status, buyer, nil, http.StatusOK)
+			bookings := page["bookings"].([]interface{})
+			if len(bookings) != want {
+				t.Fatalf("?status=%s lists %d bookings, want %d", status, len(bookings), want)
+			}
+			for _, booking := range bookings {
+				if got := booking.(map[string]interface{})["status"]; status != "" && got != status {
+					t.Fatalf("?status=%s lists a %v booking", status, got)
+				}
+			}
+		}
+		call(t, http.MethodGet, api+"/bookings/user?status=refunded", buyer, nil, http.StatusBadRequest)
+	})
+
+	t.Run("cascade deletes", func(t *testing.T) {
+		buyer := register(t, api, "Fan", "fan@example.com")
+
+		//? Deleting an event deletes its bookings
+		id := createEvent(t, api, host, eventRequest("Farewell Tour", "Concerts", 10))
+		if status := book(t, api, buyer, id); status != http.StatusCreated {
+			t.Fatalf("booking answered %d", status)
+		}
+		eventID, _ := bson.ObjectIDFromHex(id)
+		call(t, http.MethodDelete, api+"/events/"+id, host, nil, http.StatusOK)
+		if n := count(t, database, "Bookings", bson.M{"event_id": eventID}); n != 0 {
+			t.Fatalf("%d bookings left of the deleted event", n)
+		}
+
+		//? A category with events is only deleted with ?cascade=true, its events and their bookings go with it
+		kept := createEvent(t, api, host, eventRequest("Encore", "Concerts", 10))
+		categoryID := createCategory(t, api, admin, "Theatre")
+		first := createEvent(t, api, host, eventRequest("Hamlet", "Theatre", 10))
+		second := createEvent(t, api, host, eventRequest("Macbeth", "Theatre", 10))
+		for _, id := range []string{first, second} {
+			if status := book(t, api, buyer, id); status != http.StatusCreated {
+				t.Fatalf("booking answered %d", status)
+			}
+		}
+
+		call(t, http.MethodDelete, api+"/categories/"+categoryID, admin, nil, http.StatusBadRequest)
+		call(t, http.MethodDelete, api+"/categories/"+categoryID+"?cascade=true", admin, nil, http.StatusOK)
+
+		categoryObjID, _ := bson.ObjectIDFromHex(categoryID)
+		firstID, _ := bson.ObjectIDFromHex(first)
+		secondID, _ := bson.ObjectIDFromHex(second)
+		if n := count(t, database, "Events", bson.M{"category_id": categoryObjID}); n != 0 {
+			t.Fatalf("%d events left in the deleted category", n)
+		}
+		if n := count(t, database, "Bookings", bson.M{"event_id": bson.M{"$in": bson.A{firstID, secondID}}}); n != 0 {
+			t.Fatalf("%d bookings left of the category's events", n)
+		}
+		call(t, http.MethodGet, api+"/categories/"+categoryID, "", nil, http.StatusNotFound)
+
+		//? Events of other categories are untouched
+		call(t, http.MethodGet, api+"/events/"+kept, "", nil, http.StatusOK)
+	})
+}
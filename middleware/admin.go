@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"event-horizon/store"
+	"event-horizon/utils"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+/*********** ADMIN MIDDLEWARE FUNCTION  *************************************************
+
+1. AdminMiddleware - Only lets requests from ADMIN users through
+
+2. Must run AFTER JWTMiddleware, it reads the user ID from the parsed token
+
+3. Tokens without the admin scope are turned away first, without touching the database
+
+4. The user is loaded from the database, so revoking admin takes effect immediately
+
+ ***************************************************************************************/
+
+// AdminMiddleware returns a middleware that rejects non-admin users
+func AdminMiddleware(userStore store.UserRepository) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			claims, err := utils.GetClaimsFromToken(c)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+			}
+
+			//? Only tokens with a role can be turned away early, older ones are still checked below
+			if claims.Role != "" && !claims.HasScope(utils.ScopeAdmin) {
+				return echo.NewHTTPError(http.StatusForbidden, "Admin access required")
+			}
+
+			userID, err := utils.GetUserIDFromToken(c)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+			}
+
+			userObjID, err := bson.ObjectIDFromHex(userID)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusUnauthorized, "Invalid user ID")
+			}
+
+			user, err := userStore.GetUserByID(c.Request().Context(), userObjID)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusUnauthorized, "User not found")
+			}
+
+			//! Only admins can continue
+			if !user.IsAdmin {
+				return echo.NewHTTPError(http.StatusForbidden, "Admin access required")
+			}
+
+			return next(c)
+		}
+	}
+}
@@ -1,12 +1,17 @@
 package middleware
 
 import (
+	"errors"
+	"event-horizon/models"
+	"event-horizon/store"
 	"event-horizon/utils"
+	"net/http"
 	"strings"
 
 	"github.com/golang-jwt/jwt/v5"
 	echojwt "github.com/labstack/echo-jwt/v4"
 	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/v2/bson"
 )
 
 /*********** JWT MIDDLEWARE FUNCTION  *************************************************
@@ -29,13 +34,66 @@ import (
 
 9. echojwt.WithConfig - Create middleware function with the specified configuration
 
+10. SetSessionStore - Session store used to REJECT tokens of revoked sessions (jti claim)
+
+11. TokenCookie - The web app can get the token in an HttpOnly cookie (use_cookie) instead of the Authorization header,
+    the header wins when both are sent
+
  ***************************************************************************************/
 
+// sessionStore is used to reject tokens whose session was revoked
+var sessionStore store.SessionRepository
+
+// SetSessionStore sets the session store checked on every protected request
+func SetSessionStore(s store.SessionRepository) {
+	sessionStore = s
+}
+
+// TokenCookieName is the HttpOnly cookie the token is delivered in for use_cookie sign ins
+const TokenCookieName = "eh_token"
+
+// secureCookies sends the token cookie over HTTPS only (off for local HTTP)
+var secureCookies = true
+
+// ConfigureTokenCookie sets whether the token cookie is Secure
+func ConfigureTokenCookie(secure bool) {
+	secureCookies = secure
+}
+
+// TokenCookie returns the cookie carrying the token of the session, it only outlives the browser with remember me
+func TokenCookie(token string, session *models.UserSession) *http.Cookie {
+	cookie := &http.Cookie{
+		Name:     TokenCookieName,
+		Value:    token,
+		Path:     "/",
+		HttpOnly: true,                 //! Scripts (XSS) can't read it
+		Secure:   secureCookies,        //! HTTPS only
+		SameSite: http.SameSiteLaxMode, //! Not sent on cross-site POSTs (CSRF)
+	}
+	if session.RememberMe {
+		cookie.Expires = session.ExpiresAt
+	}
+	return cookie
+}
+
+// ClearTokenCookie returns a cookie that removes the token cookie
+func ClearTokenCookie() *http.Cookie {
+	return &http.Cookie{
+		Name:     TokenCookieName,
+		Value:    "",
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   secureCookies,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
+
 // JWTMiddleware returns the JWT middleware configured with the secret
 func JWTMiddleware() echo.MiddlewareFunc {
 	config := echojwt.Config{
-		SigningKey:  []byte(utils.GetJWTSecret()), //! Get secret from utils
-		TokenLookup: "header:Authorization",       //! Look for token in Authorization header
+		SigningKey:  []byte(utils.GetJWTSecret()),                     //! Get secret from utils
+		TokenLookup: "header:Authorization,cookie:" + TokenCookieName, //! Look for token in Authorization header, then the cookie
 
 		ParseTokenFunc: func(c echo.Context, auth string) (interface{}, error) { //! Parse token using your custom JWTClaims struct
 			//? Remove "Bearer " prefix if present
@@ -43,7 +101,11 @@ func JWTMiddleware() echo.MiddlewareFunc {
 			tokenString = strings.TrimSpace(tokenString)
 
 			token, err := jwt.ParseWithClaims(tokenString, &utils.JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
-				return []byte(utils.GetJWTSecret()), nil
+				secret := utils.GetJWTSecret()
+				if secret == "" {
+					return nil, errors.New("jwt secret is not configured") //! never verify with an empty key
+				}
+				return []byte(secret), nil
 			})
 
 			if err != nil {
@@ -51,6 +113,27 @@ func JWTMiddleware() echo.MiddlewareFunc {
 				return nil, err
 			}
 
+			//? Reject tokens whose session was revoked (signed out) or never existed
+			if sessionStore != nil {
+				claims, ok := token.Claims.(*utils.JWTClaims)
+				if !ok {
+					return nil, errors.New("invalid claims format")
+				}
+
+				sessionID, err := bson.ObjectIDFromHex(claims.ID)
+				if err != nil {
+					return nil, errors.New("token has no session, please log in again")
+				}
+
+				active, err := sessionStore.IsSessionActive(c.Request().Context(), sessionID)
+				if err != nil {
+					return nil, err
+				}
+				if !active {
+					return nil, errors.New("session has been revoked")
+				}
+			}
+
 			return token, nil
 		},
 	}
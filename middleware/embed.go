@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	echoMiddleware "github.com/labstack/echo/v4/middleware"
+)
+
+/*********** EMBED CORS MIDDLEWARE  *************************************************
+
+1. The embeddable widget (/api/v1/embed/...) is loaded from the hosts' own sites, so any origin may read it
+
+2. IsEmbedRequest is the Skipper of the app's CORS config, EmbedCORS only runs on embed requests and never
+   allows credentials, the widget is public data only
+
+ ***************************************************************************************/
+
+// IsEmbedRequest reports whether the request is for the embeddable widget
+func IsEmbedRequest(c echo.Context) bool {
+	return strings.Contains(c.Request().URL.Path, "/embed/")
+}
+
+// EmbedCORS returns a CORS middleware that lets every origin read the embed routes
+func EmbedCORS() echo.MiddlewareFunc {
+	return echoMiddleware.CORSWithConfig(echoMiddleware.CORSConfig{
+		Skipper:       func(c echo.Context) bool { return !IsEmbedRequest(c) },
+		AllowOrigins:  []string{"*"},
+		AllowMethods:  []string{http.MethodGet, http.MethodHead, http.MethodOptions},
+		AllowHeaders:  []string{echo.HeaderOrigin, echo.HeaderAccept, "If-None-Match"},
+		ExposeHeaders: []string{"ETag"},
+		MaxAge:        86400,
+	})
+}
@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"errors"
+	"event-horizon/reporting"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+/*********** PANIC RECOVERY AND ERROR REPORTING  *************************************************
+
+1. Recover - Echo's Recover middleware, a panic in a handler becomes a 500 for that request instead of
+   killing the process, the panic goes to the reporter with its stack trace
+
+2. ErrorHandler - Echo's default error handler, 500 answers are reported first with the error behind
+   them (HTTPError.Internal, set by serviceError), panics already reported by Recover are skipped
+
+3. Registered right after Tracing, the reports carry the request's trace ID
+
+ ***************************************************************************************/
+
+// panicReportedKey marks a request whose panic Recover already reported
+const panicReportedKey = "panic_reported"
+
+// Recover returns a middleware that turns handler panics into 500s and reports them
+func Recover() echo.MiddlewareFunc {
+	return middleware.RecoverWithConfig(middleware.RecoverConfig{
+		DisablePrintStack: true, //? The reporter logs the stack
+		LogErrorFunc: func(c echo.Context, err error, stack []byte) error {
+			req := c.Request()
+			reporting.CapturePanic(req.Context(), err, req.Method+" "+c.Path(), map[string]string{"url.path": req.URL.Path})
+			c.Set(panicReportedKey, true)
+			return err
+		},
+	})
+}
+
+// ErrorHandler returns the app's HTTP error handler, it reports 500s before answering them like Echo does
+func ErrorHandler(e *echo.Echo) echo.HTTPErrorHandler {
+	return func(err error, c echo.Context) {
+		if reported, _ := c.Get(panicReportedKey).(bool); !reported && !c.Response().Committed {
+			status, cause := http.StatusInternalServerError, err
+			var httpErr *echo.HTTPError
+			if errors.As(err, &httpErr) {
+				status = httpErr.Code
+				if httpErr.Internal != nil {
+					cause = httpErr.Internal
+				}
+			}
+
+			//? 503 and 504 are load or dependency problems, not bugs
+			if status == http.StatusInternalServerError {
+				req := c.Request()
+				reporting.CaptureError(req.Context(), cause, req.Method+" "+c.Path(), map[string]string{"url.path": req.URL.Path})
+			}
+		}
+
+		e.DefaultHTTPErrorHandler(err, c)
+	}
+}
@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"context"
+	"event-horizon/models"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+/*********** SCANNER KEY MIDDLEWARE FUNCTION  *************************************************
+
+1. ScannerKeyOrJWT - Lets door staff in with a scanner key ("X-Scanner-Key" header) instead of a login
+
+2. Requests without the header fall back to JWTMiddleware, so hosts keep using their token
+
+3. Only mount it on the check-in and attendee lookup routes, the services check that the key belongs to the event
+
+4. ScannerKeyFromContext - Returns the key the request came in with, nil for logged in users
+
+ ***************************************************************************************/
+
+// HeaderScannerKey is the request header door devices send their scanner key in
+const HeaderScannerKey = "X-Scanner-Key"
+
+// scannerKeyContextKey is where the scanner key of the request is kept
+const scannerKeyContextKey = "scanner_key"
+
+// ScannerKeyAuthenticator looks up the active key behind a scanner key, nil when it is unknown, revoked or expired
+type ScannerKeyAuthenticator interface {
+	Authenticate(ctx context.Context, secret string) (*models.ScannerKey, error)
+}
+
+// ScannerKeyOrJWT returns a middleware that accepts a scanner key or a JWT
+func ScannerKeyOrJWT(keys ScannerKeyAuthenticator) echo.MiddlewareFunc {
+	jwtMiddleware := JWTMiddleware()
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		withJWT := jwtMiddleware(next)
+
+		return func(c echo.Context) error {
+			secret := strings.TrimSpace(c.Request().Header.Get(HeaderScannerKey))
+			if secret == "" {
+				return withJWT(c)
+			}
+
+			key, err := keys.Authenticate(c.Request().Context(), secret)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusInternalServerError, "Failed to check scanner key").SetInternal(err)
+			}
+			if key == nil {
+				return echo.NewHTTPError(http.StatusUnauthorized, "Invalid, expired or revoked scanner key")
+			}
+
+			c.Set(scannerKeyContextKey, key)
+			return next(c)
+		}
+	}
+}
+
+// ScannerKeyFromContext returns the scanner key the request came in with, nil when it came with a JWT
+func ScannerKeyFromContext(c echo.Context) *models.ScannerKey {
+	key, _ := c.Get(scannerKeyContextKey).(*models.ScannerKey)
+	return key
+}
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"event-horizon/utils"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+/*********** SCOPE MIDDLEWARE FUNCTION  *************************************************
+
+1. RequireScope - Only lets tokens that carry the scope through, the user is NOT loaded from the database
+
+2. Must run AFTER JWTMiddleware, it reads the scopes from the parsed token
+
+3. Tokens issued before roles were added have no role, their users must log in again
+
+4. Tokens keep the scopes they were issued with, POST /users/me/token hands out one with the current roles
+
+ ***************************************************************************************/
+
+// RequireScope returns a middleware that rejects tokens without the scope
+func RequireScope(scope string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			claims, err := utils.GetClaimsFromToken(c)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+			}
+
+			if claims.Role == "" {
+				return echo.NewHTTPError(http.StatusUnauthorized, "Token has no role, please log in again")
+			}
+
+			if !claims.HasScope(scope) {
+				return echo.NewHTTPError(http.StatusForbidden, "Your token doesn't allow this ("+scope+"), refresh it if your role changed")
+			}
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+/*********** REQUEST TIMEOUT MIDDLEWARE  *************************************************
+
+1. RequestTimeout - Puts a deadline on the request context, every store call gets its ctx from
+   c.Request().Context(), so no Mongo query can outlive the request budget
+
+2. When the deadline was hit the handler's error is replaced by a 504 with a clear message
+
+3. Long lived SSE streams (/stream) are skipped, they are meant to stay open
+
+4. Streamed downloads (/export) are skipped too, a big export can take longer than the budget
+
+ ***************************************************************************************/
+
+// RequestTimeout returns a middleware that bounds how long a request (and its store calls) may run
+func RequestTimeout(timeout time.Duration) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if strings.HasSuffix(c.Path(), "/stream") || strings.HasSuffix(c.Path(), "/export") {
+				return next(c)
+			}
+
+			ctx, cancel := context.WithTimeout(c.Request().Context(), timeout)
+			defer cancel()
+			c.SetRequest(c.Request().WithContext(ctx))
+
+			err := next(c)
+
+			//! Controllers wrap store errors, so look at the context to know the deadline was the cause
+			if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) && !c.Response().Committed {
+				return echo.NewHTTPError(http.StatusGatewayTimeout, "The request took too long and was cancelled, please try again").SetInternal(err)
+			}
+
+			return err
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
+	"go.opentelemetry.io/otel/trace"
+)
+
+/*********** REQUEST TRACING MIDDLEWARE  *************************************************
+
+1. Tracing - Opens the SERVER SPAN of the request with otelecho, named after the route ("POST /api/v1/events/:id/bookings"),
+   the store and Mongo spans of the request hang below it
+
+2. A W3C traceparent header is continued, the trace ID goes back in X-Trace-Id so a slow or failed request
+   can be looked up in Jaeger
+
+3. 5xx answers mark the span as failed
+
+4. Registered first, the request timeout and every other middleware run inside the span
+
+ ***************************************************************************************/
+
+// Tracing returns a middleware that traces every request, its spans are no-ops while tracing is not set up
+func Tracing(serviceName string) echo.MiddlewareFunc {
+	traced := otelecho.Middleware(serviceName)
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return traced(func(c echo.Context) error {
+			if spanContext := trace.SpanContextFromContext(c.Request().Context()); spanContext.IsSampled() {
+				c.Response().Header().Set("X-Trace-Id", spanContext.TraceID().String())
+			}
+			return next(c)
+		})
+	}
+}
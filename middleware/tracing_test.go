@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"event-horizon/tracing"
+
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := tracing.NewProvider(recorder, "event-horizon-test", 1)
+	previous, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer func() {
+		otel.SetTracerProvider(previous)
+		otel.SetTextMapPropagator(previousPropagator)
+		provider.Shutdown(context.Background())
+	}()
+
+	e := echo.New()
+	e.Use(Tracing("event-horizon-test"))
+	e.GET("/events/:id", func(c echo.Context) error {
+		_, span := tracing.Start(c.Request().Context(), "EventStore.GetEventByID", tracing.KindInternal)
+		tracing.End(span, nil)
+		return c.NoContent(http.StatusOK)
+	})
+	e.GET("/broken", func(c echo.Context) error {
+		return echo.NewHTTPError(http.StatusInternalServerError, "broken")
+	})
+
+	//? The caller's trace is continued and its ID sent back
+	req := httptest.NewRequest(http.MethodGet, "/events/42", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	if got := rec.Header().Get("X-Trace-Id"); got != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Fatalf("X-Trace-Id = %q", got)
+	}
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("%d spans ended, want the store and the server span", len(spans))
+	}
+	store, server := spans[0], spans[1]
+	if server.Name() != "GET /events/:id" || server.Parent().SpanID().String() != "00f067aa0ba902b7" {
+		t.Fatalf("server span %q with parent %s", server.Name(), server.Parent().SpanID())
+	}
+	if store.Parent().SpanID() != server.SpanContext().SpanID() {
+		t.Fatal("the store span is not below the server span")
+	}
+
+	//? A 5xx marks the span as failed
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/broken", nil))
+	spans = recorder.Ended()
+	if broken := spans[len(spans)-1]; broken.Name() != "GET /broken" || broken.Status().Code != codes.Error {
+		t.Fatalf("span %q status %+v, want a failed span", broken.Name(), broken.Status())
+	}
+	if rec.Header().Get("X-Trace-Id") == "" {
+		t.Fatal("no X-Trace-Id on a new trace")
+	}
+}
@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+/*********** API VERSIONING MIDDLEWARE  *************************************************
+
+1. APIVersion - Tags every response with the API version and rejects requests asking for a version this group doesn't serve
+
+2. Clients can ask for a version with the "Accept-Version" header (e.g. "v1" or "1")
+
+3. Deprecated - Marks legacy (unversioned) routes with Deprecation / Sunset / Link headers pointing at the versioned path
+
+ ***************************************************************************************/
+
+// HeaderAcceptVersion is the request header clients use to pick an API version
+const HeaderAcceptVersion = "Accept-Version"
+
+// HeaderAPIVersion is the response header telling clients which version served them
+const HeaderAPIVersion = "API-Version"
+
+// NegotiateVersion normalizes a requested version ("1", "v1", "V1") to "v1", empty if none was requested
+func NegotiateVersion(requested string) string {
+	requested = strings.ToLower(strings.TrimSpace(requested))
+	if requested == "" {
+		return ""
+	}
+	if !strings.HasPrefix(requested, "v") {
+		requested = "v" + requested
+	}
+	return requested
+}
+
+// APIVersion returns a middleware for a group serving the given version (e.g. "v1")
+func APIVersion(version string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			requested := NegotiateVersion(c.Request().Header.Get(HeaderAcceptVersion))
+			if requested != "" && requested != version {
+				return echo.NewHTTPError(http.StatusNotAcceptable, "API version "+requested+" is not supported on this path, use /api/"+requested)
+			}
+
+			c.Response().Header().Set(HeaderAPIVersion, version)
+			return next(c)
+		}
+	}
+}
+
+// Deprecated returns a middleware that marks legacy routes as deprecated in favour of the versioned prefix
+func Deprecated(legacyPrefix, versionedPrefix, sunset string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			successor := versionedPrefix + strings.TrimPrefix(c.Request().URL.Path, legacyPrefix)
+
+			header := c.Response().Header()
+			header.Set("Deprecation", "true")
+			header.Set("Sunset", sunset)
+			header.Set("Link", "<"+successor+">; rel=\"successor-version\"")
+
+			return next(c)
+		}
+	}
+}
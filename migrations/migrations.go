@@ -0,0 +1,218 @@
+package migrations
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+/** *********************  SCHEMA MIGRATIONS   ********************
+
+New fields (category_id, slug, version, updated_at ...) need backfills on
+documents that were written before the field existed. Every backfill is a
+Migration with a version number, they run in version order and each applied
+version is recorded in the Migrations collection, so a migration only ever
+runs once per database.
+
+Migrations run on startup (RUN_MIGRATIONS, default on) or by hand with
+
+	go run ./cmd/migrate            (apply pending migrations)
+	go run ./cmd/migrate -status    (list applied and pending migrations)
+
+With several instances starting at once only one of them migrates: a lock
+document with an expiry is taken first, the others wait for it.
+
+To add a migration, append it to the list in steps.go with the next
+version number. Never renumber or edit a migration that was released,
+write a new one instead. Migrations should be safe to run twice (only touch
+documents that still need it), in case one fails halfway.
+
+ **************************************/
+
+// Migration is one versioned schema change
+type Migration struct {
+	Version int
+	Name    string
+	Up      func(ctx context.Context, db *mongo.Database) error
+}
+
+// Record is an applied migration as stored in the Migrations collection
+type Record struct {
+	Version   int       `bson:"_id"`
+	Name      string    `bson:"name"`
+	AppliedAt time.Time `bson:"applied_at"`
+}
+
+// State is a migration and when it was applied (nil while pending)
+type State struct {
+	Version   int
+	Name      string
+	AppliedAt *time.Time
+}
+
+const (
+	collectionName = "Migrations"
+	lockID         = "lock"
+	lockTTL        = 10 * time.Minute //? a crashed instance can't block migrations forever
+	lockPoll       = time.Second
+)
+
+// ! Run applies all pending migrations in version order and returns how many ran
+func Run(ctx context.Context, db *mongo.Database) (int, error) {
+	return run(ctx, db, all)
+}
+
+// ! Status lists every known migration and whether it was applied
+func Status(ctx context.Context, db *mongo.Database) ([]State, error) {
+	applied, err := appliedVersions(ctx, db.Collection(collectionName))
+	if err != nil {
+		return nil, err
+	}
+
+	states := make([]State, 0, len(all))
+	for _, migration := range sorted(all) {
+		state := State{Version: migration.Version, Name: migration.Name}
+		if record, ok := applied[migration.Version]; ok {
+			state.AppliedAt = &record.AppliedAt
+		}
+		states = append(states, state)
+	}
+
+	return states, nil
+}
+
+func run(ctx context.Context, db *mongo.Database, migrations []Migration) (int, error) {
+	if err := validate(migrations); err != nil {
+		return 0, err
+	}
+
+	collection := db.Collection(collectionName)
+
+	release, err := acquireLock(ctx, collection)
+	if err != nil {
+		return 0, err
+	}
+	defer release()
+
+	//! Read what was applied only after taking the lock, another instance may have just finished
+	applied, err := appliedVersions(ctx, collection)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, migration := range sorted(migrations) {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+
+		log.Printf("Running migration %d (%s)", migration.Version, migration.Name)
+		started := time.Now()
+		if err := migration.Up(ctx, db); err != nil {
+			return count, fmt.Errorf("migration %d (%s) failed: %w", migration.Version, migration.Name, err)
+		}
+
+		record := Record{Version: migration.Version, Name: migration.Name, AppliedAt: time.Now()}
+		if _, err := collection.InsertOne(ctx, record); err != nil {
+			return count, fmt.Errorf("migration %d (%s) ran but could not be recorded: %w", migration.Version, migration.Name, err)
+		}
+		log.Printf("Migration %d done in %s", migration.Version, time.Since(started).Round(time.Millisecond))
+		count++
+	}
+
+	return count, nil
+}
+
+// validate rejects duplicate or non positive versions, a typo there would skip or rerun a migration
+func validate(migrations []Migration) error {
+	seen := make(map[int]bool, len(migrations))
+	for _, migration := range migrations {
+		if migration.Version <= 0 {
+			return fmt.Errorf("migration %q has version %d, versions start at 1", migration.Name, migration.Version)
+		}
+		if seen[migration.Version] {
+			return fmt.Errorf("migration version %d is used twice", migration.Version)
+		}
+		seen[migration.Version] = true
+	}
+	return nil
+}
+
+// sorted returns the migrations ordered by version
+func sorted(migrations []Migration) []Migration {
+	ordered := append([]Migration(nil), migrations...)
+	sort.Slice(ordered, func(i, j int) bool {
+		return ordered[i].Version < ordered[j].Version
+	})
+	return ordered
+}
+
+// appliedVersions loads the applied migrations by version
+func appliedVersions(ctx context.Context, collection *mongo.Collection) (map[int]Record, error) {
+	//? Only numeric IDs are migrations, the lock document has a string ID
+	cursor, err := collection.Find(ctx, bson.M{"_id": bson.M{"$type": "number"}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var records []Record
+	if err := cursor.All(ctx, &records); err != nil {
+		return nil, err
+	}
+
+	applied := make(map[int]Record, len(records))
+	for _, record := range records {
+		applied[record.Version] = record
+	}
+	return applied, nil
+}
+
+// acquireLock waits until this instance holds the migration lock and returns the function that releases it
+func acquireLock(ctx context.Context, collection *mongo.Collection) (func(), error) {
+	tokenBytes := make([]byte, 16)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return nil, err
+	}
+	token := hex.EncodeToString(tokenBytes)
+
+	waiting := false
+	for {
+		now := time.Now()
+
+		//? Take the lock if there is none or it expired, a held lock makes the upsert hit the unique _id
+		filter := bson.M{"_id": lockID, "locked_until": bson.M{"$lt": now}}
+		update := bson.M{"$set": bson.M{"owner": token, "locked_until": now.Add(lockTTL)}}
+		_, err := collection.UpdateOne(ctx, filter, update, options.UpdateOne().SetUpsert(true))
+		if err == nil {
+			release := func() {
+				//! Use a fresh context so a cancelled run still releases the lock
+				collection.DeleteOne(context.Background(), bson.M{"_id": lockID, "owner": token})
+			}
+			return release, nil
+		}
+		if !mongo.IsDuplicateKeyError(err) {
+			return nil, err
+		}
+
+		if !waiting {
+			log.Println("Another instance is running migrations, waiting for it")
+			waiting = true
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, errors.New("gave up waiting for the migration lock: " + ctx.Err().Error())
+		case <-time.After(lockPoll):
+		}
+	}
+}
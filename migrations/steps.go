@@ -0,0 +1,163 @@
+package migrations
+
+import (
+	"context"
+	"event-horizon/models"
+	"event-horizon/store"
+	"event-horizon/utils"
+	"log"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+//! THE LIST OF MIGRATIONS, APPEND NEW ONES WITH THE NEXT VERSION NUMBER
+
+var all = []Migration{
+	{
+		Version: 1,
+		Name:    "event_category_ids",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			//? Events used to reference their category only by category_name
+			updated, err := store.NewCategoryStore(db, store.NewBookingStore(db)).BackfillEventCategoryIDs(ctx)
+			log.Printf("Set category_id on %d event(s)", updated)
+			return err
+		},
+	},
+	{
+		Version: 2,
+		Name:    "category_slugs",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			//? Categories created before slugs existed
+			updated, err := store.NewCategoryStore(db, store.NewBookingStore(db)).BackfillCategorySlugs(ctx)
+			log.Printf("Set slug on %d category(ies)", updated)
+			return err
+		},
+	},
+	{
+		Version: 3,
+		Name:    "event_version_and_updated_at",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			events := db.Collection("Events")
+
+			//? Events written before optimistic locking start at version 1
+			_, err := events.UpdateMany(ctx,
+				bson.M{"version": bson.M{"$exists": false}},
+				bson.M{"$set": bson.M{"version": 1}})
+			if err != nil {
+				return err
+			}
+
+			//? Events never written since updated_at was added were last changed when created
+			_, err = events.UpdateMany(ctx,
+				bson.M{"updated_at": bson.M{"$exists": false}},
+				bson.A{bson.M{"$set": bson.M{"updated_at": "$created_at"}}})
+			return err
+		},
+	},
+	{
+		Version: 4,
+		Name:    "user_role_flags",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			users := db.Collection("Users")
+
+			//? Early users have no role flags at all, make them explicit attendees
+			for _, field := range []string{"is_host", "is_admin"} {
+				_, err := users.UpdateMany(ctx,
+					bson.M{field: bson.M{"$exists": false}},
+					bson.M{"$set": bson.M{field: false}})
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		Version: 5,
+		Name:    "amounts_in_minor_units",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			//? Everything sold before currencies existed was in the default currency (2 decimals)
+			toMinor := func(field string) bson.M {
+				return bson.M{"$toLong": bson.M{"$round": bson.A{bson.M{"$multiply": bson.A{field, 100}}, 0}}}
+			}
+
+			_, err := db.Collection("Events").UpdateMany(ctx,
+				bson.M{"currency": bson.M{"$exists": false}},
+				bson.A{bson.M{"$set": bson.M{
+					"currency": models.DefaultCurrency,
+					"tickets": bson.M{"$map": bson.M{
+						"input": bson.M{"$ifNull": bson.A{"$tickets", bson.A{}}},
+						"as":    "ticket",
+						"in": bson.M{"$mergeObjects": bson.A{"$$ticket", bson.M{
+							"currency":    models.DefaultCurrency,
+							"price_minor": toMinor("$$ticket.price"),
+						}}},
+					}},
+				}}})
+			if err != nil {
+				return err
+			}
+
+			_, err = db.Collection("Bookings").UpdateMany(ctx,
+				bson.M{"total_paid_minor": bson.M{"$exists": false}},
+				bson.A{bson.M{"$set": bson.M{
+					"currency":         models.DefaultCurrency,
+					"total_paid_minor": toMinor("$total_paid"),
+				}}})
+			return err
+		},
+	},
+	{
+		Version: 6,
+		Name:    "booking_tax_breakdown",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			//? Bookings from before taxes were all untaxed, the subtotal is what was paid
+			_, err := db.Collection("Bookings").UpdateMany(ctx,
+				bson.M{"subtotal_minor": bson.M{"$exists": false}},
+				bson.A{bson.M{"$set": bson.M{
+					"subtotal_minor": "$total_paid_minor",
+					"tax_minor":      0,
+				}}})
+			return err
+		},
+	},
+	{
+		Version: 7,
+		Name:    "booking_attendees",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			bookings := db.Collection("Bookings")
+
+			//? Older bookings get one unnamed attendee per ticket so every ticket has a check-in code
+			cursor, err := bookings.Find(ctx, bson.M{"attendees": bson.M{"$exists": false}})
+			if err != nil {
+				return err
+			}
+			defer cursor.Close(ctx)
+
+			updated := 0
+			for cursor.Next(ctx) {
+				var booking models.Booking
+				if err := cursor.Decode(&booking); err != nil {
+					return err
+				}
+
+				attendees := make([]models.Attendee, booking.Quantity)
+				for i := range attendees {
+					attendees[i].CheckInCode = utils.GenerateCheckInCode()
+				}
+
+				_, err := bookings.UpdateOne(ctx,
+					bson.M{"_id": booking.ID, "attendees": bson.M{"$exists": false}},
+					bson.M{"$set": bson.M{"attendees": attendees}})
+				if err != nil {
+					return err
+				}
+				updated++
+			}
+
+			log.Printf("Added attendees to %d booking(s)", updated)
+			return cursor.Err()
+		},
+	},
+}
@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// AccountExport is everything the platform keeps about a user, for GDPR data access requests
+type AccountExport struct {
+	ExportedAt    time.Time      `json:"exported_at"`
+	Profile       *User          `json:"profile"`
+	Bookings      []Booking      `json:"bookings"`
+	Comments      []Comment      `json:"comments"` //? Written on event pages, the closest thing to reviews
+	Following     []Follow       `json:"following"`
+	Sessions      []UserSession  `json:"sessions"` //? Active logins
+	Notifications []Notification `json:"notifications"`
+	Consents      []Consent      `json:"consents"` //? Accepted versions of the terms and privacy policy
+
+	HostVerifications []HostVerification `json:"host_verifications"` //? Hosts' KYC submissions, the payout account only as its last 4 digits
+}
+
+// Files splits the export into one file per part, for the ZIP download
+func (e *AccountExport) Files() map[string]interface{} {
+	return map[string]interface{}{
+		"profile.json":       e.Profile,
+		"bookings.json":      e.Bookings,
+		"comments.json":      e.Comments,
+		"following.json":     e.Following,
+		"sessions.json":      e.Sessions,
+		"notifications.json": e.Notifications,
+		"consents.json":      e.Consents,
+
+		"host_verifications.json": e.HostVerifications,
+	}
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// Affiliate is an ambassador a host pays a commission for every booking made with their code
+type Affiliate struct {
+	ID                bson.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	HostID            bson.ObjectID `bson:"host_id" json:"host_id"` //? AUTO, the code works on every event of the host
+	Name              string        `bson:"name" json:"name"`
+	Email             string        `bson:"email,omitempty" json:"email,omitempty"`
+	Code              string        `bson:"code" json:"code"`                             //? AUTO, unique, sent as ref when booking
+	CommissionPercent float64       `bson:"commission_percent" json:"commission_percent"` //? Of the booking subtotal (without tax)
+	Active            bool          `bson:"active" json:"active"`                         //? Inactive codes no longer earn commission
+	CreatedAt         time.Time     `bson:"created_at" json:"created_at"`
+}
+
+// AffiliateSales is what an affiliate sold in one currency
+type AffiliateSales struct {
+	AffiliateID     bson.ObjectID `bson:"affiliate_id" json:"-"`
+	Currency        string        `bson:"currency" json:"currency"`
+	Bookings        int           `bson:"bookings" json:"bookings"`
+	Tickets         int           `bson:"tickets" json:"tickets"`
+	SalesMinor      int64         `bson:"sales_minor" json:"sales_minor"` //? Subtotals, without tax
+	Sales           string        `bson:"-" json:"sales"`
+	CommissionMinor int64         `bson:"commission_minor" json:"commission_minor"` //? Owed to the affiliate
+	Commission      string        `bson:"-" json:"commission"`
+}
+
+// AffiliateReport is an affiliate with their sales, one entry per currency
+type AffiliateReport struct {
+	Affiliate
+	Sales []AffiliateSales `json:"sales"`
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// TicketTypeSales is what one ticket type of an event sold
+type TicketTypeSales struct {
+	TicketType   string  `bson:"_id" json:"ticket_type"`
+	Bookings     int     `bson:"bookings" json:"bookings"`
+	Sold         int     `bson:"sold" json:"sold"`
+	RevenueMinor int64   `bson:"revenue" json:"revenue_minor"` //? In minor units of the event currency
+	Revenue      float64 `bson:"-" json:"revenue"`
+	Total        int     `bson:"-" json:"total"` //? From the event, not the aggregation
+}
+
+// DailySales is what an event sold on one day (in the event's timezone), for charts
+type DailySales struct {
+	Date         string  `bson:"_id" json:"date"` //? YYYY-MM-DD
+	Bookings     int     `bson:"bookings" json:"bookings"`
+	Sold         int     `bson:"sold" json:"sold"`
+	RevenueMinor int64   `bson:"revenue" json:"revenue_minor"`
+	Revenue      float64 `bson:"-" json:"revenue"`
+}
+
+// EventAnalytics is the sales report of one event for its host
+type EventAnalytics struct {
+	EventID           bson.ObjectID     `json:"event_id"`
+	Timezone          string            `json:"timezone"` //? Days of daily_sales are in this zone
+	Currency          string            `json:"currency"` //? Every amount of the report is in it
+	TicketsSold       int               `json:"tickets_sold"`
+	TicketsTotal      int               `json:"tickets_total"`
+	SellThroughRate   float64           `json:"sell_through_rate"` //? tickets_sold / tickets_total
+	RevenueMinor      int64             `json:"revenue_minor"`
+	Revenue           float64           `json:"revenue"`
+	ConfirmedBookings int               `json:"confirmed_bookings"`
+	CancelledBookings int               `json:"cancelled_bookings"`
+	CancellationRate  float64           `json:"cancellation_rate"` //? cancelled / (confirmed + cancelled)
+	CheckedIn         int               `json:"checked_in"`
+	CheckInRate       float64           `json:"check_in_rate"` //? checked_in / attendees
+	ByTicketType      []TicketTypeSales `json:"by_ticket_type"`
+	DailySales        []DailySales      `json:"daily_sales"`
+}
+
+// BookingExportRow is one transaction in a host's bookings export
+type BookingExportRow struct {
+	TransactionID  string        `json:"transaction_id"`
+	BookingID      bson.ObjectID `json:"booking_id"`
+	EventID        bson.ObjectID `json:"event_id"`
+	EventName      string        `json:"event_name"`
+	TicketType     string        `json:"ticket_type"`
+	Quantity       int           `json:"quantity"`
+	SubtotalMinor  int64         `json:"subtotal_minor"`
+	TaxMinor       int64         `json:"tax_minor"`
+	TotalPaidMinor int64         `json:"total_paid_minor"`
+	Currency       string        `json:"currency"`
+	Status         string        `json:"status"`
+	BookedAt       time.Time     `json:"booked_at"`
+}
@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// Announcement is an update the host sends to everyone attending an event ("venue changed", "doors open 7pm")
+type Announcement struct {
+	ID         bson.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	EventID    bson.ObjectID `bson:"event_id" json:"event_id"`
+	AuthorID   bson.ObjectID `bson:"author_id" json:"author_id"` //? AUTO, the host or co-host who sent it
+	Message    string        `bson:"message" json:"message"`
+	Recipients int           `bson:"recipients" json:"recipients"` //? Attendees it was sent to
+	CreatedAt  time.Time     `bson:"created_at" json:"created_at"`
+}
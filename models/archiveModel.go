@@ -0,0 +1,128 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// ArchivedEvent is what a host keeps of an event that ended, with its final sales and attendance.
+// The cleanup writes it when it deletes the event, until then it is computed from the live bookings.
+type ArchivedEvent struct {
+	ID           bson.ObjectID   `bson:"_id" json:"id"` //? The ID the event had
+	HostID       bson.ObjectID   `bson:"host_id" json:"host_id"`
+	CoHosts      []bson.ObjectID `bson:"co_hosts,omitempty" json:"co_hosts,omitempty"`
+	OrgID        *bson.ObjectID  `bson:"org_id,omitempty" json:"org_id,omitempty"`
+	VenueID      *bson.ObjectID  `bson:"venue_id,omitempty" json:"venue_id,omitempty"`
+	Name         string          `bson:"name" json:"name"`
+	CategoryName string          `bson:"category_name" json:"category_name"`
+	Location     string          `bson:"location" json:"location"`
+	Status       string          `bson:"status" json:"status"` //? published or cancelled
+	Timezone     string          `bson:"timezone,omitempty" json:"timezone,omitempty"`
+	Currency     string          `bson:"currency" json:"currency"`
+	StartTime    time.Time       `bson:"start_time" json:"start_time"`
+	EndTime      time.Time       `bson:"end_time" json:"end_time"`
+
+	TicketsSold       int                  `bson:"tickets_sold" json:"tickets_sold"`
+	TicketsTotal      int                  `bson:"tickets_total" json:"tickets_total"`
+	ConfirmedBookings int                  `bson:"confirmed_bookings" json:"confirmed_bookings"`
+	Attendees         int                  `bson:"attendees" json:"attendees"`
+	CheckedIn         int                  `bson:"checked_in" json:"checked_in"`
+	RevenueMinor      int64                `bson:"revenue_minor" json:"revenue_minor"` //? In minor units of the event currency
+	Revenue           float64              `bson:"-" json:"revenue"`
+	ByTicketType      []ArchivedTicketType `bson:"by_ticket_type" json:"by_ticket_type"`
+
+	ArchivedAt *time.Time `bson:"archived_at,omitempty" json:"archived_at,omitempty"` //? Missing while the event itself is still kept
+}
+
+// ArchivedTicketType is the final count of one ticket type of an archived event
+type ArchivedTicketType struct {
+	TicketType   string  `bson:"ticket_type" json:"ticket_type"`
+	Sold         int     `bson:"sold" json:"sold"`
+	Total        int     `bson:"total" json:"total"`
+	RevenueMinor int64   `bson:"revenue_minor" json:"revenue_minor"`
+	Revenue      float64 `bson:"-" json:"revenue"`
+	Attendees    int     `bson:"attendees" json:"attendees"`
+	CheckedIn    int     `bson:"checked_in" json:"checked_in"`
+}
+
+// IsArchivable reports whether an ended event belongs in its host's history, drafts and rejected events never ran
+func (e *Event) IsArchivable() bool {
+	return e.Status == "" || e.Status == EventStatusPublished || e.Status == EventStatusCancelled
+}
+
+// NewArchivedEvent snapshots an event with the sales and check-ins of its confirmed bookings
+func NewArchivedEvent(event *Event, sales []TicketTypeSales, checkIns []TicketTypeCheckIns) *ArchivedEvent {
+	archived := &ArchivedEvent{
+		ID:           event.ID,
+		HostID:       event.HostID,
+		CoHosts:      event.CoHosts,
+		OrgID:        event.OrgID,
+		VenueID:      event.VenueID,
+		Name:         event.Name,
+		CategoryName: event.CategoryName,
+		Location:     event.Location,
+		Status:       event.Status,
+		Timezone:     event.Timezone,
+		Currency:     event.Currency,
+		StartTime:    event.StartTime,
+		EndTime:      event.EndTime,
+		ByTicketType: []ArchivedTicketType{},
+	}
+	if archived.Status == "" {
+		archived.Status = EventStatusPublished
+	}
+	if archived.Currency == "" {
+		archived.Currency = DefaultCurrency
+	}
+
+	//? Every ticket type of the event is listed, ticket types removed after they sold too
+	index := make(map[string]int)
+	ticketType := func(name string) *ArchivedTicketType {
+		i, ok := index[name]
+		if !ok {
+			i = len(archived.ByTicketType)
+			index[name] = i
+			archived.ByTicketType = append(archived.ByTicketType, ArchivedTicketType{TicketType: name})
+		}
+		return &archived.ByTicketType[i]
+	}
+
+	for _, ticket := range event.Tickets {
+		ticketType(ticket.Type).Total = ticket.TotalQuantity
+		archived.TicketsTotal += ticket.TotalQuantity
+	}
+	for _, sale := range sales {
+		entry := ticketType(sale.TicketType)
+		entry.Sold = sale.Sold
+		entry.RevenueMinor = sale.RevenueMinor
+		archived.TicketsSold += sale.Sold
+		archived.RevenueMinor += sale.RevenueMinor
+		archived.ConfirmedBookings += sale.Bookings
+	}
+	for _, count := range checkIns {
+		entry := ticketType(count.TicketType)
+		entry.Attendees = count.Attendees
+		entry.CheckedIn = count.CheckedIn
+		archived.Attendees += count.Attendees
+		archived.CheckedIn += count.CheckedIn
+	}
+
+	return archived
+}
+
+// ConvertRevenue fills in the revenue in major units of the currency, only minor units are stored
+func (a *ArchivedEvent) ConvertRevenue() {
+	a.Revenue = FromMinorUnits(a.RevenueMinor, a.Currency)
+	for i := range a.ByTicketType {
+		a.ByTicketType[i].Revenue = FromMinorUnits(a.ByTicketType[i].RevenueMinor, a.Currency)
+	}
+}
+
+// PastEventsQuery picks one page of a host's ended events, newest end first
+type PastEventsQuery struct {
+	UserID    bson.ObjectID //? Events they host or co-host
+	BeforeEnd time.Time     //? With BeforeID the cursor, zero starts at the newest
+	BeforeID  bson.ObjectID
+	Limit     int
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// Audit actions
+const (
+	AuditEventCreated             = "event.created"
+	AuditEventDeleted             = "event.deleted"
+	AuditEventCancelled           = "event.cancelled"
+	AuditCategoryCascadeDeleted   = "category.cascade_deleted"
+	AuditBookingCancelled         = "booking.cancelled"
+	AuditBookingForceCancelled    = "booking.force_cancelled"
+	AuditBookingTicketTypeChanged = "booking.ticket_type_changed"
+	AuditUserRoleChanged          = "user.role_changed"
+	AuditEventApproved            = "event.approved"
+	AuditEventRescheduled         = "event.rescheduled"
+	AuditEventRejected            = "event.rejected"
+	AuditEventAutoHidden          = "event.auto_hidden"
+	AuditUserSuspended            = "user.suspended"
+	AuditUserUnsuspended          = "user.unsuspended"
+	AuditHostVerified             = "host.verified"
+	AuditHostVerificationRejected = "host.verification_rejected"
+	AuditCommentAutoHidden        = "comment.auto_hidden"
+	AuditCommentDeleted           = "comment.deleted"
+	AuditJobRetried               = "job.retried"
+	AuditSchedulerRun             = "scheduler.run"
+	AuditScannerKeyCreated        = "scanner_key.created"
+	AuditScannerKeyRevoked        = "scanner_key.revoked"
+	AuditUserDeleted              = "user.deleted"
+	AuditVenueDeleted             = "venue.deleted"
+)
+
+// AuditLog records who did what to which resource
+type AuditLog struct {
+	ID         bson.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	ActorID    bson.ObjectID `bson:"actor_id" json:"actor_id"`
+	Action     string        `bson:"action" json:"action"`
+	TargetType string        `bson:"target_type" json:"target_type"`
+	TargetID   bson.ObjectID `bson:"target_id" json:"target_id"`
+	IP         string        `bson:"ip" json:"ip"`
+	Before     interface{}   `bson:"before,omitempty" json:"before,omitempty"`
+	After      interface{}   `bson:"after,omitempty" json:"after,omitempty"`
+	CreatedAt  time.Time     `bson:"created_at" json:"created_at"`
+}
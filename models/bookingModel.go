@@ -1,6 +1,7 @@
 package models
 
 import (
+	"encoding/json"
 	"time"
 
 	"go.mongodb.org/mongo-driver/v2/bson"
@@ -8,19 +9,104 @@ import (
 
 type Booking struct {
 	ID            bson.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
-	UserID        bson.ObjectID `bson:"user_id" json:"user_id" validate:"required"` //? AUTO
+	UserID        bson.ObjectID `bson:"user_id,omitempty" json:"user_id" validate:"required"` //? AUTO, empty for guest bookings
+	GuestID       bson.ObjectID `bson:"guest_id,omitempty" json:"guest_id,omitempty"` //? AUTO, set instead of user_id for GUEST checkout
+	GuestCodeHash string        `bson:"guest_code_hash,omitempty" json:"-"` //! SHA-256 of the guest's access code, the code itself is only emailed
 	EventID       bson.ObjectID `bson:"event_id" json:"event_id" validate:"required"`
+	SessionID     bson.ObjectID `bson:"session_id,omitempty" json:"session_id,omitempty"` //? Optional, for multi-session events
 	TicketType    string        `bson:"ticket_type" json:"ticket_type" validate:"required,oneof=VIP Regular Student"`
 	TransactionID string        `bson:"transaction_id" json:"transaction_id"`
 	Quantity      int           `bson:"quantity" json:"quantity" validate:"required,gt=0"`
-	TotalPaid     float64       `bson:"total_paid" json:"total_paid"` //? AUTO
-	Status        string        `bson:"status" json:"status"` //? AUTO
+	TotalPaid     float64       `bson:"total_paid" json:"total_paid"` //? AUTO, derived from total_paid_minor
+	TotalPaidMinor int64        `bson:"total_paid_minor" json:"total_paid_minor"` //? AUTO, in minor units of the currency
+	Currency      string        `bson:"currency" json:"currency"` //? AUTO, the event's currency
+	SubtotalMinor int64         `bson:"subtotal_minor" json:"subtotal_minor"` //? AUTO, total_paid_minor without the tax
+	TaxMinor      int64         `bson:"tax_minor" json:"tax_minor"` //? AUTO
+	TaxRate       float64       `bson:"tax_rate,omitempty" json:"tax_rate,omitempty"` //? AUTO, the event's rate when booked (percent)
+	TaxInclusive  bool          `bson:"tax_inclusive,omitempty" json:"tax_inclusive,omitempty"` //? AUTO, the ticket price already contained the tax
+	Attendees     []Attendee    `bson:"attendees,omitempty" json:"attendees,omitempty"` //? One per ticket, AUTO when not given
+	Status        string        `bson:"status" json:"status"` //? AUTO, confirmed or cancelled
+	CancelledAt   *time.Time    `bson:"cancelled_at,omitempty" json:"cancelled_at,omitempty"` //? AUTO, cancelled bookings are kept for the records
+	ReferralCode  string        `bson:"referral_code,omitempty" json:"referral_code,omitempty"` //? Share link the booking came from
+	AffiliateID   bson.ObjectID `bson:"affiliate_id,omitempty" json:"-"` //? AUTO, set when the ref is an affiliate code of the host
+	CommissionMinor int64       `bson:"commission_minor,omitempty" json:"-"` //? AUTO, what the affiliate earned
 	BookedAt      time.Time     `bson:"booked_at" json:"booked_at"` //? AUTO
+	Adjustments   []PriceAdjustment `bson:"adjustments,omitempty" json:"adjustments,omitempty"` //? AUTO, one per ticket type change
+	NeedsReconfirmation bool        `bson:"needs_reconfirmation,omitempty" json:"needs_reconfirmation,omitempty"` //? AUTO, the event was rescheduled, the buyer must keep or cancel it
+	ReconfirmBy   *time.Time        `bson:"reconfirm_by,omitempty" json:"reconfirm_by,omitempty"` //? AUTO, refunded after this when not reconfirmed
 }
 
-// BookingWithDetails includes populated related data for API responses
+// PriceAdjustment records a ticket type change and what it cost, payments settle the difference
+type PriceAdjustment struct {
+	FromType        string    `bson:"from_type" json:"from_type"`
+	ToType          string    `bson:"to_type" json:"to_type"`
+	DifferenceMinor int64     `bson:"difference_minor" json:"difference_minor"` //? > 0 the buyer owes it, < 0 it is refunded
+	Currency        string    `bson:"currency" json:"currency"`
+	ChangedAt       time.Time `bson:"changed_at" json:"changed_at"`
+}
+
+// Attendee is the person one ticket of a booking is for, every attendee has their own check-in code
+type Attendee struct {
+	Name        string     `bson:"name" json:"name"` //? Can be changed until the event starts
+	Email       string     `bson:"email,omitempty" json:"email,omitempty"`
+	CheckInCode string     `bson:"check_in_code" json:"check_in_code"` //? AUTO, what the door scans
+	CheckedInAt *time.Time `bson:"checked_in_at,omitempty" json:"checked_in_at,omitempty"`
+}
+
+// bookingJSON has the same fields as Booking without its MarshalJSON, or json.Marshal would recurse
+type bookingJSON Booking
+
+// bookingResponse is a booking with the formatted amounts ("25.00 USD"), unset user / guest / session ids are left out
+type bookingResponse struct {
+	bookingJSON
+	UserID             *bson.ObjectID `json:"user_id,omitempty"` //? Shadows the embedded field
+	GuestID            *bson.ObjectID `json:"guest_id,omitempty"`
+	SessionID          *bson.ObjectID `json:"session_id,omitempty"`
+	SubtotalFormatted  string         `json:"subtotal_formatted"`
+	TaxFormatted       string         `json:"tax_formatted"`
+	TotalPaidFormatted string         `json:"total_paid_formatted"`
+}
+
+func newBookingResponse(b Booking) bookingResponse {
+	return bookingResponse{
+		bookingJSON:        bookingJSON(b),
+		UserID:             OptionalID(b.UserID),
+		GuestID:            OptionalID(b.GuestID),
+		SessionID:          OptionalID(b.SessionID),
+		SubtotalFormatted:  FormatAmount(b.SubtotalMinor, b.Currency),
+		TaxFormatted:       FormatAmount(b.TaxMinor, b.Currency),
+		TotalPaidFormatted: FormatAmount(b.TotalPaidMinor, b.Currency),
+	}
+}
+
+// MarshalJSON adds the formatted amounts ("25.00 USD") to the booking and leaves out unset ids
+func (b Booking) MarshalJSON() ([]byte, error) {
+	return json.Marshal(newBookingResponse(b))
+}
+
+// BookingWithDetails is a booking with its event and (for hosts and admins) its buyer looked up, for list responses
 type BookingWithDetails struct {
-	Booking Booking `json:"booking"`
-	Event   *Event  `json:"event,omitempty"`
-	User    *User   `json:"user,omitempty"`
+	Booking `bson:",inline"`
+	Event   *EventSummary `bson:"event,omitempty" json:"event,omitempty"` //? Missing when the event is gone
+	User    *BookingUser  `bson:"user,omitempty" json:"user,omitempty"`
+}
+
+// BookingUser is the buyer of a booking as hosts and admins see them
+type BookingUser struct {
+	ID    bson.ObjectID `bson:"_id" json:"id"`
+	Name  string        `bson:"name" json:"name"`
+	Email string        `bson:"email" json:"email"`
+}
+
+// MarshalJSON writes the booking's own fields with the event and the user next to them
+func (b BookingWithDetails) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		bookingResponse
+		Event *EventSummary `json:"event,omitempty"`
+		User  *BookingUser  `json:"user,omitempty"`
+	}{
+		newBookingResponse(b.Booking),
+		b.Event,
+		b.User,
+	})
 }
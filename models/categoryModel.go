@@ -7,9 +7,12 @@ import (
 )
 
 type Category struct {
-	ID        bson.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
-	Name      string        `bson:"name" json:"name" validate:"required,min=2"`
-	CreatedAt time.Time     `bson:"created_at" json:"created_at"`
+	ID          bson.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	Name        string        `bson:"name" json:"name" validate:"required,min=2"`
+	Slug        string        `bson:"slug" json:"slug"` //? AUTO, unique and URL-safe
+	Description string        `bson:"description" json:"description"`
+	ImageURL    string        `bson:"image_url" json:"image_url"`
+	CreatedAt   time.Time     `bson:"created_at" json:"created_at"`
 }
 
 // CategoryWithEvents includes all events under this category
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// Check-in results of one scanned code
+const (
+	CheckInStatusCheckedIn        = "checked_in"
+	CheckInStatusAlreadyCheckedIn = "already_checked_in" //? The code was used before, checked_in_at is the first scan
+	CheckInStatusNotFound         = "not_found"          //? No attendee of this event has the code
+)
+
+// TicketTypeCheckIns is how many attendees of one ticket type there are and how many are in
+type TicketTypeCheckIns struct {
+	TicketType string `bson:"_id" json:"ticket_type"`
+	Attendees  int    `bson:"attendees" json:"attendees"`
+	CheckedIn  int    `bson:"checked_in" json:"checked_in"`
+}
+
+// CheckInStats are the live door counts of an event
+type CheckInStats struct {
+	EventID      bson.ObjectID        `json:"event_id"`
+	Attendees    int                  `json:"attendees"`
+	CheckedIn    int                  `json:"checked_in"`
+	ByTicketType []TicketTypeCheckIns `json:"by_ticket_type"`
+	GeneratedAt  time.Time            `json:"generated_at"`
+}
+
+// CheckInResult is what happened to one scanned check-in code
+type CheckInResult struct {
+	Code        string     `json:"code"`
+	Status      string     `json:"status"`
+	Name        string     `json:"name,omitempty"` //? The attendee, empty when the code was not found
+	TicketType  string     `json:"ticket_type,omitempty"`
+	CheckedInAt *time.Time `json:"checked_in_at,omitempty"`
+}
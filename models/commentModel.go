@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// Comment is a question or remark on an event page, replies go one level deep
+type Comment struct {
+	ID          bson.ObjectID   `bson:"_id,omitempty" json:"id,omitempty"`
+	EventID     bson.ObjectID   `bson:"event_id" json:"event_id"`
+	ParentID    *bson.ObjectID  `bson:"parent_id,omitempty" json:"parent_id,omitempty"` //? Set on replies
+	AuthorID    bson.ObjectID   `bson:"author_id" json:"author_id"`                     //? AUTO
+	AuthorName  string          `bson:"author_name" json:"author_name"`                 //? AUTO
+	ByHost      bool            `bson:"by_host" json:"by_host"`                         //? Written by the host or a co-host, answers stand out
+	Body        string          `bson:"body" json:"body"`
+	Hidden      bool            `bson:"hidden" json:"-"` //? Reported too often, waits for an admin
+	ReportCount int             `bson:"report_count" json:"-"`
+	Reports     []CommentReport `bson:"reports,omitempty" json:"-"`
+	CreatedAt   time.Time       `bson:"created_at" json:"created_at"`
+	Replies     []Comment       `bson:"-" json:"replies,omitempty"` //? Filled on top level comments when listing
+}
+
+// CommentReport is a user flagging a comment, a user can report a comment once
+type CommentReport struct {
+	UserID    bson.ObjectID `bson:"user_id"`
+	Reason    string        `bson:"reason"`
+	CreatedAt time.Time     `bson:"created_at"`
+}
+
+// IsReply reports whether the comment answers another comment
+func (c *Comment) IsReply() bool {
+	return c.ParentID != nil
+}
+
+// ReportedComment is a reported comment with its report count for the admin view
+type ReportedComment struct {
+	Comment Comment        `json:"comment"`
+	Reports int            `json:"reports"`
+	Reasons map[string]int `json:"reasons"`
+	Hidden  bool           `json:"hidden"`
+}
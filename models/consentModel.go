@@ -0,0 +1,59 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// Legal documents users accept
+const (
+	PolicyTerms   = "terms"
+	PolicyPrivacy = "privacy"
+)
+
+// Where an acceptance was given
+const (
+	ConsentAtRegistration = "registration"
+	ConsentAtBooking      = "booking"
+	ConsentAtReacceptance = "reacceptance" //? Accepted again after the document changed
+)
+
+// Consent records that a user or guest accepted one version of a legal document, they are never changed or deleted
+type Consent struct {
+	ID         bson.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID     bson.ObjectID `bson:"user_id,omitempty" json:"user_id,omitempty"`
+	GuestID    bson.ObjectID `bson:"guest_id,omitempty" json:"guest_id,omitempty"`     //? Guest bookings, there is no user
+	BookingID  bson.ObjectID `bson:"booking_id,omitempty" json:"booking_id,omitempty"` //? Set when accepted while booking
+	Policy     string        `bson:"policy" json:"policy"`                             //? PolicyTerms or PolicyPrivacy
+	Version    string        `bson:"version" json:"version"`
+	Source     string        `bson:"source" json:"source"` //? ConsentAtRegistration, ConsentAtBooking or ConsentAtReacceptance
+	IP         string        `bson:"ip" json:"ip"`
+	AcceptedAt time.Time     `bson:"accepted_at" json:"accepted_at"`
+}
+
+// PolicyVersions are versions of the legal documents, empty ones aren't asked for
+type PolicyVersions struct {
+	Terms   string `json:"terms,omitempty"`
+	Privacy string `json:"privacy,omitempty"`
+}
+
+// ByPolicy returns the versions keyed by document, empty ones left out
+func (v PolicyVersions) ByPolicy() map[string]string {
+	versions := map[string]string{}
+	if v.Terms != "" {
+		versions[PolicyTerms] = v.Terms
+	}
+	if v.Privacy != "" {
+		versions[PolicyPrivacy] = v.Privacy
+	}
+	return versions
+}
+
+// ConsentStatus tells the frontend whether the user has to accept the legal documents again
+type ConsentStatus struct {
+	Current          PolicyVersions     `json:"current"`
+	Accepted         map[string]Consent `json:"accepted"` //? The latest acceptance per document
+	Outdated         []string           `json:"outdated"` //? Documents whose current version the user hasn't accepted
+	ReacceptRequired bool               `json:"reaccept_required"`
+}
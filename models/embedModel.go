@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// EmbedEvent is an event of the embeddable widget hosts put on their own sites
+type EmbedEvent struct {
+	ID        bson.ObjectID `json:"id"`
+	Name      string        `json:"name"`
+	URL       string        `json:"url"`        //? The public event page
+	StartTime time.Time     `json:"start_time"` //? In the event's own time zone
+	Timezone  string        `json:"timezone"`
+	Location  string        `json:"location"`
+	EventType string        `json:"event_type"`
+	ImageURL  string        `json:"image_url,omitempty"`
+	PriceFrom string        `json:"price_from,omitempty"` //? Cheapest ticket, formatted ("12.50 USD")
+	SoldOut   bool          `json:"sold_out"`
+	UpdatedAt time.Time     `json:"-"` //? Only used for the ETag
+}
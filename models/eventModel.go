@@ -1,6 +1,8 @@
 package models
 
 import (
+	"encoding/json"
+	"slices"
 	"time"
 
 	"go.mongodb.org/mongo-driver/v2/bson"
@@ -8,39 +10,400 @@ import (
 
 type TicketInfo struct {
 	Type              string  `json:"type" bson:"type" validate:"required,oneof=VIP Regular Student"`
-	Price             float64 `json:"price" bson:"price" validate:"required,gt=0"`
+	Price             float64 `json:"price" bson:"price" validate:"gte=0"` //? Derived from price_minor, 0 for a free ticket
+	PriceMinor        int64   `json:"price_minor" bson:"price_minor"`      //? AUTO, what a ticket costs in minor units of the currency
+	Currency          string  `json:"currency" bson:"currency"`            //? AUTO, copied from the event
 	TotalQuantity     int     `json:"total_quantity" bson:"total_quantity" validate:"required,gt=0"`
 	AvailableQuantity int     `json:"available_quantity" bson:"available_quantity" validate:"required,gte=0"`
+
+	SaleStart *time.Time `json:"sale_start,omitempty" bson:"sale_start,omitempty"` //? Optional, the ticket type can't be booked before (presale / general sale)
+	SaleEnd   *time.Time `json:"sale_end,omitempty" bson:"sale_end,omitempty"`     //? Optional, the ticket type can't be booked after
+
+	countsHidden bool //? Set by PublicTickets, available_quantity is left out of the JSON
+}
+
+// Sale statuses of a ticket type
+const (
+	SaleUpcoming = "upcoming" //? sale_start is still ahead
+	SaleOnSale   = "on_sale"
+	SaleEnded    = "ended"
+)
+
+// SaleStatus says whether the ticket type can be booked at the given time
+func (t TicketInfo) SaleStatus(now time.Time) string {
+	if t.SaleStart != nil && now.Before(*t.SaleStart) {
+		return SaleUpcoming
+	}
+	if t.SaleEnd != nil && !now.Before(*t.SaleEnd) {
+		return SaleEnded
+	}
+	return SaleOnSale
+}
+
+// GeoPoint is a GeoJSON Point, coordinates are [longitude, latitude]
+type GeoPoint struct {
+	Type        string    `json:"type" bson:"type"`
+	Coordinates []float64 `json:"coordinates" bson:"coordinates"`
+}
+
+// Session is one part of a multi-day / multi-session event
+type Session struct {
+	ID                bson.ObjectID `json:"id" bson:"id"` //? AUTO
+	Title             string        `json:"title" bson:"title" validate:"required"`
+	StartTime         time.Time     `json:"start_time" bson:"start_time" validate:"required"`
+	EndTime           time.Time     `json:"end_time" bson:"end_time" validate:"required"`
+	Capacity          int           `json:"capacity,omitempty" bson:"capacity,omitempty"` //? 0 means unlimited
+	AvailableCapacity int           `json:"available_capacity,omitempty" bson:"available_capacity,omitempty"`
+}
+
+// Event types
+const (
+	EventTypeInPerson = "in_person"
+	EventTypeOnline   = "online"
+	EventTypeHybrid   = "hybrid"
+)
+
+// Event statuses, events without a status are published
+const (
+	EventStatusDraft         = "draft"
+	EventStatusPendingReview = "pending_review" //? Waiting for an admin when moderation is enabled
+	EventStatusPublished     = "published"
+	EventStatusRejected      = "rejected"
+	EventStatusCancelled     = "cancelled" //? Called off by the host, kept for the records but never listed or booked
+)
+
+// Actions of a bulk event request (POST /events/bulk)
+const (
+	BulkActionPublish        = "publish"
+	BulkActionUnpublish      = "unpublish" //? Back to draft
+	BulkActionCancel         = "cancel"    //? Cancelled, the bookings are refunded
+	BulkActionDelete         = "delete"
+	BulkActionChangeCategory = "change_category"
+)
+
+// TicketPriceChange is the old and new price of one ticket type in a bulk price change
+type TicketPriceChange struct {
+	TicketType    string  `json:"ticket_type"`
+	Currency      string  `json:"currency"`
+	OldPrice      float64 `json:"old_price"`
+	NewPrice      float64 `json:"new_price"`
+	OldPriceMinor int64   `json:"old_price_minor"`
+	NewPriceMinor int64   `json:"new_price_minor"`
+}
+
+// BulkPriceResult is what a bulk price change did (or would do, on a dry run) to one event
+type BulkPriceResult struct {
+	EventID   string              `json:"event_id"`
+	EventName string              `json:"event_name,omitempty"`
+	OK        bool                `json:"ok"`
+	Changes   []TicketPriceChange `json:"changes,omitempty"`
+	Error     string              `json:"error,omitempty"`
+}
+
+// BulkEventResult is what happened to one event of a bulk request
+type BulkEventResult struct {
+	EventID  string `json:"event_id"`
+	OK       bool   `json:"ok"`
+	Status   string `json:"status,omitempty"`   //? The event's status after the action, empty when it was deleted
+	Refunded int    `json:"refunded,omitempty"` //? Bookings refunded by a cancellation
+	Error    string `json:"error,omitempty"`
 }
 
 type Event struct {
-	ID           bson.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
-	HostID       bson.ObjectID `bson:"host_id" json:"host_id" validate:"required"`
-	CategoryName string        `bson:"category_name" json:"category_name" validate:"required"`
-	Name         string        `bson:"name" json:"name" validate:"required"`
-	Description  string        `bson:"description" json:"description"`
-	Date         time.Time     `bson:"date" json:"date" validate:"required"`
-	Location     string        `bson:"location" json:"location" validate:"required"`
-	ImageURL     string        `bson:"image_url" json:"image_url"`
-	StartTime    time.Time     `bson:"start_time" json:"start_time" validate:"required"`
-	EndTime      time.Time     `bson:"end_time" json:"end_time" validate:"required"`
-	CreatedAt    time.Time     `bson:"created_at" json:"created_at"`
-	Tickets      []TicketInfo  `bson:"tickets" json:"tickets" validate:"dive,required"`
+	ID               bson.ObjectID   `bson:"_id,omitempty" json:"id,omitempty"`
+	HostID           bson.ObjectID   `bson:"host_id" json:"host_id" validate:"required"`
+	CoHosts          []bson.ObjectID `bson:"co_hosts,omitempty" json:"co_hosts,omitempty"`               //? Can manage the event like the host
+	CoHostInvites    []bson.ObjectID `bson:"co_host_invites,omitempty" json:"co_host_invites,omitempty"` //? Invited, not yet accepted
+	OrgID            *bson.ObjectID  `bson:"org_id,omitempty" json:"org_id,omitempty"`                   //? The ORGANIZATION running the event, HostID is its owner
+	OrgManagers      []bson.ObjectID `bson:"org_managers,omitempty" json:"-"`                            //? AUTO, owner and managers of the org, can manage the event like a co-host
+	OrgScanners      []bson.ObjectID `bson:"org_scanners,omitempty" json:"-"`                            //? AUTO, scanners of the org, can only check attendees in
+	CategoryID       bson.ObjectID   `bson:"category_id" json:"category_id"`                             //? AUTO, looked up from category_name
+	CategoryName     string          `bson:"category_name" json:"category_name" validate:"required"`
+	Tags             []string        `bson:"tags,omitempty" json:"tags,omitempty" validate:"max=10"`
+	Name             string          `bson:"name" json:"name" validate:"required"`
+	Description      string          `bson:"description" json:"description"`
+	Date             time.Time       `bson:"date" json:"date" validate:"required"`
+	Timezone         string          `bson:"timezone" json:"timezone"`                               //? IANA name, times are stored in UTC
+	Currency         string          `bson:"currency" json:"currency" validate:"omitempty,len=3"`    //? ISO 4217, every ticket is sold in it
+	TaxRate          float64         `bson:"tax_rate,omitempty" json:"tax_rate,omitempty"`           //? Percent, 0 means no tax
+	TaxInclusive     bool            `bson:"tax_inclusive,omitempty" json:"tax_inclusive,omitempty"` //? Ticket prices already contain the tax
+	Location         string          `bson:"location" json:"location" validate:"required"`
+	GeoLocation      *GeoPoint       `bson:"geo_location,omitempty" json:"geo_location,omitempty"`
+	VenueID          *bson.ObjectID  `bson:"venue_id,omitempty" json:"venue_id,omitempty"` //? Optional VENUE, location and geo_location are copied from it
+	EventType        string          `bson:"event_type" json:"event_type" validate:"omitempty,oneof=in_person online hybrid"`
+	StreamURL        string          `bson:"stream_url,omitempty" json:"stream_url,omitempty"` //! Hidden in public responses
+	ImageURL         string          `bson:"image_url" json:"image_url"`
+	Status           string          `bson:"status,omitempty" json:"status,omitempty"`                       //? AUTO, only published events are listed
+	PublishAt        *time.Time      `bson:"publish_at,omitempty" json:"publish_at,omitempty"`               //? A draft goes live at this time (SCHEDULED publishing)
+	ModerationReason string          `bson:"moderation_reason,omitempty" json:"moderation_reason,omitempty"` //? Why an admin rejected the event
+	StartTime        time.Time       `bson:"start_time" json:"start_time" validate:"required"`
+	EndTime          time.Time       `bson:"end_time" json:"end_time" validate:"required"`
+	CreatedAt        time.Time       `bson:"created_at" json:"created_at"`
+	UpdatedAt        time.Time       `bson:"updated_at,omitempty" json:"updated_at,omitempty"`         //? AUTO, set on every write (ETag / Last-Modified)
+	Version          int             `bson:"version" json:"version"`                                   //? AUTO, +1 on every host edit, PUT must send the version it read
+	HostSuspended    bool            `bson:"host_suspended,omitempty" json:"host_suspended,omitempty"` //? AUTO, the host is suspended, the event is hidden and can't be booked
+	HostVerified     bool            `bson:"host_verified,omitempty" json:"host_verified,omitempty"`   //? AUTO, VERIFIED HOST badge, an admin approved the host's KYC
+	Tickets          []TicketInfo    `bson:"tickets" json:"tickets" validate:"dive,required"`
+	Sessions         []Session       `bson:"sessions,omitempty" json:"sessions,omitempty" validate:"dive"`
+
+	CapacityAlerts     *CapacityAlertSettings `bson:"capacity_alerts,omitempty" json:"capacity_alerts,omitempty"` //? nil means the defaults, hidden in public responses
+	CapacityAlertsSent map[string][]int       `bson:"capacity_alerts_sent,omitempty" json:"-"`                    //? AUTO, thresholds already alerted per ticket type
+
+	HideTicketCounts bool   `bson:"hide_ticket_counts,omitempty" json:"hide_ticket_counts,omitempty"` //? Public responses show availability buckets instead of available_quantity
+	StockFlag        string `bson:"-" json:"stock_flag,omitempty"`                                    //? Computed for public responses (selling_fast / almost_sold_out)
+
+	Reschedule *Reschedule `bson:"reschedule,omitempty" json:"reschedule,omitempty"` //? AUTO, the last time the host moved the event
+
+	AllowVenueOverlap bool            `bson:"-" json:"-"`                         //? Sent by the host to keep an event that runs while another one at its venue does
+	VenueConflicts    []VenueConflict `bson:"-" json:"venue_conflicts,omitempty"` //? Filled when an overlap was allowed, the events the venue is shared with
+}
+
+// Reschedule keeps the dates an event had before the host moved it
+type Reschedule struct {
+	PreviousDate      time.Time  `bson:"previous_date" json:"previous_date"`
+	PreviousStartTime time.Time  `bson:"previous_start_time" json:"previous_start_time"`
+	PreviousEndTime   time.Time  `bson:"previous_end_time" json:"previous_end_time"`
+	RescheduledAt     time.Time  `bson:"rescheduled_at" json:"rescheduled_at"`
+	ReconfirmBy       *time.Time `bson:"reconfirm_by,omitempty" json:"reconfirm_by,omitempty"` //? Set when attendees had to reconfirm, unanswered bookings are refunded after it
+}
+
+// DefaultCapacityThresholds are the capacity alerts of events without settings, in percent sold (100 is sold out)
+var DefaultCapacityThresholds = []int{50, 90, 100}
+
+// CapacityAlertSettings says when and how the host hears that a ticket type is selling out
+type CapacityAlertSettings struct {
+	Thresholds []int `bson:"thresholds,omitempty" json:"thresholds,omitempty"` //? Percent of a ticket type sold, empty means the defaults
+	InApp      bool  `bson:"in_app" json:"in_app"`
+	Email      bool  `bson:"email" json:"email"` //? Both off turns the alerts off
+}
+
+// AlertSettings returns the event's capacity alert settings with the defaults filled in
+func (e *Event) AlertSettings() CapacityAlertSettings {
+	settings := CapacityAlertSettings{InApp: true, Email: true}
+	if e.CapacityAlerts != nil {
+		settings = *e.CapacityAlerts
+	}
+	if len(settings.Thresholds) == 0 {
+		settings.Thresholds = DefaultCapacityThresholds
+	}
+	return settings
 }
 
 type EventResponse struct {
-	ID           bson.ObjectID `json:"id,omitempty"`
-	Name		 string        `json:"name"`
-	HostID       bson.ObjectID `json:"host_id"`
-	CategoryName string        `json:"category_name"`
-	Date         time.Time     `json:"date"`
-	Location     string        `json:"location"`
-	Tickets      []TicketInfo  `json:"tickets"`
+	ID               bson.ObjectID   `json:"id,omitempty"`
+	Name             string          `json:"name"`
+	Description      string          `json:"description"`
+	HostID           bson.ObjectID   `json:"host_id"`
+	HostVerified     bool            `json:"host_verified"` //? VERIFIED HOST badge
+	OrgID            *bson.ObjectID  `json:"org_id,omitempty"`
+	CoHosts          []bson.ObjectID `json:"co_hosts,omitempty"`
+	CategoryID       bson.ObjectID   `json:"category_id"`
+	CategoryName     string          `json:"category_name"`
+	Tags             []string        `json:"tags,omitempty"`
+	Date             time.Time       `json:"date"`
+	Timezone         string          `json:"timezone"`
+	Currency         string          `json:"currency"`
+	TaxRate          float64         `json:"tax_rate,omitempty"`
+	TaxInclusive     bool            `json:"tax_inclusive,omitempty"`
+	StartTime        time.Time       `json:"start_time"`
+	EndTime          time.Time       `json:"end_time"`
+	Location         string          `json:"location"`
+	GeoLocation      *GeoPoint       `json:"geo_location,omitempty"`
+	VenueID          *bson.ObjectID  `json:"venue_id,omitempty"`
+	EventType        string          `json:"event_type"`
+	StreamURL        string          `json:"stream_url,omitempty"`
+	ImageURL         string          `json:"image_url"`
+	Status           string          `json:"status,omitempty"`
+	PublishAt        *time.Time      `json:"publish_at,omitempty"`
+	ModerationReason string          `json:"moderation_reason,omitempty"`
+	CreatedAt        time.Time       `json:"created_at"`
+	UpdatedAt        time.Time       `json:"updated_at,omitempty"`
+	Version          int             `json:"version"`
+	Tickets          []TicketInfo    `json:"tickets"`
+	Sessions         []Session       `json:"sessions,omitempty"`
+
+	CapacityAlerts   *CapacityAlertSettings `json:"capacity_alerts,omitempty"`
+	HideTicketCounts bool                   `json:"hide_ticket_counts,omitempty"`
+	StockFlag        string                 `json:"stock_flag,omitempty"` //? selling_fast / almost_sold_out over every ticket type
+	Reschedule       *Reschedule            `json:"reschedule,omitempty"`
+
+	Warnings       []DuplicateWarning `json:"warnings,omitempty"`        //? Create only, existing events that look like this one
+	VenueConflicts []VenueConflict    `json:"venue_conflicts,omitempty"` //? Events at the same venue at the same time, the host allowed the overlap
 }
 
+// VenueConflict points at another event held at the same venue while the event runs
+type VenueConflict struct {
+	EventID   bson.ObjectID `json:"event_id"`
+	Name      string        `json:"name"`
+	StartTime time.Time     `json:"start_time"`
+	EndTime   time.Time     `json:"end_time"`
+	SameHost  bool          `json:"same_host"`
+}
 
+// DuplicateWarning points at an existing event on the same day whose name is nearly the same
+type DuplicateWarning struct {
+	EventID    bson.ObjectID `json:"event_id"`
+	Name       string        `json:"name"`
+	StartTime  time.Time     `json:"start_time"`
+	SameHost   bool          `json:"same_host"`
+	Similarity float64       `json:"similarity"` //? 1 is the same name
+}
 
+// EventSummary is a slim event for cards and grids, only the requested fields are filled in
+type EventSummary struct {
+	ID           bson.ObjectID `bson:"_id" json:"id"`
+	Name         string        `bson:"name,omitempty" json:"name,omitempty"`
+	CategoryName string        `bson:"category_name,omitempty" json:"category_name,omitempty"`
+	Tags         []string      `bson:"tags,omitempty" json:"tags,omitempty"`
+	Date         *time.Time    `bson:"date,omitempty" json:"date,omitempty"`
+	StartTime    *time.Time    `bson:"start_time,omitempty" json:"start_time,omitempty"`
+	EndTime      *time.Time    `bson:"end_time,omitempty" json:"end_time,omitempty"`
+	Timezone     string        `bson:"timezone,omitempty" json:"timezone,omitempty"`
+	Location     string        `bson:"location,omitempty" json:"location,omitempty"`
+	EventType    string        `bson:"event_type,omitempty" json:"event_type,omitempty"`
+	ImageURL     string        `bson:"image_url,omitempty" json:"image_url,omitempty"`
+	MinPrice     *float64      `bson:"min_price,omitempty" json:"min_price,omitempty"`         //? Cheapest ticket, computed by the projection
+	Currency     string        `bson:"currency,omitempty" json:"currency,omitempty"`           //? Projected along with min_price
+	HostVerified bool          `bson:"host_verified,omitempty" json:"host_verified,omitempty"` //? VERIFIED HOST badge
+	UpdatedAt    time.Time     `bson:"updated_at,omitempty" json:"-"`                          //? Always projected, only used for the listing ETag
+}
 
+// CalendarQuery picks the public events running between From and To (UTC) for the platform calendar
+type CalendarQuery struct {
+	From     time.Time
+	To       time.Time
+	Category string //? Exact category name, empty means every category
+	Limit    int
+}
 
+// EventCalendar is the platform calendar, only the days something runs on are listed
+type EventCalendar struct {
+	From      string        `json:"from"` //? 2006-01-02 in the calendar's time zone
+	To        string        `json:"to"`
+	Timezone  string        `json:"timezone"`
+	Days      []CalendarDay `json:"days"`
+	Truncated bool          `json:"truncated,omitempty"` //? More events run in the range than the calendar lists, narrow it down
+}
 
+// CalendarDay is one day of the calendar, an event running for several days is listed on each of them
+type CalendarDay struct {
+	Date   string          `json:"date"`
+	Events []*EventSummary `json:"events"`
+}
 
+// ExpiredEvent is an event the cleanup would delete, with the number of bookings deleted along with it
+type ExpiredEvent struct {
+	ID       bson.ObjectID `bson:"_id" json:"id"`
+	Name     string        `bson:"name" json:"name"`
+	HostID   bson.ObjectID `bson:"host_id" json:"host_id"`
+	EndTime  time.Time     `bson:"end_time" json:"end_time"`
+	Bookings int64         `bson:"bookings" json:"bookings"`
+}
+
+// UpcomingEventsQuery picks public events that haven't ended yet
+type UpcomingEventsQuery struct {
+	HostID      bson.ObjectID //? Zero means every host
+	Category    string        //? Exact category name, empty means every category
+	VenueID     bson.ObjectID //? Zero means every venue
+	Limit       int
+	NewestFirst bool //? Latest listed first instead of soonest first
+}
+
+// MarshalJSON adds the formatted price ("12.50 USD"), the stock flag, the availability bucket and the sale status to the ticket
+func (t TicketInfo) MarshalJSON() ([]byte, error) {
+	type ticketJSON TicketInfo //? Same fields without this method, or json.Marshal would recurse
+
+	//? Shadows the embedded available_quantity, nil leaves it out
+	available := &t.AvailableQuantity
+	if t.countsHidden {
+		available = nil
+	}
+
+	return json.Marshal(struct {
+		ticketJSON
+		AvailableQuantity *int   `json:"available_quantity,omitempty"`
+		PriceFormatted    string `json:"price_formatted"`
+		StockFlag         string `json:"stock_flag,omitempty"`
+		Availability      string `json:"availability"`
+		SaleStatus        string `json:"sale_status"`
+	}{
+		ticketJSON(t),
+		available,
+		FormatAmount(t.PriceMinor, t.Currency),
+		StockFlag(t.AvailableQuantity, t.TotalQuantity),
+		Availability(t.AvailableQuantity, t.TotalQuantity),
+		t.SaleStatus(time.Now()),
+	})
+}
+
+// PublicTickets returns a copy of the tickets for public responses, without exact counts when the host hides them
+func (e *Event) PublicTickets() []TicketInfo {
+	tickets := slices.Clone(e.Tickets)
+	if e.HideTicketCounts {
+		for i := range tickets {
+			tickets[i].countsHidden = true
+		}
+	}
+	return tickets
+}
+
+// ApplyCurrency defaults the event's currency and prices every ticket in its minor units
+func (e *Event) ApplyCurrency() {
+	if e.Currency == "" {
+		e.Currency = DefaultCurrency
+	}
+	for i := range e.Tickets {
+		ticket := &e.Tickets[i]
+		ticket.Currency = e.Currency
+		ticket.PriceMinor = ToMinorUnits(ticket.Price, e.Currency)
+		ticket.Price = FromMinorUnits(ticket.PriceMinor, e.Currency) //? 12.345 USD is sold as 12.35
+	}
+}
+
+// IsPublished reports whether the event is live (listed and bookable)
+func (e *Event) IsPublished() bool {
+	return !e.HostSuspended && (e.Status == "" || e.Status == EventStatusPublished)
+}
+
+// HasPaidTickets reports whether any ticket type of the event costs money, selling those needs a verified host
+func (e *Event) HasPaidTickets() bool {
+	for _, ticket := range e.Tickets {
+		if ticket.PriceMinor > 0 || ticket.Price > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// LastModified is when the event last changed, events written before UpdatedAt existed fall back to CreatedAt
+func (e *Event) LastModified() time.Time {
+	if e.UpdatedAt.IsZero() {
+		return e.CreatedAt
+	}
+	return e.UpdatedAt
+}
+
+// IsManagedBy reports whether the user is the host, a co-host or a manager of the event's organization
+func (e *Event) IsManagedBy(userID bson.ObjectID) bool {
+	if e.HostID == userID {
+		return true
+	}
+	return slices.Contains(e.CoHosts, userID) || slices.Contains(e.OrgManagers, userID)
+}
+
+// CanCheckIn reports whether the user may check attendees in: the event's managers and its organization's scanners
+func (e *Event) CanCheckIn(userID bson.ObjectID) bool {
+	return e.IsManagedBy(userID) || slices.Contains(e.OrgScanners, userID)
+}
+
+// EventWithDistance is an event returned from a nearby search
+type EventWithDistance struct {
+	Event      `bson:",inline"`
+	DistanceKm float64 `bson:"distance_km" json:"distance_km"`
+}
+
+// TagCount is how many events use a tag
+type TagCount struct {
+	Tag   string `bson:"_id" json:"tag"`
+	Count int    `bson:"count" json:"count"`
+}
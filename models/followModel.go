@@ -0,0 +1,14 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type Follow struct {
+	ID         bson.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	FollowerID bson.ObjectID `bson:"follower_id" json:"follower_id"` //? AUTO
+	HostID     bson.ObjectID `bson:"host_id" json:"host_id" validate:"required"`
+	CreatedAt  time.Time     `bson:"created_at" json:"created_at"` //? AUTO
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// Guest is someone who booked with only a name and an email, without an account
+type Guest struct {
+	ID        bson.ObjectID `bson:"_id,omitempty" json:"id"`
+	Name      string        `bson:"name" json:"name"`
+	Email     string        `bson:"email" json:"email"` //? Lower case
+	CreatedAt time.Time     `bson:"created_at" json:"created_at"`
+
+	ClaimedBy bson.ObjectID `bson:"claimed_by,omitempty" json:"claimed_by,omitempty"` //? The account the guest bookings were moved to
+	ClaimedAt *time.Time    `bson:"claimed_at,omitempty" json:"claimed_at,omitempty"`
+}
+
+// MarshalJSON leaves claimed_by out until the guest bookings are claimed
+func (g Guest) MarshalJSON() ([]byte, error) {
+	type guestJSON Guest //? Same fields without this method, or json.Marshal would recurse
+	return json.Marshal(struct {
+		guestJSON
+		ClaimedBy *bson.ObjectID `json:"claimed_by,omitempty"`
+	}{
+		guestJSON(g),
+		OptionalID(g.ClaimedBy),
+	})
+}
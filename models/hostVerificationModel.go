@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// Statuses of a host verification
+const (
+	VerificationPending  = "pending"
+	VerificationApproved = "approved"
+	VerificationRejected = "rejected"
+)
+
+// What a host submits to get verified
+const (
+	VerificationDocument      = "document"       //? An ID or business registration document
+	VerificationPayoutAccount = "payout_account" //? The bank or payout account ticket sales are paid out to
+)
+
+// Document types of a VerificationDocument
+var VerificationDocumentTypes = []string{"passport", "id_card", "drivers_license", "business_registration"}
+
+// HostVerification is what a host submitted to sell paid tickets (KYC), an admin approves or rejects it
+type HostVerification struct {
+	ID        bson.ObjectID `bson:"_id,omitempty" json:"id"`
+	HostID    bson.ObjectID `bson:"host_id" json:"host_id"` //? AUTO, the host behind the token
+	Kind      string        `bson:"kind" json:"kind"`       //? document or payout_account
+	LegalName string        `bson:"legal_name" json:"legal_name"`
+	Country   string        `bson:"country" json:"country"` //? ISO 3166 alpha-2
+
+	DocumentType string `bson:"document_type,omitempty" json:"document_type,omitempty"`
+	DocumentURL  string `bson:"document_url,omitempty" json:"document_url,omitempty"` //? Where the frontend uploaded the document (private storage)
+
+	PayoutProvider string `bson:"payout_provider,omitempty" json:"payout_provider,omitempty"` //? bank, stripe, paypal ...
+	PayoutAccount  string `bson:"payout_account,omitempty" json:"-"`                          //! Never returned, reviewers see PayoutLast4
+	PayoutLast4    string `bson:"payout_last4,omitempty" json:"payout_last4,omitempty"`
+
+	Status      string         `bson:"status" json:"status"`
+	ReviewNote  string         `bson:"review_note,omitempty" json:"review_note,omitempty"` //? Why it was rejected
+	ReviewedBy  *bson.ObjectID `bson:"reviewed_by,omitempty" json:"reviewed_by,omitempty"`
+	SubmittedAt time.Time      `bson:"submitted_at" json:"submitted_at"`
+	ReviewedAt  *time.Time     `bson:"reviewed_at,omitempty" json:"reviewed_at,omitempty"`
+}
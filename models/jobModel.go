@@ -0,0 +1,33 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// Job statuses
+const (
+	JobPending = "pending" //? Waiting for RunAt, new jobs and jobs that will be retried
+	JobRunning = "running"
+	JobDone    = "done"
+	JobDead    = "dead" //? Failed MaxAttempts times, only an admin retry runs it again
+)
+
+// Job is one piece of background work (an email, a commission ...) the job queue runs with retries
+type Job struct {
+	ID          bson.ObjectID   `bson:"_id,omitempty" json:"id,omitempty"`
+	Type        string          `bson:"type" json:"type"`
+	Payload     bson.Raw        `bson:"payload,omitempty" json:"-"`
+	PayloadJSON json.RawMessage `bson:"-" json:"payload,omitempty"` //? Filled for the admin view
+	Status      string          `bson:"status" json:"status"`
+	Attempts    int             `bson:"attempts" json:"attempts"`
+	MaxAttempts int             `bson:"max_attempts" json:"max_attempts"`
+	RunAt       time.Time       `bson:"run_at" json:"run_at"`
+	LockedUntil *time.Time      `bson:"locked_until,omitempty" json:"locked_until,omitempty"` //? A worker holds the job until then
+	LastError   string          `bson:"last_error,omitempty" json:"last_error,omitempty"`
+	CreatedAt   time.Time       `bson:"created_at" json:"created_at"`
+	UpdatedAt   time.Time       `bson:"updated_at" json:"updated_at"`
+	FinishedAt  *time.Time      `bson:"finished_at,omitempty" json:"finished_at,omitempty"`
+}
@@ -0,0 +1,95 @@
+package models
+
+import (
+	"math"
+	"strconv"
+)
+
+/** *********************  MONEY   ********************
+
+Every event sells in one currency (ISO 4217 code, USD when none is given).
+Amounts are stored in MINOR UNITS (cents for USD, yen for JPY, fils for KWD)
+as int64, so ticket prices and booking totals add up without float rounding.
+
+The float fields (price, total_paid) are kept next to them for older clients
+and are always derived from the minor units, never the other way round.
+Responses also carry the amount formatted with its currency.
+
+Events can charge TAX (VAT): a rate in percent, either on top of the ticket
+price (exclusive) or already in it (inclusive). Every booking keeps its own
+subtotal, tax and rate, so changing the rate later leaves sold tickets alone.
+
+ **************************************/
+
+// DefaultCurrency is the currency of events that don't name one (and of everything sold before currencies existed)
+const DefaultCurrency = "USD"
+
+// currencyExponents lists the currencies that don't use 2 decimals
+var currencyExponents = map[string]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0,
+	"PYG": 0, "RWF": 0, "UGX": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+}
+
+// IsCurrencyCode reports whether code looks like an ISO 4217 code (three upper case letters)
+func IsCurrencyCode(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// CurrencyExponent returns how many decimals the currency has
+func CurrencyExponent(currency string) int {
+	if exponent, ok := currencyExponents[currency]; ok {
+		return exponent
+	}
+	return 2
+}
+
+// ToMinorUnits converts an amount like 12.5 into minor units (1250 for USD), rounding to the nearest unit
+func ToMinorUnits(amount float64, currency string) int64 {
+	return int64(math.Round(amount * math.Pow10(CurrencyExponent(currency))))
+}
+
+// FromMinorUnits converts minor units back into an amount (1250 -> 12.5 for USD)
+func FromMinorUnits(minor int64, currency string) float64 {
+	return float64(minor) / math.Pow10(CurrencyExponent(currency))
+}
+
+// FormatMinorUnits writes minor units as a plain decimal number with the currency's decimals ("12.50")
+func FormatMinorUnits(minor int64, currency string) string {
+	return strconv.FormatFloat(FromMinorUnits(minor, currency), 'f', CurrencyExponent(currency), 64)
+}
+
+// MaxTaxRate is the highest tax rate an event can have, in percent
+const MaxTaxRate = 100.0
+
+// ApplyTax splits the price of a booking into subtotal (without tax), tax and total in minor units.
+// Exclusive tax is added on top of the amount, inclusive tax is taken out of it.
+func ApplyTax(amountMinor int64, ratePercent float64, inclusive bool) (subtotal, tax, total int64) {
+	if ratePercent <= 0 {
+		return amountMinor, 0, amountMinor
+	}
+
+	if inclusive {
+		tax = int64(math.Round(float64(amountMinor) * ratePercent / (100 + ratePercent)))
+		return amountMinor - tax, tax, amountMinor
+	}
+
+	tax = int64(math.Round(float64(amountMinor) * ratePercent / 100))
+	return amountMinor, tax, amountMinor + tax
+}
+
+// FormatAmount writes minor units with their currency ("12.50 USD")
+func FormatAmount(minor int64, currency string) string {
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+	return FormatMinorUnits(minor, currency) + " " + currency
+}
@@ -0,0 +1,105 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type Notification struct {
+	ID        bson.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	UserID    bson.ObjectID `bson:"user_id" json:"user_id"`
+	Type      string        `bson:"type" json:"type"`
+	Message   string        `bson:"message" json:"message"`
+	EventID   bson.ObjectID `bson:"event_id,omitempty" json:"event_id,omitempty"`
+	Read      bool          `bson:"read" json:"read"`
+	CreatedAt time.Time     `bson:"created_at" json:"created_at"`
+}
+
+// MarshalJSON leaves event_id out of notifications that are not about an event
+func (n Notification) MarshalJSON() ([]byte, error) {
+	type notificationJSON Notification //? Same fields without this method, or json.Marshal would recurse
+	return json.Marshal(struct {
+		notificationJSON
+		EventID *bson.ObjectID `json:"event_id,omitempty"`
+	}{
+		notificationJSON(n),
+		OptionalID(n.EventID),
+	})
+}
+
+// Notification types
+const (
+	NotificationBookingConfirmation = "booking_confirmation"
+	NotificationBookingCancellation = "booking_cancellation"
+	NotificationNewEvent            = "new_event"
+	NotificationCoHostInvite        = "co_host_invite"
+	NotificationEventApproved       = "event_approved"
+	NotificationEventRejected       = "event_rejected"
+	NotificationCapacityAlert       = "capacity_alert"
+	NotificationEventAnnouncement   = "event_announcement"
+	NotificationEventRescheduled    = "event_rescheduled"
+	NotificationNewComment          = "new_comment"
+	NotificationCommentReply        = "comment_reply"
+)
+
+// Notification channels
+const (
+	ChannelEmail = "email"
+	ChannelSMS   = "sms"
+	ChannelInApp = "in_app"
+)
+
+// NotificationChannels lists the notification types users can turn off, with the channels each one goes out on.
+// Account notices (host suspended, event suspended ...) and event announcements are not listed, they are always sent.
+var NotificationChannels = map[string][]string{
+	NotificationBookingConfirmation: {ChannelEmail, ChannelSMS},
+	NotificationBookingCancellation: {ChannelEmail},
+	NotificationNewEvent:            {ChannelInApp},
+	NotificationCoHostInvite:        {ChannelInApp},
+	NotificationEventApproved:       {ChannelInApp},
+	NotificationEventRejected:       {ChannelInApp},
+	NotificationCapacityAlert:       {ChannelInApp, ChannelEmail},
+	NotificationNewComment:          {ChannelInApp},
+	NotificationCommentReply:        {ChannelInApp},
+}
+
+// IsConfigurable reports whether users can turn the channel of the notification type off
+func IsConfigurable(notificationType, channel string) bool {
+	for _, c := range NotificationChannels[notificationType] {
+		if c == channel {
+			return true
+		}
+	}
+	return false
+}
+
+// UserPreferences is the preferences sub-document of a user
+type UserPreferences struct {
+	Notifications NotificationPreferences `bson:"notifications,omitempty" json:"notifications"`
+}
+
+// NotificationPreferences maps notification type -> channel -> on / off, anything missing is on
+type NotificationPreferences map[string]map[string]bool
+
+// Allows reports whether the notification type may be sent on the channel
+func (p NotificationPreferences) Allows(notificationType, channel string) bool {
+	if !IsConfigurable(notificationType, channel) {
+		return true
+	}
+	enabled, set := p[notificationType][channel]
+	return !set || enabled
+}
+
+// Effective returns the setting of every configurable type and channel, the defaults filled in
+func (p NotificationPreferences) Effective() NotificationPreferences {
+	effective := make(NotificationPreferences, len(NotificationChannels))
+	for notificationType, channels := range NotificationChannels {
+		effective[notificationType] = make(map[string]bool, len(channels))
+		for _, channel := range channels {
+			effective[notificationType][channel] = p.Allows(notificationType, channel)
+		}
+	}
+	return effective
+}
@@ -0,0 +1,22 @@
+package models
+
+import "go.mongodb.org/mongo-driver/v2/bson"
+
+/** *********************  OBJECT IDS IN JSON   ********************
+
+Every id in a response is a plain 24 character hex string, that is how
+bson.ObjectID marshals itself. The zero ObjectID is an unset reference
+(the user of a guest booking, the event of a notification about no event),
+omitempty doesn't skip it because it is an array, so it would show up as
+"000000000000000000000000". Models with optional references marshal them
+through OptionalID, which leaves them out instead.
+
+ **************************************/
+
+// OptionalID is nil for the zero ObjectID, so an omitempty field leaves an unset reference out
+func OptionalID(id bson.ObjectID) *bson.ObjectID {
+	if id.IsZero() {
+		return nil
+	}
+	return &id
+}
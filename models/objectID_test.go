@@ -0,0 +1,161 @@
+package models
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+var hexID = regexp.MustCompile(`^[0-9a-f]{24}$`)
+
+// marshalFields encodes v and decodes it back into its JSON fields
+func marshalFields(t *testing.T, v interface{}) ([]byte, map[string]interface{}) {
+	t.Helper()
+	raw, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		t.Fatalf("unmarshal %s: %v", raw, err)
+	}
+	return raw, fields
+}
+
+// assertHexID checks that the field is the hex string of want
+func assertHexID(t *testing.T, fields map[string]interface{}, name string, want bson.ObjectID) {
+	t.Helper()
+	got, ok := fields[name].(string)
+	if !ok {
+		t.Fatalf("%s = %#v, want a string", name, fields[name])
+	}
+	if !hexID.MatchString(got) || got != want.Hex() {
+		t.Fatalf("%s = %q, want %q", name, got, want.Hex())
+	}
+}
+
+// assertOmitted checks that the field is left out
+func assertOmitted(t *testing.T, fields map[string]interface{}, name string) {
+	t.Helper()
+	if value, ok := fields[name]; ok {
+		t.Fatalf("%s = %#v, want it left out", name, value)
+	}
+}
+
+func TestOptionalID(t *testing.T) {
+	if OptionalID(bson.ObjectID{}) != nil {
+		t.Fatal("OptionalID of the zero id is not nil")
+	}
+	id := bson.NewObjectID()
+	if got := OptionalID(id); got == nil || *got != id {
+		t.Fatalf("OptionalID(%s) = %v", id.Hex(), got)
+	}
+}
+
+func TestBookingJSONIDs(t *testing.T) {
+	booking := Booking{
+		ID:        bson.NewObjectID(),
+		UserID:    bson.NewObjectID(),
+		EventID:   bson.NewObjectID(),
+		SessionID: bson.NewObjectID(),
+		Currency:  "USD",
+	}
+
+	raw, fields := marshalFields(t, booking)
+	assertHexID(t, fields, "id", booking.ID)
+	assertHexID(t, fields, "user_id", booking.UserID)
+	assertHexID(t, fields, "event_id", booking.EventID)
+	assertHexID(t, fields, "session_id", booking.SessionID)
+	assertOmitted(t, fields, "guest_id")
+
+	var decoded Booking
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		t.Fatalf("unmarshal %s: %v", raw, err)
+	}
+	if decoded.ID != booking.ID || decoded.UserID != booking.UserID || decoded.EventID != booking.EventID || decoded.SessionID != booking.SessionID {
+		t.Fatalf("round trip changed the ids: %+v", decoded)
+	}
+}
+
+func TestGuestBookingJSONIDs(t *testing.T) {
+	booking := Booking{
+		ID:      bson.NewObjectID(),
+		GuestID: bson.NewObjectID(),
+		EventID: bson.NewObjectID(),
+	}
+
+	raw, fields := marshalFields(t, booking)
+	assertHexID(t, fields, "guest_id", booking.GuestID)
+	assertOmitted(t, fields, "user_id")
+	assertOmitted(t, fields, "session_id")
+
+	var decoded Booking
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		t.Fatalf("unmarshal %s: %v", raw, err)
+	}
+	if decoded.GuestID != booking.GuestID || !decoded.UserID.IsZero() || !decoded.SessionID.IsZero() {
+		t.Fatalf("round trip changed the ids: %+v", decoded)
+	}
+}
+
+func TestBookingWithDetailsJSONIDs(t *testing.T) {
+	booking := BookingWithDetails{
+		Booking: Booking{ID: bson.NewObjectID(), UserID: bson.NewObjectID(), EventID: bson.NewObjectID()},
+		User:    &BookingUser{ID: bson.NewObjectID(), Name: "Ada"},
+	}
+
+	_, fields := marshalFields(t, booking)
+	assertHexID(t, fields, "id", booking.ID)
+	assertHexID(t, fields, "user_id", booking.UserID)
+	assertOmitted(t, fields, "guest_id")
+	assertOmitted(t, fields, "session_id")
+
+	user, ok := fields["user"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("user = %#v, want an object", fields["user"])
+	}
+	assertHexID(t, user, "id", booking.User.ID)
+}
+
+func TestGuestJSONIDs(t *testing.T) {
+	guest := Guest{ID: bson.NewObjectID(), Name: "Ada", Email: "ada@example.com"}
+
+	_, fields := marshalFields(t, guest)
+	assertHexID(t, fields, "id", guest.ID)
+	assertOmitted(t, fields, "claimed_by")
+
+	guest.ClaimedBy = bson.NewObjectID()
+	raw, fields := marshalFields(t, guest)
+	assertHexID(t, fields, "claimed_by", guest.ClaimedBy)
+
+	var decoded Guest
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		t.Fatalf("unmarshal %s: %v", raw, err)
+	}
+	if decoded.ID != guest.ID || decoded.ClaimedBy != guest.ClaimedBy {
+		t.Fatalf("round trip changed the ids: %+v", decoded)
+	}
+}
+
+func TestNotificationJSONIDs(t *testing.T) {
+	notification := Notification{ID: bson.NewObjectID(), UserID: bson.NewObjectID(), Type: NotificationNewEvent}
+
+	_, fields := marshalFields(t, notification)
+	assertHexID(t, fields, "id", notification.ID)
+	assertHexID(t, fields, "user_id", notification.UserID)
+	assertOmitted(t, fields, "event_id")
+
+	notification.EventID = bson.NewObjectID()
+	raw, fields := marshalFields(t, notification)
+	assertHexID(t, fields, "event_id", notification.EventID)
+
+	var decoded Notification
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		t.Fatalf("unmarshal %s: %v", raw, err)
+	}
+	if decoded.ID != notification.ID || decoded.UserID != notification.UserID || decoded.EventID != notification.EventID {
+		t.Fatalf("round trip changed the ids: %+v", decoded)
+	}
+}
@@ -0,0 +1,55 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// Roles of organization members
+const (
+	OrgRoleOwner   = "owner"   //? The host the org's events belong to, manages the members
+	OrgRoleManager = "manager" //? Creates and manages the org's events like a co-host
+	OrgRoleScanner = "scanner" //? Door staff, only checks attendees in
+)
+
+// Organization is a team of users running events together, its events belong to the owner's host account
+type Organization struct {
+	ID        bson.ObjectID `bson:"_id,omitempty" json:"id"`
+	Name      string        `bson:"name" json:"name"`
+	OwnerID   bson.ObjectID `bson:"owner_id" json:"owner_id"` //? AUTO, the host who created it
+	Members   []OrgMember   `bson:"members" json:"members"`   //? The owner included
+	CreatedAt time.Time     `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time     `bson:"updated_at" json:"updated_at"`
+}
+
+// OrgMember is a user of an organization with their role
+type OrgMember struct {
+	UserID  bson.ObjectID `bson:"user_id" json:"user_id"`
+	Role    string        `bson:"role" json:"role"`
+	AddedAt time.Time     `bson:"added_at" json:"added_at"`
+}
+
+// RoleOf returns the user's role in the organization, empty when they are not a member
+func (o *Organization) RoleOf(userID bson.ObjectID) string {
+	for _, member := range o.Members {
+		if member.UserID == userID {
+			return member.Role
+		}
+	}
+	return ""
+}
+
+// Staff returns the members that manage the org's events (owner and managers) and the scanners
+func (o *Organization) Staff() (managers, scanners []bson.ObjectID) {
+	managers, scanners = []bson.ObjectID{}, []bson.ObjectID{}
+	for _, member := range o.Members {
+		switch member.Role {
+		case OrgRoleOwner, OrgRoleManager:
+			managers = append(managers, member.UserID)
+		case OrgRoleScanner:
+			scanners = append(scanners, member.UserID)
+		}
+	}
+	return managers, scanners
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// Outbox message types, what happened to the booking
+const (
+	OutboxBookingCreated   = "booking.created"
+	OutboxBookingCancelled = "booking.cancelled"
+)
+
+// Outbox message statuses
+const (
+	OutboxPending    = "pending"
+	OutboxDelivering = "delivering"
+	OutboxDelivered  = "delivered"
+)
+
+// OutboxMessage is written in the same transaction as the booking change it announces, the outbox relay
+// hands it to the booking hooks once the transaction committed
+type OutboxMessage struct {
+	ID            bson.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	Type          string        `bson:"type" json:"type"`
+	Booking       Booking       `bson:"booking" json:"booking"` //? As it was in the transaction
+	Status        string        `bson:"status" json:"status"`
+	Attempts      int           `bson:"attempts" json:"attempts"`
+	NextAttemptAt time.Time     `bson:"next_attempt_at" json:"next_attempt_at"`
+	LockedUntil   *time.Time    `bson:"locked_until,omitempty" json:"locked_until,omitempty"`
+	LastError     string        `bson:"last_error,omitempty" json:"last_error,omitempty"`
+	CreatedAt     time.Time     `bson:"created_at" json:"created_at"`
+	DeliveredAt   *time.Time    `bson:"delivered_at,omitempty" json:"delivered_at,omitempty"`
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// Report reasons
+const (
+	ReportReasonSpam          = "spam"
+	ReportReasonScam          = "scam"
+	ReportReasonInappropriate = "inappropriate"
+	ReportReasonOther         = "other"
+)
+
+// Report is a user flagging an event, a user can report an event once
+type Report struct {
+	ID         bson.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	EventID    bson.ObjectID `bson:"event_id" json:"event_id"`
+	ReporterID bson.ObjectID `bson:"reporter_id" json:"reporter_id"` //? AUTO
+	Reason     string        `bson:"reason" json:"reason" validate:"required,oneof=spam scam inappropriate other"`
+	Details    string        `bson:"details,omitempty" json:"details,omitempty"`
+	CreatedAt  time.Time     `bson:"created_at" json:"created_at"`
+}
+
+// ReportSummary is the report count of one event for the admin view
+type ReportSummary struct {
+	EventID        bson.ObjectID  `bson:"_id" json:"event_id"`
+	Count          int            `bson:"count" json:"count"`
+	Reasons        map[string]int `bson:"-" json:"reasons"`
+	ReasonList     []string       `bson:"reasons" json:"-"`
+	LastReportedAt time.Time      `bson:"last_reported_at" json:"last_reported_at"`
+	Event          *Event         `bson:"event,omitempty" json:"event,omitempty"`
+}
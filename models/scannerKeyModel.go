@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// ScannerKeyPrefix starts every scanner key, so a leaked one is easy to recognize
+const ScannerKeyPrefix = "ehs_"
+
+// ScannerKey lets a check-in device of door staff scan one event without a user account behind it
+type ScannerKey struct {
+	ID         bson.ObjectID `bson:"_id,omitempty" json:"id"`
+	EventID    bson.ObjectID `bson:"event_id" json:"event_id"`
+	Name       string        `bson:"name" json:"name"`             //? Which device or door, e.g. "Door 2"
+	Hint       string        `bson:"hint" json:"hint"`             //? AUTO, the first characters of the key, to tell keys apart
+	Hash       string        `bson:"hash" json:"-"`                //! SHA-256 of the key, the key itself is only shown once
+	CreatedBy  bson.ObjectID `bson:"created_by" json:"created_by"` //? The host or co-host who handed it out
+	CreatedAt  time.Time     `bson:"created_at" json:"created_at"`
+	ExpiresAt  time.Time     `bson:"expires_at" json:"expires_at"` //? AUTO, a day after the event ends
+	LastUsedAt *time.Time    `bson:"last_used_at,omitempty" json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time    `bson:"revoked_at,omitempty" json:"revoked_at,omitempty"`
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// What started a scheduler run
+const (
+	TriggerSchedule = "schedule"
+	TriggerManual   = "manual" //? An admin ran it from the dashboard
+)
+
+// Scheduler is the latest state of one background scheduler (expired event cleanup, scheduled publishing ...),
+// every instance of the API updates it after each run
+type Scheduler struct {
+	ID             bson.ObjectID `bson:"_id,omitempty" json:"id"`
+	Name           string        `bson:"name" json:"name"` //? e.g. "cleanup", POST /admin/jobs/:name/run
+	Description    string        `bson:"description" json:"description"`
+	Interval       string        `bson:"interval" json:"interval"`
+	CountLabel     string        `bson:"count_label" json:"count_label"` //? What last_count counts, e.g. "events deleted"
+	LastTrigger    string        `bson:"last_trigger" json:"last_trigger"`
+	LastStartedAt  time.Time     `bson:"last_started_at" json:"last_started_at"`
+	LastFinishedAt time.Time     `bson:"last_finished_at" json:"last_finished_at"`
+	LastDurationMs int64         `bson:"last_duration_ms" json:"last_duration_ms"`
+	LastCount      int64         `bson:"last_count" json:"last_count"`
+	LastRunOK      bool          `bson:"last_run_ok" json:"last_run_ok"`
+	LastError      string        `bson:"last_error,omitempty" json:"last_error,omitempty"` //? Of the latest failed run, kept after later runs succeed
+	LastErrorAt    *time.Time    `bson:"last_error_at,omitempty" json:"last_error_at,omitempty"`
+	LastInstance   string        `bson:"last_instance" json:"last_instance"` //? Host name of the API instance that ran it
+	Runs           int64         `bson:"runs" json:"runs"`
+	Failures       int64         `bson:"failures" json:"failures"`
+	TotalCount     int64         `bson:"total_count" json:"total_count"`
+}
+
+// SchedulerRun is the outcome of one run, recorded on its Scheduler
+type SchedulerRun struct {
+	Name        string
+	Description string
+	Interval    time.Duration
+	CountLabel  string
+	Trigger     string
+	Instance    string
+	StartedAt   time.Time
+	FinishedAt  time.Time
+	Count       int64
+	Err         error
+}
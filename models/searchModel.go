@@ -0,0 +1,72 @@
+package models
+
+import "time"
+
+// EventSearchQuery is a full-text search over the public events
+type EventSearchQuery struct {
+	Text     string
+	Category string    //? Exact category name
+	From     time.Time //? Events starting at or after, zero leaves it open
+	To       time.Time //? Events starting before, zero leaves it open
+	MinPrice *float64  //? On the cheapest ticket, in the event's currency
+	MaxPrice *float64
+	Limit    int
+	Offset   int
+}
+
+// EventSearchHit is one event found by a search
+type EventSearchHit struct {
+	ID           string    `bson:"_id" json:"id"`
+	Name         string    `bson:"name" json:"name"`
+	CategoryName string    `bson:"category_name" json:"category_name"`
+	Tags         []string  `bson:"tags" json:"tags,omitempty"`
+	Location     string    `bson:"location" json:"location"`
+	EventType    string    `bson:"event_type" json:"event_type,omitempty"`
+	ImageURL     string    `bson:"image_url" json:"image_url"`
+	StartTime    time.Time `bson:"start_time" json:"start_time"`
+	EndTime      time.Time `bson:"end_time" json:"end_time"`
+	Timezone     string    `bson:"timezone" json:"timezone,omitempty"`
+	MinPrice     float64   `bson:"min_price" json:"min_price"`
+	Currency     string    `bson:"currency" json:"currency"`
+}
+
+// EventSearchFacets counts the matching events per category, start month (YYYY-MM, UTC) and price range
+type EventSearchFacets struct {
+	Categories  map[string]int `json:"category_name"`
+	Months      map[string]int `json:"month"`
+	PriceRanges map[string]int `json:"price_range"`
+}
+
+// EventSearchResult is a page of search hits with the facets of every match
+type EventSearchResult struct {
+	Hits   []EventSearchHit  `json:"hits"`
+	Total  int64             `json:"total"`
+	Facets EventSearchFacets `json:"facets"`
+	Engine string            `json:"engine"` //? Which search engine answered
+}
+
+// PriceRange is one bucket of the price facet, prices below Below (and above the previous bucket) fall in it
+type PriceRange struct {
+	Label string
+	Below float64
+}
+
+// PriceRanges are the buckets of the price facet, the last one has no upper end.
+// Prices of all currencies share them, so they are rough for currencies far from USD.
+var PriceRanges = []PriceRange{
+	{Label: "free", Below: 0.01},
+	{Label: "under_25", Below: 25},
+	{Label: "25_to_50", Below: 50},
+	{Label: "50_to_100", Below: 100},
+	{Label: "100_plus"},
+}
+
+// PriceRangeOf returns the price facet bucket of a price
+func PriceRangeOf(price float64) string {
+	for _, bucket := range PriceRanges[:len(PriceRanges)-1] {
+		if price < bucket.Below {
+			return bucket.Label
+		}
+	}
+	return PriceRanges[len(PriceRanges)-1].Label
+}
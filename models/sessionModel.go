@@ -0,0 +1,19 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// UserSession is one signed-in device, referenced by the jti claim of its JWT
+type UserSession struct {
+	ID         bson.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	UserID     bson.ObjectID `bson:"user_id" json:"user_id"`
+	UserAgent  string        `bson:"user_agent" json:"user_agent"`
+	IP         string        `bson:"ip" json:"ip"`
+	RememberMe bool          `bson:"remember_me" json:"remember_me"` //? Long lived (TOKEN_TTL) instead of SESSION_TTL
+	Revoked    bool          `bson:"revoked" json:"revoked"`
+	CreatedAt  time.Time     `bson:"created_at" json:"created_at"`
+	ExpiresAt  time.Time     `bson:"expires_at" json:"expires_at"`
+}
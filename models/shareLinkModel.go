@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// ShareLink is a short link to an event for one channel, bookings made through it are attributed to it
+type ShareLink struct {
+	ID          bson.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	Code        string        `bson:"code" json:"code"` //? AUTO, unique
+	EventID     bson.ObjectID `bson:"event_id" json:"event_id"`
+	CreatedBy   bson.ObjectID `bson:"created_by" json:"created_by"` //? AUTO, the host or co-host who shares it
+	Channel     string        `bson:"channel" json:"channel"`       //? Where the link is posted: instagram, newsletter ...
+	UTMSource   string        `bson:"utm_source,omitempty" json:"utm_source,omitempty"`
+	UTMMedium   string        `bson:"utm_medium,omitempty" json:"utm_medium,omitempty"`
+	UTMCampaign string        `bson:"utm_campaign,omitempty" json:"utm_campaign,omitempty"`
+	Clicks      int           `bson:"clicks" json:"clicks"`
+	CreatedAt   time.Time     `bson:"created_at" json:"created_at"`
+}
+
+// ReferralStats counts the confirmed bookings that came from one source
+type ReferralStats struct {
+	Bookings     int    `json:"bookings"`
+	Tickets      int    `json:"tickets"`
+	RevenueMinor int64  `json:"revenue_minor"`
+	Revenue      string `json:"revenue"` //? Formatted, "25.00 USD"
+}
+
+// Add counts a booking
+func (s *ReferralStats) Add(booking *Booking) {
+	s.Bookings++
+	s.Tickets += booking.Quantity
+	s.RevenueMinor += booking.TotalPaidMinor
+}
+
+// ShareLinkStats is a share link with the bookings it brought in
+type ShareLinkStats struct {
+	ShareLink
+	URL string `json:"url"`
+	ReferralStats
+	ConversionRate float64 `json:"conversion_rate"` //? Bookings per click, 0 without clicks
+}
+
+// ReferralReport shows the host which share links sell tickets
+type ReferralReport struct {
+	EventID  bson.ObjectID    `json:"event_id"`
+	Currency string           `json:"currency"`
+	Links    []ShareLinkStats `json:"links"`  //? Most bookings first
+	Direct   ReferralStats    `json:"direct"` //? Bookings without a share link of the event
+	Total    ReferralStats    `json:"total"`
+}
@@ -0,0 +1,62 @@
+package models
+
+// Availability buckets, shown instead of available_quantity when the host hides ticket counts
+const (
+	AvailabilityAvailable = "available"
+	AvailabilityLow       = "low"
+	AvailabilitySoldOut   = "sold_out"
+)
+
+// Availability returns the bucket of available of total tickets, low from the selling fast threshold on
+func Availability(available, total int) string {
+	switch {
+	case available <= 0:
+		return AvailabilitySoldOut
+	case StockFlag(available, total) != "":
+		return AvailabilityLow
+	}
+	return AvailabilityAvailable
+}
+
+// Stock flags show urgency on events and ticket types without telling how many tickets are left
+const (
+	StockSellingFast   = "selling_fast"
+	StockAlmostSoldOut = "almost_sold_out"
+)
+
+// stockThresholds are the percentages of tickets left below which the flags are shown
+var stockThresholds = struct {
+	sellingFast   int
+	almostSoldOut int
+}{sellingFast: 25, almostSoldOut: 10}
+
+// ConfigureStockFlags sets below which percentage of tickets left an event is selling fast / almost sold out, 0 turns a flag off
+func ConfigureStockFlags(sellingFastPercent, almostSoldOutPercent int) {
+	stockThresholds.sellingFast = sellingFastPercent
+	stockThresholds.almostSoldOut = almostSoldOutPercent
+}
+
+// StockFlag returns the flag for available of total tickets, empty when plenty are left or none are
+func StockFlag(available, total int) string {
+	if total <= 0 || available <= 0 {
+		return ""
+	}
+	//? Compared in whole tickets, 100 * available < percent * total, so small events round the safe way
+	switch {
+	case available*100 < stockThresholds.almostSoldOut*total:
+		return StockAlmostSoldOut
+	case available*100 < stockThresholds.sellingFast*total:
+		return StockSellingFast
+	}
+	return ""
+}
+
+// EventStockFlag returns the flag of the whole event, over every ticket type
+func EventStockFlag(tickets []TicketInfo) string {
+	available, total := 0, 0
+	for _, ticket := range tickets {
+		available += ticket.AvailableQuantity
+		total += ticket.TotalQuantity
+	}
+	return StockFlag(available, total)
+}
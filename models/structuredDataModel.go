@@ -0,0 +1,65 @@
+package models
+
+// schema.org vocabulary the structured data (JSON-LD) of an event page uses
+const (
+	SchemaContext           = "https://schema.org"
+	SchemaEventScheduled    = "https://schema.org/EventScheduled"
+	SchemaOfflineAttendance = "https://schema.org/OfflineEventAttendanceMode"
+	SchemaOnlineAttendance  = "https://schema.org/OnlineEventAttendanceMode"
+	SchemaMixedAttendance   = "https://schema.org/MixedEventAttendanceMode"
+	SchemaInStock           = "https://schema.org/InStock"
+	SchemaSoldOut           = "https://schema.org/SoldOut"
+	SchemaPreSale           = "https://schema.org/PreSale"
+	SchemaDiscontinued      = "https://schema.org/Discontinued"
+)
+
+// StructuredEvent is a schema.org Event, search engines read it from the event page
+type StructuredEvent struct {
+	Context             string               `json:"@context"`
+	Type                string               `json:"@type"`
+	Name                string               `json:"name"`
+	Description         string               `json:"description,omitempty"`
+	URL                 string               `json:"url"`
+	StartDate           string               `json:"startDate"` //? ISO 8601 with the event's UTC offset
+	EndDate             string               `json:"endDate"`
+	EventStatus         string               `json:"eventStatus"`
+	EventAttendanceMode string               `json:"eventAttendanceMode"`
+	Location            []StructuredLocation `json:"location"` //? Hybrid events have a Place and a VirtualLocation
+	Image               []string             `json:"image,omitempty"`
+	Offers              []StructuredOffer    `json:"offers,omitempty"`
+	Organizer           *StructuredOrganizer `json:"organizer,omitempty"`
+}
+
+// StructuredLocation is a schema.org Place or VirtualLocation
+type StructuredLocation struct {
+	Type    string             `json:"@type"`
+	Name    string             `json:"name,omitempty"`
+	Address string             `json:"address,omitempty"`
+	URL     string             `json:"url,omitempty"` //? VirtualLocation only, the event page (the stream link stays private)
+	Geo     *StructuredGeoData `json:"geo,omitempty"`
+}
+
+// StructuredGeoData is a schema.org GeoCoordinates
+type StructuredGeoData struct {
+	Type      string  `json:"@type"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// StructuredOffer is a schema.org Offer, one per ticket type
+type StructuredOffer struct {
+	Type          string `json:"@type"`
+	Name          string `json:"name"`
+	Price         string `json:"price"` //? Plain decimal ("12.50")
+	PriceCurrency string `json:"priceCurrency"`
+	Availability  string `json:"availability"`
+	URL           string `json:"url"`
+	ValidFrom     string `json:"validFrom,omitempty"`
+	ValidThrough  string `json:"validThrough,omitempty"`
+}
+
+// StructuredOrganizer is the host as a schema.org Person
+type StructuredOrganizer struct {
+	Type string `json:"@type"`
+	Name string `json:"name"`
+}
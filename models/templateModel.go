@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// EventTemplate is one of a host's events saved to create new events from
+type EventTemplate struct {
+	ID        bson.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	HostID    bson.ObjectID `bson:"host_id" json:"host_id"`
+	Name      string        `bson:"name" json:"name"`                               //? Unique per host, e.g. "Friday jazz night"
+	SourceID  bson.ObjectID `bson:"source_id,omitempty" json:"source_id,omitempty"` //? The event it was saved from
+	Event     Event         `bson:"event" json:"event"`                             //? Tickets, description, images and settings, its dates only place the sessions and sale windows
+	CreatedAt time.Time     `bson:"created_at" json:"created_at"`
+}
@@ -12,7 +12,59 @@ type User struct {
 	Email     string        `bson:"email" json:"email" validate:"required,email"`
 	Password  string        `bson:"password" json:"password,omitempty" validate:"required,min=6"`
 	IsHost    bool          `bson:"is_host" json:"is_host"`
+	IsAdmin   bool          `bson:"is_admin" json:"is_admin"`
 	CreatedAt time.Time     `bson:"created_at" json:"created_at"`
+
+	SuspendedAt   *time.Time `bson:"suspended_at,omitempty" json:"suspended_at,omitempty"`     //? Set by an admin, a suspended host can't run events
+	SuspendReason string     `bson:"suspend_reason,omitempty" json:"suspend_reason,omitempty"` //? Why the admin suspended the host
+	VerifiedAt    *time.Time `bson:"verified_at,omitempty" json:"verified_at,omitempty"`       //? An admin approved the host's KYC, they may sell paid tickets
+
+	Language string `bson:"language,omitempty" json:"language,omitempty"` //? Preferred language of emails ("en", "es" ...), empty is English
+
+	Phone             string             `bson:"phone,omitempty" json:"phone,omitempty"` //? E.164, only set once verified, booking SMS go here
+	PhoneVerifiedAt   *time.Time         `bson:"phone_verified_at,omitempty" json:"phone_verified_at,omitempty"`
+	PhoneVerification *PhoneVerification `bson:"phone_verification,omitempty" json:"-"` //! Pending number, the code itself is only sent by SMS
+
+	Preferences UserPreferences `bson:"preferences,omitempty" json:"preferences"` //? Which notifications the user gets on which channel
+
+	DeletedAt *time.Time `bson:"deleted_at,omitempty" json:"deleted_at,omitempty"` //? The user deleted their account, the personal data is gone
+}
+
+// DeletedUserName replaces the name of deleted users and of the attendees of their bookings
+const DeletedUserName = "Deleted user"
+
+// IsDeleted reports whether the user deleted their account
+func (u *User) IsDeleted() bool {
+	return u.DeletedAt != nil
+}
+
+// Allows reports whether the user wants the notification type on the channel
+func (u *User) Allows(notificationType, channel string) bool {
+	return u.Preferences.Notifications.Allows(notificationType, channel)
+}
+
+// PhoneVerification is a phone number waiting for the user to type in the code sent to it
+type PhoneVerification struct {
+	Phone     string    `bson:"phone"`
+	CodeHash  string    `bson:"code_hash"` //? SHA-256 of the code
+	SentAt    time.Time `bson:"sent_at"`
+	ExpiresAt time.Time `bson:"expires_at"`
+	Attempts  int       `bson:"attempts"` //? Wrong codes typed in so far
+}
+
+// CanReceiveSMS reports whether the user has a verified phone number
+func (u *User) CanReceiveSMS() bool {
+	return u.Phone != "" && u.PhoneVerifiedAt != nil
+}
+
+// IsVerified reports whether the user is a verified host
+func (u *User) IsVerified() bool {
+	return u.VerifiedAt != nil
+}
+
+// IsSuspended reports whether an admin suspended the user
+func (u *User) IsSuspended() bool {
+	return u.SuspendedAt != nil
 }
 
 // UserPublic is the user data returned in API responses (without password)
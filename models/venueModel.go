@@ -0,0 +1,38 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// Venue is a place events are held at, any host can hold events there
+type Venue struct {
+	ID          bson.ObjectID `bson:"_id,omitempty" json:"id,omitempty"`
+	CreatedBy   bson.ObjectID `bson:"created_by" json:"created_by"` //? AUTO, the host who added it, only they (or an admin) can change it
+	Name        string        `bson:"name" json:"name"`
+	Address     string        `bson:"address" json:"address"`
+	City        string        `bson:"city,omitempty" json:"city,omitempty"`
+	GeoLocation *GeoPoint     `bson:"geo_location,omitempty" json:"geo_location,omitempty"`
+	Capacity    int           `bson:"capacity,omitempty" json:"capacity,omitempty"`   //? How many people fit, 0 when unknown
+	Amenities   []string      `bson:"amenities,omitempty" json:"amenities,omitempty"` //? Lowercase, e.g. parking, wheelchair_access
+	CreatedAt   time.Time     `bson:"created_at" json:"created_at"`
+	UpdatedAt   time.Time     `bson:"updated_at,omitempty" json:"updated_at,omitempty"`
+}
+
+// Location is the location events at the venue show, e.g. "Blue Hall, 12 Main Street, Dhaka"
+func (v *Venue) Location() string {
+	parts := []string{v.Name, v.Address}
+	if v.City != "" {
+		parts = append(parts, v.City)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// VenueQuery filters the venue list
+type VenueQuery struct {
+	Name  string //? Part of the name, any case
+	City  string
+	Limit int
+}
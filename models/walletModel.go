@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// WalletTicket is one upcoming confirmed booking as the mobile ticket wallet shows it, everything the screen needs in one item
+type WalletTicket struct {
+	BookingID           bson.ObjectID  `bson:"_id" json:"booking_id"`
+	TicketType          string         `bson:"ticket_type" json:"ticket_type"`
+	Quantity            int            `bson:"quantity" json:"quantity"`
+	SessionID           *bson.ObjectID `bson:"session_id,omitempty" json:"session_id,omitempty"`
+	SessionTitle        string         `bson:"-" json:"session_title,omitempty"`
+	NeedsReconfirmation bool           `bson:"needs_reconfirmation,omitempty" json:"needs_reconfirmation,omitempty"`
+	ReconfirmBy         *time.Time     `bson:"reconfirm_by,omitempty" json:"reconfirm_by,omitempty"`
+	Event               WalletEvent    `bson:"event" json:"event"`
+	Passes              []WalletPass   `bson:"attendees" json:"passes"`
+}
+
+// WalletEvent is the snapshot of the booked event in the wallet, for a session booking its times are the session's
+type WalletEvent struct {
+	ID          bson.ObjectID  `bson:"_id" json:"id"`
+	Name        string         `bson:"name" json:"name"`
+	StartTime   time.Time      `bson:"start_time" json:"start_time"`
+	EndTime     time.Time      `bson:"end_time" json:"end_time"`
+	Timezone    string         `bson:"timezone,omitempty" json:"timezone,omitempty"`
+	Location    string         `bson:"location" json:"location"`
+	GeoLocation *GeoPoint      `bson:"geo_location,omitempty" json:"geo_location,omitempty"` //? The venue's when the event is held at one
+	VenueID     *bson.ObjectID `bson:"venue_id,omitempty" json:"venue_id,omitempty"`
+	EventType   string         `bson:"event_type,omitempty" json:"event_type,omitempty"`
+	ImageURL    string         `bson:"image_url,omitempty" json:"image_url,omitempty"`
+	Sessions    []Session      `bson:"sessions,omitempty" json:"-"` //? Only read to find the booked session
+}
+
+// WalletPass is one ticket of a wallet booking, the door scans its QR code
+type WalletPass struct {
+	Name        string     `bson:"name" json:"name"`
+	QRPayload   string     `bson:"check_in_code" json:"qr_payload"` //? The check-in code, encoded into the QR code as is
+	CheckedInAt *time.Time `bson:"checked_in_at,omitempty" json:"checked_in_at,omitempty"`
+}
@@ -0,0 +1,87 @@
+package realtime
+
+import (
+	"event-horizon/models"
+	"sync"
+)
+
+/** *********************  REALTIME HUB   ********************
+
+The hub keeps track of every client listening to an event (over Server-Sent
+Events) and pushes ticket-availability changes and event updates to them as
+soon as they happen, so clients don't have to keep polling GetEventByID.
+
+1. Subscribe   - register a listener channel for one event
+2. Publish     - push an update to every listener of that event
+3. Unsubscribe - returned by Subscribe, removes the listener again
+
+Door counts (check-ins) go through a hub of their own, so only the hosts
+watching GET /events/:id/checkins/stream get them, not the public stream.
+
+ **************************************/
+
+// Update types pushed to listeners
+const (
+	UpdateTicketsChanged = "tickets_changed"
+	UpdateEventUpdated   = "event_updated"
+	UpdateEventDeleted   = "event_deleted"
+	UpdateCheckIns       = "checkins_changed"
+)
+
+// Update is a single realtime message for an event
+type Update struct {
+	Type     string               `json:"type"`
+	EventID  string               `json:"event_id"`
+	Tickets  []models.TicketInfo  `json:"tickets,omitempty"`
+	CheckIns *models.CheckInStats `json:"check_ins,omitempty"`
+}
+
+// Hub fans out event updates to all subscribed listeners
+type Hub struct {
+	mu          sync.RWMutex
+	subscribers map[string]map[chan Update]struct{}
+}
+
+// NewHub creates a new empty Hub
+func NewHub() *Hub {
+	return &Hub{
+		subscribers: make(map[string]map[chan Update]struct{}),
+	}
+}
+
+// Subscribe registers a listener for an event and returns its channel and an unsubscribe func
+func (h *Hub) Subscribe(eventID string) (chan Update, func()) {
+	ch := make(chan Update, 10)
+
+	h.mu.Lock()
+	if h.subscribers[eventID] == nil {
+		h.subscribers[eventID] = make(map[chan Update]struct{})
+	}
+	h.subscribers[eventID][ch] = struct{}{}
+	h.mu.Unlock()
+
+	unsubscribe := func() {
+		h.mu.Lock()
+		delete(h.subscribers[eventID], ch)
+		if len(h.subscribers[eventID]) == 0 {
+			delete(h.subscribers, eventID)
+		}
+		h.mu.Unlock()
+	}
+
+	return ch, unsubscribe
+}
+
+// Publish sends an update to every listener of the event
+func (h *Hub) Publish(update Update) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for ch := range h.subscribers[update.EventID] {
+		select {
+		case ch <- update:
+		default:
+			//! Slow listener, skip instead of blocking the publisher
+		}
+	}
+}
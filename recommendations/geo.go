@@ -0,0 +1,26 @@
+package recommendations
+
+import "math"
+
+// earthRadiusKm is the mean radius of the earth
+const earthRadiusKm = 6371.0
+
+// DistanceKm is the great-circle (haversine) distance between two points
+func DistanceKm(a, b Location) float64 {
+	lat1, lat2 := a.Lat*math.Pi/180, b.Lat*math.Pi/180
+	dLat := lat2 - lat1
+	dLng := (b.Lng - a.Lng) * math.Pi / 180
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// centroid averages points, good enough for places a user went to in one region
+func centroid(points []Location) *Location {
+	var sum Location
+	for _, point := range points {
+		sum.Lat += point.Lat
+		sum.Lng += point.Lng
+	}
+	return &Location{Lat: sum.Lat / float64(len(points)), Lng: sum.Lng / float64(len(points))}
+}
@@ -0,0 +1,86 @@
+package recommendations
+
+import (
+	"event-horizon/models"
+	"math"
+	"sort"
+	"time"
+)
+
+// ReasonSameHost marks a related event by the same host
+const ReasonSameHost = "same_host"
+
+// Related weights, same host or category is required, the rest only orders the rail
+const (
+	relatedHostWeight     = 2.0
+	relatedCategoryWeight = 2.0
+)
+
+// Related returns the upcoming events in the same category or by the same host as the source event, best first.
+// Sold-out and started events are left out, closer and sooner events rank higher.
+func Related(source *models.Event, candidates []*models.Event, now time.Time, limit int) []Recommendation {
+	sourceLocation := eventLocation(source)
+	sourceTags := make(map[string]bool, len(source.Tags))
+	for _, tag := range source.Tags {
+		sourceTags[tag] = true
+	}
+
+	related := []Recommendation{}
+	for _, event := range candidates {
+		if event.ID == source.ID || !event.StartTime.After(now) || soldOut(event) {
+			continue
+		}
+
+		score := 0.0
+		reasons := []string{}
+		if event.HostID == source.HostID {
+			score += relatedHostWeight
+			reasons = append(reasons, ReasonSameHost)
+		}
+		if event.CategoryName == source.CategoryName {
+			score += relatedCategoryWeight
+			reasons = append(reasons, ReasonCategory)
+		}
+		if len(reasons) == 0 {
+			continue
+		}
+
+		shared := 0
+		for _, tag := range event.Tags {
+			if sourceTags[tag] {
+				shared++
+			}
+		}
+		if shared > 0 {
+			score += tagWeight * float64(shared) / float64(len(sourceTags))
+			reasons = append(reasons, ReasonTags)
+		}
+
+		if sourceLocation != nil {
+			if location := eventLocation(event); location != nil {
+				if distance := DistanceKm(*sourceLocation, *location); distance < nearbyRadiusKm {
+					score += nearbyWeight * (1 - distance/nearbyRadiusKm)
+					reasons = append(reasons, ReasonNearby)
+				}
+			}
+		}
+
+		if until := event.StartTime.Sub(now); until < soonWindow {
+			score += soonWeight * (1 - float64(until)/float64(soonWindow))
+		}
+
+		related = append(related, Recommendation{Event: event, Score: math.Round(score*1000) / 1000, Reasons: reasons})
+	}
+
+	sort.SliceStable(related, func(i, j int) bool {
+		if related[i].Score != related[j].Score {
+			return related[i].Score > related[j].Score
+		}
+		return related[i].Event.StartTime.Before(related[j].Event.StartTime)
+	})
+
+	if len(related) > limit {
+		related = related[:limit]
+	}
+	return related
+}
@@ -0,0 +1,226 @@
+package recommendations
+
+import (
+	"event-horizon/models"
+	"math"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+/** *********************  EVENTS YOU MAY LIKE   ********************
+
+GET /events/recommendations scores every upcoming public event for one user.
+The scorer only works on data handed to it, the service loads it:
+
+1. CATEGORIES  - the categories the user books most (their favorites), weighted by share of bookings
+2. TAGS        - tags of the events the user booked before
+3. HOSTS       - hosts the user follows or booked before
+4. CO-BOOKINGS - how many people who booked the same events as the user also booked this one
+5. LOCATION    - in-person events close to the user (given, or where they went before)
+6. SOON        - a small bonus for events in the next weeks, so ties go to what's coming up
+
+A user without bookings gets the soonest events near them, every score keeps
+the reasons it is made of so the app can say why an event was picked.
+
+GET /events/:id/related uses Related instead: events of the same host or in
+the same category, ordered by shared tags, distance and how soon they start.
+
+ **************************************/
+
+// Score weights, a perfect match on one signal is worth its weight
+const (
+	categoryWeight = 3.0
+	tagWeight      = 1.0
+	followedWeight = 2.0
+	bookedHostBump = 1.0
+	coBookedWeight = 2.0
+	nearbyWeight   = 2.0
+	soonWeight     = 0.5
+
+	nearbyRadiusKm = 50.0 //? Events further away get no location points
+	soonWindow     = 90 * 24 * time.Hour
+)
+
+// Reasons a recommendation can have
+const (
+	ReasonCategory   = "category"
+	ReasonTags       = "tags"
+	ReasonFollowed   = "followed_host"
+	ReasonBookedHost = "booked_host"
+	ReasonCoBooked   = "booked_by_similar_attendees"
+	ReasonNearby     = "nearby"
+)
+
+// Location is a point on the map
+type Location struct {
+	Lat float64
+	Lng float64
+}
+
+// Profile is what is known about a user's taste
+type Profile struct {
+	Categories    map[string]float64     //? Share of the user's bookings per category, adds up to 1
+	Tags          map[string]float64     //? Share of the user's booked events with the tag
+	FollowedHosts map[bson.ObjectID]bool //? Hosts the user follows
+	BookedHosts   map[bson.ObjectID]bool //? Hosts of events the user booked
+	CoBooked      map[bson.ObjectID]int  //? Event -> how many similar attendees booked it
+	Booked        map[bson.ObjectID]bool //? Events the user already booked, never recommended
+	Location      *Location
+}
+
+// Recommendation is an event with its score and why it got it
+type Recommendation struct {
+	Event   *models.Event `json:"event"`
+	Score   float64       `json:"score"`
+	Reasons []string      `json:"reasons"`
+}
+
+// BuildProfile builds a profile from the user's confirmed bookings (booked maps their event IDs to the events that still exist)
+func BuildProfile(bookings []models.Booking, booked map[bson.ObjectID]*models.Event, followedHosts []bson.ObjectID) *Profile {
+	profile := &Profile{
+		Categories:    make(map[string]float64),
+		Tags:          make(map[string]float64),
+		FollowedHosts: make(map[bson.ObjectID]bool, len(followedHosts)),
+		BookedHosts:   make(map[bson.ObjectID]bool),
+		CoBooked:      make(map[bson.ObjectID]int),
+		Booked:        make(map[bson.ObjectID]bool),
+	}
+	for _, hostID := range followedHosts {
+		profile.FollowedHosts[hostID] = true
+	}
+
+	var points []Location
+	for _, booking := range bookings {
+		if booking.Status != "confirmed" || profile.Booked[booking.EventID] {
+			continue
+		}
+		profile.Booked[booking.EventID] = true
+
+		event := booked[booking.EventID]
+		if event == nil {
+			continue //? Deleted since, it still can't be recommended
+		}
+		profile.Categories[event.CategoryName]++
+		for _, tag := range event.Tags {
+			profile.Tags[tag]++
+		}
+		profile.BookedHosts[event.HostID] = true
+		if location := eventLocation(event); location != nil {
+			points = append(points, *location)
+		}
+	}
+
+	normalize(profile.Categories, float64(len(profile.Booked)))
+	normalize(profile.Tags, float64(len(profile.Booked)))
+
+	//? Without a location from the app, the user is probably where they went before
+	if len(points) > 0 {
+		profile.Location = centroid(points)
+	}
+
+	return profile
+}
+
+// normalize divides every weight by total
+func normalize(weights map[string]float64, total float64) {
+	if total == 0 {
+		return
+	}
+	for key := range weights {
+		weights[key] /= total
+	}
+}
+
+// Score rates one event for the profile, 0 with no reasons means nothing matched
+func Score(profile *Profile, event *models.Event, now time.Time) (float64, []string) {
+	score := 0.0
+	reasons := []string{}
+
+	if share := profile.Categories[event.CategoryName]; share > 0 {
+		score += categoryWeight * share
+		reasons = append(reasons, ReasonCategory)
+	}
+
+	tagScore := 0.0
+	for _, tag := range event.Tags {
+		tagScore += profile.Tags[tag]
+	}
+	if tagScore > 0 {
+		score += tagWeight * math.Min(tagScore, 1)
+		reasons = append(reasons, ReasonTags)
+	}
+
+	if profile.FollowedHosts[event.HostID] {
+		score += followedWeight
+		reasons = append(reasons, ReasonFollowed)
+	} else if profile.BookedHosts[event.HostID] {
+		score += bookedHostBump
+		reasons = append(reasons, ReasonBookedHost)
+	}
+
+	if count := profile.CoBooked[event.ID]; count > 0 {
+		//? Diminishing returns, 1 similar attendee counts, 10 count a lot more but not 10 times as much
+		score += coBookedWeight * math.Min(math.Log2(float64(count)+1)/math.Log2(11), 1)
+		reasons = append(reasons, ReasonCoBooked)
+	}
+
+	if profile.Location != nil {
+		if location := eventLocation(event); location != nil {
+			if distance := DistanceKm(*profile.Location, *location); distance < nearbyRadiusKm {
+				score += nearbyWeight * (1 - distance/nearbyRadiusKm)
+				reasons = append(reasons, ReasonNearby)
+			}
+		}
+	}
+
+	if until := event.StartTime.Sub(now); until < soonWindow {
+		score += soonWeight * (1 - float64(until)/float64(soonWindow))
+	}
+
+	return score, reasons
+}
+
+// Rank scores the candidates and returns the best limit of them, best first.
+// Events the user booked, manages, that already started or are sold out are skipped.
+func Rank(profile *Profile, userID bson.ObjectID, candidates []*models.Event, now time.Time, limit int) []Recommendation {
+	ranked := []Recommendation{}
+	for _, event := range candidates {
+		if profile.Booked[event.ID] || event.IsManagedBy(userID) || !event.StartTime.After(now) || soldOut(event) {
+			continue
+		}
+		score, reasons := Score(profile, event, now)
+		ranked = append(ranked, Recommendation{Event: event, Score: math.Round(score*1000) / 1000, Reasons: reasons})
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].Score != ranked[j].Score {
+			return ranked[i].Score > ranked[j].Score
+		}
+		return ranked[i].Event.StartTime.Before(ranked[j].Event.StartTime)
+	})
+
+	if len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+	return ranked
+}
+
+// soldOut reports whether no ticket of the event is left
+func soldOut(event *models.Event) bool {
+	for _, ticket := range event.Tickets {
+		if ticket.AvailableQuantity > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// eventLocation returns where an in-person event happens, nil for online events and events without coordinates
+func eventLocation(event *models.Event) *Location {
+	if event.EventType == models.EventTypeOnline || event.GeoLocation == nil || len(event.GeoLocation.Coordinates) != 2 {
+		return nil
+	}
+	return &Location{Lng: event.GeoLocation.Coordinates[0], Lat: event.GeoLocation.Coordinates[1]}
+}
@@ -0,0 +1,128 @@
+package reporting
+
+import (
+	"context"
+	"event-horizon/tracing"
+	"fmt"
+	"log"
+	"runtime"
+	"strings"
+	"time"
+)
+
+/** *********************  ERROR REPORTING   ********************
+
+Panics and unexpected errors go to a Reporter so they are not only lost in
+the log:
+
+1. LogReporter    - writes them with their stack trace to the log (default)
+2. SentryReporter - sends them to Sentry (SENTRY_DSN) with the sentry-go SDK,
+                    where they are grouped by stack trace, the trace ID links
+                    them to the request trace
+
+What is reported:
+
+- a panic in a handler (answered with a 500, the process keeps running)
+- a 500 answer of a handler, with the error behind it
+- a panic in background work (schedulers, workers, hooks ...), which defers
+  Recover at its top, the worker carries on with the next run
+- a job the queue gave up on (dead)
+
+ **************************************/
+
+// Reporter sends reports somewhere they are aggregated
+type Reporter interface {
+	Report(report Report)
+}
+
+// Report is one panic or error
+type Report struct {
+	Message string
+	Type    string //? The error's Go type, "panic" for panics
+	Panic   bool
+	Where   string //? Route, scheduler or job it happened in
+	TraceID string
+	Tags    map[string]string
+	Frames  []runtime.Frame //? Innermost first
+	Time    time.Time
+}
+
+// active is where reports go, the log until Configure
+var active Reporter = LogReporter{}
+
+// Configure sets where reports go, call it once at startup
+func Configure(reporter Reporter) {
+	active = reporter
+}
+
+// maxFrames is the deepest stack a report keeps
+const maxFrames = 64
+
+// CaptureError reports an error, the stack is where CaptureError was called
+func CaptureError(ctx context.Context, err error, where string, tags map[string]string) {
+	if err == nil {
+		return
+	}
+	active.Report(newReport(ctx, err.Error(), fmt.Sprintf("%T", err), false, where, tags, 2))
+}
+
+// CapturePanic reports a recovered panic value, call it from the deferred function that recovered
+func CapturePanic(ctx context.Context, value interface{}, where string, tags map[string]string) {
+	active.Report(newReport(ctx, fmt.Sprint(value), "panic", true, where, tags, 2))
+}
+
+// Recover reports a panic of background work instead of letting it kill the process: defer reporting.Recover("...")
+func Recover(where string) {
+	if value := recover(); value != nil {
+		active.Report(newReport(context.Background(), fmt.Sprint(value), "panic", true, where, nil, 2))
+	}
+}
+
+func newReport(ctx context.Context, message, errType string, panicked bool, where string, tags map[string]string, skip int) Report {
+	return Report{
+		Message: message,
+		Type:    errType,
+		Panic:   panicked,
+		Where:   where,
+		TraceID: tracing.TraceID(ctx),
+		Tags:    tags,
+		Frames:  callers(skip + 1),
+		Time:    time.Now().UTC(),
+	}
+}
+
+// callers returns the stack above skip, runtime frames of the panic machinery left out
+func callers(skip int) []runtime.Frame {
+	pcs := make([]uintptr, maxFrames)
+	n := runtime.Callers(skip+1, pcs)
+
+	var frames []runtime.Frame
+	iter := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := iter.Next()
+		if !strings.HasPrefix(frame.Function, "runtime.") {
+			frames = append(frames, frame)
+		}
+		if !more {
+			break
+		}
+	}
+	return frames
+}
+
+// LogReporter writes reports to the log
+type LogReporter struct{}
+
+// Report logs the report with its stack trace
+func (LogReporter) Report(report Report) {
+	var stack strings.Builder
+	for _, frame := range report.Frames {
+		fmt.Fprintf(&stack, "\n\t%s\n\t\t%s:%d", frame.Function, frame.File, frame.Line)
+	}
+
+	kind := "Error"
+	if report.Panic {
+		kind = "Panic"
+	}
+	log.Printf("%s in %s: %s%s", kind, report.Where, report.Message, stack.String())
+}
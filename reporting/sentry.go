@@ -0,0 +1,68 @@
+package reporting
+
+import (
+	"strings"
+
+	"github.com/getsentry/sentry-go"
+)
+
+// SentryReporter sends reports to Sentry with the sentry-go SDK, which queues and sends them in the background
+type SentryReporter struct {
+	client *sentry.Client
+}
+
+// NewSentryReporter creates a reporter for the project of the DSN (https://<key>@<host>/<project id>)
+func NewSentryReporter(dsn, environment, release string) (*SentryReporter, error) {
+	client, err := sentry.NewClient(sentry.ClientOptions{
+		Dsn:         dsn,
+		Environment: environment,
+		Release:     release,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &SentryReporter{client: client}, nil
+}
+
+// Report logs the report and hands it to the SDK, it never blocks
+func (r *SentryReporter) Report(report Report) {
+	LogReporter{}.Report(report)
+	r.client.CaptureEvent(sentryEvent(report), nil, nil)
+}
+
+// sentryEvent converts a report, Sentry wants the frames outermost first
+func sentryEvent(report Report) *sentry.Event {
+	mechanism := &sentry.Mechanism{Type: "generic"}
+	if report.Panic {
+		mechanism.Type = "panic"
+		mechanism.SetUnhandled()
+	} else {
+		handled := true
+		mechanism.Handled = &handled
+	}
+
+	stacktrace := &sentry.Stacktrace{}
+	for i := len(report.Frames) - 1; i >= 0; i-- {
+		frame := sentry.NewFrame(report.Frames[i])
+		frame.InApp = frame.Module == "main" || strings.HasPrefix(frame.Module, "event-horizon")
+		stacktrace.Frames = append(stacktrace.Frames, frame)
+	}
+
+	event := sentry.NewEvent()
+	event.Level = sentry.LevelError
+	event.Transaction = report.Where
+	event.Timestamp = report.Time
+	for key, value := range report.Tags {
+		event.Tags[key] = value
+	}
+	event.Exception = []sentry.Exception{{
+		Type:       report.Type,
+		Value:      report.Message,
+		Stacktrace: stacktrace,
+		Mechanism:  mechanism,
+	}}
+	if report.TraceID != "" {
+		event.Contexts["trace"] = sentry.Context{"trace_id": report.TraceID}
+	}
+	return event
+}
@@ -0,0 +1,149 @@
+package reporting
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testReport(panicked bool) Report {
+	return Report{
+		Message: "boom",
+		Type:    "*errors.errorString",
+		Panic:   panicked,
+		Where:   "GET /api/v1/events/:id",
+		TraceID: "4bf92f3577b34da6a3ce929d0e0e4736",
+		Tags:    map[string]string{"url.path": "/api/v1/events/1"},
+		Frames: []runtime.Frame{
+			{Function: "event-horizon/controllers.(*EventController).GetEvent", File: "/app/controllers/eventController.go", Line: 42},
+			{Function: "github.com/labstack/echo/v4.(*Echo).ServeHTTP", File: "/go/echo.go", Line: 7},
+		},
+		Time: time.Date(2026, 10, 16, 8, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestSentryEvent(t *testing.T) {
+	event := sentryEvent(testReport(false))
+
+	if event.Transaction != "GET /api/v1/events/:id" || event.Tags["url.path"] != "/api/v1/events/1" {
+		t.Fatalf("transaction %q, tags %v", event.Transaction, event.Tags)
+	}
+	if trace := event.Contexts["trace"]; trace["trace_id"] != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Fatalf("trace context = %v", trace)
+	}
+	if len(event.Exception) != 1 {
+		t.Fatalf("%d exceptions, want 1", len(event.Exception))
+	}
+
+	exception := event.Exception[0]
+	if exception.Type != "*errors.errorString" || exception.Value != "boom" {
+		t.Fatalf("exception %q: %q", exception.Type, exception.Value)
+	}
+	if exception.Mechanism.Type != "generic" || exception.Mechanism.Handled == nil || !*exception.Mechanism.Handled {
+		t.Fatalf("mechanism = %+v, want a handled generic error", exception.Mechanism)
+	}
+
+	//? Outermost first, only the app's own frames are in app
+	frames := exception.Stacktrace.Frames
+	if len(frames) != 2 {
+		t.Fatalf("%d frames, want 2", len(frames))
+	}
+	if frames[0].Module != "github.com/labstack/echo/v4" || frames[0].InApp {
+		t.Fatalf("first frame = %+v, want echo outside the app", frames[0])
+	}
+	if frames[1].Module != "event-horizon/controllers" || frames[1].Function != "(*EventController).GetEvent" || frames[1].Lineno != 42 || !frames[1].InApp {
+		t.Fatalf("last frame = %+v, want the controller in the app", frames[1])
+	}
+}
+
+func TestSentryEventPanic(t *testing.T) {
+	mechanism := sentryEvent(testReport(true)).Exception[0].Mechanism
+	if mechanism.Type != "panic" || mechanism.Handled == nil || *mechanism.Handled {
+		t.Fatalf("mechanism = %+v, want an unhandled panic", mechanism)
+	}
+}
+
+func TestNewSentryReporterInvalidDSN(t *testing.T) {
+	for _, dsn := range []string{"not a dsn", "https://sentry.example.com/42", "https://key@sentry.example.com/"} {
+		if _, err := NewSentryReporter(dsn, "test", ""); err == nil {
+			t.Errorf("NewSentryReporter(%q) accepted the DSN", dsn)
+		}
+	}
+}
+
+// TestSentryReporterEnvelope sends a report to a fake Sentry and reads the envelope it gets
+func TestSentryReporterEnvelope(t *testing.T) {
+	type request struct {
+		path, auth string
+		body       []byte
+	}
+	received := make(chan request, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		select {
+		case received <- request{r.URL.Path, r.Header.Get("X-Sentry-Auth"), body}:
+		default:
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	dsn := strings.Replace(server.URL, "://", "://public@", 1) + "/42"
+	reporter, err := NewSentryReporter(dsn, "test", "1.2.3")
+	if err != nil {
+		t.Fatalf("NewSentryReporter: %v", err)
+	}
+	reporter.Report(testReport(false))
+	reporter.client.Flush(5 * time.Second)
+
+	var got request
+	select {
+	case got = <-received:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Sentry got no envelope")
+	}
+
+	if got.path != "/api/42/envelope/" {
+		t.Fatalf("posted to %s", got.path)
+	}
+	if !strings.Contains(got.auth, "sentry_key=public") {
+		t.Fatalf("X-Sentry-Auth = %q", got.auth)
+	}
+
+	//? Envelope: a header line, then an item header and its payload
+	lines := bufio.NewScanner(bytes.NewReader(got.body))
+	lines.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	var parts []map[string]interface{}
+	for lines.Scan() {
+		var part map[string]interface{}
+		if err := json.Unmarshal(lines.Bytes(), &part); err != nil {
+			t.Fatalf("envelope line %q: %v", lines.Text(), err)
+		}
+		parts = append(parts, part)
+	}
+	if len(parts) < 3 {
+		t.Fatalf("envelope has %d lines, want a header, an item header and the event", len(parts))
+	}
+	if parts[1]["type"] != "event" {
+		t.Fatalf("item header = %v", parts[1])
+	}
+
+	event := parts[2]
+	if event["event_id"] != parts[0]["event_id"] || event["environment"] != "test" || event["release"] != "1.2.3" {
+		t.Fatalf("event = %v, envelope header = %v", event, parts[0])
+	}
+	exceptions, ok := event["exception"].([]interface{})
+	if !ok || len(exceptions) != 1 {
+		t.Fatalf("exception = %v, want one", event["exception"])
+	}
+	if exception := exceptions[0].(map[string]interface{}); exception["type"] != "*errors.errorString" || exception["value"] != "boom" {
+		t.Fatalf("exception = %v", exception)
+	}
+}
@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"event-horizon/controllers"
+	"event-horizon/middleware"
+
+	"github.com/labstack/echo/v4"
+)
+
+/** *********************  ACCOUNT ROUTES   ********************
+
+GET /users/me/export - Everything kept about the user as JSON, ?format=zip for one file per part (protected)
+DELETE /users/me     - Delete the account, body {password}, bookings stay for the hosts without personal data (protected)
+
+*****************************************************/
+
+func SetupAccountRoutes(grp *echo.Group, cntrlr *controllers.AccountController) {
+	grp.GET("/me/export", cntrlr.ExportAccount, middleware.JWTMiddleware())
+	grp.DELETE("/me", cntrlr.DeleteAccount, middleware.JWTMiddleware())
+}
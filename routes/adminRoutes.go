@@ -0,0 +1,78 @@
+package routes
+
+import (
+	"event-horizon/controllers"
+	"event-horizon/middleware"
+
+	"github.com/labstack/echo/v4"
+)
+
+/** *********************  ADMIN ROUTES   ********************
+
+GET /admin/audit-logs        - Query the audit log (protected - admin)
+GET /admin/events/pending    - Events waiting for review (protected - admin)
+POST /admin/events/:id/approve - Approve and publish an event (protected - admin)
+POST /admin/events/:id/reject  - Reject an event with a reason (protected - admin)
+GET /admin/reports           - Events by report count (protected - admin)
+POST /admin/users/:id/suspend   - Suspend a host, hide their events and notify attendees (protected - admin)
+POST /admin/users/:id/unsuspend - Lift a host's suspension (protected - admin)
+GET /admin/verifications     - Host verification submissions, pending ones by default, ?status= (protected - admin)
+POST /admin/verifications/:id/approve - Verify the host, their events get the verified badge (protected - admin)
+POST /admin/verifications/:id/reject  - Reject a submission with a note for the host (protected - admin)
+GET /admin/comments/reported - Comments by report count, hidden ones included (protected - admin)
+DELETE /admin/comments/:id   - Delete a comment and its replies (protected - admin)
+DELETE /admin/bookings/:id   - Force-cancel any booking with a reason, restores its tickets and notifies the user (protected - admin)
+GET /admin/cleanup/preview   - Dry run of the expired event cleanup: what it would delete now, ?limit= (protected - admin)
+GET /admin/queue/jobs        - Background jobs, the dead (failed for good) ones by default, ?status=&limit= (protected - admin)
+POST /admin/queue/jobs/:id/retry - Put a dead job back in the queue (protected - admin)
+GET /admin/jobs              - The background schedulers (cleanup, publish ...) with their last run, duration, count and error (protected - admin)
+POST /admin/jobs/:name/run   - Run a scheduler right away, e.g. /admin/jobs/cleanup/run (protected - admin)
+GET /admin/email-templates   - Every email template with its locales (protected - admin)
+GET /admin/email-templates/:name/preview - Render a template with sample data, ?locale=es&format=json|html|text (protected - admin)
+
+*****************************************************/
+
+func SetupAdminRoutes(grp *echo.Group, auditController *controllers.AuditController, moderationController *controllers.ModerationController, reportController *controllers.ReportController, commentController *controllers.CommentController, emailTemplateController *controllers.EmailTemplateController, bookingController *controllers.BookingController, cleanupController *controllers.CleanupController, jobController *controllers.JobController, verificationController *controllers.VerificationController, adminOnly echo.MiddlewareFunc) {
+	grp.Use(middleware.JWTMiddleware(), adminOnly)
+
+	grp.GET("/audit-logs", auditController.GetAuditLogs)
+
+	//! MODERATION QUEUE
+	grp.GET("/events/pending", moderationController.GetPendingEvents)
+	grp.POST("/events/:id/approve", moderationController.ApproveEvent)
+	grp.POST("/events/:id/reject", moderationController.RejectEvent)
+
+	//! REPORTED EVENTS
+	grp.GET("/reports", reportController.GetReportedEvents)
+
+	//! REPORTED COMMENTS
+	grp.GET("/comments/reported", commentController.GetReportedComments)
+	grp.DELETE("/comments/:id", commentController.AdminDeleteComment)
+
+	//! DISPUTED BOOKINGS
+	grp.DELETE("/bookings/:id", bookingController.ForceCancelBooking)
+
+	//! SUSPENDED HOSTS
+	grp.POST("/users/:id/suspend", moderationController.SuspendHost)
+	grp.POST("/users/:id/unsuspend", moderationController.UnsuspendHost)
+
+	//! HOST VERIFICATION (KYC)
+	grp.GET("/verifications", verificationController.GetVerifications)
+	grp.POST("/verifications/:id/approve", verificationController.ApproveVerification)
+	grp.POST("/verifications/:id/reject", verificationController.RejectVerification)
+
+	//! EXPIRED EVENT CLEANUP
+	grp.GET("/cleanup/preview", cleanupController.GetCleanupPreview)
+
+	//! BACKGROUND JOB QUEUE
+	grp.GET("/queue/jobs", jobController.GetJobs)
+	grp.POST("/queue/jobs/:id/retry", jobController.RetryJob)
+
+	//! SCHEDULERS
+	grp.GET("/jobs", jobController.GetSchedulers)
+	grp.POST("/jobs/:name/run", jobController.RunScheduler)
+
+	//! EMAIL TEMPLATES
+	grp.GET("/email-templates", emailTemplateController.GetEmailTemplates)
+	grp.GET("/email-templates/:name/preview", emailTemplateController.PreviewEmailTemplate)
+}
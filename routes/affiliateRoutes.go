@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"event-horizon/controllers"
+	"event-horizon/middleware"
+	"event-horizon/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+/** *********************  AFFILIATE ROUTES   ********************
+
+POST /hosts/me/affiliates          - Register an affiliate with a commission, they get a unique code (protected - hosts)
+GET /hosts/me/affiliates           - The host's affiliates (protected - hosts)
+PATCH /hosts/me/affiliates/:id     - Change the commission or turn an affiliate on / off (protected - hosts)
+GET /hosts/me/affiliates/report    - Sales and owed commission per affiliate, ?from=&to= (YYYY-MM-DD) (protected - hosts)
+
+*****************************************************/
+
+func SetupAffiliateRoutes(grp *echo.Group, cntrlr *controllers.AffiliateController) {
+	grp.POST("/me/affiliates", cntrlr.CreateAffiliate, middleware.JWTMiddleware(), middleware.RequireScope(utils.ScopeHostReports))
+	grp.GET("/me/affiliates", cntrlr.GetAffiliates, middleware.JWTMiddleware(), middleware.RequireScope(utils.ScopeHostReports))
+	grp.GET("/me/affiliates/report", cntrlr.GetAffiliateReport, middleware.JWTMiddleware(), middleware.RequireScope(utils.ScopeHostReports))
+	grp.PATCH("/me/affiliates/:id", cntrlr.UpdateAffiliate, middleware.JWTMiddleware(), middleware.RequireScope(utils.ScopeHostReports))
+}
@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"event-horizon/controllers"
+	"event-horizon/middleware"
+	"event-horizon/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+/** *********************  ANALYTICS ROUTES   ********************
+
+GET /events/:id/analytics     - Tickets sold, revenue, daily sales and cancellation rate (protected - event host / co-hosts)
+GET /hosts/me/bookings/export - CSV of the host's transactions, ?from=&to= (YYYY-MM-DD) (protected - hosts)
+GET /hosts/me/events/past     - Ended events with final sales and attendance, archived ones included, ?cursor=&limit= (protected - hosts)
+
+*****************************************************/
+
+func SetupAnalyticsRoutes(grp *echo.Group, cntrlr *controllers.AnalyticsController) {
+	grp.GET("/:id/analytics", cntrlr.GetEventAnalytics, middleware.JWTMiddleware(), middleware.RequireScope(utils.ScopeHostReports))
+}
+
+func SetupHostExportRoutes(grp *echo.Group, cntrlr *controllers.AnalyticsController) {
+	grp.GET("/me/bookings/export", cntrlr.ExportBookings, middleware.JWTMiddleware(), middleware.RequireScope(utils.ScopeHostReports))
+	grp.GET("/me/events/past", cntrlr.GetPastEvents, middleware.JWTMiddleware(), middleware.RequireScope(utils.ScopeHostReports))
+}
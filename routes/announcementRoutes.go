@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"event-horizon/controllers"
+	"event-horizon/middleware"
+	"event-horizon/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+/** *********************  ANNOUNCEMENT ROUTES   ********************
+
+POST /events/:id/announcements      - Send an update to every confirmed attendee (protected - event host and co-hosts)
+GET /events/:id/announcements       - Announcement history of the event, newest first (?limit=)
+
+*****************************************************/
+
+func SetupAnnouncementRoutes(grp *echo.Group, cntrlr *controllers.AnnouncementController) {
+	grp.POST("/:id/announcements", cntrlr.CreateAnnouncement, middleware.JWTMiddleware(), middleware.RequireScope(utils.ScopeEventsWrite))
+	grp.GET("/:id/announcements", cntrlr.GetAnnouncements)
+}
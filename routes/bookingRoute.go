@@ -3,6 +3,7 @@ package routes
 import (
 	"event-horizon/controllers"
 	"event-horizon/middleware"
+	"event-horizon/utils"
 
 	"github.com/labstack/echo/v4"
 )
@@ -10,17 +11,29 @@ import (
 /** *********************  BOOKING ROUTES   ********************
 
 POST /bookings/create         - Create a new booking (protected)
-GET /bookings/user           - Get bookings for the authenticated user (protected)
-GET /bookings/all            - Get all bookings (protected - admin)
+GET /bookings/user           - Get bookings for the authenticated user, cursor paginated, ?when=upcoming|past (protected)
+GET /bookings/wallet         - Upcoming confirmed tickets with event, QR payloads and venue geo, for the mobile wallet (protected)
+GET /bookings/all            - Get all bookings, cursor paginated (protected - admin)
+GET /bookings/event/:eventId - Get bookings of an event (protected - event host / co-hosts / org scanners / scanner key)
 GET /bookings/:id            - Get booking by ID (protected)
+GET /bookings/:id/receipt.pdf - PDF receipt of a booking (protected - buyer / admin)
+PUT /bookings/:id/attendees  - Change the attendees' names before the event (protected - buyer)
+PUT /bookings/:id/change-type - Move the tickets to another ticket type, returns the price difference (protected - buyer)
 PUT /bookings/:id/cancel     - Cancel a booking (protected)
+POST /bookings/:id/reconfirm - Keep a booking after its event was rescheduled (protected - buyer)
 
 *****************************************************/
 
-func SetupBookingRoutes(grp *echo.Group, cntrlr *controllers.BookingController) {
-	grp.POST("/create", cntrlr.CreateBooking, middleware.JWTMiddleware())
+func SetupBookingRoutes(grp *echo.Group, cntrlr *controllers.BookingController, adminOnly, doorAuth echo.MiddlewareFunc) {
+	grp.POST("/create", cntrlr.CreateBooking, middleware.JWTMiddleware(), middleware.RequireScope(utils.ScopeBookingsWrite))
 	grp.GET("/user", cntrlr.GetUserBookings, middleware.JWTMiddleware())
-	grp.GET("/all", cntrlr.GetAllBookings, middleware.JWTMiddleware())
+	grp.GET("/wallet", cntrlr.GetWallet, middleware.JWTMiddleware())
+	grp.GET("/all", cntrlr.GetAllBookings, middleware.JWTMiddleware(), adminOnly)
+	grp.GET("/event/:eventId", cntrlr.GetEventBookings, doorAuth)
 	grp.GET("/:id", cntrlr.GetBookingByID, middleware.JWTMiddleware())
+	grp.GET("/:id/receipt.pdf", cntrlr.GetBookingReceipt, middleware.JWTMiddleware())
+	grp.PUT("/:id/attendees", cntrlr.UpdateAttendees, middleware.JWTMiddleware())
+	grp.PUT("/:id/change-type", cntrlr.ChangeTicketType, middleware.JWTMiddleware())
 	grp.PUT("/:id/cancel", cntrlr.CancelBooking, middleware.JWTMiddleware())
+	grp.POST("/:id/reconfirm", cntrlr.ReconfirmBooking, middleware.JWTMiddleware())
 }
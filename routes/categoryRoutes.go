@@ -12,24 +12,26 @@ import (
 GET /categories                     - Get all categories
 GET /categories/with-events        - Get all categories with their events
 GET /categories/:id                - Get category by ID
+GET /categories/slug/:slug         - Get category by slug
 GET /categories/:id/events         - Get events by category ID
 GET /categories/name/:name/events  - Get events by category name
-POST /categories/create            - Create a new category (protected)
-PUT /categories/:id                - Update a category (protected)
-DELETE /categories/:id             - Delete a category (protected)
+POST /categories/create            - Create a new category (protected - admin)
+PUT /categories/:id                - Update a category (protected - admin)
+DELETE /categories/:id             - Delete an empty category, ?cascade=true also deletes its events and bookings (protected - admin)
 
 *****************************************************/
 
-func CategoryRoutes(grp *echo.Group, cc *controllers.CategoryController) {
+func CategoryRoutes(grp *echo.Group, cc *controllers.CategoryController, adminOnly echo.MiddlewareFunc) {
 
 	grp.GET("", cc.GetAllCategories)
 	grp.GET("/with-events", cc.GetAllCategoriesWithEvents)
 	grp.GET("/:id", cc.GetCategoryByID)
+	grp.GET("/slug/:slug", cc.GetCategoryBySlug)
 	grp.GET("/:id/events", cc.GetCategoryWithEvents)
 	grp.GET("/name/:name/events", cc.GetEventsByCategoryName)
 
-	// Protected routes (require authentication)
-	grp.POST("/create", cc.CreateCategory, middleware.JWTMiddleware())
-	grp.PUT("/:id", cc.UpdateCategory, middleware.JWTMiddleware())
-	grp.DELETE("/:id", cc.DeleteCategory, middleware.JWTMiddleware())
+	// Admin routes (require authentication + admin role)
+	grp.POST("/create", cc.CreateCategory, middleware.JWTMiddleware(), adminOnly)
+	grp.PUT("/:id", cc.UpdateCategory, middleware.JWTMiddleware(), adminOnly)
+	grp.DELETE("/:id", cc.DeleteCategory, middleware.JWTMiddleware(), adminOnly)
 }
@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"event-horizon/controllers"
+
+	"github.com/labstack/echo/v4"
+)
+
+/** *********************  CHECK-IN ROUTES   ********************
+
+GET /events/:id/checkins        - Door counts per ticket type, for polling (protected - event host / co-hosts / org scanners / scanner key)
+GET /events/:id/checkins/stream - Door counts via Server-Sent Events (protected - event host / co-hosts / org scanners / scanner key)
+POST /events/:id/checkins       - Check in one or many scanned codes (protected - event host / co-hosts / org scanners / scanner key)
+
+*****************************************************/
+
+func SetupCheckInRoutes(grp *echo.Group, cntrlr *controllers.CheckInController, doorAuth echo.MiddlewareFunc) {
+	grp.GET("/:id/checkins", cntrlr.GetCheckIns, doorAuth)
+	grp.GET("/:id/checkins/stream", cntrlr.StreamCheckIns, doorAuth)
+	grp.POST("/:id/checkins", cntrlr.CheckIn, doorAuth)
+}
@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"event-horizon/controllers"
+	"event-horizon/middleware"
+	"event-horizon/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+/** *********************  CO-HOST ROUTES   ********************
+
+POST /events/:id/cohosts                - Invite a host as co-host by email (protected - event host)
+POST /events/:id/cohosts/accept         - Accept a co-host invite (protected)
+DELETE /events/:id/cohosts/:userId      - Remove a co-host, or leave as co-host (protected)
+
+*****************************************************/
+
+func SetupCoHostRoutes(grp *echo.Group, cntrlr *controllers.CoHostController) {
+	grp.POST("/:id/cohosts", cntrlr.InviteCoHost, middleware.JWTMiddleware(), middleware.RequireScope(utils.ScopeEventsWrite))
+	grp.POST("/:id/cohosts/accept", cntrlr.AcceptCoHostInvite, middleware.JWTMiddleware())
+	grp.DELETE("/:id/cohosts/:userId", cntrlr.RemoveCoHost, middleware.JWTMiddleware())
+}
@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"event-horizon/controllers"
+	"event-horizon/middleware"
+
+	"github.com/labstack/echo/v4"
+)
+
+/** *********************  COMMENT ROUTES   ********************
+
+GET /events/:id/comments                        - Comments with their replies, newest first (?limit=, ?offset=)
+POST /events/:id/comments                       - Ask a question or reply to one (protected)
+DELETE /events/:id/comments/:commentId          - Delete a comment and its replies (protected - author, event host and co-hosts)
+POST /events/:id/comments/:commentId/report     - Report a comment (protected)
+
+*****************************************************/
+
+func SetupCommentRoutes(grp *echo.Group, cntrlr *controllers.CommentController) {
+	grp.GET("/:id/comments", cntrlr.GetComments)
+	grp.POST("/:id/comments", cntrlr.CreateComment, middleware.JWTMiddleware())
+	grp.DELETE("/:id/comments/:commentId", cntrlr.DeleteComment, middleware.JWTMiddleware())
+	grp.POST("/:id/comments/:commentId/report", cntrlr.ReportComment, middleware.JWTMiddleware())
+}
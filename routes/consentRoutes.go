@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"event-horizon/controllers"
+	"event-horizon/middleware"
+
+	"github.com/labstack/echo/v4"
+)
+
+/** *********************  CONSENT ROUTES   ********************
+
+GET /policies           - Current versions of the terms and privacy policy, sign ups and bookings send them back (public)
+GET /users/me/consents  - The user's latest acceptances and whether the documents changed since (protected)
+POST /users/me/consents - Accept the current versions again, body {terms_version, privacy_version} (protected)
+
+*****************************************************/
+
+func SetupConsentRoutes(users *echo.Group, policies *echo.Group, cntrlr *controllers.ConsentController) {
+	policies.GET("", cntrlr.GetPolicies)
+
+	users.GET("/me/consents", cntrlr.GetConsents, middleware.JWTMiddleware())
+	users.POST("/me/consents", cntrlr.AcceptPolicies, middleware.JWTMiddleware())
+}
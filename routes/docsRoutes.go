@@ -0,0 +1,13 @@
+package routes
+
+import (
+	"event-horizon/controllers"
+
+	"github.com/labstack/echo/v4"
+)
+
+func SetupDocsRoutes(grp *echo.Group, docsController *controllers.DocsController) {
+	//! API DOCS ROUTES (public)
+	grp.GET("", docsController.GetSwaggerUI)
+	grp.GET("/openapi.yaml", docsController.GetSpec)
+}
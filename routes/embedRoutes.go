@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"event-horizon/controllers"
+
+	"github.com/labstack/echo/v4"
+)
+
+/** *********************  EMBED ROUTES   ********************
+
+GET /embed/events   - Upcoming events for the widget hosts embed on their sites, ?host_id= ?category= ?limit= ?format=json|html (public, any origin)
+
+*****************************************************/
+
+func SetupEmbedRoutes(grp *echo.Group, cntrlr *controllers.EmbedController) {
+	grp.GET("/events", cntrlr.GetEmbedEvents)
+}
@@ -3,29 +3,62 @@ package routes
 import (
 	"event-horizon/controllers"
 	"event-horizon/middleware"
+	"event-horizon/utils"
 
 	"github.com/labstack/echo/v4"
 )
 
 /********************* EVENT ROUTES ********************
 
-GET /events/all           - Get all events (public)
+GET /events/all           - Get all events, optional ?tags=a,b filter (public)
+GET /events/nearby        - Get events near ?lat=&lng=&radius_km= (public)
+GET /events/calendar      - Events by day from ?from= to ?to=, optional ?category= and ?timezone= (public)
 GET /events/:id           - Get event by ID (public)
-POST /events/create       - Create a new event (protected)
-PUT /events/:id           - Update an event (protected)
-DELETE /events/:id        - Delete an event (protected)
+GET /events/:id/stream    - Live ticket availability via Server-Sent Events (public)
+POST /events/create       - Create a new event (protected - hosts, events:write scope)
+POST /events/bulk         - Publish, unpublish, cancel, delete or re-categorize many events at once (protected - hosts, events:write scope)
+PUT /events/:id           - Update an event (protected - hosts, events:write scope)
+PATCH /events/:id         - Update only the sent fields of an event (protected - hosts, events:write scope)
+DELETE /events/:id        - Delete an event (protected - hosts, events:write scope)
+POST /events/:id/duplicate - Copy an event into a new draft with new dates (protected - hosts, events:write scope)
+POST /events/:id/publish  - Publish a draft event (protected - hosts, events:write scope)
+PUT /events/:id/schedule  - Publish a draft automatically at publish_at (protected - hosts, events:write scope)
+DELETE /events/:id/schedule - Cancel the scheduled publishing of a draft (protected - hosts, events:write scope)
+POST /events/:id/reschedule - Move an event to new dates, attendees reconfirm or get refunded (protected - hosts, events:write scope)
+GET /events/:id/join      - Get the stream URL of an online event (protected - confirmed attendees / host)
 
 */
 
 func SetupEventRoutes(grp *echo.Group, cntrlr *controllers.EventController) {
 
-	//! Protected routes (require JWT authentication)
-	grp.POST("/create", cntrlr.CreateEvent, middleware.JWTMiddleware())
-	grp.PUT("/:id", cntrlr.UpdateEvent, middleware.JWTMiddleware())
-	grp.DELETE("/:id", cntrlr.DeleteEvent, middleware.JWTMiddleware())
+	//! Protected routes (require JWT authentication, host routes the events:write scope too)
+	grp.POST("/create", cntrlr.CreateEvent, middleware.JWTMiddleware(), middleware.RequireScope(utils.ScopeEventsWrite))
+	grp.POST("/bulk", cntrlr.BulkEvents, middleware.JWTMiddleware(), middleware.RequireScope(utils.ScopeEventsWrite))
+	grp.PUT("/:id", cntrlr.UpdateEvent, middleware.JWTMiddleware(), middleware.RequireScope(utils.ScopeEventsWrite))
+	grp.PATCH("/:id", cntrlr.PatchEvent, middleware.JWTMiddleware(), middleware.RequireScope(utils.ScopeEventsWrite))
+	grp.DELETE("/:id", cntrlr.DeleteEvent, middleware.JWTMiddleware(), middleware.RequireScope(utils.ScopeEventsWrite))
+	grp.POST("/:id/duplicate", cntrlr.DuplicateEvent, middleware.JWTMiddleware(), middleware.RequireScope(utils.ScopeEventsWrite))
+	grp.POST("/:id/publish", cntrlr.PublishEvent, middleware.JWTMiddleware(), middleware.RequireScope(utils.ScopeEventsWrite))
+	grp.PUT("/:id/schedule", cntrlr.SchedulePublish, middleware.JWTMiddleware(), middleware.RequireScope(utils.ScopeEventsWrite))
+	grp.DELETE("/:id/schedule", cntrlr.CancelScheduledPublish, middleware.JWTMiddleware(), middleware.RequireScope(utils.ScopeEventsWrite))
+	grp.POST("/:id/reschedule", cntrlr.RescheduleEvent, middleware.JWTMiddleware(), middleware.RequireScope(utils.ScopeEventsWrite))
+	grp.GET("/:id/join", cntrlr.GetJoinLink, middleware.JWTMiddleware())
 
 	//! Public routes (no authentication required)
 	grp.GET("/all", cntrlr.GetAllEvents)
+	grp.GET("/nearby", cntrlr.GetNearbyEvents)
+	grp.GET("/calendar", cntrlr.GetCalendar)
 	grp.GET("/:id", cntrlr.GetEventByID)
+	grp.GET("/:id/stream", cntrlr.StreamEventUpdates)
 
 }
+
+/** *********************  HOST TICKET ROUTES   ********************
+
+PUT /hosts/me/tickets/bulk-price - Raise or lower ticket prices across many events by a percent or an amount, dry_run previews (protected - hosts, events:write scope)
+
+*****************************************************/
+
+func SetupHostTicketRoutes(grp *echo.Group, cntrlr *controllers.EventController) {
+	grp.PUT("/me/tickets/bulk-price", cntrlr.BulkPrice, middleware.JWTMiddleware(), middleware.RequireScope(utils.ScopeEventsWrite))
+}
@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"event-horizon/controllers"
+	"event-horizon/middleware"
+
+	"github.com/labstack/echo/v4"
+)
+
+/** *********************  FOLLOW ROUTES   ********************
+
+GET /hosts/following          - Get hosts the authenticated user follows (protected)
+POST /hosts/:id/follow        - Follow a host (protected)
+DELETE /hosts/:id/follow      - Unfollow a host (protected)
+
+*****************************************************/
+
+func SetupFollowRoutes(grp *echo.Group, cntrlr *controllers.FollowController) {
+	grp.GET("/following", cntrlr.GetFollowing, middleware.JWTMiddleware())
+	grp.POST("/:id/follow", cntrlr.FollowHost, middleware.JWTMiddleware())
+	grp.DELETE("/:id/follow", cntrlr.UnfollowHost, middleware.JWTMiddleware())
+}
@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"event-horizon/controllers"
+	"event-horizon/middleware"
+
+	"github.com/labstack/echo/v4"
+)
+
+/** *********************  GUEST CHECKOUT ROUTES   ********************
+
+POST /guest/bookings         - Book with only a name and an email, the access code is emailed
+GET /guest/bookings/:code    - Get a guest booking by its access code
+POST /guest/bookings/claim   - Move the guest bookings behind a code into your account (protected - the guest's email only)
+
+*****************************************************/
+
+func SetupGuestRoutes(grp *echo.Group, cntrlr *controllers.BookingController) {
+	grp.POST("/bookings", cntrlr.CreateGuestBooking)
+	grp.GET("/bookings/:code", cntrlr.GetGuestBooking)
+	grp.POST("/bookings/claim", cntrlr.ClaimGuestBookings, middleware.JWTMiddleware())
+}
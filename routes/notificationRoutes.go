@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"event-horizon/controllers"
+	"event-horizon/middleware"
+
+	"github.com/labstack/echo/v4"
+)
+
+/** *********************  NOTIFICATION ROUTES   ********************
+
+GET /notifications            - Get notifications for the authenticated user (protected)
+PUT /notifications/:id/read   - Mark a notification as read (protected)
+
+*****************************************************/
+
+func SetupNotificationRoutes(grp *echo.Group, cntrlr *controllers.NotificationController) {
+	grp.GET("", cntrlr.GetUserNotifications, middleware.JWTMiddleware())
+	grp.PUT("/:id/read", cntrlr.MarkNotificationAsRead, middleware.JWTMiddleware())
+}
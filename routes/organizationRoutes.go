@@ -0,0 +1,37 @@
+package routes
+
+import (
+	"event-horizon/controllers"
+	"event-horizon/middleware"
+	"event-horizon/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+/** *********************  ORGANIZATION ROUTES   ********************
+
+POST /orgs                         - Create an organization, the host becomes its owner (protected - hosts)
+GET /orgs                          - The organizations of the user (protected)
+GET /orgs/:id                      - An organization with its members (protected - members)
+POST /orgs/:id/members             - Add a user by email as manager or scanner (protected - owner)
+PATCH /orgs/:id/members/:userId    - Change a member's role (protected - owner)
+DELETE /orgs/:id/members/:userId   - Remove a member, or leave the organization (protected - owner / the member)
+GET /orgs/:id/events               - Every event of the organization, drafts included (protected - members)
+POST /orgs/:id/events              - Create an event of the organization, hosted by its owner (protected - owner / managers)
+
+*****************************************************/
+
+func SetupOrganizationRoutes(grp *echo.Group, cntrlr *controllers.OrganizationController) {
+	grp.Use(middleware.JWTMiddleware())
+
+	grp.POST("", cntrlr.CreateOrganization, middleware.RequireScope(utils.ScopeEventsWrite))
+	grp.GET("", cntrlr.GetOrganizations)
+	grp.GET("/:id", cntrlr.GetOrganization)
+
+	grp.POST("/:id/members", cntrlr.AddMember)
+	grp.PATCH("/:id/members/:userId", cntrlr.UpdateMember)
+	grp.DELETE("/:id/members/:userId", cntrlr.RemoveMember)
+
+	grp.GET("/:id/events", cntrlr.GetOrgEvents)
+	grp.POST("/:id/events", cntrlr.CreateOrgEvent, middleware.RequireScope(utils.ScopeEventsWrite))
+}
@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"event-horizon/controllers"
+	"event-horizon/middleware"
+
+	"github.com/labstack/echo/v4"
+)
+
+/** *********************  RECOMMENDATION ROUTES   ********************
+
+GET /events/recommendations - Upcoming events the user may like, optional ?lat=&lng=&limit= (protected)
+GET /events/:id/related     - Upcoming events of the same host or category, not sold out, optional ?limit= (public)
+
+*****************************************************/
+
+func SetupRecommendationRoutes(grp *echo.Group, cntrlr *controllers.RecommendationController) {
+	grp.GET("/recommendations", cntrlr.GetRecommendations, middleware.JWTMiddleware())
+	grp.GET("/:id/related", cntrlr.GetRelatedEvents)
+}
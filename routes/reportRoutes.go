@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"event-horizon/controllers"
+	"event-horizon/middleware"
+
+	"github.com/labstack/echo/v4"
+)
+
+/** *********************  REPORT ROUTES   ********************
+
+POST /events/:id/report      - Report an event as spam, scam ... (protected)
+
+*****************************************************/
+
+func SetupReportRoutes(grp *echo.Group, cntrlr *controllers.ReportController) {
+	grp.POST("/:id/report", cntrlr.ReportEvent, middleware.JWTMiddleware())
+}
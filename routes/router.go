@@ -0,0 +1,85 @@
+package routes
+
+import (
+	"event-horizon/controllers"
+
+	"github.com/labstack/echo/v4"
+)
+
+/** *********************  VERSIONED ROUTER   ********************
+
+All API routes are registered through RegisterV1 so the same set of routes
+can be mounted under /api/v1 and, during the deprecation window, under the
+legacy /api prefix. A future v2 gets its own RegisterV2.
+
+*****************************************************/
+
+// Controllers groups everything the routes need
+type Controllers struct {
+	Event        *controllers.EventController
+	CoHost       *controllers.CoHostController
+	User         *controllers.UserController
+	Account      *controllers.AccountController
+	Consent      *controllers.ConsentController
+	Template     *controllers.TemplateController
+	Venue        *controllers.VenueController
+	Category     *controllers.CategoryController
+	Booking      *controllers.BookingController
+	Follow       *controllers.FollowController
+	Notification *controllers.NotificationController
+	Audit        *controllers.AuditController
+	Moderation   *controllers.ModerationController
+	Report       *controllers.ReportController
+	Analytics    *controllers.AnalyticsController
+	CheckIn      *controllers.CheckInController
+	Search       *controllers.SearchController
+	Recommend    *controllers.RecommendationController
+	EmailPreview *controllers.EmailTemplateController
+	Announcement *controllers.AnnouncementController
+	Comment      *controllers.CommentController
+	ShareLink    *controllers.ShareLinkController
+	Affiliate    *controllers.AffiliateController
+	SEO          *controllers.SEOController
+	Embed        *controllers.EmbedController
+	Cleanup      *controllers.CleanupController
+	Jobs         *controllers.JobController
+	Verification *controllers.VerificationController
+	Organization *controllers.OrganizationController
+	ScannerKey   *controllers.ScannerKeyController
+	AdminOnly    echo.MiddlewareFunc
+	DoorAuth     echo.MiddlewareFunc //? Scanner key or JWT, only for the check-in and attendee lookup routes
+}
+
+// RegisterV1 registers every v1 route on the given API group
+func RegisterV1(api *echo.Group, ctrls Controllers) {
+	SetupEventRoutes(api.Group("/events"), ctrls.Event)
+	SetupCoHostRoutes(api.Group("/events"), ctrls.CoHost)
+	SetupReportRoutes(api.Group("/events"), ctrls.Report)
+	SetupAnalyticsRoutes(api.Group("/events"), ctrls.Analytics)
+	SetupCheckInRoutes(api.Group("/events"), ctrls.CheckIn, ctrls.DoorAuth)
+	SetupScannerKeyRoutes(api.Group("/events"), ctrls.ScannerKey)
+	SetupSearchRoutes(api.Group("/events"), ctrls.Search)
+	SetupRecommendationRoutes(api.Group("/events"), ctrls.Recommend)
+	SetupAnnouncementRoutes(api.Group("/events"), ctrls.Announcement)
+	SetupCommentRoutes(api.Group("/events"), ctrls.Comment)
+	SetupShareLinkRoutes(api.Group("/events"), ctrls.ShareLink)
+	SetupSEORoutes(api.Group("/events"), ctrls.SEO)
+	UserRoutes(api.Group("/users"), ctrls.User)
+	SetupAccountRoutes(api.Group("/users"), ctrls.Account)
+	SetupConsentRoutes(api.Group("/users"), api.Group("/policies"), ctrls.Consent)
+	CategoryRoutes(api.Group("/categories"), ctrls.Category, ctrls.AdminOnly)
+	SetupBookingRoutes(api.Group("/bookings"), ctrls.Booking, ctrls.AdminOnly, ctrls.DoorAuth)
+	SetupGuestRoutes(api.Group("/guest"), ctrls.Booking)
+	SetupFollowRoutes(api.Group("/hosts"), ctrls.Follow)
+	SetupHostExportRoutes(api.Group("/hosts"), ctrls.Analytics)
+	SetupHostTicketRoutes(api.Group("/hosts"), ctrls.Event)
+	SetupTemplateRoutes(api.Group("/hosts"), api.Group("/events"), ctrls.Template)
+	SetupVenueRoutes(api.Group("/venues"), ctrls.Venue)
+	SetupAffiliateRoutes(api.Group("/hosts"), ctrls.Affiliate)
+	SetupVerificationRoutes(api.Group("/hosts"), ctrls.Verification)
+	SetupOrganizationRoutes(api.Group("/orgs"), ctrls.Organization)
+	SetupNotificationRoutes(api.Group("/notifications"), ctrls.Notification)
+	SetupEmbedRoutes(api.Group("/embed"), ctrls.Embed)
+	SetupTagRoutes(api.Group("/tags"), ctrls.Event)
+	SetupAdminRoutes(api.Group("/admin"), ctrls.Audit, ctrls.Moderation, ctrls.Report, ctrls.Comment, ctrls.EmailPreview, ctrls.Booking, ctrls.Cleanup, ctrls.Jobs, ctrls.Verification, ctrls.AdminOnly)
+}
@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"event-horizon/controllers"
+	"event-horizon/middleware"
+	"event-horizon/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+/** *********************  SCANNER KEY ROUTES   ********************
+
+POST /events/:id/scanner-keys          - Create a scanner key for a door device, shown once (protected - event host / co-hosts)
+GET /events/:id/scanner-keys           - The scanner keys of the event (protected - event host / co-hosts)
+DELETE /events/:id/scanner-keys/:keyId - Revoke a scanner key (protected - event host / co-hosts)
+
+A scanner key is sent in the X-Scanner-Key header and only opens the check-in
+routes and GET /bookings/event/:eventId of its own event.
+
+*****************************************************/
+
+func SetupScannerKeyRoutes(grp *echo.Group, cntrlr *controllers.ScannerKeyController) {
+	grp.POST("/:id/scanner-keys", cntrlr.CreateScannerKey, middleware.JWTMiddleware(), middleware.RequireScope(utils.ScopeEventsWrite))
+	grp.GET("/:id/scanner-keys", cntrlr.GetScannerKeys, middleware.JWTMiddleware(), middleware.RequireScope(utils.ScopeEventsWrite))
+	grp.DELETE("/:id/scanner-keys/:keyId", cntrlr.RevokeScannerKey, middleware.JWTMiddleware(), middleware.RequireScope(utils.ScopeEventsWrite))
+}
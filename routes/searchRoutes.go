@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"event-horizon/controllers"
+
+	"github.com/labstack/echo/v4"
+)
+
+/** *********************  SEARCH ROUTES   ********************
+
+GET /events/search - Full-text search, ?q=&category=&from=&to=&min_price=&max_price=&limit=&offset= (public)
+
+*****************************************************/
+
+func SetupSearchRoutes(grp *echo.Group, cntrlr *controllers.SearchController) {
+	grp.GET("/search", cntrlr.SearchEvents)
+}
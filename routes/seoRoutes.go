@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"event-horizon/controllers"
+
+	"github.com/labstack/echo/v4"
+)
+
+/** *********************  SEO ROUTES   ********************
+
+GET /events/feed.rss     - RSS feed of upcoming events, optional ?category= (public)
+GET /events/:id/jsonld   - schema.org Event structured data of a public event (public)
+GET /sitemap.xml         - Sitemap of every public event page (not versioned, outside /api)
+
+*****************************************************/
+
+func SetupSEORoutes(grp *echo.Group, cntrlr *controllers.SEOController) {
+	grp.GET("/feed.rss", cntrlr.GetEventFeed)
+	grp.GET("/:id/jsonld", cntrlr.GetEventJSONLD)
+}
+
+func SetupSitemapRoutes(grp *echo.Group, cntrlr *controllers.SEOController) {
+	grp.GET("/sitemap.xml", cntrlr.GetSitemap)
+}
@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"event-horizon/controllers"
+	"event-horizon/middleware"
+	"event-horizon/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+/** *********************  SHARE LINK ROUTES   ********************
+
+POST /events/:id/share-links   - Create a short share link for a channel (protected - event host and co-hosts)
+GET /events/:id/referrals      - Clicks and bookings per share link (protected - event host and co-hosts)
+GET /s/:code                   - Count the click and redirect to the event page (not versioned, outside /api)
+
+*****************************************************/
+
+func SetupShareLinkRoutes(grp *echo.Group, cntrlr *controllers.ShareLinkController) {
+	grp.POST("/:id/share-links", cntrlr.CreateShareLink, middleware.JWTMiddleware(), middleware.RequireScope(utils.ScopeEventsWrite))
+	grp.GET("/:id/referrals", cntrlr.GetReferrals, middleware.JWTMiddleware(), middleware.RequireScope(utils.ScopeHostReports))
+}
+
+func SetupShortLinkRoutes(grp *echo.Group, cntrlr *controllers.ShareLinkController) {
+	grp.GET("/:code", cntrlr.FollowShareLink)
+}
@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"event-horizon/controllers"
+
+	"github.com/labstack/echo/v4"
+)
+
+/** *********************  TAG ROUTES   ********************
+
+GET /tags/popular            - Get the most used event tags (public)
+
+*****************************************************/
+
+func SetupTagRoutes(grp *echo.Group, cntrlr *controllers.EventController) {
+	grp.GET("/popular", cntrlr.GetPopularTags)
+}
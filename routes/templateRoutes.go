@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"event-horizon/controllers"
+	"event-horizon/middleware"
+	"event-horizon/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+/** *********************  TEMPLATE ROUTES   ********************
+
+POST /hosts/me/templates                 - Save one of your events as a template, body {event_id, name} (protected - hosts, events:write scope)
+GET /hosts/me/templates                  - The host's templates (protected - hosts, events:write scope)
+GET /hosts/me/templates/:id              - One of the host's templates (protected - hosts, events:write scope)
+DELETE /hosts/me/templates/:id           - Delete a template, events made from it stay (protected - hosts, events:write scope)
+POST /events/from-template/:templateId   - Create a draft from a template on new dates (protected - hosts, events:write scope)
+
+*****************************************************/
+
+func SetupTemplateRoutes(hosts *echo.Group, events *echo.Group, cntrlr *controllers.TemplateController) {
+	hosts.POST("/me/templates", cntrlr.SaveTemplate, middleware.JWTMiddleware(), middleware.RequireScope(utils.ScopeEventsWrite))
+	hosts.GET("/me/templates", cntrlr.GetTemplates, middleware.JWTMiddleware(), middleware.RequireScope(utils.ScopeEventsWrite))
+	hosts.GET("/me/templates/:id", cntrlr.GetTemplate, middleware.JWTMiddleware(), middleware.RequireScope(utils.ScopeEventsWrite))
+	hosts.DELETE("/me/templates/:id", cntrlr.DeleteTemplate, middleware.JWTMiddleware(), middleware.RequireScope(utils.ScopeEventsWrite))
+
+	events.POST("/from-template/:templateId", cntrlr.CreateEventFromTemplate, middleware.JWTMiddleware(), middleware.RequireScope(utils.ScopeEventsWrite))
+}
@@ -2,6 +2,7 @@ package routes
 
 import (
 	"event-horizon/controllers"
+	"event-horizon/middleware"
 
 	"github.com/labstack/echo/v4"
 )
@@ -10,4 +11,25 @@ func UserRoutes(e *echo.Group, controller *controllers.UserController) {
 	//! USER ROUTES
 	e.POST("/register", controller.Register)
 	e.POST("/login", controller.Login)
+
+	//! SESSION ROUTES (protected)
+	e.POST("/logout", controller.Logout, middleware.JWTMiddleware())
+	e.GET("/me/sessions", controller.GetSessions, middleware.JWTMiddleware())
+	e.DELETE("/me/sessions/:id", controller.RevokeSession, middleware.JWTMiddleware())
+	e.POST("/me/token", controller.RefreshToken, middleware.JWTMiddleware())
+
+	//! HOST APPLICATION (protected)
+	e.POST("/me/become-host", controller.BecomeHost, middleware.JWTMiddleware())
+
+	//! EMAIL LANGUAGE (protected)
+	e.PUT("/me/language", controller.UpdateLanguage, middleware.JWTMiddleware())
+
+	//! PHONE NUMBER FOR SMS (protected)
+	e.PUT("/me/phone", controller.RequestPhoneVerification, middleware.JWTMiddleware())
+	e.POST("/me/phone/verify", controller.VerifyPhone, middleware.JWTMiddleware())
+	e.DELETE("/me/phone", controller.RemovePhone, middleware.JWTMiddleware())
+
+	//! NOTIFICATION PREFERENCES (protected)
+	e.GET("/me/preferences", controller.GetPreferences, middleware.JWTMiddleware())
+	e.PUT("/me/preferences", controller.UpdatePreferences, middleware.JWTMiddleware())
 }
@@ -0,0 +1,30 @@
+package routes
+
+import (
+	"event-horizon/controllers"
+	"event-horizon/middleware"
+	"event-horizon/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+/** *********************  VENUE ROUTES   ********************
+
+GET /venues               - Venues, filter with ?name=, ?city= and ?limit= (public)
+GET /venues/:id           - A venue (public)
+GET /venues/:id/events    - Upcoming events at the venue (public)
+POST /venues              - Add a venue (protected - hosts, events:write scope)
+PUT /venues/:id           - Change a venue, upcoming events get the new location (protected - the host who added it or admins)
+DELETE /venues/:id        - Delete a venue without upcoming events (protected - the host who added it or admins)
+
+*****************************************************/
+
+func SetupVenueRoutes(venues *echo.Group, cntrlr *controllers.VenueController) {
+	venues.GET("", cntrlr.GetVenues)
+	venues.GET("/:id", cntrlr.GetVenue)
+	venues.GET("/:id/events", cntrlr.GetVenueEvents)
+
+	venues.POST("", cntrlr.CreateVenue, middleware.JWTMiddleware(), middleware.RequireScope(utils.ScopeEventsWrite))
+	venues.PUT("/:id", cntrlr.UpdateVenue, middleware.JWTMiddleware(), middleware.RequireScope(utils.ScopeEventsWrite))
+	venues.DELETE("/:id", cntrlr.DeleteVenue, middleware.JWTMiddleware(), middleware.RequireScope(utils.ScopeEventsWrite))
+}
@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"event-horizon/controllers"
+	"event-horizon/middleware"
+	"event-horizon/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+/** *********************  HOST VERIFICATION ROUTES   ********************
+
+POST /hosts/me/verification        - Submit an identity document or payout account for review, needed to sell paid tickets (protected - hosts)
+GET /hosts/me/verification         - The host's newest submission and its review status (protected - hosts)
+
+*****************************************************/
+
+func SetupVerificationRoutes(grp *echo.Group, cntrlr *controllers.VerificationController) {
+	grp.POST("/me/verification", cntrlr.SubmitVerification, middleware.JWTMiddleware(), middleware.RequireScope(utils.ScopeEventsWrite))
+	grp.GET("/me/verification", cntrlr.GetVerification, middleware.JWTMiddleware(), middleware.RequireScope(utils.ScopeEventsWrite))
+}
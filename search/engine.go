@@ -0,0 +1,118 @@
+package search
+
+import (
+	"context"
+	"event-horizon/models"
+	"event-horizon/reporting"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+/** *********************  EVENT SEARCH   ********************
+
+GET /events/search goes through an Engine:
+
+1. MeilisearchEngine - typo-tolerant full-text search with facets, when MEILISEARCH_URL is set
+2. MongoEngine       - case-insensitive substring search in MongoDB, the default, fine for small catalogs
+
+Events are (re)indexed by the Indexer in the background whenever they are
+created, changed, published or deleted, so a slow search engine never holds
+up a write. `go run ./cmd/reindex` fills a new (or broken) index from the
+database. An index can lag behind for a moment, so hits are always checked
+against the database before they are shown.
+
+Elasticsearch (or anything else) only needs another Engine.
+
+ **************************************/
+
+// Engine indexes and searches the public events
+type Engine interface {
+	// Name is reported in search results
+	Name() string
+	// Index adds or replaces events in the index
+	Index(ctx context.Context, events ...models.Event) error
+	// Remove takes events out of the index
+	Remove(ctx context.Context, ids ...bson.ObjectID) error
+	// Search returns a page of hits with the facets of every match
+	Search(ctx context.Context, query models.EventSearchQuery) (*models.EventSearchResult, error)
+}
+
+// Searchable reports whether an event belongs in the search index (what the public listing shows)
+func Searchable(event *models.Event) bool {
+	return event.IsPublished() && !event.HostSuspended
+}
+
+// indexTimeout is how long the background worker gives the engine for one change
+const indexTimeout = 10 * time.Second
+
+// indexJob is one change for the index, remove when event is nil
+type indexJob struct {
+	event *models.Event
+	id    bson.ObjectID
+}
+
+// Indexer applies event changes to the search index in the background
+type Indexer struct {
+	engine Engine
+	queue  chan indexJob
+}
+
+// StartIndexer starts the background worker that keeps the engine's index up to date
+func StartIndexer(engine Engine, buffer int) *Indexer {
+	indexer := &Indexer{
+		engine: engine,
+		queue:  make(chan indexJob, buffer),
+	}
+
+	//! RUN IN CONCURRENT GO ROUTINE
+	go func() {
+		for job := range indexer.queue {
+			indexer.apply(job)
+		}
+	}()
+
+	return indexer
+}
+
+// apply runs one index job, a panic is reported and the worker goes on with the next job
+func (i *Indexer) apply(job indexJob) {
+	defer reporting.Recover("search indexer")
+
+	ctx, cancel := context.WithTimeout(context.Background(), indexTimeout)
+	defer cancel()
+
+	var err error
+	if job.event != nil {
+		err = i.engine.Index(ctx, *job.event)
+	} else {
+		err = i.engine.Remove(ctx, job.id)
+	}
+	if err != nil {
+		log.Printf("Error updating the search index for event %s: %v", job.id.Hex(), err)
+	}
+}
+
+// Update indexes the event, or takes it out of the index if it is no longer public
+func (i *Indexer) Update(event models.Event) {
+	if !Searchable(&event) {
+		i.Remove(event.ID)
+		return
+	}
+	i.enqueue(indexJob{event: &event, id: event.ID})
+}
+
+// Remove takes a deleted event out of the index
+func (i *Indexer) Remove(eventID bson.ObjectID) {
+	i.enqueue(indexJob{id: eventID})
+}
+
+func (i *Indexer) enqueue(job indexJob) {
+	select {
+	case i.queue <- job:
+	default:
+		//! Never block the request if the queue is full, go run ./cmd/reindex catches up
+		log.Printf("Search index queue full, dropping the change of event %s", job.id.Hex())
+	}
+}
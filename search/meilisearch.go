@@ -0,0 +1,213 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"event-horizon/models"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// MeilisearchEngine keeps the public events in a Meilisearch index (spoken to over its REST API)
+type MeilisearchEngine struct {
+	baseURL string
+	apiKey  string
+	index   string
+	client  *http.Client
+}
+
+// NewMeilisearchEngine creates an engine for the index on the Meilisearch server at baseURL
+func NewMeilisearchEngine(baseURL, apiKey, index string) *MeilisearchEngine {
+	return &MeilisearchEngine{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		apiKey:  apiKey,
+		index:   index,
+		client:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Name is reported in search results
+func (e *MeilisearchEngine) Name() string {
+	return "meilisearch"
+}
+
+// meiliDocument is what is stored in the index, the hit plus the fields search filters and facets on
+type meiliDocument struct {
+	models.EventSearchHit
+	Description string `json:"description"`
+	StartTS     int64  `json:"start_ts"` //? Unix seconds, Meilisearch filters numbers, not dates
+	Month       string `json:"month"`    //? YYYY-MM of start_time in UTC
+	PriceRange  string `json:"price_range"`
+}
+
+// newMeiliDocument builds the index document of an event
+func newMeiliDocument(event *models.Event) meiliDocument {
+	minPrice := 0.0
+	for i, ticket := range event.Tickets {
+		if i == 0 || ticket.Price < minPrice {
+			minPrice = ticket.Price
+		}
+	}
+
+	return meiliDocument{
+		EventSearchHit: models.EventSearchHit{
+			ID:           event.ID.Hex(),
+			Name:         event.Name,
+			CategoryName: event.CategoryName,
+			Tags:         event.Tags,
+			Location:     event.Location,
+			EventType:    event.EventType,
+			ImageURL:     event.ImageURL,
+			StartTime:    event.StartTime,
+			EndTime:      event.EndTime,
+			Timezone:     event.Timezone,
+			MinPrice:     minPrice,
+			Currency:     event.Currency,
+		},
+		Description: event.Description,
+		StartTS:     event.StartTime.Unix(),
+		Month:       event.StartTime.UTC().Format("2006-01"),
+		PriceRange:  models.PriceRangeOf(minPrice),
+	}
+}
+
+// request sends a JSON request to Meilisearch and decodes the answer into out (if not nil)
+func (e *MeilisearchEngine) request(ctx context.Context, method, path string, body, out interface{}) error {
+	var payload io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		payload = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, e.baseURL+path, payload)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if e.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+e.apiKey)
+	}
+
+	res, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return fmt.Errorf("meilisearch %s %s: %s: %s", method, path, res.Status, message)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(res.Body).Decode(out)
+}
+
+// indexPath is the path of the index (or something in it)
+func (e *MeilisearchEngine) indexPath(suffix string) string {
+	return "/indexes/" + url.PathEscape(e.index) + suffix
+}
+
+// EnsureIndex creates the index if needed and tells Meilisearch what to search, filter, facet and sort on
+func (e *MeilisearchEngine) EnsureIndex(ctx context.Context) error {
+	settings := map[string]interface{}{
+		"searchableAttributes": []string{"name", "tags", "category_name", "location", "description"},
+		"filterableAttributes": []string{"category_name", "start_ts", "min_price", "month", "price_range"},
+		"sortableAttributes":   []string{"start_ts"},
+	}
+	return e.request(ctx, http.MethodPatch, e.indexPath("/settings"), settings, nil)
+}
+
+// Index adds or replaces events in the index (Meilisearch applies it asynchronously)
+func (e *MeilisearchEngine) Index(ctx context.Context, events ...models.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	documents := make([]meiliDocument, 0, len(events))
+	for i := range events {
+		documents = append(documents, newMeiliDocument(&events[i]))
+	}
+	return e.request(ctx, http.MethodPost, e.indexPath("/documents?primaryKey=id"), documents, nil)
+}
+
+// Remove takes events out of the index
+func (e *MeilisearchEngine) Remove(ctx context.Context, ids ...bson.ObjectID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	hexIDs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		hexIDs = append(hexIDs, id.Hex())
+	}
+	return e.request(ctx, http.MethodPost, e.indexPath("/documents/delete-batch"), hexIDs, nil)
+}
+
+// Search runs the query on Meilisearch
+func (e *MeilisearchEngine) Search(ctx context.Context, query models.EventSearchQuery) (*models.EventSearchResult, error) {
+	var filters []string
+	if query.Category != "" {
+		filters = append(filters, "category_name = "+strconv.Quote(query.Category))
+	}
+	if !query.From.IsZero() {
+		filters = append(filters, "start_ts >= "+strconv.FormatInt(query.From.Unix(), 10))
+	}
+	if !query.To.IsZero() {
+		filters = append(filters, "start_ts < "+strconv.FormatInt(query.To.Unix(), 10))
+	}
+	if query.MinPrice != nil {
+		filters = append(filters, "min_price >= "+strconv.FormatFloat(*query.MinPrice, 'f', -1, 64))
+	}
+	if query.MaxPrice != nil {
+		filters = append(filters, "min_price <= "+strconv.FormatFloat(*query.MaxPrice, 'f', -1, 64))
+	}
+
+	body := map[string]interface{}{
+		"q":      query.Text,
+		"filter": filters, //? Every entry must match
+		"facets": []string{"category_name", "month", "price_range"},
+		"limit":  query.Limit,
+		"offset": query.Offset,
+	}
+	//? Without search words the best match is the soonest event
+	if query.Text == "" {
+		body["sort"] = []string{"start_ts:asc"}
+	}
+
+	var response struct {
+		Hits               []models.EventSearchHit   `json:"hits"`
+		EstimatedTotalHits int64                     `json:"estimatedTotalHits"`
+		FacetDistribution  map[string]map[string]int `json:"facetDistribution"`
+	}
+	if err := e.request(ctx, http.MethodPost, e.indexPath("/search"), body, &response); err != nil {
+		return nil, err
+	}
+
+	result := &models.EventSearchResult{
+		Hits:  response.Hits,
+		Total: response.EstimatedTotalHits,
+		Facets: models.EventSearchFacets{
+			Categories:  response.FacetDistribution["category_name"],
+			Months:      response.FacetDistribution["month"],
+			PriceRanges: response.FacetDistribution["price_range"],
+		},
+		Engine: e.Name(),
+	}
+	if result.Hits == nil {
+		result.Hits = []models.EventSearchHit{}
+	}
+	return result, nil
+}
@@ -0,0 +1,44 @@
+package search
+
+import (
+	"context"
+	"event-horizon/models"
+	"event-horizon/store"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// MongoEngine searches the events collection directly, there is no index to keep up to date
+type MongoEngine struct {
+	events store.EventRepository
+}
+
+// NewMongoEngine creates the default engine
+func NewMongoEngine(events store.EventRepository) *MongoEngine {
+	return &MongoEngine{events: events}
+}
+
+// Name is reported in search results
+func (e *MongoEngine) Name() string {
+	return "mongodb"
+}
+
+// Index does nothing, the collection is the index
+func (e *MongoEngine) Index(ctx context.Context, events ...models.Event) error {
+	return nil
+}
+
+// Remove does nothing, the collection is the index
+func (e *MongoEngine) Remove(ctx context.Context, ids ...bson.ObjectID) error {
+	return nil
+}
+
+// Search runs the search on MongoDB
+func (e *MongoEngine) Search(ctx context.Context, query models.EventSearchQuery) (*models.EventSearchResult, error) {
+	result, err := e.events.SearchEvents(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	result.Engine = e.Name()
+	return result, nil
+}
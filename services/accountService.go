@@ -0,0 +1,164 @@
+package services
+
+import (
+	"context"
+	"event-horizon/models"
+	"event-horizon/store"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+//! THIS FILE HOLDS THE RULES OF A USER'S OWN DATA (GDPR EXPORT AND ACCOUNT DELETION)
+
+/******************************* NOTE **************************************
+
+I DID THE FOLLOWING THINGS IN THIS FILE:
+
+1. Export collects everything kept about the user (profile, bookings, comments, follows, sessions, notifications), the password hash left out.
+
+2. Delete is a SOFT DELETE: the user document stays so the bookings pointing to it stay valid, every personal field is removed.
+
+3. Bookings of a deleted user keep their amounts and ticket types, hosts' revenue and sales reports don't change, only the attendee names and emails go.
+
+4. A user can't delete their account while they have upcoming bookings or host upcoming events, they cancel those first.
+
+5. Comments keep their text under "Deleted user", follows and notifications are deleted and every session is revoked.
+
+6. The export includes every acceptance of the terms and privacy policy, deleting the account keeps them as the record of the consent.
+
+7. The export includes a host's verification submissions, deleting the account deletes them (legal name, document, payout account).
+
+********************************* NOTE ************************************/
+
+// AccountService holds the rules of exporting and deleting a user's own data
+type AccountService struct {
+	users         store.UserRepository
+	sessions      store.SessionRepository
+	bookings      store.BookingRepository
+	events        store.EventRepository
+	comments      store.CommentRepository
+	follows       store.FollowRepository
+	notifications store.NotificationRepository
+	consents      store.ConsentRepository
+	verifications store.HostVerificationRepository
+}
+
+// NewAccountService creates a new AccountService
+func NewAccountService(users store.UserRepository, sessions store.SessionRepository, bookings store.BookingRepository, events store.EventRepository, comments store.CommentRepository, follows store.FollowRepository, notifications store.NotificationRepository, consents store.ConsentRepository, verifications store.HostVerificationRepository) *AccountService {
+	return &AccountService{
+		users:         users,
+		sessions:      sessions,
+		bookings:      bookings,
+		events:        events,
+		comments:      comments,
+		follows:       follows,
+		notifications: notifications,
+		consents:      consents,
+		verifications: verifications,
+	}
+}
+
+// activeUser loads a user that hasn't deleted their account
+func (s *AccountService) activeUser(ctx context.Context, userID bson.ObjectID) (*models.User, error) {
+	user, err := s.users.GetUserByID(ctx, userID)
+	if err != nil || user.IsDeleted() {
+		return nil, newError(KindNotFound, "User not found")
+	}
+	return user, nil
+}
+
+// ! Export returns everything kept about the user
+func (s *AccountService) Export(ctx context.Context, userID bson.ObjectID) (*models.AccountExport, error) {
+	user, err := s.activeUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	user.Password = ""
+
+	export := &models.AccountExport{ExportedAt: time.Now(), Profile: user}
+
+	if export.Bookings, err = s.bookings.GetBookingsByUserID(ctx, userID); err != nil {
+		return nil, wrapError(KindInternal, "Failed to export bookings", err)
+	}
+	if export.Comments, err = s.comments.GetCommentsByAuthor(ctx, userID); err != nil {
+		return nil, wrapError(KindInternal, "Failed to export comments", err)
+	}
+	if export.Following, err = s.follows.GetFollowing(ctx, userID); err != nil {
+		return nil, wrapError(KindInternal, "Failed to export follows", err)
+	}
+	if export.Sessions, err = s.sessions.GetActiveSessionsByUserID(ctx, userID); err != nil {
+		return nil, wrapError(KindInternal, "Failed to export sessions", err)
+	}
+	if export.Notifications, err = s.notifications.GetNotificationsByUserID(ctx, userID); err != nil {
+		return nil, wrapError(KindInternal, "Failed to export notifications", err)
+	}
+	if export.Consents, err = s.consents.GetConsentsByUserID(ctx, userID); err != nil {
+		return nil, wrapError(KindInternal, "Failed to export consents", err)
+	}
+	if export.HostVerifications, err = s.verifications.GetVerificationsByHost(ctx, userID); err != nil {
+		return nil, wrapError(KindInternal, "Failed to export host verifications", err)
+	}
+
+	return export, nil
+}
+
+// ! Delete anonymizes the user's account after checking their password, nothing of it can be restored
+func (s *AccountService) Delete(ctx context.Context, userID bson.ObjectID, password string) (*models.User, error) {
+	user, err := s.activeUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.users.VerifyPassword(user.Password, password); err != nil {
+		return nil, wrapError(KindUnauthorized, "Wrong password", err)
+	}
+
+	if user.IsHost {
+		upcoming, err := s.events.CountUpcomingHostEvents(ctx, userID)
+		if err != nil {
+			return nil, wrapError(KindInternal, "Failed to check your events", err)
+		}
+		if upcoming > 0 {
+			return nil, newError(KindConflict, "You host upcoming events, delete them or wait until they are over")
+		}
+	}
+
+	booked, _, err := s.bookings.ListBookings(ctx, store.BookingFilter{
+		UserID: userID,
+		Status: "confirmed",
+		When:   store.BookingsUpcoming,
+		Limit:  1,
+	})
+	if err != nil {
+		return nil, wrapError(KindInternal, "Failed to check your bookings", err)
+	}
+	if len(booked) > 0 {
+		return nil, newError(KindConflict, "You have upcoming bookings, cancel them first")
+	}
+
+	//? The user document goes last, a failure halfway can be retried with the same password
+	if _, err := s.bookings.AnonymizeUserBookings(ctx, userID); err != nil {
+		return nil, wrapError(KindInternal, "Failed to anonymize bookings", err)
+	}
+	if _, err := s.comments.AnonymizeAuthor(ctx, userID); err != nil {
+		return nil, wrapError(KindInternal, "Failed to anonymize comments", err)
+	}
+	if _, err := s.follows.DeleteFollowsOfUser(ctx, userID); err != nil {
+		return nil, wrapError(KindInternal, "Failed to delete follows", err)
+	}
+	if _, err := s.notifications.DeleteNotificationsByUserID(ctx, userID); err != nil {
+		return nil, wrapError(KindInternal, "Failed to delete notifications", err)
+	}
+	if _, err := s.verifications.DeleteVerificationsOfHost(ctx, userID); err != nil {
+		return nil, wrapError(KindInternal, "Failed to delete host verifications", err)
+	}
+	if _, err := s.sessions.RevokeAllSessions(ctx, userID); err != nil {
+		return nil, wrapError(KindInternal, "Failed to sign out your sessions", err)
+	}
+	if err := s.users.AnonymizeUser(ctx, userID); err != nil {
+		return nil, wrapError(KindInternal, "Failed to delete account", err)
+	}
+
+	return user, nil
+}
@@ -0,0 +1,211 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"event-horizon/models"
+	"event-horizon/store"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func (s *stubUsers) GetUserByID(ctx context.Context, userID bson.ObjectID) (*models.User, error) {
+	if userID != s.user.ID {
+		return nil, errors.New("user not found")
+	}
+	return s.user, nil
+}
+
+func (s *stubUsers) AnonymizeUser(ctx context.Context, userID bson.ObjectID) error {
+	s.anonymized = append(s.anonymized, userID)
+	return nil
+}
+
+func (s *stubSessions) GetActiveSessionsByUserID(ctx context.Context, userID bson.ObjectID) ([]models.UserSession, error) {
+	return []models.UserSession{}, nil
+}
+
+func (s *stubSessions) RevokeAllSessions(ctx context.Context, userID bson.ObjectID) (int64, error) {
+	return 0, nil
+}
+
+// stubAccountBookings holds the user's past bookings, none of them upcoming
+type stubAccountBookings struct {
+	store.BookingRepository
+	bookings   []models.Booking
+	anonymized bool
+}
+
+func (s *stubAccountBookings) GetBookingsByUserID(ctx context.Context, userID bson.ObjectID) ([]models.Booking, error) {
+	return s.bookings, nil
+}
+
+func (s *stubAccountBookings) ListBookings(ctx context.Context, bookingFilter store.BookingFilter) ([]models.BookingWithDetails, string, error) {
+	return []models.BookingWithDetails{}, "", nil
+}
+
+func (s *stubAccountBookings) AnonymizeUserBookings(ctx context.Context, userID bson.ObjectID) (int64, error) {
+	s.anonymized = true
+	return int64(len(s.bookings)), nil
+}
+
+// stubHostEvents has no upcoming events
+type stubHostEvents struct {
+	store.EventRepository
+}
+
+func (s *stubHostEvents) CountUpcomingHostEvents(ctx context.Context, hostID bson.ObjectID) (int64, error) {
+	return 0, nil
+}
+
+// stubAccountData answers the comment, follow, notification and consent reads with nothing
+type stubAccountData struct {
+	store.CommentRepository
+	store.FollowRepository
+	store.NotificationRepository
+	store.ConsentRepository
+}
+
+func (s *stubAccountData) GetCommentsByAuthor(ctx context.Context, authorID bson.ObjectID) ([]models.Comment, error) {
+	return []models.Comment{}, nil
+}
+
+func (s *stubAccountData) AnonymizeAuthor(ctx context.Context, authorID bson.ObjectID) (int64, error) {
+	return 0, nil
+}
+
+func (s *stubAccountData) GetFollowing(ctx context.Context, followerID bson.ObjectID) ([]models.Follow, error) {
+	return []models.Follow{}, nil
+}
+
+func (s *stubAccountData) DeleteFollowsOfUser(ctx context.Context, userID bson.ObjectID) (int64, error) {
+	return 0, nil
+}
+
+func (s *stubAccountData) GetNotificationsByUserID(ctx context.Context, userID bson.ObjectID) ([]models.Notification, error) {
+	return []models.Notification{}, nil
+}
+
+func (s *stubAccountData) DeleteNotificationsByUserID(ctx context.Context, userID bson.ObjectID) (int64, error) {
+	return 0, nil
+}
+
+func (s *stubAccountData) GetConsentsByUserID(ctx context.Context, userID bson.ObjectID) ([]models.Consent, error) {
+	return []models.Consent{}, nil
+}
+
+// stubVerifications keeps the submissions of every host in memory
+type stubVerifications struct {
+	store.HostVerificationRepository
+	verifications []models.HostVerification
+}
+
+func (s *stubVerifications) GetVerificationsByHost(ctx context.Context, hostID bson.ObjectID) ([]models.HostVerification, error) {
+	verifications := []models.HostVerification{}
+	for _, verification := range s.verifications {
+		if verification.HostID == hostID {
+			verifications = append(verifications, verification)
+		}
+	}
+	return verifications, nil
+}
+
+func (s *stubVerifications) DeleteVerificationsOfHost(ctx context.Context, hostID bson.ObjectID) (int64, error) {
+	kept := s.verifications[:0]
+	for _, verification := range s.verifications {
+		if verification.HostID != hostID {
+			kept = append(kept, verification)
+		}
+	}
+	deleted := int64(len(s.verifications) - len(kept))
+	s.verifications = kept
+	return deleted, nil
+}
+
+// accountFixture is a verified host with a past booking, next to another host's verification
+type accountFixture struct {
+	service       *AccountService
+	host          *models.User
+	users         *stubUsers
+	bookings      *stubAccountBookings
+	verifications *stubVerifications
+}
+
+func newAccountFixture() *accountFixture {
+	host := &models.User{ID: bson.NewObjectID(), Name: "Ada", Email: testEmail, Password: testPassword, IsHost: true}
+	users := &stubUsers{user: host}
+	bookings := &stubAccountBookings{bookings: []models.Booking{{ID: bson.NewObjectID(), UserID: host.ID, Status: "confirmed"}}}
+	verifications := &stubVerifications{verifications: []models.HostVerification{
+		{ID: bson.NewObjectID(), HostID: host.ID, Kind: models.VerificationPayoutAccount, LegalName: "Ada Lovelace", PayoutAccount: "GB29NWBK60161331926819", PayoutLast4: "6819"},
+		{ID: bson.NewObjectID(), HostID: host.ID, Kind: models.VerificationDocument, LegalName: "Ada Lovelace", DocumentURL: "https://files.example.com/ada-passport.pdf"},
+		{ID: bson.NewObjectID(), HostID: bson.NewObjectID(), Kind: models.VerificationDocument, LegalName: "Grace Hopper"},
+	}}
+	data := &stubAccountData{}
+
+	service := NewAccountService(users, &stubSessions{}, bookings, &stubHostEvents{}, data, data, data, data, verifications)
+	return &accountFixture{service: service, host: host, users: users, bookings: bookings, verifications: verifications}
+}
+
+func TestExportIncludesHostVerifications(t *testing.T) {
+	fixture := newAccountFixture()
+
+	export, err := fixture.service.Export(context.Background(), fixture.host.ID)
+	if err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+	if export.Profile.Password != "" {
+		t.Fatal("the export contains the password hash")
+	}
+	if len(export.Bookings) != 1 {
+		t.Fatalf("the export has %d bookings, want 1", len(export.Bookings))
+	}
+
+	//! Only the host's own submissions, both of them
+	if len(export.HostVerifications) != 2 {
+		t.Fatalf("the export has %d host verifications, want 2", len(export.HostVerifications))
+	}
+	for _, verification := range export.HostVerifications {
+		if verification.HostID != fixture.host.ID {
+			t.Fatalf("the export has %s's verification", verification.LegalName)
+		}
+	}
+	if _, ok := export.Files()["host_verifications.json"]; !ok {
+		t.Fatal("the ZIP export has no host_verifications.json")
+	}
+}
+
+func TestDeleteRemovesHostVerifications(t *testing.T) {
+	fixture := newAccountFixture()
+	ctx := context.Background()
+
+	//? A wrong password changes nothing
+	_, err := fixture.service.Delete(ctx, fixture.host.ID, "wrong")
+	var serviceErr *Error
+	if !errors.As(err, &serviceErr) || serviceErr.Kind != KindUnauthorized {
+		t.Fatalf("Delete with a wrong password = %v, want KindUnauthorized", err)
+	}
+	if len(fixture.verifications.verifications) != 3 || fixture.bookings.anonymized || len(fixture.users.anonymized) != 0 {
+		t.Fatal("Delete with a wrong password changed data")
+	}
+
+	if _, err := fixture.service.Delete(ctx, fixture.host.ID, testPassword); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	//! The legal name, documents and payout account go, other hosts' submissions stay
+	left, _ := fixture.verifications.GetVerificationsByHost(ctx, fixture.host.ID)
+	if len(left) != 0 {
+		t.Fatalf("%d host verifications left after deleting the account", len(left))
+	}
+	if len(fixture.verifications.verifications) != 1 || fixture.verifications.verifications[0].LegalName != "Grace Hopper" {
+		t.Fatalf("another host's verification was touched: %v", fixture.verifications.verifications)
+	}
+	if !fixture.bookings.anonymized {
+		t.Fatal("the bookings were not anonymized")
+	}
+	if len(fixture.users.anonymized) != 1 || fixture.users.anonymized[0] != fixture.host.ID {
+		t.Fatalf("anonymized users %v, want the host", fixture.users.anonymized)
+	}
+}
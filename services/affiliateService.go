@@ -0,0 +1,230 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"event-horizon/dto"
+	"event-horizon/models"
+	"event-horizon/store"
+	"event-horizon/utils"
+	"fmt"
+	"math"
+	"net/mail"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+//! THIS FILE HOLDS THE RULES FOR AFFILIATES (AMBASSADORS) AND THEIR COMMISSIONS
+
+/******************************* NOTE **************************************
+
+I DID THE FOLLOWING THINGS IN THIS FILE:
+
+1. Create registers an affiliate of a host with a unique code and a commission in percent of the booking subtotal (without tax).
+
+2. RecordBooking is a booking hook: bookings whose ref is an active affiliate code of the event's host are attributed to the affiliate with the commission they earned.
+
+3. The commission is fixed when the booking is made, Update only changes it for new bookings.
+
+4. Report sums the confirmed bookings and the owed commission per affiliate and currency, cancelled bookings earn nothing.
+
+5. RecordBooking runs as a job, failing to read the event or to save the attribution returns the error so the job is retried.
+
+********************************* NOTE ************************************/
+
+const maxAffiliateNameLength = 100
+
+// AffiliateService holds the rules for affiliates
+type AffiliateService struct {
+	affiliates store.AffiliateRepository
+	users      store.UserRepository
+	events     store.EventRepository
+	bookings   store.BookingRepository
+}
+
+// NewAffiliateService creates a new AffiliateService
+func NewAffiliateService(affiliates store.AffiliateRepository, users store.UserRepository, events store.EventRepository, bookings store.BookingRepository) *AffiliateService {
+	return &AffiliateService{
+		affiliates: affiliates,
+		users:      users,
+		events:     events,
+		bookings:   bookings,
+	}
+}
+
+// requireHost checks the user is a host
+func (s *AffiliateService) requireHost(ctx context.Context, userID bson.ObjectID) error {
+	user, err := s.users.GetUserByID(ctx, userID)
+	if err != nil {
+		return newError(KindNotFound, "User not found")
+	}
+	if !user.IsHost {
+		return newError(KindForbidden, "Only hosts can have affiliates")
+	}
+	return nil
+}
+
+// validCommission checks a commission percent
+func validCommission(percent float64) error {
+	if percent <= 0 || percent > 100 {
+		return newError(KindInvalid, "commission_percent must be greater than 0 and at most 100")
+	}
+	return nil
+}
+
+// ! Create registers an affiliate of the host
+func (s *AffiliateService) Create(ctx context.Context, hostID bson.ObjectID, req *dto.AffiliateRequest) (*models.Affiliate, error) {
+	affiliate := &models.Affiliate{
+		HostID:            hostID,
+		Name:              strings.TrimSpace(req.Name),
+		CommissionPercent: req.CommissionPercent,
+		Active:            true,
+	}
+	if affiliate.Name == "" {
+		return nil, newError(KindInvalid, "name is required")
+	}
+	if len(affiliate.Name) > maxAffiliateNameLength {
+		return nil, newError(KindInvalid, "name can be at most 100 characters")
+	}
+	if email := strings.TrimSpace(req.Email); email != "" {
+		address, err := mail.ParseAddress(email)
+		if err != nil {
+			return nil, newError(KindInvalid, email+" is not a valid email address")
+		}
+		affiliate.Email = strings.ToLower(address.Address)
+	}
+	if err := validCommission(affiliate.CommissionPercent); err != nil {
+		return nil, err
+	}
+
+	if err := s.requireHost(ctx, hostID); err != nil {
+		return nil, err
+	}
+
+	//? Codes are random, try again on the rare clash
+	for attempt := 0; ; attempt++ {
+		affiliate.Code = utils.GenerateShareCode()
+		err := s.affiliates.CreateAffiliate(ctx, affiliate)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, store.ErrShareCodeTaken) || attempt == shareCodeAttempts-1 {
+			return nil, wrapError(KindInternal, "Failed to create the affiliate", err)
+		}
+	}
+
+	return affiliate, nil
+}
+
+// ! List returns the affiliates of the host
+func (s *AffiliateService) List(ctx context.Context, hostID bson.ObjectID) ([]models.Affiliate, error) {
+	if err := s.requireHost(ctx, hostID); err != nil {
+		return nil, err
+	}
+
+	affiliates, err := s.affiliates.GetAffiliatesByHostID(ctx, hostID)
+	if err != nil {
+		return nil, wrapError(KindInternal, "Failed to read the affiliates", err)
+	}
+	return affiliates, nil
+}
+
+// ! Update changes the commission or turns one of the host's affiliates on / off
+func (s *AffiliateService) Update(ctx context.Context, hostID bson.ObjectID, affiliateID string, req *dto.UpdateAffiliateRequest) (*models.Affiliate, error) {
+	id, err := bson.ObjectIDFromHex(affiliateID)
+	if err != nil {
+		return nil, newError(KindInvalid, "Invalid affiliate ID")
+	}
+
+	fields := bson.M{}
+	if req.CommissionPercent != nil {
+		if err := validCommission(*req.CommissionPercent); err != nil {
+			return nil, err
+		}
+		fields["commission_percent"] = *req.CommissionPercent
+	}
+	if req.Active != nil {
+		fields["active"] = *req.Active
+	}
+	if len(fields) == 0 {
+		return nil, newError(KindInvalid, "Send commission_percent or active")
+	}
+
+	affiliate, err := s.affiliates.UpdateAffiliate(ctx, hostID, id, fields)
+	if err != nil {
+		return nil, newError(KindNotFound, "Affiliate not found")
+	}
+	return affiliate, nil
+}
+
+// ! Report returns the sales and owed commission of every affiliate of the host for bookings made in [from, to), zero times leave the range open
+func (s *AffiliateService) Report(ctx context.Context, hostID bson.ObjectID, from, to time.Time) ([]models.AffiliateReport, error) {
+	if !from.IsZero() && !to.IsZero() && !from.Before(to) {
+		return nil, newError(KindInvalid, "from must be before to")
+	}
+
+	affiliates, err := s.List(ctx, hostID)
+	if err != nil {
+		return nil, err
+	}
+
+	reports := make([]models.AffiliateReport, 0, len(affiliates))
+	if len(affiliates) == 0 {
+		return reports, nil
+	}
+
+	ids := make([]bson.ObjectID, 0, len(affiliates))
+	for _, affiliate := range affiliates {
+		ids = append(ids, affiliate.ID)
+	}
+
+	sales, err := s.bookings.GetAffiliateSales(ctx, ids, from, to)
+	if err != nil {
+		return nil, wrapError(KindInternal, "Failed to sum the affiliate sales", err)
+	}
+
+	byAffiliate := make(map[bson.ObjectID][]models.AffiliateSales, len(affiliates))
+	for _, sale := range sales {
+		sale.Sales = models.FormatAmount(sale.SalesMinor, sale.Currency)
+		sale.Commission = models.FormatAmount(sale.CommissionMinor, sale.Currency)
+		byAffiliate[sale.AffiliateID] = append(byAffiliate[sale.AffiliateID], sale)
+	}
+
+	for _, affiliate := range affiliates {
+		affiliateSales := byAffiliate[affiliate.ID]
+		if affiliateSales == nil {
+			affiliateSales = []models.AffiliateSales{}
+		}
+		reports = append(reports, models.AffiliateReport{Affiliate: affiliate, Sales: affiliateSales})
+	}
+
+	return reports, nil
+}
+
+// ! RecordBooking attributes a booking made with an affiliate code to the affiliate
+func (s *AffiliateService) RecordBooking(ctx context.Context, booking models.Booking) error {
+	if booking.ReferralCode == "" {
+		return nil
+	}
+
+	affiliate, err := s.affiliates.GetAffiliateByCode(ctx, booking.ReferralCode)
+	if err != nil || !affiliate.Active {
+		return nil //? A share link code, or an affiliate that was turned off
+	}
+
+	event, err := s.events.GetEventByID(ctx, booking.EventID.Hex())
+	if err != nil {
+		return fmt.Errorf("reading event %s: %w", booking.EventID.Hex(), err)
+	}
+	if event.HostID != affiliate.HostID {
+		return nil //! Affiliates only earn on the events of the host who registered them
+	}
+
+	commission := int64(math.Round(float64(booking.SubtotalMinor) * affiliate.CommissionPercent / 100))
+	if err := s.bookings.SetAffiliate(ctx, booking.ID, affiliate.ID, commission); err != nil {
+		return fmt.Errorf("attributing booking %s to affiliate %s: %w", booking.ID.Hex(), affiliate.ID.Hex(), err)
+	}
+	return nil
+}
@@ -0,0 +1,271 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"event-horizon/models"
+	"event-horizon/store"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+//! THIS FILE BUILDS THE SALES ANALYTICS HOSTS SEE FOR THEIR EVENTS
+
+/******************************* NOTE **************************************
+
+I DID THE FOLLOWING THINGS IN THIS FILE:
+
+1. EventAnalytics combines the booking aggregations (per ticket type, per day) with the event's ticket totals, for the host and co-hosts only.
+
+2. Cancellations are counted from the audit log, it also has the bookings cancelled back when cancelling deleted them.
+
+3. There is no check-in yet, so the report has no check-in rate.
+
+4. ExportBookings checks the caller is a host and the date range is sane, then streams the rows of every event they manage.
+
+5. Revenue is summed in MINOR UNITS of the event currency and converted once at the end.
+
+6. Now that attendees check in at the door, the report has the check-in rate (checked in / attendees).
+
+7. PastEvents lists the ended events of a host (and co-host) with their final sales and attendance, from the bookings while the event is kept and from the ARCHIVE once the cleanup deleted it.
+
+********************************* NOTE ************************************/
+
+// AnalyticsService builds sales reports for hosts
+type AnalyticsService struct {
+	users    store.UserRepository
+	events   store.EventRepository
+	bookings store.BookingRepository
+	audit    store.AuditRepository
+}
+
+// NewAnalyticsService creates a new AnalyticsService
+func NewAnalyticsService(users store.UserRepository, events store.EventRepository, bookings store.BookingRepository, audit store.AuditRepository) *AnalyticsService {
+	return &AnalyticsService{
+		users:    users,
+		events:   events,
+		bookings: bookings,
+		audit:    audit,
+	}
+}
+
+// ! EventAnalytics returns the sales report of an event to its host and co-hosts
+func (s *AnalyticsService) EventAnalytics(ctx context.Context, userID bson.ObjectID, eventID string) (*models.EventAnalytics, error) {
+	event, err := s.events.GetEventByID(ctx, eventID)
+	if err != nil {
+		return nil, newError(KindNotFound, "Event not found")
+	}
+
+	if !event.IsManagedBy(userID) {
+		return nil, newError(KindForbidden, "Only the event host and co-hosts can view its analytics")
+	}
+
+	timezone := event.Timezone
+	if timezone == "" {
+		timezone = "UTC"
+	}
+
+	sales, err := s.bookings.GetTicketSales(ctx, event.ID)
+	if err != nil {
+		return nil, wrapError(KindInternal, "Failed to compute ticket sales", err)
+	}
+
+	days, err := s.bookings.GetDailySales(ctx, event.ID, timezone)
+	if err != nil {
+		return nil, wrapError(KindInternal, "Failed to compute daily sales", err)
+	}
+
+	cancelled, err := s.audit.CountCancelledBookings(ctx, event.ID)
+	if err != nil {
+		return nil, wrapError(KindInternal, "Failed to count cancellations", err)
+	}
+
+	checkIns, err := s.bookings.GetCheckInCounts(ctx, event.ID)
+	if err != nil {
+		return nil, wrapError(KindInternal, "Failed to count check-ins", err)
+	}
+
+	currency := event.Currency
+	if currency == "" {
+		currency = models.DefaultCurrency
+	}
+
+	report := &models.EventAnalytics{
+		EventID:           event.ID,
+		Timezone:          timezone,
+		Currency:          currency,
+		CancelledBookings: int(cancelled),
+		DailySales:        days,
+	}
+
+	//? Every ticket type of the event is listed, also the ones nothing was sold of yet
+	soldByType := make(map[string]models.TicketTypeSales, len(sales))
+	for _, sale := range sales {
+		soldByType[sale.TicketType] = sale
+	}
+	for _, ticket := range event.Tickets {
+		sale := soldByType[ticket.Type]
+		sale.TicketType = ticket.Type
+		sale.Total = ticket.TotalQuantity
+		sale.Revenue = models.FromMinorUnits(sale.RevenueMinor, currency)
+		delete(soldByType, ticket.Type)
+
+		report.ByTicketType = append(report.ByTicketType, sale)
+		report.TicketsTotal += ticket.TotalQuantity
+	}
+	//? Ticket types removed after they sold still count
+	for _, sale := range sales {
+		if _, removed := soldByType[sale.TicketType]; removed {
+			sale.Revenue = models.FromMinorUnits(sale.RevenueMinor, currency)
+			report.ByTicketType = append(report.ByTicketType, sale)
+		}
+	}
+
+	for _, sale := range report.ByTicketType {
+		report.TicketsSold += sale.Sold
+		report.RevenueMinor += sale.RevenueMinor
+		report.ConfirmedBookings += sale.Bookings
+	}
+	report.Revenue = models.FromMinorUnits(report.RevenueMinor, currency)
+
+	for i := range report.DailySales {
+		report.DailySales[i].Revenue = models.FromMinorUnits(report.DailySales[i].RevenueMinor, currency)
+	}
+	if report.DailySales == nil {
+		report.DailySales = []models.DailySales{}
+	}
+
+	attendees := 0
+	for _, count := range checkIns {
+		attendees += count.Attendees
+		report.CheckedIn += count.CheckedIn
+	}
+
+	report.SellThroughRate = ratio(report.TicketsSold, report.TicketsTotal)
+	report.CheckInRate = ratio(report.CheckedIn, attendees)
+	report.CancellationRate = ratio(report.CancelledBookings, report.ConfirmedBookings+report.CancelledBookings)
+
+	return report, nil
+}
+
+// ! ExportBookings calls fn for every booking of the host's events booked in [from, to), zero times leave the range open
+func (s *AnalyticsService) ExportBookings(ctx context.Context, userID bson.ObjectID, from, to time.Time, fn func(models.BookingExportRow) error) error {
+	if !from.IsZero() && !to.IsZero() && !from.Before(to) {
+		return newError(KindInvalid, "from must be before to")
+	}
+
+	user, err := s.users.GetUserByID(ctx, userID)
+	if err != nil {
+		return newError(KindNotFound, "User not found")
+	}
+
+	if !user.IsHost {
+		return newError(KindForbidden, "Only hosts can export bookings")
+	}
+
+	filter := store.BookingExportFilter{HostID: userID, From: from, To: to}
+	if err := s.bookings.ExportHostBookings(ctx, filter, fn); err != nil {
+		return wrapError(KindInternal, "Failed to export bookings", err)
+	}
+
+	return nil
+}
+
+const (
+	defaultPastEvents = 20
+	maxPastEvents     = 50
+)
+
+// ! PastEvents returns one page of the ended events the user hosts or co-hosts with their final sales and attendance, newest
+// first. Events still kept are counted from their bookings, older ones come from the archive the cleanup writes
+func (s *AnalyticsService) PastEvents(ctx context.Context, userID bson.ObjectID, cursor string, limit int) ([]models.ArchivedEvent, string, error) {
+	user, err := s.users.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, "", newError(KindNotFound, "User not found")
+	}
+	if !user.IsHost {
+		return nil, "", newError(KindForbidden, "Only hosts have past events")
+	}
+
+	query := models.PastEventsQuery{UserID: userID, Limit: limit}
+	if query.Limit <= 0 || query.Limit > maxPastEvents {
+		query.Limit = defaultPastEvents
+	}
+	if cursor != "" {
+		if query.BeforeEnd, query.BeforeID, err = parsePastCursor(cursor); err != nil {
+			return nil, "", newError(KindInvalid, "Invalid cursor")
+		}
+	}
+
+	ended, err := s.events.GetEndedEvents(ctx, query)
+	if err != nil {
+		return nil, "", wrapError(KindInternal, "Failed to get your past events", err)
+	}
+	archived, err := s.events.GetArchivedEvents(ctx, query)
+	if err != nil {
+		return nil, "", wrapError(KindInternal, "Failed to get your past events", err)
+	}
+
+	//? An event is either still kept or archived, the cleanup moves it in one transaction
+	for i := range ended {
+		sales, err := s.bookings.GetTicketSales(ctx, ended[i].ID)
+		if err != nil {
+			return nil, "", wrapError(KindInternal, "Failed to compute ticket sales", err)
+		}
+		checkIns, err := s.bookings.GetCheckInCounts(ctx, ended[i].ID)
+		if err != nil {
+			return nil, "", wrapError(KindInternal, "Failed to count check-ins", err)
+		}
+		archived = append(archived, *models.NewArchivedEvent(&ended[i], sales, checkIns))
+	}
+
+	sort.Slice(archived, func(i, j int) bool {
+		if !archived[i].EndTime.Equal(archived[j].EndTime) {
+			return archived[i].EndTime.After(archived[j].EndTime)
+		}
+		return archived[i].ID.Hex() > archived[j].ID.Hex()
+	})
+
+	next := ""
+	if len(archived) > query.Limit {
+		archived = archived[:query.Limit]
+		last := archived[len(archived)-1]
+		next = fmt.Sprintf("%d_%s", last.EndTime.UnixMilli(), last.ID.Hex())
+	}
+	for i := range archived {
+		archived[i].ConvertRevenue()
+	}
+
+	return archived, next, nil
+}
+
+// parsePastCursor reads the "<end time in unix ms>_<event id>" cursor of PastEvents
+func parsePastCursor(cursor string) (time.Time, bson.ObjectID, error) {
+	millis, id, ok := strings.Cut(cursor, "_")
+	if !ok {
+		return time.Time{}, bson.ObjectID{}, errors.New("missing separator")
+	}
+	endMillis, err := strconv.ParseInt(millis, 10, 64)
+	if err != nil {
+		return time.Time{}, bson.ObjectID{}, err
+	}
+	eventID, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return time.Time{}, bson.ObjectID{}, err
+	}
+	return time.UnixMilli(endMillis).UTC(), eventID, nil
+}
+
+// ratio returns part / whole rounded to 4 decimals, 0 when whole is 0
+func ratio(part, whole int) float64 {
+	if whole == 0 {
+		return 0
+	}
+	return math.Round(float64(part)/float64(whole)*10000) / 10000
+}
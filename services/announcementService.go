@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"event-horizon/dto"
+	"event-horizon/models"
+	"event-horizon/store"
+	"event-horizon/utils"
+	"strings"
+	"unicode/utf8"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+//! THIS FILE HOLDS THE RULES FOR EVENT ANNOUNCEMENTS (UPDATES FROM THE HOST TO THE ATTENDEES)
+
+/******************************* NOTE **************************************
+
+I DID THE FOLLOWING THINGS IN THIS FILE:
+
+1. Announce lets the event host and co-hosts send an update ("venue changed", "doors open 7pm") to every confirmed attendee as an in-app notification.
+
+2. Every announcement is stored, List returns the history of an event (newest first) for the event page.
+
+3. Guest bookings have no account, their attendees are skipped like in HostService.
+
+********************************* NOTE ************************************/
+
+const (
+	maxAnnouncementLength    = 500
+	defaultAnnouncementLimit = 20
+	maxAnnouncementListLimit = 100
+)
+
+// AnnouncementService holds the rules for event announcements
+type AnnouncementService struct {
+	announcements store.AnnouncementRepository
+	events        store.EventRepository
+	bookings      store.BookingRepository
+	notifier      *utils.NotificationWorker
+}
+
+// NewAnnouncementService creates a new AnnouncementService
+func NewAnnouncementService(announcements store.AnnouncementRepository, events store.EventRepository, bookings store.BookingRepository, notifier *utils.NotificationWorker) *AnnouncementService {
+	return &AnnouncementService{
+		announcements: announcements,
+		events:        events,
+		bookings:      bookings,
+		notifier:      notifier,
+	}
+}
+
+// ! Announce stores the announcement and notifies every confirmed attendee of the event
+func (s *AnnouncementService) Announce(ctx context.Context, userID bson.ObjectID, eventID string, req *dto.AnnouncementRequest) (*models.Announcement, error) {
+	message := strings.TrimSpace(req.Message)
+	if message == "" {
+		return nil, newError(KindInvalid, "message is required")
+	}
+	if utf8.RuneCountInString(message) > maxAnnouncementLength {
+		return nil, newError(KindInvalid, "message can be at most 500 characters")
+	}
+
+	event, err := s.events.GetEventByID(ctx, eventID)
+	if err != nil {
+		return nil, newError(KindNotFound, "Event not found")
+	}
+
+	if !event.IsManagedBy(userID) {
+		return nil, newError(KindForbidden, "Only the event host and co-hosts can send announcements")
+	}
+
+	attendees, err := confirmedAttendeeIDs(ctx, s.bookings, event.ID)
+	if err != nil {
+		return nil, wrapError(KindInternal, "Failed to read the attendees", err)
+	}
+
+	announcement := &models.Announcement{
+		EventID:    event.ID,
+		AuthorID:   userID,
+		Message:    message,
+		Recipients: len(attendees),
+	}
+	if err := s.announcements.CreateAnnouncement(ctx, announcement); err != nil {
+		return nil, wrapError(KindInternal, "Failed to save the announcement", err)
+	}
+
+	//? Stored first, so the attendees find the announcement on the event page when the notification arrives
+	s.notifier.NotifyUsers(attendees, models.NotificationEventAnnouncement, event.Name+": "+message, event.ID)
+
+	return announcement, nil
+}
+
+// ! List returns the latest announcements of an event, newest first
+func (s *AnnouncementService) List(ctx context.Context, eventID string, limit int) ([]models.Announcement, error) {
+	if limit == 0 {
+		limit = defaultAnnouncementLimit
+	}
+	if limit < 0 || limit > maxAnnouncementListLimit {
+		return nil, newError(KindInvalid, "limit must be between 1 and 100")
+	}
+
+	event, err := s.events.GetEventByID(ctx, eventID)
+	if err != nil || event.HostSuspended {
+		return nil, newError(KindNotFound, "Event not found") //? Events of suspended hosts are gone for the public
+	}
+
+	announcements, err := s.announcements.GetAnnouncementsByEventID(ctx, event.ID, limit)
+	if err != nil {
+		return nil, wrapError(KindInternal, "Failed to read the announcements", err)
+	}
+
+	return announcements, nil
+}
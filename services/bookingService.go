@@ -0,0 +1,750 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"event-horizon/dto"
+	"event-horizon/eventbus"
+	"event-horizon/models"
+	"event-horizon/reporting"
+	"event-horizon/store"
+	"event-horizon/utils"
+	"fmt"
+	"log"
+	"net/mail"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+//! THIS FILE HOLDS THE BUSINESS RULES OF BOOKINGS (WHAT A VALID BOOKING IS AND WHO MAY SEE OR CANCEL IT)
+
+/******************************* NOTE **************************************
+
+I DID THE FOLLOWING THINGS IN THIS FILE:
+
+1. Moved quantity and ID validation, transaction IDs and the booking lock error out of the BookingController into BookingService.
+
+2. CancelBooking checks that the booking belongs to the user, GetEventBookings that the user manages the event.
+
+3. Bookings for events of suspended hosts are refused with 403.
+
+4. CreateGuestBooking books for a GUEST (no account) through the same checks as CreateBooking.
+
+5. Every ticket of a booking gets an ATTENDEE with its own check-in code, UpdateAttendees renames them until the event starts.
+
+6. Created and cancelled bookings are published on the EVENT BUS for other services.
+
+7. AfterBooking registers HOOKS that run in the background once a booking is committed (capacity alerts ...).
+
+8. Ticket types can only be booked inside their SALE WINDOW.
+
+9. AfterCancel registers hooks that run once a booking was cancelled (cancellation email ...).
+
+10. The share code (?ref=) the booking came from is kept on it for the referral report, malformed codes are dropped.
+
+11. ForceCancelBooking lets support cancel ANY booking (disputes), a reason is required and the owner check is skipped.
+
+12. ChangeTicketType moves a booking to another ticket type before the event starts, the price difference is kept on the booking.
+
+13. Bookings of a RESCHEDULED event are kept with Reconfirm, RefundUnconfirmed cancels (refunds) the ones nobody reconfirmed in time.
+
+14. With an OUTBOX the hooks no longer start after the request: DeliverOutbox runs them for the messages the booking transactions wrote, so a crash right after the commit loses nothing (at least once, a hook can run twice).
+
+15. A panic in a background hook is reported (reporting.Recover) instead of killing the process.
+
+16. ANTI-SCALPING LIMITS: at most MaxPerRequest tickets per booking, MaxPerEvent tickets per buyer (user or guest) and event,
+    and PerMinute bookings per minute per buyer and per IP (429), see BookingLimits.
+    The per-buyer cap is checked by the store inside the booking transaction, parallel requests can't pass it together.
+
+17. With a CAPTCHA verifier a buyer over PerMinute is asked for a CAPTCHA (428) instead of being refused, bots get stuck there.
+
+18. SCANNERS of the event's organization can look up its bookings too (attendee lookup at the door).
+
+19. So can a SCANNER KEY of the event, GetEventBookings takes the Door of the check-in rules.
+
+20. Bookings record the buyer accepting the TERMS and PRIVACY POLICY (see ConsentService), guests must always accept them.
+
+21. RefundEvent cancels and refunds every booking of an event the host CANCELLED (bulk event actions).
+
+22. Wallet lists the user's upcoming confirmed bookings for the mobile TICKET WALLET, each with its event (session times for session bookings) and one QR payload per ticket.
+
+********************************* NOTE ************************************/
+
+// BookingHook runs in the background after a booking was committed (main enqueues them as retried jobs),
+// an error makes the outbox deliver the booking again
+type BookingHook func(ctx context.Context, booking models.Booking) error
+
+// Outbox relay timings
+const (
+	outboxBatchSize  = 100
+	outboxLease      = time.Minute
+	outboxRetryDelay = 30 * time.Second
+)
+
+// BookingLimits slow down scalpers during popular on-sales, zero turns a limit off
+type BookingLimits struct {
+	MaxPerRequest int                   // tickets in one booking
+	MaxPerEvent   int                   // tickets one buyer holds for an event, cancelled bookings don't count
+	PerMinute     int                   // bookings per minute of one buyer, and of one IP
+	Velocity      store.VelocityCounter // counts the bookings per minute, required with PerMinute
+	Captcha       utils.CaptchaVerifier // optional, over PerMinute a solved CAPTCHA lets the booking through
+}
+
+// velocityWindow is the window BookingLimits.PerMinute counts in
+const velocityWindow = time.Minute
+
+// BookingService holds the business rules of bookings
+type BookingService struct {
+	bookings     store.BookingRepository
+	events       store.EventRepository
+	bus          eventbus.Publisher
+	notifier     *utils.NotificationWorker
+	outbox       store.OutboxRepository // optional, nil runs the hooks right after the request
+	consents     *ConsentService        // optional, nil doesn't ask for the terms (seed data)
+	limits       BookingLimits
+	afterBooking []BookingHook
+	afterCancel  []BookingHook
+}
+
+// NewBookingService creates a new BookingService
+func NewBookingService(bookings store.BookingRepository, events store.EventRepository, bus eventbus.Publisher, notifier *utils.NotificationWorker, outbox store.OutboxRepository, consents *ConsentService, limits BookingLimits) *BookingService {
+	return &BookingService{
+		bookings: bookings,
+		events:   events,
+		bus:      bus,
+		notifier: notifier,
+		outbox:   outbox,
+		consents: consents,
+		limits:   limits,
+	}
+}
+
+// AfterBooking adds a hook that runs after every committed booking (user and guest), register hooks before serving requests
+func (s *BookingService) AfterBooking(hook BookingHook) {
+	s.afterBooking = append(s.afterBooking, hook)
+}
+
+// AfterCancel adds a hook that runs after every booking the user cancelled, register hooks before serving requests
+func (s *BookingService) AfterCancel(hook BookingHook) {
+	s.afterCancel = append(s.afterCancel, hook)
+}
+
+// runBookingHooks starts the hooks for a booking without an outbox, they never hold up or fail the request
+func (s *BookingService) runBookingHooks(hooks []BookingHook, booking models.Booking) {
+	if s.outbox != nil {
+		return //? DeliverOutbox runs them once the outbox message is read
+	}
+	for _, hook := range hooks {
+		go func(hook BookingHook) {
+			defer reporting.Recover("booking hook")
+			if err := hook(context.Background(), booking); err != nil {
+				log.Printf("Booking hook failed for booking %s: %v", booking.ID.Hex(), err)
+			}
+		}(hook)
+	}
+}
+
+// ! DeliverOutbox runs the hooks of the pending outbox messages and returns how many were delivered
+func (s *BookingService) DeliverOutbox(ctx context.Context) (int, error) {
+	delivered := 0
+	for i := 0; i < outboxBatchSize; i++ {
+		message, err := s.outbox.ClaimOutboxMessage(ctx, outboxLease)
+		if err != nil {
+			return delivered, err
+		}
+		if message == nil {
+			return delivered, nil
+		}
+
+		if err := s.deliver(ctx, message); err != nil {
+			//? Tried again later, hooks that already ran will run again
+			log.Printf("Outbox: error delivering %s of booking %s (attempt %d): %v", message.Type, message.Booking.ID.Hex(), message.Attempts, err)
+			if err := s.outbox.FailOutboxMessage(ctx, message.ID, err.Error(), time.Now().Add(outboxRetryDelay)); err != nil {
+				return delivered, err
+			}
+			continue
+		}
+
+		if err := s.outbox.MarkOutboxDelivered(ctx, message.ID); err != nil {
+			return delivered, err
+		}
+		delivered++
+	}
+	return delivered, nil
+}
+
+// deliver runs every hook of the message's type, the errors of all hooks are returned together
+func (s *BookingService) deliver(ctx context.Context, message *models.OutboxMessage) error {
+	var hooks []BookingHook
+	switch message.Type {
+	case models.OutboxBookingCreated:
+		hooks = s.afterBooking
+	case models.OutboxBookingCancelled:
+		hooks = s.afterCancel
+	default:
+		return fmt.Errorf("unknown outbox message type %q", message.Type)
+	}
+
+	var errs []error
+	for _, hook := range hooks {
+		if err := hook(ctx, message.Booking); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// generateTransactionID generates a random transaction ID
+func generateTransactionID() string {
+	bytes := make([]byte, 16)
+	rand.Read(bytes)
+	return "TXN-" + hex.EncodeToString(bytes)
+}
+
+// ! CreateBooking books tickets for the user and returns the booking with its event
+func (s *BookingService) CreateBooking(ctx context.Context, userID bson.ObjectID, req *dto.CreateBookingRequest) (*models.Booking, *models.Event, error) {
+	return s.create(ctx, &models.Booking{UserID: userID}, req)
+}
+
+// ! CreateGuestBooking books tickets for a guest, the booking is found again by the hash of its access code
+func (s *BookingService) CreateGuestBooking(ctx context.Context, guestID bson.ObjectID, codeHash string, req *dto.CreateBookingRequest) (*models.Booking, *models.Event, error) {
+	return s.create(ctx, &models.Booking{GuestID: guestID, GuestCodeHash: codeHash}, req)
+}
+
+// create validates the request and books it for the owner already set on the booking
+func (s *BookingService) create(ctx context.Context, booking *models.Booking, req *dto.CreateBookingRequest) (*models.Booking, *models.Event, error) {
+	//? Validate quantity
+	if req.Quantity <= 0 {
+		return nil, nil, newError(KindInvalid, "Quantity must be greater than zero FROM BOOKING")
+	}
+	if s.limits.MaxPerRequest > 0 && req.Quantity > s.limits.MaxPerRequest {
+		return nil, nil, newError(KindInvalid, fmt.Sprintf("At most %d tickets can be booked at once", s.limits.MaxPerRequest))
+	}
+
+	//? Validate and convert event ID
+	eventObjID, err := bson.ObjectIDFromHex(req.EventID)
+	if err != nil {
+		return nil, nil, newError(KindInvalid, "Invalid event ID FROM BOOKING")
+	}
+
+	//? Verify event exists
+	event, err := s.events.GetEventByID(ctx, req.EventID)
+	if err != nil {
+		return nil, nil, newError(KindNotFound, "Event not found FROM BOOKING")
+	}
+
+	//? The ticket type must be on sale (presale / general sale windows)
+	if err := checkSaleWindow(event, req.TicketType, time.Now()); err != nil {
+		return nil, nil, err
+	}
+
+	//? Validate and convert the optional session ID
+	var sessionObjID bson.ObjectID
+	if req.SessionID != "" {
+		sessionObjID, err = bson.ObjectIDFromHex(req.SessionID)
+		if err != nil {
+			return nil, nil, newError(KindInvalid, "Invalid session ID FROM BOOKING")
+		}
+	}
+
+	attendees, err := buildAttendees(req.Attendees, req.Quantity)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	//? Terms and privacy policy, recorded once the booking went through
+	var accepted map[string]string
+	if s.consents != nil {
+		if accepted, err = s.consents.forBooking(ctx, booking, req.PolicyAcceptance); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	//! Anti-scalping, after the cheap checks so only real booking attempts are counted
+	if err := s.checkVelocity(ctx, booking, req.ClientIP, req.CaptchaToken); err != nil {
+		return nil, nil, err
+	}
+
+	booking.EventID = eventObjID
+	booking.SessionID = sessionObjID
+	booking.TicketType = req.TicketType
+	booking.TransactionID = generateTransactionID()
+	booking.Quantity = req.Quantity
+	booking.Attendees = attendees
+	booking.Status = "confirmed" // Auto-set
+	if code, ok := utils.NormalizeShareCode(req.Ref); ok {
+		booking.ReferralCode = code //? Never fails the booking, unknown codes count as direct bookings
+	}
+
+	// Create booking (this handles ticket availability check and price calculation)
+	if err := s.bookings.CreateBooking(ctx, booking, s.limits.MaxPerEvent); err != nil {
+		var capErr *store.BuyerCapError
+		switch {
+		case errors.Is(err, store.ErrBookingBusy):
+			return nil, nil, wrapError(KindUnavailable, err.Error(), err)
+		case errors.Is(err, store.ErrHostSuspended):
+			return nil, nil, wrapError(KindForbidden, err.Error(), err)
+		case errors.Is(err, store.ErrNotEnoughTickets):
+			return nil, nil, wrapError(KindConflict, "Not enough "+req.TicketType+" tickets available", err)
+		case errors.As(err, &capErr):
+			return nil, nil, wrapError(KindConflict, capErr.Error(), err)
+		}
+		return nil, nil, wrapError(KindInternal, "error creating booking FROM BOOKING", err)
+	}
+	s.bus.Publish(ctx, eventbus.NewBookingMessage(eventbus.BookingCreated, booking))
+	s.runBookingHooks(s.afterBooking, *booking)
+	if len(accepted) > 0 {
+		s.consents.recordLater(ctx, models.Consent{
+			UserID:    booking.UserID,
+			GuestID:   booking.GuestID,
+			BookingID: booking.ID,
+			Source:    models.ConsentAtBooking,
+			IP:        req.ClientIP,
+		}, accepted)
+	}
+
+	return booking, event, nil
+}
+
+// checkVelocity counts the booking for its buyer and IP and refuses it once either booked too often this minute,
+// or asks for a CAPTCHA when there is a verifier. A counter error lets the booking through, a sale is never lost to a Redis hiccup.
+func (s *BookingService) checkVelocity(ctx context.Context, booking *models.Booking, clientIP, captchaToken string) error {
+	if s.limits.PerMinute <= 0 {
+		return nil
+	}
+
+	keys := []string{"booking:user:" + booking.UserID.Hex()}
+	if booking.UserID.IsZero() {
+		keys[0] = "booking:guest:" + booking.GuestID.Hex()
+	}
+	if clientIP != "" {
+		keys = append(keys, "booking:ip:"+clientIP)
+	}
+
+	tooMany := false
+	for _, key := range keys {
+		hits, err := s.limits.Velocity.Hit(ctx, key, velocityWindow)
+		if err != nil {
+			log.Printf("Error counting bookings of %s: %v", key, err)
+			continue
+		}
+		tooMany = tooMany || hits > int64(s.limits.PerMinute)
+	}
+	if !tooMany {
+		return nil
+	}
+
+	if s.limits.Captcha != nil {
+		return verifyCaptcha(ctx, s.limits.Captcha, captchaToken, clientIP, "Too many bookings")
+	}
+	return newError(KindRateLimited, "Too many bookings, wait a minute before booking again")
+}
+
+// checkSaleWindow refuses ticket types whose sale has not started or is over, unknown types are left to the store
+func checkSaleWindow(event *models.Event, ticketType string, now time.Time) error {
+	for _, ticket := range event.Tickets {
+		if ticket.Type != ticketType {
+			continue
+		}
+		switch ticket.SaleStatus(now) {
+		case models.SaleUpcoming:
+			return newError(KindConflict, fmt.Sprintf("%s tickets go on sale at %s", ticket.Type, ticket.SaleStart.UTC().Format(time.RFC3339)))
+		case models.SaleEnded:
+			return newError(KindGone, fmt.Sprintf("%s ticket sales ended at %s", ticket.Type, ticket.SaleEnd.UTC().Format(time.RFC3339)))
+		}
+	}
+	return nil
+}
+
+// cleanAttendee trims an attendee's name and email and checks the email
+func cleanAttendee(input dto.AttendeeInput) (models.Attendee, error) {
+	attendee := models.Attendee{
+		Name:  strings.TrimSpace(input.Name),
+		Email: strings.TrimSpace(input.Email),
+	}
+	if len(attendee.Name) > 100 {
+		return attendee, newError(KindInvalid, "Attendee names can be at most 100 characters")
+	}
+	if attendee.Email != "" {
+		address, err := mail.ParseAddress(attendee.Email)
+		if err != nil {
+			return attendee, newError(KindInvalid, attendee.Email+" is not a valid email address")
+		}
+		attendee.Email = strings.ToLower(address.Address)
+	}
+	return attendee, nil
+}
+
+// buildAttendees creates one attendee per ticket, the names are optional and can be filled in later
+func buildAttendees(inputs []dto.AttendeeInput, quantity int) ([]models.Attendee, error) {
+	if len(inputs) > 0 && len(inputs) != quantity {
+		return nil, newError(KindInvalid, "Give one attendee per ticket or none FROM BOOKING")
+	}
+
+	attendees := make([]models.Attendee, quantity)
+	for i := range attendees {
+		if i < len(inputs) {
+			attendee, err := cleanAttendee(inputs[i])
+			if err != nil {
+				return nil, err
+			}
+			attendees[i] = attendee
+		}
+		attendees[i].CheckInCode = utils.GenerateCheckInCode()
+	}
+	return attendees, nil
+}
+
+// bookingStartTime returns when the booked event (or booked session) starts
+func bookingStartTime(event *models.Event, booking *models.Booking) time.Time {
+	if !booking.SessionID.IsZero() {
+		for _, session := range event.Sessions {
+			if session.ID == booking.SessionID {
+				return session.StartTime
+			}
+		}
+	}
+	return event.StartTime
+}
+
+// ! UpdateAttendees changes the names and emails of the attendees of one of the user's bookings before the event starts
+func (s *BookingService) UpdateAttendees(ctx context.Context, userID bson.ObjectID, bookingID string, req *dto.UpdateAttendeesRequest) (*models.Booking, error) {
+	booking, err := s.bookings.GetBookingByID(ctx, bookingID)
+	if err != nil {
+		return nil, newError(KindNotFound, "Booking not found FROM BOOKING")
+	}
+
+	if booking.UserID != userID {
+		return nil, newError(KindForbidden, "You can only change your own bookings FROM BOOKING")
+	}
+	if booking.Status != "confirmed" {
+		return nil, newError(KindConflict, "Cancelled bookings can't be changed")
+	}
+
+	if len(booking.Attendees) == 0 {
+		return nil, newError(KindConflict, "This booking has no attendees to change")
+	}
+	if len(req.Attendees) != len(booking.Attendees) {
+		return nil, newError(KindInvalid, "Give one attendee per ticket FROM BOOKING")
+	}
+
+	event, err := s.events.GetEventByID(ctx, booking.EventID.Hex())
+	if err != nil {
+		return nil, newError(KindNotFound, "Event not found FROM BOOKING")
+	}
+	if !time.Now().Before(bookingStartTime(event, booking)) {
+		return nil, newError(KindConflict, "Attendees can't be changed once the event has started")
+	}
+
+	attendees := make([]models.Attendee, len(booking.Attendees))
+	for i, input := range req.Attendees {
+		attendee, err := cleanAttendee(input)
+		if err != nil {
+			return nil, err
+		}
+
+		//! The ticket was already used at the door, it stays with that person
+		current := booking.Attendees[i]
+		if current.CheckedInAt != nil && (attendee.Name != current.Name || attendee.Email != current.Email) {
+			return nil, newError(KindConflict, "Checked in attendees can't be changed")
+		}
+
+		attendee.CheckInCode = current.CheckInCode
+		attendee.CheckedInAt = current.CheckedInAt
+		attendees[i] = attendee
+	}
+
+	if err := s.bookings.UpdateAttendees(ctx, booking.ID, attendees); err != nil {
+		return nil, wrapError(KindInternal, "Error updating attendees FROM BOOKING", err)
+	}
+
+	booking.Attendees = attendees
+	return booking, nil
+}
+
+// ! CancelBooking cancels one of the user's bookings and returns it
+func (s *BookingService) CancelBooking(ctx context.Context, userID bson.ObjectID, bookingID string) (*models.Booking, error) {
+	bookingObjID, err := bson.ObjectIDFromHex(bookingID)
+	if err != nil {
+		return nil, newError(KindInvalid, "Invalid booking ID FROM BOOKING")
+	}
+
+	//? Verify the booking belongs to the user
+	booking, err := s.bookings.GetBookingByID(ctx, bookingID)
+	if err != nil {
+		return nil, newError(KindNotFound, "Booking not found FROM BOOKING")
+	}
+
+	if booking.UserID != userID {
+		return nil, newError(KindForbidden, "You can only cancel your own bookings FROM BOOKING")
+	}
+
+	if err := s.cancel(ctx, bookingObjID, booking); err != nil {
+		return nil, err
+	}
+	return booking, nil
+}
+
+// ! Reconfirm keeps one of the user's bookings after its event was rescheduled
+func (s *BookingService) Reconfirm(ctx context.Context, userID bson.ObjectID, bookingID string) (*models.Booking, error) {
+	booking, err := s.bookings.GetBookingByID(ctx, bookingID)
+	if err != nil {
+		return nil, newError(KindNotFound, "Booking not found FROM BOOKING")
+	}
+	if booking.UserID != userID {
+		return nil, newError(KindForbidden, "You can only reconfirm your own bookings FROM BOOKING")
+	}
+	if !booking.NeedsReconfirmation {
+		return nil, newError(KindConflict, "This booking doesn't need to be reconfirmed")
+	}
+	if booking.ReconfirmBy != nil && !time.Now().Before(*booking.ReconfirmBy) {
+		return nil, newError(KindGone, "The time to reconfirm this booking is over, it is being refunded")
+	}
+
+	ok, err := s.bookings.Reconfirm(ctx, booking.ID)
+	if err != nil {
+		return nil, wrapError(KindInternal, "Error reconfirming booking FROM BOOKING", err)
+	}
+	if !ok {
+		return nil, newError(KindConflict, "This booking doesn't need to be reconfirmed")
+	}
+
+	booking.NeedsReconfirmation = false
+	booking.ReconfirmBy = nil
+	return booking, nil
+}
+
+// refundBatch is how many unconfirmed bookings one scheduler run refunds, the rest follow on the next run
+const refundBatch = 100
+
+// ! RefundUnconfirmed cancels the bookings of rescheduled events that weren't reconfirmed in time and returns how many.
+// Cancelling is the refund: the tickets go back on sale and booking.cancelled tells payments to pay the buyer back.
+func (s *BookingService) RefundUnconfirmed(ctx context.Context) (int, error) {
+	bookings, err := s.bookings.GetExpiredReconfirmations(ctx, time.Now(), refundBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	refunded := 0
+	for i := range bookings {
+		booking := &bookings[i]
+
+		//? Another instance may have refunded it already
+		if err := s.cancel(ctx, booking.ID, booking); err != nil {
+			log.Printf("Error refunding unconfirmed booking %s: %v", booking.ID.Hex(), err)
+			continue
+		}
+		refunded++
+
+		message := "Your booking was not reconfirmed after the event was rescheduled, it was cancelled and refunded"
+		if event, err := s.events.GetEventByID(ctx, booking.EventID.Hex()); err == nil {
+			message = "Your booking for " + event.Name + " was not reconfirmed after the event was rescheduled, it was cancelled and refunded"
+		}
+		s.notifier.NotifyUser(booking.UserID, models.NotificationBookingCancellation, message, booking.EventID)
+	}
+
+	return refunded, nil
+}
+
+// ! RefundEvent cancels and refunds every booking of an event the host called off, returns how many were refunded
+func (s *BookingService) RefundEvent(ctx context.Context, event *models.Event) (int, error) {
+	bookings, err := s.bookings.GetBookingsByEventID(ctx, event.ID)
+	if err != nil {
+		return 0, err
+	}
+
+	refunded := 0
+	for i := range bookings {
+		booking := &bookings[i]
+		if booking.Status == "cancelled" {
+			continue
+		}
+
+		if err := s.cancel(ctx, booking.ID, booking); err != nil {
+			log.Printf("Error refunding booking %s of cancelled event %s: %v", booking.ID.Hex(), event.ID.Hex(), err)
+			continue
+		}
+		refunded++
+
+		//? Guests have no account to notify, the cancellation email reaches them
+		if !booking.UserID.IsZero() {
+			s.notifier.NotifyUser(booking.UserID, models.NotificationBookingCancellation,
+				event.Name+" was cancelled by the host, your booking was cancelled and refunded", event.ID)
+		}
+	}
+
+	return refunded, nil
+}
+
+// maxCancelReasonLength caps the reason support gives for a forced cancellation
+const maxCancelReasonLength = 500
+
+// ! ForceCancelBooking cancels any booking whoever owns it (admin function), returns it with its event for the notification
+func (s *BookingService) ForceCancelBooking(ctx context.Context, bookingID, reason string) (*models.Booking, *models.Event, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, nil, newError(KindInvalid, "reason is required")
+	}
+	if len(reason) > maxCancelReasonLength {
+		return nil, nil, newError(KindInvalid, "reason must be at most 500 characters")
+	}
+
+	bookingObjID, err := bson.ObjectIDFromHex(bookingID)
+	if err != nil {
+		return nil, nil, newError(KindInvalid, "Invalid booking ID")
+	}
+
+	booking, err := s.bookings.GetBookingByID(ctx, bookingID)
+	if err != nil {
+		return nil, nil, newError(KindNotFound, "Booking not found")
+	}
+
+	if err := s.cancel(ctx, bookingObjID, booking); err != nil {
+		return nil, nil, err
+	}
+
+	//? The booking is cancelled already, a missing event only costs the name in the notification
+	event, _ := s.events.GetEventByID(ctx, booking.EventID.Hex())
+	return booking, event, nil
+}
+
+// cancel marks the booking cancelled (its tickets go back on sale), publishes it and runs the cancel hooks
+func (s *BookingService) cancel(ctx context.Context, bookingObjID bson.ObjectID, booking *models.Booking) error {
+	if err := s.bookings.CancelBooking(ctx, bookingObjID); err != nil {
+		if errors.Is(err, store.ErrBookingCancelled) {
+			return wrapError(KindConflict, "This booking is already cancelled", err)
+		}
+		return wrapError(KindInternal, "Error cancelling booking FROM BOOKING", err)
+	}
+	s.bus.Publish(ctx, eventbus.NewBookingMessage(eventbus.BookingCancelled, booking))
+	s.runBookingHooks(s.afterCancel, *booking)
+	return nil
+}
+
+// ! ChangeTicketType moves one of the user's bookings to another ticket type and returns it with the price difference
+func (s *BookingService) ChangeTicketType(ctx context.Context, userID bson.ObjectID, bookingID string, req *dto.ChangeTicketTypeRequest) (*models.Booking, *models.PriceAdjustment, error) {
+	if req.TicketType == "" {
+		return nil, nil, newError(KindInvalid, "ticket_type is required")
+	}
+
+	booking, err := s.bookings.GetBookingByID(ctx, bookingID)
+	if err != nil {
+		return nil, nil, newError(KindNotFound, "Booking not found FROM BOOKING")
+	}
+	if booking.UserID != userID {
+		return nil, nil, newError(KindForbidden, "You can only change your own bookings FROM BOOKING")
+	}
+	if booking.Status != "confirmed" {
+		return nil, nil, newError(KindConflict, "Cancelled bookings can't be changed")
+	}
+	if booking.TicketType == req.TicketType {
+		return nil, nil, newError(KindConflict, "The booking already has this ticket type")
+	}
+
+	event, err := s.events.GetEventByID(ctx, booking.EventID.Hex())
+	if err != nil {
+		return nil, nil, newError(KindNotFound, "Event not found FROM BOOKING")
+	}
+	now := time.Now()
+	if !now.Before(bookingStartTime(event, booking)) {
+		return nil, nil, newError(KindConflict, "Tickets can't be changed once the event has started")
+	}
+
+	if !hasTicketType(event, req.TicketType) {
+		return nil, nil, newError(KindInvalid, "Ticket type not found for this event")
+	}
+
+	//? The new type must be on sale like for a new booking
+	if err := checkSaleWindow(event, req.TicketType, now); err != nil {
+		return nil, nil, err
+	}
+
+	changed, adjustment, err := s.bookings.ChangeTicketType(ctx, booking.ID, req.TicketType)
+	if err != nil {
+		switch {
+		case errors.Is(err, store.ErrNotEnoughTickets):
+			return nil, nil, wrapError(KindConflict, "Not enough "+req.TicketType+" tickets available", err)
+		case errors.Is(err, store.ErrBookingBusy):
+			return nil, nil, wrapError(KindUnavailable, err.Error(), err)
+		}
+		return nil, nil, wrapError(KindInternal, "Error changing ticket type FROM BOOKING", err)
+	}
+	s.bus.Publish(ctx, eventbus.NewBookingMessage(eventbus.BookingChanged, changed))
+
+	return changed, adjustment, nil
+}
+
+// hasTicketType reports whether the event sells the ticket type
+func hasTicketType(event *models.Event, ticketType string) bool {
+	for _, ticket := range event.Tickets {
+		if ticket.Type == ticketType {
+			return true
+		}
+	}
+	return false
+}
+
+// ! GetEventBookings returns the bookings of an event to its host, co-hosts and door staff
+func (s *BookingService) GetEventBookings(ctx context.Context, door Door, eventID string) ([]models.BookingWithDetails, error) {
+	event, err := s.events.GetEventByID(ctx, eventID)
+	if err != nil {
+		return nil, newError(KindNotFound, "Event not found FROM BOOKING")
+	}
+
+	if !door.canCheckIn(event) {
+		return nil, newError(KindForbidden, "Only the event host, co-hosts and door staff can view its bookings")
+	}
+
+	bookings, err := s.bookings.GetEventBookingsWithUsers(ctx, event.ID)
+	if err != nil {
+		return nil, wrapError(KindInternal, "Error retrieving bookings FROM BOOKING", err)
+	}
+
+	return bookings, nil
+}
+
+// maxWalletTickets caps the ticket wallet, nobody holds more upcoming bookings than that
+const maxWalletTickets = 100
+
+// ! Wallet returns the user's upcoming confirmed bookings for the mobile ticket wallet, soonest first.
+// A session booking shows the session's times and is gone from the wallet once its session ended
+func (s *BookingService) Wallet(ctx context.Context, userID bson.ObjectID) ([]models.WalletTicket, error) {
+	tickets, err := s.bookings.GetWalletTickets(ctx, userID, maxWalletTickets)
+	if err != nil {
+		return nil, wrapError(KindInternal, "Failed to get your tickets", err)
+	}
+
+	now := time.Now()
+	wallet := make([]models.WalletTicket, 0, len(tickets))
+	for _, ticket := range tickets {
+		if ticket.SessionID != nil {
+			for _, session := range ticket.Event.Sessions {
+				if session.ID == *ticket.SessionID {
+					ticket.SessionTitle = session.Title
+					ticket.Event.StartTime = session.StartTime
+					ticket.Event.EndTime = session.EndTime
+				}
+			}
+			if !ticket.Event.EndTime.After(now) {
+				continue
+			}
+		}
+		ticket.Event.Sessions = nil
+		if ticket.Passes == nil {
+			ticket.Passes = []models.WalletPass{}
+		}
+		wallet = append(wallet, ticket)
+	}
+
+	//? Sessions can start later than their event, keep the wallet in the order they happen
+	sort.SliceStable(wallet, func(i, j int) bool {
+		return wallet[i].Event.StartTime.Before(wallet[j].Event.StartTime)
+	})
+
+	return wallet, nil
+}
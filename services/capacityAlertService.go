@@ -0,0 +1,116 @@
+package services
+
+import (
+	"context"
+	"event-horizon/models"
+	"event-horizon/store"
+	"event-horizon/utils"
+	"fmt"
+	"log"
+)
+
+//! THIS FILE TELLS HOSTS WHEN THEIR TICKET TYPES ARE SELLING OUT (CAPACITY ALERTS)
+
+/******************************* NOTE **************************************
+
+I DID THE FOLLOWING THINGS IN THIS FILE:
+
+1. CheckBooking is a booking hook: after every booking it compares the ticket type's sold share with the event's alert thresholds (50%, 90%, sold out by default).
+
+2. Every threshold is alerted once per ticket type, the store marks it so parallel bookings can't both send it.
+
+3. A booking that jumps over several thresholds sends one alert for the highest.
+
+4. The host gets an in-app notification and / or an email, as the event's settings say.
+
+5. The host's notification preferences come on top: an email they turned off isn't sent even if the event asks for it.
+
+6. CheckBooking runs as a job, reading the event or marking a threshold failed returns the error so the job is retried (thresholds already marked aren't sent twice).
+
+********************************* NOTE ************************************/
+
+// CapacityAlertService sends capacity alerts to hosts
+type CapacityAlertService struct {
+	events   store.EventRepository
+	users    store.UserRepository
+	notifier *utils.NotificationWorker
+	mailer   utils.Mailer
+}
+
+// NewCapacityAlertService creates a new CapacityAlertService
+func NewCapacityAlertService(events store.EventRepository, users store.UserRepository, notifier *utils.NotificationWorker, mailer utils.Mailer) *CapacityAlertService {
+	return &CapacityAlertService{
+		events:   events,
+		users:    users,
+		notifier: notifier,
+		mailer:   mailer,
+	}
+}
+
+// ! CheckBooking alerts the host when the booking pushed its ticket type over a threshold
+func (s *CapacityAlertService) CheckBooking(ctx context.Context, booking models.Booking) error {
+	//? Read the event again, the booking changed its availability
+	event, err := s.events.GetEventByID(ctx, booking.EventID.Hex())
+	if err != nil {
+		return fmt.Errorf("reading event %s: %w", booking.EventID.Hex(), err)
+	}
+
+	settings := event.AlertSettings()
+	if !settings.InApp && !settings.Email {
+		return nil
+	}
+
+	var ticket *models.TicketInfo
+	for i := range event.Tickets {
+		if event.Tickets[i].Type == booking.TicketType {
+			ticket = &event.Tickets[i]
+		}
+	}
+	if ticket == nil || ticket.TotalQuantity <= 0 {
+		return nil
+	}
+
+	sold := ticket.TotalQuantity - ticket.AvailableQuantity
+	percent := sold * 100 / ticket.TotalQuantity
+
+	highest := 0
+	var markErr error
+	for _, threshold := range settings.Thresholds {
+		if percent < threshold {
+			continue
+		}
+		sent, err := s.events.MarkCapacityAlertSent(ctx, event.ID, ticket.Type, threshold)
+		if err != nil {
+			markErr = fmt.Errorf("marking the %d%% alert of event %s: %w", threshold, event.ID.Hex(), err)
+			continue
+		}
+		if sent && threshold > highest {
+			highest = threshold
+		}
+	}
+	if highest == 0 {
+		return markErr
+	}
+
+	message := fmt.Sprintf("%s tickets for %s are %d%% sold (%d of %d)", ticket.Type, event.Name, percent, sold, ticket.TotalQuantity)
+	if ticket.AvailableQuantity <= 0 {
+		message = fmt.Sprintf("%s tickets for %s are sold out (%d sold)", ticket.Type, event.Name, sold)
+	}
+
+	if settings.InApp {
+		s.notifier.NotifyUser(event.HostID, models.NotificationCapacityAlert, message, event.ID)
+	}
+	if settings.Email {
+		host, err := s.users.GetUserByID(ctx, event.HostID)
+		if err != nil {
+			log.Printf("Capacity alerts: error reading host %s: %v", event.HostID.Hex(), err)
+			return markErr
+		}
+		if !host.Allows(models.NotificationCapacityAlert, models.ChannelEmail) {
+			return markErr
+		}
+		body := fmt.Sprintf("Hi %s,\n\n%s.\n\nYou get this email because of the capacity alerts of your event, change them under capacity_alerts.\n", host.Name, message)
+		utils.SendInBackground(s.mailer, host.Email, message, body)
+	}
+	return markErr
+}
@@ -0,0 +1,19 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"event-horizon/utils"
+)
+
+// verifyCaptcha checks the CAPTCHA token of a request, why tells the client why one is asked for
+func verifyCaptcha(ctx context.Context, verifier utils.CaptchaVerifier, token, remoteIP, why string) error {
+	err := verifier.Verify(ctx, token, remoteIP)
+	if errors.Is(err, utils.ErrCaptchaInvalid) {
+		return newError(KindCaptchaRequired, why+", solve the CAPTCHA and send its token as captcha_token")
+	}
+	if err != nil {
+		return wrapError(KindUnavailable, "The CAPTCHA could not be checked, please try again", err)
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+package services
+
+import (
+	"context"
+	"event-horizon/dto"
+	"event-horizon/models"
+	"event-horizon/store"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+//! THIS FILE HOLDS THE RULES OF CHECK-IN AT THE DOOR
+
+/******************************* NOTE **************************************
+
+I DID THE FOLLOWING THINGS IN THIS FILE:
+
+1. Stats counts the attendees of an event and how many are checked in per ticket type, for the host and co-hosts only.
+
+2. CheckIn takes a batch of scanned codes (a door app that was offline syncs them later), every code gets its own result.
+
+3. A code can only be checked in once, scanning it again answers already_checked_in with the time of the first scan.
+
+4. SCANNERS of the event's organization can check in too, besides the host and co-hosts.
+
+5. Door staff can also come with a SCANNER KEY instead of an account (see Door), a key only opens its own event.
+
+********************************* NOTE ************************************/
+
+// maxCheckInScans is how many codes one check-in request may carry
+const maxCheckInScans = 500
+
+// CheckInService holds the rules of checking attendees in
+type CheckInService struct {
+	events   store.EventRepository
+	bookings store.BookingRepository
+}
+
+// NewCheckInService creates a new CheckInService
+func NewCheckInService(events store.EventRepository, bookings store.BookingRepository) *CheckInService {
+	return &CheckInService{
+		events:   events,
+		bookings: bookings,
+	}
+}
+
+// Door is who works the door of an event, a logged in user or a device with a scanner key
+type Door struct {
+	UserID bson.ObjectID      //? Set for logged in users
+	Key    *models.ScannerKey //? Set for scanner keys, UserID is then empty
+}
+
+// canCheckIn reports whether the door may check attendees of the event in
+func (d Door) canCheckIn(event *models.Event) bool {
+	if d.Key != nil {
+		return d.Key.EventID == event.ID
+	}
+	return event.CanCheckIn(d.UserID)
+}
+
+// managedEvent returns the event if the door is its host, a co-host, a scanner of its organization or one of its scanner keys
+func (s *CheckInService) managedEvent(ctx context.Context, door Door, eventID string) (*models.Event, error) {
+	event, err := s.events.GetEventByID(ctx, eventID)
+	if err != nil {
+		return nil, newError(KindNotFound, "Event not found")
+	}
+
+	if !door.canCheckIn(event) {
+		return nil, newError(KindForbidden, "Only the event host, co-hosts and door staff can check attendees in")
+	}
+
+	return event, nil
+}
+
+// stats builds the door counts of an event, every ticket type of the event is listed
+func (s *CheckInService) stats(ctx context.Context, event *models.Event) (*models.CheckInStats, error) {
+	counts, err := s.bookings.GetCheckInCounts(ctx, event.ID)
+	if err != nil {
+		return nil, wrapError(KindInternal, "Failed to count check-ins", err)
+	}
+
+	stats := &models.CheckInStats{
+		EventID:      event.ID,
+		ByTicketType: []models.TicketTypeCheckIns{},
+		GeneratedAt:  time.Now(),
+	}
+
+	countByType := make(map[string]models.TicketTypeCheckIns, len(counts))
+	for _, count := range counts {
+		countByType[count.TicketType] = count
+	}
+	for _, ticket := range event.Tickets {
+		count := countByType[ticket.Type]
+		count.TicketType = ticket.Type
+		delete(countByType, ticket.Type)
+		stats.ByTicketType = append(stats.ByTicketType, count)
+	}
+	//? Ticket types removed after they sold still have attendees
+	for _, count := range counts {
+		if _, removed := countByType[count.TicketType]; removed {
+			stats.ByTicketType = append(stats.ByTicketType, count)
+		}
+	}
+
+	for _, count := range stats.ByTicketType {
+		stats.Attendees += count.Attendees
+		stats.CheckedIn += count.CheckedIn
+	}
+
+	return stats, nil
+}
+
+// ! Stats returns the live door counts of an event to its host, co-hosts and door staff
+func (s *CheckInService) Stats(ctx context.Context, door Door, eventID string) (*models.CheckInStats, error) {
+	event, err := s.managedEvent(ctx, door, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.stats(ctx, event)
+}
+
+// ! CheckIn checks in every scanned code and returns one result per scan with the new door counts
+func (s *CheckInService) CheckIn(ctx context.Context, door Door, eventID string, req *dto.CheckInRequest) ([]models.CheckInResult, *models.CheckInStats, error) {
+	if len(req.Scans) == 0 {
+		return nil, nil, newError(KindInvalid, "scans must contain at least one code")
+	}
+	if len(req.Scans) > maxCheckInScans {
+		return nil, nil, newError(KindInvalid, "At most 500 codes can be checked in at once")
+	}
+
+	event, err := s.managedEvent(ctx, door, eventID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	results := make([]models.CheckInResult, 0, len(req.Scans))
+	for _, scan := range req.Scans {
+		code := strings.ToUpper(strings.TrimSpace(scan.Code))
+		result := models.CheckInResult{Code: code, Status: models.CheckInStatusNotFound}
+
+		//? Offline scans keep their time, a clock ahead of the server can't check in from the future
+		at := now
+		if scan.ScannedAt != nil && scan.ScannedAt.Before(now) {
+			at = *scan.ScannedAt
+		}
+
+		if code != "" {
+			booking, checkedIn, err := s.bookings.CheckInAttendee(ctx, event.ID, code, at)
+			if err != nil {
+				return nil, nil, wrapError(KindInternal, "Failed to check in "+code, err)
+			}
+
+			if booking != nil {
+				result.Status = models.CheckInStatusAlreadyCheckedIn
+				if checkedIn {
+					result.Status = models.CheckInStatusCheckedIn
+				}
+				result.TicketType = booking.TicketType
+				for _, attendee := range booking.Attendees {
+					if attendee.CheckInCode == code {
+						result.Name = attendee.Name
+						result.CheckedInAt = attendee.CheckedInAt
+						break
+					}
+				}
+			}
+		}
+
+		results = append(results, result)
+	}
+
+	stats, err := s.stats(ctx, event)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return results, stats, nil
+}
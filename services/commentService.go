@@ -0,0 +1,328 @@
+package services
+
+import (
+	"context"
+	"event-horizon/dto"
+	"event-horizon/models"
+	"event-horizon/store"
+	"event-horizon/utils"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+//! THIS FILE HOLDS THE RULES FOR THE Q&A COMMENTS ON EVENT PAGES
+
+/******************************* NOTE **************************************
+
+I DID THE FOLLOWING THINGS IN THIS FILE:
+
+1. Post adds a comment or a reply, replies go one level deep (a reply can't be replied to).
+
+2. Authors are rate limited: at most 5 comments a minute.
+
+3. The host hears about new questions and authors hear about replies (in-app, nobody is told about their own comment).
+
+4. List returns a page of top level comments (newest first) with all their replies (oldest first).
+
+5. Delete lets the author or the event host and co-hosts delete a comment, its replies go with it.
+
+6. Report flags a comment once per user, comments reaching the report THRESHOLD are hidden until an admin reviews them.
+
+7. Reported and AdminDelete are the admin side of the reports.
+
+********************************* NOTE ************************************/
+
+const (
+	maxCommentLength     = 2000
+	defaultCommentLimit  = 20
+	maxCommentListLimit  = 100
+	maxCommentOffset     = 10000
+	commentRateWindow    = time.Minute
+	maxCommentsPerWindow = 5
+	defaultReportedLimit = 100
+	maxReportedListLimit = 1000
+)
+
+// CommentService holds the rules for comments on event pages
+type CommentService struct {
+	comments      store.CommentRepository
+	events        store.EventRepository
+	users         store.UserRepository
+	notifier      *utils.NotificationWorker
+	hideThreshold int
+}
+
+// NewCommentService creates a new CommentService, comments with hideThreshold reports are hidden
+func NewCommentService(comments store.CommentRepository, events store.EventRepository, users store.UserRepository, notifier *utils.NotificationWorker, hideThreshold int) *CommentService {
+	return &CommentService{
+		comments:      comments,
+		events:        events,
+		users:         users,
+		notifier:      notifier,
+		hideThreshold: hideThreshold,
+	}
+}
+
+// publicEvent returns the event if its page is visible
+func (s *CommentService) publicEvent(ctx context.Context, eventID string) (*models.Event, error) {
+	event, err := s.events.GetEventByID(ctx, eventID)
+	if err != nil || event.HostSuspended {
+		return nil, newError(KindNotFound, "Event not found") //? Events of suspended hosts are gone for the public
+	}
+	return event, nil
+}
+
+// eventComment returns a comment of the event, hidden ones included
+func (s *CommentService) eventComment(ctx context.Context, event *models.Event, commentID string) (*models.Comment, error) {
+	id, err := bson.ObjectIDFromHex(commentID)
+	if err != nil {
+		return nil, newError(KindInvalid, "Invalid comment ID")
+	}
+
+	comment, err := s.comments.GetCommentByID(ctx, id)
+	if err != nil || comment.EventID != event.ID {
+		return nil, newError(KindNotFound, "Comment not found")
+	}
+	return comment, nil
+}
+
+// ! Post adds a comment (or a reply when parent_id is set) to the event page
+func (s *CommentService) Post(ctx context.Context, userID bson.ObjectID, eventID string, req *dto.CommentRequest) (*models.Comment, error) {
+	body := strings.TrimSpace(req.Body)
+	if body == "" {
+		return nil, newError(KindInvalid, "body is required")
+	}
+	if utf8.RuneCountInString(body) > maxCommentLength {
+		return nil, newError(KindInvalid, "body can be at most 2000 characters")
+	}
+
+	event, err := s.publicEvent(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	var parent *models.Comment
+	if req.ParentID != "" {
+		parent, err = s.eventComment(ctx, event, req.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		if parent.IsReply() {
+			return nil, newError(KindInvalid, "Replies can't be replied to, reply to the question instead")
+		}
+		if parent.Hidden {
+			return nil, newError(KindNotFound, "Comment not found")
+		}
+	}
+
+	//! Rate limit
+	recent, err := s.comments.CountCommentsByAuthorSince(ctx, userID, time.Now().Add(-commentRateWindow))
+	if err != nil {
+		return nil, wrapError(KindInternal, "Failed to post the comment", err)
+	}
+	if recent >= maxCommentsPerWindow {
+		return nil, newError(KindRateLimited, "Too many comments, wait a minute before posting again")
+	}
+
+	author, err := s.users.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, newError(KindUnauthorized, "User not found")
+	}
+
+	comment := &models.Comment{
+		EventID:    event.ID,
+		AuthorID:   userID,
+		AuthorName: author.Name,
+		ByHost:     event.IsManagedBy(userID),
+		Body:       body,
+	}
+	if parent != nil {
+		comment.ParentID = &parent.ID
+	}
+
+	if err := s.comments.CreateComment(ctx, comment); err != nil {
+		return nil, wrapError(KindInternal, "Failed to post the comment", err)
+	}
+
+	s.notify(event, parent, comment)
+
+	return comment, nil
+}
+
+// notify tells the host about a new question and the author of a comment about a reply
+func (s *CommentService) notify(event *models.Event, parent, comment *models.Comment) {
+	if parent != nil {
+		if parent.AuthorID != comment.AuthorID {
+			s.notifier.NotifyUser(parent.AuthorID, models.NotificationCommentReply,
+				comment.AuthorName+" replied to your comment on "+event.Name, event.ID)
+		}
+		return
+	}
+
+	if !comment.ByHost {
+		s.notifier.NotifyUser(event.HostID, models.NotificationNewComment,
+			comment.AuthorName+" asked a question on "+event.Name, event.ID)
+	}
+}
+
+// ! List returns a page of the top level comments of an event with their replies
+func (s *CommentService) List(ctx context.Context, eventID string, limit, offset int) (*dto.CommentPage, error) {
+	if limit == 0 {
+		limit = defaultCommentLimit
+	}
+	if limit < 0 || limit > maxCommentListLimit {
+		return nil, newError(KindInvalid, "limit must be between 1 and 100")
+	}
+	if offset < 0 || offset > maxCommentOffset {
+		return nil, newError(KindInvalid, "offset must be between 0 and 10000")
+	}
+
+	event, err := s.publicEvent(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, total, err := s.comments.GetComments(ctx, event.ID, limit, offset)
+	if err != nil {
+		return nil, wrapError(KindInternal, "Failed to read the comments", err)
+	}
+
+	parentIDs := make([]bson.ObjectID, 0, len(comments))
+	for _, comment := range comments {
+		parentIDs = append(parentIDs, comment.ID)
+	}
+
+	replies, err := s.comments.GetReplies(ctx, parentIDs)
+	if err != nil {
+		return nil, wrapError(KindInternal, "Failed to read the replies", err)
+	}
+
+	byParent := make(map[bson.ObjectID][]models.Comment, len(comments))
+	for _, reply := range replies {
+		byParent[*reply.ParentID] = append(byParent[*reply.ParentID], reply)
+	}
+	for i := range comments {
+		comments[i].Replies = byParent[comments[i].ID]
+	}
+
+	return &dto.CommentPage{
+		Comments: comments,
+		Total:    total,
+		Limit:    limit,
+		Offset:   offset,
+	}, nil
+}
+
+// ! Delete deletes a comment with its replies (the author or the event host and co-hosts)
+func (s *CommentService) Delete(ctx context.Context, userID bson.ObjectID, eventID, commentID string) error {
+	event, err := s.events.GetEventByID(ctx, eventID)
+	if err != nil {
+		return newError(KindNotFound, "Event not found")
+	}
+
+	comment, err := s.eventComment(ctx, event, commentID)
+	if err != nil {
+		return err
+	}
+
+	if comment.AuthorID != userID && !event.IsManagedBy(userID) {
+		return newError(KindForbidden, "Only the author or the event host can delete this comment")
+	}
+
+	if _, err := s.comments.DeleteComment(ctx, comment.ID); err != nil {
+		return wrapError(KindInternal, "Failed to delete the comment", err)
+	}
+	return nil
+}
+
+// ! Report flags a comment, hidden is true when this report hid it
+func (s *CommentService) Report(ctx context.Context, userID bson.ObjectID, eventID, commentID string, req *dto.CommentReportRequest) (hidden bool, err error) {
+	switch req.Reason {
+	case models.ReportReasonSpam, models.ReportReasonScam, models.ReportReasonInappropriate, models.ReportReasonOther:
+	default:
+		return false, newError(KindInvalid, "reason must be one of spam, scam, inappropriate, other")
+	}
+
+	event, err := s.publicEvent(ctx, eventID)
+	if err != nil {
+		return false, err
+	}
+
+	comment, err := s.eventComment(ctx, event, commentID)
+	if err != nil {
+		return false, err
+	}
+	if comment.AuthorID == userID {
+		return false, newError(KindInvalid, "You can't report your own comment")
+	}
+
+	count, reported, err := s.comments.ReportComment(ctx, comment.ID, models.CommentReport{UserID: userID, Reason: req.Reason})
+	if err != nil {
+		return false, wrapError(KindInternal, "Failed to report the comment", err)
+	}
+	if !reported {
+		return false, newError(KindConflict, "You already reported this comment")
+	}
+
+	//? Too many reports, hide the comment until an admin reviews it
+	if count < s.hideThreshold {
+		return false, nil
+	}
+	hidden, err = s.comments.HideComment(ctx, comment.ID)
+	if err != nil {
+		return false, wrapError(KindInternal, "Failed to hide the comment", err)
+	}
+	return hidden, nil
+}
+
+// ! Reported returns the reported comments for the admins, most reported first
+func (s *CommentService) Reported(ctx context.Context, limit int) ([]models.ReportedComment, error) {
+	if limit == 0 {
+		limit = defaultReportedLimit
+	}
+	if limit < 0 || limit > maxReportedListLimit {
+		return nil, newError(KindInvalid, "limit must be between 1 and 1000")
+	}
+
+	comments, err := s.comments.GetReportedComments(ctx, limit)
+	if err != nil {
+		return nil, wrapError(KindInternal, "Failed to read the reported comments", err)
+	}
+
+	reported := make([]models.ReportedComment, 0, len(comments))
+	for _, comment := range comments {
+		reasons := make(map[string]int)
+		for _, report := range comment.Reports {
+			reasons[report.Reason]++
+		}
+		reported = append(reported, models.ReportedComment{
+			Comment: comment,
+			Reports: comment.ReportCount,
+			Reasons: reasons,
+			Hidden:  comment.Hidden,
+		})
+	}
+
+	return reported, nil
+}
+
+// ! AdminDelete deletes any comment with its replies and returns the deleted comment
+func (s *CommentService) AdminDelete(ctx context.Context, commentID string) (*models.Comment, error) {
+	id, err := bson.ObjectIDFromHex(commentID)
+	if err != nil {
+		return nil, newError(KindInvalid, "Invalid comment ID")
+	}
+
+	comment, err := s.comments.GetCommentByID(ctx, id)
+	if err != nil {
+		return nil, newError(KindNotFound, "Comment not found")
+	}
+
+	if _, err := s.comments.DeleteComment(ctx, comment.ID); err != nil {
+		return nil, wrapError(KindInternal, "Failed to delete the comment", err)
+	}
+	return comment, nil
+}
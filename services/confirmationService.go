@@ -0,0 +1,280 @@
+package services
+
+import (
+	"context"
+	"event-horizon/models"
+	"event-horizon/store"
+	"event-horizon/templates"
+	"event-horizon/utils"
+	"fmt"
+	"log"
+	"strings"
+)
+
+//! THIS FILE SENDS THE BOOKING EMAILS (CONFIRMATION WITH TICKETS, QR CODES AND CALENDAR INVITE, CANCELLATION)
+
+/******************************* NOTE **************************************
+
+I DID THE FOLLOWING THINGS IN THIS FILE:
+
+1. SendConfirmation is a booking hook: it emails the user their booking once it is committed.
+
+2. Every attendee's check-in code is attached as a QR code image the HTML body shows inline, the event is attached as event.ics.
+
+3. The bodies come from templates/booking_confirmation.html and .txt, rendered with html/template and text/template.
+
+4. SendGuestConfirmation sends the same email to guests with their access code, the hook skips guest bookings so they get only one.
+
+5. Emails are rendered in the user's LANGUAGE (templates/<locale>/), guests get the default.
+
+6. SendCancellation is a cancel hook, it tells the user their booking and its check-in codes are gone.
+
+7. Users with a verified phone number also get the confirmation by SMS (templates/<locale>/booking_confirmation.sms).
+
+8. Every channel is checked against the user's notification preferences first.
+
+9. SendConfirmation and SendCancellation run as jobs: they send the email themselves and return the errors, so a failed read or a mail server that is down is retried.
+
+********************************* NOTE ************************************/
+
+// ConfirmationService emails booking confirmations
+type ConfirmationService struct {
+	events  store.EventRepository
+	users   store.UserRepository
+	mailer  utils.Mailer
+	sms     utils.SMSSender
+	baseURL string
+}
+
+// NewConfirmationService creates a new ConfirmationService, baseURL is the frontend the emailed links point to
+func NewConfirmationService(events store.EventRepository, users store.UserRepository, mailer utils.Mailer, sms utils.SMSSender, baseURL string) *ConfirmationService {
+	return &ConfirmationService{
+		events:  events,
+		users:   users,
+		mailer:  mailer,
+		sms:     sms,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}
+}
+
+// confirmationEmail is what the booking_confirmation templates show
+type confirmationEmail struct {
+	Name          string
+	EventName     string
+	When          string
+	Location      string
+	TicketType    string
+	Quantity      int
+	TransactionID string
+	Total         string
+	Attendees     []confirmationAttendee
+	BookingURL    string
+	AccessCode    string //? Guests only
+}
+
+type confirmationAttendee struct {
+	Name        string
+	CheckInCode string
+	QRContentID string //? Empty when the QR code couldn't be drawn, the code is still shown
+}
+
+// cancellationEmail is what the booking_cancellation templates show
+type cancellationEmail struct {
+	Name          string
+	EventName     string
+	When          string
+	TicketType    string
+	Quantity      int
+	TransactionID string
+	EventURL      string
+}
+
+// emailTimeLayout formats event times the same way in every language
+const emailTimeLayout = "2006-01-02 15:04 MST"
+
+// eventStartTime is the event's start time in its own timezone, like the API shows it
+func eventStartTime(event *models.Event) string {
+	start := event.StartTime
+	if loc, err := utils.LoadEventLocation(event.Timezone); err == nil {
+		start = start.In(loc)
+	}
+	return start.Format(emailTimeLayout)
+}
+
+// ! SendConfirmation emails the user their tickets after a booking, guest bookings are left to SendGuestConfirmation
+func (s *ConfirmationService) SendConfirmation(ctx context.Context, booking models.Booking) error {
+	if booking.UserID.IsZero() {
+		return nil
+	}
+
+	user, err := s.users.GetUserByID(ctx, booking.UserID)
+	if err != nil {
+		return fmt.Errorf("reading user %s: %w", booking.UserID.Hex(), err)
+	}
+
+	event, err := s.events.GetEventByID(ctx, booking.EventID.Hex())
+	if err != nil {
+		return fmt.Errorf("reading event %s: %w", booking.EventID.Hex(), err)
+	}
+
+	link := s.baseURL + "/bookings/" + booking.ID.Hex()
+	if user.Allows(models.NotificationBookingConfirmation, models.ChannelEmail) {
+		msg, err := s.buildConfirmation(&booking, event, user.Name, user.Email, user.Language, "", link)
+		if err != nil {
+			log.Printf("Booking confirmation: error building the email of booking %s: %v", booking.ID.Hex(), err)
+		} else if err := s.mailer.SendMessage(msg); err != nil {
+			return fmt.Errorf("sending the confirmation email of booking %s: %w", booking.ID.Hex(), err) //? Before the SMS, a retry doesn't text twice
+		}
+	}
+
+	if user.CanReceiveSMS() && user.Allows(models.NotificationBookingConfirmation, models.ChannelSMS) {
+		s.sendSMS(&booking, event, user, link)
+	}
+	return nil
+}
+
+// sendSMS texts the booking's check-in codes to the user's verified phone number
+func (s *ConfirmationService) sendSMS(booking *models.Booking, event *models.Event, user *models.User, link string) {
+	data := confirmationEmail{
+		EventName:  event.Name,
+		When:       eventStartTime(event),
+		TicketType: booking.TicketType,
+		Quantity:   booking.Quantity,
+		BookingURL: link,
+	}
+	for _, attendee := range booking.Attendees {
+		data.Attendees = append(data.Attendees, confirmationAttendee{Name: attendee.Name, CheckInCode: attendee.CheckInCode})
+	}
+
+	body, err := templates.RenderSMS(templates.BookingConfirmation, user.Language, data)
+	if err != nil {
+		log.Printf("Booking confirmation: error building the SMS of booking %s: %v", booking.ID.Hex(), err)
+		return
+	}
+	utils.SendSMSInBackground(s.sms, user.Phone, body)
+}
+
+// ! SendCancellation emails the user that their booking is cancelled
+func (s *ConfirmationService) SendCancellation(ctx context.Context, booking models.Booking) error {
+	if booking.UserID.IsZero() {
+		return nil
+	}
+
+	user, err := s.users.GetUserByID(ctx, booking.UserID)
+	if err != nil {
+		return fmt.Errorf("reading user %s: %w", booking.UserID.Hex(), err)
+	}
+	if !user.Allows(models.NotificationBookingCancellation, models.ChannelEmail) {
+		return nil
+	}
+
+	event, err := s.events.GetEventByID(ctx, booking.EventID.Hex())
+	if err != nil {
+		return fmt.Errorf("reading event %s: %w", booking.EventID.Hex(), err)
+	}
+
+	email, err := templates.Render(templates.BookingCancellation, user.Language, cancellationEmail{
+		Name:          user.Name,
+		EventName:     event.Name,
+		When:          eventStartTime(event),
+		TicketType:    booking.TicketType,
+		Quantity:      booking.Quantity,
+		TransactionID: booking.TransactionID,
+		EventURL:      s.baseURL + "/events/" + event.ID.Hex(),
+	})
+	if err != nil {
+		log.Printf("Booking cancellation: error building the email of booking %s: %v", booking.ID.Hex(), err)
+		return nil
+	}
+
+	if err := s.mailer.SendMessage(utils.Message{To: user.Email, Subject: email.Subject, Text: email.Text, HTML: email.HTML}); err != nil {
+		return fmt.Errorf("sending the cancellation email of booking %s: %w", booking.ID.Hex(), err)
+	}
+	return nil
+}
+
+// ! SendGuestConfirmation emails a guest their tickets with the access code and magic link of the booking
+func (s *ConfirmationService) SendGuestConfirmation(booking *models.Booking, event *models.Event, name, email, code string) {
+	s.send(booking, event, name, email, templates.DefaultLocale, code, s.baseURL+"/guest/bookings/"+code)
+}
+
+// send builds the confirmation email and sends it in the background, failures are only logged
+func (s *ConfirmationService) send(booking *models.Booking, event *models.Event, name, email, language, accessCode, link string) {
+	msg, err := s.buildConfirmation(booking, event, name, email, language, accessCode, link)
+	if err != nil {
+		log.Printf("Booking confirmation: error building the email of booking %s: %v", booking.ID.Hex(), err)
+		return
+	}
+	utils.SendMessageInBackground(s.mailer, msg)
+}
+
+// buildConfirmation renders the bodies and attaches the QR codes and the calendar invite
+func (s *ConfirmationService) buildConfirmation(booking *models.Booking, event *models.Event, name, email, language, accessCode, link string) (utils.Message, error) {
+	data := confirmationEmail{
+		Name:          name,
+		EventName:     event.Name,
+		When:          eventStartTime(event),
+		Location:      confirmationLocation(event),
+		TicketType:    booking.TicketType,
+		Quantity:      booking.Quantity,
+		TransactionID: booking.TransactionID,
+		Total:         models.FormatAmount(booking.TotalPaidMinor, booking.Currency),
+		BookingURL:    link,
+		AccessCode:    accessCode,
+	}
+
+	var attachments []utils.Attachment
+	for i, attendee := range booking.Attendees {
+		entry := confirmationAttendee{Name: attendee.Name, CheckInCode: attendee.CheckInCode}
+
+		qr, err := utils.QRCodePNG(attendee.CheckInCode)
+		if err != nil {
+			log.Printf("Booking confirmation: error drawing the QR code of booking %s: %v", booking.ID.Hex(), err)
+		} else {
+			entry.QRContentID = fmt.Sprintf("ticket-%d@event-horizon", i+1)
+			attachments = append(attachments, utils.Attachment{
+				Filename:    fmt.Sprintf("ticket-%d.png", i+1),
+				ContentType: "image/png",
+				ContentID:   entry.QRContentID,
+				Data:        qr,
+			})
+		}
+		data.Attendees = append(data.Attendees, entry)
+	}
+
+	rendered, err := templates.Render(templates.BookingConfirmation, language, data)
+	if err != nil {
+		return utils.Message{}, err
+	}
+
+	invite := utils.CalendarInvite{
+		UID:         booking.ID.Hex() + "@event-horizon",
+		Start:       event.StartTime,
+		End:         event.EndTime,
+		Summary:     event.Name,
+		Location:    data.Location,
+		Description: event.Description,
+		URL:         link,
+	}
+	attachments = append(attachments, utils.Attachment{
+		Filename:    "event.ics",
+		ContentType: "text/calendar; charset=UTF-8; method=PUBLISH",
+		Data:        invite.ICS(),
+	})
+
+	return utils.Message{
+		To:          email,
+		Subject:     rendered.Subject,
+		Text:        rendered.Text,
+		HTML:        rendered.HTML,
+		Attachments: attachments,
+	}, nil
+}
+
+// confirmationLocation is where the event happens, online events have no place
+func confirmationLocation(event *models.Event) string {
+	if event.EventType == models.EventTypeOnline {
+		return "Online"
+	}
+	return event.Location
+}
@@ -0,0 +1,153 @@
+package services
+
+import (
+	"context"
+	"event-horizon/dto"
+	"event-horizon/models"
+	"event-horizon/store"
+	"log"
+	"sort"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+//! THIS FILE HOLDS THE RULES OF ACCEPTING THE TERMS OF SERVICE AND THE PRIVACY POLICY
+
+/******************************* NOTE **************************************
+
+I DID THE FOLLOWING THINGS IN THIS FILE:
+
+1. The current versions come from TERMS_VERSION / PRIVACY_VERSION, a document without a version isn't asked for.
+
+2. Sign ups must accept the current version of every document, the acceptance is recorded with its time and IP.
+
+3. Bookings record the acceptance when the checkout sends one, guests always have to, users only when
+   the documents changed since they last accepted them.
+
+4. Status tells the frontend which documents changed since the user accepted them, Accept records the new acceptance.
+
+5. Acceptances are never changed or deleted, an older version stays on record next to the newer one.
+
+********************************* NOTE ************************************/
+
+// ConsentService holds the rules of accepting the legal documents
+type ConsentService struct {
+	consents store.ConsentRepository
+	current  models.PolicyVersions
+}
+
+// NewConsentService creates a new ConsentService, current are the versions users must accept
+func NewConsentService(consents store.ConsentRepository, current models.PolicyVersions) *ConsentService {
+	return &ConsentService{
+		consents: consents,
+		current:  current,
+	}
+}
+
+// ! Current returns the versions users must accept
+func (s *ConsentService) Current() models.PolicyVersions {
+	return s.current
+}
+
+// accepted checks that the request accepts the current version of every document and returns them keyed by document
+func (s *ConsentService) accepted(acceptance dto.PolicyAcceptance) (map[string]string, error) {
+	sent := map[string]string{
+		models.PolicyTerms:   acceptance.TermsVersion,
+		models.PolicyPrivacy: acceptance.PrivacyVersion,
+	}
+
+	current := s.current.ByPolicy()
+	for _, policy := range sortedPolicies(current) {
+		switch sent[policy] {
+		case current[policy]:
+		case "":
+			return nil, newError(KindInvalid, policy+"_version is required, accept version "+current[policy]+" (see GET /policies)")
+		default:
+			return nil, newError(KindConflict, "The "+policy+" changed, accept version "+current[policy]+" instead of "+sent[policy])
+		}
+	}
+	return current, nil
+}
+
+// sortedPolicies returns the documents in a fixed order, so errors and records don't change between requests
+func sortedPolicies(versions map[string]string) []string {
+	policies := make([]string, 0, len(versions))
+	for policy := range versions {
+		policies = append(policies, policy)
+	}
+	sort.Strings(policies)
+	return policies
+}
+
+// record stores one acceptance per document, the owner, booking, source and IP are taken from the template
+func (s *ConsentService) record(ctx context.Context, template models.Consent, versions map[string]string) error {
+	consents := make([]models.Consent, 0, len(versions))
+	for _, policy := range sortedPolicies(versions) {
+		consent := template
+		consent.Policy = policy
+		consent.Version = versions[policy]
+		consents = append(consents, consent)
+	}
+	return s.consents.RecordConsents(ctx, consents)
+}
+
+// recordLater stores acceptances given with a sign up or booking that already went through, a failure is only logged
+func (s *ConsentService) recordLater(ctx context.Context, template models.Consent, versions map[string]string) {
+	if err := s.record(ctx, template, versions); err != nil {
+		log.Printf("Error recording %s consents of user %s / guest %s: %v", template.Source, template.UserID.Hex(), template.GuestID.Hex(), err)
+	}
+}
+
+// forBooking checks the acceptance a booking came with and returns what to record, nothing when a user already accepted the current versions
+func (s *ConsentService) forBooking(ctx context.Context, booking *models.Booking, acceptance dto.PolicyAcceptance) (map[string]string, error) {
+	if acceptance != (dto.PolicyAcceptance{}) || booking.UserID.IsZero() {
+		return s.accepted(acceptance)
+	}
+
+	status, err := s.Status(ctx, booking.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if status.ReacceptRequired {
+		return nil, newError(KindConflict, "Our terms changed, accept the current versions (see GET /policies) to book")
+	}
+	return nil, nil
+}
+
+// ! Status returns the user's latest acceptances and which documents they have to accept again
+func (s *ConsentService) Status(ctx context.Context, userID bson.ObjectID) (*models.ConsentStatus, error) {
+	latest, err := s.consents.GetLatestConsents(ctx, userID)
+	if err != nil {
+		return nil, wrapError(KindInternal, "Failed to get your consents", err)
+	}
+
+	status := &models.ConsentStatus{
+		Current:  s.current,
+		Accepted: latest,
+		Outdated: []string{},
+	}
+	current := s.current.ByPolicy()
+	for _, policy := range sortedPolicies(current) {
+		if latest[policy].Version != current[policy] {
+			status.Outdated = append(status.Outdated, policy)
+		}
+	}
+	status.ReacceptRequired = len(status.Outdated) > 0
+
+	return status, nil
+}
+
+// ! Accept records that the user accepted the current versions again, after the documents changed
+func (s *ConsentService) Accept(ctx context.Context, userID bson.ObjectID, acceptance dto.PolicyAcceptance, ip string) (*models.ConsentStatus, error) {
+	versions, err := s.accepted(acceptance)
+	if err != nil {
+		return nil, err
+	}
+
+	template := models.Consent{UserID: userID, Source: models.ConsentAtReacceptance, IP: ip}
+	if err := s.record(ctx, template, versions); err != nil {
+		return nil, wrapError(KindInternal, "Failed to record your consent", err)
+	}
+
+	return s.Status(ctx, userID)
+}
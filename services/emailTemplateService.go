@@ -0,0 +1,143 @@
+package services
+
+import (
+	"event-horizon/templates"
+	"strings"
+	"time"
+)
+
+//! THIS FILE LETS ADMINS LIST AND PREVIEW THE EMAIL TEMPLATES
+
+/******************************* NOTE **************************************
+
+I DID THE FOLLOWING THINGS IN THIS FILE:
+
+1. List returns every email of the template registry with the locales it is translated to.
+
+2. Preview renders an email in a locale with SAMPLE DATA, so admins see a translation without booking anything.
+
+3. The reminder and password reset emails only have their data shape here until their flows send them.
+
+4. Messages that also go out by SMS show their SMS body in the preview.
+
+********************************* NOTE ************************************/
+
+// reminderEmail is what the event_reminder templates show
+type reminderEmail struct {
+	Name       string
+	EventName  string
+	When       string
+	Location   string
+	BookingURL string
+}
+
+// passwordResetEmail is what the password_reset templates show
+type passwordResetEmail struct {
+	Name      string
+	ResetURL  string
+	ExpiresIn string
+}
+
+// EmailTemplateInfo is one email of the registry
+type EmailTemplateInfo struct {
+	Name    string   `json:"name"`
+	Locales []string `json:"locales"`
+}
+
+// EmailPreview is an email rendered with sample data
+type EmailPreview struct {
+	Name   string `json:"name"`
+	Locale string `json:"locale"` //? The locale that was rendered, the default when the requested one has no variant
+	templates.Email
+	SMS string `json:"sms,omitempty"` //? Only for messages that also go out by SMS
+}
+
+// EmailTemplateService lists and previews the email templates
+type EmailTemplateService struct {
+	baseURL string
+}
+
+// NewEmailTemplateService creates a new EmailTemplateService, baseURL is the frontend the sample links point to
+func NewEmailTemplateService(baseURL string) *EmailTemplateService {
+	return &EmailTemplateService{baseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+// ! List returns every email with its locales
+func (s *EmailTemplateService) List() []EmailTemplateInfo {
+	list := []EmailTemplateInfo{}
+	for _, name := range templates.Names() {
+		list = append(list, EmailTemplateInfo{Name: name, Locales: templates.Locales(name)})
+	}
+	return list
+}
+
+// ! Preview renders the email in the language with sample data
+func (s *EmailTemplateService) Preview(name, language string) (*EmailPreview, error) {
+	if !templates.Exists(name) {
+		return nil, newError(KindNotFound, "Email template not found")
+	}
+
+	data := s.sampleData(name)
+	email, err := templates.Render(name, language, data)
+	if err != nil {
+		return nil, wrapError(KindInternal, "Failed to render the email template", err)
+	}
+
+	preview := &EmailPreview{Name: name, Locale: templates.ResolveLocale(name, language), Email: email}
+	if templates.HasSMS(name) {
+		if preview.SMS, err = templates.RenderSMS(name, language, data); err != nil {
+			return nil, wrapError(KindInternal, "Failed to render the SMS template", err)
+		}
+	}
+
+	return preview, nil
+}
+
+// sampleData returns made up data of the shape the email expects
+func (s *EmailTemplateService) sampleData(name string) any {
+	when := time.Date(2030, time.June, 14, 19, 30, 0, 0, time.UTC).Format(emailTimeLayout)
+
+	switch name {
+	case templates.BookingConfirmation:
+		return confirmationEmail{
+			Name:          "Alex Example",
+			EventName:     "Summer Jazz Night",
+			When:          when,
+			Location:      "Riverside Hall",
+			TicketType:    "VIP",
+			Quantity:      2,
+			TransactionID: "TXN-0123456789abcdef",
+			Total:         "120.00 USD",
+			Attendees: []confirmationAttendee{
+				{Name: "Alex Example", CheckInCode: "A1B2C3D4E5F6"},
+				{Name: "Sam Example", CheckInCode: "0F1E2D3C4B5A"},
+			},
+			BookingURL: s.baseURL + "/bookings/sample",
+		}
+	case templates.BookingCancellation:
+		return cancellationEmail{
+			Name:          "Alex Example",
+			EventName:     "Summer Jazz Night",
+			When:          when,
+			TicketType:    "VIP",
+			Quantity:      2,
+			TransactionID: "TXN-0123456789abcdef",
+			EventURL:      s.baseURL + "/events/sample",
+		}
+	case templates.EventReminder:
+		return reminderEmail{
+			Name:       "Alex Example",
+			EventName:  "Summer Jazz Night",
+			When:       when,
+			Location:   "Riverside Hall",
+			BookingURL: s.baseURL + "/bookings/sample",
+		}
+	case templates.PasswordReset:
+		return passwordResetEmail{
+			Name:      "Alex Example",
+			ResetURL:  s.baseURL + "/reset-password/sample",
+			ExpiresIn: "1 hour",
+		}
+	}
+	return nil
+}
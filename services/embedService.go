@@ -0,0 +1,140 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"event-horizon/models"
+	"event-horizon/store"
+	"event-horizon/utils"
+	"html/template"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+//! THIS FILE BUILDS THE EMBEDDABLE WIDGET HOSTS PUT ON THEIR OWN SITES
+
+/******************************* NOTE **************************************
+
+I DID THE FOLLOWING THINGS IN THIS FILE:
+
+1. Events lists the upcoming public events of a host and/or a category, soonest first, slimmed down to what a widget shows.
+
+2. HTML renders the same events as a plain <ul> snippet (html/template escapes every value), the host's site styles the eh-* classes.
+
+********************************* NOTE ************************************/
+
+// Widget sizes
+const (
+	defaultEmbedLimit = 10
+	maxEmbedLimit     = 50
+)
+
+// embedTemplate is the HTML snippet of the widget
+var embedTemplate = template.Must(template.New("embed").Parse(`<ul class="eh-events">
+{{- range .}}
+  <li class="eh-event">
+    <a class="eh-name" href="{{.URL}}" target="_blank" rel="noopener">{{.Name}}</a>
+    <time class="eh-time" datetime="{{.StartTime.Format "2006-01-02T15:04:05Z07:00"}}">{{.StartTime.Format "Mon, Jan 2 2006 15:04 MST"}}</time>
+    <span class="eh-location">{{.Location}}</span>
+    {{- if .SoldOut}}
+    <span class="eh-sold-out">Sold out</span>
+    {{- else if .PriceFrom}}
+    <span class="eh-price">From {{.PriceFrom}}</span>
+    {{- end}}
+  </li>
+{{- else}}
+  <li class="eh-empty">No upcoming events</li>
+{{- end}}
+</ul>
+`))
+
+// EmbedService builds the embeddable event widget
+type EmbedService struct {
+	events  store.EventRepository
+	baseURL string
+}
+
+// NewEmbedService creates a new EmbedService, baseURL is the frontend the widget links to
+func NewEmbedService(events store.EventRepository, baseURL string) *EmbedService {
+	return &EmbedService{
+		events:  events,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}
+}
+
+// ! Events returns the upcoming public events of the widget, hostID and category are optional filters
+func (s *EmbedService) Events(ctx context.Context, hostID, category string, limit int) ([]models.EmbedEvent, error) {
+	if limit == 0 {
+		limit = defaultEmbedLimit
+	}
+	if limit < 0 || limit > maxEmbedLimit {
+		return nil, newError(KindInvalid, "limit must be between 1 and 50")
+	}
+
+	query := models.UpcomingEventsQuery{Category: category, Limit: limit}
+	if hostID != "" {
+		id, err := bson.ObjectIDFromHex(hostID)
+		if err != nil {
+			return nil, newError(KindInvalid, "Invalid host_id")
+		}
+		query.HostID = id
+	}
+
+	events, err := s.events.GetUpcomingEvents(ctx, query)
+	if err != nil {
+		return nil, wrapError(KindInternal, "Failed to load events", err)
+	}
+
+	widget := make([]models.EmbedEvent, 0, len(events))
+	for i := range events {
+		widget = append(widget, s.embedEvent(&events[i]))
+	}
+	return widget, nil
+}
+
+// embedEvent slims an event down to what the widget shows
+func (s *EmbedService) embedEvent(event *models.Event) models.EmbedEvent {
+	utils.LocalizeEventTimes(event)
+
+	embed := models.EmbedEvent{
+		ID:        event.ID,
+		Name:      event.Name,
+		URL:       s.baseURL + "/events/" + event.ID.Hex(),
+		StartTime: event.StartTime,
+		Timezone:  event.Timezone,
+		Location:  confirmationLocation(event),
+		EventType: event.EventType,
+		ImageURL:  event.ImageURL,
+		SoldOut:   true,
+		UpdatedAt: event.LastModified(),
+	}
+
+	var cheapest *models.TicketInfo
+	for i := range event.Tickets {
+		ticket := &event.Tickets[i]
+		if ticket.AvailableQuantity > 0 {
+			embed.SoldOut = false
+		}
+		if cheapest == nil || ticket.PriceMinor < cheapest.PriceMinor {
+			cheapest = ticket
+		}
+	}
+	if cheapest != nil {
+		currency := event.Currency
+		if cheapest.Currency != "" {
+			currency = cheapest.Currency
+		}
+		embed.PriceFrom = models.FormatAmount(cheapest.PriceMinor, currency)
+	}
+	return embed
+}
+
+// ! HTML renders the widget events as an HTML snippet
+func (s *EmbedService) HTML(events []models.EmbedEvent) ([]byte, error) {
+	var snippet bytes.Buffer
+	if err := embedTemplate.Execute(&snippet, events); err != nil {
+		return nil, wrapError(KindInternal, "Failed to render the widget", err)
+	}
+	return snippet.Bytes(), nil
+}
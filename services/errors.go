@@ -0,0 +1,60 @@
+package services
+
+/** *********************  SERVICE LAYER   ********************
+
+The services hold the business rules (host checks, ownership, date and
+ticket validation ...) so they live in one place instead of inside every
+HTTP handler. Controllers only bind requests, call a service and turn the
+result into a response, so another frontend (gRPC, CLI) can reuse the same
+rules.
+
+Plain reads without rules (listings, lookups) stay on the repositories.
+
+Services never know about HTTP. They return an *Error with a Kind and the
+frontend decides which status code that is.
+
+ **************************************/
+
+// Kind classifies a service error
+type Kind int
+
+const (
+	KindInternal        Kind = iota // something failed on our side
+	KindInvalid                     // the input breaks a rule
+	KindUnauthorized                // the caller is not who they claim to be
+	KindForbidden                   // the caller may not do this
+	KindNotFound                    // the target does not exist
+	KindConflict                    // the target changed or is in the wrong state
+	KindGone                        // the target is no longer available
+	KindUnavailable                 // temporarily busy, retry later
+	KindRateLimited                 // the caller is doing this too often, slow down
+	KindCaptchaRequired             // the request must come with a solved CAPTCHA
+)
+
+// Error is a business rule violation (or an internal failure) returned by a service
+type Error struct {
+	Kind    Kind
+	Message string // safe to show to the client
+	Err     error  // underlying error, if any
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// newError returns a service error of the given kind
+func newError(kind Kind, message string) *Error {
+	return &Error{Kind: kind, Message: message}
+}
+
+// wrapError returns a service error of the given kind that keeps the underlying error
+func wrapError(kind Kind, message string, err error) *Error {
+	return &Error{Kind: kind, Message: message, Err: err}
+}
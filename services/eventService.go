@@ -0,0 +1,1699 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"event-horizon/dto"
+	"event-horizon/eventbus"
+	"event-horizon/models"
+	"event-horizon/search"
+	"event-horizon/store"
+	"event-horizon/utils"
+	"fmt"
+	"log"
+	"math"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+//! THIS FILE HOLDS THE BUSINESS RULES OF EVENTS (WHO MAY CHANGE THEM AND WHAT A VALID EVENT IS)
+
+/******************************* NOTE **************************************
+
+I DID THE FOLLOWING THINGS IN THIS FILE:
+
+1. Moved the host and ownership checks, date, tag, geo, event type and session validation out of the EventController into EventService.
+
+2. CreateEvent, UpdateEvent, PatchEvent, DeleteEvent, DuplicateEvent and PublishEvent return service errors instead of HTTP errors.
+
+3. Followers are notified from here when an event gets published, so every frontend does it the same way.
+
+4. JoinLink decides who gets the stream URL of online events and when.
+
+5. SUSPENDED hosts can't create, change or duplicate events.
+
+6. Events get a CURRENCY (USD by default), ticket prices are converted to its minor units and it can't change once tickets were sold.
+
+7. Events can charge TAX, the rate is checked here (bookings keep the rate they were sold with).
+
+8. Created, changed, published and deleted events are published on the EVENT BUS for other services.
+
+9. The same changes are sent to the SEARCH index (only public events are kept in it).
+
+10. CAPACITY ALERT settings are checked here, duplicates keep the settings of their source (and whether ticket counts are hidden).
+
+11. Drafts can be SCHEDULED to go live (publish_at), PublishScheduled publishes the due ones for the background scheduler.
+
+12. Ticket types can have a SALE WINDOW (presale / general sale), duplicates move it along with the new dates.
+
+13. Names are only unique per host and day (in the event's timezone), near-identical events on that day come back as WARNINGS instead of blocking.
+
+14. RescheduleEvent moves an event and keeps its old dates, a move of a day or more asks the attendees to RECONFIRM (or get refunded).
+
+15. Paid events of hosts that aren't VERIFIED can only be saved as drafts (HOST_VERIFICATION_REQUIRED), events carry the host's badge.
+    Tickets priced 0 are FREE, events with only free tickets go live without verification and bulk price changes leave them free.
+
+16. CreateOrgEvent lets owners and managers of an ORGANIZATION create its events, they belong to the owner's host account.
+
+17. BulkEvents publishes, unpublishes, CANCELS, deletes or re-categorizes many of the host's events at once, one result per event.
+
+18. Cancelled events stay on record and their bookings are refunded (RefundCancelledWith), a category that doesn't exist is a client error.
+
+19. BulkPrice raises or lowers the prices of matching ticket types across many events (percent or amount), a DRY RUN only returns the new prices.
+
+20. DuplicateEvent copies through copyEvent, which TEMPLATES use too, createCopy stores a draft made from a template.
+
+21. An event can point to a VENUE (VenueID), its location and geo location are then copied from the venue on create, update and patch.
+
+22. An event can't run at its venue while another one does (the host's own events or public ones), on create, update, patch, reschedule and copies. With allow_venue_overlap it is kept and the response lists the clashing events.
+
+23. Calendar lists the public events of a date range (at most 62 days) by day in the viewer's time zone, an event running for several days is on each of them.
+
+********************************* NOTE ************************************/
+
+// EventService holds the business rules of events
+type EventService struct {
+	events   store.EventRepository
+	users    store.UserRepository
+	bookings store.BookingRepository
+	notifier *utils.NotificationWorker
+	bus      eventbus.Publisher
+	indexer  *search.Indexer
+	orgs     store.OrganizationRepository
+	venues   store.VenueRepository
+
+	requireVerification bool //? Only verified hosts can put paid events live
+
+	refundEvent func(ctx context.Context, event *models.Event) (int, error) //? Refunds the bookings of a cancelled event
+}
+
+// NewEventService creates a new EventService
+func NewEventService(events store.EventRepository, users store.UserRepository, bookings store.BookingRepository, notifier *utils.NotificationWorker, bus eventbus.Publisher, indexer *search.Indexer, orgs store.OrganizationRepository, venues store.VenueRepository, requireVerification bool) *EventService {
+	return &EventService{
+		events:   events,
+		users:    users,
+		bookings: bookings,
+		notifier: notifier,
+		bus:      bus,
+		indexer:  indexer,
+		orgs:     orgs,
+		venues:   venues,
+
+		requireVerification: requireVerification,
+	}
+}
+
+// RefundCancelledWith sets how the bookings of a cancelled event are refunded, set it before serving requests
+func (s *EventService) RefundCancelledWith(refund func(ctx context.Context, event *models.Event) (int, error)) {
+	s.refundEvent = refund
+}
+
+// errUnverifiedHost is returned when an unverified host tries to put a paid event live
+var errUnverifiedHost = newError(KindForbidden, "Verify your host account (POST /hosts/me/verification) before selling paid tickets, or save the event as a draft")
+
+// ! checkCanSell refuses to put a paid event live while its host isn't verified
+func (s *EventService) checkCanSell(event *models.Event) error {
+	if s.requireVerification && !event.HostVerified && event.HasPaidTickets() {
+		return errUnverifiedHost
+	}
+	return nil
+}
+
+// reindex sends the stored event to the search index, which keeps it only while it is public
+func (s *EventService) reindex(ctx context.Context, eventID bson.ObjectID) {
+	event, err := s.events.GetEventByID(ctx, eventID.Hex())
+	if err != nil {
+		return
+	}
+	s.indexer.Update(*event)
+}
+
+// maxEventTags is the maximum number of tags an event can have
+const maxEventTags = 10
+
+// streamURLRevealWindow is how long before start time attendees can get the stream URL
+const streamURLRevealWindow = 15 * time.Minute
+
+// ! requireHost loads the user behind the token and checks that they are a host
+func (s *EventService) requireHost(ctx context.Context, email, action string) (*models.User, error) {
+	user, err := s.users.FindUserByEmail(ctx, email)
+	if err != nil {
+		return nil, newError(KindUnauthorized, "User not found")
+	}
+
+	if !user.IsHost {
+		return nil, newError(KindForbidden, "Only hosts can "+action+" events")
+	}
+
+	if user.IsSuspended() {
+		return nil, newError(KindForbidden, "Your host account is suspended")
+	}
+
+	return user, nil
+}
+
+// ! managedEvent loads an event the host (or a co-host) is about to change
+func (s *EventService) managedEvent(ctx context.Context, user *models.User, id, action string) (*models.Event, error) {
+	event, err := s.events.GetEventByID(ctx, id)
+	if err != nil {
+		return nil, newError(KindNotFound, "Event not found")
+	}
+
+	if !event.IsManagedBy(user.ID) {
+		return nil, newError(KindForbidden, "You can only "+action+" your own events")
+	}
+
+	return event, nil
+}
+
+// ! applyVenue copies the location of the event's venue onto it, so every event at a venue shows the same address
+func (s *EventService) applyVenue(ctx context.Context, event *models.Event) error {
+	if event.VenueID == nil {
+		return nil
+	}
+
+	venue, err := s.venues.GetVenueByID(ctx, *event.VenueID)
+	if err != nil {
+		if errors.Is(err, store.ErrVenueNotFound) {
+			return newError(KindInvalid, "venue not found")
+		}
+		return wrapError(KindInternal, "Failed to get venue", err)
+	}
+
+	event.Location = venue.Location()
+	event.GeoLocation = venue.GeoLocation
+	return nil
+}
+
+// ! checkVenueOverlap refuses an event that runs while another event at its venue does, unless the host allowed it.
+// An allowed overlap is kept on the event (VenueConflicts) so the response can show it
+func (s *EventService) checkVenueOverlap(ctx context.Context, event *models.Event) error {
+	event.VenueConflicts = nil
+	if event.VenueID == nil || event.Status == models.EventStatusCancelled {
+		return nil
+	}
+
+	others, err := s.events.GetOverlappingVenueEvents(ctx, *event.VenueID, event.HostID, event.ID, event.StartTime, event.EndTime)
+	if err != nil {
+		return wrapError(KindInternal, "Failed to check the venue's schedule", err)
+	}
+	if len(others) == 0 {
+		return nil
+	}
+
+	if !event.AllowVenueOverlap {
+		message := fmt.Sprintf("The venue is taken by %s from %s to %s (UTC)", others[0].Name,
+			others[0].StartTime.UTC().Format(time.RFC3339), others[0].EndTime.UTC().Format(time.RFC3339))
+		if len(others) > 1 {
+			message += fmt.Sprintf(" and %d more events", len(others)-1)
+		}
+		return newError(KindConflict, message+", pick other times or send allow_venue_overlap to keep both")
+	}
+
+	for _, other := range others {
+		event.VenueConflicts = append(event.VenueConflicts, models.VenueConflict{
+			EventID:   other.ID,
+			Name:      other.Name,
+			StartTime: other.StartTime,
+			EndTime:   other.EndTime,
+			SameHost:  other.HostID == event.HostID,
+		})
+	}
+	return nil
+}
+
+// ! validateEvent checks a full event (create and update) and normalizes its times, tags and sessions
+func validateEvent(event *models.Event, existingSessions []models.Session) error {
+	//? Validate that category_name is provided
+	if event.CategoryName == "" {
+		return newError(KindInvalid, "category_name is required")
+	}
+
+	//? Validate the timezone and normalize all times to UTC
+	if err := utils.NormalizeEventTimes(event); err != nil {
+		return newError(KindInvalid, err.Error())
+	}
+
+	//? Validate that event date is not in the past (compare dates only, in the event's timezone)
+	if utils.IsEventDateInPast(event) {
+		return newError(KindInvalid, "event date cannot be in the past")
+	}
+
+	//? Clean up tags
+	event.Tags = utils.NormalizeTags(event.Tags)
+	if len(event.Tags) > maxEventTags {
+		return newError(KindInvalid, fmt.Sprintf("an event can have at most %d tags", maxEventTags))
+	}
+
+	//? Validate the optional geo location
+	if err := validateGeoLocation(event.GeoLocation); err != nil {
+		return newError(KindInvalid, err.Error())
+	}
+
+	//? Validate event type and stream URL for online/hybrid events
+	if err := validateEventType(event); err != nil {
+		return newError(KindInvalid, err.Error())
+	}
+
+	//? Validate the currency and price the tickets in its minor units
+	if err := validateCurrency(event); err != nil {
+		return newError(KindInvalid, err.Error())
+	}
+
+	//? Validate the optional tax rate
+	if err := validateTax(event); err != nil {
+		return newError(KindInvalid, err.Error())
+	}
+
+	//? Validate the optional capacity alert settings
+	if err := validateCapacityAlerts(event.CapacityAlerts); err != nil {
+		return newError(KindInvalid, err.Error())
+	}
+
+	//? Validate the optional sale windows of the ticket types
+	if err := validateSaleWindows(event); err != nil {
+		return newError(KindInvalid, err.Error())
+	}
+
+	//? Validate sessions (keeping booked capacity of existing ones) and derive start/end time from them
+	if err := prepareSessions(event, existingSessions); err != nil {
+		return newError(KindInvalid, err.Error())
+	}
+
+	//? Validate that end_time is after start_time
+	if !event.EndTime.After(event.StartTime) {
+		return newError(KindInvalid, "end time must be after start time")
+	}
+
+	return nil
+}
+
+// ! prepareSessions validates sessions, assigns IDs and capacity, and spans the event start/end over all sessions
+func prepareSessions(event *models.Event, existing []models.Session) error {
+	if len(event.Sessions) == 0 {
+		return nil
+	}
+
+	//? Index the existing sessions so updates keep their booked capacity
+	existingByID := make(map[bson.ObjectID]models.Session, len(existing))
+	for _, session := range existing {
+		existingByID[session.ID] = session
+	}
+
+	for i := range event.Sessions {
+		session := &event.Sessions[i]
+
+		if session.Title == "" {
+			return errors.New("session title is required")
+		}
+
+		if !session.EndTime.After(session.StartTime) {
+			return errors.New("session end time must be after start time: " + session.Title)
+		}
+
+		if session.Capacity < 0 {
+			return errors.New("session capacity cannot be negative: " + session.Title)
+		}
+
+		old, found := existingByID[session.ID]
+		if session.ID.IsZero() || !found {
+			//? New session
+			session.ID = bson.NewObjectID()
+			session.AvailableCapacity = session.Capacity
+			continue
+		}
+
+		//? Existing session, keep what is already booked
+		booked := old.Capacity - old.AvailableCapacity
+		if session.Capacity > 0 && session.Capacity < booked {
+			return errors.New("session capacity cannot be lower than seats already booked: " + session.Title)
+		}
+		session.AvailableCapacity = session.Capacity - booked
+		if session.Capacity == 0 {
+			session.AvailableCapacity = 0
+		}
+	}
+
+	//! The event spans from the first session start to the last session end
+	event.StartTime = event.Sessions[0].StartTime
+	event.EndTime = event.Sessions[0].EndTime
+	for _, session := range event.Sessions[1:] {
+		if session.StartTime.Before(event.StartTime) {
+			event.StartTime = session.StartTime
+		}
+		if session.EndTime.After(event.EndTime) {
+			event.EndTime = session.EndTime
+		}
+	}
+
+	return nil
+}
+
+// ! validateGeoLocation checks that a geo location is a valid GeoJSON Point
+func validateGeoLocation(point *models.GeoPoint) error {
+	if point == nil {
+		return nil
+	}
+
+	if point.Type == "" {
+		point.Type = "Point"
+	}
+
+	if point.Type != "Point" || len(point.Coordinates) != 2 {
+		return errors.New("geo_location must be a GeoJSON Point with [longitude, latitude]")
+	}
+
+	lng, lat := point.Coordinates[0], point.Coordinates[1]
+	if lng < -180 || lng > 180 || lat < -90 || lat > 90 {
+		return errors.New("geo_location coordinates are out of range")
+	}
+
+	return nil
+}
+
+// ! validateEventType defaults the event type and requires a valid stream URL for online/hybrid events
+func validateEventType(event *models.Event) error {
+	if event.EventType == "" {
+		event.EventType = models.EventTypeInPerson
+	}
+
+	switch event.EventType {
+	case models.EventTypeInPerson:
+		return nil
+	case models.EventTypeOnline, models.EventTypeHybrid:
+		if event.StreamURL == "" {
+			return errors.New("stream_url is required for online and hybrid events")
+		}
+		parsed, err := url.ParseRequestURI(event.StreamURL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+			return errors.New("stream_url must be a valid http(s) URL")
+		}
+		return nil
+	default:
+		return errors.New("event_type must be one of in_person, online, hybrid")
+	}
+}
+
+// ! validateCurrency defaults and checks the event currency, then prices every ticket in its minor units.
+// A price of 0 is a free ticket, a price that rounds down to 0 is refused
+func validateCurrency(event *models.Event) error {
+	event.Currency = strings.ToUpper(strings.TrimSpace(event.Currency))
+	if event.Currency != "" && !models.IsCurrencyCode(event.Currency) {
+		return errors.New("currency must be an ISO 4217 code like USD or EUR")
+	}
+
+	paid := make([]bool, len(event.Tickets))
+	for i, ticket := range event.Tickets {
+		if ticket.Price < 0 {
+			return fmt.Errorf("the %s ticket price can't be negative", ticket.Type)
+		}
+		paid[i] = ticket.Price > 0
+	}
+
+	event.ApplyCurrency()
+
+	for i, ticket := range event.Tickets {
+		if paid[i] && ticket.PriceMinor == 0 {
+			return fmt.Errorf("the %s ticket price is below the smallest unit of %s", ticket.Type, event.Currency)
+		}
+	}
+
+	return nil
+}
+
+// ! validateTax checks the tax rate, an inclusive flag without a rate means nothing and is dropped
+func validateTax(event *models.Event) error {
+	if event.TaxRate < 0 || event.TaxRate > models.MaxTaxRate {
+		return fmt.Errorf("tax_rate must be between 0 and %g percent", models.MaxTaxRate)
+	}
+	if event.TaxRate == 0 {
+		event.TaxInclusive = false
+	}
+	return nil
+}
+
+// ! validateSaleWindows checks that every ticket type's sale window ends after it starts, and starts before the event ends
+func validateSaleWindows(event *models.Event) error {
+	for _, ticket := range event.Tickets {
+		if ticket.SaleStart != nil && ticket.SaleEnd != nil && !ticket.SaleEnd.After(*ticket.SaleStart) {
+			return fmt.Errorf("the %s ticket sale_end must be after its sale_start", ticket.Type)
+		}
+		if ticket.SaleStart != nil && !event.EndTime.IsZero() && !ticket.SaleStart.Before(event.EndTime) {
+			return fmt.Errorf("the %s ticket sale_start must be before the event ends", ticket.Type)
+		}
+	}
+	return nil
+}
+
+// maxCapacityThresholds is how many capacity alerts an event can have per ticket type
+const maxCapacityThresholds = 5
+
+// ! validateCapacityAlerts checks the thresholds (1-100 percent) and sorts them without duplicates
+func validateCapacityAlerts(settings *models.CapacityAlertSettings) error {
+	if settings == nil {
+		return nil
+	}
+	if len(settings.Thresholds) > maxCapacityThresholds {
+		return fmt.Errorf("capacity_alerts can have at most %d thresholds", maxCapacityThresholds)
+	}
+
+	thresholds := make([]int, 0, len(settings.Thresholds))
+	for _, threshold := range settings.Thresholds {
+		if threshold < 1 || threshold > 100 {
+			return errors.New("capacity_alerts thresholds must be between 1 and 100 percent")
+		}
+		if !slices.Contains(thresholds, threshold) {
+			thresholds = append(thresholds, threshold)
+		}
+	}
+	slices.Sort(thresholds)
+	settings.Thresholds = thresholds
+
+	return nil
+}
+
+// ! checkCurrencyChange refuses a new currency once tickets were sold in the old one
+func (s *EventService) checkCurrencyChange(ctx context.Context, existing, updated *models.Event) error {
+	current := existing.Currency
+	if current == "" {
+		current = models.DefaultCurrency
+	}
+	if updated.Currency == current {
+		return nil
+	}
+
+	sold, err := s.bookings.GetSoldTicketCounts(ctx, existing.ID)
+	if err != nil {
+		return wrapError(KindInternal, "Failed to update event", err)
+	}
+	for _, count := range sold {
+		if count > 0 {
+			return newError(KindInvalid, "currency can't change once tickets were sold")
+		}
+	}
+
+	return nil
+}
+
+// writeError turns the store errors of an event write into service errors
+func writeError(err error, conflictMessage, message string) error {
+	if errors.Is(err, store.ErrEventChanged) {
+		return wrapError(KindConflict, conflictMessage, err)
+	}
+	if errors.Is(err, store.ErrTicketsBelowSold) || errors.Is(err, store.ErrSessionBelowBooked) || errors.Is(err, store.ErrCategoryNotFound) {
+		return wrapError(KindInvalid, err.Error(), err) //? the message says which ticket type, session or category
+	}
+	return wrapError(KindInternal, message, err)
+}
+
+// ! CreateEvent creates an event for the host behind hostEmail and returns the existing events it looks like
+func (s *EventService) CreateEvent(ctx context.Context, hostEmail string, event *models.Event) ([]models.DuplicateWarning, error) {
+	user, err := s.requireHost(ctx, hostEmail, "create")
+	if err != nil {
+		return nil, err
+	}
+
+	return s.createEvent(ctx, user, event)
+}
+
+// ! CreateOrgEvent creates an event of an organization for one of its owners or managers, the org's owner is its host
+func (s *EventService) CreateOrgEvent(ctx context.Context, userID bson.ObjectID, orgID string, event *models.Event) ([]models.DuplicateWarning, error) {
+	org, err := loadOrg(ctx, s.orgs, userID, orgID, models.OrgRoleOwner, models.OrgRoleManager)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.users.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, newError(KindUnauthorized, "User not found")
+	}
+	if user.IsSuspended() {
+		return nil, newError(KindForbidden, "Your host account is suspended")
+	}
+
+	owner, err := s.users.GetUserByID(ctx, org.OwnerID)
+	if err != nil {
+		return nil, wrapError(KindInternal, "Failed to load the organization's owner", err)
+	}
+	if !owner.IsHost || owner.IsSuspended() {
+		return nil, newError(KindForbidden, "The organization's owner can't host events right now")
+	}
+
+	event.OrgID = &org.ID
+	event.OrgManagers, event.OrgScanners = org.Staff()
+
+	return s.createEvent(ctx, owner, event)
+}
+
+// createEvent validates and stores a new event of the host
+func (s *EventService) createEvent(ctx context.Context, user *models.User, event *models.Event) ([]models.DuplicateWarning, error) {
+	//? The event belongs to the host, for organization events that's the owner
+	event.HostID = user.ID
+	event.HostVerified = user.IsVerified()
+
+	if err := s.applyVenue(ctx, event); err != nil {
+		return nil, err
+	}
+
+	if err := validateEvent(event, nil); err != nil {
+		return nil, err
+	}
+
+	//? A scheduled event stays a draft until its publish_at
+	if event.PublishAt != nil {
+		if err := validatePublishAt(event, *event.PublishAt); err != nil {
+			return nil, err
+		}
+		event.Status = models.EventStatusDraft
+	}
+
+	//? Drafts can be prepared before the host is verified, they just can't go live
+	if event.Status != models.EventStatusDraft {
+		if err := s.checkCanSell(event); err != nil {
+			return nil, err
+		}
+	}
+
+	//? The same host can't list the same name twice on one day, anything close is only a warning
+	warnings, err := s.checkDuplicates(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkVenueOverlap(ctx, event); err != nil {
+		return nil, err
+	}
+
+	//? Create the event in database (CategoryID lookup happens in store)
+	if err := s.events.CreateEvent(ctx, event); err != nil {
+		return nil, wrapError(KindInternal, "Failed to create event", err)
+	}
+	s.bus.Publish(ctx, eventbus.NewEventMessage(eventbus.EventCreated, event))
+	s.indexer.Update(*event)
+
+	//? Let the host's followers know in the background (events under review are announced once approved)
+	if event.IsPublished() {
+		s.notifier.NotifyNewEvent(*event)
+		s.bus.Publish(ctx, eventbus.NewEventMessage(eventbus.EventPublished, event))
+	}
+
+	return warnings, nil
+}
+
+// duplicateSimilarity is how alike two names must be (utils.NameSimilarity) to warn about a possible duplicate
+const duplicateSimilarity = 0.8
+
+// checkDuplicates refuses a name the host already uses on the event's day and warns about events on that day with nearly the same name
+func (s *EventService) checkDuplicates(ctx context.Context, event *models.Event) ([]models.DuplicateWarning, error) {
+	//? The day in the event's own timezone, times are already normalized to UTC
+	loc, err := utils.LoadEventLocation(event.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	local := event.StartTime.In(loc)
+	dayStart := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+
+	candidates, err := s.events.GetEventsStartingBetween(ctx, event.HostID, dayStart, dayStart.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, wrapError(KindInternal, "Failed to check for duplicate events", err)
+	}
+
+	var warnings []models.DuplicateWarning
+	for _, candidate := range candidates {
+		similarity := utils.NameSimilarity(event.Name, candidate.Name)
+		sameHost := candidate.HostID == event.HostID
+
+		if sameHost && utils.NormalizeName(event.Name) == utils.NormalizeName(candidate.Name) {
+			return nil, newError(KindConflict, "You already have an event named "+candidate.Name+" on that day")
+		}
+		if similarity >= duplicateSimilarity {
+			warnings = append(warnings, models.DuplicateWarning{
+				EventID:    candidate.ID,
+				Name:       candidate.Name,
+				StartTime:  candidate.StartTime,
+				SameHost:   sameHost,
+				Similarity: math.Round(similarity*100) / 100,
+			})
+		}
+	}
+
+	return warnings, nil
+}
+
+// ! UpdateEvent replaces an event, as long as nobody changed it since the client read expectedVersion
+func (s *EventService) UpdateEvent(ctx context.Context, hostEmail, id string, updatedEvent *models.Event, expectedVersion int) error {
+	user, err := s.requireHost(ctx, hostEmail, "update")
+	if err != nil {
+		return err
+	}
+
+	existingEvent, err := s.managedEvent(ctx, user, id, "update")
+	if err != nil {
+		return err
+	}
+
+	//? Preserve the original ID and HostID
+	updatedEvent.ID = existingEvent.ID
+	updatedEvent.HostID = existingEvent.HostID
+	updatedEvent.CreatedAt = existingEvent.CreatedAt
+	updatedEvent.PublishAt = existingEvent.PublishAt //? The schedule only changes through PUT /events/:id/schedule
+
+	if err := s.applyVenue(ctx, updatedEvent); err != nil {
+		return err
+	}
+
+	if err := validateEvent(updatedEvent, existingEvent.Sessions); err != nil {
+		return err
+	}
+
+	updatedEvent.Status = existingEvent.Status //? The store keeps the status, the overlap check needs it
+	if err := s.checkVenueOverlap(ctx, updatedEvent); err != nil {
+		return err
+	}
+
+	if err := s.checkCurrencyChange(ctx, existingEvent, updatedEvent); err != nil {
+		return err
+	}
+
+	if err := s.events.UpdateEvent(ctx, updatedEvent, expectedVersion); err != nil {
+		return writeError(err, "event was changed by someone else, reload it and apply your changes again", "Failed to update event")
+	}
+	s.bus.Publish(ctx, eventbus.NewEventMessage(eventbus.EventUpdated, updatedEvent))
+	s.reindex(ctx, updatedEvent.ID)
+
+	return nil
+}
+
+// ! PatchEvent applies only the sent fields to an event and returns the patched event
+func (s *EventService) PatchEvent(ctx context.Context, hostEmail, id string, req *dto.PatchEventRequest) (*models.Event, error) {
+	user, err := s.requireHost(ctx, hostEmail, "update")
+	if err != nil {
+		return nil, err
+	}
+
+	existingEvent, err := s.managedEvent(ctx, user, id, "update")
+	if err != nil {
+		return nil, err
+	}
+
+	//? Apply the patch on a copy so the existing event stays untouched for comparisons
+	patchedEvent := *existingEvent
+	patchedEvent.Tickets = append([]models.TicketInfo(nil), existingEvent.Tickets...)
+	patchedEvent.Sessions = append([]models.Session(nil), existingEvent.Sessions...)
+
+	fields := req.ApplyTo(&patchedEvent)
+	if len(fields) == 0 {
+		return nil, newError(KindInvalid, "No fields to update")
+	}
+	changed := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		changed[field] = true
+	}
+
+	//? Validate the timezone and normalize all times to UTC
+	if err := utils.NormalizeEventTimes(&patchedEvent); err != nil {
+		return nil, newError(KindInvalid, err.Error())
+	}
+
+	//? A new date (or zone) must not be in the past
+	if (changed["date"] || changed["timezone"]) && utils.IsEventDateInPast(&patchedEvent) {
+		return nil, newError(KindInvalid, "event date cannot be in the past")
+	}
+
+	if changed["category_name"] && patchedEvent.CategoryName == "" {
+		return nil, newError(KindInvalid, "category_name cannot be empty")
+	}
+
+	//? A new venue brings its location along, leaving a venue keeps the location the event had
+	if changed["venue_id"] && patchedEvent.VenueID != nil {
+		if err := s.applyVenue(ctx, &patchedEvent); err != nil {
+			return nil, err
+		}
+		for _, field := range []string{"location", "geo_location"} {
+			if !changed[field] {
+				fields = append(fields, field)
+			}
+		}
+	} else if patchedEvent.VenueID != nil && (changed["location"] || changed["geo_location"]) {
+		return nil, newError(KindInvalid, "The event is held at a venue, change the venue or send venue_id \"\" to set its location")
+	}
+
+	//? Clean up tags
+	if changed["tags"] {
+		patchedEvent.Tags = utils.NormalizeTags(patchedEvent.Tags)
+		if len(patchedEvent.Tags) > maxEventTags {
+			return nil, newError(KindInvalid, fmt.Sprintf("an event can have at most %d tags", maxEventTags))
+		}
+	}
+
+	//? Validate the optional geo location
+	if err := validateGeoLocation(patchedEvent.GeoLocation); err != nil {
+		return nil, newError(KindInvalid, err.Error())
+	}
+
+	//? Validate event type and stream URL for online/hybrid events
+	if err := validateEventType(&patchedEvent); err != nil {
+		return nil, newError(KindInvalid, err.Error())
+	}
+	if changed["event_type"] && !changed["stream_url"] {
+		fields = append(fields, "stream_url") //? in_person clears the stream URL
+	}
+
+	//? New sessions keep booked capacity and move the event start/end time
+	if changed["sessions"] {
+		if err := prepareSessions(&patchedEvent, existingEvent.Sessions); err != nil {
+			return nil, newError(KindInvalid, err.Error())
+		}
+		fields = append(fields, "start_time", "end_time")
+	}
+
+	//? Validate that end_time is after start_time
+	if !patchedEvent.EndTime.After(patchedEvent.StartTime) {
+		return nil, newError(KindInvalid, "end time must be after start time")
+	}
+
+	if changed["tickets"] || changed["sessions"] {
+		if err := validateSaleWindows(&patchedEvent); err != nil {
+			return nil, newError(KindInvalid, err.Error())
+		}
+	}
+
+	//? Only a new venue or new times can make the event clash with another one
+	if changed["venue_id"] || changed["date"] || changed["timezone"] || changed["start_time"] || changed["end_time"] || changed["sessions"] {
+		if err := s.checkVenueOverlap(ctx, &patchedEvent); err != nil {
+			return nil, err
+		}
+	}
+
+	if changed["capacity_alerts"] {
+		if err := validateCapacityAlerts(patchedEvent.CapacityAlerts); err != nil {
+			return nil, newError(KindInvalid, err.Error())
+		}
+	}
+
+	if changed["tax_rate"] || changed["tax_inclusive"] {
+		if err := validateTax(&patchedEvent); err != nil {
+			return nil, newError(KindInvalid, err.Error())
+		}
+		if !changed["tax_inclusive"] {
+			fields = append(fields, "tax_inclusive")
+		}
+	}
+
+	//? A new currency reprices every ticket, new tickets are priced in the current one
+	if changed["currency"] || changed["tickets"] {
+		if err := validateCurrency(&patchedEvent); err != nil {
+			return nil, newError(KindInvalid, err.Error())
+		}
+		if err := s.checkCurrencyChange(ctx, existingEvent, &patchedEvent); err != nil {
+			return nil, err
+		}
+		if !changed["tickets"] {
+			fields = append(fields, "tickets")
+		}
+		if !changed["currency"] {
+			fields = append(fields, "currency")
+		}
+	}
+
+	//? Only the patched fields are written, sessions are guarded against bookings made in the meantime
+	//? (ticket availability is reconciled against sold tickets by the store)
+	guard := bson.M{}
+	if changed["sessions"] {
+		guard["sessions"] = existingEvent.Sessions
+	}
+	if req.Version != nil {
+		for key, value := range store.VersionGuard(*req.Version) {
+			guard[key] = value
+		}
+	}
+
+	if err := s.events.PatchEvent(ctx, &patchedEvent, fields, guard); err != nil {
+		return nil, writeError(err, err.Error(), "Failed to update event")
+	}
+	s.bus.Publish(ctx, eventbus.NewEventMessage(eventbus.EventUpdated, &patchedEvent))
+	s.reindex(ctx, patchedEvent.ID)
+
+	return &patchedEvent, nil
+}
+
+// ! DeleteEvent deletes an event and all its bookings, and returns what was deleted
+func (s *EventService) DeleteEvent(ctx context.Context, hostEmail, id string) (*models.Event, error) {
+	user, err := s.requireHost(ctx, hostEmail, "delete")
+	if err != nil {
+		return nil, err
+	}
+
+	return s.deleteEvent(ctx, user, id)
+}
+
+// deleteEvent deletes one of the host's events (and CASCADE deletes its bookings)
+func (s *EventService) deleteEvent(ctx context.Context, user *models.User, id string) (*models.Event, error) {
+	event, err := s.managedEvent(ctx, user, id, "delete")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.events.DeleteEvent(ctx, event.ID); err != nil {
+		return nil, wrapError(KindInternal, "Failed to delete event", err)
+	}
+	s.bus.Publish(ctx, eventbus.NewEventMessage(eventbus.EventCancelled, event))
+	s.indexer.Remove(event.ID)
+
+	return event, nil
+}
+
+// maxBulkEvents caps how many events one bulk request can change
+const maxBulkEvents = 100
+
+// ! BulkEvents applies one action to many of the host's events, every event gets its own result and a failed one doesn't stop the others
+func (s *EventService) BulkEvents(ctx context.Context, hostEmail string, req *dto.BulkEventRequest) ([]models.BulkEventResult, error) {
+	switch req.Action {
+	case models.BulkActionPublish, models.BulkActionUnpublish, models.BulkActionCancel, models.BulkActionDelete:
+	case models.BulkActionChangeCategory:
+		req.CategoryName = strings.TrimSpace(req.CategoryName)
+		if req.CategoryName == "" {
+			return nil, newError(KindInvalid, "category_name is required to change the category")
+		}
+	default:
+		return nil, newError(KindInvalid, "action must be publish, unpublish, cancel, delete or change_category")
+	}
+
+	if len(req.EventIDs) == 0 {
+		return nil, newError(KindInvalid, "event_ids must contain at least one event")
+	}
+	if len(req.EventIDs) > maxBulkEvents {
+		return nil, newError(KindInvalid, fmt.Sprintf("At most %d events can be changed at once", maxBulkEvents))
+	}
+
+	user, err := s.requireHost(ctx, hostEmail, "change")
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]models.BulkEventResult, 0, len(req.EventIDs))
+	seen := make(map[string]bool, len(req.EventIDs))
+	for _, id := range req.EventIDs {
+		if seen[id] {
+			continue //? The same event twice would fail the second time for no reason
+		}
+		seen[id] = true
+
+		result := models.BulkEventResult{EventID: id}
+		event, refunded, err := s.bulkEvent(ctx, user, id, req)
+		if err != nil {
+			result.Error = bulkErrorMessage(err)
+		} else {
+			result.OK = true
+			result.Refunded = refunded
+			if req.Action != models.BulkActionDelete {
+				result.Status = event.Status
+			}
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// bulkEvent applies the action of a bulk request to one event and returns it as it is now
+func (s *EventService) bulkEvent(ctx context.Context, user *models.User, id string, req *dto.BulkEventRequest) (*models.Event, int, error) {
+	switch req.Action {
+	case models.BulkActionPublish:
+		event, err := s.PublishEvent(ctx, user.ID, id)
+		return event, 0, err
+	case models.BulkActionDelete:
+		event, err := s.deleteEvent(ctx, user, id)
+		return event, 0, err
+	case models.BulkActionUnpublish:
+		event, err := s.unpublishEvent(ctx, user, id)
+		return event, 0, err
+	case models.BulkActionCancel:
+		return s.cancelEvent(ctx, user, id)
+	default:
+		event, err := s.changeCategory(ctx, user, id, req.CategoryName)
+		return event, 0, err
+	}
+}
+
+// ! BulkPrice changes the prices of matching ticket types across many of the host's events, a dry run only returns the new prices
+func (s *EventService) BulkPrice(ctx context.Context, hostEmail string, req *dto.BulkPriceRequest) ([]models.BulkPriceResult, error) {
+	if (req.Percent == nil) == (req.Amount == nil) {
+		return nil, newError(KindInvalid, "Send either percent or amount")
+	}
+	if req.Percent != nil && (*req.Percent <= -100 || *req.Percent == 0) {
+		return nil, newError(KindInvalid, "percent must be above -100 and not 0")
+	}
+	if req.Amount != nil && *req.Amount == 0 {
+		return nil, newError(KindInvalid, "amount can't be 0")
+	}
+
+	if len(req.EventIDs) == 0 {
+		return nil, newError(KindInvalid, "event_ids must contain at least one event")
+	}
+	if len(req.EventIDs) > maxBulkEvents {
+		return nil, newError(KindInvalid, fmt.Sprintf("At most %d events can be changed at once", maxBulkEvents))
+	}
+
+	user, err := s.requireHost(ctx, hostEmail, "change the prices of")
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]models.BulkPriceResult, 0, len(req.EventIDs))
+	seen := make(map[string]bool, len(req.EventIDs))
+	for _, id := range req.EventIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		result := models.BulkPriceResult{EventID: id}
+		event, changes, err := s.repriceEvent(ctx, user, id, req)
+		if err != nil {
+			result.Error = bulkErrorMessage(err)
+		} else {
+			result.OK = true
+			result.EventName = event.Name
+			result.Changes = changes
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// repriceEvent applies the price change of a bulk request to one event, nothing is written on a dry run
+func (s *EventService) repriceEvent(ctx context.Context, user *models.User, id string, req *dto.BulkPriceRequest) (*models.Event, []models.TicketPriceChange, error) {
+	event, err := s.managedEvent(ctx, user, id, "update")
+	if err != nil {
+		return nil, nil, err
+	}
+	if event.Status == models.EventStatusCancelled {
+		return nil, nil, newError(KindInvalid, "The event is cancelled")
+	}
+	if !event.EndTime.After(time.Now()) {
+		return nil, nil, newError(KindInvalid, "The event is already over")
+	}
+
+	//? Bookings keep the price they were sold at, only new sales pay the new price
+	repriced := *event
+	repriced.Tickets = append([]models.TicketInfo(nil), event.Tickets...)
+	repriced.ApplyCurrency() //? Events saved before currencies existed are priced in USD
+
+	var changes []models.TicketPriceChange
+	for i := range repriced.Tickets {
+		ticket := &repriced.Tickets[i]
+		if len(req.TicketTypes) > 0 && !slices.Contains(req.TicketTypes, ticket.Type) {
+			continue
+		}
+		if ticket.PriceMinor == 0 {
+			continue //! free tickets stay free, selling them is an event edit (unverified hosts can't)
+		}
+
+		oldMinor := ticket.PriceMinor
+		newMinor := oldMinor
+		if req.Percent != nil {
+			newMinor = int64(math.Round(float64(oldMinor) * (1 + *req.Percent/100)))
+		} else {
+			newMinor += models.ToMinorUnits(*req.Amount, repriced.Currency)
+		}
+		if newMinor <= 0 {
+			return nil, nil, newError(KindInvalid, "The new price of "+ticket.Type+" tickets would be 0 or less")
+		}
+
+		ticket.PriceMinor = newMinor
+		ticket.Price = models.FromMinorUnits(newMinor, repriced.Currency)
+		ticket.Currency = repriced.Currency
+		changes = append(changes, models.TicketPriceChange{
+			TicketType:    ticket.Type,
+			Currency:      repriced.Currency,
+			OldPrice:      models.FromMinorUnits(oldMinor, repriced.Currency),
+			NewPrice:      ticket.Price,
+			OldPriceMinor: oldMinor,
+			NewPriceMinor: newMinor,
+		})
+	}
+	if len(changes) == 0 {
+		return nil, nil, newError(KindInvalid, "The event has no paid tickets of these types")
+	}
+
+	if req.DryRun {
+		return &repriced, changes, nil
+	}
+
+	//! Guarded by the version read above, a booking or edit in the meantime fails this event only
+	if err := s.events.PatchEvent(ctx, &repriced, []string{"tickets"}, store.VersionGuard(event.Version)); err != nil {
+		return nil, nil, writeError(err, "The event changed while its prices were updated, try again", "Failed to update prices")
+	}
+	s.bus.Publish(ctx, eventbus.NewEventMessage(eventbus.EventUpdated, &repriced))
+	s.reindex(ctx, repriced.ID)
+
+	return &repriced, changes, nil
+}
+
+// bulkErrorMessage is the message of a failed bulk item, internal details stay in the log
+func bulkErrorMessage(err error) string {
+	var serviceErr *Error
+	if !errors.As(err, &serviceErr) {
+		return "Something went wrong"
+	}
+	if serviceErr.Kind == KindInternal && serviceErr.Err != nil {
+		log.Printf("Bulk event action failed: %v", serviceErr.Err)
+	}
+	return serviceErr.Message
+}
+
+// unpublishEvent takes a live (or in review) event back to draft
+func (s *EventService) unpublishEvent(ctx context.Context, user *models.User, id string) (*models.Event, error) {
+	event, err := s.managedEvent(ctx, user, id, "unpublish")
+	if err != nil {
+		return nil, err
+	}
+
+	from := []string{models.EventStatusPublished, models.EventStatusPendingReview}
+	if err := s.events.SetEventStatus(ctx, event.ID, from, models.EventStatusDraft); err != nil {
+		if errors.Is(err, store.ErrStatusChanged) {
+			return nil, wrapError(KindInvalid, "Only published events can be unpublished", err)
+		}
+		return nil, wrapError(KindInternal, "Failed to unpublish event", err)
+	}
+
+	event.Status = models.EventStatusDraft
+	event.PublishAt = nil
+	s.bus.Publish(ctx, eventbus.NewEventMessage(eventbus.EventUpdated, event))
+	s.indexer.Remove(event.ID)
+
+	return event, nil
+}
+
+// cancelEvent calls an event off, it stays on record while its bookings are cancelled and refunded
+func (s *EventService) cancelEvent(ctx context.Context, user *models.User, id string) (*models.Event, int, error) {
+	event, err := s.managedEvent(ctx, user, id, "cancel")
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if !event.EndTime.After(time.Now()) {
+		return nil, 0, newError(KindInvalid, "The event is already over")
+	}
+
+	//? Whatever the event is, as long as it isn't cancelled already
+	from := []string{models.EventStatusDraft, models.EventStatusPendingReview, models.EventStatusPublished, models.EventStatusRejected}
+	if err := s.events.SetEventStatus(ctx, event.ID, from, models.EventStatusCancelled); err != nil {
+		if errors.Is(err, store.ErrStatusChanged) {
+			return nil, 0, wrapError(KindInvalid, "Event is already cancelled", err)
+		}
+		return nil, 0, wrapError(KindInternal, "Failed to cancel event", err)
+	}
+
+	event.Status = models.EventStatusCancelled
+	event.PublishAt = nil
+	s.bus.Publish(ctx, eventbus.NewEventMessage(eventbus.EventCancelled, event))
+	s.indexer.Remove(event.ID)
+
+	//! The event is cancelled already, a failed refund is retried by cancelling the bookings one by one
+	refunded := 0
+	if s.refundEvent != nil {
+		if refunded, err = s.refundEvent(ctx, event); err != nil {
+			log.Printf("Error refunding the bookings of cancelled event %s: %v", event.ID.Hex(), err)
+		}
+	}
+
+	return event, refunded, nil
+}
+
+// changeCategory moves one of the host's events to another category
+func (s *EventService) changeCategory(ctx context.Context, user *models.User, id, categoryName string) (*models.Event, error) {
+	event, err := s.managedEvent(ctx, user, id, "update")
+	if err != nil {
+		return nil, err
+	}
+	if event.CategoryName == categoryName {
+		return event, nil
+	}
+
+	event.CategoryName = categoryName
+	if err := s.events.PatchEvent(ctx, event, []string{"category_name"}, bson.M{}); err != nil {
+		return nil, writeError(err, err.Error(), "Failed to update event")
+	}
+	s.bus.Publish(ctx, eventbus.NewEventMessage(eventbus.EventUpdated, event))
+	s.reindex(ctx, event.ID)
+
+	return event, nil
+}
+
+// ! DuplicateEvent copies one of the host's events into a new draft with new dates
+func (s *EventService) DuplicateEvent(ctx context.Context, hostEmail, id string, req *dto.DuplicateEventRequest) (*models.Event, error) {
+	user, err := s.requireHost(ctx, hostEmail, "duplicate")
+	if err != nil {
+		return nil, err
+	}
+
+	//? Get the source event to verify ownership (only the host, not co-hosts)
+	source, err := s.events.GetEventByID(ctx, id)
+	if err != nil {
+		return nil, newError(KindNotFound, "Event not found")
+	}
+
+	if source.HostID != user.ID {
+		return nil, newError(KindForbidden, "You can only duplicate your own events")
+	}
+
+	if req.Date.IsZero() || req.StartTime.IsZero() || req.EndTime.IsZero() {
+		return nil, newError(KindInvalid, "date, start_time and end_time are required")
+	}
+
+	name, err := s.events.UniqueCopyName(ctx, user.ID, source.Name)
+	if err != nil {
+		return nil, wrapError(KindInternal, "Failed to duplicate event", err)
+	}
+
+	event := copyEvent(source, name, req)
+	if err := prepareCopy(user, event); err != nil {
+		return nil, err
+	}
+	if err := s.checkVenueOverlap(ctx, event); err != nil {
+		return nil, err
+	}
+
+	if err := s.events.CreateEvent(ctx, event); err != nil {
+		return nil, wrapError(KindInternal, "Failed to duplicate event", err)
+	}
+	s.bus.Publish(ctx, eventbus.NewEventMessage(eventbus.EventCreated, event))
+
+	return event, nil
+}
+
+// createCopy stores an event copied from another event or a template as a draft of the host,
+// it returns the host's events it looks like
+func (s *EventService) createCopy(ctx context.Context, user *models.User, event *models.Event) ([]models.DuplicateWarning, error) {
+	if err := prepareCopy(user, event); err != nil {
+		return nil, err
+	}
+
+	warnings, err := s.checkDuplicates(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkVenueOverlap(ctx, event); err != nil {
+		return nil, err
+	}
+
+	if err := s.events.CreateEvent(ctx, event); err != nil {
+		if errors.Is(err, store.ErrCategoryNotFound) {
+			return nil, wrapError(KindInvalid, err.Error(), err) //? The category was deleted since
+		}
+		return nil, wrapError(KindInternal, "Failed to create event", err)
+	}
+	s.bus.Publish(ctx, eventbus.NewEventMessage(eventbus.EventCreated, event))
+
+	return warnings, nil
+}
+
+// copyEvent copies the tickets, description, images and settings of an event into a new draft on the given dates,
+// tickets start fully available again
+func copyEvent(source *models.Event, name string, dates *dto.DuplicateEventRequest) *models.Event {
+	event := &models.Event{
+		OrgID:        source.OrgID,
+		OrgManagers:  source.OrgManagers,
+		OrgScanners:  source.OrgScanners,
+		CategoryName: source.CategoryName,
+		Tags:         source.Tags,
+		Name:         name,
+		Description:  source.Description,
+		Date:         dates.Date,
+		Timezone:     source.Timezone,
+		Currency:     source.Currency,
+		TaxRate:      source.TaxRate,
+		TaxInclusive: source.TaxInclusive,
+		Location:     source.Location,
+		GeoLocation:  source.GeoLocation,
+		VenueID:      source.VenueID,
+		EventType:    source.EventType,
+		StreamURL:    source.StreamURL,
+		ImageURL:     source.ImageURL,
+		Status:       models.EventStatusDraft,
+		StartTime:    dates.StartTime,
+		EndTime:      dates.EndTime,
+
+		CapacityAlerts:   source.CapacityAlerts,
+		HideTicketCounts: source.HideTicketCounts,
+
+		AllowVenueOverlap: dates.AllowVenueOverlap,
+	}
+
+	//? Sessions and sale windows keep their place in the schedule, shifted to the new start time
+	shift := dates.StartTime.Sub(source.StartTime)
+
+	for _, ticket := range source.Tickets {
+		ticket.AvailableQuantity = ticket.TotalQuantity
+		if ticket.SaleStart != nil {
+			start := ticket.SaleStart.Add(shift)
+			ticket.SaleStart = &start
+		}
+		if ticket.SaleEnd != nil {
+			end := ticket.SaleEnd.Add(shift)
+			ticket.SaleEnd = &end
+		}
+		event.Tickets = append(event.Tickets, ticket)
+	}
+	event.ApplyCurrency() //? sources from before currencies existed get priced too
+
+	for _, session := range source.Sessions {
+		event.Sessions = append(event.Sessions, models.Session{
+			Title:     session.Title,
+			StartTime: session.StartTime.Add(shift),
+			EndTime:   session.EndTime.Add(shift),
+			Capacity:  session.Capacity,
+		})
+	}
+
+	return event
+}
+
+// prepareCopy gives a copied event to the host and checks its new dates
+func prepareCopy(user *models.User, event *models.Event) error {
+	event.HostID = user.ID
+	event.HostVerified = user.IsVerified()
+
+	//? Validate the timezone and normalize all times to UTC
+	if err := utils.NormalizeEventTimes(event); err != nil {
+		return newError(KindInvalid, err.Error())
+	}
+
+	if utils.IsEventDateInPast(event) {
+		return newError(KindInvalid, "event date cannot be in the past")
+	}
+
+	//? New session IDs and capacities (also derives start/end time from the sessions)
+	if err := prepareSessions(event, nil); err != nil {
+		return newError(KindInvalid, err.Error())
+	}
+
+	if !event.EndTime.After(event.StartTime) {
+		return newError(KindInvalid, "end time must be after start time")
+	}
+
+	return nil
+}
+
+// ! PublishEvent publishes a draft event and notifies the host's followers
+func (s *EventService) PublishEvent(ctx context.Context, userID bson.ObjectID, id string) (*models.Event, error) {
+	event, err := s.events.GetEventByID(ctx, id)
+	if err != nil {
+		return nil, newError(KindNotFound, "Event not found")
+	}
+
+	if !event.IsManagedBy(userID) {
+		return nil, newError(KindForbidden, "You can only publish your own events")
+	}
+
+	if event.Status == models.EventStatusCancelled {
+		return nil, newError(KindInvalid, "A cancelled event can't be published again")
+	}
+	if event.Status != models.EventStatusDraft {
+		return nil, newError(KindInvalid, "Event is already published")
+	}
+
+	if utils.IsEventDateInPast(event) {
+		return nil, newError(KindInvalid, "event date cannot be in the past")
+	}
+
+	if err := s.checkCanSell(event); err != nil {
+		return nil, err
+	}
+
+	status, err := s.events.PublishEvent(ctx, event.ID)
+	if err != nil {
+		return nil, wrapError(KindInternal, "Failed to publish event", err)
+	}
+	s.announcePublished(ctx, event, status)
+
+	return event, nil
+}
+
+// announcePublished updates a just published draft and lets the search index, followers and the event bus know
+func (s *EventService) announcePublished(ctx context.Context, event *models.Event, status string) {
+	event.Status = status
+	event.PublishAt = nil
+	s.indexer.Update(*event)
+
+	//? Let the host's followers know in the background (events under review are announced once approved)
+	if event.IsPublished() {
+		s.notifier.NotifyNewEvent(*event)
+		s.bus.Publish(ctx, eventbus.NewEventMessage(eventbus.EventPublished, event))
+	}
+}
+
+// ! validatePublishAt checks that a draft is scheduled in the future and before the event starts
+func validatePublishAt(event *models.Event, publishAt time.Time) error {
+	if !publishAt.After(time.Now()) {
+		return newError(KindInvalid, "publish_at must be in the future")
+	}
+	if !publishAt.Before(event.StartTime) {
+		return newError(KindInvalid, "publish_at must be before the event starts")
+	}
+	return nil
+}
+
+// ! SchedulePublish sets when one of the user's drafts goes live, nil cancels the schedule
+func (s *EventService) SchedulePublish(ctx context.Context, userID bson.ObjectID, id string, publishAt *time.Time) (*models.Event, error) {
+	event, err := s.events.GetEventByID(ctx, id)
+	if err != nil {
+		return nil, newError(KindNotFound, "Event not found")
+	}
+
+	if !event.IsManagedBy(userID) {
+		return nil, newError(KindForbidden, "You can only schedule your own events")
+	}
+
+	if event.Status != models.EventStatusDraft {
+		return nil, newError(KindInvalid, "Only draft events can be scheduled, this one is already published")
+	}
+
+	if publishAt != nil {
+		utc := publishAt.UTC()
+		publishAt = &utc
+		if err := validatePublishAt(event, utc); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.events.SetPublishAt(ctx, event.ID, publishAt); err != nil {
+		if errors.Is(err, store.ErrNotDraft) {
+			return nil, wrapError(KindConflict, "The event was published in the meantime", err)
+		}
+		return nil, wrapError(KindInternal, "Failed to schedule event", err)
+	}
+	event.PublishAt = publishAt
+
+	return event, nil
+}
+
+// scheduledBatch is how many due drafts one scheduler run publishes, the rest follow on the next run
+const scheduledBatch = 100
+
+// ! PublishScheduled publishes the drafts whose publish_at has come and returns how many went live.
+// Several instances can run it at once, the store only publishes a draft once.
+func (s *EventService) PublishScheduled(ctx context.Context) (int, error) {
+	events, err := s.events.GetScheduledEvents(ctx, time.Now(), scheduledBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	published := 0
+	for i := range events {
+		event := &events[i]
+
+		//? A draft whose day already passed is not published, only unscheduled
+		if utils.IsEventDateInPast(event) {
+			log.Printf("Scheduled event %s is in the past, not publishing it", event.ID.Hex())
+			if err := s.events.SetPublishAt(ctx, event.ID, nil); err != nil && !errors.Is(err, store.ErrNotDraft) {
+				log.Printf("Error unscheduling event %s: %v", event.ID.Hex(), err)
+			}
+			continue
+		}
+
+		//? The host wasn't verified in time, the draft stays a draft and the host is told why
+		if s.checkCanSell(event) != nil {
+			log.Printf("Host of scheduled event %s is not verified, not publishing it", event.ID.Hex())
+			if err := s.events.SetPublishAt(ctx, event.ID, nil); err != nil && !errors.Is(err, store.ErrNotDraft) {
+				log.Printf("Error unscheduling event %s: %v", event.ID.Hex(), err)
+			}
+			s.notifier.NotifyUser(event.HostID, "event_publish_blocked", "\""+event.Name+"\" was not published, verify your host account to sell paid tickets", event.ID)
+			continue
+		}
+
+		status, err := s.events.PublishEvent(ctx, event.ID)
+		if err != nil {
+			continue //? Published by another instance (or the host) in the meantime
+		}
+		s.announcePublished(ctx, event, status)
+		published++
+	}
+
+	return published, nil
+}
+
+// significantReschedule is how far an event has to move before attendees must reconfirm their bookings
+const significantReschedule = 24 * time.Hour
+
+// How long attendees have to reconfirm a rescheduled event, in days
+const (
+	defaultReconfirmDays = 7
+	maxReconfirmDays     = 30
+)
+
+// ! RescheduleEvent moves one of the user's events to new dates and returns it with the number of bookings that must be
+// reconfirmed. Sessions move along with the start time, a move of significantReschedule or more flags the bookings.
+func (s *EventService) RescheduleEvent(ctx context.Context, userID bson.ObjectID, id string, req *dto.RescheduleEventRequest) (*models.Event, int64, error) {
+	event, err := s.events.GetEventByID(ctx, id)
+	if err != nil {
+		return nil, 0, newError(KindNotFound, "Event not found")
+	}
+
+	if !event.IsManagedBy(userID) {
+		return nil, 0, newError(KindForbidden, "You can only reschedule your own events")
+	}
+	if event.HostSuspended {
+		return nil, 0, newError(KindForbidden, "Your host account is suspended")
+	}
+
+	if req.Date.IsZero() || req.StartTime.IsZero() || req.EndTime.IsZero() {
+		return nil, 0, newError(KindInvalid, "date, start_time and end_time are required")
+	}
+	if req.ReconfirmDays == 0 {
+		req.ReconfirmDays = defaultReconfirmDays
+	}
+	if req.ReconfirmDays < 1 || req.ReconfirmDays > maxReconfirmDays {
+		return nil, 0, newError(KindInvalid, fmt.Sprintf("reconfirm_days must be between 1 and %d", maxReconfirmDays))
+	}
+
+	now := time.Now()
+	if !now.Before(event.EndTime) {
+		return nil, 0, newError(KindConflict, "Events that have ended can't be rescheduled")
+	}
+
+	//? Move a copy so the stored event stays untouched for comparisons
+	rescheduled := *event
+	rescheduled.Date = req.Date
+	rescheduled.StartTime = req.StartTime
+	rescheduled.EndTime = req.EndTime
+
+	shift := req.StartTime.Sub(event.StartTime)
+	rescheduled.Sessions = nil
+	for _, session := range event.Sessions {
+		session.StartTime = session.StartTime.Add(shift)
+		session.EndTime = session.EndTime.Add(shift)
+		rescheduled.Sessions = append(rescheduled.Sessions, session)
+	}
+
+	//? Validate the timezone and normalize all times to UTC
+	if err := utils.NormalizeEventTimes(&rescheduled); err != nil {
+		return nil, 0, newError(KindInvalid, err.Error())
+	}
+	if utils.IsEventDateInPast(&rescheduled) {
+		return nil, 0, newError(KindInvalid, "event date cannot be in the past")
+	}
+
+	//? Sessions keep their IDs and booked seats (also derives start/end time from the sessions)
+	if err := prepareSessions(&rescheduled, event.Sessions); err != nil {
+		return nil, 0, newError(KindInvalid, err.Error())
+	}
+	if !rescheduled.EndTime.After(rescheduled.StartTime) {
+		return nil, 0, newError(KindInvalid, "end time must be after start time")
+	}
+	if err := validateSaleWindows(&rescheduled); err != nil {
+		return nil, 0, newError(KindInvalid, err.Error())
+	}
+
+	rescheduled.AllowVenueOverlap = req.AllowVenueOverlap
+	if err := s.checkVenueOverlap(ctx, &rescheduled); err != nil {
+		return nil, 0, err
+	}
+
+	moved := rescheduled.StartTime.Sub(event.StartTime)
+	if moved == 0 && rescheduled.EndTime.Equal(event.EndTime) {
+		return nil, 0, newError(KindInvalid, "The event already has these dates")
+	}
+	significant := moved.Abs() >= significantReschedule
+
+	rescheduled.Reschedule = &models.Reschedule{
+		PreviousDate:      event.Date,
+		PreviousStartTime: event.StartTime,
+		PreviousEndTime:   event.EndTime,
+		RescheduledAt:     now,
+	}
+	if significant {
+		//! Attendees must be able to answer before the event starts
+		reconfirmBy := now.Add(time.Duration(req.ReconfirmDays) * 24 * time.Hour)
+		if reconfirmBy.After(rescheduled.StartTime) {
+			reconfirmBy = rescheduled.StartTime
+		}
+		rescheduled.Reschedule.ReconfirmBy = &reconfirmBy
+	}
+
+	//? Sessions are guarded against bookings made in the meantime
+	guard := store.VersionGuard(event.Version)
+	guard["sessions"] = event.Sessions
+	fields := []string{"date", "start_time", "end_time", "sessions", "reschedule"}
+	if err := s.events.PatchEvent(ctx, &rescheduled, fields, guard); err != nil {
+		return nil, 0, writeError(err, "event was changed by someone else, reload it and reschedule again", "Failed to reschedule event")
+	}
+	s.bus.Publish(ctx, eventbus.NewEventMessage(eventbus.EventUpdated, &rescheduled))
+	s.reindex(ctx, rescheduled.ID)
+
+	var flagged int64
+	message := rescheduled.Name + " was moved to " + eventStartTime(&rescheduled)
+	if significant {
+		flagged, err = s.bookings.RequestReconfirmation(ctx, rescheduled.ID, *rescheduled.Reschedule.ReconfirmBy)
+		if err != nil {
+			return nil, 0, wrapError(KindInternal, "The event was moved but its attendees could not be asked to reconfirm, reschedule it again", err)
+		}
+		deadline := *rescheduled.Reschedule.ReconfirmBy
+		if loc, err := utils.LoadEventLocation(rescheduled.Timezone); err == nil {
+			deadline = deadline.In(loc)
+		}
+		message += ". Please confirm you can still come or cancel your booking for a refund by " +
+			deadline.Format(emailTimeLayout) + ", unconfirmed bookings are refunded then."
+	}
+
+	//? Attendees hear about every move, only significant ones need an answer
+	attendees, err := confirmedAttendeeIDs(ctx, s.bookings, rescheduled.ID)
+	if err != nil {
+		log.Printf("Error loading the attendees of rescheduled event %s: %v", rescheduled.ID.Hex(), err)
+	}
+	s.notifier.NotifyUsers(attendees, models.NotificationEventRescheduled, message, rescheduled.ID)
+
+	return &rescheduled, flagged, nil
+}
+
+// ! JoinLink returns an online/hybrid event if the user may see its stream URL right now
+func (s *EventService) JoinLink(ctx context.Context, userID bson.ObjectID, id string) (*models.Event, error) {
+	event, err := s.events.GetEventByID(ctx, id)
+	if err != nil {
+		return nil, newError(KindNotFound, "Event not found")
+	}
+
+	if event.EventType != models.EventTypeOnline && event.EventType != models.EventTypeHybrid {
+		return nil, newError(KindInvalid, "This event has no online stream")
+	}
+
+	//? The host and co-hosts can always see the link
+	if event.IsManagedBy(userID) {
+		return event, nil
+	}
+
+	hasBooking, err := s.bookings.HasConfirmedBooking(ctx, userID, event.ID)
+	if err != nil {
+		return nil, wrapError(KindInternal, "Failed to check booking", err)
+	}
+	if !hasBooking {
+		return nil, newError(KindForbidden, "You need a confirmed booking to join this event")
+	}
+
+	now := time.Now()
+	if now.Before(event.StartTime.Add(-streamURLRevealWindow)) {
+		return nil, newError(KindForbidden, "The join link will be available shortly before the event starts")
+	}
+	if now.After(event.EndTime) {
+		return nil, newError(KindGone, "This event has already ended")
+	}
+
+	return event, nil
+}
+
+const (
+	calendarDateLayout  = "2006-01-02"
+	defaultCalendarDays = 31
+	maxCalendarDays     = 62
+	maxCalendarEvents   = 1000
+)
+
+// ! Calendar returns the public events running from one day to another (both included), grouped by day in the given time zone
+func (s *EventService) Calendar(ctx context.Context, from, to, timezone, category string) (*models.EventCalendar, error) {
+	loc, err := utils.LoadEventLocation(timezone)
+	if err != nil {
+		return nil, newError(KindInvalid, err.Error())
+	}
+
+	now := time.Now().In(loc)
+	firstDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	if from != "" {
+		if firstDay, err = time.ParseInLocation(calendarDateLayout, from, loc); err != nil {
+			return nil, newError(KindInvalid, "from must be a date like 2025-01-31")
+		}
+	}
+
+	lastDay := firstDay.AddDate(0, 0, defaultCalendarDays-1)
+	if to != "" {
+		if lastDay, err = time.ParseInLocation(calendarDateLayout, to, loc); err != nil {
+			return nil, newError(KindInvalid, "to must be a date like 2025-01-31")
+		}
+	}
+	if lastDay.Before(firstDay) {
+		return nil, newError(KindInvalid, "to can't be before from")
+	}
+	if lastDay.After(firstDay.AddDate(0, 0, maxCalendarDays-1)) {
+		return nil, newError(KindInvalid, fmt.Sprintf("the calendar shows at most %d days at once", maxCalendarDays))
+	}
+	end := lastDay.AddDate(0, 0, 1)
+
+	//? One more than the limit tells whether the range had to be cut
+	events, err := s.events.GetCalendarEvents(ctx, models.CalendarQuery{
+		From:     firstDay,
+		To:       end,
+		Category: strings.TrimSpace(category),
+		Limit:    maxCalendarEvents + 1,
+	})
+	if err != nil {
+		return nil, wrapError(KindInternal, "Failed to get the calendar", err)
+	}
+
+	calendar := &models.EventCalendar{
+		From:      firstDay.Format(calendarDateLayout),
+		To:        lastDay.Format(calendarDateLayout),
+		Timezone:  loc.String(),
+		Days:      []models.CalendarDay{},
+		Truncated: len(events) > maxCalendarEvents,
+	}
+	if calendar.Truncated {
+		events = events[:maxCalendarEvents]
+	}
+
+	//? An event is listed on every day of the range it runs on, in the calendar's time zone
+	byDay := make(map[string][]*models.EventSummary)
+	for _, event := range events {
+		if event.StartTime == nil || event.EndTime == nil {
+			continue
+		}
+		start := event.StartTime.In(loc)
+		if start.Before(firstDay) {
+			start = firstDay
+		}
+		for day := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, loc); day.Before(end) && day.Before(*event.EndTime); day = day.AddDate(0, 0, 1) {
+			key := day.Format(calendarDateLayout)
+			byDay[key] = append(byDay[key], event)
+		}
+	}
+
+	for day := firstDay; day.Before(end); day = day.AddDate(0, 0, 1) {
+		key := day.Format(calendarDateLayout)
+		if len(byDay[key]) > 0 {
+			calendar.Days = append(calendar.Days, models.CalendarDay{Date: key, Events: byDay[key]})
+		}
+	}
+
+	//? Grouped in the calendar's zone, shown in each event's own zone like every event response
+	for _, event := range events {
+		utils.LocalizeSummaryTimes(event)
+	}
+
+	return calendar, nil
+}
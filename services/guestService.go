@@ -0,0 +1,156 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"event-horizon/dto"
+	"event-horizon/models"
+	"event-horizon/store"
+	"net/mail"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+//! THIS FILE HOLDS THE RULES OF GUEST CHECKOUT (BOOKING WITH ONLY A NAME AND AN EMAIL)
+
+/******************************* NOTE **************************************
+
+I DID THE FOLLOWING THINGS IN THIS FILE:
+
+1. CreateGuestBooking books for a guest record of the email and mails a magic link with the booking's access code.
+
+2. Only the SHA-256 of the access code is stored, the code itself is only in the email, never in a response.
+   Whoever books with an email can't read the code, so holding it proves access to the mailbox.
+
+3. GuestBooking finds a booking again by its access code.
+
+4. ClaimGuestBookings moves every booking of the guest behind a code into the signed in user's account,
+   only when the account has the guest's email.
+
+5. The magic link goes out in the booking CONFIRMATION email (tickets, QR codes, calendar invite) of ConfirmationService.
+
+********************************* NOTE ************************************/
+
+// GuestService holds the rules of guest checkout
+type GuestService struct {
+	guests   store.GuestRepository
+	bookings store.BookingRepository
+	events   store.EventRepository
+	booking  *BookingService
+	confirm  *ConfirmationService
+}
+
+// NewGuestService creates a new GuestService, the confirmation service emails guests their magic link
+func NewGuestService(guests store.GuestRepository, bookings store.BookingRepository, events store.EventRepository, bookingService *BookingService, confirmations *ConfirmationService) *GuestService {
+	return &GuestService{
+		guests:   guests,
+		bookings: bookings,
+		events:   events,
+		booking:  bookingService,
+		confirm:  confirmations,
+	}
+}
+
+// generateAccessCode returns a random guest access code
+func generateAccessCode() (string, error) {
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
+
+// hashAccessCode returns what is stored for an access code
+func hashAccessCode(code string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(code))))
+	return hex.EncodeToString(sum[:])
+}
+
+// ! CreateGuestBooking books tickets for a guest and returns the booking and its event, the access code is only emailed
+func (s *GuestService) CreateGuestBooking(ctx context.Context, req *dto.GuestBookingRequest) (*models.Booking, *models.Event, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, nil, newError(KindInvalid, "name is required")
+	}
+
+	address, err := mail.ParseAddress(strings.TrimSpace(req.Email))
+	if err != nil {
+		return nil, nil, newError(KindInvalid, "email is not a valid email address")
+	}
+	email := strings.ToLower(address.Address)
+
+	guest, err := s.guests.FindOrCreateGuest(ctx, name, email)
+	if err != nil {
+		return nil, nil, wrapError(KindInternal, "Failed to create the guest", err)
+	}
+
+	code, err := generateAccessCode()
+	if err != nil {
+		return nil, nil, wrapError(KindInternal, "Failed to create the access code", err)
+	}
+
+	booking, event, err := s.booking.CreateGuestBooking(ctx, guest.ID, hashAccessCode(code), &req.CreateBookingRequest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s.confirm.SendGuestConfirmation(booking, event, name, email, code)
+
+	return booking, event, nil
+}
+
+// ! GuestBooking returns the booking behind an access code with its event
+func (s *GuestService) GuestBooking(ctx context.Context, code string) (*models.Booking, *models.Event, error) {
+	booking, err := s.bookings.GetBookingByGuestCode(ctx, hashAccessCode(code))
+	if err != nil {
+		return nil, nil, newError(KindNotFound, "Booking not found")
+	}
+
+	//? The event may be gone or hidden, the booking is still shown
+	event, err := s.events.GetEventByID(ctx, booking.EventID.Hex())
+	if err != nil {
+		event = nil
+	}
+
+	return booking, event, nil
+}
+
+// ! ClaimGuestBookings moves the bookings of the guest behind an access code to the user and returns how many moved.
+// The user's email must be the guest's, a code alone never hands over another person's bookings
+func (s *GuestService) ClaimGuestBookings(ctx context.Context, userID bson.ObjectID, userEmail, code string) (int64, error) {
+	if strings.TrimSpace(code) == "" {
+		return 0, newError(KindInvalid, "code is required")
+	}
+
+	booking, err := s.bookings.GetBookingByGuestCode(ctx, hashAccessCode(code))
+	if err != nil {
+		return 0, newError(KindNotFound, "No guest booking has this code")
+	}
+
+	guest, err := s.guests.GetGuestByID(ctx, booking.GuestID)
+	if err != nil {
+		return 0, wrapError(KindInternal, "Failed to load the guest", err)
+	}
+	if !strings.EqualFold(guest.Email, strings.TrimSpace(userEmail)) {
+		return 0, newError(KindForbidden, "These bookings were made with another email, sign in with that email to claim them")
+	}
+
+	//! Mark the guest first, two accounts racing for the same code can't both get the bookings
+	claimed, err := s.guests.MarkGuestClaimed(ctx, booking.GuestID, userID)
+	if err != nil {
+		return 0, wrapError(KindInternal, "Failed to claim the bookings", err)
+	}
+	if !claimed {
+		return 0, newError(KindConflict, "These bookings were already claimed")
+	}
+
+	moved, err := s.bookings.ClaimGuestBookings(ctx, booking.GuestID, userID)
+	if err != nil {
+		return 0, wrapError(KindInternal, "Failed to move the bookings to your account", err)
+	}
+
+	return moved, nil
+}
@@ -0,0 +1,261 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"event-horizon/dto"
+	"event-horizon/models"
+	"event-horizon/store"
+	"event-horizon/utils"
+	"slices"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+//! THIS FILE HOLDS THE RULES OF ORGANIZATIONS (TEAM ACCOUNTS) AND THEIR MEMBERS
+
+/******************************* NOTE **************************************
+
+I DID THE FOLLOWING THINGS IN THIS FILE:
+
+1. Create makes a host the OWNER of a new organization, its events belong to the owner's host account (payouts, verification, suspension).
+
+2. Only the owner adds, changes and removes members, every other member can leave on their own.
+
+3. MANAGERS create and manage the org's events like co-hosts and must be hosts themselves (like co-hosts), SCANNERS can be any user and only check attendees in.
+
+4. Every membership change is copied onto the org's events (org_managers / org_scanners), the ownership checks read them from there.
+
+5. Events lists every event of the organization, drafts included, to its members.
+
+********************************* NOTE ************************************/
+
+// How long an organization name can be
+const (
+	minOrgNameLength = 2
+	maxOrgNameLength = 100
+)
+
+// OrganizationService holds the rules of organizations
+type OrganizationService struct {
+	orgs     store.OrganizationRepository
+	users    store.UserRepository
+	events   store.EventRepository
+	notifier *utils.NotificationWorker
+}
+
+// NewOrganizationService creates a new OrganizationService
+func NewOrganizationService(orgs store.OrganizationRepository, users store.UserRepository, events store.EventRepository, notifier *utils.NotificationWorker) *OrganizationService {
+	return &OrganizationService{
+		orgs:     orgs,
+		users:    users,
+		events:   events,
+		notifier: notifier,
+	}
+}
+
+// loadOrg loads an organization and checks that the user has one of the roles in it (any role when none are given)
+func loadOrg(ctx context.Context, orgs store.OrganizationRepository, userID bson.ObjectID, id string, roles ...string) (*models.Organization, error) {
+	orgID, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, newError(KindInvalid, "Invalid organization ID")
+	}
+
+	org, err := orgs.GetOrganizationByID(ctx, orgID)
+	if err != nil {
+		return nil, newError(KindNotFound, "Organization not found")
+	}
+
+	role := org.RoleOf(userID)
+	if role == "" {
+		return nil, newError(KindNotFound, "Organization not found") //? Outsiders don't learn it exists
+	}
+	if len(roles) > 0 && !slices.Contains(roles, role) {
+		return nil, newError(KindForbidden, "Your role in this organization doesn't allow that")
+	}
+
+	return org, nil
+}
+
+// ! Create makes a new organization owned by the host
+func (s *OrganizationService) Create(ctx context.Context, userID bson.ObjectID, req *dto.OrganizationRequest) (*models.Organization, error) {
+	user, err := s.users.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, newError(KindNotFound, "User not found")
+	}
+	if !user.IsHost {
+		return nil, newError(KindForbidden, "Only hosts can create organizations")
+	}
+	if user.IsSuspended() {
+		return nil, newError(KindForbidden, "Your host account is suspended")
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if len(name) < minOrgNameLength || len(name) > maxOrgNameLength {
+		return nil, newError(KindInvalid, "name must be between 2 and 100 characters")
+	}
+
+	org := &models.Organization{
+		Name:    name,
+		OwnerID: user.ID,
+		Members: []models.OrgMember{{UserID: user.ID, Role: models.OrgRoleOwner, AddedAt: time.Now()}},
+	}
+	if err := s.orgs.CreateOrganization(ctx, org); err != nil {
+		return nil, wrapError(KindInternal, "Failed to create organization", err)
+	}
+
+	return org, nil
+}
+
+// ! List returns the organizations the user is a member of
+func (s *OrganizationService) List(ctx context.Context, userID bson.ObjectID) ([]models.Organization, error) {
+	orgs, err := s.orgs.GetOrganizationsForUser(ctx, userID)
+	if err != nil {
+		return nil, wrapError(KindInternal, "Failed to list organizations", err)
+	}
+	return orgs, nil
+}
+
+// ! Get returns an organization with its members to one of its members
+func (s *OrganizationService) Get(ctx context.Context, userID bson.ObjectID, id string) (*models.Organization, error) {
+	return loadOrg(ctx, s.orgs, userID, id)
+}
+
+// ! Events returns every event of the organization, drafts included, to one of its members
+func (s *OrganizationService) Events(ctx context.Context, userID bson.ObjectID, id string) ([]models.Event, error) {
+	org, err := loadOrg(ctx, s.orgs, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := s.events.GetEventsByOrg(ctx, org.ID)
+	if err != nil {
+		return nil, wrapError(KindInternal, "Failed to get the organization's events", err)
+	}
+	return events, nil
+}
+
+// checkMemberRole checks a role the owner hands out, managers must be hosts like co-hosts
+func checkMemberRole(user *models.User, role string) error {
+	switch role {
+	case models.OrgRoleManager:
+		if !user.IsHost {
+			return newError(KindInvalid, "Only hosts can be managers, add them as scanner instead")
+		}
+	case models.OrgRoleScanner:
+	default:
+		return newError(KindInvalid, "role must be manager or scanner")
+	}
+	return nil
+}
+
+// ! AddMember adds a user (by email) to the owner's organization
+func (s *OrganizationService) AddMember(ctx context.Context, ownerID bson.ObjectID, id string, req *dto.OrgMemberRequest) (*models.Organization, error) {
+	org, err := loadOrg(ctx, s.orgs, ownerID, id, models.OrgRoleOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.users.FindUserByEmail(ctx, strings.TrimSpace(req.Email))
+	if err != nil {
+		return nil, newError(KindNotFound, "User not found")
+	}
+	if err := checkMemberRole(user, req.Role); err != nil {
+		return nil, err
+	}
+
+	org, err = s.orgs.AddMember(ctx, org.ID, models.OrgMember{UserID: user.ID, Role: req.Role})
+	if err != nil {
+		if errors.Is(err, store.ErrAlreadyMember) {
+			return nil, wrapError(KindConflict, "The user is already a member", err)
+		}
+		return nil, wrapError(KindInternal, "Failed to add member", err)
+	}
+	if err := s.syncStaff(ctx, org); err != nil {
+		return nil, err
+	}
+
+	s.notifier.NotifyUser(user.ID, "org_member_added", "You were added to "+org.Name+" as "+req.Role, bson.NilObjectID)
+
+	return org, nil
+}
+
+// ! SetMemberRole changes the role of a member of the owner's organization
+func (s *OrganizationService) SetMemberRole(ctx context.Context, ownerID bson.ObjectID, id, memberID, role string) (*models.Organization, error) {
+	org, err := loadOrg(ctx, s.orgs, ownerID, id, models.OrgRoleOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := bson.ObjectIDFromHex(memberID)
+	if err != nil {
+		return nil, newError(KindInvalid, "Invalid user ID")
+	}
+	if userID == org.OwnerID {
+		return nil, newError(KindInvalid, "The owner's role can't change")
+	}
+
+	user, err := s.users.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, newError(KindNotFound, "User not found")
+	}
+	if err := checkMemberRole(user, role); err != nil {
+		return nil, err
+	}
+
+	org, err = s.orgs.SetMemberRole(ctx, org.ID, userID, role)
+	if err != nil {
+		if errors.Is(err, store.ErrNotMember) {
+			return nil, wrapError(KindNotFound, "The user is not a member", err)
+		}
+		return nil, wrapError(KindInternal, "Failed to change the member's role", err)
+	}
+	if err := s.syncStaff(ctx, org); err != nil {
+		return nil, err
+	}
+
+	return org, nil
+}
+
+// ! RemoveMember takes a member out of the organization, the owner removes anyone else, a member can remove themselves
+func (s *OrganizationService) RemoveMember(ctx context.Context, userID bson.ObjectID, id, memberID string) (*models.Organization, error) {
+	org, err := loadOrg(ctx, s.orgs, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	memberObjID, err := bson.ObjectIDFromHex(memberID)
+	if err != nil {
+		return nil, newError(KindInvalid, "Invalid user ID")
+	}
+	if memberObjID == org.OwnerID {
+		return nil, newError(KindInvalid, "The owner can't leave the organization")
+	}
+	if memberObjID != userID && userID != org.OwnerID {
+		return nil, newError(KindForbidden, "Only the owner can remove other members")
+	}
+
+	org, err = s.orgs.RemoveMember(ctx, org.ID, memberObjID)
+	if err != nil {
+		if errors.Is(err, store.ErrNotMember) {
+			return nil, wrapError(KindNotFound, "The user is not a member", err)
+		}
+		return nil, wrapError(KindInternal, "Failed to remove member", err)
+	}
+	if err := s.syncStaff(ctx, org); err != nil {
+		return nil, err
+	}
+
+	return org, nil
+}
+
+// syncStaff copies the organization's managers and scanners onto its events, so the event checks see the change right away
+func (s *OrganizationService) syncStaff(ctx context.Context, org *models.Organization) error {
+	managers, scanners := org.Staff()
+	if _, err := s.events.SetOrgStaff(ctx, org.ID, managers, scanners); err != nil {
+		return wrapError(KindInternal, "Members changed, but updating the organization's events failed", err)
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package services
+
+import (
+	"context"
+	"event-horizon/models"
+	"event-horizon/recommendations"
+	"event-horizon/store"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+//! THIS FILE LOADS WHAT THE RECOMMENDATION SCORER NEEDS ("EVENTS YOU MAY LIKE")
+
+/******************************* NOTE **************************************
+
+I DID THE FOLLOWING THINGS IN THIS FILE:
+
+1. Recommend builds the user's profile (bookings, followed hosts, similar attendees) and ranks the upcoming public events with the recommendations package.
+
+2. Only the latest bookings shape the profile, old taste fades out and the lookups stay bounded.
+
+3. Related returns the "you might also like" events of an event page (same host or category, not sold out).
+
+********************************* NOTE ************************************/
+
+// Recommendation list sizes
+const (
+	defaultRecommendationLimit = 10
+	maxRecommendationLimit     = 50
+	recommendationHistory      = 100 //? Latest bookings that shape the profile
+	defaultRelatedLimit        = 6
+)
+
+// RecommendationService recommends events to users
+type RecommendationService struct {
+	bookings store.BookingRepository
+	events   store.EventRepository
+	follows  store.FollowRepository
+}
+
+// NewRecommendationService creates a new RecommendationService
+func NewRecommendationService(bookings store.BookingRepository, events store.EventRepository, follows store.FollowRepository) *RecommendationService {
+	return &RecommendationService{
+		bookings: bookings,
+		events:   events,
+		follows:  follows,
+	}
+}
+
+// ! Recommend returns the upcoming events the user may like, best first. location is optional (the app's current position).
+func (s *RecommendationService) Recommend(ctx context.Context, userID bson.ObjectID, location *recommendations.Location, limit int) ([]recommendations.Recommendation, error) {
+	if limit == 0 {
+		limit = defaultRecommendationLimit
+	}
+	if limit < 0 || limit > maxRecommendationLimit {
+		return nil, newError(KindInvalid, "limit must be between 1 and 50")
+	}
+
+	bookings, err := s.bookings.GetBookingsByUserID(ctx, userID)
+	if err != nil {
+		return nil, wrapError(KindInternal, "Failed to load your bookings", err)
+	}
+	sort.Slice(bookings, func(i, j int) bool { return bookings[i].BookedAt.After(bookings[j].BookedAt) })
+	if len(bookings) > recommendationHistory {
+		bookings = bookings[:recommendationHistory]
+	}
+
+	candidates, err := s.events.GetAllEvents(ctx, nil)
+	if err != nil {
+		return nil, wrapError(KindInternal, "Failed to load events", err)
+	}
+	byID := make(map[bson.ObjectID]*models.Event, len(candidates))
+	for _, event := range candidates {
+		byID[event.ID] = event
+	}
+
+	//? Booked events that are no longer listed (hidden, drafts again ...) still tell what the user likes
+	booked := make(map[bson.ObjectID]*models.Event)
+	bookedIDs := []bson.ObjectID{}
+	for _, booking := range bookings {
+		if booking.Status != "confirmed" {
+			continue
+		}
+		if _, seen := booked[booking.EventID]; seen {
+			continue
+		}
+		event := byID[booking.EventID]
+		if event == nil {
+			event, _ = s.events.GetEventByID(ctx, booking.EventID.Hex()) //? nil when deleted
+		}
+		booked[booking.EventID] = event
+		bookedIDs = append(bookedIDs, booking.EventID)
+	}
+
+	follows, err := s.follows.GetFollowing(ctx, userID)
+	if err != nil {
+		return nil, wrapError(KindInternal, "Failed to load the hosts you follow", err)
+	}
+	hostIDs := make([]bson.ObjectID, 0, len(follows))
+	for _, follow := range follows {
+		hostIDs = append(hostIDs, follow.HostID)
+	}
+
+	profile := recommendations.BuildProfile(bookings, booked, hostIDs)
+	if location != nil {
+		profile.Location = location
+	}
+
+	profile.CoBooked, err = s.bookings.GetCoBookedEventCounts(ctx, userID, bookedIDs)
+	if err != nil {
+		return nil, wrapError(KindInternal, "Failed to load recommendations", err)
+	}
+
+	return recommendations.Rank(profile, userID, candidates, time.Now(), limit), nil
+}
+
+// ! Related returns upcoming public events related to the event, best first
+func (s *RecommendationService) Related(ctx context.Context, eventID string, limit int) ([]recommendations.Recommendation, error) {
+	if limit == 0 {
+		limit = defaultRelatedLimit
+	}
+	if limit < 0 || limit > maxRecommendationLimit {
+		return nil, newError(KindInvalid, "limit must be between 1 and 50")
+	}
+
+	event, err := s.events.GetEventByID(ctx, eventID)
+	if err != nil || event.HostSuspended {
+		return nil, newError(KindNotFound, "Event not found")
+	}
+
+	candidates, err := s.events.GetAllEvents(ctx, nil)
+	if err != nil {
+		return nil, wrapError(KindInternal, "Failed to load events", err)
+	}
+
+	return recommendations.Related(event, candidates, time.Now(), limit), nil
+}
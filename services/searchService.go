@@ -0,0 +1,140 @@
+package services
+
+import (
+	"context"
+	"event-horizon/models"
+	"event-horizon/search"
+	"event-horizon/store"
+	"event-horizon/utils"
+	"log"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+//! THIS FILE HOLDS THE RULES OF EVENT SEARCH (WHAT A VALID QUERY IS AND WHICH HITS MAY BE SHOWN)
+
+/******************************* NOTE **************************************
+
+I DID THE FOLLOWING THINGS IN THIS FILE:
+
+1. SearchEvents checks the query and runs it on the configured search engine (Meilisearch or MongoDB).
+
+2. Without from, only upcoming events are searched.
+
+3. Hits are checked against the database, an index that lags behind never shows deleted or hidden events.
+
+********************************* NOTE ************************************/
+
+// Search page sizes
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 50
+	maxSearchOffset    = 1000 //? Deeper pages should narrow the search instead
+)
+
+// SearchService runs event searches
+type SearchService struct {
+	engine search.Engine
+	events store.EventRepository
+}
+
+// NewSearchService creates a new SearchService
+func NewSearchService(engine search.Engine, events store.EventRepository) *SearchService {
+	return &SearchService{
+		engine: engine,
+		events: events,
+	}
+}
+
+// ! SearchEvents returns a page of public events matching the query with facets for category, month and price
+func (s *SearchService) SearchEvents(ctx context.Context, query models.EventSearchQuery) (*models.EventSearchResult, error) {
+	query.Text = strings.TrimSpace(query.Text)
+	if len(query.Text) > 200 {
+		return nil, newError(KindInvalid, "q can be at most 200 characters")
+	}
+
+	if query.Limit == 0 {
+		query.Limit = defaultSearchLimit
+	}
+	if query.Limit < 0 || query.Limit > maxSearchLimit {
+		return nil, newError(KindInvalid, "limit must be between 1 and 50")
+	}
+	if query.Offset < 0 || query.Offset > maxSearchOffset {
+		return nil, newError(KindInvalid, "offset must be between 0 and 1000")
+	}
+
+	if query.From.IsZero() {
+		query.From = time.Now()
+	}
+	if !query.To.IsZero() && !query.From.Before(query.To) {
+		return nil, newError(KindInvalid, "from must be before to")
+	}
+
+	if (query.MinPrice != nil && *query.MinPrice < 0) || (query.MaxPrice != nil && *query.MaxPrice < 0) {
+		return nil, newError(KindInvalid, "prices cannot be negative")
+	}
+	if query.MinPrice != nil && query.MaxPrice != nil && *query.MinPrice > *query.MaxPrice {
+		return nil, newError(KindInvalid, "min_price must not be above max_price")
+	}
+
+	result, err := s.engine.Search(ctx, query)
+	if err != nil {
+		return nil, wrapError(KindUnavailable, "Search is unavailable right now, please try again", err)
+	}
+
+	if err := s.dropHiddenHits(ctx, result); err != nil {
+		return nil, wrapError(KindInternal, "Failed to search events", err)
+	}
+
+	if result.Facets.Categories == nil {
+		result.Facets.Categories = map[string]int{}
+	}
+	if result.Facets.Months == nil {
+		result.Facets.Months = map[string]int{}
+	}
+	if result.Facets.PriceRanges == nil {
+		result.Facets.PriceRanges = map[string]int{}
+	}
+
+	return result, nil
+}
+
+// dropHiddenHits removes hits whose event was deleted or hidden since it was indexed and localizes the times of the rest
+func (s *SearchService) dropHiddenHits(ctx context.Context, result *models.EventSearchResult) error {
+	if len(result.Hits) == 0 {
+		return nil
+	}
+
+	ids := make([]bson.ObjectID, 0, len(result.Hits))
+	for _, hit := range result.Hits {
+		if id, err := bson.ObjectIDFromHex(hit.ID); err == nil {
+			ids = append(ids, id)
+		}
+	}
+
+	public, err := s.events.FilterPublicEvents(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	hits := result.Hits[:0]
+	for _, hit := range result.Hits {
+		id, _ := bson.ObjectIDFromHex(hit.ID)
+		if !public[id] {
+			log.Printf("Search index is behind, skipping hidden event %s", hit.ID)
+			continue
+		}
+
+		//? Times in the event's own zone, like every other event response
+		if loc, err := utils.LoadEventLocation(hit.Timezone); err == nil {
+			hit.StartTime = hit.StartTime.In(loc)
+			hit.EndTime = hit.EndTime.In(loc)
+		}
+		hits = append(hits, hit)
+	}
+	result.Hits = hits
+
+	return nil
+}
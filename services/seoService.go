@@ -0,0 +1,208 @@
+package services
+
+import (
+	"context"
+	"event-horizon/models"
+	"event-horizon/store"
+	"event-horizon/utils"
+	"strings"
+	"time"
+)
+
+//! THIS FILE BUILDS WHAT SEARCH ENGINES AND FEED READERS READ: THE SITEMAP, THE STRUCTURED DATA OF EVENT PAGES AND THE RSS FEED
+
+/******************************* NOTE **************************************
+
+I DID THE FOLLOWING THINGS IN THIS FILE:
+
+1. Sitemap lists the page of every public event (APP_BASE_URL/events/<id>) with the time it last changed.
+
+2. EventJSONLD describes a public event as a schema.org Event: dates in the event's time zone, the venue and/or the online page, one offer per ticket type and the host as organizer.
+
+3. Drafts, events in review and events of suspended hosts are never in either, the JSON-LD of such an event is a 404.
+
+4. Feed is the RSS feed of upcoming public events (optionally of one category), the latest listed first so new events reach the syndicating sites.
+
+********************************* NOTE ************************************/
+
+// feedSize is how many events the RSS feed carries
+const feedSize = 50
+
+// SEOService builds sitemaps, structured data and feeds for the marketing site
+type SEOService struct {
+	events  store.EventRepository
+	users   store.UserRepository
+	baseURL string
+}
+
+// NewSEOService creates a new SEOService, baseURL is the frontend the event pages live on
+func NewSEOService(events store.EventRepository, users store.UserRepository, baseURL string) *SEOService {
+	return &SEOService{
+		events:  events,
+		users:   users,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}
+}
+
+// eventURL is the public page of the event
+func (s *SEOService) eventURL(id string) string {
+	return s.baseURL + "/events/" + id
+}
+
+// ! Sitemap returns the sitemap XML of every public event page
+func (s *SEOService) Sitemap(ctx context.Context) ([]byte, error) {
+	events, err := s.events.GetSitemapEvents(ctx, utils.MaxSitemapURLs)
+	if err != nil {
+		return nil, wrapError(KindInternal, "Failed to load events", err)
+	}
+
+	urls := make([]utils.SitemapURL, 0, len(events))
+	for i := range events {
+		urls = append(urls, utils.SitemapURL{Loc: s.eventURL(events[i].ID.Hex()), LastMod: events[i].LastModified()})
+	}
+
+	body, err := utils.Sitemap(urls)
+	if err != nil {
+		return nil, wrapError(KindInternal, "Failed to build the sitemap", err)
+	}
+	return body, nil
+}
+
+// ! Feed returns the RSS feed of upcoming public events, category is an exact category name or empty for every event
+func (s *SEOService) Feed(ctx context.Context, category string) ([]byte, error) {
+	events, err := s.events.GetUpcomingEvents(ctx, models.UpcomingEventsQuery{Category: category, Limit: feedSize, NewestFirst: true})
+	if err != nil {
+		return nil, wrapError(KindInternal, "Failed to load events", err)
+	}
+
+	feed := utils.Feed{
+		Title:       "Event Horizon: upcoming events",
+		Link:        s.baseURL + "/events",
+		Description: "Upcoming events on Event Horizon",
+	}
+	if category != "" {
+		feed.Title += " in " + category
+		feed.Description += " in " + category
+	}
+
+	for i := range events {
+		event := &events[i]
+		description := eventStartTime(event) + ", " + confirmationLocation(event)
+		if event.Description != "" {
+			description += "\n\n" + event.Description
+		}
+		feed.Items = append(feed.Items, utils.FeedItem{
+			Title:       event.Name,
+			Link:        s.eventURL(event.ID.Hex()),
+			Description: description,
+			Category:    event.CategoryName,
+			PubDate:     event.CreatedAt,
+		})
+	}
+
+	body, err := feed.RSS()
+	if err != nil {
+		return nil, wrapError(KindInternal, "Failed to build the feed", err)
+	}
+	return body, nil
+}
+
+// ! EventJSONLD returns the schema.org structured data of a public event
+func (s *SEOService) EventJSONLD(ctx context.Context, eventID string) (*models.StructuredEvent, error) {
+	event, err := s.events.GetEventByID(ctx, eventID)
+	if err != nil || !event.IsPublished() {
+		return nil, newError(KindNotFound, "Event not found")
+	}
+	utils.LocalizeEventTimes(event) //? Dates carry the event's own UTC offset
+
+	url := s.eventURL(event.ID.Hex())
+	data := &models.StructuredEvent{
+		Context:             models.SchemaContext,
+		Type:                "Event",
+		Name:                event.Name,
+		Description:         event.Description,
+		URL:                 url,
+		StartDate:           event.StartTime.Format(time.RFC3339),
+		EndDate:             event.EndTime.Format(time.RFC3339),
+		EventStatus:         models.SchemaEventScheduled,
+		EventAttendanceMode: models.SchemaOfflineAttendance,
+		Location:            []models.StructuredLocation{},
+	}
+	if event.ImageURL != "" {
+		data.Image = []string{event.ImageURL}
+	}
+
+	switch event.EventType {
+	case models.EventTypeOnline:
+		data.EventAttendanceMode = models.SchemaOnlineAttendance
+	case models.EventTypeHybrid:
+		data.EventAttendanceMode = models.SchemaMixedAttendance
+	}
+	if event.EventType != models.EventTypeOnline {
+		data.Location = append(data.Location, structuredPlace(event))
+	}
+	if event.EventType == models.EventTypeOnline || event.EventType == models.EventTypeHybrid {
+		data.Location = append(data.Location, models.StructuredLocation{Type: "VirtualLocation", URL: url})
+	}
+
+	now := time.Now()
+	for _, ticket := range event.Tickets {
+		data.Offers = append(data.Offers, structuredOffer(event, ticket, url, now))
+	}
+
+	//? No organizer beats failing the whole page
+	if host, err := s.users.GetUserByID(ctx, event.HostID); err == nil {
+		data.Organizer = &models.StructuredOrganizer{Type: "Person", Name: host.Name}
+	}
+
+	return data, nil
+}
+
+// structuredPlace is the venue of an in-person or hybrid event
+func structuredPlace(event *models.Event) models.StructuredLocation {
+	place := models.StructuredLocation{Type: "Place", Name: event.Location, Address: event.Location}
+	if event.GeoLocation != nil && len(event.GeoLocation.Coordinates) == 2 {
+		place.Geo = &models.StructuredGeoData{
+			Type:      "GeoCoordinates",
+			Latitude:  event.GeoLocation.Coordinates[1],
+			Longitude: event.GeoLocation.Coordinates[0],
+		}
+	}
+	return place
+}
+
+// structuredOffer describes one ticket type, availability follows the sale window and what is left
+func structuredOffer(event *models.Event, ticket models.TicketInfo, url string, now time.Time) models.StructuredOffer {
+	currency := ticket.Currency
+	if currency == "" {
+		currency = event.Currency
+	}
+	if currency == "" {
+		currency = models.DefaultCurrency
+	}
+
+	offer := models.StructuredOffer{
+		Type:          "Offer",
+		Name:          ticket.Type,
+		Price:         models.FormatMinorUnits(ticket.PriceMinor, currency),
+		PriceCurrency: currency,
+		Availability:  models.SchemaInStock,
+		URL:           url,
+	}
+	if ticket.SaleStart != nil {
+		offer.ValidFrom = ticket.SaleStart.Format(time.RFC3339)
+	}
+	if ticket.SaleEnd != nil {
+		offer.ValidThrough = ticket.SaleEnd.Format(time.RFC3339)
+	}
+
+	switch {
+	case ticket.SaleStatus(now) == models.SaleUpcoming:
+		offer.Availability = models.SchemaPreSale
+	case ticket.SaleStatus(now) == models.SaleEnded:
+		offer.Availability = models.SchemaDiscontinued
+	case ticket.AvailableQuantity <= 0:
+		offer.Availability = models.SchemaSoldOut
+	}
+	return offer
+}
@@ -0,0 +1,203 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"event-horizon/dto"
+	"event-horizon/models"
+	"event-horizon/store"
+	"event-horizon/utils"
+	"math"
+	"net/url"
+	"sort"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+//! THIS FILE HOLDS THE RULES FOR SHARE LINKS AND THE REFERRAL REPORT
+
+/******************************* NOTE **************************************
+
+I DID THE FOLLOWING THINGS IN THIS FILE:
+
+1. Create gives the event host and co-hosts a short share link (/s/<code>) per channel, with optional UTM parameters.
+
+2. Click counts a visit and returns the event page URL with ?ref=<code> and the UTM parameters.
+
+3. Referrals attributes the confirmed bookings of the event to its share links, everything else is a direct booking.
+
+********************************* NOTE ************************************/
+
+const (
+	maxShareLinksPerEvent = 100
+	maxShareFieldLength   = 100
+	shareCodeAttempts     = 5
+)
+
+// ShareLinkService holds the rules for share links
+type ShareLinkService struct {
+	links    store.ShareLinkRepository
+	events   store.EventRepository
+	bookings store.BookingRepository
+	baseURL  string //? Frontend the links open
+	shortURL string //? Where /s/<code> is served
+}
+
+// NewShareLinkService creates a new ShareLinkService, baseURL is the frontend and shortURL the API host serving /s/<code>
+func NewShareLinkService(links store.ShareLinkRepository, events store.EventRepository, bookings store.BookingRepository, baseURL, shortURL string) *ShareLinkService {
+	return &ShareLinkService{
+		links:    links,
+		events:   events,
+		bookings: bookings,
+		baseURL:  strings.TrimSuffix(baseURL, "/"),
+		shortURL: strings.TrimSuffix(shortURL, "/"),
+	}
+}
+
+// managedEvent returns the event if the user is its host or a co-host
+func (s *ShareLinkService) managedEvent(ctx context.Context, userID bson.ObjectID, eventID string) (*models.Event, error) {
+	event, err := s.events.GetEventByID(ctx, eventID)
+	if err != nil {
+		return nil, newError(KindNotFound, "Event not found")
+	}
+
+	if !event.IsManagedBy(userID) {
+		return nil, newError(KindForbidden, "Only the event host and co-hosts can manage share links")
+	}
+
+	return event, nil
+}
+
+// ! Create makes a new share link for the event
+func (s *ShareLinkService) Create(ctx context.Context, userID bson.ObjectID, eventID string, req *dto.ShareLinkRequest) (*models.ShareLinkStats, error) {
+	link := &models.ShareLink{
+		CreatedBy:   userID,
+		Channel:     strings.ToLower(strings.TrimSpace(req.Channel)),
+		UTMSource:   strings.TrimSpace(req.UTMSource),
+		UTMMedium:   strings.TrimSpace(req.UTMMedium),
+		UTMCampaign: strings.TrimSpace(req.UTMCampaign),
+	}
+	if link.Channel == "" {
+		return nil, newError(KindInvalid, "channel is required")
+	}
+	for _, field := range []string{link.Channel, link.UTMSource, link.UTMMedium, link.UTMCampaign} {
+		if len(field) > maxShareFieldLength {
+			return nil, newError(KindInvalid, "channel and utm fields can be at most 100 characters")
+		}
+	}
+	if link.UTMSource == "" {
+		link.UTMSource = link.Channel
+	}
+
+	event, err := s.managedEvent(ctx, userID, eventID)
+	if err != nil {
+		return nil, err
+	}
+	link.EventID = event.ID
+
+	existing, err := s.links.GetShareLinksByEventID(ctx, event.ID)
+	if err != nil {
+		return nil, wrapError(KindInternal, "Failed to read the share links", err)
+	}
+	if len(existing) >= maxShareLinksPerEvent {
+		return nil, newError(KindConflict, "An event can have at most 100 share links")
+	}
+
+	//? Codes are random, try again on the rare clash
+	for attempt := 0; ; attempt++ {
+		link.Code = utils.GenerateShareCode()
+		err = s.links.CreateShareLink(ctx, link)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, store.ErrShareCodeTaken) || attempt == shareCodeAttempts-1 {
+			return nil, wrapError(KindInternal, "Failed to create the share link", err)
+		}
+	}
+
+	return &models.ShareLinkStats{ShareLink: *link, URL: s.shortURL + "/s/" + link.Code}, nil
+}
+
+// ! Click counts a visit of the share link and returns the event page it opens
+func (s *ShareLinkService) Click(ctx context.Context, code string) (string, error) {
+	code, ok := utils.NormalizeShareCode(code)
+	if !ok {
+		return "", newError(KindNotFound, "Share link not found")
+	}
+
+	link, err := s.links.RecordClick(ctx, code)
+	if err != nil {
+		return "", newError(KindNotFound, "Share link not found")
+	}
+
+	query := url.Values{"ref": {link.Code}}
+	for key, value := range map[string]string{"utm_source": link.UTMSource, "utm_medium": link.UTMMedium, "utm_campaign": link.UTMCampaign} {
+		if value != "" {
+			query.Set(key, value)
+		}
+	}
+
+	return s.baseURL + "/events/" + link.EventID.Hex() + "?" + query.Encode(), nil
+}
+
+// ! Referrals shows which share links of the event sold tickets (event host and co-hosts only)
+func (s *ShareLinkService) Referrals(ctx context.Context, userID bson.ObjectID, eventID string) (*models.ReferralReport, error) {
+	event, err := s.managedEvent(ctx, userID, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	links, err := s.links.GetShareLinksByEventID(ctx, event.ID)
+	if err != nil {
+		return nil, wrapError(KindInternal, "Failed to read the share links", err)
+	}
+
+	bookings, err := s.bookings.GetBookingsByEventID(ctx, event.ID)
+	if err != nil {
+		return nil, wrapError(KindInternal, "Failed to read the bookings", err)
+	}
+
+	report := &models.ReferralReport{
+		EventID:  event.ID,
+		Currency: event.Currency,
+		Links:    make([]models.ShareLinkStats, 0, len(links)),
+	}
+
+	byCode := make(map[string]*models.ReferralStats, len(links))
+	for _, link := range links {
+		report.Links = append(report.Links, models.ShareLinkStats{ShareLink: link, URL: s.shortURL + "/s/" + link.Code})
+	}
+	for i := range report.Links {
+		byCode[report.Links[i].Code] = &report.Links[i].ReferralStats
+	}
+
+	for i := range bookings {
+		booking := &bookings[i]
+		if booking.Status != "confirmed" {
+			continue
+		}
+		report.Total.Add(booking)
+		if stats, ok := byCode[booking.ReferralCode]; ok {
+			stats.Add(booking)
+		} else {
+			report.Direct.Add(booking)
+		}
+	}
+
+	for i := range report.Links {
+		stats := &report.Links[i]
+		if stats.Clicks > 0 {
+			stats.ConversionRate = math.Round(float64(stats.Bookings)/float64(stats.Clicks)*10000) / 10000
+		}
+		stats.Revenue = models.FormatAmount(stats.RevenueMinor, event.Currency)
+	}
+	report.Direct.Revenue = models.FormatAmount(report.Direct.RevenueMinor, event.Currency)
+	report.Total.Revenue = models.FormatAmount(report.Total.RevenueMinor, event.Currency)
+
+	sort.SliceStable(report.Links, func(i, j int) bool {
+		return report.Links[i].Bookings > report.Links[j].Bookings
+	})
+
+	return report, nil
+}
@@ -0,0 +1,179 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"event-horizon/dto"
+	"event-horizon/models"
+	"event-horizon/store"
+	"fmt"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+//! THIS FILE HOLDS THE RULES OF EVENT TEMPLATES
+
+/******************************* NOTE **************************************
+
+I DID THE FOLLOWING THINGS IN THIS FILE:
+
+1. Save keeps a copy of one of the host's events (tickets, description, images and settings) under a name, at most 50 per host.
+
+2. Templates belong to the host who saved them, nobody else can see, use or delete them.
+
+3. CreateEvent makes a new DRAFT from a template on the given dates, just like duplicating an event, sessions and sale windows move along.
+
+********************************* NOTE ************************************/
+
+const (
+	maxTemplatesPerHost   = 50
+	maxTemplateNameLength = 100
+)
+
+// TemplateService holds the rules of event templates
+type TemplateService struct {
+	templates  store.TemplateRepository
+	eventStore store.EventRepository
+	events     *EventService //? Checks the host and creates the events
+}
+
+// NewTemplateService creates a new TemplateService
+func NewTemplateService(templates store.TemplateRepository, eventStore store.EventRepository, events *EventService) *TemplateService {
+	return &TemplateService{
+		templates:  templates,
+		eventStore: eventStore,
+		events:     events,
+	}
+}
+
+// ownTemplate loads a template of the user
+func (s *TemplateService) ownTemplate(ctx context.Context, userID bson.ObjectID, id string) (*models.EventTemplate, error) {
+	templateID, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, newError(KindInvalid, "Invalid template ID")
+	}
+
+	template, err := s.templates.GetTemplateByID(ctx, templateID)
+	if err != nil || template.HostID != userID {
+		return nil, newError(KindNotFound, "Template not found") //? Other hosts' templates don't exist for this one
+	}
+
+	return template, nil
+}
+
+// ! Save keeps one of the host's events as a template
+func (s *TemplateService) Save(ctx context.Context, hostEmail string, req *dto.SaveTemplateRequest) (*models.EventTemplate, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, newError(KindInvalid, "name is required")
+	}
+	if len(name) > maxTemplateNameLength {
+		return nil, newError(KindInvalid, fmt.Sprintf("name must be at most %d characters", maxTemplateNameLength))
+	}
+
+	user, err := s.events.requireHost(ctx, hostEmail, "save templates of")
+	if err != nil {
+		return nil, err
+	}
+
+	//? Only the host, not co-hosts, the same as duplicating
+	source, err := s.eventStore.GetEventByID(ctx, req.EventID)
+	if err != nil {
+		return nil, newError(KindNotFound, "Event not found")
+	}
+	if source.HostID != user.ID {
+		return nil, newError(KindForbidden, "You can only save your own events as templates")
+	}
+
+	count, err := s.templates.CountTemplatesByHost(ctx, user.ID)
+	if err != nil {
+		return nil, wrapError(KindInternal, "Failed to save template", err)
+	}
+	if count >= maxTemplatesPerHost {
+		return nil, newError(KindInvalid, fmt.Sprintf("You can have at most %d templates, delete one first", maxTemplatesPerHost))
+	}
+
+	snapshot := copyEvent(source, source.Name, &dto.DuplicateEventRequest{
+		Date:      source.Date,
+		StartTime: source.StartTime,
+		EndTime:   source.EndTime,
+	})
+	snapshot.Status = "" //? Events made from it are drafts anyway
+
+	template := &models.EventTemplate{
+		HostID:   user.ID,
+		Name:     name,
+		SourceID: source.ID,
+		Event:    *snapshot,
+	}
+	if err := s.templates.CreateTemplate(ctx, template); err != nil {
+		if errors.Is(err, store.ErrTemplateNameTaken) {
+			return nil, wrapError(KindConflict, "You already have a template named "+name, err)
+		}
+		return nil, wrapError(KindInternal, "Failed to save template", err)
+	}
+
+	return template, nil
+}
+
+// ! List returns the host's templates
+func (s *TemplateService) List(ctx context.Context, userID bson.ObjectID) ([]models.EventTemplate, error) {
+	templates, err := s.templates.GetTemplatesByHost(ctx, userID)
+	if err != nil {
+		return nil, wrapError(KindInternal, "Failed to get templates", err)
+	}
+	return templates, nil
+}
+
+// ! Get returns one of the host's templates
+func (s *TemplateService) Get(ctx context.Context, userID bson.ObjectID, id string) (*models.EventTemplate, error) {
+	return s.ownTemplate(ctx, userID, id)
+}
+
+// ! Delete deletes one of the host's templates, the events made from it stay
+func (s *TemplateService) Delete(ctx context.Context, userID bson.ObjectID, id string) error {
+	template, err := s.ownTemplate(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := s.templates.DeleteTemplate(ctx, template.ID, userID)
+	if err != nil {
+		return wrapError(KindInternal, "Failed to delete template", err)
+	}
+	if !deleted {
+		return newError(KindNotFound, "Template not found")
+	}
+	return nil
+}
+
+// ! CreateEvent makes a new draft from one of the host's templates and returns the host's events it looks like
+func (s *TemplateService) CreateEvent(ctx context.Context, hostEmail, templateID string, req *dto.EventFromTemplateRequest) (*models.Event, []models.DuplicateWarning, error) {
+	user, err := s.events.requireHost(ctx, hostEmail, "create")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	template, err := s.ownTemplate(ctx, user.ID, templateID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if req.Date.IsZero() || req.StartTime.IsZero() || req.EndTime.IsZero() {
+		return nil, nil, newError(KindInvalid, "date, start_time and end_time are required")
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		name = template.Event.Name
+	}
+
+	event := copyEvent(&template.Event, name, &req.DuplicateEventRequest)
+	warnings, err := s.events.createCopy(ctx, user, event)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return event, warnings, nil
+}
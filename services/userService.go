@@ -0,0 +1,465 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"event-horizon/dto"
+	"event-horizon/models"
+	"event-horizon/store"
+	"event-horizon/templates"
+	"event-horizon/utils"
+	"fmt"
+	"log"
+	"math/big"
+	"slices"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+//! THIS FILE HOLDS THE BUSINESS RULES OF USER ACCOUNTS (SIGN UP, SIGN IN AND BECOMING A HOST)
+
+/******************************* NOTE **************************************
+
+I DID THE FOLLOWING THINGS IN THIS FILE:
+
+1. Moved registration, password checks and session creation out of the UserController into UserService.
+
+2. BecomeHost refuses users that already are hosts.
+
+3. Users pick the LANGUAGE of their emails (at registration or later), only languages with email templates are accepted.
+
+4. Users add a PHONE number for SMS: a 6 digit code is sent to it and the number only counts once the code is typed in.
+
+5. Users turn notification types off per channel in their PREFERENCES, only types and channels that exist are accepted.
+
+6. CAPTCHA: Register always asks for one, Login once the email or IP failed to sign in CaptchaRules.LoginFailures times
+   (failed sign ins are counted for loginFailureWindow, a successful one forgets the email's failures).
+
+7. Tokens carry the user's ROLE and SCOPES, BecomeHost and RefreshToken hand out a new token for the same session so they follow role changes.
+
+8. REMEMBER ME: sessions started with remember_me last TOKEN_TTL, every other one the short SESSION_TTL, tokens never outlive their session.
+
+9. Register requires accepting the current TERMS and PRIVACY POLICY and records it (see ConsentService).
+
+********************************* NOTE ************************************/
+
+// Device describes where a sign in comes from, it is stored with the session
+type Device struct {
+	UserAgent string
+	IP        string
+}
+
+// SignIn is a new session with its token
+type SignIn struct {
+	Token   string
+	Session *models.UserSession
+}
+
+// CaptchaRules decide when sign ups and sign ins must come with a solved CAPTCHA
+type CaptchaRules struct {
+	Verifier      utils.CaptchaVerifier // optional, nil never asks for one
+	Failures      store.VelocityCounter // counts failed sign ins per email and IP, required with a Verifier
+	LoginFailures int                   // failed sign ins after which Login asks for one, 0 never asks
+}
+
+// loginFailureWindow is how long failed sign ins are counted
+const loginFailureWindow = 15 * time.Minute
+
+// UserService holds the business rules of user accounts
+type UserService struct {
+	users    store.UserRepository
+	sessions store.SessionRepository
+	consents *ConsentService
+	sms      utils.SMSSender
+	captcha  CaptchaRules
+}
+
+// NewUserService creates a new UserService, sms sends the phone verification codes
+func NewUserService(users store.UserRepository, sessions store.SessionRepository, consents *ConsentService, sms utils.SMSSender, captcha CaptchaRules) *UserService {
+	return &UserService{
+		users:    users,
+		sessions: sessions,
+		consents: consents,
+		sms:      sms,
+		captcha:  captcha,
+	}
+}
+
+// Phone verification limits
+const (
+	phoneCodeTTL         = 10 * time.Minute
+	phoneCodeResendAfter = time.Minute //? A new code can't be asked for sooner, every SMS costs money
+	maxPhoneCodeAttempts = 5
+)
+
+// startSession creates a session for this device, long lived with remember me, and returns it with a JWT tied to it
+func (s *UserService) startSession(ctx context.Context, user *models.User, device Device, rememberMe bool) (*SignIn, error) {
+	session := models.UserSession{
+		UserID:     user.ID,
+		UserAgent:  device.UserAgent,
+		IP:         device.IP,
+		RememberMe: rememberMe,
+	}
+
+	if err := s.sessions.CreateSession(ctx, &session, utils.GetTokenTTL(rememberMe)); err != nil {
+		return nil, err
+	}
+
+	token, err := utils.GenerateJWT(user, &session)
+	if err != nil {
+		return nil, err
+	}
+	return &SignIn{Token: token, Session: &session}, nil
+}
+
+// ! Register creates a user (never a host or admin) and signs them in on this device
+func (s *UserService) Register(ctx context.Context, req *dto.RegisterRequest, device Device) (*models.User, *SignIn, error) {
+	if s.captcha.Verifier != nil {
+		if err := verifyCaptcha(ctx, s.captcha.Verifier, req.CaptchaToken, device.IP, "Signing up needs a CAPTCHA"); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	accepted, err := s.consents.accepted(req.PolicyAcceptance)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	user := req.ToModel()
+
+	language, err := normalizeLanguage(user.Language)
+	if err != nil {
+		return nil, nil, err
+	}
+	user.Language = language
+
+	//? The store hashes the password
+	if err := s.users.CreateUser(ctx, user); err != nil {
+		return nil, nil, wrapError(KindInternal, "Failed to create user", err)
+	}
+
+	//! IMPORTANT: Fetch the newly created user to get the generated ID
+	createdUser, err := s.users.FindUserByEmail(ctx, user.Email)
+	if err != nil {
+		return nil, nil, wrapError(KindInternal, "Failed to retrieve user", err)
+	}
+	s.consents.recordLater(ctx, models.Consent{UserID: createdUser.ID, Source: models.ConsentAtRegistration, IP: device.IP}, accepted)
+
+	signIn, err := s.startSession(ctx, createdUser, device, req.RememberMe)
+	if err != nil {
+		return nil, nil, wrapError(KindInternal, "Failed to generate token", err)
+	}
+
+	return createdUser, signIn, nil
+}
+
+// ! Login checks the credentials and signs the user in on this device
+func (s *UserService) Login(ctx context.Context, req *dto.LoginRequest, device Device) (*models.User, *SignIn, error) {
+	failureKeys := loginFailureKeys(req.Email, device.IP)
+	if err := s.checkLoginCaptcha(ctx, failureKeys, req.CaptchaToken, device.IP); err != nil {
+		return nil, nil, err
+	}
+
+	user, err := s.users.FindUserByEmail(ctx, req.Email)
+	if err != nil {
+		s.countLoginFailure(ctx, failureKeys)
+		return nil, nil, wrapError(KindUnauthorized, "Invalid email or password", err)
+	}
+
+	if err := s.users.VerifyPassword(user.Password, req.Password); err != nil {
+		s.countLoginFailure(ctx, failureKeys)
+		return nil, nil, wrapError(KindUnauthorized, "Invalid email or password", err)
+	}
+	if s.captchaOnLogin() {
+		s.captcha.Failures.Reset(ctx, failureKeys[0]) //? The IP keeps its count, one right password doesn't vouch for the others
+	}
+
+	signIn, err := s.startSession(ctx, user, device, req.RememberMe)
+	if err != nil {
+		return nil, nil, wrapError(KindInternal, "Failed to generate token", err)
+	}
+
+	return user, signIn, nil
+}
+
+// loginFailureKeys are the counters of failed sign ins of the email and the IP
+func loginFailureKeys(email, ip string) []string {
+	keys := []string{"login:email:" + strings.ToLower(strings.TrimSpace(email))}
+	if ip != "" {
+		keys = append(keys, "login:ip:"+ip)
+	}
+	return keys
+}
+
+// captchaOnLogin tells whether failed sign ins lead to a CAPTCHA
+func (s *UserService) captchaOnLogin() bool {
+	return s.captcha.Verifier != nil && s.captcha.LoginFailures > 0
+}
+
+// checkLoginCaptcha asks for a CAPTCHA once the email or the IP failed to sign in too often.
+// A counter error lets the sign in through, nobody is locked out by a Redis hiccup.
+func (s *UserService) checkLoginCaptcha(ctx context.Context, keys []string, token, ip string) error {
+	if !s.captchaOnLogin() {
+		return nil
+	}
+	for _, key := range keys {
+		failures, err := s.captcha.Failures.Count(ctx, key)
+		if err != nil {
+			log.Printf("Error reading failed sign ins of %s: %v", key, err)
+			continue
+		}
+		if failures >= int64(s.captcha.LoginFailures) {
+			return verifyCaptcha(ctx, s.captcha.Verifier, token, ip, "Too many failed sign ins")
+		}
+	}
+	return nil
+}
+
+// countLoginFailure counts a failed sign in for the email and the IP
+func (s *UserService) countLoginFailure(ctx context.Context, keys []string) {
+	if !s.captchaOnLogin() {
+		return
+	}
+	for _, key := range keys {
+		if _, err := s.captcha.Failures.Hit(ctx, key, loginFailureWindow); err != nil {
+			log.Printf("Error counting a failed sign in of %s: %v", key, err)
+		}
+	}
+}
+
+// normalizeLanguage lower cases the language and checks that emails exist in it, empty stays empty (the default)
+func normalizeLanguage(language string) (string, error) {
+	language = strings.ToLower(strings.TrimSpace(language))
+	if language == "" {
+		return "", nil
+	}
+	if !slices.Contains(templates.SupportedLocales(), language) {
+		return "", newError(KindInvalid, "language must be one of "+strings.Join(templates.SupportedLocales(), ", "))
+	}
+	return language, nil
+}
+
+// ! UpdateLanguage sets the language of the user's emails and returns the updated user
+func (s *UserService) UpdateLanguage(ctx context.Context, userID bson.ObjectID, language string) (*models.User, error) {
+	language, err := normalizeLanguage(language)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.users.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, newError(KindNotFound, "User not found")
+	}
+
+	if err := s.users.SetLanguage(ctx, userID, language); err != nil {
+		return nil, wrapError(KindInternal, "Failed to update user", err)
+	}
+
+	user.Language = language
+
+	return user, nil
+}
+
+// ! BecomeHost turns a user into a host and returns the updated user with a token of this session that has the host scopes
+func (s *UserService) BecomeHost(ctx context.Context, userID bson.ObjectID, session *models.UserSession) (*models.User, string, error) {
+	user, err := s.users.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, "", newError(KindNotFound, "User not found")
+	}
+
+	if user.IsHost {
+		return nil, "", newError(KindConflict, "You are already a host")
+	}
+
+	if err := s.users.SetHostStatus(ctx, userID, true); err != nil {
+		return nil, "", wrapError(KindInternal, "Failed to update user", err)
+	}
+
+	user.IsHost = true
+
+	token, err := utils.GenerateJWT(user, session)
+	if err != nil {
+		return nil, "", wrapError(KindInternal, "You are a host now, but refreshing your token failed, please log in again", err)
+	}
+
+	return user, token, nil
+}
+
+// ! RefreshToken returns a new token of the same session with the user's current role and scopes
+func (s *UserService) RefreshToken(ctx context.Context, userID bson.ObjectID, session *models.UserSession) (string, error) {
+	user, err := s.users.GetUserByID(ctx, userID)
+	if err != nil {
+		return "", newError(KindNotFound, "User not found")
+	}
+
+	token, err := utils.GenerateJWT(user, session)
+	if err != nil {
+		return "", wrapError(KindInternal, "Failed to refresh token", err)
+	}
+	return token, nil
+}
+
+// ! Preferences returns the user's preferences
+func (s *UserService) Preferences(ctx context.Context, userID bson.ObjectID) (models.UserPreferences, error) {
+	user, err := s.users.GetUserByID(ctx, userID)
+	if err != nil {
+		return models.UserPreferences{}, newError(KindNotFound, "User not found")
+	}
+	return user.Preferences, nil
+}
+
+// ! UpdatePreferences replaces the user's notification preferences, types and channels left out go back to on
+func (s *UserService) UpdatePreferences(ctx context.Context, userID bson.ObjectID, notifications models.NotificationPreferences) (models.UserPreferences, error) {
+	//? Only the turned off channels are stored, on is the default
+	stored := models.NotificationPreferences{}
+	for notificationType, channels := range notifications {
+		if _, ok := models.NotificationChannels[notificationType]; !ok {
+			return models.UserPreferences{}, newError(KindInvalid, fmt.Sprintf("unknown notification type %q", notificationType))
+		}
+		for channel, enabled := range channels {
+			if !models.IsConfigurable(notificationType, channel) {
+				return models.UserPreferences{}, newError(KindInvalid, fmt.Sprintf("%s notifications are not sent by %s", notificationType, channel))
+			}
+			if enabled {
+				continue
+			}
+			if stored[notificationType] == nil {
+				stored[notificationType] = map[string]bool{}
+			}
+			stored[notificationType][channel] = false
+		}
+	}
+
+	preferences := models.UserPreferences{Notifications: stored}
+	if err := s.users.SetPreferences(ctx, userID, preferences); err != nil {
+		return models.UserPreferences{}, wrapError(KindInternal, "Failed to update user", err)
+	}
+
+	return preferences, nil
+}
+
+// generatePhoneCode returns a random 6 digit code
+func generatePhoneCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%06d", n.Int64()), nil
+}
+
+// hashPhoneCode returns what is stored for a verification code
+func hashPhoneCode(code string) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(code)))
+	return hex.EncodeToString(sum[:])
+}
+
+// ! RequestPhoneVerification texts a code to the phone number, it becomes the user's number once VerifyPhone gets the code
+func (s *UserService) RequestPhoneVerification(ctx context.Context, userID bson.ObjectID, phone string) error {
+	phone, ok := utils.NormalizePhone(phone)
+	if !ok {
+		return newError(KindInvalid, "phone must be in international format, like +14155550123")
+	}
+
+	user, err := s.users.GetUserByID(ctx, userID)
+	if err != nil {
+		return newError(KindNotFound, "User not found")
+	}
+
+	if user.CanReceiveSMS() && user.Phone == phone {
+		return newError(KindConflict, "This phone number is already verified")
+	}
+	if pending := user.PhoneVerification; pending != nil && time.Since(pending.SentAt) < phoneCodeResendAfter {
+		return newError(KindConflict, "A code was just sent, wait a minute before asking for a new one")
+	}
+
+	code, err := generatePhoneCode()
+	if err != nil {
+		return wrapError(KindInternal, "Failed to create the verification code", err)
+	}
+
+	body, err := templates.RenderSMS(templates.PhoneVerification, user.Language, map[string]any{
+		"Code":    code,
+		"Minutes": int(phoneCodeTTL.Minutes()),
+	})
+	if err != nil {
+		return wrapError(KindInternal, "Failed to create the verification code", err)
+	}
+
+	now := time.Now()
+	verification := &models.PhoneVerification{
+		Phone:     phone,
+		CodeHash:  hashPhoneCode(code),
+		SentAt:    now,
+		ExpiresAt: now.Add(phoneCodeTTL),
+	}
+	if err := s.users.SetPhoneVerification(ctx, userID, verification); err != nil {
+		return wrapError(KindInternal, "Failed to update user", err)
+	}
+
+	//! Sent right away, not in the background: the user is waiting for it and should hear if it failed
+	if err := s.sms.SendSMS(phone, body); err != nil {
+		return wrapError(KindUnavailable, "Failed to send the verification code, try again later", err)
+	}
+
+	return nil
+}
+
+// ! VerifyPhone checks the code sent to the pending phone number and makes it the user's number
+func (s *UserService) VerifyPhone(ctx context.Context, userID bson.ObjectID, code string) (*models.User, error) {
+	user, err := s.users.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, newError(KindNotFound, "User not found")
+	}
+
+	pending := user.PhoneVerification
+	if pending == nil {
+		return nil, newError(KindNotFound, "No phone number is waiting for verification")
+	}
+	if time.Now().After(pending.ExpiresAt) {
+		return nil, newError(KindGone, "The code expired, ask for a new one")
+	}
+	if pending.Attempts >= maxPhoneCodeAttempts {
+		return nil, newError(KindForbidden, "Too many wrong codes, ask for a new one")
+	}
+
+	if subtle.ConstantTimeCompare([]byte(hashPhoneCode(code)), []byte(pending.CodeHash)) != 1 {
+		if err := s.users.AddPhoneVerificationAttempt(ctx, userID); err != nil {
+			return nil, wrapError(KindInternal, "Failed to update user", err)
+		}
+		return nil, newError(KindInvalid, "Wrong code")
+	}
+
+	if err := s.users.SetPhone(ctx, userID, pending.Phone); err != nil {
+		return nil, wrapError(KindInternal, "Failed to update user", err)
+	}
+
+	now := time.Now()
+	user.Phone = pending.Phone
+	user.PhoneVerifiedAt = &now
+	user.PhoneVerification = nil
+
+	return user, nil
+}
+
+// ! RemovePhone removes the user's phone number (and a pending one), no SMS are sent after it
+func (s *UserService) RemovePhone(ctx context.Context, userID bson.ObjectID) (*models.User, error) {
+	user, err := s.users.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, newError(KindNotFound, "User not found")
+	}
+
+	if err := s.users.SetPhone(ctx, userID, ""); err != nil {
+		return nil, wrapError(KindInternal, "Failed to update user", err)
+	}
+
+	user.Phone = ""
+	user.PhoneVerifiedAt = nil
+	user.PhoneVerification = nil
+
+	return user, nil
+}
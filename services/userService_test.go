@@ -0,0 +1,152 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"event-horizon/dto"
+	"event-horizon/models"
+	"event-horizon/store"
+	"event-horizon/utils"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// stubUsers knows one user, whose password is stored in plain text
+type stubUsers struct {
+	store.UserRepository
+	user       *models.User
+	anonymized []bson.ObjectID
+}
+
+func (s *stubUsers) FindUserByEmail(ctx context.Context, email string) (*models.User, error) {
+	if email != s.user.Email {
+		return nil, errors.New("user not found")
+	}
+	return s.user, nil
+}
+
+func (s *stubUsers) VerifyPassword(hashedPassword, plainPassword string) error {
+	if hashedPassword != plainPassword {
+		return errors.New("wrong password")
+	}
+	return nil
+}
+
+// stubSessions accepts every new session
+type stubSessions struct {
+	store.SessionRepository
+}
+
+func (s *stubSessions) CreateSession(ctx context.Context, session *models.UserSession, ttl time.Duration) error {
+	session.ID = bson.NewObjectID()
+	session.ExpiresAt = time.Now().Add(ttl)
+	return nil
+}
+
+// stubCaptcha accepts one token and records the IPs it was asked for
+type stubCaptcha struct {
+	solved string
+	err    error //? set when the provider can't be reached
+	asked  []string
+}
+
+func (s *stubCaptcha) Verify(ctx context.Context, token, remoteIP string) error {
+	s.asked = append(s.asked, remoteIP)
+	if s.err != nil {
+		return s.err
+	}
+	if token == "" || token != s.solved {
+		return utils.ErrCaptchaInvalid
+	}
+	return nil
+}
+
+const (
+	testEmail    = "ada@example.com"
+	testPassword = "correct horse battery staple"
+)
+
+// newLoginService returns a UserService with one user that asks for a CAPTCHA after 3 failed sign ins
+func newLoginService(t *testing.T, captcha *stubCaptcha) *UserService {
+	t.Helper()
+	utils.ConfigureJWT("a-test-secret-that-is-long-enough-123", time.Hour, time.Hour)
+
+	user := &models.User{ID: bson.NewObjectID(), Name: "Ada", Email: testEmail, Password: testPassword}
+	rules := CaptchaRules{Verifier: captcha, Failures: store.NewMemoryVelocityCounter(), LoginFailures: 3}
+	return NewUserService(&stubUsers{user: user}, &stubSessions{}, nil, nil, rules)
+}
+
+// login signs in and returns the kind of the error, -1 when it went through
+func login(t *testing.T, users *UserService, email, password, token, ip string) Kind {
+	t.Helper()
+	req := &dto.LoginRequest{Email: email, Password: password, CaptchaToken: token}
+	_, signIn, err := users.Login(context.Background(), req, Device{UserAgent: "test", IP: ip})
+	if err == nil {
+		if signIn == nil || signIn.Token == "" {
+			t.Fatal("Login went through without a token")
+		}
+		return -1
+	}
+
+	var serviceErr *Error
+	if !errors.As(err, &serviceErr) {
+		t.Fatalf("Login returned %v, want a service error", err)
+	}
+	return serviceErr.Kind
+}
+
+func TestLoginAsksForCaptchaAfterFailures(t *testing.T) {
+	captcha := &stubCaptcha{solved: "solved"}
+	users := newLoginService(t, captcha)
+
+	//? Up to the threshold a wrong password is only a wrong password
+	for i := 0; i < 3; i++ {
+		if kind := login(t, users, testEmail, "wrong", "", "203.0.113.7"); kind != KindUnauthorized {
+			t.Fatalf("failed sign in %d: kind %v, want KindUnauthorized", i+1, kind)
+		}
+	}
+	if len(captcha.asked) != 0 {
+		t.Fatalf("the CAPTCHA was checked %d times below the threshold", len(captcha.asked))
+	}
+
+	//! Now even the right password needs a solved CAPTCHA
+	if kind := login(t, users, testEmail, testPassword, "", "203.0.113.7"); kind != KindCaptchaRequired {
+		t.Fatalf("sign in after 3 failures without a token: kind %v, want KindCaptchaRequired", kind)
+	}
+	if kind := login(t, users, testEmail, testPassword, "solved", "203.0.113.7"); kind != -1 {
+		t.Fatalf("sign in with a solved CAPTCHA: kind %v, want it to go through", kind)
+	}
+	if captcha.asked[len(captcha.asked)-1] != "203.0.113.7" {
+		t.Fatalf("the CAPTCHA was checked for %v, want the client's IP", captcha.asked)
+	}
+
+	//? The IP keeps its count after the right password, another email from it is still asked
+	if kind := login(t, users, "grace@example.com", "anything", "", "203.0.113.7"); kind != KindCaptchaRequired {
+		t.Fatalf("another email from the same IP: kind %v, want KindCaptchaRequired", kind)
+	}
+	if kind := login(t, users, testEmail, testPassword, "", "198.51.100.1"); kind != -1 {
+		t.Fatalf("the email after its right password from another IP: kind %v, want it to go through", kind)
+	}
+}
+
+func TestLoginRejectsFailedCaptcha(t *testing.T) {
+	captcha := &stubCaptcha{solved: "solved"}
+	users := newLoginService(t, captcha)
+	for i := 0; i < 3; i++ {
+		login(t, users, testEmail, "wrong", "", "203.0.113.7")
+	}
+
+	//! A token the provider turns down is no better than none
+	if kind := login(t, users, testEmail, testPassword, "forged", "203.0.113.7"); kind != KindCaptchaRequired {
+		t.Fatalf("sign in with a rejected token: kind %v, want KindCaptchaRequired", kind)
+	}
+
+	//? When the provider can't be asked the sign in waits instead of slipping through
+	captcha.err = errors.New("siteverify timed out")
+	if kind := login(t, users, testEmail, testPassword, "solved", "203.0.113.7"); kind != KindUnavailable {
+		t.Fatalf("sign in while the provider is down: kind %v, want KindUnavailable", kind)
+	}
+}
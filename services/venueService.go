@@ -0,0 +1,255 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"event-horizon/dto"
+	"event-horizon/models"
+	"event-horizon/search"
+	"event-horizon/store"
+	"event-horizon/utils"
+	"fmt"
+	"slices"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+//! THIS FILE HOLDS THE RULES OF VENUES (PLACES EVENTS ARE HELD AT)
+
+/******************************* NOTE **************************************
+
+I DID THE FOLLOWING THINGS IN THIS FILE:
+
+1. Hosts add venues once (name, address, geo location, capacity, amenities) and every host can hold events there.
+
+2. Only the host who added a venue (or an admin) can change or delete it, a venue with upcoming events can't be deleted.
+
+3. A changed address or geo location is copied onto the venue's upcoming events, and the search index is told.
+
+4. Events lists the upcoming public events at a venue ("other events at this venue").
+
+********************************* NOTE ************************************/
+
+const (
+	maxVenueFieldLength = 200
+	maxVenueAmenities   = 20
+	defaultVenueLimit   = 50
+	maxVenueLimit       = 100
+)
+
+// VenueService holds the rules of venues
+type VenueService struct {
+	venues  store.VenueRepository
+	events  store.EventRepository
+	users   store.UserRepository
+	indexer *search.Indexer
+}
+
+// NewVenueService creates a new VenueService
+func NewVenueService(venues store.VenueRepository, events store.EventRepository, users store.UserRepository, indexer *search.Indexer) *VenueService {
+	return &VenueService{
+		venues:  venues,
+		events:  events,
+		users:   users,
+		indexer: indexer,
+	}
+}
+
+// venueFromRequest checks the request and returns it as a venue
+func venueFromRequest(req *dto.VenueRequest) (*models.Venue, error) {
+	venue := &models.Venue{
+		Name:        strings.TrimSpace(req.Name),
+		Address:     strings.TrimSpace(req.Address),
+		City:        strings.TrimSpace(req.City),
+		GeoLocation: req.GeoLocation,
+		Capacity:    req.Capacity,
+	}
+
+	if venue.Name == "" || venue.Address == "" {
+		return nil, newError(KindInvalid, "name and address are required")
+	}
+	if len(venue.Name) > maxVenueFieldLength || len(venue.Address) > maxVenueFieldLength || len(venue.City) > maxVenueFieldLength {
+		return nil, newError(KindInvalid, fmt.Sprintf("name, address and city must be at most %d characters", maxVenueFieldLength))
+	}
+	if venue.Capacity < 0 {
+		return nil, newError(KindInvalid, "capacity can't be negative")
+	}
+	if err := validateGeoLocation(venue.GeoLocation); err != nil {
+		return nil, newError(KindInvalid, err.Error())
+	}
+
+	//? Amenities are cleaned up like tags, lowercase and without duplicates
+	venue.Amenities = utils.NormalizeTags(req.Amenities)
+	if len(venue.Amenities) > maxVenueAmenities {
+		return nil, newError(KindInvalid, fmt.Sprintf("a venue can have at most %d amenities", maxVenueAmenities))
+	}
+
+	return venue, nil
+}
+
+// loadVenue loads a venue by its hex ID
+func (s *VenueService) loadVenue(ctx context.Context, id string) (*models.Venue, error) {
+	venueID, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, newError(KindInvalid, "Invalid venue ID")
+	}
+
+	venue, err := s.venues.GetVenueByID(ctx, venueID)
+	if err != nil {
+		if errors.Is(err, store.ErrVenueNotFound) {
+			return nil, newError(KindNotFound, "Venue not found")
+		}
+		return nil, wrapError(KindInternal, "Failed to get venue", err)
+	}
+	return venue, nil
+}
+
+// ownVenue loads a venue the user may change: they added it or they are an admin
+func (s *VenueService) ownVenue(ctx context.Context, userID bson.ObjectID, id string) (*models.Venue, error) {
+	venue, err := s.loadVenue(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if venue.CreatedBy != userID {
+		user, err := s.users.GetUserByID(ctx, userID)
+		if err != nil || !user.IsAdmin {
+			return nil, newError(KindForbidden, "Only the host who added the venue can change it")
+		}
+	}
+	return venue, nil
+}
+
+// ! Create adds a venue, only hosts can
+func (s *VenueService) Create(ctx context.Context, userID bson.ObjectID, req *dto.VenueRequest) (*models.Venue, error) {
+	user, err := s.users.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, newError(KindUnauthorized, "User not found")
+	}
+	if !user.IsHost {
+		return nil, newError(KindForbidden, "Only hosts can add venues")
+	}
+	if user.IsSuspended() {
+		return nil, newError(KindForbidden, "Your host account is suspended")
+	}
+
+	venue, err := venueFromRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	venue.CreatedBy = user.ID
+
+	if err := s.venues.CreateVenue(ctx, venue); err != nil {
+		return nil, wrapError(KindInternal, "Failed to add venue", err)
+	}
+	return venue, nil
+}
+
+// ! List returns the venues matching the query, by name
+func (s *VenueService) List(ctx context.Context, query models.VenueQuery) ([]models.Venue, error) {
+	query.Name = strings.TrimSpace(query.Name)
+	query.City = strings.TrimSpace(query.City)
+	if query.Limit <= 0 {
+		query.Limit = defaultVenueLimit
+	}
+	if query.Limit > maxVenueLimit {
+		query.Limit = maxVenueLimit
+	}
+
+	venues, err := s.venues.GetVenues(ctx, query)
+	if err != nil {
+		return nil, wrapError(KindInternal, "Failed to get venues", err)
+	}
+	return venues, nil
+}
+
+// ! Get returns a venue
+func (s *VenueService) Get(ctx context.Context, id string) (*models.Venue, error) {
+	return s.loadVenue(ctx, id)
+}
+
+// ! Update changes a venue and copies its new location onto its upcoming events
+func (s *VenueService) Update(ctx context.Context, userID bson.ObjectID, id string, req *dto.VenueRequest) (*models.Venue, error) {
+	existing, err := s.ownVenue(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	venue, err := venueFromRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	venue.ID = existing.ID
+	venue.CreatedBy = existing.CreatedBy
+	venue.CreatedAt = existing.CreatedAt
+
+	if err := s.venues.UpdateVenue(ctx, venue); err != nil {
+		if errors.Is(err, store.ErrVenueNotFound) {
+			return nil, newError(KindNotFound, "Venue not found")
+		}
+		return nil, wrapError(KindInternal, "Failed to update venue", err)
+	}
+
+	if venue.Location() != existing.Location() || !sameGeoPoint(venue.GeoLocation, existing.GeoLocation) {
+		events, err := s.events.SetVenueLocation(ctx, venue)
+		if err != nil {
+			return nil, wrapError(KindInternal, "The venue was saved but its events still show the old location, save it again", err)
+		}
+		for _, event := range events {
+			s.indexer.Update(event)
+		}
+	}
+
+	return venue, nil
+}
+
+// sameGeoPoint reports whether two optional geo locations are the same point
+func sameGeoPoint(a, b *models.GeoPoint) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return slices.Equal(a.Coordinates, b.Coordinates)
+}
+
+// ! Delete deletes a venue without upcoming events, past events keep the location they had
+func (s *VenueService) Delete(ctx context.Context, userID bson.ObjectID, id string) (*models.Venue, error) {
+	venue, err := s.ownVenue(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	upcoming, err := s.events.CountUpcomingVenueEvents(ctx, venue.ID)
+	if err != nil {
+		return nil, wrapError(KindInternal, "Failed to check the venue's events", err)
+	}
+	if upcoming > 0 {
+		return nil, newError(KindConflict, fmt.Sprintf("%d upcoming events are held at this venue", upcoming))
+	}
+
+	if err := s.venues.DeleteVenue(ctx, venue.ID); err != nil {
+		if errors.Is(err, store.ErrVenueNotFound) {
+			return nil, newError(KindNotFound, "Venue not found")
+		}
+		return nil, wrapError(KindInternal, "Failed to delete venue", err)
+	}
+	return venue, nil
+}
+
+// ! Events returns the upcoming public events at a venue, soonest first
+func (s *VenueService) Events(ctx context.Context, id string, limit int) ([]models.Event, error) {
+	venue, err := s.loadVenue(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 || limit > maxVenueLimit {
+		limit = defaultVenueLimit
+	}
+
+	events, err := s.events.GetUpcomingEvents(ctx, models.UpcomingEventsQuery{VenueID: venue.ID, Limit: limit})
+	if err != nil {
+		return nil, wrapError(KindInternal, "Failed to get the venue's events", err)
+	}
+	return events, nil
+}
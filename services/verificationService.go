@@ -0,0 +1,209 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"event-horizon/dto"
+	"event-horizon/models"
+	"event-horizon/store"
+	"event-horizon/utils"
+	"log"
+	"net/url"
+	"slices"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+//! THIS FILE HOLDS THE RULES FOR VERIFYING HOSTS BEFORE THEY SELL PAID TICKETS (KYC)
+
+/******************************* NOTE **************************************
+
+I DID THE FOLLOWING THINGS IN THIS FILE:
+
+1. Submit stores a host's identity document or payout account for review, one pending submission at a time.
+
+2. Payout account numbers are never shown again, the submission keeps their last 4 characters for the reviewer.
+
+3. Review lets an admin approve (the host and all their events get the VERIFIED HOST badge) or reject with a reason, the host is notified either way.
+
+4. Status returns the host's newest submission so the frontend can show where they are (none, pending, approved, rejected).
+
+********************************* NOTE ************************************/
+
+// How long the free text fields of a submission can be
+const (
+	maxLegalNameLength  = 200
+	maxReviewNoteLength = 1000
+)
+
+// HostVerificationService holds the rules for verifying hosts
+type HostVerificationService struct {
+	verifications store.HostVerificationRepository
+	users         store.UserRepository
+	events        store.EventRepository
+	notifier      *utils.NotificationWorker
+}
+
+// NewHostVerificationService creates a new HostVerificationService
+func NewHostVerificationService(verifications store.HostVerificationRepository, users store.UserRepository, events store.EventRepository, notifier *utils.NotificationWorker) *HostVerificationService {
+	return &HostVerificationService{
+		verifications: verifications,
+		users:         users,
+		events:        events,
+		notifier:      notifier,
+	}
+}
+
+// ! validateVerification checks the fields of the submitted kind and copies them into a submission
+func validateVerification(req *dto.HostVerificationRequest) (*models.HostVerification, error) {
+	verification := &models.HostVerification{
+		Kind:      req.Kind,
+		LegalName: strings.TrimSpace(req.LegalName),
+		Country:   strings.ToUpper(strings.TrimSpace(req.Country)),
+	}
+
+	if verification.LegalName == "" || len(verification.LegalName) > maxLegalNameLength {
+		return nil, newError(KindInvalid, "legal_name is required (at most 200 characters)")
+	}
+	if len(verification.Country) != 2 || strings.Trim(verification.Country, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "" {
+		return nil, newError(KindInvalid, "country must be an ISO 3166 alpha-2 code, e.g. US")
+	}
+
+	switch req.Kind {
+	case models.VerificationDocument:
+		if !slices.Contains(models.VerificationDocumentTypes, req.DocumentType) {
+			return nil, newError(KindInvalid, "document_type must be one of "+strings.Join(models.VerificationDocumentTypes, ", "))
+		}
+		parsed, err := url.ParseRequestURI(req.DocumentURL)
+		if err != nil || parsed.Scheme != "https" || parsed.Host == "" {
+			return nil, newError(KindInvalid, "document_url must be a valid https URL")
+		}
+		verification.DocumentType = req.DocumentType
+		verification.DocumentURL = req.DocumentURL
+
+	case models.VerificationPayoutAccount:
+		provider := strings.ToLower(strings.TrimSpace(req.PayoutProvider))
+		account := strings.ReplaceAll(strings.TrimSpace(req.PayoutAccount), " ", "")
+		if provider == "" {
+			return nil, newError(KindInvalid, "payout_provider is required")
+		}
+		if len(account) < 4 {
+			return nil, newError(KindInvalid, "payout_account is required")
+		}
+		verification.PayoutProvider = provider
+		verification.PayoutAccount = account
+		verification.PayoutLast4 = account[len(account)-4:]
+
+	default:
+		return nil, newError(KindInvalid, "kind must be document or payout_account")
+	}
+
+	return verification, nil
+}
+
+// ! Submit stores the host's verification for review
+func (s *HostVerificationService) Submit(ctx context.Context, userID bson.ObjectID, req *dto.HostVerificationRequest) (*models.HostVerification, error) {
+	user, err := s.users.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, newError(KindNotFound, "User not found")
+	}
+	if !user.IsHost {
+		return nil, newError(KindForbidden, "Only hosts can be verified")
+	}
+	if user.IsVerified() {
+		return nil, newError(KindConflict, "Your host account is already verified")
+	}
+
+	verification, err := validateVerification(req)
+	if err != nil {
+		return nil, err
+	}
+	verification.HostID = user.ID
+
+	if err := s.verifications.CreateVerification(ctx, verification); err != nil {
+		if errors.Is(err, store.ErrVerificationPending) {
+			return nil, wrapError(KindConflict, "Your last submission is still waiting for review", err)
+		}
+		return nil, wrapError(KindInternal, "Failed to submit verification", err)
+	}
+
+	return verification, nil
+}
+
+// ! Status returns the host's newest submission, nil when they never submitted one
+func (s *HostVerificationService) Status(ctx context.Context, userID bson.ObjectID) (*models.HostVerification, error) {
+	verification, err := s.verifications.GetLatestVerification(ctx, userID)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, wrapError(KindInternal, "Failed to get verification", err)
+	}
+	return verification, nil
+}
+
+// maxVerificationList is how many submissions the review queue returns at once
+const maxVerificationList = 100
+
+// ! List returns the submissions with the status, oldest first
+func (s *HostVerificationService) List(ctx context.Context, status string) ([]models.HostVerification, error) {
+	switch status {
+	case models.VerificationPending, models.VerificationApproved, models.VerificationRejected, "":
+	default:
+		return nil, newError(KindInvalid, "status must be pending, approved or rejected")
+	}
+
+	verifications, err := s.verifications.ListVerifications(ctx, status, maxVerificationList)
+	if err != nil {
+		return nil, wrapError(KindInternal, "Failed to list verifications", err)
+	}
+	return verifications, nil
+}
+
+// ! Review approves or rejects a pending submission and tells the host, a rejection needs a note
+func (s *HostVerificationService) Review(ctx context.Context, id string, reviewerID bson.ObjectID, approve bool, note string) (*models.HostVerification, error) {
+	objID, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, newError(KindInvalid, "Invalid verification ID")
+	}
+
+	note = strings.TrimSpace(note)
+	if !approve && note == "" {
+		return nil, newError(KindInvalid, "note is required to reject a verification")
+	}
+	if len(note) > maxReviewNoteLength {
+		return nil, newError(KindInvalid, "note can have at most 1000 characters")
+	}
+
+	if _, err := s.verifications.GetVerificationByID(ctx, objID); err != nil {
+		return nil, newError(KindNotFound, "Verification not found")
+	}
+
+	verification, err := s.verifications.ReviewVerification(ctx, objID, reviewerID, approve, note)
+	if err != nil {
+		if errors.Is(err, store.ErrVerificationReviewed) {
+			return nil, wrapError(KindConflict, "The verification was already reviewed", err)
+		}
+		return nil, wrapError(KindInternal, "Failed to review verification", err)
+	}
+
+	if !approve {
+		s.notifier.NotifyUser(verification.HostID, "host_verification_rejected", "Your host verification was rejected: "+note, bson.NilObjectID)
+		return verification, nil
+	}
+
+	if err := s.users.SetVerified(ctx, verification.HostID, true); err != nil {
+		return nil, wrapError(KindInternal, "Verification approved, but marking the host verified failed", err)
+	}
+
+	//? The badge is copied onto the events so public listings can show it without looking up the host
+	if _, err := s.events.SetHostVerified(ctx, verification.HostID, true); err != nil {
+		log.Printf("Error showing the verified badge on the events of host %s: %v", verification.HostID.Hex(), err)
+	}
+
+	s.notifier.NotifyUser(verification.HostID, "host_verified", "Your host account is verified, you can sell paid tickets now", bson.NilObjectID)
+
+	return verification, nil
+}
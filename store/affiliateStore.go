@@ -0,0 +1,125 @@
+package store
+
+import (
+	"context"
+	"event-horizon/models"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+/******************** MONGODB FUNCTIONALITY FOR AFFILIATES COLLECTION ********************
+
+1. BSON MAPPING FOR AFFILIATES COLLECTION
+2. InsertOne
+3. FindOne / Find with Sort
+4. FindOneAndUpdate with $set
+5. Unique index
+
+ ****************************************************************************************/
+
+/************************** I DID THE FOLLOWING THINGS IN THIS FILE: *******************************************
+
+1. Created AffiliateStore struct to manage the affiliates (ambassadors) of hosts.
+
+2. Developed CreateAffiliate method, codes are unique (ErrShareCodeTaken like share links).
+
+3. Added GetAffiliateByCode and GetAffiliatesByHostID methods.
+
+4. Implemented UpdateAffiliate method for the commission and the active flag.
+
+5. Created EnsureAffiliateIndexes method for the unique code and the host index.
+
+************************************************************************************************************/
+
+type AffiliateStore struct {
+	collection *mongo.Collection
+}
+
+func NewAffiliateStore(db *mongo.Database) *AffiliateStore {
+	return &AffiliateStore{
+		collection: db.Collection("Affiliates"),
+	}
+}
+
+// CreateAffiliate stores an affiliate, ErrShareCodeTaken when its code is used
+func (s *AffiliateStore) CreateAffiliate(ctx context.Context, affiliate *models.Affiliate) error {
+	affiliate.CreatedAt = time.Now()
+
+	result, err := s.collection.InsertOne(ctx, affiliate)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return ErrShareCodeTaken
+		}
+		return err
+	}
+
+	affiliate.ID = result.InsertedID.(bson.ObjectID)
+	return nil
+}
+
+// GetAffiliateByCode returns the affiliate with the code
+func (s *AffiliateStore) GetAffiliateByCode(ctx context.Context, code string) (*models.Affiliate, error) {
+	var affiliate models.Affiliate
+	if err := s.collection.FindOne(ctx, bson.M{"code": code}).Decode(&affiliate); err != nil {
+		return nil, err
+	}
+	return &affiliate, nil
+}
+
+// GetAffiliatesByHostID returns the affiliates of a host, oldest first
+func (s *AffiliateStore) GetAffiliatesByHostID(ctx context.Context, hostID bson.ObjectID) ([]models.Affiliate, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+
+	cursor, err := s.collection.Find(ctx, bson.M{"host_id": hostID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var affiliates []models.Affiliate
+	if err = cursor.All(ctx, &affiliates); err != nil {
+		return nil, err
+	}
+
+	if affiliates == nil {
+		affiliates = []models.Affiliate{}
+	}
+
+	return affiliates, nil
+}
+
+// UpdateAffiliate sets the given fields of one of the host's affiliates and returns it
+func (s *AffiliateStore) UpdateAffiliate(ctx context.Context, hostID, affiliateID bson.ObjectID, fields bson.M) (*models.Affiliate, error) {
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var affiliate models.Affiliate
+	err := s.collection.FindOneAndUpdate(ctx,
+		bson.M{"_id": affiliateID, "host_id": hostID},
+		bson.M{"$set": fields},
+		opts,
+	).Decode(&affiliate)
+	if err != nil {
+		return nil, err
+	}
+	return &affiliate, nil
+}
+
+// EnsureAffiliateIndexes makes codes unique and indexes the affiliates of a host
+func (s *AffiliateStore) EnsureAffiliateIndexes(ctx context.Context) error {
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "code", Value: 1}},
+			Options: options.Index().SetName("code_unique").SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "host_id", Value: 1}, {Key: "created_at", Value: 1}},
+			Options: options.Index().SetName("host_created_at"),
+		},
+	}
+
+	_, err := s.collection.Indexes().CreateMany(ctx, indexes)
+	return err
+}
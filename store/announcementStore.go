@@ -0,0 +1,91 @@
+package store
+
+import (
+	"context"
+	"event-horizon/models"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+/******************** MONGODB FUNCTIONALITY FOR ANNOUNCEMENTS COLLECTION ********************
+
+1. BSON MAPPING FOR ANNOUNCEMENTS COLLECTION
+2. InsertOne
+3. Find with Sort and Limit
+4. Compound index
+
+ ****************************************************************************************/
+
+/************************** I DID THE FOLLOWING THINGS IN THIS FILE: *******************************************
+
+1. Created AnnouncementStore struct to keep the updates hosts send to the attendees of their events.
+
+2. Developed CreateAnnouncement method to store an announcement.
+
+3. Added GetAnnouncementsByEventID method for the announcement history of an event, newest first.
+
+4. Created EnsureAnnouncementIndex method for the (event, created_at) index the history is read with.
+
+************************************************************************************************************/
+
+type AnnouncementStore struct {
+	collection *mongo.Collection
+}
+
+func NewAnnouncementStore(db *mongo.Database) *AnnouncementStore {
+	return &AnnouncementStore{
+		collection: db.Collection("Announcements"),
+	}
+}
+
+// CreateAnnouncement stores an announcement
+func (s *AnnouncementStore) CreateAnnouncement(ctx context.Context, announcement *models.Announcement) error {
+	announcement.CreatedAt = time.Now()
+
+	result, err := s.collection.InsertOne(ctx, announcement)
+	if err != nil {
+		return err
+	}
+
+	announcement.ID = result.InsertedID.(bson.ObjectID)
+	return nil
+}
+
+// GetAnnouncementsByEventID returns the latest announcements of an event, newest first
+func (s *AnnouncementStore) GetAnnouncementsByEventID(ctx context.Context, eventID bson.ObjectID, limit int) ([]models.Announcement, error) {
+	var announcements []models.Announcement
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := s.collection.Find(ctx, bson.M{"event_id": eventID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	if err = cursor.All(ctx, &announcements); err != nil {
+		return nil, err
+	}
+
+	if announcements == nil {
+		announcements = []models.Announcement{}
+	}
+
+	return announcements, nil
+}
+
+// EnsureAnnouncementIndex creates the (event_id, created_at) index the history is read with
+func (s *AnnouncementStore) EnsureAnnouncementIndex(ctx context.Context) error {
+	index := mongo.IndexModel{
+		Keys:    bson.D{{Key: "event_id", Value: 1}, {Key: "created_at", Value: -1}},
+		Options: options.Index().SetName("event_created_at"),
+	}
+
+	_, err := s.collection.Indexes().CreateOne(ctx, index)
+	return err
+}
@@ -0,0 +1,109 @@
+package store
+
+import (
+	"context"
+	"event-horizon/models"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+/******************** MONGODB FUNCTIONALITY FOR AUDIT LOGS COLLECTION ********************
+
+1. BSON MAPPING FOR AUDIT LOGS COLLECTION
+2. InsertOne
+3. Find (filtered, newest first, limited)
+
+ ****************************************************************************************/
+
+/************************** I DID THE FOLLOWING THINGS IN THIS FILE: *******************************************
+
+1. Created AuditStore struct to keep an append-only log of sensitive actions.
+
+2. Implemented RecordAudit method to write a single audit entry.
+
+3. Developed GetAuditLogs method so admins can query the log by action, actor or target.
+
+4. Added CountCancelledBookings, counted from the audit log so bookings cancelled back when cancelling deleted them are counted too.
+
+5. Before / after snapshots are read back as objects so they are JSON objects in responses, not key / value arrays.
+
+************************************************************************************************************/
+
+// AuditFilter narrows down an audit log query, zero values are ignored
+type AuditFilter struct {
+	Action   string
+	ActorID  bson.ObjectID
+	TargetID bson.ObjectID
+	Limit    int64
+}
+
+type AuditStore struct {
+	collection *mongo.Collection
+}
+
+func NewAuditStore(db *mongo.Database) *AuditStore {
+	return &AuditStore{
+		//? before / after are read back as objects (bson.M), bson.D would marshal to [{"Key": ..., "Value": ...}]
+		collection: db.Collection("AuditLogs", options.Collection().SetBSONOptions(&options.BSONOptions{DefaultDocumentM: true})),
+	}
+}
+
+// RecordAudit writes an audit entry
+func (s *AuditStore) RecordAudit(ctx context.Context, entry *models.AuditLog) error {
+	entry.CreatedAt = time.Now()
+
+	result, err := s.collection.InsertOne(ctx, entry)
+	if err != nil {
+		return err
+	}
+
+	entry.ID = result.InsertedID.(bson.ObjectID)
+	return nil
+}
+
+// GetAuditLogs returns audit entries matching the filter, newest first
+func (s *AuditStore) GetAuditLogs(ctx context.Context, auditFilter AuditFilter) ([]models.AuditLog, error) {
+	var logs []models.AuditLog
+
+	filter := bson.M{}
+	if auditFilter.Action != "" {
+		filter["action"] = auditFilter.Action
+	}
+	if !auditFilter.ActorID.IsZero() {
+		filter["actor_id"] = auditFilter.ActorID
+	}
+	if !auditFilter.TargetID.IsZero() {
+		filter["target_id"] = auditFilter.TargetID
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetLimit(auditFilter.Limit)
+
+	cursor, err := s.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	if err = cursor.All(ctx, &logs); err != nil {
+		return nil, err
+	}
+
+	if logs == nil {
+		logs = []models.AuditLog{}
+	}
+
+	return logs, nil
+}
+
+// CountCancelledBookings counts the cancellations of an event's bookings (the log keeps the booking as "before")
+func (s *AuditStore) CountCancelledBookings(ctx context.Context, eventID bson.ObjectID) (int64, error) {
+	return s.collection.CountDocuments(ctx, bson.M{
+		"action":          models.AuditBookingCancelled,
+		"before.event_id": eventID,
+	})
+}
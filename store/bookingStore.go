@@ -4,11 +4,14 @@ import (
 	"context"
 	"errors"
 	"event-horizon/models"
+	"event-horizon/tracing"
 	"fmt"
 	"time"
 
 	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 /******************** MONGODB FUNCTIONALITY FOR BOOKINGS COLLECTION ********************
@@ -29,7 +32,7 @@ import (
 
 2. Implemented NewBookingStore constructor to initialize BookingStore with MongoDB collections.
 
-3. Developed CreateBooking method to add new bookings, ensuring ticket availability and updating event ticket quantities within a transaction.
+3. Developed CreateBooking method to add new bookings, ensuring ticket (and session) availability and updating event ticket quantities within a transaction.
 
 4. Created GetBookingByID method to fetch a specific booking by its ID.
 
@@ -37,18 +40,90 @@ import (
 
 6. Developed GetBookingsByEventID method to fetch all bookings for a specific event.
 
-7. Implemented CancelBooking method to delete a booking and restore ticket quantities within a transaction.
+7. Implemented CancelBooking method to mark a booking cancelled (it is kept for the records) and restore ticket quantities within a transaction.
 
 8. Created GetAllBookings method to retrieve all bookings (admin function).
 
 9. Added DeleteBookingsByEventID method to delete all bookings associated with a specific event.
 
+10. Added HasConfirmedBooking method to check if a user holds a confirmed booking for an event.
+
+11. Added GetSoldTicketCounts method to sum confirmed booking quantities per ticket type for an event,
+    and GetBookedSessionSeats for the seats booked per session.
+
+12. CreateBooking and CancelBooking drop the cached event after the transaction, since ticket counts changed.
+
+13. Added SetLocker so CreateBooking can take a per-event distributed lock (Redis) before its transaction.
+
+14. CreateBooking and CancelBooking bump the event's updated_at, so conditional GETs see the new availability.
+
+15. CreateBooking refuses events of SUSPENDED hosts with ErrHostSuspended.
+
+16. Added GetTicketSales and GetDailySales aggregations for the host's event ANALYTICS.
+
+17. Added ExportHostBookings to stream the bookings of every event a host manages (oldest first) for the accounting EXPORT.
+
+18. CreateBooking charges in MINOR UNITS of the event's currency, sales aggregations sum total_paid_minor.
+
+19. CreateBooking adds the event's TAX and stores subtotal, tax and rate on the booking.
+
+20. Added GetBookingByGuestCode, ClaimGuestBookings and EnsureGuestCodeIndex for GUEST checkout.
+
+21. Added UpdateAttendees (names and emails of a booking's ATTENDEES) and EnsureCheckInCodeIndex.
+
+22. Added CheckInAttendee and GetCheckInCounts for CHECK-IN at the door.
+
+23. Added GetCoBookedEventCounts ("people who booked these events also booked") for RECOMMENDATIONS.
+
+24. Added SetAffiliate, GetAffiliateSales and EnsureAffiliateIndex for AFFILIATE commissions.
+
+25. GetAllBookings became ListBookings: newest first, filtered by user, status and booking date, one CURSOR page at a time (EnsureBookingListIndex).
+
+26. ListBookings can keep the bookings of UPCOMING or PAST events, a $lookup on Events compares the event's end_time.
+
+27. ListBookings and GetEventBookingsWithUsers return BookingWithDetails: the event's name, dates and location and, for hosts and admins, the buyer's name and email are looked up.
+
+28. Added ChangeTicketType: moves a booking's tickets to another ticket type in one transaction, reprices it and records the difference.
+
+29. Added RequestReconfirmation, Reconfirm, GetExpiredReconfirmations and EnsureReconfirmIndex for RESCHEDULED events.
+
+30. Added SetOutbox: with an outbox, CreateBooking and CancelBooking write their OUTBOX message in the same transaction.
+
+31. CreateBooking, CancelBooking and ChangeTicketType are TRACED: one span for the transaction and one for the wait on the event lock, the Mongo commands hang below.
+
+32. Added CountBuyerTickets, the tickets a user or guest holds for an event, for the per-buyer cap against SCALPERS.
+    CreateBooking counts them inside its transaction and returns a BuyerCapError once the cap would be passed.
+
+33. Added AnonymizeUserBookings, the bookings of deleted accounts keep their amounts for the hosts' revenue but lose the attendee names and emails.
+
+34. Added GetWalletTickets method, upcoming confirmed bookings with a trimmed event lookup and no amounts or attendee emails.
+
 ************************************************************************************************************/
 
+// Values of BookingFilter.When
+const (
+	BookingsUpcoming = "upcoming" //? The event hasn't ended yet
+	BookingsPast     = "past"
+)
+
+// BookingFilter narrows down a booking listing, zero values are ignored
+type BookingFilter struct {
+	UserID   bson.ObjectID
+	Status   string
+	From     time.Time     //? booked_at at or after
+	To       time.Time     //? booked_at before
+	When     string        //? BookingsUpcoming or BookingsPast, by the event's end time
+	WithUser bool          //? Look up the buyer's name and email (hosts and admins)
+	After    bson.ObjectID //? Cursor, the last booking of the previous page
+	Limit    int64
+}
+
 type BookingStore struct {
 	db                *mongo.Database
 	bookingCollection *mongo.Collection
 	eventCollection   *mongo.Collection
+	locker            BookingLocker // optional, nil means no distributed lock
+	outbox            *OutboxStore  // optional, nil means booking changes are not announced
 }
 
 func NewBookingStore(db *mongo.Database) *BookingStore {
@@ -59,10 +134,70 @@ func NewBookingStore(db *mongo.Database) *BookingStore {
 	}
 }
 
-// CreateBooking creates a booking with transaction to ensure data consistency
-func (s *BookingStore) CreateBooking(ctx context.Context, booking *models.Booking) error {
+// SetLocker ! SetLocker serializes bookings of the same event across instances
+func (s *BookingStore) SetLocker(locker BookingLocker) {
+	s.locker = locker
+}
+
+// SetOutbox ! SetOutbox writes an outbox message for every booking created or cancelled, in the same transaction
+func (s *BookingStore) SetOutbox(outbox *OutboxStore) {
+	s.outbox = outbox
+}
+
+// addToOutbox announces the booking change inside the transaction, a no-op without an outbox
+func (s *BookingStore) addToOutbox(sessCtx context.Context, messageType string, booking models.Booking) error {
+	if s.outbox == nil {
+		return nil
+	}
+	return s.outbox.add(sessCtx, messageType, booking)
+}
+
+// ErrHostSuspended is returned when booking an event whose host was suspended by an admin
+var ErrHostSuspended = errors.New("the host of this event is suspended, it can't be booked")
+
+// ErrNotEnoughTickets is returned when a ticket type has fewer tickets left than asked for
+var ErrNotEnoughTickets = errors.New("not enough tickets available")
+
+// ErrBookingCancelled is returned when cancelling a booking that was cancelled before
+var ErrBookingCancelled = errors.New("booking already cancelled")
+
+// BuyerCapError is returned when a booking would give its buyer more tickets of the event than allowed
+type BuyerCapError struct {
+	Max  int
+	Held int
+}
+
+func (e *BuyerCapError) Error() string {
+	return fmt.Sprintf("At most %d tickets per person can be booked for this event, you already hold %d", e.Max, e.Held)
+}
+
+// lockEvent takes the distributed lock of the event (traced, it is where hot events wait), a no-op without a locker
+func (s *BookingStore) lockEvent(ctx context.Context, eventID bson.ObjectID) (func(), error) {
+	if s.locker == nil {
+		return func() {}, nil
+	}
+
+	ctx, span := tracing.Start(ctx, "BookingLocker.Lock", tracing.KindInternal)
+	unlock, err := s.locker.Lock(ctx, eventID)
+	tracing.End(span, err)
+	return unlock, err
+}
+
+// CreateBooking creates a booking with transaction to ensure data consistency.
+// maxPerBuyer caps the tickets the buyer holds for the event (0 means no cap), it is counted inside the transaction
+func (s *BookingStore) CreateBooking(ctx context.Context, booking *models.Booking, maxPerBuyer int) (err error) {
+	ctx, span := tracing.Start(ctx, "BookingStore.CreateBooking", tracing.KindInternal, attribute.String("event.id", booking.EventID.Hex()))
+	defer func() { tracing.End(span, err) }()
+
+	//! Take the event lock first so hot events don't storm the transaction with retries
+	unlock, err := s.lockEvent(ctx, booking.EventID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	//? Start a session for transaction
-	session, err := s.db.Client().StartSession()
+	session, err := s.db.Client().StartSession(txnSessionOptions)
 	if err != nil {
 		return err
 	}
@@ -81,6 +216,16 @@ func (s *BookingStore) CreateBooking(ctx context.Context, booking *models.Bookin
 			return nil, err
 		}
 
+		//! Events of suspended hosts can't be booked
+		if event.HostSuspended {
+			return nil, ErrHostSuspended
+		}
+
+		//! Drafts and events waiting for review can't be booked until they are published
+		if !event.IsPublished() {
+			return nil, errors.New("event is not published yet")
+		}
+
 		//? 2. Find the matching ticket type in the event's tickets array
 		var selectedTicket *models.TicketInfo
 		var ticketIndex int
@@ -98,11 +243,57 @@ func (s *BookingStore) CreateBooking(ctx context.Context, booking *models.Bookin
 
 		//? 3. Check ticket availability
 		if selectedTicket.AvailableQuantity < booking.Quantity {
-			return nil, errors.New("not enough tickets available")
+			return nil, ErrNotEnoughTickets
 		}
 
-		//? 4. Calculate total price
-		booking.TotalPaid = selectedTicket.Price * float64(booking.Quantity)
+		//! Per-buyer cap against SCALPERS, counted in the transaction so parallel bookings of one buyer can't both pass.
+		//! Every booking also writes the event document, so two of them racing conflict and one is retried and recounts
+		if maxPerBuyer > 0 {
+			held, err := s.CountBuyerTickets(sessCtx, booking.EventID, booking.UserID, booking.GuestID)
+			if err != nil {
+				return nil, err
+			}
+			if held+booking.Quantity > maxPerBuyer {
+				return nil, &BuyerCapError{Max: maxPerBuyer, Held: held}
+			}
+		}
+
+		//? 3b. Check session capacity when booking a specific session
+		sessionIndex := -1
+		if !booking.SessionID.IsZero() {
+			for i, session := range event.Sessions {
+				if session.ID == booking.SessionID {
+					sessionIndex = i
+					break
+				}
+			}
+
+			if sessionIndex == -1 {
+				return nil, errors.New("session not found for this event")
+			}
+
+			session := event.Sessions[sessionIndex]
+			if session.Capacity > 0 && session.AvailableCapacity < booking.Quantity {
+				return nil, errors.New("not enough seats available in this session")
+			}
+		}
+
+		//? 4. Calculate total price in minor units of the event's currency (no float rounding)
+		if selectedTicket.PriceMinor == 0 {
+			//! Events not migrated yet only have the float price
+			selectedTicket.PriceMinor = models.ToMinorUnits(selectedTicket.Price, event.Currency)
+		}
+		booking.Currency = event.Currency
+		if booking.Currency == "" {
+			booking.Currency = models.DefaultCurrency
+		}
+
+		//? 4b. Add (or take out) the event's tax, the booking keeps the rate it was sold with
+		booking.TaxRate = event.TaxRate
+		booking.TaxInclusive = event.TaxInclusive && event.TaxRate > 0
+		booking.SubtotalMinor, booking.TaxMinor, booking.TotalPaidMinor = models.ApplyTax(
+			selectedTicket.PriceMinor*int64(booking.Quantity), event.TaxRate, event.TaxInclusive)
+		booking.TotalPaid = models.FromMinorUnits(booking.TotalPaidMinor, booking.Currency)
 		booking.BookedAt = time.Now()
 		booking.Status = "confirmed"
 
@@ -113,6 +304,11 @@ func (s *BookingStore) CreateBooking(ctx context.Context, booking *models.Bookin
 		}
 		booking.ID = result.InsertedID.(bson.ObjectID)
 
+		//! Committed with the booking or not at all
+		if err := s.addToOutbox(sessCtx, models.OutboxBookingCreated, *booking); err != nil {
+			return nil, err
+		}
+
 		//? 6. Update event's ticket available quantity using positional operator
 		newAvailableQuantity := selectedTicket.AvailableQuantity - booking.Quantity
 
@@ -121,9 +317,16 @@ func (s *BookingStore) CreateBooking(ctx context.Context, booking *models.Bookin
 		eventUpdate := bson.M{
 			"$set": bson.M{
 				ticketFieldPath: newAvailableQuantity,
+				"updated_at":    time.Now(),
 			},
 		}
 
+		//? Reserve seats in the session if it has a capacity
+		if sessionIndex != -1 && event.Sessions[sessionIndex].Capacity > 0 {
+			sessionFieldPath := "sessions." + fmt.Sprint(sessionIndex) + ".available_capacity"
+			eventUpdate["$set"].(bson.M)[sessionFieldPath] = event.Sessions[sessionIndex].AvailableCapacity - booking.Quantity
+		}
+
 		if _, err := s.eventCollection.UpdateOne(sessCtx, eventFilter, eventUpdate); err != nil {
 			return nil, err
 		}
@@ -133,6 +336,9 @@ func (s *BookingStore) CreateBooking(ctx context.Context, booking *models.Bookin
 
 	//? Execute transaction
 	_, err = session.WithTransaction(ctx, callback)
+
+	//? Ticket counts changed, cached reads of the event are stale
+	eventReadCache.invalidate(booking.EventID)
 	return err
 }
 
@@ -199,15 +405,20 @@ func (s *BookingStore) GetBookingsByEventID(ctx context.Context, eventID bson.Ob
 	return bookings, nil
 }
 
-// CancelBooking deletes a booking and restores ticket quantity
-func (s *BookingStore) CancelBooking(ctx context.Context, bookingID bson.ObjectID) error {
+// CancelBooking marks a booking cancelled and restores ticket quantity, the booking itself is kept
+func (s *BookingStore) CancelBooking(ctx context.Context, bookingID bson.ObjectID) (err error) {
+	ctx, span := tracing.Start(ctx, "BookingStore.CancelBooking", tracing.KindInternal, attribute.String("booking.id", bookingID.Hex()))
+	defer func() { tracing.End(span, err) }()
+
 	//? Start a session for transaction
-	session, err := s.db.Client().StartSession()
+	session, err := s.db.Client().StartSession(txnSessionOptions)
 	if err != nil {
 		return err
 	}
 	defer session.EndSession(ctx)
 
+	var cancelledEventID bson.ObjectID
+
 	// Define transaction callback
 	callback := func(sessCtx context.Context) (interface{}, error) {
 		//? 1. Get the booking
@@ -223,7 +434,19 @@ func (s *BookingStore) CancelBooking(ctx context.Context, bookingID bson.ObjectI
 
 		//? Check if already cancelled
 		if booking.Status == "cancelled" {
-			return nil, errors.New("booking already cancelled")
+			return nil, ErrBookingCancelled
+		}
+		cancelledEventID = booking.EventID
+
+		//! Kept with its status, a pending reconfirmation is dropped so the refund scheduler doesn't pick it up again
+		bookingUpdate := bson.M{
+			"$set":   bson.M{"status": "cancelled", "cancelled_at": time.Now()},
+			"$unset": bson.M{"needs_reconfirmation": "", "reconfirm_by": ""},
+		}
+
+		//! Committed with the cancellation or not at all
+		if err := s.addToOutbox(sessCtx, models.OutboxBookingCancelled, booking); err != nil {
+			return nil, err
 		}
 
 		//? 2. Get the event and find the ticket type
@@ -233,8 +456,8 @@ func (s *BookingStore) CancelBooking(ctx context.Context, bookingID bson.ObjectI
 		if err := s.eventCollection.FindOne(sessCtx, eventFilter).Decode(&event); err != nil {
 			//! If event doesn't exist
 			if errors.Is(err, mongo.ErrNoDocuments) {
-				//! Delete  booking without restoring tickets
-				if _, err := s.bookingCollection.DeleteOne(sessCtx, bookingFilter); err != nil {
+				//! Cancel the booking without restoring tickets
+				if _, err := s.bookingCollection.UpdateOne(sessCtx, bookingFilter, bookingUpdate); err != nil {
 					return nil, err
 				}
 				return nil, nil
@@ -260,16 +483,26 @@ func (s *BookingStore) CancelBooking(ctx context.Context, bookingID bson.ObjectI
 			eventUpdate := bson.M{
 				"$set": bson.M{
 					ticketFieldPath: newAvailableQuantity,
+					"updated_at":    time.Now(),
 				},
 			}
 
+			//? Give the seats back to the session too
+			for i, session := range event.Sessions {
+				if session.ID == booking.SessionID && !booking.SessionID.IsZero() && session.Capacity > 0 {
+					sessionFieldPath := "sessions." + fmt.Sprint(i) + ".available_capacity"
+					eventUpdate["$set"].(bson.M)[sessionFieldPath] = session.AvailableCapacity + booking.Quantity
+					break
+				}
+			}
+
 			if _, err := s.eventCollection.UpdateOne(sessCtx, eventFilter, eventUpdate); err != nil {
 				return nil, err
 			}
 		}
 
-		//? 5. Delete the booking
-		if _, err := s.bookingCollection.DeleteOne(sessCtx, bookingFilter); err != nil {
+		//? 5. Mark the booking cancelled
+		if _, err := s.bookingCollection.UpdateOne(sessCtx, bookingFilter, bookingUpdate); err != nil {
 			return nil, err
 		}
 
@@ -278,30 +511,380 @@ func (s *BookingStore) CancelBooking(ctx context.Context, bookingID bson.ObjectI
 
 	// Execute transaction
 	_, err = session.WithTransaction(ctx, callback)
+
+	//? Ticket counts changed, cached reads of the event are stale
+	if !cancelledEventID.IsZero() {
+		eventReadCache.invalidate(cancelledEventID)
+	}
 	return err
 }
 
-// GetAllBookings retrieves all bookings (admin function)
-func (s *BookingStore) GetAllBookings(ctx context.Context) ([]models.Booking, error) {
-	var bookings []models.Booking
+// ChangeTicketType moves all tickets of a confirmed booking to another ticket type of its event, the tickets of the old
+// type go back on sale. The booking is repriced with the tax rate it was sold with and the difference is recorded on it.
+func (s *BookingStore) ChangeTicketType(ctx context.Context, bookingID bson.ObjectID, ticketType string) (_ *models.Booking, _ *models.PriceAdjustment, err error) {
+	ctx, span := tracing.Start(ctx, "BookingStore.ChangeTicketType", tracing.KindInternal, attribute.String("booking.id", bookingID.Hex()))
+	defer func() { tracing.End(span, err) }()
+
+	var booking models.Booking
+	if err := s.bookingCollection.FindOne(ctx, bson.M{"_id": bookingID}).Decode(&booking); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil, errors.New("booking not found")
+		}
+		return nil, nil, err
+	}
+
+	//! Same lock as CreateBooking, the new type's tickets are taken like a new booking's
+	unlock, err := s.lockEvent(ctx, booking.EventID)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer unlock()
 
-	cursor, err := s.bookingCollection.Find(ctx, bson.M{})
+	//? Start a session for transaction
+	session, err := s.db.Client().StartSession(txnSessionOptions)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer session.EndSession(ctx)
+
+	var adjustment models.PriceAdjustment
+
+	//! CALLBACK FUNCTION FOR TRANSACTION
+	callback := func(sessCtx context.Context) (interface{}, error) {
+		//? 1. Read the booking again inside the transaction
+		bookingFilter := bson.M{"_id": bookingID}
+		if err := s.bookingCollection.FindOne(sessCtx, bookingFilter).Decode(&booking); err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				return nil, errors.New("booking not found")
+			}
+			return nil, err
+		}
+		if booking.Status != "confirmed" {
+			return nil, errors.New("only confirmed bookings can be changed")
+		}
+		if booking.TicketType == ticketType {
+			return nil, errors.New("the booking already has this ticket type")
+		}
+
+		//? 2. Find the old and the new ticket type
+		var event models.Event
+		eventFilter := bson.M{"_id": booking.EventID}
+		if err := s.eventCollection.FindOne(sessCtx, eventFilter).Decode(&event); err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				return nil, errors.New("event not found")
+			}
+			return nil, err
+		}
+
+		oldIndex, newIndex := -1, -1
+		for i, ticket := range event.Tickets {
+			switch ticket.Type {
+			case booking.TicketType:
+				oldIndex = i
+			case ticketType:
+				newIndex = i
+			}
+		}
+		if newIndex == -1 {
+			return nil, errors.New("ticket type not found for this event")
+		}
+
+		//? 3. Check availability of the new type
+		newTicket := event.Tickets[newIndex]
+		if newTicket.AvailableQuantity < booking.Quantity {
+			return nil, ErrNotEnoughTickets
+		}
+
+		//? 4. Reprice in minor units with the booking's own currency and tax rate
+		priceMinor := newTicket.PriceMinor
+		if priceMinor == 0 {
+			//! Events not migrated yet only have the float price
+			priceMinor = models.ToMinorUnits(newTicket.Price, booking.Currency)
+		}
+		subtotal, tax, total := models.ApplyTax(priceMinor*int64(booking.Quantity), booking.TaxRate, booking.TaxInclusive)
+
+		adjustment = models.PriceAdjustment{
+			FromType:        booking.TicketType,
+			ToType:          ticketType,
+			DifferenceMinor: total - booking.TotalPaidMinor,
+			Currency:        booking.Currency,
+			ChangedAt:       time.Now(),
+		}
+
+		booking.TicketType = ticketType
+		booking.SubtotalMinor, booking.TaxMinor, booking.TotalPaidMinor = subtotal, tax, total
+		booking.TotalPaid = models.FromMinorUnits(total, booking.Currency)
+		booking.Adjustments = append(booking.Adjustments, adjustment)
+
+		//? 5. Update the booking
+		bookingUpdate := bson.M{
+			"$set": bson.M{
+				"ticket_type":      booking.TicketType,
+				"subtotal_minor":   booking.SubtotalMinor,
+				"tax_minor":        booking.TaxMinor,
+				"total_paid_minor": booking.TotalPaidMinor,
+				"total_paid":       booking.TotalPaid,
+			},
+			"$push": bson.M{"adjustments": adjustment},
+		}
+		if _, err := s.bookingCollection.UpdateOne(sessCtx, bookingFilter, bookingUpdate); err != nil {
+			return nil, err
+		}
+
+		//? 6. Take the new type's tickets and give the old type's back (a removed type has nothing to restore)
+		set := bson.M{
+			"tickets." + fmt.Sprint(newIndex) + ".available_quantity": newTicket.AvailableQuantity - booking.Quantity,
+			"updated_at": time.Now(),
+		}
+		if oldIndex != -1 {
+			set["tickets."+fmt.Sprint(oldIndex)+".available_quantity"] = event.Tickets[oldIndex].AvailableQuantity + booking.Quantity
+		}
+		if _, err := s.eventCollection.UpdateOne(sessCtx, eventFilter, bson.M{"$set": set}); err != nil {
+			return nil, err
+		}
+
+		return nil, nil
+	}
+
+	//? Execute transaction
+	_, err = session.WithTransaction(ctx, callback)
+
+	//? Ticket counts changed, cached reads of the event are stale
+	eventReadCache.invalidate(booking.EventID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &booking, &adjustment, nil
+}
+
+// RequestReconfirmation flags the confirmed bookings of an event as needing reconfirmation by the given time and returns
+// how many were flagged. Guest bookings are left alone, without an account they can't answer.
+func (s *BookingStore) RequestReconfirmation(ctx context.Context, eventID bson.ObjectID, by time.Time) (int64, error) {
+	filter := bson.M{
+		"event_id": eventID,
+		"status":   "confirmed",
+		"user_id":  bson.M{"$exists": true},
+	}
+	update := bson.M{"$set": bson.M{"needs_reconfirmation": true, "reconfirm_by": by}}
+
+	result, err := s.bookingCollection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+// Reconfirm clears the reconfirmation flag of a booking, false when it wasn't flagged (anymore)
+func (s *BookingStore) Reconfirm(ctx context.Context, bookingID bson.ObjectID) (bool, error) {
+	filter := bson.M{"_id": bookingID, "needs_reconfirmation": true}
+	update := bson.M{"$unset": bson.M{"needs_reconfirmation": "", "reconfirm_by": ""}}
+
+	result, err := s.bookingCollection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount == 1, nil
+}
+
+// GetExpiredReconfirmations returns up to limit flagged bookings whose reconfirm_by is at or before due, oldest deadline first
+func (s *BookingStore) GetExpiredReconfirmations(ctx context.Context, due time.Time, limit int) ([]models.Booking, error) {
+	filter := bson.M{
+		"needs_reconfirmation": true,
+		"reconfirm_by":         bson.M{"$lte": due},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "reconfirm_by", Value: 1}}).SetLimit(int64(limit))
+
+	cursor, err := s.bookingCollection.Find(ctx, filter, opts)
 	if err != nil {
 		return nil, err
 	}
 	defer cursor.Close(ctx)
 
+	bookings := []models.Booking{}
+	if err := cursor.All(ctx, &bookings); err != nil {
+		return nil, err
+	}
+	return bookings, nil
+}
+
+// EnsureReconfirmIndex creates the sparse index the reconfirmation scheduler looks up expired bookings with
+func (s *BookingStore) EnsureReconfirmIndex(ctx context.Context) error {
+	index := mongo.IndexModel{
+		Keys:    bson.D{{Key: "reconfirm_by", Value: 1}},
+		Options: options.Index().SetName("reconfirm_by").SetSparse(true),
+	}
+
+	_, err := s.bookingCollection.Indexes().CreateOne(ctx, index)
+	return err
+}
+
+// ListBookings returns one page of bookings with their event looked up, newest first. nextCursor is empty on the last page.
+func (s *BookingStore) ListBookings(ctx context.Context, bookingFilter BookingFilter) (bookings []models.BookingWithDetails, nextCursor string, err error) {
+	filter := bson.M{}
+	if !bookingFilter.UserID.IsZero() {
+		filter["user_id"] = bookingFilter.UserID
+	}
+	if bookingFilter.Status != "" {
+		filter["status"] = bookingFilter.Status
+	}
+	bookedAt := bson.M{}
+	if !bookingFilter.From.IsZero() {
+		bookedAt["$gte"] = bookingFilter.From
+	}
+	if !bookingFilter.To.IsZero() {
+		bookedAt["$lt"] = bookingFilter.To
+	}
+	if len(bookedAt) > 0 {
+		filter["booked_at"] = bookedAt
+	}
+	//? ObjectIDs grow with time, so _id orders the bookings like booked_at and never ties
+	if !bookingFilter.After.IsZero() {
+		filter["_id"] = bson.M{"$lt": bookingFilter.After}
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$sort", Value: bson.D{{Key: "_id", Value: -1}}}},
+	}
+	pipeline = append(pipeline, bookingEventLookup()...)
+	if bookingFilter.When != "" {
+		endTime := bson.M{"$gt": time.Now()}
+		if bookingFilter.When == BookingsPast {
+			endTime = bson.M{"$lte": time.Now()}
+		}
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.M{"event.end_time": endTime}}}) //! Bookings of deleted events are in neither tab
+	}
+	//* One extra booking tells whether there is a next page
+	pipeline = append(pipeline, bson.D{{Key: "$limit", Value: bookingFilter.Limit + 1}})
+	if bookingFilter.WithUser {
+		pipeline = append(pipeline, bookingUserLookup()...)
+	}
+
+	cursor, err := s.bookingCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, "", err
+	}
+	defer cursor.Close(ctx)
+
+	bookings = []models.BookingWithDetails{} //** Return empty slice
 	if err = cursor.All(ctx, &bookings); err != nil {
+		return nil, "", err
+	}
+
+	if int64(len(bookings)) > bookingFilter.Limit {
+		bookings = bookings[:bookingFilter.Limit]
+		nextCursor = bookings[len(bookings)-1].ID.Hex()
+	}
+	return bookings, nextCursor, nil
+}
+
+// ! GetWalletTickets returns the user's confirmed bookings of events that haven't ended, soonest first, with only what the
+// ticket wallet shows (no amounts, no attendee emails). Bookings of deleted events are left out
+func (s *BookingStore) GetWalletTickets(ctx context.Context, userID bson.ObjectID, limit int) ([]models.WalletTicket, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"user_id": userID, "status": "confirmed"}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from": "Events",
+			"let":  bson.M{"eventId": "$event_id"},
+			"pipeline": bson.A{
+				bson.M{"$match": bson.M{"$expr": bson.M{"$eq": bson.A{"$_id", "$$eventId"}}}},
+				bson.M{"$project": bson.M{
+					"name": 1, "start_time": 1, "end_time": 1, "timezone": 1, "location": 1, "geo_location": 1,
+					"venue_id": 1, "event_type": 1, "image_url": 1, "sessions": 1,
+				}},
+			},
+			"as": "event",
+		}}},
+		{{Key: "$unwind", Value: "$event"}},
+		{{Key: "$match", Value: bson.M{"event.end_time": bson.M{"$gt": time.Now()}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "event.start_time", Value: 1}, {Key: "_id", Value: 1}}}},
+		{{Key: "$limit", Value: limit}},
+		{{Key: "$project", Value: bson.M{
+			"ticket_type": 1, "quantity": 1, "session_id": 1, "needs_reconfirmation": 1, "reconfirm_by": 1, "event": 1,
+			"attendees.name": 1, "attendees.check_in_code": 1, "attendees.checked_in_at": 1,
+		}}},
+	}
+
+	cursor, err := s.bookingCollection.Aggregate(ctx, pipeline)
+	if err != nil {
 		return nil, err
 	}
+	defer cursor.Close(ctx)
 
-	if bookings == nil {
-		bookings = []models.Booking{}
+	tickets := []models.WalletTicket{} //** Return empty slice
+	if err := cursor.All(ctx, &tickets); err != nil {
+		return nil, err
 	}
+	return tickets, nil
+}
 
+// GetEventBookingsWithUsers retrieves all bookings of an event with their buyer's name and email looked up
+func (s *BookingStore) GetEventBookingsWithUsers(ctx context.Context, eventID bson.ObjectID) ([]models.BookingWithDetails, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"event_id": eventID}}},
+		{{Key: "$sort", Value: bson.D{{Key: "_id", Value: -1}}}},
+	}
+	pipeline = append(pipeline, bookingUserLookup()...)
+
+	cursor, err := s.bookingCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	bookings := []models.BookingWithDetails{} //** Return empty slice
+	if err := cursor.All(ctx, &bookings); err != nil {
+		return nil, err
+	}
 	return bookings, nil
 }
 
+// bookingEventLookup puts the fields of the booking's event a ticket list shows into "event"
+func bookingEventLookup() mongo.Pipeline {
+	return mongo.Pipeline{
+		{{Key: "$lookup", Value: bson.M{
+			"from": "Events",
+			"let":  bson.M{"eventId": "$event_id"},
+			"pipeline": bson.A{
+				bson.M{"$match": bson.M{"$expr": bson.M{"$eq": bson.A{"$_id", "$$eventId"}}}},
+				bson.M{"$project": bson.M{
+					"name": 1, "date": 1, "start_time": 1, "end_time": 1, "timezone": 1,
+					"location": 1, "event_type": 1, "image_url": 1,
+				}},
+			},
+			"as": "event",
+		}}},
+		{{Key: "$unwind", Value: bson.M{"path": "$event", "preserveNullAndEmptyArrays": true}}},
+	}
+}
+
+// bookingUserLookup puts the buyer's name and email into "user", guest bookings have none
+func bookingUserLookup() mongo.Pipeline {
+	return mongo.Pipeline{
+		{{Key: "$lookup", Value: bson.M{
+			"from": "Users",
+			"let":  bson.M{"userId": "$user_id"},
+			"pipeline": bson.A{
+				bson.M{"$match": bson.M{"$expr": bson.M{"$eq": bson.A{"$_id", "$$userId"}}}},
+				bson.M{"$project": bson.M{"name": 1, "email": 1}},
+			},
+			"as": "user",
+		}}},
+		{{Key: "$unwind", Value: bson.M{"path": "$user", "preserveNullAndEmptyArrays": true}}},
+	}
+}
+
+// EnsureBookingListIndex creates the index a user's booking pages are read from
+func (s *BookingStore) EnsureBookingListIndex(ctx context.Context) error {
+	index := mongo.IndexModel{
+		Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "_id", Value: -1}},
+		Options: options.Index().SetName("user_bookings_newest_first"),
+	}
+
+	_, err := s.bookingCollection.Indexes().CreateOne(ctx, index)
+	return err
+}
+
 // DeleteBookingsByEventID deletes all bookings associated with a specific event
 func (s *BookingStore) DeleteBookingsByEventID(ctx context.Context, eventID bson.ObjectID) (int64, error) {
 	filter := bson.M{"event_id": eventID}
@@ -313,3 +896,521 @@ func (s *BookingStore) DeleteBookingsByEventID(ctx context.Context, eventID bson
 
 	return result.DeletedCount, nil
 }
+
+// HasConfirmedBooking reports whether the user has a confirmed booking for the event
+func (s *BookingStore) HasConfirmedBooking(ctx context.Context, userID, eventID bson.ObjectID) (bool, error) {
+	filter := bson.M{
+		"user_id":  userID,
+		"event_id": eventID,
+		"status":   "confirmed",
+	}
+
+	count, err := s.bookingCollection.CountDocuments(ctx, filter)
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// CountBuyerTickets returns how many tickets the user (or guest) holds in confirmed bookings of the event
+func (s *BookingStore) CountBuyerTickets(ctx context.Context, eventID, userID, guestID bson.ObjectID) (int, error) {
+	filter := bson.M{"event_id": eventID, "status": "confirmed", "user_id": userID}
+	if userID.IsZero() {
+		delete(filter, "user_id")
+		filter["guest_id"] = guestID
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$group", Value: bson.M{"_id": nil, "tickets": bson.M{"$sum": "$quantity"}}}},
+	}
+	cursor, err := s.bookingCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var result []struct {
+		Tickets int `bson:"tickets"`
+	}
+	if err := cursor.All(ctx, &result); err != nil {
+		return 0, err
+	}
+	if len(result) == 0 {
+		return 0, nil
+	}
+	return result[0].Tickets, nil
+}
+
+// GetSoldTicketCounts returns how many tickets of each type are held by confirmed bookings of the event
+func (s *BookingStore) GetSoldTicketCounts(ctx context.Context, eventID bson.ObjectID) (map[string]int, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"event_id": eventID, "status": "confirmed"}}},
+		{{Key: "$group", Value: bson.M{"_id": "$ticket_type", "sold": bson.M{"$sum": "$quantity"}}}},
+	}
+
+	cursor, err := s.bookingCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		TicketType string `bson:"_id"`
+		Sold       int    `bson:"sold"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	sold := make(map[string]int, len(results))
+	for _, result := range results {
+		sold[result.TicketType] = result.Sold
+	}
+
+	return sold, nil
+}
+
+// GetBookedSessionSeats returns how many seats of each session are held by confirmed bookings of the event
+func (s *BookingStore) GetBookedSessionSeats(ctx context.Context, eventID bson.ObjectID) (map[bson.ObjectID]int, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"event_id": eventID, "status": "confirmed", "session_id": bson.M{"$exists": true}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$session_id", "booked": bson.M{"$sum": "$quantity"}}}},
+	}
+
+	cursor, err := s.bookingCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		SessionID bson.ObjectID `bson:"_id"`
+		Booked    int           `bson:"booked"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	booked := make(map[bson.ObjectID]int, len(results))
+	for _, result := range results {
+		booked[result.SessionID] = result.Booked
+	}
+
+	return booked, nil
+}
+
+// GetTicketSales sums the confirmed bookings of an event per ticket type
+func (s *BookingStore) GetTicketSales(ctx context.Context, eventID bson.ObjectID) ([]models.TicketTypeSales, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"event_id": eventID, "status": "confirmed"}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":      "$ticket_type",
+			"bookings": bson.M{"$sum": 1},
+			"sold":     bson.M{"$sum": "$quantity"},
+			"revenue":  bson.M{"$sum": "$total_paid_minor"},
+		}}},
+		{{Key: "$sort", Value: bson.M{"_id": 1}}},
+	}
+
+	cursor, err := s.bookingCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var sales []models.TicketTypeSales
+	if err := cursor.All(ctx, &sales); err != nil {
+		return nil, err
+	}
+
+	return sales, nil
+}
+
+// GetDailySales sums the confirmed bookings of an event per day, days are cut in the given timezone
+func (s *BookingStore) GetDailySales(ctx context.Context, eventID bson.ObjectID, timezone string) ([]models.DailySales, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"event_id": eventID, "status": "confirmed"}}},
+		{{Key: "$group", Value: bson.M{
+			"_id": bson.M{"$dateToString": bson.M{
+				"format":   "%Y-%m-%d",
+				"date":     "$booked_at",
+				"timezone": timezone,
+			}},
+			"bookings": bson.M{"$sum": 1},
+			"sold":     bson.M{"$sum": "$quantity"},
+			"revenue":  bson.M{"$sum": "$total_paid_minor"},
+		}}},
+		{{Key: "$sort", Value: bson.M{"_id": 1}}},
+	}
+
+	cursor, err := s.bookingCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var days []models.DailySales
+	if err := cursor.All(ctx, &days); err != nil {
+		return nil, err
+	}
+
+	return days, nil
+}
+
+// BookingExportFilter selects the bookings of a host's export, zero times leave that side of the range open
+type BookingExportFilter struct {
+	HostID bson.ObjectID
+	From   time.Time //? inclusive
+	To     time.Time //? exclusive
+}
+
+// ExportHostBookings calls fn for every booking of the events the host or co-hosts, oldest first, without loading them all
+func (s *BookingStore) ExportHostBookings(ctx context.Context, filter BookingExportFilter, fn func(models.BookingExportRow) error) error {
+	//? Names of the host's events, the rows carry them instead of only the ID
+	eventFilter := bson.M{"$or": bson.A{
+		bson.M{"host_id": filter.HostID},
+		bson.M{"co_hosts": filter.HostID},
+	}}
+	eventCursor, err := s.eventCollection.Find(ctx, eventFilter, options.Find().SetProjection(bson.M{"name": 1}))
+	if err != nil {
+		return err
+	}
+
+	var events []models.Event
+	if err := eventCursor.All(ctx, &events); err != nil {
+		return err
+	}
+	if len(events) == 0 {
+		return nil
+	}
+
+	names := make(map[bson.ObjectID]string, len(events))
+	eventIDs := make(bson.A, 0, len(events))
+	for _, event := range events {
+		names[event.ID] = event.Name
+		eventIDs = append(eventIDs, event.ID)
+	}
+
+	bookingFilter := bson.M{"event_id": bson.M{"$in": eventIDs}}
+	bookedAt := bson.M{}
+	if !filter.From.IsZero() {
+		bookedAt["$gte"] = filter.From
+	}
+	if !filter.To.IsZero() {
+		bookedAt["$lt"] = filter.To
+	}
+	if len(bookedAt) > 0 {
+		bookingFilter["booked_at"] = bookedAt
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "booked_at", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := s.bookingCollection.Find(ctx, bookingFilter, opts)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	//! One booking at a time, a busy host can have far too many to hold in memory
+	for cursor.Next(ctx) {
+		var booking models.Booking
+		if err := cursor.Decode(&booking); err != nil {
+			return err
+		}
+
+		row := models.BookingExportRow{
+			TransactionID:  booking.TransactionID,
+			BookingID:      booking.ID,
+			EventID:        booking.EventID,
+			EventName:      names[booking.EventID],
+			TicketType:     booking.TicketType,
+			Quantity:       booking.Quantity,
+			SubtotalMinor:  booking.SubtotalMinor,
+			TaxMinor:       booking.TaxMinor,
+			TotalPaidMinor: booking.TotalPaidMinor,
+			Currency:       booking.Currency,
+			Status:         booking.Status,
+			BookedAt:       booking.BookedAt,
+		}
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+
+	return cursor.Err()
+}
+
+// GetBookingByGuestCode retrieves a guest booking by the hash of its access code
+func (s *BookingStore) GetBookingByGuestCode(ctx context.Context, codeHash string) (*models.Booking, error) {
+	var booking models.Booking
+	if err := s.bookingCollection.FindOne(ctx, bson.M{"guest_code_hash": codeHash}).Decode(&booking); err != nil {
+		return nil, err
+	}
+
+	return &booking, nil
+}
+
+// ClaimGuestBookings moves every booking of a guest to a user account, the guest access codes stop working
+func (s *BookingStore) ClaimGuestBookings(ctx context.Context, guestID, userID bson.ObjectID) (int64, error) {
+	update := bson.M{
+		"$set":   bson.M{"user_id": userID},
+		"$unset": bson.M{"guest_id": "", "guest_code_hash": ""},
+	}
+
+	result, err := s.bookingCollection.UpdateMany(ctx, bson.M{"guest_id": guestID}, update)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.ModifiedCount, nil
+}
+
+// EnsureGuestCodeIndex creates the unique index used to look up guest bookings by access code
+func (s *BookingStore) EnsureGuestCodeIndex(ctx context.Context) error {
+	index := mongo.IndexModel{
+		Keys: bson.D{{Key: "guest_code_hash", Value: 1}},
+		Options: options.Index().SetName("guest_code_hash_unique").SetUnique(true).
+			SetPartialFilterExpression(bson.M{"guest_code_hash": bson.M{"$exists": true}}),
+	}
+
+	_, err := s.bookingCollection.Indexes().CreateOne(ctx, index)
+	return err
+}
+
+// UpdateAttendees sets the name and email of every attendee of a booking, check-in codes and check-ins are left alone
+func (s *BookingStore) UpdateAttendees(ctx context.Context, bookingID bson.ObjectID, attendees []models.Attendee) error {
+	//? Field by field, a check-in at the door at the same time is not overwritten
+	set := bson.M{}
+	for i, attendee := range attendees {
+		set["attendees."+fmt.Sprint(i)+".name"] = attendee.Name
+		set["attendees."+fmt.Sprint(i)+".email"] = attendee.Email
+	}
+
+	result, err := s.bookingCollection.UpdateOne(ctx, bson.M{"_id": bookingID}, bson.M{"$set": set})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return errors.New("booking not found")
+	}
+
+	return nil
+}
+
+// AnonymizeUserBookings removes the attendee names and emails from every booking of a deleted user and returns how many changed
+func (s *BookingStore) AnonymizeUserBookings(ctx context.Context, userID bson.ObjectID) (int64, error) {
+	//? Amounts, ticket types and check-ins stay, the host's revenue and door counts don't change
+	filter := bson.M{"user_id": userID, "attendees.0": bson.M{"$exists": true}}
+	update := bson.M{
+		"$set":   bson.M{"attendees.$[].name": models.DeletedUserName},
+		"$unset": bson.M{"attendees.$[].email": ""},
+	}
+
+	result, err := s.bookingCollection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+// EnsureCheckInCodeIndex creates the unique index on the attendees' check-in codes
+func (s *BookingStore) EnsureCheckInCodeIndex(ctx context.Context) error {
+	index := mongo.IndexModel{
+		Keys: bson.D{{Key: "attendees.check_in_code", Value: 1}},
+		Options: options.Index().SetName("attendee_check_in_code_unique").SetUnique(true).
+			SetPartialFilterExpression(bson.M{"attendees.check_in_code": bson.M{"$exists": true}}),
+	}
+
+	_, err := s.bookingCollection.Indexes().CreateOne(ctx, index)
+	return err
+}
+
+// CheckInAttendee checks in the attendee of an event with the code, the booking is nil when no attendee has it.
+// checkedIn is false when the attendee was already checked in before.
+func (s *BookingStore) CheckInAttendee(ctx context.Context, eventID bson.ObjectID, code string, at time.Time) (booking *models.Booking, checkedIn bool, err error) {
+	//! Only a code that is not checked in yet matches, two doors scanning the same ticket can't both let it in
+	filter := bson.M{
+		"event_id": eventID,
+		"status":   "confirmed",
+		"attendees": bson.M{"$elemMatch": bson.M{
+			"check_in_code": code,
+			"checked_in_at": bson.M{"$exists": false},
+		}},
+	}
+	update := bson.M{"$set": bson.M{"attendees.$.checked_in_at": at}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	booking = &models.Booking{}
+	err = s.bookingCollection.FindOneAndUpdate(ctx, filter, update, opts).Decode(booking)
+	if err == nil {
+		return booking, true, nil
+	}
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, false, err
+	}
+
+	//? Not checked in now, find out whether the code was used before or doesn't exist
+	err = s.bookingCollection.FindOne(ctx, bson.M{"event_id": eventID, "status": "confirmed", "attendees.check_in_code": code}).Decode(booking)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	return booking, false, nil
+}
+
+// GetCheckInCounts counts the attendees of an event and how many are checked in, per ticket type
+func (s *BookingStore) GetCheckInCounts(ctx context.Context, eventID bson.ObjectID) ([]models.TicketTypeCheckIns, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"event_id": eventID, "status": "confirmed"}}},
+		{{Key: "$unwind", Value: "$attendees"}},
+		{{Key: "$group", Value: bson.M{
+			"_id":       "$ticket_type",
+			"attendees": bson.M{"$sum": 1},
+			"checked_in": bson.M{"$sum": bson.M{"$cond": bson.A{
+				bson.M{"$gt": bson.A{"$attendees.checked_in_at", nil}}, 1, 0,
+			}}},
+		}}},
+		{{Key: "$sort", Value: bson.M{"_id": 1}}},
+	}
+
+	cursor, err := s.bookingCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var counts []models.TicketTypeCheckIns
+	if err := cursor.All(ctx, &counts); err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+// coBookers caps how many other attendees GetCoBookedEventCounts looks at, so popular events stay cheap
+const coBookers = 500
+
+// GetCoBookedEventCounts counts, for every other event, how many attendees of the given events also booked it
+func (s *BookingStore) GetCoBookedEventCounts(ctx context.Context, userID bson.ObjectID, eventIDs []bson.ObjectID) (map[bson.ObjectID]int, error) {
+	counts := make(map[bson.ObjectID]int)
+	if len(eventIDs) == 0 {
+		return counts, nil
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"event_id": bson.M{"$in": eventIDs},
+			"status":   "confirmed",
+			"user_id":  bson.M{"$exists": true, "$ne": userID},
+		}}},
+		{{Key: "$group", Value: bson.M{"_id": "$user_id"}}},
+		{{Key: "$limit", Value: coBookers}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         s.bookingCollection.Name(),
+			"localField":   "_id",
+			"foreignField": "user_id",
+			"as":           "other",
+		}}},
+		{{Key: "$unwind", Value: "$other"}},
+		{{Key: "$match", Value: bson.M{"other.status": "confirmed", "other.event_id": bson.M{"$nin": eventIDs}}}},
+		//? One vote per attendee and event, however many bookings they made
+		{{Key: "$group", Value: bson.M{"_id": bson.M{"user": "$_id", "event": "$other.event_id"}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$_id.event", "count": bson.M{"$sum": 1}}}},
+	}
+
+	cursor, err := s.bookingCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		EventID bson.ObjectID `bson:"_id"`
+		Count   int           `bson:"count"`
+	}
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, err
+	}
+
+	for _, result := range results {
+		counts[result.EventID] = result.Count
+	}
+	return counts, nil
+}
+
+// SetAffiliate attributes a booking to an affiliate with the commission it earned
+func (s *BookingStore) SetAffiliate(ctx context.Context, bookingID, affiliateID bson.ObjectID, commissionMinor int64) error {
+	_, err := s.bookingCollection.UpdateOne(ctx,
+		bson.M{"_id": bookingID},
+		bson.M{"$set": bson.M{"affiliate_id": affiliateID, "commission_minor": commissionMinor}},
+	)
+	return err
+}
+
+// GetAffiliateSales sums the confirmed bookings of the affiliates booked in [from, to) per affiliate and currency, zero times leave the range open
+func (s *BookingStore) GetAffiliateSales(ctx context.Context, affiliateIDs []bson.ObjectID, from, to time.Time) ([]models.AffiliateSales, error) {
+	match := bson.M{
+		"affiliate_id": bson.M{"$in": affiliateIDs},
+		"status":       "confirmed",
+	}
+	bookedAt := bson.M{}
+	if !from.IsZero() {
+		bookedAt["$gte"] = from
+	}
+	if !to.IsZero() {
+		bookedAt["$lt"] = to
+	}
+	if len(bookedAt) > 0 {
+		match["booked_at"] = bookedAt
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$group", Value: bson.M{
+			"_id":              bson.M{"affiliate_id": "$affiliate_id", "currency": "$currency"},
+			"bookings":         bson.M{"$sum": 1},
+			"tickets":          bson.M{"$sum": "$quantity"},
+			"sales_minor":      bson.M{"$sum": "$subtotal_minor"},
+			"commission_minor": bson.M{"$sum": "$commission_minor"},
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"_id":              0,
+			"affiliate_id":     "$_id.affiliate_id",
+			"currency":         "$_id.currency",
+			"bookings":         1,
+			"tickets":          1,
+			"sales_minor":      1,
+			"commission_minor": 1,
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "currency", Value: 1}}}},
+	}
+
+	cursor, err := s.bookingCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var sales []models.AffiliateSales
+	if err = cursor.All(ctx, &sales); err != nil {
+		return nil, err
+	}
+
+	return sales, nil
+}
+
+// EnsureAffiliateIndex indexes the bookings made with an affiliate code
+func (s *BookingStore) EnsureAffiliateIndex(ctx context.Context) error {
+	index := mongo.IndexModel{
+		Keys: bson.D{{Key: "affiliate_id", Value: 1}, {Key: "booked_at", Value: 1}},
+		Options: options.Index().SetName("affiliate_booked_at").
+			SetPartialFilterExpression(bson.M{"affiliate_id": bson.M{"$exists": true}}),
+	}
+
+	_, err := s.bookingCollection.Indexes().CreateOne(ctx, index)
+	return err
+}
@@ -5,10 +5,13 @@ import (
 	"errors"
 	"event-horizon/models"
 	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
 )
 
 /******************** MONGODB FUNCTIONALITY FOR CATEGORY COLLECTIONS *********************
@@ -22,6 +25,7 @@ import (
 7. CountDocuments
 8. Regex Search
 9. Case-Insensitive Search
+10. UpdateMany (inside a transaction)
 
  ****************************************************************************************/
 
@@ -32,7 +36,7 @@ import (
 
 2. Implemented NewCategoryStore constructor to initialize CategoryStore with MongoDB collections.
 
-3. Developed CreateCategory method to add new categories, ensuring no duplicates by name.
+3. Developed CreateCategory method to add new categories, ensuring no duplicates by name and generating a unique slug.
 
 4. Created GetAllCategories method to retrieve all categories from the database.
 
@@ -50,30 +54,39 @@ import (
 
 11. Developed DeleteCategory method to remove a category only if it has no associated events.
 
-12. Created UpdateCategory method to modify existing category details.
+12. Created UpdateCategory method to modify existing category details, propagating renames to events in a transaction.
+
+13. Added BackfillEventCategoryIDs migration helper to set category_id on events that only have a category_name.
+
+14. Added GetCategoryBySlug, uniqueSlug and EnsureSlugIndex for clean, unique category URLs.
+
+15. Writes that touch events (rename, cascade delete, backfill) clear the event read cache.
+
+16. The rename and backfill writes also bump updated_at on the events they change.
+
+17. The BookingRepository used for cascade deletes is passed to NewCategoryStore instead of a SetBookingStore setter.
+
+18. Added BackfillCategorySlugs migration helper to give categories created before slugs existed a unique slug.
 
 
 ************************************************************************************************************/
 
 type CategoryStore struct {
+	db              *mongo.Database
 	collection      *mongo.Collection
 	eventCollection *mongo.Collection
-	bookingStore    *BookingStore
+	bookingStore    BookingRepository //? for cascade deletes
 }
 
-func NewCategoryStore(db *mongo.Database) *CategoryStore {
+func NewCategoryStore(db *mongo.Database, bookingStore BookingRepository) *CategoryStore {
 	return &CategoryStore{
+		db:              db,
 		collection:      db.Collection("Categories"),
 		eventCollection: db.Collection("Events"),
-		bookingStore:    nil, // Will be set later
+		bookingStore:    bookingStore,
 	}
 }
 
-// SetBookingStore sets the bookingStore reference for cascade deletes
-func (s *CategoryStore) SetBookingStore(bookingStore *BookingStore) {
-	s.bookingStore = bookingStore
-}
-
 func (s *CategoryStore) CreateCategory(ctx context.Context, category *models.Category) error {
 
 	filter := bson.M{"name": category.Name} //! Exact match filter
@@ -89,6 +102,13 @@ func (s *CategoryStore) CreateCategory(ctx context.Context, category *models.Cat
 		return err
 	}
 
+	//? Generate a unique URL-safe slug from the name
+	slug, err := s.uniqueSlug(ctx, category.Name, bson.NilObjectID)
+	if err != nil {
+		return err
+	}
+	category.Slug = slug
+
 	//? Set creation timestamp
 	category.CreatedAt = time.Now()
 
@@ -132,7 +152,7 @@ func (s *CategoryStore) GetAllCategoriesWithEvents(ctx context.Context) ([]model
 	var result []models.CategoryWithEvents
 	for _, category := range categories {
 		//? Get events for this category
-		events, err := s.getEventsByCategory(ctx, category.ID)
+		events, err := s.getEventsByCategory(ctx, category.ID, true)
 		if err != nil {
 			events = []models.Event{} // Empty on error
 		}
@@ -189,6 +209,75 @@ func (s *CategoryStore) GetCategoryByName(ctx context.Context, categoryName stri
 	return &category, nil
 }
 
+// GetCategoryBySlug retrieves a category by its slug
+func (s *CategoryStore) GetCategoryBySlug(ctx context.Context, slug string) (*models.Category, error) {
+	var category models.Category
+
+	err := s.collection.FindOne(ctx, bson.M{"slug": slug}).Decode(&category)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, errors.New("category not found")
+		}
+		return nil, err
+	}
+
+	return &category, nil
+}
+
+// uniqueSlug builds a slug from the name, adding -2, -3... if another category already uses it
+func (s *CategoryStore) uniqueSlug(ctx context.Context, name string, excludeID bson.ObjectID) (string, error) {
+	base := slugify(name)
+	if base == "" {
+		base = "category"
+	}
+
+	slug := base
+	for i := 2; ; i++ {
+		filter := bson.M{"slug": slug, "_id": bson.M{"$ne": excludeID}}
+		count, err := s.collection.CountDocuments(ctx, filter)
+		if err != nil {
+			return "", err
+		}
+		if count == 0 {
+			return slug, nil
+		}
+		slug = base + "-" + strconv.Itoa(i)
+	}
+}
+
+// slugify turns a name into a lowercase, URL-safe slug (e.g. "Tech & Music" -> "tech-music")
+func slugify(name string) string {
+	var builder strings.Builder
+	lastDash := true //! avoid a leading dash
+
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9'):
+			builder.WriteRune(r)
+			lastDash = false
+		case !lastDash:
+			builder.WriteRune('-')
+			lastDash = true
+		}
+	}
+
+	return strings.TrimSuffix(builder.String(), "-")
+}
+
+// EnsureSlugIndex creates the unique index on category slugs
+func (s *CategoryStore) EnsureSlugIndex(ctx context.Context) error {
+	index := mongo.IndexModel{
+		Keys: bson.D{{Key: "slug", Value: 1}},
+		Options: options.Index().
+			SetName("slug_unique").
+			SetUnique(true).
+			SetPartialFilterExpression(bson.M{"slug": bson.M{"$type": "string"}}), //! old categories may have no slug yet
+	}
+
+	_, err := s.collection.Indexes().CreateOne(ctx, index)
+	return err
+}
+
 // GetCategoryWithEvents retrieves a category with all its events
 func (s *CategoryStore) GetCategoryWithEvents(ctx context.Context, categoryID bson.ObjectID) (*models.CategoryWithEvents, error) {
 	//? Get category
@@ -198,7 +287,7 @@ func (s *CategoryStore) GetCategoryWithEvents(ctx context.Context, categoryID bs
 	}
 
 	//? Get all events under this category
-	events, err := s.getEventsByCategory(ctx, categoryID)
+	events, err := s.getEventsByCategory(ctx, categoryID, true)
 	if err != nil {
 		return nil, err
 	}
@@ -210,18 +299,20 @@ func (s *CategoryStore) GetCategoryWithEvents(ctx context.Context, categoryID bs
 	}, nil
 }
 
-// getEventsByCategory is a helper method to get all events for a category
-func (s *CategoryStore) getEventsByCategory(ctx context.Context, categoryID bson.ObjectID) ([]models.Event, error) {
+// getEventsByCategory is a helper method to get all events for a category, publicOnly leaves out drafts
+func (s *CategoryStore) getEventsByCategory(ctx context.Context, categoryID bson.ObjectID, publicOnly bool) ([]models.Event, error) {
 	var events []models.Event
 
-	//? First get the category to find its name
-	category, err := s.GetCategoryByID(ctx, categoryID)
-	if err != nil {
+	//? Make sure the category exists
+	if _, err := s.GetCategoryByID(ctx, categoryID); err != nil {
 		return nil, err
 	}
 
-	//? Search events by category_name instead of category_id
-	filter := bson.M{"category_name": category.Name} //! Match by NAME
+	filter := bson.M{}
+	if publicOnly {
+		filter = publicEventFilter()
+	}
+	filter["category_id"] = categoryID //! Match by ID so renames don't orphan events
 	cursor, err := s.eventCollection.Find(ctx, filter)
 	if err != nil {
 		return nil, err
@@ -243,14 +334,12 @@ func (s *CategoryStore) getEventsByCategory(ctx context.Context, categoryID bson
 
 // GetCategoryEventCount returns the number of events in a category
 func (s *CategoryStore) GetCategoryEventCount(ctx context.Context, categoryID bson.ObjectID) (int, error) {
-	//? Get category to find its name
-	category, err := s.GetCategoryByID(ctx, categoryID)
-	if err != nil {
+	//? Make sure the category exists
+	if _, err := s.GetCategoryByID(ctx, categoryID); err != nil {
 		return 0, err
 	}
 
-	//? Count events by category_name instead of category_id
-	count, err := s.eventCollection.CountDocuments(ctx, bson.M{"category_name": category.Name})
+	count, err := s.eventCollection.CountDocuments(ctx, bson.M{"category_id": categoryID})
 	if err != nil {
 		return 0, err
 	}
@@ -283,14 +372,8 @@ func (s *CategoryStore) DeleteCategory(ctx context.Context, categoryID bson.Obje
 
 // DeleteCategoryWithCascade deletes a category and all its associated events and bookings
 func (s *CategoryStore) DeleteCategoryWithCascade(ctx context.Context, categoryID bson.ObjectID) error {
-	//? Get category first to get its name
-	category, err := s.GetCategoryByID(ctx, categoryID)
-	if err != nil {
-		return err
-	}
-
 	//? Get all events under this category to delete their bookings
-	events, err := s.getEventsByCategory(ctx, categoryID)
+	events, err := s.getEventsByCategory(ctx, categoryID, false)
 	if err != nil {
 		return err
 	}
@@ -305,9 +388,10 @@ func (s *CategoryStore) DeleteCategoryWithCascade(ctx context.Context, categoryI
 		}
 	}
 
-	//? Delete all events by category_name
-	filter := bson.M{"category_name": category.Name}
+	//? Delete all events by category_id
+	filter := bson.M{"category_id": categoryID}
 	_, err = s.eventCollection.DeleteMany(ctx, filter)
+	eventReadCache.invalidateAll()
 	if err != nil {
 		return errors.New("failed to delete events: " + err.Error())
 	}
@@ -325,19 +409,134 @@ func (s *CategoryStore) DeleteCategoryWithCascade(ctx context.Context, categoryI
 	return nil
 }
 
-// UpdateCategory updates a category's details
+// UpdateCategory updates a category's details and propagates a rename to its events within a transaction
 func (s *CategoryStore) UpdateCategory(ctx context.Context, categoryID bson.ObjectID, updates bson.M) error {
-	filter := bson.M{"_id": categoryID} //! Match by ID
-	update := bson.M{"$set": updates}   //! Set the updates
-
-	result, err := s.collection.UpdateOne(ctx, filter, update)
+	//? Start a session for transaction
+	session, err := s.db.Client().StartSession(txnSessionOptions)
 	if err != nil {
 		return err
 	}
+	defer session.EndSession(ctx)
 
-	if result.MatchedCount == 0 {
-		return errors.New("category not found")
+	//! CALLBACK FUNCTION FOR TRANSACTION
+	callback := func(sessCtx context.Context) (interface{}, error) {
+		//? Don't allow renaming onto another category's name
+		if newName, ok := updates["name"].(string); ok {
+			filter := bson.M{"name": newName, "_id": bson.M{"$ne": categoryID}}
+			count, err := s.collection.CountDocuments(sessCtx, filter)
+			if err != nil {
+				return nil, err
+			}
+			if count > 0 {
+				return nil, errors.New("category with the same name already exists")
+			}
+		}
+
+		filter := bson.M{"_id": categoryID} //! Match by ID
+		update := bson.M{"$set": updates}   //! Set the updates
+
+		//? A rename also gets a new slug
+		if newName, ok := updates["name"].(string); ok {
+			slug, err := s.uniqueSlug(sessCtx, newName, categoryID)
+			if err != nil {
+				return nil, err
+			}
+			updates["slug"] = slug
+		}
+
+		result, err := s.collection.UpdateOne(sessCtx, filter, update)
+		if err != nil {
+			return nil, err
+		}
+
+		if result.MatchedCount == 0 {
+			return nil, errors.New("category not found")
+		}
+
+		//? Keep the denormalized category_name on events in sync
+		if newName, ok := updates["name"].(string); ok {
+			eventFilter := bson.M{"category_id": categoryID}
+			eventUpdate := bson.M{"$set": bson.M{"category_name": newName, "updated_at": time.Now()}}
+			if _, err := s.eventCollection.UpdateMany(sessCtx, eventFilter, eventUpdate); err != nil {
+				return nil, err
+			}
+		}
+
+		return nil, nil
 	}
 
-	return nil
+	//? Execute transaction
+	_, err = session.WithTransaction(ctx, callback)
+
+	//? A rename changes category_name on the events
+	eventReadCache.invalidateAll()
+	return err
+}
+
+// BackfillEventCategoryIDs sets category_id on events that were stored with only a category_name (migration)
+func (s *CategoryStore) BackfillEventCategoryIDs(ctx context.Context) (int64, error) {
+	categories, err := s.GetAllCategories(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var updated int64
+	for _, category := range categories {
+		//? Same case-insensitive match the API uses for category_name
+		filter := bson.M{
+			"category_name": bson.M{
+				"$regex":   "^" + regexp.QuoteMeta(category.Name) + "$",
+				"$options": "i",
+			},
+			"$or": bson.A{
+				bson.M{"category_id": bson.M{"$exists": false}},
+				bson.M{"category_id": bson.NilObjectID},
+			},
+		}
+		update := bson.M{"$set": bson.M{"category_id": category.ID, "updated_at": time.Now()}}
+
+		result, err := s.eventCollection.UpdateMany(ctx, filter, update)
+		eventReadCache.invalidateAll()
+		if err != nil {
+			return updated, err
+		}
+		updated += result.ModifiedCount
+	}
+
+	return updated, nil
+}
+
+// BackfillCategorySlugs gives every category without a slug a unique one (migration)
+func (s *CategoryStore) BackfillCategorySlugs(ctx context.Context) (int64, error) {
+	filter := bson.M{"$or": bson.A{
+		bson.M{"slug": bson.M{"$exists": false}},
+		bson.M{"slug": ""},
+	}}
+
+	cursor, err := s.collection.Find(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var categories []models.Category
+	if err := cursor.All(ctx, &categories); err != nil {
+		return 0, err
+	}
+
+	var updated int64
+	for _, category := range categories {
+		slug, err := s.uniqueSlug(ctx, category.Name, category.ID)
+		if err != nil {
+			return updated, err
+		}
+
+		_, err = s.collection.UpdateOne(ctx, bson.M{"_id": category.ID}, bson.M{"$set": bson.M{"slug": slug}})
+		if err != nil {
+			return updated, err
+		}
+		updated++
+	}
+
+	return updated, nil
 }
@@ -0,0 +1,119 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"event-horizon/db/mongotest"
+	"event-horizon/models"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestMain(m *testing.M) {
+	mongotest.Main(m)
+}
+
+// createTestEvent stores a published event in the category
+func createTestEvent(t *testing.T, events *EventStore, name, category string) *models.Event {
+	t.Helper()
+	start := time.Now().UTC().AddDate(0, 1, 0)
+	event := &models.Event{
+		Name:         name,
+		CategoryName: category,
+		Date:         start,
+		StartTime:    start,
+		EndTime:      start.Add(2 * time.Hour),
+		Location:     "Town Hall",
+		Tickets:      []models.TicketInfo{{Type: "Regular", Price: 10, PriceMinor: 1000, TotalQuantity: 50, AvailableQuantity: 50}},
+	}
+	if err := events.CreateEvent(context.Background(), event); err != nil {
+		t.Fatalf("creating %s: %v", name, err)
+	}
+	return event
+}
+
+// listedNames returns the names of the category's events and checks they carry its current name
+func listedNames(t *testing.T, categories *CategoryStore, name string) map[string]bool {
+	t.Helper()
+	category, err := categories.GetCategoryByName(context.Background(), name)
+	if err != nil {
+		t.Fatalf("GetCategoryByName(%q): %v", name, err)
+	}
+	listed, err := categories.GetCategoryWithEvents(context.Background(), category.ID)
+	if err != nil {
+		t.Fatalf("GetCategoryWithEvents(%q): %v", name, err)
+	}
+
+	names := map[string]bool{}
+	for _, event := range listed.Events {
+		if event.CategoryName != name {
+			t.Errorf("%s is listed under %q with category_name %q", event.Name, name, event.CategoryName)
+		}
+		names[event.Name] = true
+	}
+	return names
+}
+
+func TestUpdateCategoryRenameKeepsEvents(t *testing.T) {
+	database := mongotest.Database(t)
+	bookings := NewBookingStore(database)
+	categories := NewCategoryStore(database, bookings)
+	events := NewEventStore(database, categories, bookings)
+	ctx := context.Background()
+
+	//? With the cache on, a stale copy would still show the old name
+	ConfigureEventCache(time.Minute)
+	t.Cleanup(func() { ConfigureEventCache(0) })
+
+	music := &models.Category{Name: "Music"}
+	sports := &models.Category{Name: "Sports"}
+	for _, category := range []*models.Category{music, sports} {
+		if err := categories.CreateCategory(ctx, category); err != nil {
+			t.Fatalf("creating %s: %v", category.Name, err)
+		}
+	}
+	jazz := createTestEvent(t, events, "Jazz Night", "Music")
+	createTestEvent(t, events, "Rock Night", "Music")
+	createTestEvent(t, events, "Derby", "Sports")
+	if _, err := events.GetEventByID(ctx, jazz.ID.Hex()); err != nil {
+		t.Fatalf("GetEventByID: %v", err)
+	}
+
+	if err := categories.UpdateCategory(ctx, music.ID, bson.M{"name": "Live Music"}); err != nil {
+		t.Fatalf("UpdateCategory: %v", err)
+	}
+
+	//! The events follow the category to its new name
+	if names := listedNames(t, categories, "Live Music"); len(names) != 2 || !names["Jazz Night"] || !names["Rock Night"] {
+		t.Fatalf("Live Music lists %v, want Jazz Night and Rock Night", names)
+	}
+	if _, err := categories.GetCategoryByName(ctx, "Music"); err == nil {
+		t.Fatal("the old name still finds the category")
+	}
+	renamed, err := categories.GetCategoryBySlug(ctx, "live-music")
+	if err != nil || renamed.ID != music.ID {
+		t.Fatalf("GetCategoryBySlug(live-music) = %v, %v", renamed, err)
+	}
+	cached, err := events.GetEventByID(ctx, jazz.ID.Hex())
+	if err != nil || cached.CategoryName != "Live Music" {
+		t.Fatalf("GetEventByID after the rename = %v, %v", cached, err)
+	}
+
+	//? Another category's events are left alone
+	if names := listedNames(t, categories, "Sports"); len(names) != 1 || !names["Derby"] {
+		t.Fatalf("Sports lists %v, want Derby", names)
+	}
+
+	//! Renaming onto a taken name changes nothing
+	if err := categories.UpdateCategory(ctx, music.ID, bson.M{"name": "Sports"}); err == nil {
+		t.Fatal("renamed onto an existing category name")
+	}
+	if names := listedNames(t, categories, "Live Music"); len(names) != 2 {
+		t.Fatalf("Live Music lists %v after the refused rename", names)
+	}
+	if names := listedNames(t, categories, "Sports"); len(names) != 1 {
+		t.Fatalf("Sports lists %v after the refused rename", names)
+	}
+}
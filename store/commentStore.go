@@ -0,0 +1,246 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"event-horizon/models"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+/******************** MONGODB FUNCTIONALITY FOR COMMENTS COLLECTION ********************
+
+1. BSON MAPPING FOR COMMENTS COLLECTION
+2. InsertOne
+3. Find with Sort, Skip and Limit / $in
+4. CountDocuments
+5. FindOneAndUpdate with $push and $inc
+6. DeleteMany
+7. Compound indexes
+
+ ****************************************************************************************/
+
+/************************** I DID THE FOLLOWING THINGS IN THIS FILE: *******************************************
+
+1. Created CommentStore struct to manage the Q&A comments on event pages.
+
+2. Developed CreateComment and GetCommentByID methods.
+
+3. Added GetComments method for a page of top level comments (newest first) and GetReplies for their replies (oldest first), hidden comments are left out.
+
+4. Implemented DeleteComment method, deleting a comment deletes its replies too.
+
+5. Added CountCommentsByAuthorSince method used to rate limit authors.
+
+6. Implemented ReportComment method, a user can report a comment once, and HideComment for comments reported too often.
+
+7. Added GetReportedComments method for the admin view, most reported first.
+
+8. Created EnsureCommentIndexes method for the listing and rate limit indexes.
+
+9. Added GetCommentsByAuthor for data exports and AnonymizeAuthor for deleted accounts, the threads stay readable.
+
+************************************************************************************************************/
+
+type CommentStore struct {
+	collection *mongo.Collection
+}
+
+func NewCommentStore(db *mongo.Database) *CommentStore {
+	return &CommentStore{
+		collection: db.Collection("Comments"),
+	}
+}
+
+// CreateComment stores a comment
+func (s *CommentStore) CreateComment(ctx context.Context, comment *models.Comment) error {
+	comment.CreatedAt = time.Now()
+
+	result, err := s.collection.InsertOne(ctx, comment)
+	if err != nil {
+		return err
+	}
+
+	comment.ID = result.InsertedID.(bson.ObjectID)
+	return nil
+}
+
+// GetCommentByID returns a comment, hidden ones included
+func (s *CommentStore) GetCommentByID(ctx context.Context, commentID bson.ObjectID) (*models.Comment, error) {
+	var comment models.Comment
+	if err := s.collection.FindOne(ctx, bson.M{"_id": commentID}).Decode(&comment); err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// GetComments returns a page of the visible top level comments of an event (newest first) and how many there are
+func (s *CommentStore) GetComments(ctx context.Context, eventID bson.ObjectID, limit, offset int) ([]models.Comment, int64, error) {
+	filter := bson.M{
+		"event_id":  eventID,
+		"parent_id": bson.M{"$exists": false},
+		"hidden":    bson.M{"$ne": true},
+	}
+
+	total, err := s.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(limit))
+
+	comments, err := s.find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return comments, total, nil
+}
+
+// GetReplies returns the visible replies to the given comments, oldest first
+func (s *CommentStore) GetReplies(ctx context.Context, parentIDs []bson.ObjectID) ([]models.Comment, error) {
+	if len(parentIDs) == 0 {
+		return []models.Comment{}, nil
+	}
+
+	filter := bson.M{
+		"parent_id": bson.M{"$in": parentIDs},
+		"hidden":    bson.M{"$ne": true},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}})
+
+	return s.find(ctx, filter, opts)
+}
+
+// find runs a query and decodes every comment
+func (s *CommentStore) find(ctx context.Context, filter bson.M, opts *options.FindOptionsBuilder) ([]models.Comment, error) {
+	cursor, err := s.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var comments []models.Comment
+	if err = cursor.All(ctx, &comments); err != nil {
+		return nil, err
+	}
+
+	if comments == nil {
+		comments = []models.Comment{}
+	}
+
+	return comments, nil
+}
+
+// DeleteComment deletes a comment with its replies and returns how many comments were deleted
+func (s *CommentStore) DeleteComment(ctx context.Context, commentID bson.ObjectID) (int64, error) {
+	result, err := s.collection.DeleteMany(ctx, bson.M{
+		"$or": bson.A{
+			bson.M{"_id": commentID},
+			bson.M{"parent_id": commentID},
+		},
+	})
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
+
+// GetCommentsByAuthor returns every comment the user wrote, hidden ones included, newest first
+func (s *CommentStore) GetCommentsByAuthor(ctx context.Context, authorID bson.ObjectID) ([]models.Comment, error) {
+	return s.find(ctx, bson.M{"author_id": authorID}, options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}))
+}
+
+// AnonymizeAuthor replaces the author name on every comment of a deleted user and returns how many changed
+func (s *CommentStore) AnonymizeAuthor(ctx context.Context, authorID bson.ObjectID) (int64, error) {
+	result, err := s.collection.UpdateMany(ctx, bson.M{"author_id": authorID}, bson.M{"$set": bson.M{"author_name": models.DeletedUserName}})
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+// CountCommentsByAuthorSince returns how many comments the user wrote since the given time
+func (s *CommentStore) CountCommentsByAuthorSince(ctx context.Context, authorID bson.ObjectID, since time.Time) (int64, error) {
+	return s.collection.CountDocuments(ctx, bson.M{
+		"author_id":  authorID,
+		"created_at": bson.M{"$gte": since},
+	})
+}
+
+// ReportComment adds the user's report and returns the new report count, reported is false when the user already reported the comment
+func (s *CommentStore) ReportComment(ctx context.Context, commentID bson.ObjectID, report models.CommentReport) (count int, reported bool, err error) {
+	report.CreatedAt = time.Now()
+
+	filter := bson.M{
+		"_id":             commentID,
+		"reports.user_id": bson.M{"$ne": report.UserID}, //! One report per user
+	}
+	update := bson.M{
+		"$push": bson.M{"reports": report},
+		"$inc":  bson.M{"report_count": 1},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var comment models.Comment
+	if err := s.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&comment); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return 0, false, nil
+		}
+		return 0, false, err
+	}
+
+	return comment.ReportCount, true, nil
+}
+
+// HideComment hides a comment (and so its replies) until an admin reviews it, false when it was already hidden
+func (s *CommentStore) HideComment(ctx context.Context, commentID bson.ObjectID) (bool, error) {
+	result, err := s.collection.UpdateOne(ctx,
+		bson.M{"_id": commentID, "hidden": bson.M{"$ne": true}},
+		bson.M{"$set": bson.M{"hidden": true}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+// GetReportedComments returns the reported comments, most reported first
+func (s *CommentStore) GetReportedComments(ctx context.Context, limit int) ([]models.Comment, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "report_count", Value: -1}, {Key: "created_at", Value: -1}}).
+		SetLimit(int64(limit))
+
+	return s.find(ctx, bson.M{"report_count": bson.M{"$gt": 0}}, opts)
+}
+
+// EnsureCommentIndexes creates the indexes the event page, the rate limit and the admin view read with
+func (s *CommentStore) EnsureCommentIndexes(ctx context.Context) error {
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "event_id", Value: 1}, {Key: "parent_id", Value: 1}, {Key: "created_at", Value: -1}},
+			Options: options.Index().SetName("event_parent_created_at"),
+		},
+		{
+			Keys:    bson.D{{Key: "parent_id", Value: 1}, {Key: "created_at", Value: 1}},
+			Options: options.Index().SetName("parent_created_at"),
+		},
+		{
+			Keys:    bson.D{{Key: "author_id", Value: 1}, {Key: "created_at", Value: -1}},
+			Options: options.Index().SetName("author_created_at"),
+		},
+		{
+			Keys:    bson.D{{Key: "report_count", Value: -1}},
+			Options: options.Index().SetName("report_count").SetPartialFilterExpression(bson.M{"report_count": bson.M{"$gt": 0}}),
+		},
+	}
+
+	_, err := s.collection.Indexes().CreateMany(ctx, indexes)
+	return err
+}
@@ -0,0 +1,121 @@
+package store
+
+import (
+	"context"
+	"event-horizon/models"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+/******************** MONGODB FUNCTIONALITY FOR CONSENTS COLLECTION ********************
+
+1. BSON MAPPING FOR CONSENTS COLLECTION
+2. InsertMany
+3. Aggregate ($sort + $group with $first) for the latest acceptance per document
+4. Find by user_id
+
+ ****************************************************************************************/
+
+/************************** I DID THE FOLLOWING THINGS IN THIS FILE: *******************************************
+
+1. Created ConsentStore struct, every acceptance of the terms or the privacy policy is a new document (an append only record).
+
+2. Developed RecordConsents and GetConsentsByUserID methods.
+
+3. Implemented GetLatestConsents method, the newest acceptance of every document of a user.
+
+4. Created EnsureConsentIndexes method so the acceptances of a user are found by index.
+
+************************************************************************************************************/
+
+type ConsentStore struct {
+	collection *mongo.Collection
+}
+
+func NewConsentStore(db *mongo.Database) *ConsentStore {
+	return &ConsentStore{
+		collection: db.Collection("Consents"),
+	}
+}
+
+// RecordConsents stores acceptances given together (terms and privacy policy at once)
+func (s *ConsentStore) RecordConsents(ctx context.Context, consents []models.Consent) error {
+	if len(consents) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	docs := make([]interface{}, len(consents))
+	for i := range consents {
+		if consents[i].AcceptedAt.IsZero() {
+			consents[i].AcceptedAt = now
+		}
+		docs[i] = consents[i]
+	}
+
+	result, err := s.collection.InsertMany(ctx, docs)
+	if err != nil {
+		return err
+	}
+	for i, id := range result.InsertedIDs {
+		consents[i].ID = id.(bson.ObjectID)
+	}
+	return nil
+}
+
+// GetConsentsByUserID returns every acceptance of a user, newest first
+func (s *ConsentStore) GetConsentsByUserID(ctx context.Context, userID bson.ObjectID) ([]models.Consent, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "accepted_at", Value: -1}})
+
+	cursor, err := s.collection.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	consents := []models.Consent{} //** Return empty slice
+	if err := cursor.All(ctx, &consents); err != nil {
+		return nil, err
+	}
+	return consents, nil
+}
+
+// GetLatestConsents returns the newest acceptance of every document the user accepted, keyed by document
+func (s *ConsentStore) GetLatestConsents(ctx context.Context, userID bson.ObjectID) (map[string]models.Consent, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"user_id": userID}}},
+		{{Key: "$sort", Value: bson.D{{Key: "accepted_at", Value: -1}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$policy", "latest": bson.M{"$first": "$$ROOT"}}}},
+	}
+
+	cursor, err := s.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var groups []struct {
+		Latest models.Consent `bson:"latest"`
+	}
+	if err := cursor.All(ctx, &groups); err != nil {
+		return nil, err
+	}
+
+	latest := make(map[string]models.Consent, len(groups))
+	for _, group := range groups {
+		latest[group.Latest.Policy] = group.Latest
+	}
+	return latest, nil
+}
+
+// EnsureConsentIndexes creates the index the acceptances of a user are found with
+func (s *ConsentStore) EnsureConsentIndexes(ctx context.Context) error {
+	_, err := s.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "accepted_at", Value: -1}},
+		Options: options.Index().SetName("user_id_accepted_at"),
+	})
+	return err
+}
@@ -0,0 +1,121 @@
+package store
+
+import (
+	"context"
+	"event-horizon/models"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+/** *********************  EVENT ARCHIVE   ********************
+
+The cleanup deletes ended events with their bookings, so before an event that
+ran (published or cancelled) goes, a snapshot of it is written to the
+EventArchive collection under the same _id: its dates and place, the final
+tickets sold, revenue, attendees and check-ins per ticket type. The snapshot
+holds no attendee names or emails.
+
+Drafts, events under review and rejected events never ran and are not archived.
+
+ **************************************/
+
+// archivableStatuses are the statuses of events that ran and belong in their host's history ("" is published)
+var archivableStatuses = bson.A{"", nil, models.EventStatusPublished, models.EventStatusCancelled}
+
+// archiveEvent writes the snapshot of an event that is about to be deleted, bookings are counted in the same transaction
+func (s *EventStore) archiveEvent(sessCtx context.Context, event *models.Event) error {
+	if !event.IsArchivable() || s.bookingStore == nil {
+		return nil
+	}
+
+	sales, err := s.bookingStore.GetTicketSales(sessCtx, event.ID)
+	if err != nil {
+		return err
+	}
+	checkIns, err := s.bookingStore.GetCheckInCounts(sessCtx, event.ID)
+	if err != nil {
+		return err
+	}
+
+	archived := models.NewArchivedEvent(event, sales, checkIns)
+	now := time.Now()
+	archived.ArchivedAt = &now
+
+	//? Replaced, a cleanup that failed after archiving writes the same snapshot again
+	_, err = s.archive.ReplaceOne(sessCtx, bson.M{"_id": event.ID}, archived, options.Replace().SetUpsert(true))
+	return err
+}
+
+// pastEventsFilter matches the events of the user (host or co-host) that come after the query's cursor, newest end first
+func pastEventsFilter(query models.PastEventsQuery) bson.A {
+	conditions := bson.A{
+		bson.M{"$or": bson.A{bson.M{"host_id": query.UserID}, bson.M{"co_hosts": query.UserID}}},
+	}
+	if !query.BeforeEnd.IsZero() {
+		conditions = append(conditions, bson.M{"$or": bson.A{
+			bson.M{"end_time": bson.M{"$lt": query.BeforeEnd}},
+			bson.M{"end_time": query.BeforeEnd, "_id": bson.M{"$lt": query.BeforeID}},
+		}})
+	}
+	return conditions
+}
+
+// pastEventsOptions sorts by end time (and _id for the ties) and reads one more than the page, which tells whether there is a next one
+func pastEventsOptions(query models.PastEventsQuery) *options.FindOptionsBuilder {
+	return options.Find().
+		SetSort(bson.D{{Key: "end_time", Value: -1}, {Key: "_id", Value: -1}}).
+		SetLimit(int64(query.Limit + 1))
+}
+
+// ! GetArchivedEvents returns one page of the user's archived events, newest end first
+func (s *EventStore) GetArchivedEvents(ctx context.Context, query models.PastEventsQuery) ([]models.ArchivedEvent, error) {
+	cursor, err := s.archive.Find(ctx, bson.M{"$and": pastEventsFilter(query)}, pastEventsOptions(query))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	events := []models.ArchivedEvent{} //** Return empty slice
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// ! GetEndedEvents returns one page of the user's events that ended but are still kept, newest end first
+func (s *EventStore) GetEndedEvents(ctx context.Context, query models.PastEventsQuery) ([]models.Event, error) {
+	conditions := append(pastEventsFilter(query),
+		bson.M{"end_time": bson.M{"$lte": time.Now()}},
+		bson.M{"status": bson.M{"$in": archivableStatuses}},
+	)
+
+	cursor, err := s.collection.Find(ctx, bson.M{"$and": conditions}, pastEventsOptions(query))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	events := []models.Event{} //** Return empty slice
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// EnsureArchiveIndexes creates the indexes a host's and a co-host's archived events are listed with
+func (s *EventStore) EnsureArchiveIndexes(ctx context.Context) error {
+	_, err := s.archive.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "host_id", Value: 1}, {Key: "end_time", Value: -1}, {Key: "_id", Value: -1}},
+			Options: options.Index().SetName("host_id_end_time"),
+		},
+		{
+			Keys:    bson.D{{Key: "co_hosts", Value: 1}, {Key: "end_time", Value: -1}, {Key: "_id", Value: -1}},
+			Options: options.Index().SetName("co_hosts_end_time").SetSparse(true),
+		},
+	})
+	return err
+}
@@ -0,0 +1,177 @@
+package store
+
+import (
+	"event-horizon/models"
+	"slices"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+/** *********************  EVENT READ CACHE   ********************
+
+GET /events/all and GET /events/:id are by far the hottest reads. Results are
+kept in memory for a short TTL and dropped whenever a store writes to the
+Events collection (event edits, bookings, category renames ...).
+
+The cache lives in this process only, so with several instances another
+instance's write shows up here after at most one TTL. Callers always get a
+copy, so mutating a returned event never touches the cached one.
+
+ **************************************/
+
+type cachedEntry struct {
+	events    []*models.Event
+	expiresAt time.Time
+}
+
+type eventCache struct {
+	mu     sync.RWMutex
+	ttl    time.Duration
+	byID   map[bson.ObjectID]cachedEntry
+	byList map[string]cachedEntry
+}
+
+// eventReadCache is shared by every store that writes events (0 TTL = disabled)
+var eventReadCache = &eventCache{
+	byID:   make(map[bson.ObjectID]cachedEntry),
+	byList: make(map[string]cachedEntry),
+}
+
+// ConfigureEventCache sets how long event reads are cached, 0 disables the cache
+func ConfigureEventCache(ttl time.Duration) {
+	eventReadCache.mu.Lock()
+	defer eventReadCache.mu.Unlock()
+
+	eventReadCache.ttl = ttl
+	eventReadCache.byID = make(map[bson.ObjectID]cachedEntry)
+	eventReadCache.byList = make(map[string]cachedEntry)
+}
+
+// getEvent returns a copy of a cached event
+func (c *eventCache) getEvent(id bson.ObjectID) (*models.Event, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, ok := c.byID[id]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil, false
+	}
+	return cloneEvent(entry.events[0]), true
+}
+
+// setEvent caches a copy of an event
+func (c *eventCache) setEvent(event *models.Event) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.ttl <= 0 {
+		return
+	}
+	c.byID[event.ID] = cachedEntry{events: []*models.Event{cloneEvent(event)}, expiresAt: time.Now().Add(c.ttl)}
+}
+
+// getList returns a copy of a cached listing
+func (c *eventCache) getList(key string) ([]*models.Event, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, ok := c.byList[key]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil, false
+	}
+	return cloneEvents(entry.events), true
+}
+
+// setList caches a copy of a listing
+func (c *eventCache) setList(key string, events []*models.Event) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.ttl <= 0 {
+		return
+	}
+	c.byList[key] = cachedEntry{events: cloneEvents(events), expiresAt: time.Now().Add(c.ttl)}
+}
+
+// invalidate drops one event and every listing (a listing may contain it)
+func (c *eventCache) invalidate(id bson.ObjectID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.byID, id)
+	c.byList = make(map[string]cachedEntry)
+}
+
+// invalidateAll drops everything, used by writes touching many events
+func (c *eventCache) invalidateAll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.byID = make(map[bson.ObjectID]cachedEntry)
+	c.byList = make(map[string]cachedEntry)
+}
+
+// cloneEvent copies an event including its slices, maps and pointers so the copy can be changed freely.
+//! A new reference field of models.Event must be copied here too, or callers can change the cached event through it
+func cloneEvent(event *models.Event) *models.Event {
+	clone := *event
+	clone.CoHosts = slices.Clone(event.CoHosts)
+	clone.CoHostInvites = slices.Clone(event.CoHostInvites)
+	clone.OrgID = clonePointer(event.OrgID)
+	clone.OrgManagers = slices.Clone(event.OrgManagers)
+	clone.OrgScanners = slices.Clone(event.OrgScanners)
+	clone.Tags = slices.Clone(event.Tags)
+	clone.VenueID = clonePointer(event.VenueID)
+	clone.PublishAt = clonePointer(event.PublishAt)
+	clone.Sessions = slices.Clone(event.Sessions)
+	clone.VenueConflicts = slices.Clone(event.VenueConflicts)
+	if event.GeoLocation != nil {
+		geo := *event.GeoLocation
+		geo.Coordinates = slices.Clone(event.GeoLocation.Coordinates)
+		clone.GeoLocation = &geo
+	}
+
+	clone.Tickets = slices.Clone(event.Tickets)
+	for i := range clone.Tickets {
+		clone.Tickets[i].SaleStart = clonePointer(event.Tickets[i].SaleStart)
+		clone.Tickets[i].SaleEnd = clonePointer(event.Tickets[i].SaleEnd)
+	}
+
+	if event.CapacityAlerts != nil {
+		alerts := *event.CapacityAlerts
+		alerts.Thresholds = slices.Clone(event.CapacityAlerts.Thresholds)
+		clone.CapacityAlerts = &alerts
+	}
+	if event.CapacityAlertsSent != nil {
+		clone.CapacityAlertsSent = make(map[string][]int, len(event.CapacityAlertsSent))
+		for ticketType, sent := range event.CapacityAlertsSent {
+			clone.CapacityAlertsSent[ticketType] = slices.Clone(sent)
+		}
+	}
+	if event.Reschedule != nil {
+		reschedule := *event.Reschedule
+		reschedule.ReconfirmBy = clonePointer(event.Reschedule.ReconfirmBy)
+		clone.Reschedule = &reschedule
+	}
+	return &clone
+}
+
+// clonePointer returns a pointer to a copy of the value, nil stays nil
+func clonePointer[T any](value *T) *T {
+	if value == nil {
+		return nil
+	}
+	copied := *value
+	return &copied
+}
+
+// cloneEvents copies every event of a listing
+func cloneEvents(events []*models.Event) []*models.Event {
+	clones := make([]*models.Event, len(events))
+	for i, event := range events {
+		clones[i] = cloneEvent(event)
+	}
+	return clones
+}
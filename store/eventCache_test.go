@@ -0,0 +1,72 @@
+package store
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"event-horizon/models"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// fullEvent returns the same event on every call, with every slice, map and pointer set
+func fullEvent() *models.Event {
+	at := time.Date(2026, 11, 20, 19, 0, 0, 0, time.UTC)
+	id := bson.ObjectID{1}
+	return &models.Event{
+		ID:            id,
+		CoHosts:       []bson.ObjectID{{2}},
+		CoHostInvites: []bson.ObjectID{{3}},
+		OrgID:         &bson.ObjectID{4},
+		OrgManagers:   []bson.ObjectID{{5}},
+		OrgScanners:   []bson.ObjectID{{6}},
+		Tags:          []string{"jazz"},
+		GeoLocation:   &models.GeoPoint{Type: "Point", Coordinates: []float64{13.4, 52.5}},
+		VenueID:       &bson.ObjectID{7},
+		PublishAt:     &at,
+		Tickets: []models.TicketInfo{{
+			Type: "Regular", TotalQuantity: 50, AvailableQuantity: 50,
+			SaleStart: &at, SaleEnd: &at,
+		}},
+		Sessions:           []models.Session{{ID: bson.ObjectID{8}, Title: "Day 1", Capacity: 10}},
+		CapacityAlerts:     &models.CapacityAlertSettings{Thresholds: []int{50, 100}},
+		CapacityAlertsSent: map[string][]int{"Regular": {50}},
+		Reschedule:         &models.Reschedule{PreviousDate: at, ReconfirmBy: &at},
+		VenueConflicts:     []models.VenueConflict{{EventID: bson.ObjectID{9}, Name: "Other"}},
+	}
+}
+
+func TestCloneEventSharesNothing(t *testing.T) {
+	cached := fullEvent()
+	clone := cloneEvent(cached)
+	if !reflect.DeepEqual(clone, cached) {
+		t.Fatalf("the clone differs from the event:\n%+v\n%+v", clone, cached)
+	}
+
+	//! Change everything the clone points to, the cached event must not see any of it
+	later := time.Now()
+	clone.CoHosts[0] = bson.NewObjectID()
+	clone.CoHostInvites[0] = bson.NewObjectID()
+	*clone.OrgID = bson.NewObjectID()
+	clone.OrgManagers[0] = bson.NewObjectID()
+	clone.OrgScanners[0] = bson.NewObjectID()
+	clone.Tags[0] = "rock"
+	clone.GeoLocation.Coordinates[0] = 0
+	*clone.VenueID = bson.NewObjectID()
+	*clone.PublishAt = later
+	clone.Tickets[0].AvailableQuantity = 0
+	*clone.Tickets[0].SaleStart = later
+	*clone.Tickets[0].SaleEnd = later
+	clone.Sessions[0].Capacity = 0
+	clone.CapacityAlerts.Thresholds[0] = 90
+	clone.CapacityAlertsSent["Regular"][0] = 100
+	clone.CapacityAlertsSent["VIP"] = []int{50}
+	*clone.Reschedule.ReconfirmBy = later
+	clone.Reschedule.PreviousDate = later
+	clone.VenueConflicts[0].Name = "Changed"
+
+	if !reflect.DeepEqual(cached, fullEvent()) {
+		t.Fatalf("changing the clone changed the cached event:\n%+v", cached)
+	}
+}
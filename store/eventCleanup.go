@@ -0,0 +1,165 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"event-horizon/models"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+/** *********************  EXPIRED EVENT CLEANUP   ********************
+
+Ended events are kept for a RETENTION period (attendees still see them under
+their past bookings, hosts still export their sales) and deleted afterwards
+together with their bookings.
+
+Events that ran (published or cancelled) are ARCHIVED first, with their final
+sales and attendance, so hosts keep their history (see eventArchive.go).
+
+Events are deleted in BATCHES, oldest end_time first, every event with its
+bookings in its own transaction: a failing event never leaves bookings
+without their event behind and never holds up the rest of the batch.
+
+ **************************************/
+
+// CleanupPolicy says how long ended events are kept and how many are deleted per batch
+type CleanupPolicy struct {
+	Retention time.Duration //? 0 deletes events as soon as they end
+	BatchSize int
+}
+
+// Cutoff is the end time before which events are expired
+func (p CleanupPolicy) Cutoff(now time.Time) time.Time {
+	return now.Add(-p.Retention)
+}
+
+// expiredFilter matches the events that ended before the cutoff (times are stored in UTC, so this is zone-independent).
+// For multi-session events end_time is kept in sync with the last session, so they only expire when the last session ends.
+func expiredFilter(cutoff time.Time) bson.M {
+	return bson.M{"end_time": bson.M{"$lt": cutoff}}
+}
+
+// DeleteExpiredEvents deletes the events that ended more than the retention period ago, with their bookings, and returns
+// how many were deleted. It works through them a batch at a time until none are left or ctx is done.
+func (s *EventStore) DeleteExpiredEvents(ctx context.Context, policy CleanupPolicy) (int64, error) {
+	cutoff := policy.Cutoff(time.Now())
+	opts := options.Find().
+		SetProjection(bson.M{"_id": 1}).
+		SetSort(bson.D{{Key: "end_time", Value: 1}}).
+		SetLimit(int64(policy.BatchSize))
+
+	var deleted int64
+	var failed []bson.ObjectID
+	var errs []error
+	for ctx.Err() == nil {
+		//? Events that failed before are skipped, or the same batch would come back forever
+		filter := expiredFilter(cutoff)
+		if len(failed) > 0 {
+			filter["_id"] = bson.M{"$nin": failed}
+		}
+
+		cursor, err := s.collection.Find(ctx, filter, opts)
+		if err != nil {
+			return deleted, err
+		}
+		var batch []models.Event
+		if err := cursor.All(ctx, &batch); err != nil {
+			return deleted, err
+		}
+
+		for _, event := range batch {
+			if err := s.deleteExpiredEvent(ctx, event.ID, cutoff); err != nil {
+				failed = append(failed, event.ID)
+				errs = append(errs, errors.New("event "+event.ID.Hex()+": "+err.Error()))
+				continue
+			}
+			deleted++
+		}
+
+		if len(batch) < policy.BatchSize {
+			break
+		}
+	}
+
+	return deleted, errors.Join(errs...)
+}
+
+// deleteExpiredEvent archives one expired event and deletes it with its bookings in one transaction
+func (s *EventStore) deleteExpiredEvent(ctx context.Context, eventID bson.ObjectID, cutoff time.Time) error {
+	defer eventReadCache.invalidate(eventID)
+
+	return s.withTransaction(ctx, func(sessCtx context.Context) error {
+		//! Still expired, the host may have rescheduled it since the batch was read
+		filter := expiredFilter(cutoff)
+		filter["_id"] = eventID
+
+		var event models.Event
+		if err := s.collection.FindOne(sessCtx, filter).Decode(&event); err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				return nil
+			}
+			return err
+		}
+		if err := s.archiveEvent(sessCtx, &event); err != nil {
+			return errors.New("failed to archive expired event: " + err.Error())
+		}
+
+		result, err := s.collection.DeleteOne(sessCtx, filter)
+		if err != nil || result.DeletedCount == 0 {
+			return err
+		}
+
+		if s.bookingStore != nil {
+			if _, err := s.bookingStore.DeleteBookingsByEventID(sessCtx, eventID); err != nil {
+				return errors.New("failed to delete bookings for expired event: " + err.Error())
+			}
+		}
+		return nil
+	})
+}
+
+// GetExpiredEvents is the dry run of DeleteExpiredEvents: up to limit of the events it would delete next (oldest end_time
+// first) with their booking counts, and how many events it would delete in total
+func (s *EventStore) GetExpiredEvents(ctx context.Context, policy CleanupPolicy, limit int) ([]models.ExpiredEvent, int64, error) {
+	filter := expiredFilter(policy.Cutoff(time.Now()))
+
+	total, err := s.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$sort", Value: bson.D{{Key: "end_time", Value: 1}}}},
+		{{Key: "$limit", Value: limit}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         "Bookings",
+			"localField":   "_id",
+			"foreignField": "event_id",
+			"pipeline":     bson.A{bson.M{"$count": "n"}},
+			"as":           "bookings",
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"name":     1,
+			"host_id":  1,
+			"end_time": 1,
+			"bookings": bson.M{"$ifNull": bson.A{bson.M{"$first": "$bookings.n"}, 0}},
+		}}},
+	}
+
+	cursor, err := s.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(ctx)
+
+	events := []models.ExpiredEvent{}
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, 0, err
+	}
+	return events, total, nil
+}
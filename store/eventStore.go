@@ -4,10 +4,15 @@ import (
 	"context"
 	"errors"
 	"event-horizon/models"
+	"fmt"
+	"regexp"
+	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+	"go.mongodb.org/mongo-driver/v2/mongo/readpref"
 )
 
 //! THIS FILE IS INTERNAL DATABASE CONNECTION FOR EVENTS COLLECTION IN MONGODB
@@ -21,6 +26,8 @@ import (
 5. DeleteOne
 6. DeleteMany
 7. UpdateOne
+8. 2dsphere Index + $geoNear Aggregation
+9. $unwind / $group Aggregation
 
  ****************************************************************************************/
 
@@ -31,9 +38,9 @@ import (
 
 2. Implemented NewEventStore constructor to initialize EventStore with MongoDB collection and CategoryStore reference.
 
-3. Added SetBookingStore method to set (BookingStore) reference for managing bookings related to events.
+3. The BookingRepository (for cascade deletes and sold ticket counts) is passed to NewEventStore, there is no setter anymore.
 
-4. Developed CreateEvent method to add new events, ensuring category existence and unique event names.
+4. Developed CreateEvent method to add new events, ensuring category existence (stored by CategoryID) and unique event names.
 
 5. Created toEventResponse helper function to convert Event model to EventResponse for API responses.
 
@@ -47,54 +54,125 @@ import (
 
 10. Implemented UpdateEvent method to modify existing event details, ensuring category validity.
 
+11. Added EnsureGeoIndex method to create the 2dsphere index on geo_location.
+
+12. Developed GetEventsNearby method to find events within a radius using $geoNear.
+
+13. Added GetPopularTags method to aggregate tag usage counts across events.
+
+14. Added PatchEvent method to $set only the patched fields, guarded so a booking made in the meantime is not overwritten.
+
+15. UpdateEvent and PatchEvent reconcile ticket totals against SOLD counts from the Bookings collection (in a transaction) and reject reductions below them.
+    Session seats are reconciled the same way from the seats booked per session.
+
+16. Added UniqueCopyName and PublishEvent methods for duplicated DRAFT events, drafts are left out of public listings.
+
+17. Added InviteCoHost, AcceptCoHostInvite and RemoveCoHost methods for event CO-HOSTS.
+
+18. Added MODERATION: with SetModeration on, published events wait in "pending_review" until ModerateEvent approves or rejects them.
+
+19. Added HideForReview method so heavily REPORTED events go back to the review queue.
+
+20. GetAllEvents and GetEventByID go through the in-memory EVENT CACHE (eventCache.go), every write invalidates it.
+
+21. Added GetEventSummaries method that uses a Mongo PROJECTION (with a computed min_price) for slim listings.
+
+22. Every write to an event sets UPDATED_AT, which drives the ETag / Last-Modified headers of the event endpoints.
+
+23. Added a VERSION counter (optimistic concurrency): UpdateEvent only writes if the event is still at the expected version, PatchEvent bumps it too.
+
+24. Added SetHostSuspended to hide every event of a SUSPENDED host, public listings skip events flagged host_suspended.
+
+25. UpdateEvent writes the event CURRENCY, summaries project it next to min_price.
+
+26. UpdateEvent writes the TAX rate and whether prices include it.
+
+27. Added SearchEvents (regex search with facets, used when no search engine is configured) and FilterPublicEvents.
+
+28. UpdateEvent writes the CAPACITY ALERT settings, MarkCapacityAlertSent makes sure every alert goes out once.
+
+29. UpdateEvent writes whether the host HIDES the exact ticket counts.
+
+30. Added SetPublishAt, GetScheduledEvents and EnsurePublishAtIndex for SCHEDULED publishing, PublishEvent clears the schedule.
+
+31. CreateEvent no longer locks names globally, GetEventsStartingBetween gives the service the events of the same day to check for DUPLICATES, UniqueCopyName only looks at the host's events.
+
+32. Added GetSitemapEvents, the ids and change times of every public event for the SITEMAP.
+
+33. Added GetUpcomingEvents for the RSS FEED, public events that haven't ended, optionally of one category.
+
+34. GetUpcomingEvents can keep one host's events for the EMBEDDABLE WIDGET.
+
+35. DeleteExpiredEvents moved to eventCleanup.go, it keeps ended events for a RETENTION period and deletes them in batches.
+
+36. Added SetHostVerified for the VERIFIED HOST badge on every event of a host, listings can project host_verified.
+
+37. Added GetEventsByOrg and SetOrgStaff for ORGANIZATIONS, the org's managers and scanners are copied onto its events.
+
+38. Added CountUpcomingHostEvents, a host can only delete their account once their events are over.
+
+39. Added SetEventStatus for the BULK actions (unpublish and cancel), CANCELLED events are never listed and don't keep a host from deleting their account.
+
+40. Events can be held at a VENUE (venue_id), GetUpcomingEvents lists the events at one, SetVenueLocation copies a changed venue onto its upcoming events.
+
+41. Added GetOverlappingVenueEvents method, a range query on venue_id + start_time for events running at the same venue at the same time.
+
+42. Added GetCalendarEvents method and EnsureCalendarIndex, the calendar only projects fields of its index so the query is COVERED.
+
+43. The cleanup ARCHIVES events that ran before deleting them, GetArchivedEvents and GetEndedEvents list a host's past events (eventArchive.go).
+
 
 ************************************************************************************************************/
 
 type EventStore struct {
 	collection    *mongo.Collection
-	categoryStore *CategoryStore
-	bookingStore  *BookingStore
+	archive       *mongo.Collection //? What the cleanup keeps of deleted events, see eventArchive.go
+	categoryStore CategoryRepository
+	bookingStore  BookingRepository
+	moderation    bool
 }
 
 // NewEventStore !NewEventStore creates a new EventStore.
-func NewEventStore(db *mongo.Database, categoryStore *CategoryStore) *EventStore {
+func NewEventStore(db *mongo.Database, categoryStore CategoryRepository, bookingStore BookingRepository) *EventStore {
 	return &EventStore{
 		collection:    db.Collection("Events"),
+		archive:       db.Collection("EventArchive"),
 		categoryStore: categoryStore,
-		bookingStore:  nil, // Will be set later via SetBookingStore
+		bookingStore:  bookingStore,
 	}
 }
 
-// SetBookingStore ! SetBookingStore sets the bookingStore reference with events
-func (s *EventStore) SetBookingStore(bookingStore *BookingStore) {
-	s.bookingStore = bookingStore
+// SetModeration turns the admin review step for new events on or off
+func (s *EventStore) SetModeration(enabled bool) {
+	s.moderation = enabled
+}
+
+// publishStatus is the status an event gets when its host publishes it
+func (s *EventStore) publishStatus() string {
+	if s.moderation {
+		return models.EventStatusPendingReview
+	}
+	return models.EventStatusPublished
 }
 
 // ! CREATE EVENT
 func (s *EventStore) CreateEvent(ctx context.Context, event *models.Event) error {
 
-	//? Validate that category exists by name
-	_, err := s.categoryStore.GetCategoryByName(ctx, event.CategoryName)
+	//? Validate that category exists by name and reference it by ID
+	category, err := s.categoryStore.GetCategoryByName(ctx, event.CategoryName)
 	if err != nil {
-		return errors.New("category not found: " + event.CategoryName)
-	}
-
-	//? Check for duplicate event name
-	filter := bson.M{"name": event.Name}
-
-	var existingEvent models.Event
-	err = s.collection.FindOne(ctx, filter).Decode(&existingEvent)
-	if err == nil {
-		return errors.New("event with the same name already exists")
-	}
-
-	//? If the error is not ErrNoDocuments, return the error
-	if !errors.Is(err, mongo.ErrNoDocuments) {
-		return err
+		return fmt.Errorf("%w: %s", ErrCategoryNotFound, event.CategoryName)
 	}
+	event.CategoryID = category.ID
+	event.CategoryName = category.Name
 
-	//? 3. Set creation timestamp
+	//? 3. Set creation timestamp (events are published, or sent to review, unless created as a draft)
 	event.CreatedAt = time.Now()
+	event.UpdatedAt = event.CreatedAt
+	event.Version = 1
+	if event.Status == "" {
+		event.Status = s.publishStatus()
+	}
 
 	//? 4. Insert the event
 	result, err := s.collection.InsertOne(ctx, event)
@@ -103,6 +181,7 @@ func (s *EventStore) CreateEvent(ctx context.Context, event *models.Event) error
 	}
 
 	event.ID = result.InsertedID.(bson.ObjectID)
+	eventReadCache.invalidate(event.ID)
 	return nil
 }
 
@@ -112,18 +191,48 @@ func toEventResponse(event *models.Event) *models.EventResponse {
 		ID:           event.ID,
 		Name:         event.Name,
 		HostID:       event.HostID,
+		HostVerified: event.HostVerified,
+		CategoryID:   event.CategoryID,
 		CategoryName: event.CategoryName,
+		Tags:         event.Tags,
 		Date:         event.Date,
+		Timezone:     event.Timezone,
+		Currency:     event.Currency,
+		TaxRate:      event.TaxRate,
+		TaxInclusive: event.TaxInclusive,
+		StartTime:    event.StartTime,
+		EndTime:      event.EndTime,
 		Location:     event.Location,
+		GeoLocation:  event.GeoLocation,
+		EventType:    event.EventType,
+		StreamURL:    event.StreamURL,
 		Tickets:      event.Tickets,
+		Sessions:     event.Sessions,
+	}
+}
+
+// hiddenEventStatuses are the statuses that keep an event out of public listings
+var hiddenEventStatuses = []string{models.EventStatusDraft, models.EventStatusPendingReview, models.EventStatusRejected, models.EventStatusCancelled}
+
+// publicEventFilter matches events that can appear in public listings (events of suspended hosts never do)
+func publicEventFilter() bson.M {
+	return bson.M{
+		"status":         bson.M{"$nin": hiddenEventStatuses},
+		"host_suspended": bson.M{"$ne": true},
 	}
 }
 
-// ! GetAllEvents retrieves all events from the database and returns EventResponse
-func (s *EventStore) GetAllEvents(ctx context.Context) ([]*models.Event, error) {
+// ! GetAllEvents retrieves all events from the database, optionally only those having every given tag
+func (s *EventStore) GetAllEvents(ctx context.Context, tags []string) ([]*models.Event, error) {
 	var events []*models.Event
 
-	cursor, err := s.collection.Find(ctx, bson.M{})
+	//? Serve from the cache while it is fresh
+	cacheKey := "all:" + strings.Join(tags, ",")
+	if cached, ok := eventReadCache.getList(cacheKey); ok {
+		return cached, nil
+	}
+
+	cursor, err := s.collection.Find(ctx, listingFilter(tags))
 	if err != nil {
 		return nil, err
 	}
@@ -136,68 +245,112 @@ func (s *EventStore) GetAllEvents(ctx context.Context) ([]*models.Event, error)
 	if events == nil {
 		events = []*models.Event{} //** Return empty slice
 	}
+
+	eventReadCache.setList(cacheKey, events)
 	return events, nil
 }
 
-func (s *EventStore) GetEventByID(ctx context.Context, id string) (*models.Event, error) {
-	var event models.Event
-
-	bsonID, err := bson.ObjectIDFromHex(id)
-	if err != nil {
-		return nil, errors.New("invalid event id")
+// listingFilter is the filter of the public event listing, optionally only events having every given tag
+func listingFilter(tags []string) bson.M {
+	filter := publicEventFilter() //! drafts are not listed
+	if len(tags) > 0 {
+		filter["tags"] = bson.M{"$all": tags}
 	}
+	return filter
+}
 
-	filter := bson.M{"_id": bsonID}
-	err = s.collection.FindOne(ctx, filter).Decode(&event)
+// ErrUnknownEventField is returned when a projection asks for a field an EventSummary doesn't have
+var ErrUnknownEventField = errors.New("unknown event field")
 
-	if err != nil {
-		if errors.Is(err, mongo.ErrNoDocuments) {
-			return nil, errors.New("event not found")
+// summaryFields maps every ?fields= name to its Mongo projection
+var summaryFields = map[string]interface{}{
+	"name":          1,
+	"category_name": 1,
+	"tags":          1,
+	"date":          1,
+	"start_time":    1,
+	"end_time":      1,
+	"timezone":      1,
+	"location":      1,
+	"event_type":    1,
+	"image_url":     1,
+	"currency":      1,
+	"host_verified": 1,
+	"min_price":     bson.M{"$min": "$tickets.price"}, //? computed, so the tickets array never leaves the database
+}
+
+// summaryProjection builds the Mongo projection for the requested summary fields
+func summaryProjection(fields []string) (bson.M, error) {
+	projection := bson.M{"_id": 1, "updated_at": 1}
+	for _, field := range fields {
+		if field == "" {
+			continue
 		}
-		return nil, err
-	}
+		value, ok := summaryFields[field]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownEventField, field)
+		}
+		projection[field] = value
 
-	return &event, nil
+		//? Times are localized in responses, so they need the event's time zone
+		if field == "date" || field == "start_time" || field == "end_time" {
+			projection["timezone"] = 1
+		}
+		//? A price means nothing without its currency
+		if field == "min_price" {
+			projection["currency"] = 1
+		}
+	}
+	return projection, nil
 }
 
-// DeleteExpiredEvents deletes all events where end_time has passed and their associated bookings
-func (s *EventStore) DeleteExpiredEvents(ctx context.Context) (int64, error) {
-
-	//? Find events where end_time is before current time
-	filter := bson.M{
-		"end_time": bson.M{"$lt": time.Now()},
+// ! GetEventSummaries returns the public event listing trimmed down to the requested fields
+func (s *EventStore) GetEventSummaries(ctx context.Context, tags []string, fields []string) ([]*models.EventSummary, error) {
+	projection, err := summaryProjection(fields)
+	if err != nil {
+		return nil, err
 	}
 
-	//? First, get all expired events to delete their bookings
-	cursor, err := s.collection.Find(ctx, filter)
+	opts := options.Find().SetProjection(projection)
+	cursor, err := s.collection.Find(ctx, listingFilter(tags), opts)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
-	//! ENSURE CURSOR IS CLOSED
 	defer cursor.Close(ctx)
 
-	var expiredEvents []models.Event
-	if err = cursor.All(ctx, &expiredEvents); err != nil {
-		return 0, err
+	summaries := []*models.EventSummary{} //** Return empty slice
+	if err = cursor.All(ctx, &summaries); err != nil {
+		return nil, err
 	}
 
-	//? Delete bookings for each expired event
-	if s.bookingStore != nil {
-		for _, event := range expiredEvents {
-			_, err := s.bookingStore.DeleteBookingsByEventID(ctx, event.ID)
-			if err != nil {
-				return 0, errors.New("failed to delete bookings for expired event: " + err.Error())
-			}
-		}
+	return summaries, nil
+}
+
+func (s *EventStore) GetEventByID(ctx context.Context, id string) (*models.Event, error) {
+	var event models.Event
+
+	bsonID, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, errors.New("invalid event id")
+	}
+
+	//? Serve from the cache while it is fresh
+	if cached, ok := eventReadCache.getEvent(bsonID); ok {
+		return cached, nil
 	}
 
-	//? Then delete the expired events
-	result, err := s.collection.DeleteMany(ctx, filter)
+	filter := bson.M{"_id": bsonID}
+	err = s.collection.FindOne(ctx, filter).Decode(&event)
+
 	if err != nil {
-		return 0, err
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, errors.New("event not found")
+		}
+		return nil, err
 	}
 
-	return result.DeletedCount, nil
+	eventReadCache.setEvent(&event)
+	return &event, nil
 }
 
 // DeleteEvent deletes an event by ID and all associated bookings
@@ -214,6 +367,7 @@ func (s *EventStore) DeleteEvent(ctx context.Context, id bson.ObjectID) error {
 	filter := bson.M{"_id": id}
 
 	result, err := s.collection.DeleteOne(ctx, filter)
+	eventReadCache.invalidate(id)
 	if err != nil {
 		return err
 	}
@@ -225,37 +379,1040 @@ func (s *EventStore) DeleteEvent(ctx context.Context, id bson.ObjectID) error {
 	return nil
 }
 
-// UpdateEvent updates an event
-func (s *EventStore) UpdateEvent(ctx context.Context, event *models.Event) error {
-	//* Validate that category exists by name
-	_, err := s.categoryStore.GetCategoryByName(ctx, event.CategoryName)
+// VersionGuard matches events still at the expected version (events stored before versioning count as 0)
+func VersionGuard(expectedVersion int) bson.M {
+	if expectedVersion == 0 {
+		return bson.M{"version": bson.M{"$in": bson.A{0, nil}}}
+	}
+	return bson.M{"version": expectedVersion}
+}
+
+// UpdateEvent updates an event if it is still at expectedVersion (ErrEventChanged otherwise)
+func (s *EventStore) UpdateEvent(ctx context.Context, event *models.Event, expectedVersion int) error {
+	//* Validate that category exists by name and reference it by ID
+	category, err := s.categoryStore.GetCategoryByName(ctx, event.CategoryName)
 	if err != nil {
-		return errors.New("category not found: " + event.CategoryName)
+		return fmt.Errorf("%w: %s", ErrCategoryNotFound, event.CategoryName)
 	}
+	event.CategoryID = category.ID
+	event.CategoryName = category.Name
 
-	filter := bson.M{"_id": event.ID}
+	event.UpdatedAt = time.Now()
+
+	filter := VersionGuard(expectedVersion)
+	filter["_id"] = event.ID
 	update := bson.M{
+		"$inc": bson.M{"version": 1},
 		"$set": bson.M{
+			"updated_at":    event.UpdatedAt,
 			"name":          event.Name,
+			"category_id":   event.CategoryID,
 			"category_name": event.CategoryName,
+			"tags":          event.Tags,
 			"description":   event.Description,
 			"date":          event.Date,
+			"timezone":      event.Timezone,
+			"currency":      event.Currency,
+			"tax_rate":      event.TaxRate,
+			"tax_inclusive": event.TaxInclusive,
 			"location":      event.Location,
+			"geo_location":  event.GeoLocation,
+			"venue_id":      event.VenueID,
+			"event_type":    event.EventType,
+			"stream_url":    event.StreamURL,
 			"image_url":     event.ImageURL,
 			"start_time":    event.StartTime,
 			"end_time":      event.EndTime,
 			"tickets":       event.Tickets,
+			"sessions":      event.Sessions,
+
+			"capacity_alerts":    event.CapacityAlerts,
+			"hide_ticket_counts": event.HideTicketCounts,
+		},
+	}
+
+	//! Tickets and session seats are reconciled against the bookings and written in one transaction, so a booking can't slip in between
+	return s.withTransaction(ctx, func(sessCtx context.Context) error {
+		if err := s.reconcileTickets(sessCtx, event); err != nil {
+			return err
+		}
+		update["$set"].(bson.M)["tickets"] = event.Tickets
+		if err := s.reconcileSessions(sessCtx, event); err != nil {
+			return err
+		}
+		update["$set"].(bson.M)["sessions"] = event.Sessions
+
+		result, err := s.collection.UpdateOne(sessCtx, filter, update)
+		eventReadCache.invalidate(event.ID)
+		if err != nil {
+			return err
+		}
+
+		if result.MatchedCount == 0 {
+			return ErrEventChanged //! someone else edited (or deleted) the event since the client read it
+		}
+
+		event.Version = expectedVersion + 1
+		return nil
+	})
+}
+
+// ErrTicketsBelowSold is returned when an update would leave fewer tickets than were already sold
+var ErrTicketsBelowSold = errors.New("ticket quantity cannot be lower than tickets already sold")
+
+// reconcileTickets recomputes available quantities from the confirmed bookings and rejects totals below what was sold
+func (s *EventStore) reconcileTickets(ctx context.Context, event *models.Event) error {
+	sold, err := s.bookingStore.GetSoldTicketCounts(ctx, event.ID)
+	if err != nil {
+		return err
+	}
+
+	kept := make(map[string]bool, len(event.Tickets))
+	for i := range event.Tickets {
+		ticket := &event.Tickets[i]
+		kept[ticket.Type] = true
+
+		if ticket.TotalQuantity < sold[ticket.Type] {
+			return fmt.Errorf("%w: %d %s tickets sold", ErrTicketsBelowSold, sold[ticket.Type], ticket.Type)
+		}
+		ticket.AvailableQuantity = ticket.TotalQuantity - sold[ticket.Type]
+	}
+
+	//? A ticket type with sold tickets can't be removed
+	for ticketType, count := range sold {
+		if count > 0 && !kept[ticketType] {
+			return fmt.Errorf("%w: %d %s tickets sold, the ticket type can't be removed", ErrTicketsBelowSold, count, ticketType)
+		}
+	}
+
+	return nil
+}
+
+// ErrSessionBelowBooked is returned when an update would leave a session fewer seats than were already booked
+var ErrSessionBelowBooked = errors.New("session capacity cannot be lower than seats already booked")
+
+// reconcileSessions recomputes the available seats of the sessions from the confirmed bookings,
+// sessions without a capacity are unlimited and keep 0
+func (s *EventStore) reconcileSessions(ctx context.Context, event *models.Event) error {
+	if len(event.Sessions) == 0 {
+		return nil
+	}
+
+	booked, err := s.bookingStore.GetBookedSessionSeats(ctx, event.ID)
+	if err != nil {
+		return err
+	}
+
+	for i := range event.Sessions {
+		session := &event.Sessions[i]
+		if session.Capacity == 0 {
+			session.AvailableCapacity = 0
+			continue
+		}
+		if session.Capacity < booked[session.ID] {
+			return fmt.Errorf("%w: %d seats booked in %s", ErrSessionBelowBooked, booked[session.ID], session.Title)
+		}
+		session.AvailableCapacity = session.Capacity - booked[session.ID]
+	}
+
+	return nil
+}
+
+// txnSessionOptions keeps transactions on the primary, whatever MONGO_READ_PREFERENCE is
+var txnSessionOptions = options.Session().SetDefaultTransactionOptions(
+	options.Transaction().SetReadPreference(readpref.Primary()),
+)
+
+// withTransaction runs fn inside a MongoDB transaction
+func (s *EventStore) withTransaction(ctx context.Context, fn func(sessCtx context.Context) error) error {
+	session, err := s.collection.Database().Client().StartSession(txnSessionOptions)
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sessCtx context.Context) (interface{}, error) {
+		return nil, fn(sessCtx)
+	})
+	return err
+}
+
+// UniqueCopyName returns a name for a copy of an event ("Name (Copy)", "Name (Copy 2)", ...) the host doesn't use yet
+func (s *EventStore) UniqueCopyName(ctx context.Context, hostID bson.ObjectID, name string) (string, error) {
+	base := name + " (Copy)"
+
+	candidate := base
+	for i := 2; ; i++ {
+		count, err := s.collection.CountDocuments(ctx, bson.M{"host_id": hostID, "name": candidate})
+		if err != nil {
+			return "", err
+		}
+		if count == 0 {
+			return candidate, nil
+		}
+		candidate = fmt.Sprintf("%s (Copy %d)", name, i)
+	}
+}
+
+// GetEventsStartingBetween returns the events starting in [from, to) a new event of the host could duplicate:
+// all of the host's own events and the public events of other hosts, with only their name, host and start time
+func (s *EventStore) GetEventsStartingBetween(ctx context.Context, hostID bson.ObjectID, from, to time.Time) ([]models.Event, error) {
+	filter := bson.M{
+		"start_time": bson.M{"$gte": from, "$lt": to},
+		"$or": bson.A{
+			bson.M{"host_id": hostID},
+			publicEventFilter(), //! Other hosts' drafts stay private
 		},
 	}
+	opts := options.Find().
+		SetProjection(bson.M{"name": 1, "host_id": 1, "start_time": 1}).
+		SetLimit(500)
+
+	cursor, err := s.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var events []models.Event
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// GetSitemapEvents returns the public events with only their id and change times, soonest first
+func (s *EventStore) GetSitemapEvents(ctx context.Context, limit int) ([]models.Event, error) {
+	opts := options.Find().
+		SetProjection(bson.M{"_id": 1, "created_at": 1, "updated_at": 1}).
+		SetSort(bson.D{{Key: "start_time", Value: 1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := s.collection.Find(ctx, publicEventFilter(), opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	events := []models.Event{} //** Return empty slice
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// GetUpcomingEvents returns the public events that haven't ended yet, soonest first unless the query asks for the newest
+func (s *EventStore) GetUpcomingEvents(ctx context.Context, query models.UpcomingEventsQuery) ([]models.Event, error) {
+	filter := publicEventFilter()
+	filter["end_time"] = bson.M{"$gt": time.Now()}
+	if !query.HostID.IsZero() {
+		filter["host_id"] = query.HostID
+	}
+	if query.Category != "" {
+		filter["category_name"] = query.Category
+	}
+	if !query.VenueID.IsZero() {
+		filter["venue_id"] = query.VenueID
+	}
+
+	sort := bson.D{{Key: "start_time", Value: 1}}
+	if query.NewestFirst {
+		sort = bson.D{{Key: "created_at", Value: -1}}
+	}
+	opts := options.Find().SetSort(sort).SetLimit(int64(query.Limit))
+
+	cursor, err := s.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	events := []models.Event{} //** Return empty slice
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// PublishEvent publishes a draft (or sends it to review when moderation is on) and returns the new status
+func (s *EventStore) PublishEvent(ctx context.Context, eventID bson.ObjectID) (string, error) {
+	status := s.publishStatus()
+
+	filter := bson.M{"_id": eventID, "status": models.EventStatusDraft}
+	update := bson.M{"$set": bson.M{"status": status, "updated_at": time.Now()}, "$unset": bson.M{"publish_at": ""}}
+
+	result, err := s.collection.UpdateOne(ctx, filter, update)
+	eventReadCache.invalidate(eventID)
+	if err != nil {
+		return "", err
+	}
+
+	if result.MatchedCount == 0 {
+		return "", errors.New("draft event not found")
+	}
+
+	return status, nil
+}
+
+// ErrStatusChanged is returned when an event's status is not one of the expected ones (anymore)
+var ErrStatusChanged = errors.New("event status changed")
+
+// SetEventStatus moves an event from one of the given statuses to another one and clears its publish schedule,
+// events without a status count as published
+func (s *EventStore) SetEventStatus(ctx context.Context, eventID bson.ObjectID, from []string, to string) error {
+	statuses := bson.A{}
+	for _, status := range from {
+		statuses = append(statuses, status)
+		if status == models.EventStatusPublished {
+			statuses = append(statuses, "", nil)
+		}
+	}
+
+	filter := bson.M{"_id": eventID, "status": bson.M{"$in": statuses}}
+	update := bson.M{"$set": bson.M{"status": to, "updated_at": time.Now()}, "$unset": bson.M{"publish_at": ""}}
 
 	result, err := s.collection.UpdateOne(ctx, filter, update)
+	eventReadCache.invalidate(eventID)
 	if err != nil {
 		return err
 	}
 
 	if result.MatchedCount == 0 {
-		return errors.New("event not found")
+		return ErrStatusChanged
+	}
+	return nil
+}
+
+// ErrNotDraft is returned when only a draft could be changed (e.g. scheduled) but the event is not one (anymore)
+var ErrNotDraft = errors.New("event is not a draft")
+
+// SetPublishAt schedules when a draft goes live, nil cancels the schedule
+func (s *EventStore) SetPublishAt(ctx context.Context, eventID bson.ObjectID, publishAt *time.Time) error {
+	filter := bson.M{"_id": eventID, "status": models.EventStatusDraft}
+	update := bson.M{"$set": bson.M{"publish_at": publishAt, "updated_at": time.Now()}}
+	if publishAt == nil {
+		update = bson.M{"$unset": bson.M{"publish_at": ""}, "$set": bson.M{"updated_at": time.Now()}}
+	}
+
+	result, err := s.collection.UpdateOne(ctx, filter, update)
+	eventReadCache.invalidate(eventID)
+	if err != nil {
+		return err
 	}
 
+	if result.MatchedCount == 0 {
+		return ErrNotDraft
+	}
 	return nil
 }
+
+// GetScheduledEvents returns up to limit drafts whose publish_at is not after the given time, the most overdue first
+func (s *EventStore) GetScheduledEvents(ctx context.Context, due time.Time, limit int) ([]models.Event, error) {
+	filter := bson.M{"status": models.EventStatusDraft, "publish_at": bson.M{"$lte": due}}
+	opts := options.Find().SetSort(bson.D{{Key: "publish_at", Value: 1}}).SetLimit(int64(limit))
+
+	cursor, err := s.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	events := []models.Event{}
+	if err = cursor.All(ctx, &events); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// EnsurePublishAtIndex creates the sparse index the publish scheduler looks up due drafts with
+func (s *EventStore) EnsurePublishAtIndex(ctx context.Context) error {
+	index := mongo.IndexModel{
+		Keys:    bson.D{{Key: "publish_at", Value: 1}},
+		Options: options.Index().SetName("publish_at").SetSparse(true),
+	}
+
+	_, err := s.collection.Indexes().CreateOne(ctx, index)
+	return err
+}
+
+// GetEventsByStatus lists events with the given status, oldest first (e.g. the moderation queue)
+func (s *EventStore) GetEventsByStatus(ctx context.Context, status string) ([]models.Event, error) {
+	var events []models.Event
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+	cursor, err := s.collection.Find(ctx, bson.M{"status": status}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	if err = cursor.All(ctx, &events); err != nil {
+		return nil, err
+	}
+
+	if events == nil {
+		events = []models.Event{}
+	}
+
+	return events, nil
+}
+
+// ModerateEvent approves or rejects an event waiting for review
+func (s *EventStore) ModerateEvent(ctx context.Context, eventID bson.ObjectID, approved bool, reason string) error {
+	set := bson.M{"status": models.EventStatusPublished, "moderation_reason": ""}
+	if !approved {
+		set = bson.M{"status": models.EventStatusRejected, "moderation_reason": reason}
+	}
+
+	set["updated_at"] = time.Now()
+
+	filter := bson.M{"_id": eventID, "status": models.EventStatusPendingReview}
+	result, err := s.collection.UpdateOne(ctx, filter, bson.M{"$set": set})
+	eventReadCache.invalidate(eventID)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return errors.New("event is not waiting for review")
+	}
+
+	return nil
+}
+
+// InviteCoHost records a co-host invite for a user who is not already managing the event
+func (s *EventStore) InviteCoHost(ctx context.Context, eventID, userID bson.ObjectID) error {
+	filter := bson.M{
+		"_id":      eventID,
+		"host_id":  bson.M{"$ne": userID},
+		"co_hosts": bson.M{"$ne": userID},
+	}
+	update := bson.M{
+		"$addToSet": bson.M{"co_host_invites": userID},
+		"$set":      bson.M{"updated_at": time.Now()},
+	}
+
+	result, err := s.collection.UpdateOne(ctx, filter, update)
+	eventReadCache.invalidate(eventID)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return errors.New("user already manages this event")
+	}
+
+	return nil
+}
+
+// AcceptCoHostInvite turns a pending invite into a co-host
+func (s *EventStore) AcceptCoHostInvite(ctx context.Context, eventID, userID bson.ObjectID) error {
+	filter := bson.M{"_id": eventID, "co_host_invites": userID}
+	update := bson.M{
+		"$pull":     bson.M{"co_host_invites": userID},
+		"$addToSet": bson.M{"co_hosts": userID},
+		"$set":      bson.M{"updated_at": time.Now()},
+	}
+
+	result, err := s.collection.UpdateOne(ctx, filter, update)
+	eventReadCache.invalidate(eventID)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return errors.New("no pending co-host invite")
+	}
+
+	return nil
+}
+
+// RemoveCoHost removes a user from the co-hosts (and pending invites) of an event
+func (s *EventStore) RemoveCoHost(ctx context.Context, eventID, userID bson.ObjectID) error {
+	update := bson.M{
+		"$pull": bson.M{"co_hosts": userID, "co_host_invites": userID},
+		"$set":  bson.M{"updated_at": time.Now()},
+	}
+
+	result, err := s.collection.UpdateOne(ctx, bson.M{"_id": eventID}, update)
+	eventReadCache.invalidate(eventID)
+	if err != nil {
+		return err
+	}
+
+	if result.ModifiedCount == 0 {
+		return errors.New("user is not a co-host of this event")
+	}
+
+	return nil
+}
+
+// HideForReview takes a listed event out of public listings and puts it in the review queue, reports whether it was hidden
+func (s *EventStore) HideForReview(ctx context.Context, eventID bson.ObjectID) (bool, error) {
+	filter := publicEventFilter()
+	filter["_id"] = eventID
+	update := bson.M{"$set": bson.M{"status": models.EventStatusPendingReview, "updated_at": time.Now()}}
+
+	result, err := s.collection.UpdateOne(ctx, filter, update)
+	eventReadCache.invalidate(eventID)
+	if err != nil {
+		return false, err
+	}
+
+	return result.ModifiedCount > 0, nil
+}
+
+// ErrEventChanged is returned when an event was modified (e.g. booked) between reading and patching it
+var ErrEventChanged = errors.New("event was changed in the meantime, please retry")
+
+// ErrCategoryNotFound is returned when an event is saved with a category name that doesn't exist
+var ErrCategoryNotFound = errors.New("category not found")
+
+// ! PatchEvent $sets only the given fields of the event, guard holds extra conditions the stored event must still match
+func (s *EventStore) PatchEvent(ctx context.Context, event *models.Event, fields []string, guard bson.M) error {
+	//* A new category name is validated and referenced by ID
+	for _, field := range fields {
+		if field != "category_name" {
+			continue
+		}
+		category, err := s.categoryStore.GetCategoryByName(ctx, event.CategoryName)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrCategoryNotFound, event.CategoryName)
+		}
+		event.CategoryID = category.ID
+		event.CategoryName = category.Name
+		fields = append(fields, "category_id")
+		break
+	}
+
+	//? Encode the whole event once and pick the patched fields from it
+	raw, err := bson.Marshal(event)
+	if err != nil {
+		return err
+	}
+	var doc bson.M
+	if err := bson.Unmarshal(raw, &doc); err != nil {
+		return err
+	}
+
+	set := bson.M{}
+	for _, field := range fields {
+		set[field] = doc[field] //! omitted (empty) fields are set to null
+	}
+	event.UpdatedAt = time.Now()
+	set["updated_at"] = event.UpdatedAt
+
+	filter := bson.M{"_id": event.ID}
+	for key, value := range guard {
+		filter[key] = value
+	}
+
+	write := func(ctx context.Context) error {
+		result, err := s.collection.UpdateOne(ctx, filter, bson.M{"$set": set, "$inc": bson.M{"version": 1}})
+		eventReadCache.invalidate(event.ID)
+		if err != nil {
+			return err
+		}
+
+		if result.MatchedCount == 0 {
+			if len(guard) > 0 {
+				return ErrEventChanged
+			}
+			return errors.New("event not found")
+		}
+
+		event.Version++
+		return nil
+	}
+
+	_, hasTickets := set["tickets"]
+	_, hasSessions := set["sessions"]
+	if !hasTickets && !hasSessions {
+		return write(ctx)
+	}
+
+	//! New tickets and sessions are reconciled against the bookings in the same transaction as the write
+	return s.withTransaction(ctx, func(sessCtx context.Context) error {
+		if hasTickets {
+			if err := s.reconcileTickets(sessCtx, event); err != nil {
+				return err
+			}
+			set["tickets"] = event.Tickets
+		}
+		if hasSessions {
+			if err := s.reconcileSessions(sessCtx, event); err != nil {
+				return err
+			}
+			set["sessions"] = event.Sessions
+		}
+		return write(sessCtx)
+	})
+}
+
+// EnsureGeoIndex creates the 2dsphere index needed for nearby searches
+func (s *EventStore) EnsureGeoIndex(ctx context.Context) error {
+	index := mongo.IndexModel{
+		Keys:    bson.D{{Key: "geo_location", Value: "2dsphere"}},
+		Options: options.Index().SetName("geo_location_2dsphere"),
+	}
+
+	_, err := s.collection.Indexes().CreateOne(ctx, index)
+	return err
+}
+
+// calendarFields are the fields the calendar shows, the calendar index holds every one of them (and of the filter)
+// so the query is answered from the index without reading the events
+var calendarFields = []string{"_id", "name", "category_name", "start_time", "end_time", "timezone", "location", "event_type"}
+
+// EnsureCalendarIndex creates the covering index of the platform calendar
+func (s *EventStore) EnsureCalendarIndex(ctx context.Context) error {
+	keys := bson.D{
+		{Key: "start_time", Value: 1},
+		{Key: "category_name", Value: 1},
+		{Key: "status", Value: 1},
+		{Key: "host_suspended", Value: 1},
+	}
+	for _, field := range calendarFields {
+		if field != "start_time" && field != "category_name" {
+			keys = append(keys, bson.E{Key: field, Value: 1})
+		}
+	}
+
+	_, err := s.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    keys,
+		Options: options.Index().SetName("calendar"),
+	})
+	return err
+}
+
+// ! GetCalendarEvents returns the public events running between query.From and query.To, soonest first
+func (s *EventStore) GetCalendarEvents(ctx context.Context, query models.CalendarQuery) ([]*models.EventSummary, error) {
+	filter := publicEventFilter()
+	filter["start_time"] = bson.M{"$lt": query.To}
+	filter["end_time"] = bson.M{"$gt": query.From}
+	if query.Category != "" {
+		filter["category_name"] = query.Category
+	}
+
+	projection := bson.M{}
+	for _, field := range calendarFields {
+		projection[field] = 1
+	}
+	opts := options.Find().
+		SetProjection(projection).
+		SetSort(bson.D{{Key: "start_time", Value: 1}}).
+		SetLimit(int64(query.Limit))
+
+	cursor, err := s.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	events := []*models.EventSummary{} //** Return empty slice
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// GetEventsNearby returns events within radiusKm of the given point, closest first
+func (s *EventStore) GetEventsNearby(ctx context.Context, lng, lat, radiusKm float64) ([]models.EventWithDistance, error) {
+	var events []models.EventWithDistance
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$geoNear", Value: bson.M{
+			"near": bson.M{
+				"type":        "Point",
+				"coordinates": []float64{lng, lat},
+			},
+			"distanceField":      "distance_km",
+			"distanceMultiplier": 0.001, //! meters -> km
+			"maxDistance":        radiusKm * 1000,
+			"spherical":          true,
+			"query":              publicEventFilter(),
+		}}},
+	}
+
+	cursor, err := s.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	if err = cursor.All(ctx, &events); err != nil {
+		return nil, err
+	}
+
+	if events == nil {
+		events = []models.EventWithDistance{}
+	}
+
+	return events, nil
+}
+
+// GetPopularTags returns the most used tags across events, most used first
+func (s *EventStore) GetPopularTags(ctx context.Context, limit int) ([]models.TagCount, error) {
+	var tags []models.TagCount
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: publicEventFilter()}},
+		{{Key: "$unwind", Value: "$tags"}},
+		{{Key: "$group", Value: bson.M{"_id": "$tags", "count": bson.M{"$sum": 1}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}}},
+		{{Key: "$limit", Value: limit}},
+	}
+
+	cursor, err := s.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	if err = cursor.All(ctx, &tags); err != nil {
+		return nil, err
+	}
+
+	if tags == nil {
+		tags = []models.TagCount{}
+	}
+
+	return tags, nil
+}
+
+// ! SetHostSuspended hides (or shows again) every event of a host and returns the events that changed
+func (s *EventStore) SetHostSuspended(ctx context.Context, hostID bson.ObjectID, suspended bool) ([]models.Event, error) {
+	filter := bson.M{"host_id": hostID, "host_suspended": bson.M{"$ne": true}}
+	update := bson.M{"$set": bson.M{"host_suspended": true, "updated_at": time.Now()}}
+	if !suspended {
+		filter = bson.M{"host_id": hostID, "host_suspended": true}
+		update = bson.M{"$unset": bson.M{"host_suspended": ""}, "$set": bson.M{"updated_at": time.Now()}}
+	}
+
+	//? Read them first so the caller can tell the attendees which events are affected
+	cursor, err := s.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var events []models.Event
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, err
+	}
+
+	_, err = s.collection.UpdateMany(ctx, filter, update)
+	eventReadCache.invalidateAll()
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// CountUpcomingHostEvents counts the events of a host that haven't ended yet, drafts included and cancelled events left out
+func (s *EventStore) CountUpcomingHostEvents(ctx context.Context, hostID bson.ObjectID) (int64, error) {
+	return s.collection.CountDocuments(ctx, bson.M{
+		"host_id":  hostID,
+		"end_time": bson.M{"$gt": time.Now()},
+		"status":   bson.M{"$ne": models.EventStatusCancelled},
+	})
+}
+
+// ! SetHostVerified sets (or removes) the verified host badge on every event of a host and returns how many changed
+func (s *EventStore) SetHostVerified(ctx context.Context, hostID bson.ObjectID, verified bool) (int64, error) {
+	filter := bson.M{"host_id": hostID, "host_verified": bson.M{"$ne": true}}
+	update := bson.M{"$set": bson.M{"host_verified": true, "updated_at": time.Now()}}
+	if !verified {
+		filter = bson.M{"host_id": hostID, "host_verified": true}
+		update = bson.M{"$unset": bson.M{"host_verified": ""}, "$set": bson.M{"updated_at": time.Now()}}
+	}
+
+	result, err := s.collection.UpdateMany(ctx, filter, update)
+	eventReadCache.invalidateAll()
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+// ! GetEventsByOrg returns every event of an organization (drafts included), soonest first
+func (s *EventStore) GetEventsByOrg(ctx context.Context, orgID bson.ObjectID) ([]models.Event, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "start_time", Value: 1}})
+	cursor, err := s.collection.Find(ctx, bson.M{"org_id": orgID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	events := []models.Event{} //** Return empty slice
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// ! SetOrgStaff copies the managers and scanners of an organization onto all its events and returns how many changed
+func (s *EventStore) SetOrgStaff(ctx context.Context, orgID bson.ObjectID, managers, scanners []bson.ObjectID) (int64, error) {
+	update := bson.M{"$set": bson.M{"org_managers": managers, "org_scanners": scanners, "updated_at": time.Now()}}
+
+	result, err := s.collection.UpdateMany(ctx, bson.M{"org_id": orgID}, update)
+	eventReadCache.invalidateAll()
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+// EnsureOrgIndex creates the index the events of an organization are found with
+func (s *EventStore) EnsureOrgIndex(ctx context.Context) error {
+	_, err := s.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "org_id", Value: 1}, {Key: "start_time", Value: 1}},
+		Options: options.Index().SetName("org_id_start_time").SetSparse(true),
+	})
+	return err
+}
+
+// EnsureVenueIndex creates the index the events at a venue are found with
+func (s *EventStore) EnsureVenueIndex(ctx context.Context) error {
+	_, err := s.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "venue_id", Value: 1}, {Key: "start_time", Value: 1}},
+		Options: options.Index().SetName("venue_id_start_time").SetSparse(true),
+	})
+	return err
+}
+
+// CountUpcomingVenueEvents counts the events at a venue that haven't ended yet, drafts included and cancelled events left out
+func (s *EventStore) CountUpcomingVenueEvents(ctx context.Context, venueID bson.ObjectID) (int64, error) {
+	return s.collection.CountDocuments(ctx, bson.M{
+		"venue_id": venueID,
+		"end_time": bson.M{"$gt": time.Now()},
+		"status":   bson.M{"$ne": models.EventStatusCancelled},
+	})
+}
+
+// GetOverlappingVenueEvents returns the events at a venue that run at some point between from and to, except excludeID.
+// The host's own events count unless cancelled or rejected, other hosts' events once they are public
+func (s *EventStore) GetOverlappingVenueEvents(ctx context.Context, venueID, hostID, excludeID bson.ObjectID, from, to time.Time) ([]models.Event, error) {
+	filter := bson.M{
+		"venue_id":   venueID,
+		"_id":        bson.M{"$ne": excludeID},
+		"start_time": bson.M{"$lt": to}, //? venue_id + start_time index, the end bounds the rest
+		"end_time":   bson.M{"$gt": from},
+		"$or": bson.A{
+			bson.M{
+				"host_id": hostID,
+				"status":  bson.M{"$nin": []string{models.EventStatusCancelled, models.EventStatusRejected}},
+			},
+			publicEventFilter(), //! Other hosts' drafts stay private
+		},
+	}
+	opts := options.Find().
+		SetProjection(bson.M{"name": 1, "host_id": 1, "start_time": 1, "end_time": 1}).
+		SetSort(bson.D{{Key: "start_time", Value: 1}}).
+		SetLimit(50)
+
+	cursor, err := s.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var events []models.Event
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// SetVenueLocation copies a venue's new location onto the events at it that haven't ended and returns them
+func (s *EventStore) SetVenueLocation(ctx context.Context, venue *models.Venue) ([]models.Event, error) {
+	filter := bson.M{"venue_id": venue.ID, "end_time": bson.M{"$gt": time.Now()}}
+	update := bson.M{"$set": bson.M{
+		"location":     venue.Location(),
+		"geo_location": venue.GeoLocation,
+		"updated_at":   time.Now(),
+	}}
+
+	_, err := s.collection.UpdateMany(ctx, filter, update)
+	eventReadCache.invalidateAll()
+	if err != nil {
+		return nil, err
+	}
+
+	//? Read them back for the search index
+	cursor, err := s.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var events []models.Event
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// searchFacetCount is one value of a search facet with its number of events
+type searchFacetCount struct {
+	Value string `bson:"_id"`
+	Count int    `bson:"count"`
+}
+
+// facetMap turns facet counts into a map
+func facetMap(counts []searchFacetCount) map[string]int {
+	facet := make(map[string]int, len(counts))
+	for _, count := range counts {
+		facet[count.Value] = count.Count
+	}
+	return facet
+}
+
+// priceRangeSwitch labels a price with its models.PriceRanges bucket inside an aggregation
+func priceRangeSwitch(price string) bson.M {
+	branches := bson.A{}
+	for _, bucket := range models.PriceRanges[:len(models.PriceRanges)-1] {
+		branches = append(branches, bson.M{"case": bson.M{"$lt": bson.A{price, bucket.Below}}, "then": bucket.Label})
+	}
+	return bson.M{"$switch": bson.M{"branches": branches, "default": models.PriceRanges[len(models.PriceRanges)-1].Label}}
+}
+
+// ! SearchEvents searches the public events with case-insensitive substring matches, for deployments without a search engine
+func (s *EventStore) SearchEvents(ctx context.Context, query models.EventSearchQuery) (*models.EventSearchResult, error) {
+	filter := publicEventFilter()
+	if query.Text != "" {
+		pattern := bson.M{"$regex": regexp.QuoteMeta(query.Text), "$options": "i"}
+		filter["$or"] = bson.A{
+			bson.M{"name": pattern},
+			bson.M{"description": pattern},
+			bson.M{"tags": pattern},
+			bson.M{"location": pattern},
+			bson.M{"category_name": pattern},
+		}
+	}
+	if query.Category != "" {
+		filter["category_name"] = query.Category
+	}
+	startTime := bson.M{}
+	if !query.From.IsZero() {
+		startTime["$gte"] = query.From
+	}
+	if !query.To.IsZero() {
+		startTime["$lt"] = query.To
+	}
+	if len(startTime) > 0 {
+		filter["start_time"] = startTime
+	}
+
+	//? The price filter works on the cheapest ticket, which only exists after $addFields
+	priceFilter := bson.M{}
+	if query.MinPrice != nil {
+		priceFilter["$gte"] = *query.MinPrice
+	}
+	if query.MaxPrice != nil {
+		priceFilter["$lte"] = *query.MaxPrice
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$addFields", Value: bson.M{"min_price": bson.M{"$ifNull": bson.A{bson.M{"$min": "$tickets.price"}, 0}}}}},
+	}
+	if len(priceFilter) > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.M{"min_price": priceFilter}}})
+	}
+	pipeline = append(pipeline, bson.D{{Key: "$facet", Value: bson.M{
+		"hits": bson.A{
+			bson.M{"$sort": bson.D{{Key: "start_time", Value: 1}, {Key: "_id", Value: 1}}},
+			bson.M{"$skip": query.Offset},
+			bson.M{"$limit": query.Limit},
+			bson.M{"$project": bson.M{
+				"_id": bson.M{"$toString": "$_id"}, "name": 1, "category_name": 1, "tags": 1, "location": 1,
+				"event_type": 1, "image_url": 1, "start_time": 1, "end_time": 1, "timezone": 1, "min_price": 1, "currency": 1,
+			}},
+		},
+		"total":      bson.A{bson.M{"$count": "count"}},
+		"categories": bson.A{bson.M{"$group": bson.M{"_id": "$category_name", "count": bson.M{"$sum": 1}}}},
+		"months": bson.A{bson.M{"$group": bson.M{
+			"_id":   bson.M{"$dateToString": bson.M{"format": "%Y-%m", "date": "$start_time"}},
+			"count": bson.M{"$sum": 1},
+		}}},
+		"prices": bson.A{bson.M{"$group": bson.M{"_id": priceRangeSwitch("$min_price"), "count": bson.M{"$sum": 1}}}},
+	}}})
+
+	cursor, err := s.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var facets []struct {
+		Hits       []models.EventSearchHit `bson:"hits"`
+		Total      []searchFacetCount      `bson:"total"`
+		Categories []searchFacetCount      `bson:"categories"`
+		Months     []searchFacetCount      `bson:"months"`
+		Prices     []searchFacetCount      `bson:"prices"`
+	}
+	if err := cursor.All(ctx, &facets); err != nil {
+		return nil, err
+	}
+
+	result := &models.EventSearchResult{Hits: []models.EventSearchHit{}}
+	if len(facets) == 0 {
+		return result, nil
+	}
+	if facets[0].Hits != nil {
+		result.Hits = facets[0].Hits
+	}
+	if len(facets[0].Total) > 0 {
+		result.Total = int64(facets[0].Total[0].Count)
+	}
+	result.Facets = models.EventSearchFacets{
+		Categories:  facetMap(facets[0].Categories),
+		Months:      facetMap(facets[0].Months),
+		PriceRanges: facetMap(facets[0].Prices),
+	}
+
+	return result, nil
+}
+
+// FilterPublicEvents returns which of the events can still be shown publicly (search indexes can lag behind)
+func (s *EventStore) FilterPublicEvents(ctx context.Context, ids []bson.ObjectID) (map[bson.ObjectID]bool, error) {
+	filter := publicEventFilter()
+	filter["_id"] = bson.M{"$in": ids}
+
+	cursor, err := s.collection.Find(ctx, filter, options.Find().SetProjection(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var found []struct {
+		ID bson.ObjectID `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &found); err != nil {
+		return nil, err
+	}
+
+	public := make(map[bson.ObjectID]bool, len(found))
+	for _, event := range found {
+		public[event.ID] = true
+	}
+	return public, nil
+}
+
+// MarkCapacityAlertSent records that a capacity alert went out, false if it was already sent (e.g. by a parallel booking)
+func (s *EventStore) MarkCapacityAlertSent(ctx context.Context, eventID bson.ObjectID, ticketType string, threshold int) (bool, error) {
+	field := "capacity_alerts_sent." + ticketType
+	filter := bson.M{"_id": eventID, field: bson.M{"$ne": threshold}}
+	update := bson.M{"$addToSet": bson.M{field: threshold}}
+
+	result, err := s.collection.UpdateOne(ctx, filter, update)
+	eventReadCache.invalidate(eventID)
+	if err != nil {
+		return false, err
+	}
+
+	return result.ModifiedCount == 1, nil
+}
@@ -0,0 +1,147 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"event-horizon/models"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+/******************** MONGODB FUNCTIONALITY FOR FOLLOWS COLLECTION ********************
+
+1. BSON MAPPING FOR FOLLOWS COLLECTION
+2. InsertOne
+3. FindOne
+4. Find
+5. DeleteOne
+
+ ****************************************************************************************/
+
+/************************** I DID THE FOLLOWING THINGS IN THIS FILE: *******************************************
+
+1. Created FollowStore struct to manage follow relationships between users and hosts.
+
+2. Implemented NewFollowStore constructor to initialize FollowStore with MongoDB collection.
+
+3. Developed FollowHost method to add a follow, ensuring a user cannot follow the same host twice.
+
+4. Created UnfollowHost method to remove a follow.
+
+5. Added GetFollowerIDs method to fetch the IDs of every user following a host.
+
+6. Implemented GetFollowing method to list the hosts a user follows.
+
+7. Added DeleteFollowsOfUser for deleted accounts (the hosts they follow and their own followers).
+
+************************************************************************************************************/
+
+type FollowStore struct {
+	collection *mongo.Collection
+}
+
+func NewFollowStore(db *mongo.Database) *FollowStore {
+	return &FollowStore{
+		collection: db.Collection("Follows"),
+	}
+}
+
+// FollowHost creates a follow from a user to a host
+func (s *FollowStore) FollowHost(ctx context.Context, follow *models.Follow) error {
+	//? Check for an existing follow
+	filter := bson.M{"follower_id": follow.FollowerID, "host_id": follow.HostID}
+
+	var existingFollow models.Follow
+	err := s.collection.FindOne(ctx, filter).Decode(&existingFollow)
+	if err == nil {
+		return errors.New("already following this host")
+	}
+
+	//? If the error is not ErrNoDocuments, return the error
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		return err
+	}
+
+	follow.CreatedAt = time.Now()
+
+	result, err := s.collection.InsertOne(ctx, follow)
+	if err != nil {
+		return err
+	}
+
+	follow.ID = result.InsertedID.(bson.ObjectID)
+	return nil
+}
+
+// UnfollowHost removes a follow from a user to a host
+func (s *FollowStore) UnfollowHost(ctx context.Context, followerID, hostID bson.ObjectID) error {
+	filter := bson.M{"follower_id": followerID, "host_id": hostID}
+
+	result, err := s.collection.DeleteOne(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return errors.New("not following this host")
+	}
+
+	return nil
+}
+
+// DeleteFollowsOfUser removes every follow from and to a user and returns how many were removed
+func (s *FollowStore) DeleteFollowsOfUser(ctx context.Context, userID bson.ObjectID) (int64, error) {
+	result, err := s.collection.DeleteMany(ctx, bson.M{"$or": bson.A{
+		bson.M{"follower_id": userID},
+		bson.M{"host_id": userID},
+	}})
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
+
+// GetFollowerIDs returns the IDs of all users following a host
+func (s *FollowStore) GetFollowerIDs(ctx context.Context, hostID bson.ObjectID) ([]bson.ObjectID, error) {
+	var follows []models.Follow
+
+	cursor, err := s.collection.Find(ctx, bson.M{"host_id": hostID})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	if err = cursor.All(ctx, &follows); err != nil {
+		return nil, err
+	}
+
+	followerIDs := make([]bson.ObjectID, 0, len(follows))
+	for _, follow := range follows {
+		followerIDs = append(followerIDs, follow.FollowerID)
+	}
+
+	return followerIDs, nil
+}
+
+// GetFollowing returns all follows made by a user
+func (s *FollowStore) GetFollowing(ctx context.Context, followerID bson.ObjectID) ([]models.Follow, error) {
+	var follows []models.Follow
+
+	cursor, err := s.collection.Find(ctx, bson.M{"follower_id": followerID})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	if err = cursor.All(ctx, &follows); err != nil {
+		return nil, err
+	}
+
+	if follows == nil {
+		follows = []models.Follow{}
+	}
+
+	return follows, nil
+}
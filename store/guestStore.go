@@ -0,0 +1,80 @@
+package store
+
+import (
+	"context"
+	"event-horizon/models"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+/******************** MONGODB FUNCTIONALITY FOR GUESTS COLLECTION ********************
+
+1. BSON MAPPING FOR GUESTS COLLECTION
+2. FindOneAndUpdate (upsert)
+3. FindOne
+4. UpdateOne
+
+ ****************************************************************************************/
+
+/************************** I DID THE FOLLOWING THINGS IN THIS FILE: *******************************************
+
+1. Created GuestStore struct for people who book without an account (GUEST CHECKOUT).
+
+2. Implemented FindOrCreateGuest so every email has one unclaimed guest record, its name is the one of the first booking
+   (anyone can book with any email, so a later booking never renames the guest).
+
+3. Added GetGuestByID and MarkGuestClaimed, a claimed guest belongs to an account and gets a new record on the next guest booking.
+
+************************************************************************************************************/
+
+type GuestStore struct {
+	collection *mongo.Collection
+}
+
+func NewGuestStore(db *mongo.Database) *GuestStore {
+	return &GuestStore{
+		collection: db.Collection("Guests"),
+	}
+}
+
+// FindOrCreateGuest returns the unclaimed guest record of an email, creating it if needed
+func (s *GuestStore) FindOrCreateGuest(ctx context.Context, name, email string) (*models.Guest, error) {
+	filter := bson.M{"email": email, "claimed_by": bson.M{"$exists": false}}
+	update := bson.M{
+		"$setOnInsert": bson.M{"name": name, "email": email, "created_at": time.Now()},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var guest models.Guest
+	if err := s.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&guest); err != nil {
+		return nil, err
+	}
+
+	return &guest, nil
+}
+
+// GetGuestByID retrieves a guest by its ID
+func (s *GuestStore) GetGuestByID(ctx context.Context, guestID bson.ObjectID) (*models.Guest, error) {
+	var guest models.Guest
+	if err := s.collection.FindOne(ctx, bson.M{"_id": guestID}).Decode(&guest); err != nil {
+		return nil, err
+	}
+
+	return &guest, nil
+}
+
+// MarkGuestClaimed records that the guest's bookings moved to an account, false if someone claimed it first
+func (s *GuestStore) MarkGuestClaimed(ctx context.Context, guestID, userID bson.ObjectID) (bool, error) {
+	filter := bson.M{"_id": guestID, "claimed_by": bson.M{"$exists": false}}
+	update := bson.M{"$set": bson.M{"claimed_by": userID, "claimed_at": time.Now()}}
+
+	result, err := s.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+
+	return result.ModifiedCount == 1, nil
+}
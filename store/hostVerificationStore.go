@@ -0,0 +1,187 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"event-horizon/models"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+/******************** MONGODB FUNCTIONALITY FOR HOST VERIFICATIONS COLLECTION ********************
+
+1. BSON MAPPING FOR HOSTVERIFICATIONS COLLECTION
+2. InsertOne
+3. FindOne / Find with Sort
+4. FindOneAndUpdate on the pending status, so a submission is only reviewed once
+5. Partial unique index, one pending submission per host
+6. DeleteMany
+
+ ****************************************************************************************/
+
+/************************** I DID THE FOLLOWING THINGS IN THIS FILE: *******************************************
+
+1. Created HostVerificationStore struct for the KYC submissions of hosts.
+
+2. Developed CreateVerification method, a host can only have one pending submission (ErrVerificationPending).
+
+3. Added GetLatestVerification, GetVerificationByID and ListVerifications methods.
+
+4. Implemented ReviewVerification method, only pending submissions can be approved or rejected (ErrVerificationReviewed).
+
+5. Created EnsureHostVerificationIndexes method for the pending index and the review queue.
+
+6. Added GetVerificationsByHost and DeleteVerificationsOfHost for the account EXPORT and deleted accounts (legal names, documents, payout accounts).
+
+************************************************************************************************************/
+
+// ErrVerificationPending is returned when the host already has a submission waiting for review
+var ErrVerificationPending = errors.New("a verification is already waiting for review")
+
+// ErrVerificationReviewed is returned when reviewing a submission that is no longer pending
+var ErrVerificationReviewed = errors.New("the verification was already reviewed")
+
+type HostVerificationStore struct {
+	collection *mongo.Collection
+}
+
+func NewHostVerificationStore(db *mongo.Database) *HostVerificationStore {
+	return &HostVerificationStore{
+		collection: db.Collection("HostVerifications"),
+	}
+}
+
+// CreateVerification stores a pending submission, ErrVerificationPending when the host already has one
+func (s *HostVerificationStore) CreateVerification(ctx context.Context, verification *models.HostVerification) error {
+	verification.Status = models.VerificationPending
+	verification.SubmittedAt = time.Now()
+
+	result, err := s.collection.InsertOne(ctx, verification)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return ErrVerificationPending
+		}
+		return err
+	}
+
+	verification.ID = result.InsertedID.(bson.ObjectID)
+	return nil
+}
+
+// GetLatestVerification returns the host's newest submission, mongo.ErrNoDocuments when there is none
+func (s *HostVerificationStore) GetLatestVerification(ctx context.Context, hostID bson.ObjectID) (*models.HostVerification, error) {
+	opts := options.FindOne().SetSort(bson.D{{Key: "submitted_at", Value: -1}})
+
+	var verification models.HostVerification
+	if err := s.collection.FindOne(ctx, bson.M{"host_id": hostID}, opts).Decode(&verification); err != nil {
+		return nil, err
+	}
+	return &verification, nil
+}
+
+// GetVerificationByID returns one submission
+func (s *HostVerificationStore) GetVerificationByID(ctx context.Context, id bson.ObjectID) (*models.HostVerification, error) {
+	var verification models.HostVerification
+	if err := s.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&verification); err != nil {
+		return nil, err
+	}
+	return &verification, nil
+}
+
+// ListVerifications returns the submissions with the status (every status when empty), oldest first like a queue
+func (s *HostVerificationStore) ListVerifications(ctx context.Context, status string, limit int64) ([]models.HostVerification, error) {
+	filter := bson.M{}
+	if status != "" {
+		filter["status"] = status
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "submitted_at", Value: 1}}).SetLimit(limit)
+
+	cursor, err := s.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	verifications := []models.HostVerification{} //** Return empty slice
+	if err := cursor.All(ctx, &verifications); err != nil {
+		return nil, err
+	}
+	return verifications, nil
+}
+
+// ReviewVerification approves or rejects a pending submission and returns it reviewed, ErrVerificationReviewed when it isn't pending
+func (s *HostVerificationStore) ReviewVerification(ctx context.Context, id, reviewerID bson.ObjectID, approved bool, note string) (*models.HostVerification, error) {
+	status := models.VerificationRejected
+	if approved {
+		status = models.VerificationApproved
+	}
+
+	filter := bson.M{"_id": id, "status": models.VerificationPending}
+	update := bson.M{"$set": bson.M{
+		"status":      status,
+		"review_note": note,
+		"reviewed_by": reviewerID,
+		"reviewed_at": time.Now(),
+	}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var verification models.HostVerification
+	if err := s.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&verification); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrVerificationReviewed
+		}
+		return nil, err
+	}
+	return &verification, nil
+}
+
+// GetVerificationsByHost returns every submission of the host, newest first
+func (s *HostVerificationStore) GetVerificationsByHost(ctx context.Context, hostID bson.ObjectID) ([]models.HostVerification, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "submitted_at", Value: -1}})
+
+	cursor, err := s.collection.Find(ctx, bson.M{"host_id": hostID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	verifications := []models.HostVerification{} //** Return empty slice
+	if err := cursor.All(ctx, &verifications); err != nil {
+		return nil, err
+	}
+	return verifications, nil
+}
+
+// DeleteVerificationsOfHost removes every submission of the host and returns how many were removed
+func (s *HostVerificationStore) DeleteVerificationsOfHost(ctx context.Context, hostID bson.ObjectID) (int64, error) {
+	result, err := s.collection.DeleteMany(ctx, bson.M{"host_id": hostID})
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
+
+// EnsureHostVerificationIndexes creates the one-pending-per-host index and the index of the review queue
+func (s *HostVerificationStore) EnsureHostVerificationIndexes(ctx context.Context) error {
+	_, err := s.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{{Key: "host_id", Value: 1}},
+			Options: options.Index().
+				SetName("host_id_pending_unique").
+				SetUnique(true).
+				SetPartialFilterExpression(bson.M{"status": models.VerificationPending}),
+		},
+		{
+			Keys:    bson.D{{Key: "host_id", Value: 1}, {Key: "submitted_at", Value: -1}},
+			Options: options.Index().SetName("host_id_submitted_at"),
+		},
+		{
+			Keys:    bson.D{{Key: "status", Value: 1}, {Key: "submitted_at", Value: 1}},
+			Options: options.Index().SetName("status_submitted_at"),
+		},
+	})
+	return err
+}
@@ -0,0 +1,213 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"event-horizon/models"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+/******************** MONGODB FUNCTIONALITY FOR JOBS COLLECTION ********************
+
+1. BSON MAPPING FOR JOBS COLLECTION
+2. InsertOne
+3. FindOneAndUpdate with Sort (claiming a job)
+4. UpdateOne with a status guard
+5. Compound index and TTL index with a partial filter
+
+ ****************************************************************************************/
+
+/************************** I DID THE FOLLOWING THINGS IN THIS FILE: *******************************************
+
+1. Created JobStore struct, the persistent queue of background jobs (emails, commissions ...).
+
+2. Developed EnqueueJob method to add a pending job.
+
+3. Implemented ClaimJob method: one worker atomically takes the oldest due job, jobs of a worker that died are taken again once their lock expires.
+
+4. Added CompleteJob and FailJob methods, a failed job goes back to pending with a later run_at or is dead-lettered.
+
+5. Added ListJobs and RetryJob methods for the admin view of failed jobs.
+
+6. Created EnsureJobIndexes method for the claim query, finished jobs expire after a week.
+
+************************************************************************************************************/
+
+// doneJobRetention is how long finished jobs are kept, dead jobs stay until an admin retries them
+const doneJobRetention = 7 * 24 * time.Hour
+
+// ErrJobNotDead is returned when retrying a job that didn't fail for good
+var ErrJobNotDead = errors.New("only dead jobs can be retried")
+
+type JobStore struct {
+	collection *mongo.Collection
+}
+
+func NewJobStore(db *mongo.Database) *JobStore {
+	return &JobStore{
+		collection: db.Collection("Jobs"),
+	}
+}
+
+// EnqueueJob stores a new pending job that runs at job.RunAt (now when zero)
+func (s *JobStore) EnqueueJob(ctx context.Context, job *models.Job) error {
+	now := time.Now()
+	job.Status = models.JobPending
+	job.Attempts = 0
+	job.CreatedAt = now
+	job.UpdatedAt = now
+	if job.RunAt.IsZero() {
+		job.RunAt = now
+	}
+
+	result, err := s.collection.InsertOne(ctx, job)
+	if err != nil {
+		return err
+	}
+
+	job.ID = result.InsertedID.(bson.ObjectID)
+	return nil
+}
+
+// ClaimJob locks the oldest due job for lease and counts the attempt, nil when there is nothing to do
+func (s *JobStore) ClaimJob(ctx context.Context, lease time.Duration) (*models.Job, error) {
+	now := time.Now()
+	filter := bson.M{"$or": bson.A{
+		bson.M{"status": models.JobPending, "run_at": bson.M{"$lte": now}},
+		//? The worker running it died, its lock expired
+		bson.M{"status": models.JobRunning, "locked_until": bson.M{"$lt": now}},
+	}}
+	update := bson.M{
+		"$set": bson.M{"status": models.JobRunning, "locked_until": now.Add(lease), "updated_at": now},
+		"$inc": bson.M{"attempts": 1},
+	}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "run_at", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var job models.Job
+	err := s.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&job)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// CompleteJob marks a claimed job as done
+func (s *JobStore) CompleteJob(ctx context.Context, id bson.ObjectID) error {
+	now := time.Now()
+	_, err := s.collection.UpdateOne(ctx,
+		bson.M{"_id": id, "status": models.JobRunning},
+		bson.M{
+			"$set":   bson.M{"status": models.JobDone, "finished_at": now, "updated_at": now},
+			"$unset": bson.M{"locked_until": "", "last_error": ""},
+		},
+	)
+	return err
+}
+
+// FailJob records the error of a claimed job, it runs again at retryAt or is dead when retryAt is nil
+func (s *JobStore) FailJob(ctx context.Context, id bson.ObjectID, jobErr string, retryAt *time.Time) error {
+	now := time.Now()
+	set := bson.M{"last_error": jobErr, "updated_at": now}
+	if retryAt != nil {
+		set["status"] = models.JobPending
+		set["run_at"] = *retryAt
+	} else {
+		set["status"] = models.JobDead
+		set["finished_at"] = now
+	}
+
+	_, err := s.collection.UpdateOne(ctx,
+		bson.M{"_id": id, "status": models.JobRunning},
+		bson.M{"$set": set, "$unset": bson.M{"locked_until": ""}},
+	)
+	return err
+}
+
+// ListJobs returns the jobs with the given status (every status when empty), most recently changed first
+func (s *JobStore) ListJobs(ctx context.Context, status string, limit int) ([]models.Job, error) {
+	filter := bson.M{}
+	if status != "" {
+		filter["status"] = status
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "updated_at", Value: -1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := s.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	jobs := []models.Job{}
+	if err = cursor.All(ctx, &jobs); err != nil {
+		return nil, err
+	}
+
+	//? Payloads are shown as (relaxed extended) JSON
+	for i := range jobs {
+		if len(jobs[i].Payload) > 0 {
+			jobs[i].PayloadJSON = json.RawMessage(jobs[i].Payload.String())
+		}
+	}
+	return jobs, nil
+}
+
+// RetryJob puts a dead job back in the queue with a fresh set of attempts
+func (s *JobStore) RetryJob(ctx context.Context, id bson.ObjectID) (*models.Job, error) {
+	now := time.Now()
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var job models.Job
+	err := s.collection.FindOneAndUpdate(ctx,
+		bson.M{"_id": id, "status": models.JobDead},
+		bson.M{
+			"$set":   bson.M{"status": models.JobPending, "attempts": 0, "run_at": now, "updated_at": now},
+			"$unset": bson.M{"finished_at": ""},
+		},
+		opts,
+	).Decode(&job)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		if count, countErr := s.collection.CountDocuments(ctx, bson.M{"_id": id}); countErr == nil && count > 0 {
+			return nil, ErrJobNotDead
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// EnsureJobIndexes indexes the claim query and expires finished jobs after doneJobRetention
+func (s *JobStore) EnsureJobIndexes(ctx context.Context) error {
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "status", Value: 1}, {Key: "run_at", Value: 1}},
+			Options: options.Index().SetName("status_run_at"),
+		},
+		{
+			Keys:    bson.D{{Key: "status", Value: 1}, {Key: "updated_at", Value: -1}},
+			Options: options.Index().SetName("status_updated_at"),
+		},
+		{
+			Keys: bson.D{{Key: "finished_at", Value: 1}},
+			Options: options.Index().
+				SetName("done_ttl").
+				SetExpireAfterSeconds(int32(doneJobRetention.Seconds())).
+				SetPartialFilterExpression(bson.M{"status": models.JobDone}),
+		},
+	}
+
+	_, err := s.collection.Indexes().CreateMany(ctx, indexes)
+	return err
+}
@@ -0,0 +1,110 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"event-horizon/models"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+/******************** MONGODB FUNCTIONALITY FOR NOTIFICATIONS COLLECTION ********************
+
+1. BSON MAPPING FOR NOTIFICATIONS COLLECTION
+2. InsertMany
+3. Find (sorted newest first)
+4. UpdateOne
+
+ ****************************************************************************************/
+
+/************************** I DID THE FOLLOWING THINGS IN THIS FILE: *******************************************
+
+1. Created NotificationStore struct to manage in-app notifications.
+
+2. Implemented NewNotificationStore constructor to initialize NotificationStore with MongoDB collection.
+
+3. Developed CreateNotifications method to insert a batch of notifications at once.
+
+4. Created GetNotificationsByUserID method to list a user's notifications (newest first).
+
+5. Added MarkAsRead method so a user can mark one of their notifications as read.
+
+6. Added DeleteNotificationsByUserID for deleted accounts.
+
+************************************************************************************************************/
+
+type NotificationStore struct {
+	collection *mongo.Collection
+}
+
+func NewNotificationStore(db *mongo.Database) *NotificationStore {
+	return &NotificationStore{
+		collection: db.Collection("Notifications"),
+	}
+}
+
+// CreateNotifications inserts a batch of notifications
+func (s *NotificationStore) CreateNotifications(ctx context.Context, notifications []models.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	for i := range notifications {
+		notifications[i].CreatedAt = now
+	}
+
+	_, err := s.collection.InsertMany(ctx, notifications)
+	return err
+}
+
+// GetNotificationsByUserID retrieves all notifications for a user, newest first
+func (s *NotificationStore) GetNotificationsByUserID(ctx context.Context, userID bson.ObjectID) ([]models.Notification, error) {
+	var notifications []models.Notification
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := s.collection.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	if err = cursor.All(ctx, &notifications); err != nil {
+		return nil, err
+	}
+
+	if notifications == nil {
+		notifications = []models.Notification{}
+	}
+
+	return notifications, nil
+}
+
+// MarkAsRead marks a notification as read (only if it belongs to the user)
+func (s *NotificationStore) MarkAsRead(ctx context.Context, notificationID, userID bson.ObjectID) error {
+	filter := bson.M{"_id": notificationID, "user_id": userID}
+	update := bson.M{"$set": bson.M{"read": true}}
+
+	result, err := s.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return errors.New("notification not found")
+	}
+
+	return nil
+}
+
+// DeleteNotificationsByUserID deletes every notification of a user and returns how many were deleted
+func (s *NotificationStore) DeleteNotificationsByUserID(ctx context.Context, userID bson.ObjectID) (int64, error) {
+	result, err := s.collection.DeleteMany(ctx, bson.M{"user_id": userID})
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
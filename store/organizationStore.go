@@ -0,0 +1,138 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"event-horizon/models"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+/******************** MONGODB FUNCTIONALITY FOR ORGANIZATIONS COLLECTION ********************
+
+1. BSON MAPPING FOR ORGANIZATIONS COLLECTION
+2. InsertOne
+3. FindOne / Find on members.user_id
+4. FindOneAndUpdate with $push / $pull / positional $ on the embedded members
+5. Multikey index on members.user_id
+
+ ****************************************************************************************/
+
+/************************** I DID THE FOLLOWING THINGS IN THIS FILE: *******************************************
+
+1. Created OrganizationStore struct for team accounts, the members are embedded in the organization.
+
+2. Developed CreateOrganization, GetOrganizationByID and GetOrganizationsForUser methods.
+
+3. Implemented AddMember, SetMemberRole and RemoveMember methods, each returns the organization as it is afterwards.
+
+4. Created EnsureOrganizationIndexes method so the organizations of a user are found by index.
+
+************************************************************************************************************/
+
+// ErrAlreadyMember is returned when adding a user that is already a member
+var ErrAlreadyMember = errors.New("user is already a member of the organization")
+
+// ErrNotMember is returned when changing a user that is not a member
+var ErrNotMember = errors.New("user is not a member of the organization")
+
+type OrganizationStore struct {
+	collection *mongo.Collection
+}
+
+func NewOrganizationStore(db *mongo.Database) *OrganizationStore {
+	return &OrganizationStore{
+		collection: db.Collection("Organizations"),
+	}
+}
+
+// CreateOrganization stores a new organization with its members
+func (s *OrganizationStore) CreateOrganization(ctx context.Context, org *models.Organization) error {
+	org.CreatedAt = time.Now()
+	org.UpdatedAt = org.CreatedAt
+
+	result, err := s.collection.InsertOne(ctx, org)
+	if err != nil {
+		return err
+	}
+
+	org.ID = result.InsertedID.(bson.ObjectID)
+	return nil
+}
+
+// GetOrganizationByID returns one organization
+func (s *OrganizationStore) GetOrganizationByID(ctx context.Context, id bson.ObjectID) (*models.Organization, error) {
+	var org models.Organization
+	if err := s.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&org); err != nil {
+		return nil, err
+	}
+	return &org, nil
+}
+
+// GetOrganizationsForUser returns the organizations the user is a member of, by name
+func (s *OrganizationStore) GetOrganizationsForUser(ctx context.Context, userID bson.ObjectID) ([]models.Organization, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}})
+
+	cursor, err := s.collection.Find(ctx, bson.M{"members.user_id": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	orgs := []models.Organization{} //** Return empty slice
+	if err := cursor.All(ctx, &orgs); err != nil {
+		return nil, err
+	}
+	return orgs, nil
+}
+
+// updateMembers applies a members update to the organization and returns it afterwards, notFound when the filter missed
+func (s *OrganizationStore) updateMembers(ctx context.Context, filter, update bson.M, notFound error) (*models.Organization, error) {
+	set, _ := update["$set"].(bson.M)
+	if set == nil {
+		set = bson.M{}
+	}
+	set["updated_at"] = time.Now()
+	update["$set"] = set
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var org models.Organization
+	if err := s.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&org); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, notFound
+		}
+		return nil, err
+	}
+	return &org, nil
+}
+
+// AddMember adds a user with a role, ErrAlreadyMember when they already are one
+func (s *OrganizationStore) AddMember(ctx context.Context, orgID bson.ObjectID, member models.OrgMember) (*models.Organization, error) {
+	member.AddedAt = time.Now()
+	filter := bson.M{"_id": orgID, "members.user_id": bson.M{"$ne": member.UserID}}
+	return s.updateMembers(ctx, filter, bson.M{"$push": bson.M{"members": member}}, ErrAlreadyMember)
+}
+
+// SetMemberRole changes the role of a member, ErrNotMember when the user isn't one
+func (s *OrganizationStore) SetMemberRole(ctx context.Context, orgID, userID bson.ObjectID, role string) (*models.Organization, error) {
+	filter := bson.M{"_id": orgID, "members.user_id": userID}
+	return s.updateMembers(ctx, filter, bson.M{"$set": bson.M{"members.$.role": role}}, ErrNotMember)
+}
+
+// RemoveMember takes a user out of the organization, ErrNotMember when they aren't in it
+func (s *OrganizationStore) RemoveMember(ctx context.Context, orgID, userID bson.ObjectID) (*models.Organization, error) {
+	filter := bson.M{"_id": orgID, "members.user_id": userID}
+	return s.updateMembers(ctx, filter, bson.M{"$pull": bson.M{"members": bson.M{"user_id": userID}}}, ErrNotMember)
+}
+
+// EnsureOrganizationIndexes creates the index the organizations of a user are found with
+func (s *OrganizationStore) EnsureOrganizationIndexes(ctx context.Context) error {
+	_, err := s.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "members.user_id", Value: 1}},
+		Options: options.Index().SetName("members_user_id"),
+	})
+	return err
+}
@@ -0,0 +1,131 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"event-horizon/models"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+/******************** MONGODB FUNCTIONALITY FOR OUTBOX COLLECTION ********************
+
+1. BSON MAPPING FOR OUTBOX COLLECTION
+2. InsertOne inside another store's transaction
+3. FindOneAndUpdate with Sort (claiming a message)
+4. UpdateOne with a status guard
+5. Compound index and TTL index with a partial filter
+
+ ****************************************************************************************/
+
+/************************** I DID THE FOLLOWING THINGS IN THIS FILE: *******************************************
+
+1. Created OutboxStore struct, the TRANSACTIONAL OUTBOX of booking changes: the booking store writes a message in the same transaction as the booking, so a committed booking always has one.
+
+2. Implemented ClaimOutboxMessage method: the relay atomically takes the oldest due message, messages of a relay that died are taken again once their lock expires.
+
+3. Added MarkOutboxDelivered and FailOutboxMessage methods, a message that failed is tried again later, it is never dropped.
+
+4. Created EnsureOutboxIndexes method for the claim query, delivered messages expire after a week.
+
+************************************************************************************************************/
+
+// deliveredOutboxRetention is how long delivered messages are kept
+const deliveredOutboxRetention = 7 * 24 * time.Hour
+
+type OutboxStore struct {
+	collection *mongo.Collection
+}
+
+func NewOutboxStore(db *mongo.Database) *OutboxStore {
+	return &OutboxStore{
+		collection: db.Collection("Outbox"),
+	}
+}
+
+// add writes a pending message, ctx is the session context of the transaction it belongs to
+func (s *OutboxStore) add(ctx context.Context, messageType string, booking models.Booking) error {
+	now := time.Now()
+	_, err := s.collection.InsertOne(ctx, models.OutboxMessage{
+		Type:          messageType,
+		Booking:       booking,
+		Status:        models.OutboxPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	})
+	return err
+}
+
+// ClaimOutboxMessage locks the oldest due message for lease and counts the attempt, nil when there is nothing to deliver
+func (s *OutboxStore) ClaimOutboxMessage(ctx context.Context, lease time.Duration) (*models.OutboxMessage, error) {
+	now := time.Now()
+	filter := bson.M{"$or": bson.A{
+		bson.M{"status": models.OutboxPending, "next_attempt_at": bson.M{"$lte": now}},
+		//? The relay delivering it died, its lock expired
+		bson.M{"status": models.OutboxDelivering, "locked_until": bson.M{"$lt": now}},
+	}}
+	update := bson.M{
+		"$set": bson.M{"status": models.OutboxDelivering, "locked_until": now.Add(lease)},
+		"$inc": bson.M{"attempts": 1},
+	}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "next_attempt_at", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var message models.OutboxMessage
+	err := s.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&message)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &message, nil
+}
+
+// MarkOutboxDelivered marks a claimed message as delivered
+func (s *OutboxStore) MarkOutboxDelivered(ctx context.Context, id bson.ObjectID) error {
+	_, err := s.collection.UpdateOne(ctx,
+		bson.M{"_id": id, "status": models.OutboxDelivering},
+		bson.M{
+			"$set":   bson.M{"status": models.OutboxDelivered, "delivered_at": time.Now()},
+			"$unset": bson.M{"locked_until": "", "last_error": ""},
+		},
+	)
+	return err
+}
+
+// FailOutboxMessage records why a claimed message couldn't be delivered, it is tried again at retryAt
+func (s *OutboxStore) FailOutboxMessage(ctx context.Context, id bson.ObjectID, deliveryErr string, retryAt time.Time) error {
+	_, err := s.collection.UpdateOne(ctx,
+		bson.M{"_id": id, "status": models.OutboxDelivering},
+		bson.M{
+			"$set":   bson.M{"status": models.OutboxPending, "next_attempt_at": retryAt, "last_error": deliveryErr},
+			"$unset": bson.M{"locked_until": ""},
+		},
+	)
+	return err
+}
+
+// EnsureOutboxIndexes indexes the claim query and expires delivered messages after deliveredOutboxRetention
+func (s *OutboxStore) EnsureOutboxIndexes(ctx context.Context) error {
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "status", Value: 1}, {Key: "next_attempt_at", Value: 1}},
+			Options: options.Index().SetName("status_next_attempt_at"),
+		},
+		{
+			Keys: bson.D{{Key: "delivered_at", Value: 1}},
+			Options: options.Index().
+				SetName("delivered_ttl").
+				SetExpireAfterSeconds(int32(deliveredOutboxRetention.Seconds())).
+				SetPartialFilterExpression(bson.M{"status": models.OutboxDelivered}),
+		},
+	}
+
+	_, err := s.collection.Indexes().CreateMany(ctx, indexes)
+	return err
+}
@@ -0,0 +1,129 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"event-horizon/models"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+/******************** MONGODB FUNCTIONALITY FOR REPORTS COLLECTION ********************
+
+1. BSON MAPPING FOR REPORTS COLLECTION
+2. InsertOne
+3. CountDocuments
+4. $group / $lookup Aggregation
+5. Unique compound index
+
+ ****************************************************************************************/
+
+/************************** I DID THE FOLLOWING THINGS IN THIS FILE: *******************************************
+
+1. Created ReportStore struct to manage event reports (spam, scams ...).
+
+2. Developed CreateReport method to add a report, a user can only report an event once.
+
+3. Added CountReportsByEventID method to count the reports of an event.
+
+4. Implemented GetReportSummaries method to aggregate report counts per event (with the event) for admins.
+
+5. Created EnsureReportIndex method for the unique (event, reporter) index.
+
+************************************************************************************************************/
+
+type ReportStore struct {
+	collection *mongo.Collection
+}
+
+func NewReportStore(db *mongo.Database) *ReportStore {
+	return &ReportStore{
+		collection: db.Collection("Reports"),
+	}
+}
+
+// CreateReport stores a report, a user can report the same event only once
+func (s *ReportStore) CreateReport(ctx context.Context, report *models.Report) error {
+	report.CreatedAt = time.Now()
+
+	result, err := s.collection.InsertOne(ctx, report)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return errors.New("you already reported this event")
+		}
+		return err
+	}
+
+	report.ID = result.InsertedID.(bson.ObjectID)
+	return nil
+}
+
+// CountReportsByEventID returns how many users reported the event
+func (s *ReportStore) CountReportsByEventID(ctx context.Context, eventID bson.ObjectID) (int, error) {
+	count, err := s.collection.CountDocuments(ctx, bson.M{"event_id": eventID})
+	if err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+// GetReportSummaries returns the report count per event, most reported first
+func (s *ReportStore) GetReportSummaries(ctx context.Context, limit int) ([]models.ReportSummary, error) {
+	var summaries []models.ReportSummary
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$group", Value: bson.M{
+			"_id":              "$event_id",
+			"count":            bson.M{"$sum": 1},
+			"reasons":          bson.M{"$push": "$reason"},
+			"last_reported_at": bson.M{"$max": "$created_at"},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "count", Value: -1}, {Key: "last_reported_at", Value: -1}}}},
+		{{Key: "$limit", Value: limit}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         "Events",
+			"localField":   "_id",
+			"foreignField": "_id",
+			"as":           "event",
+		}}},
+		{{Key: "$unwind", Value: bson.M{"path": "$event", "preserveNullAndEmptyArrays": true}}},
+	}
+
+	cursor, err := s.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	if err = cursor.All(ctx, &summaries); err != nil {
+		return nil, err
+	}
+
+	//? Count each reason for the response
+	for i := range summaries {
+		summaries[i].Reasons = make(map[string]int)
+		for _, reason := range summaries[i].ReasonList {
+			summaries[i].Reasons[reason]++
+		}
+	}
+
+	if summaries == nil {
+		summaries = []models.ReportSummary{}
+	}
+
+	return summaries, nil
+}
+
+// EnsureReportIndex makes (event_id, reporter_id) unique so a user reports an event only once
+func (s *ReportStore) EnsureReportIndex(ctx context.Context) error {
+	index := mongo.IndexModel{
+		Keys:    bson.D{{Key: "event_id", Value: 1}, {Key: "reporter_id", Value: 1}},
+		Options: options.Index().SetName("event_reporter_unique").SetUnique(true),
+	}
+
+	_, err := s.collection.Indexes().CreateOne(ctx, index)
+	return err
+}
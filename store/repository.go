@@ -0,0 +1,309 @@
+package store
+
+import (
+	"context"
+	"event-horizon/models"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+/** *********************  REPOSITORY INTERFACES   ********************
+
+Controllers, middleware, background workers and the stores themselves
+depend on these interfaces instead of the concrete *Store types. Every
+dependency is passed in through a constructor, so any of them can be
+swapped for a mock in unit tests.
+
+Index setup (Ensure*), migrations and startup options (SetModeration,
+SetLocker) are only called from main, they stay on the concrete stores.
+
+ **************************************/
+
+// EventRepository reads and writes events
+type EventRepository interface {
+	CreateEvent(ctx context.Context, event *models.Event) error
+	GetAllEvents(ctx context.Context, tags []string) ([]*models.Event, error)
+	GetEventSummaries(ctx context.Context, tags []string, fields []string) ([]*models.EventSummary, error)
+	GetEventByID(ctx context.Context, id string) (*models.Event, error)
+	GetEventsByStatus(ctx context.Context, status string) ([]models.Event, error)
+	GetEventsNearby(ctx context.Context, lng, lat, radiusKm float64) ([]models.EventWithDistance, error)
+	GetPopularTags(ctx context.Context, limit int) ([]models.TagCount, error)
+	UpdateEvent(ctx context.Context, event *models.Event, expectedVersion int) error
+	PatchEvent(ctx context.Context, event *models.Event, fields []string, guard bson.M) error
+	DeleteEvent(ctx context.Context, id bson.ObjectID) error
+	DeleteExpiredEvents(ctx context.Context, policy CleanupPolicy) (int64, error)
+	GetExpiredEvents(ctx context.Context, policy CleanupPolicy, limit int) ([]models.ExpiredEvent, int64, error)
+	UniqueCopyName(ctx context.Context, hostID bson.ObjectID, name string) (string, error)
+	GetEventsStartingBetween(ctx context.Context, hostID bson.ObjectID, from, to time.Time) ([]models.Event, error)
+	GetSitemapEvents(ctx context.Context, limit int) ([]models.Event, error)
+	GetUpcomingEvents(ctx context.Context, query models.UpcomingEventsQuery) ([]models.Event, error)
+	PublishEvent(ctx context.Context, eventID bson.ObjectID) (string, error)
+	SetEventStatus(ctx context.Context, eventID bson.ObjectID, from []string, to string) error
+	ModerateEvent(ctx context.Context, eventID bson.ObjectID, approved bool, reason string) error
+	HideForReview(ctx context.Context, eventID bson.ObjectID) (bool, error)
+	InviteCoHost(ctx context.Context, eventID, userID bson.ObjectID) error
+	AcceptCoHostInvite(ctx context.Context, eventID, userID bson.ObjectID) error
+	RemoveCoHost(ctx context.Context, eventID, userID bson.ObjectID) error
+	SetHostSuspended(ctx context.Context, hostID bson.ObjectID, suspended bool) ([]models.Event, error)
+	SetHostVerified(ctx context.Context, hostID bson.ObjectID, verified bool) (int64, error)
+	GetEventsByOrg(ctx context.Context, orgID bson.ObjectID) ([]models.Event, error)
+	SetOrgStaff(ctx context.Context, orgID bson.ObjectID, managers, scanners []bson.ObjectID) (int64, error)
+	SearchEvents(ctx context.Context, query models.EventSearchQuery) (*models.EventSearchResult, error)
+	FilterPublicEvents(ctx context.Context, ids []bson.ObjectID) (map[bson.ObjectID]bool, error)
+	MarkCapacityAlertSent(ctx context.Context, eventID bson.ObjectID, ticketType string, threshold int) (bool, error)
+	SetPublishAt(ctx context.Context, eventID bson.ObjectID, publishAt *time.Time) error
+	GetScheduledEvents(ctx context.Context, due time.Time, limit int) ([]models.Event, error)
+	CountUpcomingHostEvents(ctx context.Context, hostID bson.ObjectID) (int64, error)
+	CountUpcomingVenueEvents(ctx context.Context, venueID bson.ObjectID) (int64, error)
+	GetArchivedEvents(ctx context.Context, query models.PastEventsQuery) ([]models.ArchivedEvent, error)
+	GetEndedEvents(ctx context.Context, query models.PastEventsQuery) ([]models.Event, error)
+	GetCalendarEvents(ctx context.Context, query models.CalendarQuery) ([]*models.EventSummary, error)
+	GetOverlappingVenueEvents(ctx context.Context, venueID, hostID, excludeID bson.ObjectID, from, to time.Time) ([]models.Event, error)
+	SetVenueLocation(ctx context.Context, venue *models.Venue) ([]models.Event, error)
+}
+
+// BookingRepository reads and writes bookings
+type BookingRepository interface {
+	CreateBooking(ctx context.Context, booking *models.Booking, maxPerBuyer int) error
+	GetBookingByID(ctx context.Context, bookingID string) (*models.Booking, error)
+	GetBookingsByUserID(ctx context.Context, userID bson.ObjectID) ([]models.Booking, error)
+	GetBookingsByEventID(ctx context.Context, eventID bson.ObjectID) ([]models.Booking, error)
+	ListBookings(ctx context.Context, bookingFilter BookingFilter) (bookings []models.BookingWithDetails, nextCursor string, err error)
+	GetWalletTickets(ctx context.Context, userID bson.ObjectID, limit int) ([]models.WalletTicket, error)
+	GetEventBookingsWithUsers(ctx context.Context, eventID bson.ObjectID) ([]models.BookingWithDetails, error)
+	CancelBooking(ctx context.Context, bookingID bson.ObjectID) error
+	ChangeTicketType(ctx context.Context, bookingID bson.ObjectID, ticketType string) (*models.Booking, *models.PriceAdjustment, error)
+	RequestReconfirmation(ctx context.Context, eventID bson.ObjectID, by time.Time) (int64, error)
+	Reconfirm(ctx context.Context, bookingID bson.ObjectID) (bool, error)
+	GetExpiredReconfirmations(ctx context.Context, due time.Time, limit int) ([]models.Booking, error)
+	DeleteBookingsByEventID(ctx context.Context, eventID bson.ObjectID) (int64, error)
+	HasConfirmedBooking(ctx context.Context, userID, eventID bson.ObjectID) (bool, error)
+	GetSoldTicketCounts(ctx context.Context, eventID bson.ObjectID) (map[string]int, error)
+	GetBookedSessionSeats(ctx context.Context, eventID bson.ObjectID) (map[bson.ObjectID]int, error)
+	GetTicketSales(ctx context.Context, eventID bson.ObjectID) ([]models.TicketTypeSales, error)
+	GetDailySales(ctx context.Context, eventID bson.ObjectID, timezone string) ([]models.DailySales, error)
+	ExportHostBookings(ctx context.Context, filter BookingExportFilter, fn func(models.BookingExportRow) error) error
+	GetBookingByGuestCode(ctx context.Context, codeHash string) (*models.Booking, error)
+	ClaimGuestBookings(ctx context.Context, guestID, userID bson.ObjectID) (int64, error)
+	UpdateAttendees(ctx context.Context, bookingID bson.ObjectID, attendees []models.Attendee) error
+	CheckInAttendee(ctx context.Context, eventID bson.ObjectID, code string, at time.Time) (*models.Booking, bool, error)
+	GetCheckInCounts(ctx context.Context, eventID bson.ObjectID) ([]models.TicketTypeCheckIns, error)
+	GetCoBookedEventCounts(ctx context.Context, userID bson.ObjectID, eventIDs []bson.ObjectID) (map[bson.ObjectID]int, error)
+	SetAffiliate(ctx context.Context, bookingID, affiliateID bson.ObjectID, commissionMinor int64) error
+	GetAffiliateSales(ctx context.Context, affiliateIDs []bson.ObjectID, from, to time.Time) ([]models.AffiliateSales, error)
+	AnonymizeUserBookings(ctx context.Context, userID bson.ObjectID) (int64, error)
+}
+
+// CategoryRepository reads and writes categories
+type CategoryRepository interface {
+	CreateCategory(ctx context.Context, category *models.Category) error
+	GetAllCategories(ctx context.Context) ([]models.Category, error)
+	GetAllCategoriesWithEvents(ctx context.Context) ([]models.CategoryWithEvents, error)
+	GetCategoryByID(ctx context.Context, categoryID bson.ObjectID) (*models.Category, error)
+	GetCategoryByName(ctx context.Context, categoryName string) (*models.Category, error)
+	GetCategoryBySlug(ctx context.Context, slug string) (*models.Category, error)
+	GetCategoryWithEvents(ctx context.Context, categoryID bson.ObjectID) (*models.CategoryWithEvents, error)
+	GetCategoryEventCount(ctx context.Context, categoryID bson.ObjectID) (int, error)
+	UpdateCategory(ctx context.Context, categoryID bson.ObjectID, updates bson.M) error
+	DeleteCategory(ctx context.Context, categoryID bson.ObjectID) error
+	DeleteCategoryWithCascade(ctx context.Context, categoryID bson.ObjectID) error
+}
+
+// UserRepository reads and writes users
+type UserRepository interface {
+	CreateUser(ctx context.Context, user *models.User) error
+	GetUserByID(ctx context.Context, userID bson.ObjectID) (*models.User, error)
+	FindUserByEmail(ctx context.Context, email string) (*models.User, error)
+	VerifyPassword(hashedPassword, plainPassword string) error
+	SetHostStatus(ctx context.Context, userID bson.ObjectID, isHost bool) error
+	SetSuspended(ctx context.Context, userID bson.ObjectID, suspended bool, reason string) error
+	SetVerified(ctx context.Context, userID bson.ObjectID, verified bool) error
+	SetLanguage(ctx context.Context, userID bson.ObjectID, language string) error
+	SetPhoneVerification(ctx context.Context, userID bson.ObjectID, verification *models.PhoneVerification) error
+	AddPhoneVerificationAttempt(ctx context.Context, userID bson.ObjectID) error
+	SetPhone(ctx context.Context, userID bson.ObjectID, phone string) error
+	SetPreferences(ctx context.Context, userID bson.ObjectID, preferences models.UserPreferences) error
+	FilterNotifiable(ctx context.Context, userIDs []bson.ObjectID, notificationType, channel string) ([]bson.ObjectID, error)
+	AnonymizeUser(ctx context.Context, userID bson.ObjectID) error
+}
+
+// SessionRepository reads and writes login sessions
+type SessionRepository interface {
+	CreateSession(ctx context.Context, session *models.UserSession, ttl time.Duration) error
+	IsSessionActive(ctx context.Context, sessionID bson.ObjectID) (bool, error)
+	GetActiveSessionsByUserID(ctx context.Context, userID bson.ObjectID) ([]models.UserSession, error)
+	RevokeSession(ctx context.Context, sessionID, userID bson.ObjectID) error
+	RevokeAllSessions(ctx context.Context, userID bson.ObjectID) (int64, error)
+}
+
+// AuditRepository writes and lists audit log entries
+type AuditRepository interface {
+	RecordAudit(ctx context.Context, entry *models.AuditLog) error
+	GetAuditLogs(ctx context.Context, auditFilter AuditFilter) ([]models.AuditLog, error)
+	CountCancelledBookings(ctx context.Context, eventID bson.ObjectID) (int64, error)
+}
+
+// FollowRepository reads and writes host follows
+type FollowRepository interface {
+	FollowHost(ctx context.Context, follow *models.Follow) error
+	UnfollowHost(ctx context.Context, followerID, hostID bson.ObjectID) error
+	GetFollowerIDs(ctx context.Context, hostID bson.ObjectID) ([]bson.ObjectID, error)
+	GetFollowing(ctx context.Context, followerID bson.ObjectID) ([]models.Follow, error)
+	DeleteFollowsOfUser(ctx context.Context, userID bson.ObjectID) (int64, error)
+}
+
+// NotificationRepository reads and writes in-app notifications
+type NotificationRepository interface {
+	CreateNotifications(ctx context.Context, notifications []models.Notification) error
+	GetNotificationsByUserID(ctx context.Context, userID bson.ObjectID) ([]models.Notification, error)
+	MarkAsRead(ctx context.Context, notificationID, userID bson.ObjectID) error
+	DeleteNotificationsByUserID(ctx context.Context, userID bson.ObjectID) (int64, error)
+}
+
+// ReportRepository reads and writes event reports
+type ReportRepository interface {
+	CreateReport(ctx context.Context, report *models.Report) error
+	CountReportsByEventID(ctx context.Context, eventID bson.ObjectID) (int, error)
+	GetReportSummaries(ctx context.Context, limit int) ([]models.ReportSummary, error)
+}
+
+// AnnouncementRepository reads and writes the announcements hosts send to attendees
+type AnnouncementRepository interface {
+	CreateAnnouncement(ctx context.Context, announcement *models.Announcement) error
+	GetAnnouncementsByEventID(ctx context.Context, eventID bson.ObjectID, limit int) ([]models.Announcement, error)
+}
+
+// CommentRepository reads and writes the comments on event pages
+type CommentRepository interface {
+	CreateComment(ctx context.Context, comment *models.Comment) error
+	GetCommentByID(ctx context.Context, commentID bson.ObjectID) (*models.Comment, error)
+	GetComments(ctx context.Context, eventID bson.ObjectID, limit, offset int) ([]models.Comment, int64, error)
+	GetReplies(ctx context.Context, parentIDs []bson.ObjectID) ([]models.Comment, error)
+	DeleteComment(ctx context.Context, commentID bson.ObjectID) (int64, error)
+	CountCommentsByAuthorSince(ctx context.Context, authorID bson.ObjectID, since time.Time) (int64, error)
+	ReportComment(ctx context.Context, commentID bson.ObjectID, report models.CommentReport) (count int, reported bool, err error)
+	HideComment(ctx context.Context, commentID bson.ObjectID) (bool, error)
+	GetReportedComments(ctx context.Context, limit int) ([]models.Comment, error)
+	GetCommentsByAuthor(ctx context.Context, authorID bson.ObjectID) ([]models.Comment, error)
+	AnonymizeAuthor(ctx context.Context, authorID bson.ObjectID) (int64, error)
+}
+
+// ShareLinkRepository reads and writes the share links of events
+type ShareLinkRepository interface {
+	CreateShareLink(ctx context.Context, link *models.ShareLink) error
+	RecordClick(ctx context.Context, code string) (*models.ShareLink, error)
+	GetShareLinksByEventID(ctx context.Context, eventID bson.ObjectID) ([]models.ShareLink, error)
+}
+
+// AffiliateRepository reads and writes the affiliates of hosts
+type AffiliateRepository interface {
+	CreateAffiliate(ctx context.Context, affiliate *models.Affiliate) error
+	GetAffiliateByCode(ctx context.Context, code string) (*models.Affiliate, error)
+	GetAffiliatesByHostID(ctx context.Context, hostID bson.ObjectID) ([]models.Affiliate, error)
+	UpdateAffiliate(ctx context.Context, hostID, affiliateID bson.ObjectID, fields bson.M) (*models.Affiliate, error)
+}
+
+// HostVerificationRepository reads and reviews the KYC submissions of hosts
+type HostVerificationRepository interface {
+	CreateVerification(ctx context.Context, verification *models.HostVerification) error
+	GetLatestVerification(ctx context.Context, hostID bson.ObjectID) (*models.HostVerification, error)
+	GetVerificationByID(ctx context.Context, id bson.ObjectID) (*models.HostVerification, error)
+	ListVerifications(ctx context.Context, status string, limit int64) ([]models.HostVerification, error)
+	ReviewVerification(ctx context.Context, id, reviewerID bson.ObjectID, approved bool, note string) (*models.HostVerification, error)
+	GetVerificationsByHost(ctx context.Context, hostID bson.ObjectID) ([]models.HostVerification, error)
+	DeleteVerificationsOfHost(ctx context.Context, hostID bson.ObjectID) (int64, error)
+}
+
+// OrganizationRepository reads and writes organizations and their members
+type OrganizationRepository interface {
+	CreateOrganization(ctx context.Context, org *models.Organization) error
+	GetOrganizationByID(ctx context.Context, id bson.ObjectID) (*models.Organization, error)
+	GetOrganizationsForUser(ctx context.Context, userID bson.ObjectID) ([]models.Organization, error)
+	AddMember(ctx context.Context, orgID bson.ObjectID, member models.OrgMember) (*models.Organization, error)
+	SetMemberRole(ctx context.Context, orgID, userID bson.ObjectID, role string) (*models.Organization, error)
+	RemoveMember(ctx context.Context, orgID, userID bson.ObjectID) (*models.Organization, error)
+}
+
+// ScannerKeyRepository reads and writes the check-in keys of door staff
+type ScannerKeyRepository interface {
+	CreateScannerKey(ctx context.Context, key *models.ScannerKey) error
+	GetScannerKeysByEvent(ctx context.Context, eventID bson.ObjectID) ([]models.ScannerKey, error)
+	UseScannerKey(ctx context.Context, hash string) (*models.ScannerKey, error)
+	RevokeScannerKey(ctx context.Context, eventID, keyID bson.ObjectID) error
+}
+
+// ConsentRepository records which versions of the legal documents users and guests accepted
+type ConsentRepository interface {
+	RecordConsents(ctx context.Context, consents []models.Consent) error
+	GetConsentsByUserID(ctx context.Context, userID bson.ObjectID) ([]models.Consent, error)
+	GetLatestConsents(ctx context.Context, userID bson.ObjectID) (map[string]models.Consent, error)
+}
+
+// GuestRepository reads and writes guests (bookings without an account)
+type GuestRepository interface {
+	FindOrCreateGuest(ctx context.Context, name, email string) (*models.Guest, error)
+	GetGuestByID(ctx context.Context, guestID bson.ObjectID) (*models.Guest, error)
+	MarkGuestClaimed(ctx context.Context, guestID, userID bson.ObjectID) (bool, error)
+}
+
+// JobRepository reads and writes the persistent queue of background jobs
+type JobRepository interface {
+	EnqueueJob(ctx context.Context, job *models.Job) error
+	ClaimJob(ctx context.Context, lease time.Duration) (*models.Job, error)
+	CompleteJob(ctx context.Context, id bson.ObjectID) error
+	FailJob(ctx context.Context, id bson.ObjectID, jobErr string, retryAt *time.Time) error
+	ListJobs(ctx context.Context, status string, limit int) ([]models.Job, error)
+	RetryJob(ctx context.Context, id bson.ObjectID) (*models.Job, error)
+}
+
+// SchedulerRepository records the runs of the background schedulers
+type SchedulerRepository interface {
+	RecordSchedulerRun(ctx context.Context, run models.SchedulerRun) (*models.Scheduler, error)
+	GetSchedulers(ctx context.Context) ([]models.Scheduler, error)
+}
+
+// VenueRepository reads and writes the venues events are held at
+type VenueRepository interface {
+	CreateVenue(ctx context.Context, venue *models.Venue) error
+	GetVenueByID(ctx context.Context, id bson.ObjectID) (*models.Venue, error)
+	GetVenues(ctx context.Context, query models.VenueQuery) ([]models.Venue, error)
+	UpdateVenue(ctx context.Context, venue *models.Venue) error
+	DeleteVenue(ctx context.Context, id bson.ObjectID) error
+}
+
+// TemplateRepository reads and writes the event templates of hosts
+type TemplateRepository interface {
+	CreateTemplate(ctx context.Context, template *models.EventTemplate) error
+	GetTemplatesByHost(ctx context.Context, hostID bson.ObjectID) ([]models.EventTemplate, error)
+	GetTemplateByID(ctx context.Context, id bson.ObjectID) (*models.EventTemplate, error)
+	CountTemplatesByHost(ctx context.Context, hostID bson.ObjectID) (int64, error)
+	DeleteTemplate(ctx context.Context, id, hostID bson.ObjectID) (bool, error)
+}
+
+// OutboxRepository delivers the outbox messages the booking transactions wrote
+type OutboxRepository interface {
+	ClaimOutboxMessage(ctx context.Context, lease time.Duration) (*models.OutboxMessage, error)
+	MarkOutboxDelivered(ctx context.Context, id bson.ObjectID) error
+	FailOutboxMessage(ctx context.Context, id bson.ObjectID, deliveryErr string, retryAt time.Time) error
+}
+
+// ! Compile time checks that the Mongo stores implement the interfaces
+var (
+	_ EventRepository        = (*EventStore)(nil)
+	_ BookingRepository      = (*BookingStore)(nil)
+	_ CategoryRepository     = (*CategoryStore)(nil)
+	_ UserRepository         = (*UserStore)(nil)
+	_ SessionRepository      = (*SessionStore)(nil)
+	_ AuditRepository        = (*AuditStore)(nil)
+	_ FollowRepository       = (*FollowStore)(nil)
+	_ NotificationRepository = (*NotificationStore)(nil)
+	_ ReportRepository       = (*ReportStore)(nil)
+	_ GuestRepository        = (*GuestStore)(nil)
+	_ AnnouncementRepository = (*AnnouncementStore)(nil)
+	_ CommentRepository      = (*CommentStore)(nil)
+	_ ShareLinkRepository    = (*ShareLinkStore)(nil)
+	_ AffiliateRepository    = (*AffiliateStore)(nil)
+	_ JobRepository          = (*JobStore)(nil)
+	_ OutboxRepository       = (*OutboxStore)(nil)
+)
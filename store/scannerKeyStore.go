@@ -0,0 +1,128 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"event-horizon/models"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+/******************** MONGODB FUNCTIONALITY FOR SCANNER KEYS COLLECTION ********************
+
+1. BSON MAPPING FOR SCANNERKEYS COLLECTION
+2. InsertOne
+3. Find by event_id
+4. FindOneAndUpdate on the key hash, so looking a key up also records when it was last used
+5. UpdateOne to revoke
+
+ ****************************************************************************************/
+
+/************************** I DID THE FOLLOWING THINGS IN THIS FILE: *******************************************
+
+1. Created ScannerKeyStore struct for the check-in keys of door staff, only their SHA-256 is stored.
+
+2. Developed CreateScannerKey and GetScannerKeysByEvent methods.
+
+3. Implemented UseScannerKey method, it only finds keys that are neither revoked nor expired.
+
+4. Implemented RevokeScannerKey method, a revoked key stops working on the next request.
+
+5. Created EnsureScannerKeyIndexes method, hashes are unique and keys are listed per event.
+
+************************************************************************************************************/
+
+// ErrScannerKeyNotFound is returned when a key doesn't exist, isn't the event's or is already revoked
+var ErrScannerKeyNotFound = errors.New("scanner key not found")
+
+type ScannerKeyStore struct {
+	collection *mongo.Collection
+}
+
+func NewScannerKeyStore(db *mongo.Database) *ScannerKeyStore {
+	return &ScannerKeyStore{
+		collection: db.Collection("ScannerKeys"),
+	}
+}
+
+// CreateScannerKey stores a new key
+func (s *ScannerKeyStore) CreateScannerKey(ctx context.Context, key *models.ScannerKey) error {
+	key.CreatedAt = time.Now()
+
+	result, err := s.collection.InsertOne(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	key.ID = result.InsertedID.(bson.ObjectID)
+	return nil
+}
+
+// GetScannerKeysByEvent returns the keys of an event, revoked ones included, newest first
+func (s *ScannerKeyStore) GetScannerKeysByEvent(ctx context.Context, eventID bson.ObjectID) ([]models.ScannerKey, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	cursor, err := s.collection.Find(ctx, bson.M{"event_id": eventID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	keys := []models.ScannerKey{} //** Return empty slice
+	if err := cursor.All(ctx, &keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// UseScannerKey returns the active key with the hash and records that it was used, ErrScannerKeyNotFound otherwise
+func (s *ScannerKeyStore) UseScannerKey(ctx context.Context, hash string) (*models.ScannerKey, error) {
+	now := time.Now()
+	filter := bson.M{
+		"hash":       hash,
+		"revoked_at": bson.M{"$exists": false},
+		"expires_at": bson.M{"$gt": now},
+	}
+	update := bson.M{"$set": bson.M{"last_used_at": now}}
+
+	var key models.ScannerKey
+	if err := s.collection.FindOneAndUpdate(ctx, filter, update).Decode(&key); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrScannerKeyNotFound
+		}
+		return nil, err
+	}
+	return &key, nil
+}
+
+// RevokeScannerKey revokes a key of the event, ErrScannerKeyNotFound when there is no such active key
+func (s *ScannerKeyStore) RevokeScannerKey(ctx context.Context, eventID, keyID bson.ObjectID) error {
+	filter := bson.M{"_id": keyID, "event_id": eventID, "revoked_at": bson.M{"$exists": false}}
+
+	result, err := s.collection.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"revoked_at": time.Now()}})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return ErrScannerKeyNotFound
+	}
+	return nil
+}
+
+// EnsureScannerKeyIndexes creates the unique hash index and the index keys are listed per event with
+func (s *ScannerKeyStore) EnsureScannerKeyIndexes(ctx context.Context) error {
+	_, err := s.collection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "hash", Value: 1}},
+			Options: options.Index().SetName("hash_unique").SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "event_id", Value: 1}, {Key: "created_at", Value: -1}},
+			Options: options.Index().SetName("event_id_created_at"),
+		},
+	})
+	return err
+}
@@ -0,0 +1,96 @@
+package store
+
+import (
+	"context"
+	"event-horizon/models"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+/******************** MONGODB FUNCTIONALITY FOR SCHEDULERS COLLECTION ********************
+
+1. BSON MAPPING FOR SCHEDULERS COLLECTION
+2. FindOneAndUpdate with upsert, one document per scheduler
+3. Find sorted by name
+4. Unique index on name
+
+ ****************************************************************************************/
+
+/************************** I DID THE FOLLOWING THINGS IN THIS FILE: *******************************************
+
+1. Created SchedulerStore struct, the latest run of every background scheduler for the admin dashboard.
+
+2. Developed RecordSchedulerRun method, every run overwrites the last_* fields and counts runs, failures and the total count.
+
+3. Implemented GetSchedulers method and EnsureSchedulerIndexes (one document per name).
+
+************************************************************************************************************/
+
+type SchedulerStore struct {
+	collection *mongo.Collection
+}
+
+func NewSchedulerStore(db *mongo.Database) *SchedulerStore {
+	return &SchedulerStore{
+		collection: db.Collection("Schedulers"),
+	}
+}
+
+// RecordSchedulerRun stores the outcome of a run on its scheduler, created on its first run, and returns the scheduler afterwards
+func (s *SchedulerStore) RecordSchedulerRun(ctx context.Context, run models.SchedulerRun) (*models.Scheduler, error) {
+	set := bson.M{
+		"description":      run.Description,
+		"interval":         run.Interval.String(),
+		"count_label":      run.CountLabel,
+		"last_trigger":     run.Trigger,
+		"last_started_at":  run.StartedAt,
+		"last_finished_at": run.FinishedAt,
+		"last_duration_ms": run.FinishedAt.Sub(run.StartedAt).Milliseconds(),
+		"last_count":       run.Count,
+		"last_run_ok":      run.Err == nil,
+		"last_instance":    run.Instance,
+	}
+	inc := bson.M{"runs": 1, "total_count": run.Count}
+	if run.Err != nil {
+		set["last_error"] = run.Err.Error()
+		set["last_error_at"] = run.FinishedAt
+		inc["failures"] = 1
+	}
+
+	update := bson.M{"$set": set, "$inc": inc}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var scheduler models.Scheduler
+	if err := s.collection.FindOneAndUpdate(ctx, bson.M{"name": run.Name}, update, opts).Decode(&scheduler); err != nil {
+		return nil, err
+	}
+	return &scheduler, nil
+}
+
+// GetSchedulers returns every scheduler that ran at least once, by name
+func (s *SchedulerStore) GetSchedulers(ctx context.Context) ([]models.Scheduler, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}})
+
+	cursor, err := s.collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	schedulers := []models.Scheduler{} //** Return empty slice
+	if err := cursor.All(ctx, &schedulers); err != nil {
+		return nil, err
+	}
+	return schedulers, nil
+}
+
+// EnsureSchedulerIndexes makes the scheduler names unique, two instances finishing their first run together share one document
+func (s *SchedulerStore) EnsureSchedulerIndexes(ctx context.Context) error {
+	_, err := s.collection.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "name", Value: 1}},
+		Options: options.Index().SetName("name_unique").SetUnique(true),
+	})
+	return err
+}
@@ -0,0 +1,147 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"event-horizon/models"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+/******************** MONGODB FUNCTIONALITY FOR SESSIONS COLLECTION ********************
+
+1. BSON MAPPING FOR SESSIONS COLLECTION
+2. InsertOne
+3. FindOne
+4. Find
+5. UpdateOne / UpdateMany
+6. TTL Index (expired sessions are removed by MongoDB)
+
+ ****************************************************************************************/
+
+/************************** I DID THE FOLLOWING THINGS IN THIS FILE: *******************************************
+
+1. Created SessionStore struct to track signed-in devices so JWTs can be revoked.
+
+2. Implemented CreateSession method to start a session on login/registration.
+
+3. Developed IsSessionActive method used by the JWT middleware on every protected request.
+
+4. Added GetActiveSessionsByUserID method to list a user's signed-in devices.
+
+5. Implemented RevokeSession method to sign a single device out.
+
+6. Created EnsureTTLIndex method so expired sessions are cleaned up automatically.
+
+7. Added RevokeAllSessions for deleted accounts.
+
+************************************************************************************************************/
+
+type SessionStore struct {
+	collection *mongo.Collection
+}
+
+func NewSessionStore(db *mongo.Database) *SessionStore {
+	return &SessionStore{
+		collection: db.Collection("Sessions"),
+	}
+}
+
+// CreateSession creates a new session that expires after ttl
+func (s *SessionStore) CreateSession(ctx context.Context, session *models.UserSession, ttl time.Duration) error {
+	session.CreatedAt = time.Now()
+	session.ExpiresAt = session.CreatedAt.Add(ttl)
+	session.Revoked = false
+
+	result, err := s.collection.InsertOne(ctx, session)
+	if err != nil {
+		return err
+	}
+
+	session.ID = result.InsertedID.(bson.ObjectID)
+	return nil
+}
+
+// IsSessionActive reports whether a session exists, is not revoked and has not expired
+func (s *SessionStore) IsSessionActive(ctx context.Context, sessionID bson.ObjectID) (bool, error) {
+	filter := bson.M{
+		"_id":        sessionID,
+		"revoked":    false,
+		"expires_at": bson.M{"$gt": time.Now()},
+	}
+
+	count, err := s.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+// GetActiveSessionsByUserID lists a user's active sessions, newest first
+func (s *SessionStore) GetActiveSessionsByUserID(ctx context.Context, userID bson.ObjectID) ([]models.UserSession, error) {
+	var sessions []models.UserSession
+
+	filter := bson.M{
+		"user_id":    userID,
+		"revoked":    false,
+		"expires_at": bson.M{"$gt": time.Now()},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	cursor, err := s.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	if err = cursor.All(ctx, &sessions); err != nil {
+		return nil, err
+	}
+
+	if sessions == nil {
+		sessions = []models.UserSession{}
+	}
+
+	return sessions, nil
+}
+
+// RevokeSession revokes one of the user's sessions
+func (s *SessionStore) RevokeSession(ctx context.Context, sessionID, userID bson.ObjectID) error {
+	filter := bson.M{"_id": sessionID, "user_id": userID}
+	update := bson.M{"$set": bson.M{"revoked": true}}
+
+	result, err := s.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return errors.New("session not found")
+	}
+
+	return nil
+}
+
+// RevokeAllSessions signs the user out of every device and returns how many sessions were revoked
+func (s *SessionStore) RevokeAllSessions(ctx context.Context, userID bson.ObjectID) (int64, error) {
+	result, err := s.collection.UpdateMany(ctx, bson.M{"user_id": userID, "revoked": false}, bson.M{"$set": bson.M{"revoked": true}})
+	if err != nil {
+		return 0, err
+	}
+	return result.ModifiedCount, nil
+}
+
+// EnsureTTLIndex lets MongoDB delete sessions once they expire
+func (s *SessionStore) EnsureTTLIndex(ctx context.Context) error {
+	index := mongo.IndexModel{
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetName("expires_at_ttl").SetExpireAfterSeconds(0),
+	}
+
+	_, err := s.collection.Indexes().CreateOne(ctx, index)
+	return err
+}
@@ -0,0 +1,116 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"event-horizon/models"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+/******************** MONGODB FUNCTIONALITY FOR SHARE LINKS COLLECTION ********************
+
+1. BSON MAPPING FOR SHARE LINKS COLLECTION
+2. InsertOne
+3. FindOneAndUpdate with $inc
+4. Find with Sort
+5. Unique index
+
+ ****************************************************************************************/
+
+/************************** I DID THE FOLLOWING THINGS IN THIS FILE: *******************************************
+
+1. Created ShareLinkStore struct to manage the short share links of events.
+
+2. Developed CreateShareLink method, the code must be unique.
+
+3. Added RecordClick method that counts a click and returns the link.
+
+4. Implemented GetShareLinksByEventID method for the referral report.
+
+5. Created EnsureShareLinkIndexes method for the unique code and the event index.
+
+************************************************************************************************************/
+
+// ErrShareCodeTaken is returned when a new share link's code is already used
+var ErrShareCodeTaken = errors.New("share code already taken")
+
+type ShareLinkStore struct {
+	collection *mongo.Collection
+}
+
+func NewShareLinkStore(db *mongo.Database) *ShareLinkStore {
+	return &ShareLinkStore{
+		collection: db.Collection("ShareLinks"),
+	}
+}
+
+// CreateShareLink stores a share link, ErrShareCodeTaken when its code is used
+func (s *ShareLinkStore) CreateShareLink(ctx context.Context, link *models.ShareLink) error {
+	link.CreatedAt = time.Now()
+
+	result, err := s.collection.InsertOne(ctx, link)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return ErrShareCodeTaken
+		}
+		return err
+	}
+
+	link.ID = result.InsertedID.(bson.ObjectID)
+	return nil
+}
+
+// RecordClick counts a click on the share link and returns it
+func (s *ShareLinkStore) RecordClick(ctx context.Context, code string) (*models.ShareLink, error) {
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var link models.ShareLink
+	err := s.collection.FindOneAndUpdate(ctx, bson.M{"code": code}, bson.M{"$inc": bson.M{"clicks": 1}}, opts).Decode(&link)
+	if err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
+
+// GetShareLinksByEventID returns the share links of an event, oldest first
+func (s *ShareLinkStore) GetShareLinksByEventID(ctx context.Context, eventID bson.ObjectID) ([]models.ShareLink, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+
+	cursor, err := s.collection.Find(ctx, bson.M{"event_id": eventID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var links []models.ShareLink
+	if err = cursor.All(ctx, &links); err != nil {
+		return nil, err
+	}
+
+	if links == nil {
+		links = []models.ShareLink{}
+	}
+
+	return links, nil
+}
+
+// EnsureShareLinkIndexes makes codes unique and indexes the links of an event
+func (s *ShareLinkStore) EnsureShareLinkIndexes(ctx context.Context) error {
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "code", Value: 1}},
+			Options: options.Index().SetName("code_unique").SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "event_id", Value: 1}, {Key: "created_at", Value: 1}},
+			Options: options.Index().SetName("event_created_at"),
+		},
+	}
+
+	_, err := s.collection.Indexes().CreateMany(ctx, indexes)
+	return err
+}
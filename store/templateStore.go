@@ -0,0 +1,121 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"event-horizon/models"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+/******************** MONGODB FUNCTIONALITY FOR TEMPLATES COLLECTION ********************
+
+1. BSON MAPPING FOR TEMPLATES COLLECTION
+2. InsertOne
+3. FindOne / Find with Sort
+4. DeleteOne
+5. Unique compound index
+
+ ****************************************************************************************/
+
+/************************** I DID THE FOLLOWING THINGS IN THIS FILE: *******************************************
+
+1. Created TemplateStore struct to keep the EVENT TEMPLATES of hosts.
+
+2. Developed CreateTemplate method, a host can't use the same template name twice.
+
+3. Implemented GetTemplatesByHost, GetTemplateByID and CountTemplatesByHost methods.
+
+4. Added DeleteTemplate method, only the host who saved the template can delete it.
+
+5. Created EnsureTemplateIndexes method for the unique name per host.
+
+************************************************************************************************************/
+
+// ErrTemplateNameTaken is returned when the host already has a template with the name
+var ErrTemplateNameTaken = errors.New("template name already taken")
+
+type TemplateStore struct {
+	collection *mongo.Collection
+}
+
+func NewTemplateStore(db *mongo.Database) *TemplateStore {
+	return &TemplateStore{
+		collection: db.Collection("Templates"),
+	}
+}
+
+// CreateTemplate stores a template, ErrTemplateNameTaken when the host already uses its name
+func (s *TemplateStore) CreateTemplate(ctx context.Context, template *models.EventTemplate) error {
+	template.CreatedAt = time.Now()
+
+	result, err := s.collection.InsertOne(ctx, template)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return ErrTemplateNameTaken
+		}
+		return err
+	}
+
+	template.ID = result.InsertedID.(bson.ObjectID)
+	return nil
+}
+
+// GetTemplatesByHost returns the templates of a host by name
+func (s *TemplateStore) GetTemplatesByHost(ctx context.Context, hostID bson.ObjectID) ([]models.EventTemplate, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}})
+
+	cursor, err := s.collection.Find(ctx, bson.M{"host_id": hostID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var templates []models.EventTemplate
+	if err = cursor.All(ctx, &templates); err != nil {
+		return nil, err
+	}
+
+	if templates == nil {
+		templates = []models.EventTemplate{}
+	}
+
+	return templates, nil
+}
+
+// GetTemplateByID returns a template
+func (s *TemplateStore) GetTemplateByID(ctx context.Context, id bson.ObjectID) (*models.EventTemplate, error) {
+	var template models.EventTemplate
+	if err := s.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&template); err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// CountTemplatesByHost counts the templates of a host
+func (s *TemplateStore) CountTemplatesByHost(ctx context.Context, hostID bson.ObjectID) (int64, error) {
+	return s.collection.CountDocuments(ctx, bson.M{"host_id": hostID})
+}
+
+// DeleteTemplate deletes one of the host's templates, reports whether it was found
+func (s *TemplateStore) DeleteTemplate(ctx context.Context, id, hostID bson.ObjectID) (bool, error) {
+	result, err := s.collection.DeleteOne(ctx, bson.M{"_id": id, "host_id": hostID})
+	if err != nil {
+		return false, err
+	}
+	return result.DeletedCount > 0, nil
+}
+
+// EnsureTemplateIndexes makes template names unique per host
+func (s *TemplateStore) EnsureTemplateIndexes(ctx context.Context) error {
+	index := mongo.IndexModel{
+		Keys:    bson.D{{Key: "host_id", Value: 1}, {Key: "name", Value: 1}},
+		Options: options.Index().SetName("host_name_unique").SetUnique(true),
+	}
+
+	_, err := s.collection.Indexes().CreateOne(ctx, index)
+	return err
+}
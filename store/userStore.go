@@ -8,6 +8,7 @@ import (
 
 	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -26,6 +27,20 @@ import (
 
 6. Implemented VerifyPassword method to compare a plain password with the hashed password stored in the database.
 
+7. New users are always created as regular users, host status is only granted through SetHostStatus.
+
+8. Added SetSuspended so admins can suspend (and reinstate) hosts.
+
+9. Added SetLanguage for the language users get their emails in.
+
+10. Added SetPhoneVerification, AddPhoneVerificationAttempt and SetPhone for verified phone numbers (SMS).
+
+11. Added SetPreferences and FilterNotifiable, notifications are only sent to users that didn't turn them off.
+
+12. Added SetVerified for VERIFIED HOSTS (an admin approved their KYC submission).
+
+13. Added AnonymizeUser, deleted accounts keep their ID (past bookings and revenue) but lose every personal field.
+
 
 ************************************************************************************************************/
 
@@ -57,6 +72,10 @@ func (s *UserStore) CreateUser(ctx context.Context, user *models.User) error {
 		return errors.New("email already exists")
 	}
 
+	//! Roles are never taken from the caller
+	user.IsHost = false
+	user.IsAdmin = false
+
 	//? Set creation timestamp
 	user.CreatedAt = time.Now()
 
@@ -100,3 +119,195 @@ func (s *UserStore) FindUserByEmail(ctx context.Context, email string) (*models.
 func (s *UserStore) VerifyPassword(hashedPassword, plainPassword string) error {
 	return bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(plainPassword))
 }
+
+// SetHostStatus grants or removes host status for a user
+func (s *UserStore) SetHostStatus(ctx context.Context, userID bson.ObjectID, isHost bool) error {
+	result, err := s.collection.UpdateOne(ctx, bson.M{"_id": userID}, bson.M{"$set": bson.M{"is_host": isHost}})
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return errors.New("user not found")
+	}
+
+	return nil
+}
+
+// SetLanguage sets the user's preferred language, an empty one removes it
+func (s *UserStore) SetLanguage(ctx context.Context, userID bson.ObjectID, language string) error {
+	update := bson.M{"$unset": bson.M{"language": ""}}
+	if language != "" {
+		update = bson.M{"$set": bson.M{"language": language}}
+	}
+
+	result, err := s.collection.UpdateOne(ctx, bson.M{"_id": userID}, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return errors.New("user not found")
+	}
+
+	return nil
+}
+
+// SetPhoneVerification stores a pending phone number with the hash of its code
+func (s *UserStore) SetPhoneVerification(ctx context.Context, userID bson.ObjectID, verification *models.PhoneVerification) error {
+	result, err := s.collection.UpdateOne(ctx, bson.M{"_id": userID}, bson.M{"$set": bson.M{"phone_verification": verification}})
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return errors.New("user not found")
+	}
+
+	return nil
+}
+
+// AddPhoneVerificationAttempt counts a wrong code
+func (s *UserStore) AddPhoneVerificationAttempt(ctx context.Context, userID bson.ObjectID) error {
+	_, err := s.collection.UpdateOne(ctx,
+		bson.M{"_id": userID, "phone_verification": bson.M{"$exists": true}},
+		bson.M{"$inc": bson.M{"phone_verification.attempts": 1}},
+	)
+	return err
+}
+
+// SetPhone stores a verified phone number and drops the pending verification, an empty phone removes the number
+func (s *UserStore) SetPhone(ctx context.Context, userID bson.ObjectID, phone string) error {
+	update := bson.M{"$unset": bson.M{"phone": "", "phone_verified_at": "", "phone_verification": ""}}
+	if phone != "" {
+		update = bson.M{
+			"$set":   bson.M{"phone": phone, "phone_verified_at": time.Now()},
+			"$unset": bson.M{"phone_verification": ""},
+		}
+	}
+
+	result, err := s.collection.UpdateOne(ctx, bson.M{"_id": userID}, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return errors.New("user not found")
+	}
+
+	return nil
+}
+
+// SetPreferences replaces the user's preferences sub-document
+func (s *UserStore) SetPreferences(ctx context.Context, userID bson.ObjectID, preferences models.UserPreferences) error {
+	result, err := s.collection.UpdateOne(ctx, bson.M{"_id": userID}, bson.M{"$set": bson.M{"preferences": preferences}})
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return errors.New("user not found")
+	}
+
+	return nil
+}
+
+// notifiableBatch is how many user IDs go into one $in query
+const notifiableBatch = 1000
+
+// FilterNotifiable returns the users of userIDs that didn't turn the notification type off on the channel
+func (s *UserStore) FilterNotifiable(ctx context.Context, userIDs []bson.ObjectID, notificationType, channel string) ([]bson.ObjectID, error) {
+	allowed := make([]bson.ObjectID, 0, len(userIDs))
+	for start := 0; start < len(userIDs); start += notifiableBatch {
+		batch := userIDs[start:min(start+notifiableBatch, len(userIDs))]
+
+		filter := bson.M{
+			"_id": bson.M{"$in": batch},
+			"preferences.notifications." + notificationType + "." + channel: bson.M{"$ne": false},
+		}
+		cursor, err := s.collection.Find(ctx, filter, options.Find().SetProjection(bson.M{"_id": 1}))
+		if err != nil {
+			return nil, err
+		}
+
+		var users []struct {
+			ID bson.ObjectID `bson:"_id"`
+		}
+		if err := cursor.All(ctx, &users); err != nil {
+			return nil, err
+		}
+		for _, user := range users {
+			allowed = append(allowed, user.ID)
+		}
+	}
+	return allowed, nil
+}
+
+// SetVerified marks a host as verified (or takes the badge away)
+func (s *UserStore) SetVerified(ctx context.Context, userID bson.ObjectID, verified bool) error {
+	update := bson.M{"$unset": bson.M{"verified_at": ""}}
+	if verified {
+		update = bson.M{"$set": bson.M{"verified_at": time.Now()}}
+	}
+
+	result, err := s.collection.UpdateOne(ctx, bson.M{"_id": userID}, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return errors.New("user not found")
+	}
+
+	return nil
+}
+
+// AnonymizeUser soft deletes a user: the personal data is replaced or removed, the document stays for the bookings pointing to it
+func (s *UserStore) AnonymizeUser(ctx context.Context, userID bson.ObjectID) error {
+	update := bson.M{
+		"$set": bson.M{
+			"name":       models.DeletedUserName,
+			"email":      "deleted-" + userID.Hex() + "@deleted.invalid", //? Unique like every email, nobody can sign in with it
+			"password":   "",
+			"deleted_at": time.Now(),
+		},
+		"$unset": bson.M{
+			"phone":              "",
+			"phone_verified_at":  "",
+			"phone_verification": "",
+			"language":           "",
+			"preferences":        "",
+			"suspend_reason":     "",
+		},
+	}
+
+	result, err := s.collection.UpdateOne(ctx, bson.M{"_id": userID}, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return errors.New("user not found")
+	}
+
+	return nil
+}
+
+// SetSuspended suspends a user with a reason, or lifts the suspension
+func (s *UserStore) SetSuspended(ctx context.Context, userID bson.ObjectID, suspended bool, reason string) error {
+	update := bson.M{"$unset": bson.M{"suspended_at": "", "suspend_reason": ""}}
+	if suspended {
+		update = bson.M{"$set": bson.M{"suspended_at": time.Now(), "suspend_reason": reason}}
+	}
+
+	result, err := s.collection.UpdateOne(ctx, bson.M{"_id": userID}, update)
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return errors.New("user not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,138 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+/** *********************  BOOKING VELOCITY COUNTER   ********************
+
+Scalpers fire bookings as fast as they can when a popular event goes on
+sale. The booking service counts the bookings of every buyer and IP per
+minute and refuses them once BOOKING_VELOCITY_LIMIT is reached.
+
+The counts use fixed windows: the first hit of a key opens a window, every
+hit inside it adds one, and the count starts over when it ends.
+
+Failed sign ins are counted the same way, login asks for a CAPTCHA once an
+email or IP failed too often.
+
+1. MemoryVelocityCounter - counts in this instance only (default)
+2. RedisVelocityCounter  - counts across all instances when REDIS_URL is set
+
+ **************************************/
+
+// VelocityCounter counts hits of a key inside a time window
+type VelocityCounter interface {
+	// Hit counts a hit of the key and returns the hits of its current window, this one included
+	Hit(ctx context.Context, key string, window time.Duration) (int64, error)
+	// Count returns the hits of the key's current window without counting one
+	Count(ctx context.Context, key string) (int64, error)
+	// Reset ends the key's window
+	Reset(ctx context.Context, key string) error
+}
+
+// memorySweepSize is how many keys the memory counter holds before it drops the ended windows
+const memorySweepSize = 10000
+
+// MemoryVelocityCounter is a VelocityCounter of one instance
+type MemoryVelocityCounter struct {
+	mu      sync.Mutex
+	windows map[string]*velocityWindow
+}
+
+type velocityWindow struct {
+	ends time.Time
+	hits int64
+}
+
+// NewMemoryVelocityCounter creates a MemoryVelocityCounter
+func NewMemoryVelocityCounter() *MemoryVelocityCounter {
+	return &MemoryVelocityCounter{windows: make(map[string]*velocityWindow)}
+}
+
+// Hit counts a hit of the key in memory
+func (c *MemoryVelocityCounter) Hit(ctx context.Context, key string, window time.Duration) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	if len(c.windows) >= memorySweepSize {
+		for k, w := range c.windows {
+			if !now.Before(w.ends) {
+				delete(c.windows, k)
+			}
+		}
+	}
+
+	w, ok := c.windows[key]
+	if !ok || !now.Before(w.ends) {
+		w = &velocityWindow{ends: now.Add(window)}
+		c.windows[key] = w
+	}
+	w.hits++
+	return w.hits, nil
+}
+
+// Count returns the hits of the key's window in memory
+func (c *MemoryVelocityCounter) Count(ctx context.Context, key string) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	w, ok := c.windows[key]
+	if !ok || !time.Now().Before(w.ends) {
+		return 0, nil
+	}
+	return w.hits, nil
+}
+
+// Reset forgets the key's window
+func (c *MemoryVelocityCounter) Reset(ctx context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.windows, key)
+	return nil
+}
+
+// hitScript counts a hit, the first hit of a window sets the expiry (atomic, so a key never outlives its window)
+var hitScript = redis.NewScript(`
+local hits = redis.call("INCR", KEYS[1])
+if hits == 1 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+return hits
+`)
+
+// RedisVelocityCounter is a VelocityCounter shared by all instances through Redis INCR
+type RedisVelocityCounter struct {
+	client *redis.Client
+}
+
+// NewRedisVelocityCounter creates a RedisVelocityCounter
+func NewRedisVelocityCounter(client *redis.Client) *RedisVelocityCounter {
+	return &RedisVelocityCounter{client: client}
+}
+
+// Hit counts a hit of the key in Redis, the key expires with its window
+func (c *RedisVelocityCounter) Hit(ctx context.Context, key string, window time.Duration) (int64, error) {
+	return hitScript.Run(ctx, c.client, []string{"velocity:" + key}, window.Milliseconds()).Int64()
+}
+
+// Count reads the key's hits from Redis, an expired key counts 0
+func (c *RedisVelocityCounter) Count(ctx context.Context, key string) (int64, error) {
+	hits, err := c.client.Get(ctx, "velocity:"+key).Int64()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	return hits, err
+}
+
+// Reset deletes the key from Redis
+func (c *RedisVelocityCounter) Reset(ctx context.Context, key string) error {
+	return c.client.Del(ctx, "velocity:"+key).Err()
+}
@@ -0,0 +1,155 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"event-horizon/models"
+	"regexp"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+/******************** MONGODB FUNCTIONALITY FOR VENUES COLLECTION ********************
+
+1. BSON MAPPING FOR VENUES COLLECTION
+2. InsertOne
+3. FindOne / Find with $regex and Sort
+4. UpdateOne / DeleteOne
+5. 2dsphere Index
+
+ ****************************************************************************************/
+
+/************************** I DID THE FOLLOWING THINGS IN THIS FILE: *******************************************
+
+1. Created VenueStore struct to keep the VENUES events are held at.
+
+2. Developed CreateVenue, GetVenueByID and GetVenues (by part of the name and city) methods.
+
+3. Added UpdateVenue and DeleteVenue methods, who may change a venue is checked by the service.
+
+4. Created EnsureVenueIndexes method for the name, city and geo_location indexes.
+
+************************************************************************************************************/
+
+// ErrVenueNotFound is returned when a venue doesn't exist (anymore)
+var ErrVenueNotFound = errors.New("venue not found")
+
+type VenueStore struct {
+	collection *mongo.Collection
+}
+
+func NewVenueStore(db *mongo.Database) *VenueStore {
+	return &VenueStore{
+		collection: db.Collection("Venues"),
+	}
+}
+
+// CreateVenue stores a new venue
+func (s *VenueStore) CreateVenue(ctx context.Context, venue *models.Venue) error {
+	venue.CreatedAt = time.Now()
+
+	result, err := s.collection.InsertOne(ctx, venue)
+	if err != nil {
+		return err
+	}
+
+	venue.ID = result.InsertedID.(bson.ObjectID)
+	return nil
+}
+
+// GetVenueByID returns a venue, ErrVenueNotFound when it doesn't exist
+func (s *VenueStore) GetVenueByID(ctx context.Context, id bson.ObjectID) (*models.Venue, error) {
+	var venue models.Venue
+	if err := s.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&venue); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrVenueNotFound
+		}
+		return nil, err
+	}
+	return &venue, nil
+}
+
+// GetVenues returns the venues matching the query by name
+func (s *VenueStore) GetVenues(ctx context.Context, query models.VenueQuery) ([]models.Venue, error) {
+	filter := bson.M{}
+	if query.Name != "" {
+		filter["name"] = bson.M{"$regex": regexp.QuoteMeta(query.Name), "$options": "i"}
+	}
+	if query.City != "" {
+		filter["city"] = bson.M{"$regex": "^" + regexp.QuoteMeta(query.City) + "$", "$options": "i"}
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}}).SetLimit(int64(query.Limit))
+
+	cursor, err := s.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	venues := []models.Venue{}
+	if err := cursor.All(ctx, &venues); err != nil {
+		return nil, err
+	}
+	return venues, nil
+}
+
+// UpdateVenue writes the venue's details, ErrVenueNotFound when it was deleted
+func (s *VenueStore) UpdateVenue(ctx context.Context, venue *models.Venue) error {
+	venue.UpdatedAt = time.Now()
+
+	update := bson.M{"$set": bson.M{
+		"name":         venue.Name,
+		"address":      venue.Address,
+		"city":         venue.City,
+		"geo_location": venue.GeoLocation,
+		"capacity":     venue.Capacity,
+		"amenities":    venue.Amenities,
+		"updated_at":   venue.UpdatedAt,
+	}}
+
+	result, err := s.collection.UpdateOne(ctx, bson.M{"_id": venue.ID}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return ErrVenueNotFound
+	}
+	return nil
+}
+
+// DeleteVenue deletes a venue, ErrVenueNotFound when it was already deleted
+func (s *VenueStore) DeleteVenue(ctx context.Context, id bson.ObjectID) error {
+	result, err := s.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return ErrVenueNotFound
+	}
+	return nil
+}
+
+// EnsureVenueIndexes indexes venues by name and city, and by geo_location for maps
+func (s *VenueStore) EnsureVenueIndexes(ctx context.Context) error {
+	indexes := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "name", Value: 1}},
+			Options: options.Index().SetName("name"),
+		},
+		{
+			Keys:    bson.D{{Key: "city", Value: 1}, {Key: "name", Value: 1}},
+			Options: options.Index().SetName("city_name"),
+		},
+		{
+			Keys:    bson.D{{Key: "geo_location", Value: "2dsphere"}},
+			Options: options.Index().SetName("geo_location_2dsphere"),
+		},
+	}
+
+	_, err := s.collection.Indexes().CreateMany(ctx, indexes)
+	return err
+}
@@ -0,0 +1,240 @@
+package templates
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+)
+
+/** *********************  EMAIL TEMPLATES   ********************
+
+The bodies of the app's emails live in this directory, one folder per locale
+and one pair of files per email:
+
+	<locale>/<name>.html - the HTML body, rendered with html/template (values are escaped)
+	<locale>/<name>.txt  - the plain text body, rendered with text/template, it also
+	                       defines the subject: {{define "subject"}}...{{end}}
+
+Messages that can also go out by SMS have a third file:
+
+	<locale>/<name>.sms  - the SMS body, rendered with text/template, keep it short
+
+Every email must exist in the DEFAULT LOCALE (en), other locales only need the
+emails they translate. Render picks the variant of the recipient's language:
+"es-MX" tries es-MX, then es, then en. RenderSMS does the same for SMS.
+
+The files are embedded into the binary like the OpenAPI document, so the
+server doesn't read files at runtime, and parsed once at startup.
+
+ **************************************/
+
+//go:embed */*.html */*.txt */*.sms
+var files embed.FS
+
+// DefaultLocale is used when the recipient's language has no variant
+const DefaultLocale = "en"
+
+// Names of the email templates
+const (
+	BookingConfirmation = "booking_confirmation"
+	BookingCancellation = "booking_cancellation"
+	EventReminder       = "event_reminder"
+	PasswordReset       = "password_reset"
+	PhoneVerification   = "phone_verification" //? SMS only
+)
+
+// Email is a rendered email
+type Email struct {
+	Subject string `json:"subject"`
+	HTML    string `json:"html"`
+	Text    string `json:"text"`
+}
+
+// variant is one email in one locale
+type variant struct {
+	html *htmltemplate.Template
+	text *texttemplate.Template
+}
+
+// registry maps locale -> email name -> variant, smsRegistry the same for SMS bodies
+var (
+	registry    = mustLoad(files)
+	smsRegistry = mustLoadSMS(files)
+)
+
+// mustLoad parses every template, a broken template stops the server at startup instead of failing a send
+func mustLoad(fsys fs.FS) map[string]map[string]variant {
+	loaded := make(map[string]map[string]variant)
+
+	htmlFiles, err := fs.Glob(fsys, "*/*.html")
+	if err != nil {
+		panic(err)
+	}
+	for _, file := range htmlFiles {
+		locale := path.Dir(file)
+		name := strings.TrimSuffix(path.Base(file), ".html")
+
+		//? Every file is its own set, so every .txt can define its own "subject"
+		html := htmltemplate.Must(htmltemplate.ParseFS(fsys, file))
+		text := texttemplate.Must(texttemplate.ParseFS(fsys, path.Join(locale, name+".txt")))
+		if text.Lookup("subject") == nil {
+			panic(fmt.Sprintf("templates: %s/%s.txt has no subject", locale, name))
+		}
+
+		if loaded[locale] == nil {
+			loaded[locale] = make(map[string]variant)
+		}
+		loaded[locale][name] = variant{html: html, text: text}
+	}
+
+	for locale, emails := range loaded {
+		for name := range emails {
+			if _, ok := loaded[DefaultLocale][name]; !ok {
+				panic(fmt.Sprintf("templates: %s/%s has no %s variant", locale, name, DefaultLocale))
+			}
+		}
+	}
+	return loaded
+}
+
+// mustLoadSMS parses every SMS body, like mustLoad every one must exist in the default locale
+func mustLoadSMS(fsys fs.FS) map[string]map[string]*texttemplate.Template {
+	loaded := make(map[string]map[string]*texttemplate.Template)
+
+	smsFiles, err := fs.Glob(fsys, "*/*.sms")
+	if err != nil {
+		panic(err)
+	}
+	for _, file := range smsFiles {
+		locale := path.Dir(file)
+		if loaded[locale] == nil {
+			loaded[locale] = make(map[string]*texttemplate.Template)
+		}
+		loaded[locale][strings.TrimSuffix(path.Base(file), ".sms")] = texttemplate.Must(texttemplate.ParseFS(fsys, file))
+	}
+
+	for locale, messages := range loaded {
+		for name := range messages {
+			if _, ok := loaded[DefaultLocale][name]; !ok {
+				panic(fmt.Sprintf("templates: %s/%s.sms has no %s variant", locale, name, DefaultLocale))
+			}
+		}
+	}
+	return loaded
+}
+
+// Names returns the names of every email
+func Names() []string {
+	names := make([]string, 0, len(registry[DefaultLocale]))
+	for name := range registry[DefaultLocale] {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Locales returns the locales that have a variant of the email
+func Locales(name string) []string {
+	locales := []string{}
+	for locale, emails := range registry {
+		if _, ok := emails[name]; ok {
+			locales = append(locales, locale)
+		}
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// SupportedLocales returns every locale with at least one email
+func SupportedLocales() []string {
+	locales := make([]string, 0, len(registry))
+	for locale := range registry {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// Exists reports whether an email of that name exists
+func Exists(name string) bool {
+	_, ok := registry[DefaultLocale][name]
+	return ok
+}
+
+// ResolveLocale returns the locale Render uses for the email and the language ("es-MX" -> "es")
+func ResolveLocale(name, language string) string {
+	return resolveLocale(language, func(locale string) bool {
+		_, ok := registry[locale][name]
+		return ok
+	})
+}
+
+// resolveLocale walks from the language to its parents ("es-MX" -> "es") until has says there is a variant
+func resolveLocale(language string, has func(locale string) bool) string {
+	language = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(language), "_", "-"))
+	for language != "" {
+		if has(language) {
+			return language
+		}
+		cut := strings.LastIndex(language, "-")
+		if cut == -1 {
+			break
+		}
+		language = language[:cut]
+	}
+	return DefaultLocale
+}
+
+// Render renders the named email in the variant closest to the language
+func Render(name, language string, data any) (Email, error) {
+	if !Exists(name) {
+		return Email{}, fmt.Errorf("templates: unknown email %q", name)
+	}
+	v := registry[ResolveLocale(name, language)][name]
+
+	var subject, html, text bytes.Buffer
+	if err := v.text.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return Email{}, err
+	}
+	if err := v.html.Execute(&html, data); err != nil {
+		return Email{}, err
+	}
+	if err := v.text.Execute(&text, data); err != nil {
+		return Email{}, err
+	}
+
+	return Email{
+		Subject: strings.TrimSpace(subject.String()),
+		HTML:    html.String(),
+		Text:    strings.TrimLeft(text.String(), "\n"),
+	}, nil
+}
+
+// HasSMS reports whether the message has an SMS body
+func HasSMS(name string) bool {
+	_, ok := smsRegistry[DefaultLocale][name]
+	return ok
+}
+
+// RenderSMS renders the SMS body of the named message in the variant closest to the language
+func RenderSMS(name, language string, data any) (string, error) {
+	if !HasSMS(name) {
+		return "", fmt.Errorf("templates: %q has no SMS body", name)
+	}
+	locale := resolveLocale(language, func(locale string) bool {
+		_, ok := smsRegistry[locale][name]
+		return ok
+	})
+
+	var body bytes.Buffer
+	if err := smsRegistry[locale][name].Execute(&body, data); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(body.String()), nil
+}
@@ -0,0 +1,54 @@
+package tracing
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/v2/event"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// MongoMonitor returns a driver command monitor that records every Mongo command as a client span.
+// Only commands run inside a traced operation get one, background polling (job queue, outbox ...) stays out.
+func MongoMonitor() *event.CommandMonitor {
+	var open sync.Map //? Spans of running commands by connection and request ID
+
+	finish := func(connectionID string, requestID int64, err error) {
+		value, ok := open.LoadAndDelete(connectionID + "/" + strconv.FormatInt(requestID, 10))
+		if !ok {
+			return
+		}
+		End(value.(trace.Span), err)
+	}
+
+	return &event.CommandMonitor{
+		Started: func(ctx context.Context, evt *event.CommandStartedEvent) {
+			if !trace.SpanFromContext(ctx).IsRecording() {
+				return
+			}
+
+			name := evt.CommandName
+			attributes := []attribute.KeyValue{
+				attribute.String("db.system.name", "mongodb"),
+				attribute.String("db.namespace", evt.DatabaseName),
+				attribute.String("db.operation.name", evt.CommandName),
+			}
+			if collection, _ := evt.Command.Lookup(evt.CommandName).StringValueOK(); collection != "" {
+				name += " " + collection
+				attributes = append(attributes, attribute.String("db.collection.name", collection))
+			}
+
+			//? The command itself is left out, it holds user data
+			_, span := Start(ctx, name, KindClient, attributes...)
+			open.Store(evt.ConnectionID+"/"+strconv.FormatInt(evt.RequestID, 10), span)
+		},
+		Succeeded: func(ctx context.Context, evt *event.CommandSucceededEvent) {
+			finish(evt.ConnectionID, evt.RequestID, nil)
+		},
+		Failed: func(ctx context.Context, evt *event.CommandFailedEvent) {
+			finish(evt.ConnectionID, evt.RequestID, evt.Failure)
+		},
+	}
+}
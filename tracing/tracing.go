@@ -0,0 +1,96 @@
+package tracing
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+/** *********************  REQUEST TRACING   ********************
+
+Every request becomes a TRACE of SPANS, recorded with the OpenTelemetry SDK:
+
+	GET /api/v1/events/:id/bookings       server span (middleware.Tracing, otelecho)
+	└── BookingStore.CreateBooking        store span around the transaction
+	    ├── BookingLocker.Lock            waiting for the event lock (Redis)
+	    ├── find Events                   one client span per Mongo command (MongoMonitor)
+	    ├── insert Bookings
+	    └── commitTransaction
+
+Spans are exported in batches over OTLP/HTTP to OTEL_EXPORTER_OTLP_ENDPOINT:
+Jaeger (port 4318), an OpenTelemetry Collector, Grafana Tempo, Honeycomb ...
+
+A W3C traceparent header on the request is continued, so a trace started in
+the frontend or a gateway carries on here and its sampling decision is kept.
+New traces are sampled with TRACE_SAMPLE_RATIO. Without an endpoint Setup is
+never called, the global tracer provider stays the no-op one and so do the spans.
+
+ **************************************/
+
+// tracerName is the instrumentation scope of the app's own spans
+const tracerName = "event-horizon"
+
+// Span kinds
+const (
+	KindInternal = trace.SpanKindInternal
+	KindServer   = trace.SpanKindServer
+	KindClient   = trace.SpanKindClient
+)
+
+// Setup installs the global tracer provider, exporting to the OTLP base endpoint (e.g. http://localhost:4318)
+// with headers on every export (API keys of hosted backends). Call the returned function to flush and stop it.
+func Setup(ctx context.Context, endpoint, serviceName string, headers map[string]string, sampleRatio float64) (func(context.Context) error, error) {
+	exporter, err := otlptracehttp.New(ctx,
+		otlptracehttp.WithEndpointURL(strings.TrimSuffix(endpoint, "/")+"/v1/traces"),
+		otlptracehttp.WithHeaders(headers),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	provider := NewProvider(sdktrace.NewBatchSpanProcessor(exporter), serviceName, sampleRatio)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	return provider.Shutdown, nil
+}
+
+// NewProvider creates a tracer provider handing the spans to processor, a caller's traceparent decides for its traces
+func NewProvider(processor sdktrace.SpanProcessor, serviceName string, sampleRatio float64) *sdktrace.TracerProvider {
+	service, _ := resource.Merge(resource.Default(), resource.NewSchemaless(attribute.String("service.name", serviceName)))
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithSpanProcessor(processor),
+		sdktrace.WithResource(service),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(sampleRatio))),
+	)
+}
+
+// Start opens a span, a child of the span in ctx (or of the extracted caller), a new trace otherwise
+func Start(ctx context.Context, name string, kind trace.SpanKind, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithSpanKind(kind), trace.WithAttributes(attributes...))
+}
+
+// End marks the span as failed when err isn't nil and ends it
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// TraceID returns the trace ID of the span in ctx as 32 hex characters, empty if there is none
+func TraceID(ctx context.Context) string {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.HasTraceID() {
+		return ""
+	}
+	return spanContext.TraceID().String()
+}
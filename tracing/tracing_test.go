@@ -0,0 +1,188 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/event"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// record installs a tracer provider with the sample ratio that keeps the finished spans in memory
+func record(t *testing.T, sampleRatio float64) *tracetest.SpanRecorder {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	provider := NewProvider(recorder, "event-horizon-test", sampleRatio)
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+		provider.Shutdown(context.Background())
+	})
+	return recorder
+}
+
+func attributeValue(attributes []attribute.KeyValue, key string) string {
+	for _, kv := range attributes {
+		if string(kv.Key) == key {
+			return kv.Value.Emit()
+		}
+	}
+	return ""
+}
+
+func TestStartEnd(t *testing.T) {
+	recorder := record(t, 1)
+
+	ctx, parent := Start(context.Background(), "BookingStore.CreateBooking", KindInternal, attribute.String("event.id", "42"))
+	_, child := Start(ctx, "BookingLocker.Lock", KindInternal)
+	End(child, errors.New("lock timeout"))
+	End(parent, nil)
+
+	if got := TraceID(ctx); got != parent.SpanContext().TraceID().String() || len(got) != 32 {
+		t.Fatalf("TraceID = %q", got)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("%d spans ended, want 2", len(spans))
+	}
+	lock, booking := spans[0], spans[1]
+	if lock.Parent().SpanID() != booking.SpanContext().SpanID() || lock.SpanContext().TraceID() != booking.SpanContext().TraceID() {
+		t.Fatal("the lock span is not a child of the booking span")
+	}
+	if lock.Status().Code != codes.Error || lock.Status().Description != "lock timeout" || len(lock.Events()) != 1 {
+		t.Fatalf("lock span status %+v with %d events, want the error recorded", lock.Status(), len(lock.Events()))
+	}
+	if booking.Status().Code != codes.Unset || attributeValue(booking.Attributes(), "event.id") != "42" {
+		t.Fatalf("booking span status %+v attributes %v", booking.Status(), booking.Attributes())
+	}
+	if service, _ := booking.Resource().Set().Value("service.name"); service.AsString() != "event-horizon-test" {
+		t.Fatalf("service.name = %q", service.AsString())
+	}
+}
+
+func TestTraceIDWithoutSpan(t *testing.T) {
+	if got := TraceID(context.Background()); got != "" {
+		t.Fatalf("TraceID = %q, want empty", got)
+	}
+}
+
+func TestSamplingKeepsCallerDecision(t *testing.T) {
+	recorder := record(t, 0)
+
+	//? Ratio 0 drops new traces
+	_, span := Start(context.Background(), "GET /api/v1/events", KindServer)
+	End(span, nil)
+	if len(recorder.Ended()) != 0 {
+		t.Fatal("a new trace was recorded with a sample ratio of 0")
+	}
+
+	//? A sampled caller keeps its trace recorded
+	carrier := propagation.MapCarrier{"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"}
+	ctx := propagation.TraceContext{}.Extract(context.Background(), carrier)
+	_, span = Start(ctx, "GET /api/v1/events", KindServer)
+	End(span, nil)
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("%d spans recorded, want the caller's", len(spans))
+	}
+	if spans[0].SpanContext().TraceID().String() != "4bf92f3577b34da6a3ce929d0e0e4736" || spans[0].Parent().SpanID().String() != "00f067aa0ba902b7" {
+		t.Fatalf("span is not in the caller's trace: %v", spans[0].SpanContext())
+	}
+}
+
+func TestMongoMonitor(t *testing.T) {
+	recorder := record(t, 1)
+	monitor := MongoMonitor()
+
+	command, _ := bson.Marshal(bson.D{{Key: "find", Value: "Events"}, {Key: "filter", Value: bson.D{{Key: "name", Value: "secret"}}}})
+	started := func(ctx context.Context, requestID int64) {
+		monitor.Started(ctx, &event.CommandStartedEvent{
+			Command: command, DatabaseName: "eventhorizon", CommandName: "find", RequestID: requestID, ConnectionID: "conn-1",
+		})
+	}
+	finished := event.CommandFinishedEvent{CommandName: "find", RequestID: 2, ConnectionID: "conn-1"}
+
+	//? Outside a traced operation nothing is recorded
+	started(context.Background(), 1)
+	monitor.Succeeded(context.Background(), &event.CommandSucceededEvent{CommandFinishedEvent: event.CommandFinishedEvent{RequestID: 1, ConnectionID: "conn-1"}})
+	if len(recorder.Ended()) != 0 {
+		t.Fatal("a command outside a trace got a span")
+	}
+
+	ctx, parent := Start(context.Background(), "BookingStore.CreateBooking", KindInternal)
+	started(ctx, 2)
+	monitor.Failed(ctx, &event.CommandFailedEvent{CommandFinishedEvent: finished, Failure: errors.New("write conflict")})
+	End(parent, nil)
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("%d spans ended, want the command and its parent", len(spans))
+	}
+	find := spans[0]
+	if find.Name() != "find Events" || find.SpanKind() != trace.SpanKindClient || find.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Fatalf("command span %q kind %v", find.Name(), find.SpanKind())
+	}
+	if find.Status().Code != codes.Error {
+		t.Fatalf("failed command status %+v", find.Status())
+	}
+	for key, want := range map[string]string{"db.system.name": "mongodb", "db.namespace": "eventhorizon", "db.operation.name": "find", "db.collection.name": "Events"} {
+		if got := attributeValue(find.Attributes(), key); got != want {
+			t.Errorf("%s = %q, want %q", key, got, want)
+		}
+	}
+	for _, kv := range find.Attributes() {
+		if kv.Value.Emit() == "secret" {
+			t.Fatal("the command's data ended up on the span")
+		}
+	}
+}
+
+func TestSetupExportsOverOTLP(t *testing.T) {
+	type export struct{ path, contentType, apiKey string }
+	exports := make(chan export, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case exports <- export{r.URL.Path, r.Header.Get("Content-Type"), r.Header.Get("X-Api-Key")}:
+		default:
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	previous, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	defer func() {
+		otel.SetTracerProvider(previous)
+		otel.SetTextMapPropagator(previousPropagator)
+	}()
+
+	shutdown, err := Setup(context.Background(), server.URL+"/", "event-horizon-test", map[string]string{"X-Api-Key": "key"}, 1)
+	if err != nil {
+		t.Fatalf("Setup: %v", err)
+	}
+	_, span := Start(context.Background(), "GET /api/v1/events", KindServer)
+	End(span, nil)
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+
+	select {
+	case got := <-exports:
+		if got.path != "/v1/traces" || got.contentType != "application/x-protobuf" || got.apiKey != "key" {
+			t.Fatalf("export = %+v", got)
+		}
+	default:
+		t.Fatal("nothing was exported")
+	}
+}
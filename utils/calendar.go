@@ -0,0 +1,85 @@
+package utils
+
+import (
+	"strings"
+	"time"
+)
+
+/** *********************  CALENDAR INVITES   ********************
+
+Writes iCalendar (.ics, RFC 5545) files with a single event, the format every
+calendar app imports. Times are written in UTC so no time zone definitions are
+needed, the calendar app shows them in the reader's own zone.
+
+The METHOD is PUBLISH: the invite adds the event to the calendar, there is no
+accept / decline round trip back to the server.
+
+ **************************************/
+
+// CalendarInvite is one event of an .ics file
+type CalendarInvite struct {
+	UID         string //? Stays the same for the same booking, importing it again updates the entry
+	Start       time.Time
+	End         time.Time
+	Summary     string
+	Location    string
+	Description string
+	URL         string
+}
+
+// ICS returns the invite as an iCalendar file
+func (inv CalendarInvite) ICS() []byte {
+	const layout = "20060102T150405Z"
+
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//Event Horizon//Bookings//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"BEGIN:VEVENT",
+		"UID:" + icsText(inv.UID),
+		"DTSTAMP:" + time.Now().UTC().Format(layout),
+		"DTSTART:" + inv.Start.UTC().Format(layout),
+		"DTEND:" + inv.End.UTC().Format(layout),
+		"SUMMARY:" + icsText(inv.Summary),
+	}
+	if inv.Location != "" {
+		lines = append(lines, "LOCATION:"+icsText(inv.Location))
+	}
+	if inv.Description != "" {
+		lines = append(lines, "DESCRIPTION:"+icsText(inv.Description))
+	}
+	if inv.URL != "" {
+		lines = append(lines, "URL:"+stripLineBreaks(inv.URL))
+	}
+	lines = append(lines, "END:VEVENT", "END:VCALENDAR")
+
+	var ics strings.Builder
+	for _, line := range lines {
+		ics.WriteString(foldICSLine(line))
+		ics.WriteString("\r\n")
+	}
+	return []byte(ics.String())
+}
+
+// icsText escapes a TEXT value: backslashes, semicolons, commas and line breaks
+func icsText(value string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", "").Replace(value)
+}
+
+// foldICSLine splits lines longer than 75 bytes, continuation lines start with a space. Never splits a UTF-8 character.
+func foldICSLine(line string) string {
+	var folded strings.Builder
+	length := 0
+	for _, r := range line {
+		size := len(string(r))
+		if length+size > 75 {
+			folded.WriteString("\r\n ")
+			length = 1
+		}
+		folded.WriteRune(r)
+		length += size
+	}
+	return folded.String()
+}
@@ -0,0 +1,112 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+/** *********************  CAPTCHA   ********************
+
+Bots register accounts, guess passwords and buy up tickets. A CAPTCHA is
+asked for where that happens:
+
+- POST /register, always
+- POST /login, once an email or IP failed to sign in CAPTCHA_LOGIN_FAILURES times
+- booking creation, once the buyer or IP hit BOOKING_VELOCITY_LIMIT
+
+The frontend solves the widget and sends its token as captcha_token. Both
+providers check tokens the same way (a siteverify form POST), CAPTCHA_PROVIDER
+picks the endpoint:
+
+- hcaptcha  - https://api.hcaptcha.com/siteverify
+- recaptcha - https://www.google.com/recaptcha/api/siteverify
+
+Without CAPTCHA_SECRET there is no verifier: registration and login never ask,
+bookings over the velocity limit are refused (429) like before.
+
+ **************************************/
+
+// ErrCaptchaInvalid is returned for a missing, wrong or expired CAPTCHA token
+var ErrCaptchaInvalid = errors.New("captcha token is missing or invalid")
+
+// CaptchaVerifier checks the token of a solved CAPTCHA
+type CaptchaVerifier interface {
+	// Verify returns ErrCaptchaInvalid for a bad token, other errors mean the provider could not be asked
+	Verify(ctx context.Context, token, remoteIP string) error
+}
+
+// Siteverify endpoints of the providers
+var captchaEndpoints = map[string]string{
+	"hcaptcha":  "https://api.hcaptcha.com/siteverify",
+	"recaptcha": "https://www.google.com/recaptcha/api/siteverify",
+}
+
+// SiteverifyCaptcha checks tokens with the siteverify API of hCaptcha or reCAPTCHA
+type SiteverifyCaptcha struct {
+	endpoint string
+	secret   string
+	client   *http.Client
+}
+
+// NewCaptchaVerifier creates a verifier for the provider (hcaptcha or recaptcha) with the secret key of the site
+func NewCaptchaVerifier(provider, secret string) (*SiteverifyCaptcha, error) {
+	endpoint, ok := captchaEndpoints[provider]
+	if !ok {
+		return nil, fmt.Errorf("unknown captcha provider %q", provider)
+	}
+	return &SiteverifyCaptcha{
+		endpoint: endpoint,
+		secret:   secret,
+		client:   &http.Client{Timeout: 5 * time.Second},
+	}, nil
+}
+
+// Verify asks the provider whether the token is a solved CAPTCHA
+func (v *SiteverifyCaptcha) Verify(ctx context.Context, token, remoteIP string) error {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return ErrCaptchaInvalid
+	}
+
+	form := url.Values{"secret": {v.secret}, "response": {token}}
+	if remoteIP != "" {
+		form.Set("remoteip", remoteIP)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, v.endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	res, err := v.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode >= 300 {
+		return fmt.Errorf("captcha: %s", res.Status)
+	}
+
+	var result struct {
+		Success    bool     `json:"success"`
+		ErrorCodes []string `json:"error-codes"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return err
+	}
+	for _, code := range result.ErrorCodes {
+		if strings.HasSuffix(code, "-input-secret") {
+			return fmt.Errorf("captcha: %s, check CAPTCHA_SECRET", code) //! Our mistake, not the user's
+		}
+	}
+	if !result.Success {
+		return ErrCaptchaInvalid
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package utils
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+)
+
+/** *********************  CHECK-IN CODES   ********************
+
+Every ticket of a booking belongs to one ATTENDEE with their own check-in
+code, the code is what the door scans (QR code or typed in). Codes are 12
+upper case hex characters, random enough that they can't be guessed and
+short enough to read out at the door.
+
+ **************************************/
+
+// GenerateCheckInCode returns a new random check-in code
+func GenerateCheckInCode() string {
+	bytes := make([]byte, 6)
+	rand.Read(bytes)
+	return strings.ToUpper(hex.EncodeToString(bytes))
+}
@@ -0,0 +1,77 @@
+package utils
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+/** *********************  CONDITIONAL GET (ETag / Last-Modified)   ********************
+
+Event responses carry an ETag and a Last-Modified header built from the
+events' updated_at. Browsers and CDNs send them back with If-None-Match /
+If-Modified-Since and get an empty 304 when nothing changed.
+
+Cache-Control is "no-cache": responses may be stored, but must be
+revalidated every time, so a booking shows up on the next request.
+
+ **************************************/
+
+// ETagVersion is one entry that makes up an ETag (e.g. an event ID and its updated_at)
+type ETagVersion struct {
+	ID        string
+	UpdatedAt time.Time
+}
+
+// BuildETag hashes the versions (and anything else the response depends on) into a weak ETag
+func BuildETag(variant string, versions ...ETagVersion) string {
+	hash := sha1.New()
+	hash.Write([]byte(variant))
+	for _, version := range versions {
+		hash.Write([]byte("|" + version.ID + "@" + version.UpdatedAt.UTC().Format(time.RFC3339Nano)))
+	}
+	return `W/"` + hex.EncodeToString(hash.Sum(nil)) + `"`
+}
+
+// CheckNotModified sets the validator headers and reports whether the client's copy is still fresh (answer 304)
+func CheckNotModified(c echo.Context, etag string, lastModified time.Time) bool {
+	res := c.Response().Header()
+	res.Set("ETag", etag)
+	res.Set("Cache-Control", "no-cache")
+	if !lastModified.IsZero() {
+		res.Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	req := c.Request()
+
+	//? If-None-Match wins over If-Modified-Since
+	if ifNoneMatch := req.Header.Get("If-None-Match"); ifNoneMatch != "" {
+		return etagMatches(ifNoneMatch, etag)
+	}
+
+	if ifModifiedSince := req.Header.Get("If-Modified-Since"); ifModifiedSince != "" && !lastModified.IsZero() {
+		since, err := http.ParseTime(ifModifiedSince)
+		if err != nil {
+			return false
+		}
+		//! HTTP dates have second precision
+		return !lastModified.Truncate(time.Second).After(since)
+	}
+
+	return false
+}
+
+// etagMatches compares an If-None-Match header against an ETag (weak comparison)
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
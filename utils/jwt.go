@@ -2,13 +2,13 @@ package utils
 
 import (
 	"errors"
-	"fmt"
-	"os"
-	"strings"
+	"event-horizon/models"
+	"slices"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/v2/bson"
 )
 
 /*********** JWT FUNCTIONALITY AND METHODS
@@ -25,36 +25,106 @@ import (
 
 6. token.SignedString - Sign token with secret key
 
-7. jwt.Parse - Parse and validate JWT token
+7. GetClaimsFromToken - The claims JWTMiddleware parsed, handlers never parse the Authorization header again
 
-8. jwt.MapClaims - Generic map for JWT claims
+8. GetUserIDFromToken / GetSessionIDFromToken - Single claims read from them
+
+9. Role / Scopes - What the user may do, read by middleware.RequireScope without loading the user
 
 */
 
+// Roles carried in the token, the highest one the user has
+const (
+	RoleUser  = "user"
+	RoleHost  = "host"
+	RoleAdmin = "admin"
+)
+
+// Scopes carried in the token
+const (
+	ScopeBookingsWrite = "bookings:write" //? Every user, book tickets
+	ScopeEventsWrite   = "events:write"   //? Hosts, create and manage events
+	ScopeHostReports   = "host:reports"   //? Hosts, exports and affiliates of their events
+	ScopeAdmin         = "admin"          //? Admins, the admin routes (AdminMiddleware still checks the database)
+)
+
 // JWTClaims represents the JWT claims structure
 type JWTClaims struct {
-	UserID string `json:"user_id"`
-	Email  string `json:"email"`
-	Name   string `json:"name"`
+	UserID string   `json:"user_id"`
+	Email  string   `json:"email"`
+	Name   string   `json:"name"`
+	Role   string   `json:"role"`   //? Empty in tokens issued before roles were added
+	Scopes []string `json:"scopes"` //? Refreshed through POST /users/me/token when the roles change
+
+	RememberMe bool `json:"remember_me,omitempty"` //? The session was started with remember me (long lived, persistent cookie)
 	jwt.RegisteredClaims
 }
 
-// GenerateJWT generates a new JWT token for a user
-func GenerateJWT(userID, email, name string) (string, error) {
+// HasScope reports whether the token carries the scope
+func (c *JWTClaims) HasScope(scope string) bool {
+	return slices.Contains(c.Scopes, scope)
+}
+
+// AccessFor returns the role and scopes of a user
+func AccessFor(user *models.User) (string, []string) {
+	role, scopes := RoleUser, []string{ScopeBookingsWrite}
+	if user.IsHost {
+		role = RoleHost
+		scopes = append(scopes, ScopeEventsWrite, ScopeHostReports)
+	}
+	if user.IsAdmin {
+		role = RoleAdmin
+		scopes = append(scopes, ScopeAdmin)
+	}
+	return role, scopes
+}
+
+// jwtSecret and the token lifetimes are set once at startup from the config (there is NO default secret)
+var (
+	jwtSecret     string
+	tokenTTL      = 30 * 24 * time.Hour //? "Remember me" sign ins
+	shortTokenTTL = 12 * time.Hour      //? Every other sign in
+)
 
-	//? secret from environment variable
-	secret := os.Getenv("JWT_SECRET")
+// errJWTNotConfigured is returned when the JWT secret was never configured
+var errJWTNotConfigured = errors.New("jwt secret is not configured")
+
+// ConfigureJWT sets the signing secret and the token lifetimes (remember me and short) from the app config
+func ConfigureJWT(secret string, ttl, shortTTL time.Duration) {
+	jwtSecret = secret
+	tokenTTL = ttl
+	shortTokenTTL = shortTTL
+}
+
+// GetTokenTTL returns how long a new session and its tokens stay valid
+func GetTokenTTL(rememberMe bool) time.Duration {
+	if rememberMe {
+		return tokenTTL
+	}
+	return shortTokenTTL
+}
+
+// GenerateJWT generates a new JWT token for a user with their role and scopes, tied to a session through the jti claim
+// and never outliving it
+func GenerateJWT(user *models.User, session *models.UserSession) (string, error) {
+
+	secret := GetJWTSecret()
 	if secret == "" {
-		secret = "your-secret-key" //! fallback
+		return "", errJWTNotConfigured
 	}
 
 	//! Create claims
+	role, scopes := AccessFor(user)
 	claims := JWTClaims{
-		UserID: userID,
-		Email:  email,
-		Name:   name,
+		UserID:     user.ID.Hex(),
+		Email:      user.Email,
+		Name:       user.Name,
+		Role:       role,
+		Scopes:     scopes,
+		RememberMe: session.RememberMe,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(30 * 24 * time.Hour)), // Token expires in 30 days
+			ID:        session.ID.Hex(),                      //! jti, lets the session be revoked
+			ExpiresAt: jwt.NewNumericDate(session.ExpiresAt), // Token expires with its session
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			NotBefore: jwt.NewNumericDate(time.Now()),
 		},
@@ -72,108 +142,63 @@ func GenerateJWT(userID, email, name string) (string, error) {
 	return tokenString, nil
 }
 
-// GetJWTSecret returns the JWT secret from environment
+// GetJWTSecret returns the configured JWT secret
 func GetJWTSecret() string {
-	secret := os.Getenv("JWT_SECRET")
-	if secret == "" {
-		secret = "your-secret-key" //! fallback
-	}
-	return secret
+	return jwtSecret
 }
 
-// GetUserIDFromToken extracts the user ID from the JWT token in the context
-func GetUserIDFromToken(c echo.Context) (string, error) {
-	user := c.Get("user")
-	if user == nil {
-		fmt.Println("User not found in context")
-		return "", errors.New("user not found in context")
-	}
-
-	token, ok := user.(*jwt.Token)
+// GetClaimsFromToken returns the claims JWTMiddleware parsed and stored in the context, the only place handlers read the token from
+func GetClaimsFromToken(c echo.Context) (*JWTClaims, error) {
+	token, ok := c.Get("user").(*jwt.Token)
 	if !ok {
-		fmt.Println("Invalid token format")
-		return "", errors.New("invalid token format")
-	}
-
-	if claims, ok := token.Claims.(*JWTClaims); ok {
-		if claims.UserID == "" {
-			fmt.Println("UserID is empty in token")
-			return "", errors.New("user_id is empty in token")
-		}
-		return claims.UserID, nil
+		return nil, errors.New("no token in context, the route must use JWTMiddleware")
 	}
 
-	//? Fallback to MapClaims if needed
-	claims, ok := token.Claims.(jwt.MapClaims)
+	claims, ok := token.Claims.(*JWTClaims)
 	if !ok {
-		fmt.Println("Invalid claims format 2nd attempt")
-		return "", errors.New("invalid claims format")
+		return nil, errors.New("invalid claims format")
 	}
 
-	userIDInterface, exists := claims["user_id"] //? Extract user_id from claims
-
-	if !exists {
-		fmt.Println("user_id claim not found")
-		return "", errors.New("user_id claim not found")
-	}
+	return claims, nil
+}
 
-	userID, ok := userIDInterface.(string)
-	//? Handle case where user_id is not a string
-	if !ok {
-		//* Sometimes float64
-		if userIDFloat, ok := userIDInterface.(float64); ok {
-			userID = fmt.Sprintf("%.0f", userIDFloat)
-		} else {
-			return "", fmt.Errorf("user_id has unexpected type: %T", userIDInterface)
-		}
+// GetUserIDFromToken extracts the user ID from the JWT token in the context
+func GetUserIDFromToken(c echo.Context) (string, error) {
+	claims, err := GetClaimsFromToken(c)
+	if err != nil {
+		return "", err
 	}
 
-	//? Check if userID is empty
-	if userID == "" {
-		return "", errors.New("user_id is empty")
+	if claims.UserID == "" {
+		return "", errors.New("user_id is empty in token")
 	}
-
-	println("Extracted user the userID from token")
-
-	return userID, nil
+	return claims.UserID, nil
 }
 
-func GetUserEmailFromToken(c echo.Context) (string, error) {
-
-	//? Get the Authorization header
-	authHeader := c.Request().Header.Get("Authorization")
-
-	//? Check if header is present
-	if authHeader == "" {
-		return "", fmt.Errorf("missing authorization header")
+// SessionFromToken returns the session of the JWT token in the context as far as the claims know it (ID, expiry, remember me)
+func SessionFromToken(c echo.Context) (*models.UserSession, error) {
+	claims, err := GetClaimsFromToken(c)
+	if err != nil {
+		return nil, err
 	}
 
-	//! Remove "Bearer " prefix
-	tokenString := strings.TrimPrefix(authHeader, "Bearer ")
-
-	//! Parse and validate token
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		return []byte(os.Getenv("JWT_SECRET")), nil
-	})
-
-	if err != nil || !token.Valid {
-		fmt.Println("Invalid token while extracting email")
-		return "", fmt.Errorf("invalid token")
+	sessionID, err := bson.ObjectIDFromHex(claims.ID)
+	if err != nil || claims.ExpiresAt == nil {
+		return nil, errors.New("session not found in token")
 	}
 
-	claims, ok := token.Claims.(jwt.MapClaims)
+	return &models.UserSession{ID: sessionID, ExpiresAt: claims.ExpiresAt.Time, RememberMe: claims.RememberMe}, nil
+}
 
-	//? extra check
-	if !ok {
-		return "", errors.New("invalid claims format")
+// GetSessionIDFromToken extracts the session ID (jti) from the JWT token in the context
+func GetSessionIDFromToken(c echo.Context) (string, error) {
+	claims, err := GetClaimsFromToken(c)
+	if err != nil {
+		return "", err
 	}
 
-	email, ok := claims["email"].(string)
-
-	//? Check if email claim exists and is a string
-	if !ok {
-		return "", errors.New("email not found in claims")
+	if claims.ID == "" {
+		return "", errors.New("session id not found in token")
 	}
-
-	return email, nil
+	return claims.ID, nil
 }
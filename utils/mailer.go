@@ -0,0 +1,268 @@
+package utils
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"event-horizon/reporting"
+	"fmt"
+	"log"
+	"mime"
+	"mime/quotedprintable"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+/** *********************  EMAIL   ********************
+
+Emails (guest booking links ...) go through a Mailer. With SMTP_HOST set the
+SMTPMailer sends them through that server, without it the LogMailer only
+writes them to the log, which is enough for local development.
+
+Mail is sent in the background with SendInBackground, a slow or broken mail
+server never fails or holds up the request that triggered it.
+
+Plain emails only have a text body. A Message can also have an HTML body,
+images the HTML shows inline (cid:) and attachments, it is sent as ONE MIME
+message:
+
+	multipart/mixed
+	├── multipart/related
+	│   ├── multipart/alternative (text/plain, text/html)
+	│   └── inline images
+	└── attachments (.ics ...)
+
+ **************************************/
+
+// Mailer sends emails
+type Mailer interface {
+	Send(to, subject, body string) error //? Plain text email
+	SendMessage(msg Message) error
+}
+
+// Message is an email with an optional HTML body, inline images and attachments
+type Message struct {
+	To          string
+	Subject     string
+	Text        string
+	HTML        string //? Optional, clients that can't show it show Text
+	Attachments []Attachment
+}
+
+// Attachment is a file of a Message, with a ContentID it is an inline image the HTML shows as <img src="cid:...">
+type Attachment struct {
+	Filename    string
+	ContentType string
+	ContentID   string
+	Data        []byte
+}
+
+// SMTPMailer sends emails through an SMTP server (STARTTLS when the server offers it)
+type SMTPMailer struct {
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+// NewSMTPMailer creates a mailer for the given server, username may be empty for servers without auth
+func NewSMTPMailer(host string, port int, username, password, from string) *SMTPMailer {
+	mailer := &SMTPMailer{
+		addr: fmt.Sprintf("%s:%d", host, port),
+		from: from,
+	}
+	if username != "" {
+		mailer.auth = smtp.PlainAuth("", username, password, host)
+	}
+	return mailer
+}
+
+// Send sends one email
+func (m *SMTPMailer) Send(to, subject, body string) error {
+	//! Header values come from users (names, emails), never let them add header lines
+	to = stripLineBreaks(to)
+	subject = stripLineBreaks(subject)
+
+	message := "From: " + m.from + "\r\n" +
+		"To: " + to + "\r\n" +
+		"Subject: " + subject + "\r\n" +
+		"Date: " + time.Now().Format(time.RFC1123Z) + "\r\n" +
+		"MIME-Version: 1.0\r\n" +
+		"Content-Type: text/plain; charset=UTF-8\r\n" +
+		"\r\n" +
+		strings.ReplaceAll(body, "\n", "\r\n")
+
+	return smtp.SendMail(m.addr, m.auth, senderAddress(m.from), []string{to}, []byte(message))
+}
+
+// SendMessage sends one email with its HTML body and attachments
+func (m *SMTPMailer) SendMessage(msg Message) error {
+	to := stripLineBreaks(msg.To)
+
+	var message bytes.Buffer
+	message.WriteString("From: " + m.from + "\r\n" +
+		"To: " + to + "\r\n" +
+		"Subject: " + mime.QEncoding.Encode("UTF-8", stripLineBreaks(msg.Subject)) + "\r\n" +
+		"Date: " + time.Now().Format(time.RFC1123Z) + "\r\n" +
+		"MIME-Version: 1.0\r\n")
+	writeMessageBody(&message, msg)
+
+	return smtp.SendMail(m.addr, m.auth, senderAddress(m.from), []string{to}, message.Bytes())
+}
+
+// writeMessageBody writes the Content-Type header and the body, nesting only the multiparts the message needs
+func writeMessageBody(buf *bytes.Buffer, msg Message) {
+	var inline, attached []Attachment
+	for _, attachment := range msg.Attachments {
+		if attachment.ContentID != "" && msg.HTML != "" {
+			inline = append(inline, attachment)
+		} else {
+			attached = append(attached, attachment)
+		}
+	}
+
+	//? Innermost first: the text (with its HTML alternative), then its inline images, then the attachments
+	part := textPart(msg.Text, "text/plain")
+	if msg.HTML != "" {
+		part = multipartPart("alternative", []mimePart{part, textPart(msg.HTML, "text/html")})
+	}
+	if len(inline) > 0 {
+		parts := []mimePart{part}
+		for _, attachment := range inline {
+			parts = append(parts, attachmentPart(attachment, "inline"))
+		}
+		part = multipartPart("related", parts)
+	}
+	if len(attached) > 0 {
+		parts := []mimePart{part}
+		for _, attachment := range attached {
+			parts = append(parts, attachmentPart(attachment, "attachment"))
+		}
+		part = multipartPart("mixed", parts)
+	}
+
+	part.writeTo(buf)
+}
+
+// mimePart is one part of a MIME message
+type mimePart struct {
+	header textproto.MIMEHeader
+	body   []byte
+}
+
+// writeTo writes the part's headers, a blank line and its body
+func (p mimePart) writeTo(buf *bytes.Buffer) {
+	for _, key := range []string{"Content-Type", "Content-Transfer-Encoding", "Content-Disposition", "Content-ID"} {
+		if value := p.header.Get(key); value != "" {
+			buf.WriteString(key + ": " + value + "\r\n")
+		}
+	}
+	buf.WriteString("\r\n")
+	buf.Write(p.body)
+}
+
+// textPart is a quoted-printable UTF-8 text part
+func textPart(text, contentType string) mimePart {
+	var body bytes.Buffer
+	writer := quotedprintable.NewWriter(&body)
+	writer.Write([]byte(strings.ReplaceAll(text, "\n", "\r\n")))
+	writer.Close()
+
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Type", contentType+"; charset=UTF-8")
+	header.Set("Content-Transfer-Encoding", "quoted-printable")
+	return mimePart{header: header, body: body.Bytes()}
+}
+
+// attachmentPart is a base64 file part, disposition is "inline" or "attachment"
+func attachmentPart(attachment Attachment, disposition string) mimePart {
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Type", stripLineBreaks(attachment.ContentType))
+	header.Set("Content-Transfer-Encoding", "base64")
+	header.Set("Content-Disposition", mime.FormatMediaType(disposition, map[string]string{"filename": attachment.Filename}))
+	if attachment.ContentID != "" {
+		header.Set("Content-ID", "<"+stripLineBreaks(attachment.ContentID)+">")
+	}
+
+	//? Base64 lines must not be longer than 76 characters
+	encoded := base64.StdEncoding.EncodeToString(attachment.Data)
+	var body bytes.Buffer
+	for len(encoded) > 76 {
+		body.WriteString(encoded[:76] + "\r\n")
+		encoded = encoded[76:]
+	}
+	body.WriteString(encoded + "\r\n")
+	return mimePart{header: header, body: body.Bytes()}
+}
+
+// multipartPart joins parts into a multipart/<kind> part
+func multipartPart(kind string, parts []mimePart) mimePart {
+	random := make([]byte, 16)
+	rand.Read(random)
+	boundary := "eh-" + hex.EncodeToString(random)
+
+	var body bytes.Buffer
+	for _, part := range parts {
+		body.WriteString("--" + boundary + "\r\n")
+		part.writeTo(&body)
+		body.WriteString("\r\n")
+	}
+	body.WriteString("--" + boundary + "--\r\n")
+
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Type", mime.FormatMediaType("multipart/"+kind, map[string]string{"boundary": boundary}))
+	return mimePart{header: header, body: body.Bytes()}
+}
+
+// LogMailer writes emails to the log instead of sending them
+type LogMailer struct{}
+
+// Send logs the email
+func (LogMailer) Send(to, subject, body string) error {
+	log.Printf("EMAIL (not sent, SMTP_HOST is not set) to %s: %s\n%s", to, subject, body)
+	return nil
+}
+
+// SendMessage logs the email with the names of its attachments
+func (LogMailer) SendMessage(msg Message) error {
+	names := make([]string, 0, len(msg.Attachments))
+	for _, attachment := range msg.Attachments {
+		names = append(names, fmt.Sprintf("%s (%d bytes)", attachment.Filename, len(attachment.Data)))
+	}
+	log.Printf("EMAIL (not sent, SMTP_HOST is not set) to %s: %s\nAttachments: %s\n%s", msg.To, msg.Subject, strings.Join(names, ", "), msg.Text)
+	return nil
+}
+
+// SendInBackground sends an email without blocking, failures are only logged
+func SendInBackground(mailer Mailer, to, subject, body string) {
+	go func() {
+		defer reporting.Recover("send email")
+		if err := mailer.Send(to, subject, body); err != nil {
+			log.Printf("Error sending %q email to %s: %v", subject, to, err)
+		}
+	}()
+}
+
+// SendMessageInBackground sends a Message without blocking, failures are only logged
+func SendMessageInBackground(mailer Mailer, msg Message) {
+	go func() {
+		defer reporting.Recover("send email")
+		if err := mailer.SendMessage(msg); err != nil {
+			log.Printf("Error sending %q email to %s: %v", msg.Subject, msg.To, err)
+		}
+	}()
+}
+
+// senderAddress returns the bare address of a From value like "Event Horizon <no-reply@example.com>"
+func senderAddress(from string) string {
+	if start := strings.LastIndex(from, "<"); start != -1 {
+		return strings.TrimSuffix(from[start+1:], ">")
+	}
+	return from
+}
+
+func stripLineBreaks(value string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(value)
+}
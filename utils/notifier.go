@@ -0,0 +1,179 @@
+package utils
+
+import (
+	"context"
+	"event-horizon/models"
+	"event-horizon/reporting"
+	"event-horizon/store"
+	"fmt"
+	"log"
+	"sync/atomic"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+/** *********************  FOLLOWER NOTIFICATION WORKER   ********************
+
+When a host publishes a new event, the controller hands the event to this
+worker. The worker runs in the background and fans the event out as in-app
+notifications to every follower of the host, so the HTTP request never waits
+on it.
+
+With SetFanOut the fan-out is a job of the JOB QUEUE (retried, survives
+restarts). The in-memory queue is only the fallback when the job can't be
+stored, an event it has no room for is logged and counted in Dropped.
+
+Every in-app notification goes through notifiable first: users that turned the
+type off in their preferences don't get it. Account notices (host suspended
+...) can't be turned off.
+
+
+ **************************************/
+
+// NotificationWorker fans out new-event notifications to followers in the background
+type NotificationWorker struct {
+	followStore       store.FollowRepository
+	notificationStore store.NotificationRepository
+	userStore         store.UserRepository
+	queue             chan models.Event
+	fanOut            func(ctx context.Context, event models.Event) error // optional, enqueues the fan-out as a job
+	dropped           atomic.Int64
+}
+
+// StartNotificationWorker starts the background worker that notifies followers of new events
+func StartNotificationWorker(followStore store.FollowRepository, notificationStore store.NotificationRepository, userStore store.UserRepository) *NotificationWorker {
+	worker := &NotificationWorker{
+		followStore:       followStore,
+		notificationStore: notificationStore,
+		userStore:         userStore,
+		queue:             make(chan models.Event, 100),
+	}
+
+	//! RUN IN CONCURRENT GO ROUTINE
+	go func() {
+		for event := range worker.queue {
+			worker.notifyFollowersNow(event)
+		}
+	}()
+
+	log.Println("NOTIFICATION WORKER STARTED")
+
+	return worker
+}
+
+// SetFanOut hands new events to a durable queue (the job queue) instead of the in-memory one, set it before the server starts
+func (w *NotificationWorker) SetFanOut(fanOut func(ctx context.Context, event models.Event) error) {
+	w.fanOut = fanOut
+}
+
+// NotifyNewEvent queues an event so its host's followers get notified
+func (w *NotificationWorker) NotifyNewEvent(event models.Event) {
+	if w.fanOut != nil {
+		err := w.fanOut(context.Background(), event)
+		if err == nil {
+			return
+		}
+		log.Printf("Error enqueueing the follower notifications of event %s, notifying in memory: %v", event.ID.Hex(), err)
+	}
+
+	select {
+	case w.queue <- event:
+	default:
+		//! Never block the request if the queue is full, but never lose an event without a trace either
+		dropped := w.dropped.Add(1)
+		log.Printf("Notification queue full, dropping notifications for event %s (%d dropped since start)", event.ID.Hex(), dropped)
+		reporting.CaptureError(context.Background(), fmt.Errorf("notification queue full, dropped the follower notifications of event %s", event.ID.Hex()), "notify followers", nil)
+	}
+}
+
+// Dropped returns how many new events got no follower notifications because the in-memory queue was full
+func (w *NotificationWorker) Dropped() int64 {
+	return w.dropped.Load()
+}
+
+// notifiable returns the users that want in-app notifications of the type, on errors nobody gets it (never send against a user's wishes)
+func (w *NotificationWorker) notifiable(ctx context.Context, userIDs []bson.ObjectID, notificationType string) []bson.ObjectID {
+	if !models.IsConfigurable(notificationType, models.ChannelInApp) || len(userIDs) == 0 {
+		return userIDs
+	}
+
+	allowed, err := w.userStore.FilterNotifiable(ctx, userIDs, notificationType, models.ChannelInApp)
+	if err != nil {
+		log.Printf("Error reading notification preferences for %s notifications: %v", notificationType, err)
+		return nil
+	}
+	return allowed
+}
+
+// NotifyUser sends a single in-app notification in the background
+func (w *NotificationWorker) NotifyUser(userID bson.ObjectID, notificationType, message string, eventID bson.ObjectID) {
+	w.NotifyUsers([]bson.ObjectID{userID}, notificationType, message, eventID)
+}
+
+// NotifyUsers sends the same in-app notification to many users in the background
+func (w *NotificationWorker) NotifyUsers(userIDs []bson.ObjectID, notificationType, message string, eventID bson.ObjectID) {
+	if len(userIDs) == 0 {
+		return
+	}
+
+	go func() {
+		defer reporting.Recover("notify users")
+		ctx := context.Background()
+
+		recipients := w.notifiable(ctx, userIDs, notificationType)
+		if len(recipients) == 0 {
+			return
+		}
+
+		notifications := make([]models.Notification, 0, len(recipients))
+		for _, userID := range recipients {
+			notifications = append(notifications, models.Notification{
+				UserID:  userID,
+				Type:    notificationType,
+				Message: message,
+				EventID: eventID,
+			})
+		}
+
+		if err := w.notificationStore.CreateNotifications(ctx, notifications); err != nil {
+			log.Printf("Error creating %s notifications for event %s: %v", notificationType, eventID.Hex(), err)
+		}
+	}()
+}
+
+// notifyFollowersNow runs the fan-out of the in-memory queue, errors are only logged
+func (w *NotificationWorker) notifyFollowersNow(event models.Event) {
+	defer reporting.Recover("notify followers")
+	if err := w.NotifyFollowers(context.Background(), event); err != nil {
+		log.Printf("Error notifying the followers of event %s: %v", event.ID.Hex(), err)
+	}
+}
+
+// ! FAN OUT FUNCTION, NotifyFollowers notifies every follower of the event's host, it is also the handler of the fan-out job
+func (w *NotificationWorker) NotifyFollowers(ctx context.Context, event models.Event) error {
+	followerIDs, err := w.followStore.GetFollowerIDs(ctx, event.HostID)
+	if err != nil {
+		return fmt.Errorf("fetching followers for host %s: %w", event.HostID.Hex(), err)
+	}
+
+	followerIDs = w.notifiable(ctx, followerIDs, models.NotificationNewEvent)
+
+	notifications := make([]models.Notification, 0, len(followerIDs))
+	for _, followerID := range followerIDs {
+		notifications = append(notifications, models.Notification{
+			UserID:  followerID,
+			Type:    models.NotificationNewEvent,
+			Message: "A host you follow published a new event: " + event.Name,
+			EventID: event.ID,
+		})
+	}
+
+	if err := w.notificationStore.CreateNotifications(ctx, notifications); err != nil {
+		return fmt.Errorf("creating notifications for event %s: %w", event.ID.Hex(), err)
+	}
+
+	if len(notifications) > 0 {
+		log.Printf("Notified %d follower(s) of event %s", len(notifications), event.ID.Hex())
+	}
+	return nil
+}
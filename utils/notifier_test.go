@@ -0,0 +1,108 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"event-horizon/models"
+	"event-horizon/store"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// stubFollowers has two followers for every host and keeps the notifications it is given
+type stubFollowers struct {
+	store.FollowRepository
+	store.NotificationRepository
+	store.UserRepository
+	followers []bson.ObjectID
+	created   []models.Notification
+	failing   error
+}
+
+func (s *stubFollowers) GetFollowerIDs(ctx context.Context, hostID bson.ObjectID) ([]bson.ObjectID, error) {
+	return s.followers, nil
+}
+
+func (s *stubFollowers) FilterNotifiable(ctx context.Context, userIDs []bson.ObjectID, notificationType, channel string) ([]bson.ObjectID, error) {
+	return userIDs, nil
+}
+
+func (s *stubFollowers) CreateNotifications(ctx context.Context, notifications []models.Notification) error {
+	if s.failing != nil {
+		return s.failing
+	}
+	s.created = append(s.created, notifications...)
+	return nil
+}
+
+// newTestWorker returns a worker with room for one event and nothing reading its queue
+func newTestWorker(stub *stubFollowers) *NotificationWorker {
+	return &NotificationWorker{
+		followStore:       stub,
+		notificationStore: stub,
+		userStore:         stub,
+		queue:             make(chan models.Event, 1),
+	}
+}
+
+func TestNotifyNewEventCountsDrops(t *testing.T) {
+	worker := newTestWorker(&stubFollowers{})
+
+	worker.NotifyNewEvent(models.Event{ID: bson.NewObjectID()})
+	if worker.Dropped() != 0 {
+		t.Fatalf("Dropped() = %d with room in the queue, want 0", worker.Dropped())
+	}
+
+	//! The queue is full, the next two events are dropped but counted
+	worker.NotifyNewEvent(models.Event{ID: bson.NewObjectID()})
+	worker.NotifyNewEvent(models.Event{ID: bson.NewObjectID()})
+	if worker.Dropped() != 2 {
+		t.Fatalf("Dropped() = %d, want 2", worker.Dropped())
+	}
+}
+
+func TestNotifyNewEventUsesFanOut(t *testing.T) {
+	stub := &stubFollowers{followers: []bson.ObjectID{bson.NewObjectID(), bson.NewObjectID()}}
+	worker := newTestWorker(stub)
+
+	var enqueued []models.Event
+	var enqueueErr error
+	worker.SetFanOut(func(ctx context.Context, event models.Event) error {
+		if enqueueErr != nil {
+			return enqueueErr
+		}
+		enqueued = append(enqueued, event)
+		return nil
+	})
+
+	//? An enqueued event never takes room in the in-memory queue
+	for i := 0; i < 3; i++ {
+		worker.NotifyNewEvent(models.Event{ID: bson.NewObjectID(), Name: "Jazz Night"})
+	}
+	if len(enqueued) != 3 || len(worker.queue) != 0 || worker.Dropped() != 0 {
+		t.Fatalf("enqueued %d, in memory %d, dropped %d, want 3, 0, 0", len(enqueued), len(worker.queue), worker.Dropped())
+	}
+
+	//? The job handler notifies every follower
+	if err := worker.NotifyFollowers(context.Background(), enqueued[0]); err != nil {
+		t.Fatalf("NotifyFollowers: %v", err)
+	}
+	if len(stub.created) != 2 || stub.created[0].EventID != enqueued[0].ID {
+		t.Fatalf("created %v, want one notification per follower", stub.created)
+	}
+
+	//! A failed insert is returned, so the job is retried
+	stub.failing = errors.New("write concern timeout")
+	if err := worker.NotifyFollowers(context.Background(), enqueued[1]); !errors.Is(err, stub.failing) {
+		t.Fatalf("NotifyFollowers = %v, want the insert error", err)
+	}
+
+	//? When the job can't be stored the event falls back to the in-memory queue
+	enqueueErr = errors.New("jobs collection unavailable")
+	worker.NotifyNewEvent(models.Event{ID: bson.NewObjectID()})
+	if len(worker.queue) != 1 {
+		t.Fatalf("in memory %d after a failed enqueue, want 1", len(worker.queue))
+	}
+}
@@ -0,0 +1,121 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+/** *********************  PDF DOCUMENTS   ********************
+
+A tiny PDF writer for server-side documents like booking receipts. It only
+knows what those need: A4 pages, text in Helvetica / Helvetica-Bold and
+straight lines. Both fonts are built into every PDF reader, so nothing is
+embedded and no PDF library is needed.
+
+Positions are in points (1/72 inch) from the TOP-LEFT corner of the page,
+PDF itself counts from the bottom, the writer flips it.
+
+Text is encoded as WinAnsi (Latin-1), characters outside of it print as "?".
+
+ **************************************/
+
+const (
+	PDFPageWidth  = 595.0 //? A4
+	PDFPageHeight = 842.0
+)
+
+// PDF is a document being written, page by page
+type PDF struct {
+	pages []*bytes.Buffer
+}
+
+// NewPDF creates a document with one empty page
+func NewPDF() *PDF {
+	pdf := &PDF{}
+	pdf.AddPage()
+	return pdf
+}
+
+// AddPage starts a new page, everything drawn after it goes there
+func (p *PDF) AddPage() {
+	p.pages = append(p.pages, &bytes.Buffer{})
+}
+
+func (p *PDF) page() *bytes.Buffer {
+	return p.pages[len(p.pages)-1]
+}
+
+// Text draws text with its baseline at (x, y)
+func (p *PDF) Text(x, y, size float64, bold bool, text string) {
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+	fmt.Fprintf(p.page(), "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, PDFPageHeight-y, pdfString(text))
+}
+
+// Line draws a thin line from (x1, y1) to (x2, y2)
+func (p *PDF) Line(x1, y1, x2, y2 float64) {
+	fmt.Fprintf(p.page(), "0.5 w %.2f %.2f m %.2f %.2f l S\n", x1, PDFPageHeight-y1, x2, PDFPageHeight-y2)
+}
+
+// Bytes renders the finished document
+func (p *PDF) Bytes() []byte {
+	var out bytes.Buffer
+	offsets := []int{}
+
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	//! Objects 1-4 are fixed, then a page and its content stream per page
+	out.WriteString("%PDF-1.4\n")
+
+	kids := make([]string, len(p.pages))
+	for i := range p.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+i*2)
+	}
+
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(p.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	for i, content := range p.pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			PDFPageWidth, PDFPageHeight, 6+i*2))
+		object(fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", content.Len(), content.String()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return out.Bytes()
+}
+
+// pdfString escapes text for a PDF string literal in WinAnsi
+func pdfString(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '\n' || r == '\r' || r == '\t':
+			b.WriteByte(' ')
+		case r >= 32 && r < 127:
+			b.WriteRune(r)
+		case r >= 160 && r <= 255:
+			fmt.Fprintf(&b, "\\%03o", r) //? Latin-1 matches WinAnsi in this range
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,21 @@
+package utils
+
+import (
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+/** *********************  QR CODES   ********************
+
+Ticket check-in codes are attached to the confirmation emails as QR codes.
+The encoding is left to github.com/skip2/go-qrcode, with error correction
+level M (15% of the code can be damaged) and the 4 module quiet zone
+scanners need around the code.
+
+ **************************************/
+
+const qrModulePixels = 8 //? Size of one module in the PNG
+
+// QRCodePNG encodes text as a QR code and returns it as a PNG image
+func QRCodePNG(text string) ([]byte, error) {
+	return qrcode.Encode(text, qrcode.Medium, -qrModulePixels) //? A negative size is pixels per module
+}
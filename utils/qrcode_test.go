@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+)
+
+func TestQRCodePNGCheckInCode(t *testing.T) {
+	data, err := QRCodePNG(GenerateCheckInCode())
+	if err != nil {
+		t.Fatalf("QRCodePNG: %v", err)
+	}
+
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	//? A check-in code fits a version 1 code: 21 modules and the quiet zone on both sides
+	const modules = 21 + 2*4
+	if size := img.Bounds().Size(); size.X != modules*qrModulePixels || size.Y != modules*qrModulePixels {
+		t.Fatalf("image is %v, want %dx%d", size, modules*qrModulePixels, modules*qrModulePixels)
+	}
+
+	dark := func(moduleX, moduleY int) bool {
+		r, _, _, _ := img.At(moduleX*qrModulePixels+qrModulePixels/2, moduleY*qrModulePixels+qrModulePixels/2).RGBA()
+		return r < 0x8000
+	}
+
+	//? The quiet zone is light, the top left finder pattern starts right after it
+	if dark(0, 0) || dark(3, 3) {
+		t.Fatal("quiet zone is not light")
+	}
+	for _, m := range [][2]int{{4, 4}, {10, 4}, {4, 10}, {7, 7}} {
+		if !dark(m[0], m[1]) {
+			t.Fatalf("finder module %v is not dark", m)
+		}
+	}
+	if dark(5, 5) {
+		t.Fatal("finder ring module (5,5) is not light")
+	}
+}
+
+func TestQRCodePNGTooLong(t *testing.T) {
+	if _, err := QRCodePNG(string(make([]byte, 4000))); err == nil {
+		t.Fatal("QRCodePNG accepted text that doesn't fit any QR version")
+	}
+}
@@ -0,0 +1,91 @@
+package utils
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+/** *********************  RSS FEEDS   ********************
+
+Writes RSS 2.0 feeds (https://www.rssboard.org/rss-specification), the format
+community sites, newsletter tools and feed readers all import. The item link
+doubles as its guid, so an event edited later is not shown as a new item.
+
+ **************************************/
+
+// Feed is an RSS channel
+type Feed struct {
+	Title       string
+	Link        string
+	Description string
+	Items       []FeedItem
+}
+
+// FeedItem is one entry of a feed
+type FeedItem struct {
+	Title       string
+	Link        string
+	Description string
+	Category    string
+	PubDate     time.Time
+}
+
+type rssDocument struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link"`
+	Description string  `xml:"description,omitempty"`
+	Category    string  `xml:"category,omitempty"`
+	GUID        rssGUID `xml:"guid"`
+	PubDate     string  `xml:"pubDate,omitempty"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+// RSS returns the feed as an RSS 2.0 document
+func (f Feed) RSS() ([]byte, error) {
+	doc := rssDocument{
+		Version: "2.0",
+		Channel: rssChannel{
+			Title:         f.Title,
+			Link:          f.Link,
+			Description:   f.Description,
+			LastBuildDate: time.Now().UTC().Format(time.RFC1123Z),
+		},
+	}
+	for _, item := range f.Items {
+		entry := rssItem{
+			Title:       item.Title,
+			Link:        item.Link,
+			Description: item.Description,
+			Category:    item.Category,
+			GUID:        rssGUID{IsPermaLink: true, Value: item.Link},
+		}
+		if !item.PubDate.IsZero() {
+			entry.PubDate = item.PubDate.UTC().Format(time.RFC1123Z)
+		}
+		doc.Channel.Items = append(doc.Channel.Items, entry)
+	}
+
+	body, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
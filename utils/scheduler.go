@@ -2,50 +2,321 @@ package utils
 
 import (
 	"context"
+	"errors"
+	"event-horizon/models"
+	"event-horizon/reporting"
 	"event-horizon/store"
+	"fmt"
 	"log"
+	"os"
+	"sort"
+	"sync"
 	"time"
 )
 
 /** *********************  EVENT CLEANUP SCHEDULER   ********************
 
 This scheduler runs in the background and periodically deletes expired events
-from the database to keep it clean and efficient.
+from the database to keep it clean and efficient. Events are kept for the
+retention period after they end (EVENT_RETENTION_DAYS) and deleted in batches
+(CLEANUP_BATCH_SIZE).
 
+The publish scheduler next to it puts drafts live once their publish_at has
+come (SCHEDULED publishing), hosts don't have to be there to click publish.
+
+The reconfirmation scheduler refunds the bookings of RESCHEDULED events that
+their buyers didn't reconfirm before the deadline.
+
+The outbox relay hands the OUTBOX messages written by booking transactions to
+the booking hooks, a message is only done once every hook took it.
+
+A panic in a run is reported and recovered, the scheduler keeps ticking.
+
+Every scheduler is registered in SCHEDULERS, which records each run (time,
+duration, count, error) in the Schedulers collection for the admin dashboard
+(GET /admin/jobs) and lets an admin start a run right away
+(POST /admin/jobs/:name/run). A run never overlaps another run of the same
+scheduler on this instance, a tick that comes while one is going is skipped.
 
  **************************************/
 
-// StartEventCleanupScheduler starts a background job that deletes expired events periodically
-func StartEventCleanupScheduler(eventStore *store.EventStore) {
+// Names of the schedulers, POST /admin/jobs/:name/run
+const (
+	SchedulerCleanup        = "cleanup"
+	SchedulerPublish        = "publish"
+	SchedulerReconfirmation = "reconfirmation"
+	SchedulerOutbox         = "outbox"
+)
+
+// ErrUnknownScheduler is returned when no scheduler has the name
+var ErrUnknownScheduler = errors.New("no scheduler with this name")
+
+// ErrSchedulerBusy is returned when the scheduler is already running on this instance
+var ErrSchedulerBusy = errors.New("the scheduler is running right now")
+
+// ErrSchedulerStillRunning is returned when a run started by RunNow outlives the request, it goes on in the background
+var ErrSchedulerStillRunning = errors.New("the run is still going, its outcome will show up in the dashboard")
+
+// ScheduledTask is one background job that runs every Interval and returns how many things it handled
+type ScheduledTask struct {
+	Name        string
+	Description string
+	CountLabel  string //? What the count is, e.g. "events deleted"
+	Interval    time.Duration
+	Timeout     time.Duration //? Bounds one run
+	Run         func(ctx context.Context) (int64, error)
+
+	running sync.Mutex
+}
+
+// Schedulers starts the background schedulers and records their runs
+type Schedulers struct {
+	runs     store.SchedulerRepository
+	instance string
+
+	mu    sync.Mutex
+	tasks map[string]*ScheduledTask
+}
+
+// NewSchedulers creates the registry, runs are recorded in the scheduler store
+func NewSchedulers(runs store.SchedulerRepository) *Schedulers {
+	instance, _ := os.Hostname()
+	return &Schedulers{
+		runs:     runs,
+		instance: instance,
+		tasks:    make(map[string]*ScheduledTask),
+	}
+}
+
+// Start registers the task, runs it right away and then every interval
+func (s *Schedulers) Start(task *ScheduledTask) {
+	s.mu.Lock()
+	s.tasks[task.Name] = task
+	s.mu.Unlock()
+
+	ticker := time.NewTicker(task.Interval)
 
-	//! Run  every hour
-	ticker := time.NewTicker(1 * time.Hour)
+	tick := func() {
+		if !task.running.TryLock() {
+			return //? An admin started a run that is still going
+		}
+		defer task.running.Unlock()
+		s.run(task, models.TriggerSchedule)
+	}
 
 	//! RUN IN CONCURRENT GO ROUTINE
 	go func() {
 		//! Run on startup
-		runCleanup(eventStore)
+		tick()
 
 		//! run periodically
 		for range ticker.C {
-			runCleanup(eventStore)
+			tick()
+		}
+	}()
+}
+
+// Names returns the names of the registered schedulers
+func (s *Schedulers) Names() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	names := make([]string, 0, len(s.tasks))
+	for name := range s.tasks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// RunNow runs a scheduler right away and returns it with the outcome, ErrSchedulerStillRunning when ctx ends first
+func (s *Schedulers) RunNow(ctx context.Context, name string) (*models.Scheduler, error) {
+	s.mu.Lock()
+	task := s.tasks[name]
+	s.mu.Unlock()
+
+	if task == nil {
+		return nil, ErrUnknownScheduler
+	}
+	if !task.running.TryLock() {
+		return nil, ErrSchedulerBusy
+	}
+
+	type outcome struct {
+		scheduler *models.Scheduler
+		err       error
+	}
+	done := make(chan outcome, 1)
+
+	//? Not tied to the request, a long cleanup isn't cut short by the request timeout
+	go func() {
+		defer task.running.Unlock()
+		scheduler, err := s.run(task, models.TriggerManual)
+		done <- outcome{scheduler, err}
+	}()
+
+	select {
+	case result := <-done:
+		return result.scheduler, result.err
+	case <-ctx.Done():
+		return nil, ErrSchedulerStillRunning
+	}
+}
+
+// run runs the task once and records the outcome, the error is the recording's
+func (s *Schedulers) run(task *ScheduledTask, trigger string) (*models.Scheduler, error) {
+	run := models.SchedulerRun{
+		Name:        task.Name,
+		Description: task.Description,
+		Interval:    task.Interval,
+		CountLabel:  task.CountLabel,
+		Trigger:     trigger,
+		Instance:    s.instance,
+		StartedAt:   time.Now(),
+	}
+	run.Count, run.Err = task.call()
+	run.FinishedAt = time.Now()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	scheduler, err := s.runs.RecordSchedulerRun(ctx, run)
+	if err != nil {
+		log.Printf("Error recording the %s run: %v", task.Name, err)
+	}
+	return scheduler, err
+}
+
+// call runs the task within its timeout, a panic is reported and becomes the run's error
+func (t *ScheduledTask) call() (count int64, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), t.Timeout)
+	defer cancel()
+
+	defer func() {
+		if value := recover(); value != nil {
+			reporting.CapturePanic(ctx, value, t.Name, nil)
+			err = fmt.Errorf("panic: %v", value)
 		}
 	}()
 
+	return t.Run(ctx)
+}
+
+// StartEventCleanupScheduler starts a background job that deletes expired events periodically
+func StartEventCleanupScheduler(schedulers *Schedulers, eventStore store.EventRepository, policy store.CleanupPolicy, interval time.Duration) {
+	schedulers.Start(&ScheduledTask{
+		Name:        SchedulerCleanup,
+		Description: "Archives events and deletes them (and their bookings) once they ended longer than the retention period ago",
+		CountLabel:  "events deleted",
+		Interval:    interval,
+		Timeout:     interval, //? A cleanup run never overlaps the next one
+		Run: func(ctx context.Context) (int64, error) {
+			return runCleanup(ctx, eventStore, policy)
+		},
+	})
+
 	log.Println("EVENT CLEANUP STARTED")
 }
 
 // ! CLEAN UP FUNCTION
-func runCleanup(eventStore *store.EventStore) {
-	ctx := context.Background()
-	deletedCount, err := eventStore.DeleteExpiredEvents(ctx)
+func runCleanup(ctx context.Context, eventStore store.EventRepository, policy store.CleanupPolicy) (int64, error) {
+	deletedCount, err := eventStore.DeleteExpiredEvents(ctx, policy)
 
+	//? Events that failed are retried on the next run, the others are gone
 	if err != nil {
 		log.Printf("Error cleaning up expired events: %v", err)
-		return
 	}
 
 	if deletedCount > 0 {
 		log.Printf("Successfully deleted %d expired event(s)", deletedCount)
 	}
+	return deletedCount, err
+}
+
+// StartPublishScheduler starts a background job that publishes the drafts scheduled with publish_at
+func StartPublishScheduler(schedulers *Schedulers, publishScheduled func(ctx context.Context) (int, error), interval time.Duration) {
+	schedulers.Start(&ScheduledTask{
+		Name:        SchedulerPublish,
+		Description: "Publishes the drafts whose publish_at has come",
+		CountLabel:  "events published",
+		Interval:    interval,
+		Timeout:     interval,
+		Run: func(ctx context.Context) (int64, error) {
+			return runPublish(ctx, publishScheduled)
+		},
+	})
+
+	log.Println("SCHEDULED PUBLISHING STARTED")
+}
+
+// ! PUBLISH FUNCTION
+func runPublish(ctx context.Context, publishScheduled func(ctx context.Context) (int, error)) (int64, error) {
+	published, err := publishScheduled(ctx)
+	if err != nil {
+		log.Printf("Error publishing scheduled events: %v", err)
+		return int64(published), err
+	}
+
+	if published > 0 {
+		log.Printf("Published %d scheduled event(s)", published)
+	}
+	return int64(published), nil
+}
+
+// StartReconfirmationScheduler starts a background job that refunds the bookings nobody reconfirmed after a reschedule
+func StartReconfirmationScheduler(schedulers *Schedulers, refundUnconfirmed func(ctx context.Context) (int, error), interval time.Duration) {
+	schedulers.Start(&ScheduledTask{
+		Name:        SchedulerReconfirmation,
+		Description: "Refunds the bookings of rescheduled events that weren't reconfirmed in time",
+		CountLabel:  "bookings refunded",
+		Interval:    interval,
+		Timeout:     interval,
+		Run: func(ctx context.Context) (int64, error) {
+			return runRefunds(ctx, refundUnconfirmed)
+		},
+	})
+
+	log.Println("RECONFIRMATION REFUNDS STARTED")
+}
+
+// ! REFUND FUNCTION
+func runRefunds(ctx context.Context, refundUnconfirmed func(ctx context.Context) (int, error)) (int64, error) {
+	refunded, err := refundUnconfirmed(ctx)
+	if err != nil {
+		log.Printf("Error refunding unconfirmed bookings: %v", err)
+		return int64(refunded), err
+	}
+
+	if refunded > 0 {
+		log.Printf("Refunded %d unconfirmed booking(s)", refunded)
+	}
+	return int64(refunded), nil
+}
+
+// outboxRelayTimeout bounds one relay run, the relay ticks much more often than the other schedulers
+const outboxRelayTimeout = 30 * time.Second
+
+// StartOutboxRelay starts a background job that delivers the outbox messages of committed booking transactions
+func StartOutboxRelay(schedulers *Schedulers, deliverOutbox func(ctx context.Context) (int, error), interval time.Duration) {
+	schedulers.Start(&ScheduledTask{
+		Name:        SchedulerOutbox,
+		Description: "Hands the outbox messages of booking transactions to the booking hooks",
+		CountLabel:  "messages delivered",
+		Interval:    interval,
+		Timeout:     outboxRelayTimeout,
+		Run: func(ctx context.Context) (int64, error) {
+			return runOutboxRelay(ctx, deliverOutbox)
+		},
+	})
+
+	log.Println("OUTBOX RELAY STARTED")
+}
+
+// ! OUTBOX RELAY FUNCTION
+func runOutboxRelay(ctx context.Context, deliverOutbox func(ctx context.Context) (int, error)) (int64, error) {
+	delivered, err := deliverOutbox(ctx)
+	if err != nil {
+		log.Printf("Error delivering outbox messages: %v", err)
+	}
+	return int64(delivered), err
 }
@@ -0,0 +1,47 @@
+package utils
+
+import (
+	"crypto/rand"
+	"strings"
+)
+
+/** *********************  SHARE CODES   ********************
+
+Share links are short URLs (/s/<code>) a host posts on one channel
+(instagram, newsletter ...). The code rides along as ?ref= on the event
+page and in the booking request, so bookings can be attributed to the link
+they came from.
+
+Codes are 8 lower case characters without the look-alikes (0 o 1 l i), so
+they survive being typed from a poster.
+
+ **************************************/
+
+const (
+	shareCodeAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+	shareCodeLength   = 8
+)
+
+// GenerateShareCode returns a new random share code
+func GenerateShareCode() string {
+	bytes := make([]byte, shareCodeLength)
+	rand.Read(bytes)
+	for i, b := range bytes {
+		bytes[i] = shareCodeAlphabet[int(b)%len(shareCodeAlphabet)]
+	}
+	return string(bytes)
+}
+
+// NormalizeShareCode trims and lower cases a code and checks it could be a share code
+func NormalizeShareCode(code string) (string, bool) {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if len(code) != shareCodeLength {
+		return "", false
+	}
+	for _, r := range code {
+		if !strings.ContainsRune(shareCodeAlphabet, r) {
+			return "", false
+		}
+	}
+	return code, true
+}
@@ -0,0 +1,86 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+/** *********************  NAME SIMILARITY   ********************
+
+Used to warn hosts about near-identical event listings. Names are compared
+after NormalizeName (lower case, only letters and digits, single spaces),
+so "Summer Fest 2025!" and "summer fest 2025" are the same name.
+
+NameSimilarity is the better of two scores between 0 and 1:
+
+- the edit distance score catches typos ("Sumer Fest" / "Summer Fest")
+- the word overlap score catches reordered words ("2025 Summer Fest" / "Summer Fest 2025")
+
+ **************************************/
+
+// NormalizeName lower cases a name and keeps only letters and digits, words are separated by one space
+func NormalizeName(name string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
+
+// NameSimilarity scores how alike two names are, 1 means the same name
+func NameSimilarity(a, b string) float64 {
+	a, b = NormalizeName(a), NormalizeName(b)
+	if a == b {
+		return 1
+	}
+	if a == "" || b == "" {
+		return 0
+	}
+
+	edit := editSimilarity([]rune(a), []rune(b))
+	words := wordOverlap(strings.Fields(a), strings.Fields(b))
+	if words > edit {
+		return words
+	}
+	return edit
+}
+
+// editSimilarity is 1 - the Levenshtein distance divided by the longer length
+func editSimilarity(a, b []rune) float64 {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return 1 - float64(prev[len(b)])/float64(max(len(a), len(b)))
+}
+
+// wordOverlap is the number of shared words divided by the number of different words (Jaccard index)
+func wordOverlap(a, b []string) float64 {
+	set := make(map[string]int, len(a)+len(b))
+	for _, word := range a {
+		set[word] |= 1
+	}
+	for _, word := range b {
+		set[word] |= 2
+	}
+
+	shared := 0
+	for _, in := range set {
+		if in == 3 {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(set))
+}
@@ -0,0 +1,56 @@
+package utils
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+/** *********************  SITEMAPS   ********************
+
+Writes XML sitemaps (https://www.sitemaps.org/protocol.html) so search engines
+find every public event page. One sitemap holds at most 50,000 URLs, lastmod
+is written as a W3C date in UTC.
+
+ **************************************/
+
+// MaxSitemapURLs is the most URLs one sitemap may list
+const MaxSitemapURLs = 50000
+
+// SitemapURL is one page of a sitemap
+type SitemapURL struct {
+	Loc     string
+	LastMod time.Time //? Zero leaves lastmod out
+}
+
+type sitemapURLSet struct {
+	XMLName xml.Name          `xml:"urlset"`
+	Xmlns   string            `xml:"xmlns,attr"`
+	URLs    []sitemapURLEntry `xml:"url"`
+}
+
+type sitemapURLEntry struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// Sitemap returns the URLs as a sitemap document, URLs past MaxSitemapURLs are dropped
+func Sitemap(urls []SitemapURL) ([]byte, error) {
+	if len(urls) > MaxSitemapURLs {
+		urls = urls[:MaxSitemapURLs]
+	}
+
+	set := sitemapURLSet{Xmlns: "http://www.sitemaps.org/schemas/sitemap/0.9", URLs: []sitemapURLEntry{}}
+	for _, u := range urls {
+		entry := sitemapURLEntry{Loc: u.Loc}
+		if !u.LastMod.IsZero() {
+			entry.LastMod = u.LastMod.UTC().Format(time.RFC3339)
+		}
+		set.URLs = append(set.URLs, entry)
+	}
+
+	body, err := xml.MarshalIndent(set, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
@@ -0,0 +1,109 @@
+package utils
+
+import (
+	"event-horizon/reporting"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+/** *********************  SMS   ********************
+
+SMS is an optional channel next to email and in-app notifications. Like the
+Mailer, an SMSSender has a real implementation and a logging one:
+
+- TwilioSender sends through Twilio's REST API (TWILIO_ACCOUNT_SID, TWILIO_AUTH_TOKEN, TWILIO_FROM)
+- LogSMSSender only writes the message to the log, which is enough for local development
+
+Phone numbers are E.164 ("+14155550123"), users verify them with a code sent
+by SMS before anything else is sent to them.
+
+ **************************************/
+
+// SMSSender sends text messages
+type SMSSender interface {
+	SendSMS(to, body string) error
+}
+
+// e164 is a phone number in E.164 format: +, country code, up to 15 digits
+var e164 = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+
+// NormalizePhone removes spaces, dashes, dots and brackets and checks the number is in E.164 format
+func NormalizePhone(phone string) (string, bool) {
+	phone = strings.NewReplacer(" ", "", "-", "", ".", "", "(", "", ")", "").Replace(strings.TrimSpace(phone))
+	return phone, e164.MatchString(phone)
+}
+
+// TwilioSender sends text messages through Twilio
+type TwilioSender struct {
+	accountSID string
+	authToken  string
+	from       string
+	baseURL    string
+	client     *http.Client
+}
+
+// NewTwilioSender creates a sender for the Twilio account, from is the Twilio number (or messaging service SID) messages come from
+func NewTwilioSender(accountSID, authToken, from string) *TwilioSender {
+	return &TwilioSender{
+		accountSID: accountSID,
+		authToken:  authToken,
+		from:       from,
+		baseURL:    "https://api.twilio.com/2010-04-01",
+		client:     &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// SendSMS sends one text message
+func (s *TwilioSender) SendSMS(to, body string) error {
+	form := url.Values{"To": {to}, "Body": {body}}
+	if strings.HasPrefix(s.from, "MG") {
+		form.Set("MessagingServiceSid", s.from) //? Twilio picks the number of the messaging service
+	} else {
+		form.Set("From", s.from)
+	}
+
+	endpoint := s.baseURL + "/Accounts/" + url.PathEscape(s.accountSID) + "/Messages.json"
+	req, err := http.NewRequest(http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(s.accountSID, s.authToken)
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+		return fmt.Errorf("twilio: %s: %s", res.Status, message)
+	}
+	return nil
+}
+
+// LogSMSSender writes text messages to the log instead of sending them
+type LogSMSSender struct{}
+
+// SendSMS logs the message
+func (LogSMSSender) SendSMS(to, body string) error {
+	log.Printf("SMS (not sent, Twilio is not configured) to %s: %s", to, body)
+	return nil
+}
+
+// SendSMSInBackground sends a text message without blocking, failures are only logged
+func SendSMSInBackground(sender SMSSender, to, body string) {
+	go func() {
+		defer reporting.Recover("send sms")
+		if err := sender.SendSMS(to, body); err != nil {
+			log.Printf("Error sending SMS to %s: %v", to, err)
+		}
+	}()
+}
@@ -0,0 +1,28 @@
+package utils
+
+import "strings"
+
+// NormalizeTags lowercases and trims tags, dropping empty and duplicate ones
+func NormalizeTags(tags []string) []string {
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+
+	return normalized
+}
+
+// ParseTagsQuery splits a comma separated ?tags= query into normalized tags
+func ParseTagsQuery(query string) []string {
+	if query == "" {
+		return nil
+	}
+	return NormalizeTags(strings.Split(query, ","))
+}
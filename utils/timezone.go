@@ -0,0 +1,119 @@
+package utils
+
+import (
+	"errors"
+	"event-horizon/models"
+	"time"
+)
+
+/** *********************  EVENT TIME ZONES   ********************
+
+Events carry an IANA time zone name (e.g. "Asia/Dhaka"). Times are always
+stored in UTC and only converted into the event's zone when building API
+responses, so comparisons in the store and the cleanup scheduler stay correct
+regardless of where the server runs.
+
+
+ **************************************/
+
+// LoadEventLocation returns the time zone for an event, defaulting to UTC when none is set
+func LoadEventLocation(timezone string) (*time.Location, error) {
+	if timezone == "" {
+		return time.UTC, nil
+	}
+
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, errors.New("invalid timezone: " + timezone)
+	}
+
+	return loc, nil
+}
+
+// NormalizeEventTimes validates the event time zone and converts all event times to UTC for storage
+func NormalizeEventTimes(event *models.Event) error {
+	if _, err := LoadEventLocation(event.Timezone); err != nil {
+		return err
+	}
+
+	if event.Timezone == "" {
+		event.Timezone = "UTC"
+	}
+
+	event.Date = event.Date.UTC()
+	event.StartTime = event.StartTime.UTC()
+	event.EndTime = event.EndTime.UTC()
+
+	for i := range event.Sessions {
+		event.Sessions[i].StartTime = event.Sessions[i].StartTime.UTC()
+		event.Sessions[i].EndTime = event.Sessions[i].EndTime.UTC()
+	}
+
+	for i := range event.Tickets {
+		event.Tickets[i].SaleStart = inZone(event.Tickets[i].SaleStart, time.UTC)
+		event.Tickets[i].SaleEnd = inZone(event.Tickets[i].SaleEnd, time.UTC)
+	}
+
+	return nil
+}
+
+// inZone returns a new pointer to the time in loc, the original may be shared with the event cache
+func inZone(t *time.Time, loc *time.Location) *time.Time {
+	if t == nil {
+		return nil
+	}
+	converted := t.In(loc)
+	return &converted
+}
+
+// LocalizeEventTimes converts the event times into the event's own time zone for responses
+func LocalizeEventTimes(event *models.Event) {
+	loc, err := LoadEventLocation(event.Timezone)
+	if err != nil {
+		return //! Leave times in UTC if the stored zone is unknown
+	}
+
+	event.Date = event.Date.In(loc)
+	event.StartTime = event.StartTime.In(loc)
+	event.EndTime = event.EndTime.In(loc)
+
+	for i := range event.Sessions {
+		event.Sessions[i].StartTime = event.Sessions[i].StartTime.In(loc)
+		event.Sessions[i].EndTime = event.Sessions[i].EndTime.In(loc)
+	}
+
+	for i := range event.Tickets {
+		event.Tickets[i].SaleStart = inZone(event.Tickets[i].SaleStart, loc)
+		event.Tickets[i].SaleEnd = inZone(event.Tickets[i].SaleEnd, loc)
+	}
+}
+
+// LocalizeSummaryTimes converts the times of an event summary into the event's own time zone
+func LocalizeSummaryTimes(summary *models.EventSummary) {
+	loc, err := LoadEventLocation(summary.Timezone)
+	if err != nil {
+		return
+	}
+
+	for _, t := range []*time.Time{summary.Date, summary.StartTime, summary.EndTime} {
+		if t != nil {
+			*t = t.In(loc)
+		}
+	}
+}
+
+// IsEventDateInPast reports whether the event's calendar date is before today in the event's time zone
+func IsEventDateInPast(event *models.Event) bool {
+	loc, err := LoadEventLocation(event.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	now := time.Now().In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+
+	eventDate := event.Date.In(loc)
+	eventDay := time.Date(eventDate.Year(), eventDate.Month(), eventDate.Day(), 0, 0, 0, 0, loc)
+
+	return eventDay.Before(today)
+}